@@ -18,6 +18,10 @@ type Cache interface {
 	MSet(ctx context.Context, pairs map[string]interface{}, expiration time.Duration) error
 	MDelete(ctx context.Context, keys ...string) error
 
+	// 键扫描：pattern 为 Redis glob 模式（例如 user:*:profile），Redis 驱动基于 SCAN 遍历，不会阻塞服务端
+	Keys(ctx context.Context, pattern string) ([]string, error)
+	DeleteByPattern(ctx context.Context, pattern string) (int64, error)
+
 	// 高级操作
 	Increment(ctx context.Context, key string, delta int64) (int64, error)
 	Decrement(ctx context.Context, key string, delta int64) (int64, error)
@@ -43,6 +47,20 @@ type Cache interface {
 	SMembers(ctx context.Context, key string) ([]string, error)
 	SIsMember(ctx context.Context, key string, member interface{}) (bool, error)
 
+	// 加载操作：未命中时调用 loader 加载并写入缓存，并发加载同一键时只执行一次
+	GetOrSet(ctx context.Context, key string, expiration time.Duration, loader LoaderFunc) (string, error)
+
+	// 管道与事务：命令先入队，Exec 时一次性提交；TxPipeline 以事务方式原子执行
+	Pipeline() Pipeline
+	TxPipeline() Pipeline
+
+	// 发布订阅：Redis 驱动基于 Redis Pub/Sub，内存驱动仅在进程内广播
+	Publish(ctx context.Context, channel string, message interface{}) error
+	Subscribe(ctx context.Context, channels ...string) (*Subscription, error)
+
+	// 统计信息：命中率、键数量、内存占用与淘汰次数
+	Stats(ctx context.Context) (*Stats, error)
+
 	// 健康检查
 	HealthCheck(ctx context.Context) error
 
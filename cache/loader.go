@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// ErrKeyNotFound 键不存在
+var ErrKeyNotFound = errors.New("key not found")
+
+// LoaderFunc 缓存未命中时的加载函数
+type LoaderFunc func() (interface{}, error)
+
+// getOrSet GetOrSet 的通用实现
+// - 命中直接返回；未命中时同一键的并发加载经 singleflight 合并为一次，防止缓存击穿
+// - format 将加载结果转换为与 Get 一致的字符串形式
+func getOrSet(ctx context.Context, c Cache, group *singleflight.Group, key string, expiration time.Duration, loader LoaderFunc, format func(interface{}) (string, error)) (string, error) {
+	value, err := c.Get(ctx, key)
+	if err == nil {
+		return value, nil
+	}
+	if !errors.Is(err, ErrKeyNotFound) {
+		return "", err
+	}
+
+	result, err, _ := group.Do(key, func() (interface{}, error) {
+		// 二次检查：等待期间其他调用可能已完成加载
+		if value, err := c.Get(ctx, key); err == nil {
+			return value, nil
+		}
+
+		loaded, err := loader()
+		if err != nil {
+			return "", err
+		}
+		if err := c.Set(ctx, key, loaded, expiration); err != nil {
+			return "", err
+		}
+		return format(loaded)
+	})
+	if err != nil {
+		return "", err
+	}
+	return result.(string), nil
+}
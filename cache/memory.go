@@ -1,35 +1,90 @@
 package cache
 
 import (
+	"container/list"
 	"context"
 	"fmt"
+	"hash/fnv"
 	"log/slog"
+	"math"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/so68/core/config"
+	"golang.org/x/sync/singleflight"
 )
 
+// itemOverhead 每个缓存项的固定开销估算（字节），包含 map 槽位、链表节点与过期时间
+const itemOverhead = 48
+
 // MemoryCache 内存缓存实现
+// - 键按哈希分布到多个分片，每个分片独立加锁，降低高并发下的锁竞争
+// - 按键与值估算内存占用，超过 MaxMemory 时按 LRU 淘汰最久未访问的键（跨分片比较访问顺序）
+// - 过期键在访问时或定期清理时删除
 type MemoryCache struct {
-	data   map[string]*cacheItem
-	mutex  sync.RWMutex
+	shards []*memoryShard
 	config *config.CacheConfig
 	logger *slog.Logger
+	loads  singleflight.Group
+	hub    *memoryHub
+
+	clock       atomic.Uint64 // 访问序号，用于跨分片比较访问先后
+	items       atomic.Int64  // 当前缓存项数量
+	size        atomic.Int64  // 当前估算占用（字节）
+	evictions   atomic.Uint64 // 因超出内存上限淘汰的键数
+	expirations atomic.Uint64 // 因过期删除的键数
+	hits        atomic.Uint64 // 读取命中次数
+	misses      atomic.Uint64 // 读取未命中次数
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// memoryShard 缓存分片
+type memoryShard struct {
+	cache *MemoryCache
+	data  map[string]*cacheItem
+	lru   *list.List // 访问顺序，队首为最近访问
+	mutex sync.Mutex
 }
 
 // cacheItem 缓存项
 type cacheItem struct {
+	key        string
 	value      interface{}
 	expiration time.Time
+	size       int64
+	accessed   uint64
+	element    *list.Element
+}
+
+// expired 是否已过期
+func (i *cacheItem) expired(now time.Time) bool {
+	return !i.expiration.IsZero() && now.After(i.expiration)
 }
 
 // NewMemoryCache 创建内存缓存实例
 func NewMemoryCache(cfg *config.CacheConfig, logger *slog.Logger) (*MemoryCache, error) {
+	shardCount := cfg.Shards
+	if shardCount <= 0 {
+		shardCount = 1
+	}
+
 	cache := &MemoryCache{
-		data:   make(map[string]*cacheItem),
+		shards: make([]*memoryShard, shardCount),
 		config: cfg,
 		logger: logger,
+		hub:    newMemoryHub(),
+		done:   make(chan struct{}),
+	}
+	for i := range cache.shards {
+		cache.shards[i] = &memoryShard{
+			cache: cache,
+			data:  make(map[string]*cacheItem),
+			lru:   list.New(),
+		}
 	}
 
 	// 启动清理协程
@@ -37,121 +92,447 @@ func NewMemoryCache(cfg *config.CacheConfig, logger *slog.Logger) (*MemoryCache,
 
 	logger.Info("Memory cache connected successfully",
 		slog.Int("max_memory", int(cfg.MaxMemory)),
+		slog.Int("shards", shardCount),
 		slog.Duration("cleanup_interval", cfg.CleanupInterval),
 	)
 
 	return cache, nil
 }
 
-// cleanup 定期清理过期项
+// cleanup 定期清理过期项（逐个分片加锁）
 func (m *MemoryCache) cleanup() {
 	ticker := time.NewTicker(m.config.CleanupInterval)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		m.mutex.Lock()
-		now := time.Now()
-		for key, item := range m.data {
-			if !item.expiration.IsZero() && now.After(item.expiration) {
-				delete(m.data, key)
+	for {
+		select {
+		case <-m.done:
+			return
+		case <-ticker.C:
+			for _, s := range m.shards {
+				s.mutex.Lock()
+				now := time.Now()
+				for _, item := range s.data {
+					if item.expired(now) {
+						s.removeItem(item)
+						m.expirations.Add(1)
+					}
+				}
+				s.mutex.Unlock()
 			}
 		}
-		m.mutex.Unlock()
 	}
 }
 
+// shard 获取键所在分片
+func (m *MemoryCache) shard(key string) *memoryShard {
+	return m.shards[m.shardIndex(key)]
+}
+
+// shardIndex 计算键所在分片下标
+func (m *MemoryCache) shardIndex(key string) int {
+	if len(m.shards) == 1 {
+		return 0
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % uint32(len(m.shards)))
+}
+
 // serialize 序列化值
 func (m *MemoryCache) serialize(value interface{}) interface{} {
 	return value
 }
 
-// Get 获取值
-func (m *MemoryCache) Get(ctx context.Context, key string) (string, error) {
-	m.mutex.RLock()
-	defer m.mutex.RUnlock()
+// Len 获取缓存项数量（包含尚未清理的过期项）
+func (m *MemoryCache) Len() int {
+	return int(m.items.Load())
+}
+
+// Stats 获取缓存统计
+func (m *MemoryCache) Stats(ctx context.Context) (*Stats, error) {
+	hits, misses := m.hits.Load(), m.misses.Load()
+	return &Stats{
+		Driver:      "memory",
+		Hits:        hits,
+		Misses:      misses,
+		HitRate:     hitRate(hits, misses),
+		Keys:        m.items.Load(),
+		Memory:      m.size.Load(),
+		MaxMemory:   m.config.MaxMemory,
+		Evictions:   m.evictions.Load(),
+		Expirations: m.expirations.Load(),
+		Shards:      len(m.shards),
+	}, nil
+}
+
+// evict 超出内存上限时淘汰最久未访问的键（保留最后一个键）
+// - 在不持有分片锁时调用，逐个分片比较队尾访问序号，淘汰全局最久未访问的键
+func (m *MemoryCache) evict() {
+	if m.config.MaxMemory <= 0 {
+		return
+	}
+	for m.size.Load() > m.config.MaxMemory && m.items.Load() > 1 {
+		var victim *memoryShard
+		oldest := uint64(math.MaxUint64)
+		for _, s := range m.shards {
+			s.mutex.Lock()
+			if back := s.lru.Back(); back != nil {
+				if accessed := back.Value.(*cacheItem).accessed; accessed < oldest {
+					oldest = accessed
+					victim = s
+				}
+			}
+			s.mutex.Unlock()
+		}
+		if victim == nil {
+			return
+		}
 
-	item, exists := m.data[key]
+		victim.mutex.Lock()
+		if back := victim.lru.Back(); back != nil {
+			victim.removeItem(back.Value.(*cacheItem))
+			m.evictions.Add(1)
+		}
+		victim.mutex.Unlock()
+	}
+}
+
+// getItem 获取未过期的缓存项并标记为最近访问（调用方需持有分片锁）
+func (s *memoryShard) getItem(key string) (*cacheItem, bool) {
+	item, exists := s.data[key]
 	if !exists {
-		return "", fmt.Errorf("key not found: %s", key)
+		return nil, false
+	}
+	if item.expired(time.Now()) {
+		s.removeItem(item)
+		s.cache.expirations.Add(1)
+		return nil, false
+	}
+	item.accessed = s.cache.clock.Add(1)
+	s.lru.MoveToFront(item.element)
+	return item, true
+}
+
+// lookupItem 读取缓存项并计入命中统计（调用方需持有分片锁）
+func (s *memoryShard) lookupItem(key string) (*cacheItem, bool) {
+	item, exists := s.getItem(key)
+	if exists {
+		s.cache.hits.Add(1)
+	} else {
+		s.cache.misses.Add(1)
+	}
+	return item, exists
+}
+
+// setItem 写入缓存项，替换同名键（调用方需持有分片锁）
+func (s *memoryShard) setItem(key string, value interface{}, expiration time.Time) *cacheItem {
+	if old, exists := s.data[key]; exists {
+		s.removeItem(old)
+	}
+
+	item := &cacheItem{key: key, value: value, expiration: expiration}
+	item.size = estimateItemSize(key, value)
+	item.accessed = s.cache.clock.Add(1)
+	item.element = s.lru.PushFront(item)
+	s.data[key] = item
+	s.cache.items.Add(1)
+	s.cache.size.Add(item.size)
+	return item
+}
+
+// updateItem 缓存项内容变更后重新计算占用（调用方需持有分片锁）
+func (s *memoryShard) updateItem(item *cacheItem) {
+	size := estimateItemSize(item.key, item.value)
+	s.cache.size.Add(size - item.size)
+	item.size = size
+}
+
+// removeItem 删除缓存项（调用方需持有分片锁）
+func (s *memoryShard) removeItem(item *cacheItem) {
+	s.lru.Remove(item.element)
+	delete(s.data, item.key)
+	s.cache.items.Add(-1)
+	s.cache.size.Add(-item.size)
+}
+
+// set 写入值（调用方需持有分片锁）
+func (s *memoryShard) set(key string, value interface{}, expiration time.Duration) {
+	s.setItem(key, s.cache.serialize(value), expirationAt(expiration))
+}
+
+// delete 删除键（调用方需持有分片锁）
+func (s *memoryShard) delete(key string) {
+	if item, exists := s.data[key]; exists {
+		s.removeItem(item)
 	}
+}
 
-	if !item.expiration.IsZero() && time.Now().After(item.expiration) {
-		delete(m.data, key)
-		return "", fmt.Errorf("key not found: %s", key)
+// increment 递增数值（调用方需持有分片锁）
+func (s *memoryShard) increment(key string, delta int64) (int64, error) {
+	item, exists := s.getItem(key)
+	if !exists {
+		s.setItem(key, delta, time.Time{})
+		return delta, nil
 	}
 
+	var current int64
 	switch v := item.value.(type) {
-	case string:
-		return v, nil
+	case int:
+		current = int64(v)
+	case int64:
+		current = v
+	case float64:
+		current = int64(v)
 	default:
-		return fmt.Sprintf("%v", v), nil
+		return 0, fmt.Errorf("cannot increment non-numeric value")
 	}
+
+	newValue := current + delta
+	item.value = newValue
+	s.updateItem(item)
+	return newValue, nil
 }
 
-// Set 设置值
-func (m *MemoryCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
+// expire 设置过期时间（调用方需持有分片锁）
+func (s *memoryShard) expire(key string, expiration time.Duration) error {
+	item, exists := s.getItem(key)
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+	}
+
+	item.expiration = time.Now().Add(expiration)
+	return nil
+}
+
+// hset 设置哈希字段（调用方需持有分片锁）
+func (s *memoryShard) hset(key string, pairs map[string]interface{}) {
+	item, exists := s.getItem(key)
+	if !exists {
+		item = s.setItem(key, make(map[string]interface{}), time.Time{})
+	}
+
+	hashMap, ok := item.value.(map[string]interface{})
+	if !ok {
+		hashMap = make(map[string]interface{})
+		item.value = hashMap
+	}
+
+	for field, value := range pairs {
+		hashMap[field] = s.cache.serialize(value)
+	}
+
+	s.updateItem(item)
+}
+
+// hdelete 删除哈希字段（调用方需持有分片锁）
+func (s *memoryShard) hdelete(key string, fields ...string) error {
+	item, exists := s.getItem(key)
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+	}
+
+	hashMap, ok := item.value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("key is not a hash: %s", key)
+	}
+
+	for _, field := range fields {
+		delete(hashMap, field)
+	}
+
+	s.updateItem(item)
+	return nil
+}
+
+// lpush 左推入列表（调用方需持有分片锁）
+func (s *memoryShard) lpush(key string, values ...interface{}) {
+	item, list := s.listItem(key)
+
+	for _, value := range values {
+		list = append([]interface{}{s.cache.serialize(value)}, list...)
+	}
+
+	item.value = list
+	s.updateItem(item)
+}
 
-	item := &cacheItem{
-		value: m.serialize(value),
+// rpush 右推入列表（调用方需持有分片锁）
+func (s *memoryShard) rpush(key string, values ...interface{}) {
+	item, list := s.listItem(key)
+
+	for _, value := range values {
+		list = append(list, s.cache.serialize(value))
 	}
 
+	item.value = list
+	s.updateItem(item)
+}
+
+// sadd 添加集合成员（调用方需持有分片锁）
+func (s *memoryShard) sadd(key string, members ...interface{}) {
+	item, exists := s.getItem(key)
+	if !exists {
+		item = s.setItem(key, make(map[interface{}]bool), time.Time{})
+	}
+
+	set, ok := item.value.(map[interface{}]bool)
+	if !ok {
+		set = make(map[interface{}]bool)
+		item.value = set
+	}
+
+	for _, member := range members {
+		set[s.cache.serialize(member)] = true
+	}
+
+	s.updateItem(item)
+}
+
+// srem 删除集合成员（调用方需持有分片锁）
+func (s *memoryShard) srem(key string, members ...interface{}) error {
+	item, exists := s.getItem(key)
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+	}
+
+	set, ok := item.value.(map[interface{}]bool)
+	if !ok {
+		return fmt.Errorf("key is not a set: %s", key)
+	}
+
+	for _, member := range members {
+		delete(set, s.cache.serialize(member))
+	}
+
+	s.updateItem(item)
+	return nil
+}
+
+// expirationAt 计算过期时间
+func expirationAt(expiration time.Duration) time.Time {
 	if expiration > 0 {
-		item.expiration = time.Now().Add(expiration)
+		return time.Now().Add(expiration)
+	}
+	return time.Time{}
+}
+
+// estimateItemSize 估算缓存项占用（字节）
+func estimateItemSize(key string, value interface{}) int64 {
+	return int64(len(key)) + estimateValueSize(value) + itemOverhead
+}
+
+// estimateValueSize 估算值占用（字节）
+func estimateValueSize(value interface{}) int64 {
+	switch v := value.(type) {
+	case nil:
+		return 0
+	case string:
+		return int64(len(v))
+	case []byte:
+		return int64(len(v))
+	case bool, int8, uint8:
+		return 1
+	case int16, uint16:
+		return 2
+	case int32, uint32, float32:
+		return 4
+	case int, int64, uint, uint64, float64, time.Duration:
+		return 8
+	case map[string]interface{}:
+		var size int64
+		for field, fieldValue := range v {
+			size += int64(len(field)) + estimateValueSize(fieldValue) + 16
+		}
+		return size
+	case []interface{}:
+		var size int64
+		for _, element := range v {
+			size += estimateValueSize(element) + 16
+		}
+		return size
+	case map[interface{}]bool:
+		var size int64
+		for member := range v {
+			size += estimateValueSize(member) + 16
+		}
+		return size
+	default:
+		return int64(len(fmt.Sprintf("%v", v)))
+	}
+}
+
+// stringify 转换为字符串
+func stringify(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	default:
+		return fmt.Sprintf("%v", v)
 	}
+}
+
+// Get 获取值
+func (m *MemoryCache) Get(ctx context.Context, key string) (string, error) {
+	s := m.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	item, exists := s.lookupItem(key)
+	if !exists {
+		return "", fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+	}
+	return stringify(item.value), nil
+}
 
-	m.data[key] = item
+// GetOrSet 获取值，不存在时调用 loader 加载并写入缓存（并发加载合并为一次）
+func (m *MemoryCache) GetOrSet(ctx context.Context, key string, expiration time.Duration, loader LoaderFunc) (string, error) {
+	return getOrSet(ctx, m, &m.loads, key, expiration, loader, func(value interface{}) (string, error) {
+		return stringify(value), nil
+	})
+}
+
+// Set 设置值
+func (m *MemoryCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	defer m.evict() // 先于解锁注册，释放分片锁后再执行淘汰
+	s := m.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.set(key, value, expiration)
 	return nil
 }
 
 // Delete 删除键
 func (m *MemoryCache) Delete(ctx context.Context, key string) error {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
+	s := m.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
-	delete(m.data, key)
+	s.delete(key)
 	return nil
 }
 
 // Exists 检查键是否存在
 func (m *MemoryCache) Exists(ctx context.Context, key string) (bool, error) {
-	m.mutex.RLock()
-	defer m.mutex.RUnlock()
-
-	item, exists := m.data[key]
-	if !exists {
-		return false, nil
-	}
-
-	if !item.expiration.IsZero() && time.Now().After(item.expiration) {
-		delete(m.data, key)
-		return false, nil
-	}
+	s := m.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
-	return true, nil
+	_, exists := s.lookupItem(key)
+	return exists, nil
 }
 
 // MGet 批量获取
 func (m *MemoryCache) MGet(ctx context.Context, keys ...string) ([]interface{}, error) {
-	m.mutex.RLock()
-	defer m.mutex.RUnlock()
-
 	values := make([]interface{}, len(keys))
 	for i, key := range keys {
-		item, exists := m.data[key]
-		if !exists {
-			values[i] = nil
-			continue
+		s := m.shard(key)
+		s.mutex.Lock()
+		if item, exists := s.lookupItem(key); exists {
+			values[i] = item.value
 		}
-
-		if !item.expiration.IsZero() && time.Now().After(item.expiration) {
-			delete(m.data, key)
-			values[i] = nil
-			continue
-		}
-
-		values[i] = item.value
+		s.mutex.Unlock()
 	}
 
 	return values, nil
@@ -159,20 +540,14 @@ func (m *MemoryCache) MGet(ctx context.Context, keys ...string) ([]interface{},
 
 // MSet 批量设置
 func (m *MemoryCache) MSet(ctx context.Context, pairs map[string]interface{}, expiration time.Duration) error {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
+	defer m.evict()
 
-	now := time.Now()
+	expireAt := expirationAt(expiration)
 	for key, value := range pairs {
-		item := &cacheItem{
-			value: m.serialize(value),
-		}
-
-		if expiration > 0 {
-			item.expiration = now.Add(expiration)
-		}
-
-		m.data[key] = item
+		s := m.shard(key)
+		s.mutex.Lock()
+		s.setItem(key, m.serialize(value), expireAt)
+		s.mutex.Unlock()
 	}
 
 	return nil
@@ -180,48 +555,60 @@ func (m *MemoryCache) MSet(ctx context.Context, pairs map[string]interface{}, ex
 
 // MDelete 批量删除
 func (m *MemoryCache) MDelete(ctx context.Context, keys ...string) error {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
-
 	for _, key := range keys {
-		delete(m.data, key)
+		s := m.shard(key)
+		s.mutex.Lock()
+		s.delete(key)
+		s.mutex.Unlock()
 	}
 
 	return nil
 }
 
-// Increment 递增
-func (m *MemoryCache) Increment(ctx context.Context, key string, delta int64) (int64, error) {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
-
-	item, exists := m.data[key]
-	if !exists {
-		m.data[key] = &cacheItem{value: delta}
-		return delta, nil
+// Keys 获取匹配模式的键（不包含已过期的键）
+func (m *MemoryCache) Keys(ctx context.Context, pattern string) ([]string, error) {
+	keys := make([]string, 0)
+	now := time.Now()
+	for _, s := range m.shards {
+		s.mutex.Lock()
+		for key, item := range s.data {
+			if !item.expired(now) && matchPattern(pattern, key) {
+				keys = append(keys, key)
+			}
+		}
+		s.mutex.Unlock()
 	}
+	return keys, nil
+}
 
-	if !item.expiration.IsZero() && time.Now().After(item.expiration) {
-		delete(m.data, key)
-		m.data[key] = &cacheItem{value: delta}
-		return delta, nil
+// DeleteByPattern 删除匹配模式的键，返回删除数量
+func (m *MemoryCache) DeleteByPattern(ctx context.Context, pattern string) (int64, error) {
+	var deleted int64
+	now := time.Now()
+	for _, s := range m.shards {
+		s.mutex.Lock()
+		for key, item := range s.data {
+			if !matchPattern(pattern, key) {
+				continue
+			}
+			if !item.expired(now) {
+				deleted++
+			}
+			s.removeItem(item)
+		}
+		s.mutex.Unlock()
 	}
+	return deleted, nil
+}
 
-	var current int64
-	switch v := item.value.(type) {
-	case int:
-		current = int64(v)
-	case int64:
-		current = v
-	case float64:
-		current = int64(v)
-	default:
-		return 0, fmt.Errorf("cannot increment non-numeric value")
-	}
+// Increment 递增
+func (m *MemoryCache) Increment(ctx context.Context, key string, delta int64) (int64, error) {
+	defer m.evict()
+	s := m.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
-	newValue := current + delta
-	m.data[key] = &cacheItem{value: newValue}
-	return newValue, nil
+	return s.increment(key, delta)
 }
 
 // Decrement 递减
@@ -231,58 +618,40 @@ func (m *MemoryCache) Decrement(ctx context.Context, key string, delta int64) (i
 
 // Expire 设置过期时间
 func (m *MemoryCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
-
-	item, exists := m.data[key]
-	if !exists {
-		return fmt.Errorf("key not found: %s", key)
-	}
-
-	if !item.expiration.IsZero() && time.Now().After(item.expiration) {
-		delete(m.data, key)
-		return fmt.Errorf("key not found: %s", key)
-	}
+	s := m.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
-	item.expiration = time.Now().Add(expiration)
-	return nil
+	return s.expire(key, expiration)
 }
 
 // TTL 获取剩余生存时间
 func (m *MemoryCache) TTL(ctx context.Context, key string) (time.Duration, error) {
-	m.mutex.RLock()
-	defer m.mutex.RUnlock()
+	s := m.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
-	item, exists := m.data[key]
+	item, exists := s.lookupItem(key)
 	if !exists {
-		return 0, fmt.Errorf("key not found: %s", key)
+		return 0, fmt.Errorf("%w: %s", ErrKeyNotFound, key)
 	}
 
 	if item.expiration.IsZero() {
 		return -1, nil // 永不过期
 	}
 
-	if time.Now().After(item.expiration) {
-		delete(m.data, key)
-		return 0, fmt.Errorf("key not found: %s", key)
-	}
-
 	return time.Until(item.expiration), nil
 }
 
 // HGet 获取哈希字段值
 func (m *MemoryCache) HGet(ctx context.Context, key, field string) (string, error) {
-	m.mutex.RLock()
-	defer m.mutex.RUnlock()
+	s := m.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
-	item, exists := m.data[key]
+	item, exists := s.lookupItem(key)
 	if !exists {
-		return "", fmt.Errorf("key not found: %s", key)
-	}
-
-	if !item.expiration.IsZero() && time.Now().After(item.expiration) {
-		delete(m.data, key)
-		return "", fmt.Errorf("key not found: %s", key)
+		return "", fmt.Errorf("%w: %s", ErrKeyNotFound, key)
 	}
 
 	hashMap, ok := item.value.(map[string]interface{})
@@ -295,57 +664,29 @@ func (m *MemoryCache) HGet(ctx context.Context, key, field string) (string, erro
 		return "", fmt.Errorf("field not found: %s.%s", key, field)
 	}
 
-	switch v := value.(type) {
-	case string:
-		return v, nil
-	default:
-		return fmt.Sprintf("%v", v), nil
-	}
+	return stringify(value), nil
 }
 
 // HSet 设置哈希字段
 func (m *MemoryCache) HSet(ctx context.Context, key string, pairs map[string]interface{}) error {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
-
-	item, exists := m.data[key]
-	if !exists {
-		item = &cacheItem{value: make(map[string]interface{})}
-		m.data[key] = item
-	}
-
-	if !item.expiration.IsZero() && time.Now().After(item.expiration) {
-		delete(m.data, key)
-		item = &cacheItem{value: make(map[string]interface{})}
-		m.data[key] = item
-	}
-
-	hashMap, ok := item.value.(map[string]interface{})
-	if !ok {
-		hashMap = make(map[string]interface{})
-		item.value = hashMap
-	}
-
-	for field, value := range pairs {
-		hashMap[field] = m.serialize(value)
-	}
+	defer m.evict()
+	s := m.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
+	s.hset(key, pairs)
 	return nil
 }
 
 // HGetAll 获取所有哈希字段
 func (m *MemoryCache) HGetAll(ctx context.Context, key string) (map[string]string, error) {
-	m.mutex.RLock()
-	defer m.mutex.RUnlock()
+	s := m.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
-	item, exists := m.data[key]
+	item, exists := s.lookupItem(key)
 	if !exists {
-		return nil, fmt.Errorf("key not found: %s", key)
-	}
-
-	if !item.expiration.IsZero() && time.Now().After(item.expiration) {
-		delete(m.data, key)
-		return nil, fmt.Errorf("key not found: %s", key)
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, key)
 	}
 
 	hashMap, ok := item.value.(map[string]interface{})
@@ -355,12 +696,7 @@ func (m *MemoryCache) HGetAll(ctx context.Context, key string) (map[string]strin
 
 	result := make(map[string]string)
 	for field, value := range hashMap {
-		switch v := value.(type) {
-		case string:
-			result[field] = v
-		default:
-			result[field] = fmt.Sprintf("%v", v)
-		}
+		result[field] = stringify(value)
 	}
 
 	return result, nil
@@ -368,46 +704,18 @@ func (m *MemoryCache) HGetAll(ctx context.Context, key string) (map[string]strin
 
 // HDelete 删除哈希字段
 func (m *MemoryCache) HDelete(ctx context.Context, key string, fields ...string) error {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
-
-	item, exists := m.data[key]
-	if !exists {
-		return fmt.Errorf("key not found: %s", key)
-	}
+	s := m.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
-	if !item.expiration.IsZero() && time.Now().After(item.expiration) {
-		delete(m.data, key)
-		return fmt.Errorf("key not found: %s", key)
-	}
-
-	hashMap, ok := item.value.(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("key is not a hash: %s", key)
-	}
-
-	for _, field := range fields {
-		delete(hashMap, field)
-	}
-
-	return nil
+	return s.hdelete(key, fields...)
 }
 
-// LPush 左推入列表
-func (m *MemoryCache) LPush(ctx context.Context, key string, values ...interface{}) error {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
-
-	item, exists := m.data[key]
+// listItem 获取或创建列表缓存项（调用方需持有分片锁）
+func (s *memoryShard) listItem(key string) (*cacheItem, []interface{}) {
+	item, exists := s.getItem(key)
 	if !exists {
-		item = &cacheItem{value: make([]interface{}, 0)}
-		m.data[key] = item
-	}
-
-	if !item.expiration.IsZero() && time.Now().After(item.expiration) {
-		delete(m.data, key)
-		item = &cacheItem{value: make([]interface{}, 0)}
-		m.data[key] = item
+		item = s.setItem(key, make([]interface{}, 0), time.Time{})
 	}
 
 	list, ok := item.value.([]interface{})
@@ -415,61 +723,40 @@ func (m *MemoryCache) LPush(ctx context.Context, key string, values ...interface
 		list = make([]interface{}, 0)
 		item.value = list
 	}
+	return item, list
+}
 
-	// 左推入
-	for _, value := range values {
-		list = append([]interface{}{m.serialize(value)}, list...)
-	}
+// LPush 左推入列表
+func (m *MemoryCache) LPush(ctx context.Context, key string, values ...interface{}) error {
+	defer m.evict()
+	s := m.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
-	item.value = list
+	s.lpush(key, values...)
 	return nil
 }
 
 // RPush 右推入列表
 func (m *MemoryCache) RPush(ctx context.Context, key string, values ...interface{}) error {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
+	defer m.evict()
+	s := m.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
-	item, exists := m.data[key]
-	if !exists {
-		item = &cacheItem{value: make([]interface{}, 0)}
-		m.data[key] = item
-	}
-
-	if !item.expiration.IsZero() && time.Now().After(item.expiration) {
-		delete(m.data, key)
-		item = &cacheItem{value: make([]interface{}, 0)}
-		m.data[key] = item
-	}
-
-	list, ok := item.value.([]interface{})
-	if !ok {
-		list = make([]interface{}, 0)
-		item.value = list
-	}
-
-	// 右推入
-	for _, value := range values {
-		list = append(list, m.serialize(value))
-	}
-
-	item.value = list
+	s.rpush(key, values...)
 	return nil
 }
 
 // LPop 左弹出列表
 func (m *MemoryCache) LPop(ctx context.Context, key string) (string, error) {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
+	s := m.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
-	item, exists := m.data[key]
+	item, exists := s.getItem(key)
 	if !exists {
-		return "", fmt.Errorf("key not found: %s", key)
-	}
-
-	if !item.expiration.IsZero() && time.Now().After(item.expiration) {
-		delete(m.data, key)
-		return "", fmt.Errorf("key not found: %s", key)
+		return "", fmt.Errorf("%w: %s", ErrKeyNotFound, key)
 	}
 
 	list, ok := item.value.([]interface{})
@@ -478,30 +765,21 @@ func (m *MemoryCache) LPop(ctx context.Context, key string) (string, error) {
 	}
 
 	value := list[0]
-	list = list[1:]
-	item.value = list
+	item.value = list[1:]
+	s.updateItem(item)
 
-	switch v := value.(type) {
-	case string:
-		return v, nil
-	default:
-		return fmt.Sprintf("%v", v), nil
-	}
+	return stringify(value), nil
 }
 
 // RPop 右弹出列表
 func (m *MemoryCache) RPop(ctx context.Context, key string) (string, error) {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
+	s := m.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
-	item, exists := m.data[key]
+	item, exists := s.getItem(key)
 	if !exists {
-		return "", fmt.Errorf("key not found: %s", key)
-	}
-
-	if !item.expiration.IsZero() && time.Now().After(item.expiration) {
-		delete(m.data, key)
-		return "", fmt.Errorf("key not found: %s", key)
+		return "", fmt.Errorf("%w: %s", ErrKeyNotFound, key)
 	}
 
 	list, ok := item.value.([]interface{})
@@ -510,30 +788,21 @@ func (m *MemoryCache) RPop(ctx context.Context, key string) (string, error) {
 	}
 
 	value := list[len(list)-1]
-	list = list[:len(list)-1]
-	item.value = list
+	item.value = list[:len(list)-1]
+	s.updateItem(item)
 
-	switch v := value.(type) {
-	case string:
-		return v, nil
-	default:
-		return fmt.Sprintf("%v", v), nil
-	}
+	return stringify(value), nil
 }
 
 // LRange 获取列表范围
 func (m *MemoryCache) LRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
-	m.mutex.RLock()
-	defer m.mutex.RUnlock()
+	s := m.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
-	item, exists := m.data[key]
+	item, exists := s.lookupItem(key)
 	if !exists {
-		return nil, fmt.Errorf("key not found: %s", key)
-	}
-
-	if !item.expiration.IsZero() && time.Now().After(item.expiration) {
-		delete(m.data, key)
-		return nil, fmt.Errorf("key not found: %s", key)
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, key)
 	}
 
 	list, ok := item.value.([]interface{})
@@ -561,12 +830,7 @@ func (m *MemoryCache) LRange(ctx context.Context, key string, start, stop int64)
 
 	result := make([]string, stop-start+1)
 	for i := start; i <= stop; i++ {
-		switch v := list[i].(type) {
-		case string:
-			result[i-start] = v
-		default:
-			result[i-start] = fmt.Sprintf("%v", v)
-		}
+		result[i-start] = stringify(list[i])
 	}
 
 	return result, nil
@@ -574,74 +838,33 @@ func (m *MemoryCache) LRange(ctx context.Context, key string, start, stop int64)
 
 // SAdd 添加集合成员
 func (m *MemoryCache) SAdd(ctx context.Context, key string, members ...interface{}) error {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
-
-	item, exists := m.data[key]
-	if !exists {
-		item = &cacheItem{value: make(map[interface{}]bool)}
-		m.data[key] = item
-	}
-
-	if !item.expiration.IsZero() && time.Now().After(item.expiration) {
-		delete(m.data, key)
-		item = &cacheItem{value: make(map[interface{}]bool)}
-		m.data[key] = item
-	}
-
-	set, ok := item.value.(map[interface{}]bool)
-	if !ok {
-		set = make(map[interface{}]bool)
-		item.value = set
-	}
-
-	for _, member := range members {
-		set[m.serialize(member)] = true
-	}
+	defer m.evict()
+	s := m.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
+	s.sadd(key, members...)
 	return nil
 }
 
 // SRem 删除集合成员
 func (m *MemoryCache) SRem(ctx context.Context, key string, members ...interface{}) error {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
-
-	item, exists := m.data[key]
-	if !exists {
-		return fmt.Errorf("key not found: %s", key)
-	}
-
-	if !item.expiration.IsZero() && time.Now().After(item.expiration) {
-		delete(m.data, key)
-		return fmt.Errorf("key not found: %s", key)
-	}
+	s := m.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
-	set, ok := item.value.(map[interface{}]bool)
-	if !ok {
-		return fmt.Errorf("key is not a set: %s", key)
-	}
-
-	for _, member := range members {
-		delete(set, m.serialize(member))
-	}
-
-	return nil
+	return s.srem(key, members...)
 }
 
 // SMembers 获取集合所有成员
 func (m *MemoryCache) SMembers(ctx context.Context, key string) ([]string, error) {
-	m.mutex.RLock()
-	defer m.mutex.RUnlock()
+	s := m.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
-	item, exists := m.data[key]
+	item, exists := s.lookupItem(key)
 	if !exists {
-		return nil, fmt.Errorf("key not found: %s", key)
-	}
-
-	if !item.expiration.IsZero() && time.Now().After(item.expiration) {
-		delete(m.data, key)
-		return nil, fmt.Errorf("key not found: %s", key)
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, key)
 	}
 
 	set, ok := item.value.(map[interface{}]bool)
@@ -651,12 +874,7 @@ func (m *MemoryCache) SMembers(ctx context.Context, key string) ([]string, error
 
 	result := make([]string, 0, len(set))
 	for member := range set {
-		switch v := member.(type) {
-		case string:
-			result = append(result, v)
-		default:
-			result = append(result, fmt.Sprintf("%v", v))
-		}
+		result = append(result, stringify(member))
 	}
 
 	return result, nil
@@ -664,17 +882,13 @@ func (m *MemoryCache) SMembers(ctx context.Context, key string) ([]string, error
 
 // SIsMember 检查集合成员
 func (m *MemoryCache) SIsMember(ctx context.Context, key string, member interface{}) (bool, error) {
-	m.mutex.RLock()
-	defer m.mutex.RUnlock()
+	s := m.shard(key)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
-	item, exists := m.data[key]
+	item, exists := s.lookupItem(key)
 	if !exists {
-		return false, fmt.Errorf("key not found: %s", key)
-	}
-
-	if !item.expiration.IsZero() && time.Now().After(item.expiration) {
-		delete(m.data, key)
-		return false, fmt.Errorf("key not found: %s", key)
+		return false, fmt.Errorf("%w: %s", ErrKeyNotFound, key)
 	}
 
 	set, ok := item.value.(map[interface{}]bool)
@@ -685,6 +899,185 @@ func (m *MemoryCache) SIsMember(ctx context.Context, key string, member interfac
 	return set[m.serialize(member)], nil
 }
 
+// Pipeline 创建命令管道（逐条加锁执行）
+func (m *MemoryCache) Pipeline() Pipeline {
+	return &memoryPipeline{cache: m}
+}
+
+// TxPipeline 创建事务管道（同时锁定涉及的全部分片后执行，其他操作看不到中间状态）
+func (m *MemoryCache) TxPipeline() Pipeline {
+	return &memoryPipeline{cache: m, tx: true}
+}
+
+// memoryPipeline 内存缓存命令管道
+type memoryPipeline struct {
+	cache *MemoryCache
+	tx    bool
+	ops   []memoryOp
+}
+
+// memoryOp 入队的单键命令
+type memoryOp struct {
+	key   string
+	apply func(s *memoryShard) error
+}
+
+// queue 入队命令
+func (p *memoryPipeline) queue(key string, apply func(s *memoryShard) error) {
+	p.ops = append(p.ops, memoryOp{key: key, apply: apply})
+}
+
+func (p *memoryPipeline) Set(key string, value interface{}, expiration time.Duration) {
+	p.queue(key, func(s *memoryShard) error {
+		s.set(key, value, expiration)
+		return nil
+	})
+}
+
+func (p *memoryPipeline) Delete(keys ...string) {
+	for _, key := range keys {
+		p.queue(key, func(s *memoryShard) error {
+			s.delete(key)
+			return nil
+		})
+	}
+}
+
+func (p *memoryPipeline) Increment(key string, delta int64) *IntResult {
+	result := &IntResult{}
+	p.queue(key, func(s *memoryShard) error {
+		result.val, result.err = s.increment(key, delta)
+		return result.err
+	})
+	return result
+}
+
+func (p *memoryPipeline) Decrement(key string, delta int64) *IntResult {
+	return p.Increment(key, -delta)
+}
+
+func (p *memoryPipeline) Expire(key string, expiration time.Duration) {
+	p.queue(key, func(s *memoryShard) error {
+		return s.expire(key, expiration)
+	})
+}
+
+func (p *memoryPipeline) HSet(key string, pairs map[string]interface{}) {
+	p.queue(key, func(s *memoryShard) error {
+		s.hset(key, pairs)
+		return nil
+	})
+}
+
+func (p *memoryPipeline) HDelete(key string, fields ...string) {
+	p.queue(key, func(s *memoryShard) error {
+		return s.hdelete(key, fields...)
+	})
+}
+
+func (p *memoryPipeline) LPush(key string, values ...interface{}) {
+	p.queue(key, func(s *memoryShard) error {
+		s.lpush(key, values...)
+		return nil
+	})
+}
+
+func (p *memoryPipeline) RPush(key string, values ...interface{}) {
+	p.queue(key, func(s *memoryShard) error {
+		s.rpush(key, values...)
+		return nil
+	})
+}
+
+func (p *memoryPipeline) SAdd(key string, members ...interface{}) {
+	p.queue(key, func(s *memoryShard) error {
+		s.sadd(key, members...)
+		return nil
+	})
+}
+
+func (p *memoryPipeline) SRem(key string, members ...interface{}) {
+	p.queue(key, func(s *memoryShard) error {
+		return s.srem(key, members...)
+	})
+}
+
+// Len 已入队的命令数
+func (p *memoryPipeline) Len() int {
+	return len(p.ops)
+}
+
+// Discard 丢弃已入队的命令
+func (p *memoryPipeline) Discard() {
+	p.ops = nil
+}
+
+// Exec 提交已入队的命令，返回第一个错误（不回滚已执行的命令）
+func (p *memoryPipeline) Exec(ctx context.Context) error {
+	ops := p.ops
+	p.ops = nil
+	if len(ops) == 0 {
+		return nil
+	}
+	defer p.cache.evict()
+
+	if p.tx {
+		// 按分片下标顺序加锁，避免与其他事务交叉加锁导致死锁
+		locked := make(map[int]bool)
+		for _, op := range ops {
+			locked[p.cache.shardIndex(op.key)] = true
+		}
+		indexes := make([]int, 0, len(locked))
+		for i := range locked {
+			indexes = append(indexes, i)
+		}
+		sort.Ints(indexes)
+		for _, i := range indexes {
+			p.cache.shards[i].mutex.Lock()
+		}
+		defer func() {
+			for _, i := range indexes {
+				p.cache.shards[i].mutex.Unlock()
+			}
+		}()
+	}
+
+	var firstErr error
+	for _, op := range ops {
+		s := p.cache.shard(op.key)
+		if !p.tx {
+			s.mutex.Lock()
+		}
+		err := op.apply(s)
+		if !p.tx {
+			s.mutex.Unlock()
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Publish 发布消息（仅投递给当前进程内的订阅）
+func (m *MemoryCache) Publish(ctx context.Context, channel string, message interface{}) error {
+	if _, dropped := m.hub.publish(channel, stringify(message)); dropped > 0 {
+		m.logger.Warn("memory cache subscriber buffer full, message dropped",
+			slog.String("channel", channel),
+			slog.Int("dropped", dropped),
+		)
+	}
+	return nil
+}
+
+// Subscribe 订阅频道
+func (m *MemoryCache) Subscribe(ctx context.Context, channels ...string) (*Subscription, error) {
+	if len(channels) == 0 {
+		return nil, fmt.Errorf("no channels to subscribe")
+	}
+	return m.hub.subscribe(channels...), nil
+}
+
 // HealthCheck 健康检查
 func (m *MemoryCache) HealthCheck(ctx context.Context) error {
 	// 内存缓存总是健康的
@@ -692,8 +1085,11 @@ func (m *MemoryCache) HealthCheck(ctx context.Context) error {
 	return nil
 }
 
-// Close 关闭连接
+// Close 关闭连接（停止清理协程并关闭所有订阅，可重复调用）
 func (m *MemoryCache) Close() error {
-	// 内存缓存不需要关闭连接
+	m.closeOnce.Do(func() {
+		close(m.done)
+		m.hub.close()
+	})
 	return nil
 }
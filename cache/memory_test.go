@@ -2,9 +2,12 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -31,6 +34,13 @@ go test -v -run "^Test.*Memory.*$"
 8. 并发安全测试 (多协程访问等)
 9. 内存管理测试 (内存限制、清理机制等)
 10. 健康检查和错误处理测试
+11. 加载合并测试 (GetOrSet, singleflight)
+12. LRU 淘汰测试 (MaxMemory, Stats)
+13. 分片测试 (Shards, 并发读写)
+14. 发布订阅测试 (Publish, Subscribe)
+15. 管道与事务测试 (Pipeline, TxPipeline)
+16. 键扫描测试 (Keys, DeleteByPattern, glob 匹配)
+17. 统计测试 (Stats 命中率、键数量)
 */
 
 func TestMemoryCache_BasicOperations(t *testing.T) {
@@ -862,3 +872,513 @@ func TestMemoryCache_Close(t *testing.T) {
 		t.Fatalf("Multiple close failed: %v", err)
 	}
 }
+
+func TestMemoryCache_GetOrSet(t *testing.T) {
+	cfg := &config.CacheConfig{
+		Driver:          "memory",
+		MaxMemory:       100 * 1024 * 1024,
+		CleanupInterval: time.Minute,
+	}
+	cfg.SetDefaults()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+	cache, err := NewMemoryCache(cfg, logger)
+	if err != nil {
+		t.Fatalf("Failed to create memory cache: %v", err)
+	}
+	defer cache.Close()
+
+	ctx := context.Background()
+
+	t.Run("Concurrent loads are deduplicated", func(t *testing.T) {
+		var calls int32
+		loader := func() (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			time.Sleep(50 * time.Millisecond)
+			return "loaded", nil
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				value, err := cache.GetOrSet(ctx, "hot_key", time.Minute, loader)
+				if err != nil {
+					t.Errorf("GetOrSet failed: %v", err)
+					return
+				}
+				if value != "loaded" {
+					t.Errorf("Expected loaded, got %s", value)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if calls != 1 {
+			t.Errorf("Expected loader to be called once, got %d", calls)
+		}
+		if value, _ := cache.Get(ctx, "hot_key"); value != "loaded" {
+			t.Errorf("Expected cached value loaded, got %s", value)
+		}
+	})
+
+	t.Run("Hit skips loader", func(t *testing.T) {
+		if err := cache.Set(ctx, "cached_key", 42, time.Minute); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+		value, err := cache.GetOrSet(ctx, "cached_key", time.Minute, func() (interface{}, error) {
+			t.Error("Loader should not be called on hit")
+			return nil, nil
+		})
+		if err != nil || value != "42" {
+			t.Errorf("Expected 42, got %s (%v)", value, err)
+		}
+	})
+
+	t.Run("Loader error is not cached", func(t *testing.T) {
+		loadErr := errors.New("load failed")
+		if _, err := cache.GetOrSet(ctx, "failing_key", time.Minute, func() (interface{}, error) {
+			return nil, loadErr
+		}); !errors.Is(err, loadErr) {
+			t.Errorf("Expected load error, got %v", err)
+		}
+		if _, err := cache.Get(ctx, "failing_key"); !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("Expected ErrKeyNotFound, got %v", err)
+		}
+	})
+}
+
+func TestMemoryCache_LRUEviction(t *testing.T) {
+	cfg := &config.CacheConfig{
+		Driver:          "memory",
+		MaxMemory:       4 * (itemOverhead + 16), // 约可容纳 4 个短键值
+		CleanupInterval: time.Minute,
+	}
+	cfg.SetDefaults()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+	cache, err := NewMemoryCache(cfg, logger)
+	if err != nil {
+		t.Fatalf("Failed to create memory cache: %v", err)
+	}
+	defer cache.Close()
+
+	ctx := context.Background()
+
+	for i := 0; i < 4; i++ {
+		if err := cache.Set(ctx, fmt.Sprintf("lru_%d", i), "value", time.Hour); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+	}
+
+	// 访问 lru_0，使 lru_1 成为最久未访问的键
+	if _, err := cache.Get(ctx, "lru_0"); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if err := cache.Set(ctx, "lru_4", "value", time.Hour); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	tests := []struct {
+		key      string
+		expected bool
+	}{
+		{key: "lru_0", expected: true},
+		{key: "lru_1", expected: false},
+		{key: "lru_4", expected: true},
+	}
+	for _, tt := range tests {
+		exists, err := cache.Exists(ctx, tt.key)
+		if err != nil {
+			t.Fatalf("Exists failed: %v", err)
+		}
+		if exists != tt.expected {
+			t.Errorf("Expected %s exists=%v, got %v", tt.key, tt.expected, exists)
+		}
+	}
+
+	stats, _ := cache.Stats(ctx)
+	if stats.Evictions == 0 {
+		t.Error("Expected evictions to be counted")
+	}
+	if stats.Memory > cfg.MaxMemory {
+		t.Errorf("Expected size <= %d, got %d", cfg.MaxMemory, stats.Memory)
+	}
+
+	// 删除后占用应回收
+	if err := cache.MDelete(ctx, "lru_0", "lru_2", "lru_3", "lru_4"); err != nil {
+		t.Fatalf("MDelete failed: %v", err)
+	}
+	if stats, _ := cache.Stats(ctx); stats.Keys != 0 || stats.Memory != 0 {
+		t.Errorf("Expected empty cache, got %+v", stats)
+	}
+}
+
+func TestMemoryCache_Sharding(t *testing.T) {
+	cfg := &config.CacheConfig{
+		Driver:          "memory",
+		MaxMemory:       100 * 1024 * 1024,
+		CleanupInterval: time.Minute,
+		Shards:          8,
+	}
+	cfg.SetDefaults()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+	cache, err := NewMemoryCache(cfg, logger)
+	if err != nil {
+		t.Fatalf("Failed to create memory cache: %v", err)
+	}
+	defer cache.Close()
+
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				key := fmt.Sprintf("shard_%d_%d", worker, i)
+				if err := cache.Set(ctx, key, i, time.Hour); err != nil {
+					t.Errorf("Set failed: %v", err)
+					return
+				}
+				if _, err := cache.Get(ctx, key); err != nil {
+					t.Errorf("Get failed: %v", err)
+					return
+				}
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	stats, _ := cache.Stats(ctx)
+	if stats.Keys != 800 || stats.Shards != 8 {
+		t.Errorf("Expected 800 items in 8 shards, got %+v", stats)
+	}
+
+	used := 0
+	for _, shard := range cache.shards {
+		if len(shard.data) > 0 {
+			used++
+		}
+	}
+	if used < 2 {
+		t.Errorf("Expected keys to spread across shards, got %d used shards", used)
+	}
+}
+
+func TestMemoryCache_PubSub(t *testing.T) {
+	cfg := &config.CacheConfig{
+		Driver:          "memory",
+		MaxMemory:       100 * 1024 * 1024,
+		CleanupInterval: time.Minute,
+	}
+	cfg.SetDefaults()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+	cache, err := NewMemoryCache(cfg, logger)
+	if err != nil {
+		t.Fatalf("Failed to create memory cache: %v", err)
+	}
+	defer cache.Close()
+
+	ctx := context.Background()
+
+	sub, err := cache.Subscribe(ctx, "cache.invalidate", "admin.events")
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	if err := cache.Publish(ctx, "cache.invalidate", "user:1"); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if err := cache.Publish(ctx, "other", "ignored"); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if err := cache.Publish(ctx, "admin.events", 42); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	expected := []Message{{Channel: "cache.invalidate", Payload: "user:1"}, {Channel: "admin.events", Payload: "42"}}
+	for _, want := range expected {
+		select {
+		case msg := <-sub.Channel():
+			if *msg != want {
+				t.Errorf("Expected %+v, got %+v", want, *msg)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for message")
+		}
+	}
+
+	if err := sub.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if _, ok := <-sub.Channel(); ok {
+		t.Error("Expected channel to be closed after unsubscribe")
+	}
+	if err := cache.Publish(ctx, "cache.invalidate", "user:2"); err != nil {
+		t.Errorf("Publish after unsubscribe failed: %v", err)
+	}
+}
+
+func TestMemoryCache_Pipeline(t *testing.T) {
+	cfg := &config.CacheConfig{
+		Driver:          "memory",
+		MaxMemory:       100 * 1024 * 1024,
+		CleanupInterval: time.Minute,
+	}
+	cfg.SetDefaults()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+	cache, err := NewMemoryCache(cfg, logger)
+	if err != nil {
+		t.Fatalf("Failed to create memory cache: %v", err)
+	}
+	defer cache.Close()
+
+	ctx := context.Background()
+
+	t.Run("Pipeline", func(t *testing.T) {
+		pipe := cache.Pipeline()
+		pipe.Set("pipe:a", "1", time.Minute)
+		pipe.HSet("pipe:hash", map[string]interface{}{"field": "value"})
+		pipe.RPush("pipe:list", "x", "y")
+		pipe.SAdd("pipe:set", "m")
+		incr := pipe.Increment("pipe:counter", 5)
+		if pipe.Len() != 5 {
+			t.Errorf("Expected 5 queued commands, got %d", pipe.Len())
+		}
+
+		// 提交前不可见
+		if exists, _ := cache.Exists(ctx, "pipe:a"); exists {
+			t.Error("Expected queued command to be invisible before Exec")
+		}
+
+		if err := pipe.Exec(ctx); err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+		if pipe.Len() != 0 {
+			t.Errorf("Expected pipeline to be empty after Exec, got %d", pipe.Len())
+		}
+		if incr.Val() != 5 {
+			t.Errorf("Expected increment result 5, got %d", incr.Val())
+		}
+		if value, _ := cache.Get(ctx, "pipe:a"); value != "1" {
+			t.Errorf("Expected '1', got '%s'", value)
+		}
+		if value, _ := cache.HGet(ctx, "pipe:hash", "field"); value != "value" {
+			t.Errorf("Expected 'value', got '%s'", value)
+		}
+		if values, _ := cache.LRange(ctx, "pipe:list", 0, -1); len(values) != 2 {
+			t.Errorf("Expected 2 list items, got %v", values)
+		}
+		if ok, _ := cache.SIsMember(ctx, "pipe:set", "m"); !ok {
+			t.Error("Expected set member 'm'")
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		pipe := cache.Pipeline()
+		pipe.Expire("pipe:missing", time.Minute)
+		pipe.Set("pipe:after", "ok", 0)
+		err := pipe.Exec(ctx)
+		if !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("Expected ErrKeyNotFound, got %v", err)
+		}
+		// 失败命令不影响后续命令
+		if value, _ := cache.Get(ctx, "pipe:after"); value != "ok" {
+			t.Errorf("Expected 'ok', got '%s'", value)
+		}
+	})
+
+	t.Run("Discard", func(t *testing.T) {
+		pipe := cache.TxPipeline()
+		pipe.Set("pipe:discarded", "1", 0)
+		pipe.Discard()
+		if err := pipe.Exec(ctx); err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+		if exists, _ := cache.Exists(ctx, "pipe:discarded"); exists {
+			t.Error("Expected discarded command not to be executed")
+		}
+	})
+
+	t.Run("TxAtomic", func(t *testing.T) {
+		// 事务内两个账户转账，并发读取时总额应保持不变
+		_ = cache.MSet(ctx, map[string]interface{}{"account:a": int64(1000), "account:b": int64(0)}, 0)
+
+		var wg sync.WaitGroup
+		stop := make(chan struct{})
+		var violations atomic.Int64
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				tx := cache.TxPipeline()
+				a := tx.Increment("account:a", 0)
+				b := tx.Increment("account:b", 0)
+				if err := tx.Exec(ctx); err != nil {
+					t.Errorf("Exec failed: %v", err)
+					return
+				}
+				if a.Val()+b.Val() != 1000 {
+					violations.Add(1)
+				}
+			}
+		}()
+
+		for i := 0; i < 500; i++ {
+			tx := cache.TxPipeline()
+			tx.Decrement("account:a", 1)
+			tx.Increment("account:b", 1)
+			if err := tx.Exec(ctx); err != nil {
+				t.Fatalf("Exec failed: %v", err)
+			}
+		}
+		close(stop)
+		wg.Wait()
+
+		if n := violations.Load(); n != 0 {
+			t.Errorf("Expected no partial transaction visible, got %d violations", n)
+		}
+		a, _ := cache.Get(ctx, "account:a")
+		b, _ := cache.Get(ctx, "account:b")
+		if a != "500" || b != "500" {
+			t.Errorf("Expected 500/500, got %s/%s", a, b)
+		}
+	})
+}
+
+func TestMemoryCache_KeysByPattern(t *testing.T) {
+	cfg := &config.CacheConfig{
+		Driver:          "memory",
+		MaxMemory:       100 * 1024 * 1024,
+		CleanupInterval: time.Minute,
+	}
+	cfg.SetDefaults()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+	cache, err := NewMemoryCache(cfg, logger)
+	if err != nil {
+		t.Fatalf("Failed to create memory cache: %v", err)
+	}
+	defer cache.Close()
+
+	ctx := context.Background()
+	_ = cache.MSet(ctx, map[string]interface{}{
+		"user:1:profile": "a",
+		"user:2:profile": "b",
+		"user:2:token":   "c",
+		"order:1":        "d",
+	}, 0)
+	_ = cache.Set(ctx, "user:3:profile", "expired", 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+
+	keys, err := cache.Keys(ctx, "user:*:profile")
+	if err != nil {
+		t.Fatalf("Keys failed: %v", err)
+	}
+	if len(keys) != 2 {
+		t.Errorf("Expected 2 keys, got %v", keys)
+	}
+
+	deleted, err := cache.DeleteByPattern(ctx, "user:*")
+	if err != nil {
+		t.Fatalf("DeleteByPattern failed: %v", err)
+	}
+	if deleted != 3 {
+		t.Errorf("Expected 3 deleted keys, got %d", deleted)
+	}
+	if keys, _ := cache.Keys(ctx, "*"); len(keys) != 1 || keys[0] != "order:1" {
+		t.Errorf("Expected only order:1 to remain, got %v", keys)
+	}
+
+	tests := []struct {
+		pattern string
+		key     string
+		want    bool
+	}{
+		{"*", "", true},
+		{"user:*", "user:1/profile", true},
+		{"user:?", "user:1", true},
+		{"user:?", "user:12", false},
+		{"user:[12]", "user:2", true},
+		{"user:[^12]", "user:2", false},
+		{"user:[a-c]", "user:b", true},
+		{"user:[a-c]", "user:d", false},
+		{"user\\*", "user*", true},
+		{"user\\*", "users", false},
+		{"*:profile", "user:1:token", false},
+		{"a*b*c", "axxbyyc", true},
+	}
+	for _, tt := range tests {
+		if got := matchPattern(tt.pattern, tt.key); got != tt.want {
+			t.Errorf("matchPattern(%q, %q) = %v, want %v", tt.pattern, tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestMemoryCache_Stats(t *testing.T) {
+	cfg := &config.CacheConfig{
+		Driver:          "memory",
+		MaxMemory:       100 * 1024 * 1024,
+		CleanupInterval: time.Minute,
+	}
+	cfg.SetDefaults()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+	cache, err := NewMemoryCache(cfg, logger)
+	if err != nil {
+		t.Fatalf("Failed to create memory cache: %v", err)
+	}
+	defer cache.Close()
+
+	ctx := context.Background()
+	_ = cache.Set(ctx, "stats:a", "1", 0)
+	_ = cache.HSet(ctx, "stats:hash", map[string]interface{}{"f": "v"})
+
+	// 3 次命中，1 次未命中；写操作不计入
+	_, _ = cache.Get(ctx, "stats:a")
+	_, _ = cache.Get(ctx, "stats:a")
+	_, _ = cache.HGet(ctx, "stats:hash", "f")
+	_, _ = cache.Get(ctx, "stats:missing")
+
+	stats, err := cache.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if stats.Driver != "memory" || stats.Keys != 2 {
+		t.Errorf("Expected memory driver with 2 keys, got %+v", stats)
+	}
+	if stats.Hits != 3 || stats.Misses != 1 {
+		t.Errorf("Expected 3 hits and 1 miss, got %d/%d", stats.Hits, stats.Misses)
+	}
+	if stats.HitRate != 0.75 {
+		t.Errorf("Expected hit rate 0.75, got %v", stats.HitRate)
+	}
+	if stats.Memory <= 0 {
+		t.Errorf("Expected memory usage to be estimated, got %d", stats.Memory)
+	}
+}
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Pipeline 命令管道：命令先入队，调用 Exec 时一次性提交
+// - Pipeline() 仅批量发送，命令之间可能穿插其他客户端的命令
+// - TxPipeline() 以事务方式执行（Redis MULTI/EXEC），执行期间其他客户端看不到中间状态
+// - 与 Redis 事务语义一致：单条命令失败不会回滚已执行的命令，Exec 返回第一个错误
+// - Redis 集群模式下事务内的键需位于同一槽位（可使用 {tag} 哈希标签）
+type Pipeline interface {
+	Set(key string, value interface{}, expiration time.Duration)
+	Delete(keys ...string)
+	Increment(key string, delta int64) *IntResult
+	Decrement(key string, delta int64) *IntResult
+	Expire(key string, expiration time.Duration)
+	HSet(key string, pairs map[string]interface{})
+	HDelete(key string, fields ...string)
+	LPush(key string, values ...interface{})
+	RPush(key string, values ...interface{})
+	SAdd(key string, members ...interface{})
+	SRem(key string, members ...interface{})
+
+	// Len 已入队的命令数
+	Len() int
+	// Exec 提交已入队的命令，提交后管道清空可继续复用
+	Exec(ctx context.Context) error
+	// Discard 丢弃已入队的命令
+	Discard()
+}
+
+// IntResult 整数命令结果，Exec 之后可读取
+type IntResult struct {
+	val int64
+	err error
+}
+
+// Val 结果值
+func (r *IntResult) Val() int64 {
+	return r.val
+}
+
+// Err 命令错误
+func (r *IntResult) Err() error {
+	return r.err
+}
+
+// Result 结果值与错误
+func (r *IntResult) Result() (int64, error) {
+	return r.val, r.err
+}
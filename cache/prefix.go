@@ -0,0 +1,275 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// prefixCache 带键前缀的缓存视图（例如按租户隔离），与底层缓存共享连接
+type prefixCache struct {
+	Cache
+	prefix string
+}
+
+// WithPrefix 返回带键前缀的缓存视图，键、扫描模式与发布订阅频道均加上 prefix:
+// - 返回的键与订阅消息的频道不含该前缀
+// - 统计信息与健康检查为底层缓存的数据，Close 不关闭底层缓存
+func WithPrefix(c Cache, prefix string) Cache {
+	if prefix == "" {
+		return c
+	}
+	return &prefixCache{Cache: c, prefix: prefix + ":"}
+}
+
+// key 获取带前缀的键
+func (p *prefixCache) key(key string) string {
+	return p.prefix + key
+}
+
+// keys 获取带前缀的键列表
+func (p *prefixCache) keys(keys []string) []string {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = p.key(key)
+	}
+	return prefixed
+}
+
+// Get 获取值
+func (p *prefixCache) Get(ctx context.Context, key string) (string, error) {
+	return p.Cache.Get(ctx, p.key(key))
+}
+
+// Set 设置值
+func (p *prefixCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return p.Cache.Set(ctx, p.key(key), value, expiration)
+}
+
+// Delete 删除键
+func (p *prefixCache) Delete(ctx context.Context, key string) error {
+	return p.Cache.Delete(ctx, p.key(key))
+}
+
+// Exists 检查键是否存在
+func (p *prefixCache) Exists(ctx context.Context, key string) (bool, error) {
+	return p.Cache.Exists(ctx, p.key(key))
+}
+
+// MGet 批量获取值
+func (p *prefixCache) MGet(ctx context.Context, keys ...string) ([]interface{}, error) {
+	return p.Cache.MGet(ctx, p.keys(keys)...)
+}
+
+// MSet 批量设置值
+func (p *prefixCache) MSet(ctx context.Context, pairs map[string]interface{}, expiration time.Duration) error {
+	prefixed := make(map[string]interface{}, len(pairs))
+	for key, value := range pairs {
+		prefixed[p.key(key)] = value
+	}
+	return p.Cache.MSet(ctx, prefixed, expiration)
+}
+
+// MDelete 批量删除键
+func (p *prefixCache) MDelete(ctx context.Context, keys ...string) error {
+	return p.Cache.MDelete(ctx, p.keys(keys)...)
+}
+
+// Keys 按模式扫描键（仅扫描前缀下的键）
+func (p *prefixCache) Keys(ctx context.Context, pattern string) ([]string, error) {
+	keys, err := p.Cache.Keys(ctx, p.key(pattern))
+	if err != nil {
+		return nil, err
+	}
+	for i, key := range keys {
+		keys[i] = strings.TrimPrefix(key, p.prefix)
+	}
+	return keys, nil
+}
+
+// DeleteByPattern 按模式删除键（仅删除前缀下的键）
+func (p *prefixCache) DeleteByPattern(ctx context.Context, pattern string) (int64, error) {
+	return p.Cache.DeleteByPattern(ctx, p.key(pattern))
+}
+
+// Increment 自增
+func (p *prefixCache) Increment(ctx context.Context, key string, delta int64) (int64, error) {
+	return p.Cache.Increment(ctx, p.key(key), delta)
+}
+
+// Decrement 自减
+func (p *prefixCache) Decrement(ctx context.Context, key string, delta int64) (int64, error) {
+	return p.Cache.Decrement(ctx, p.key(key), delta)
+}
+
+// Expire 设置过期时间
+func (p *prefixCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	return p.Cache.Expire(ctx, p.key(key), expiration)
+}
+
+// TTL 获取剩余过期时间
+func (p *prefixCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	return p.Cache.TTL(ctx, p.key(key))
+}
+
+// HGet 获取哈希字段值
+func (p *prefixCache) HGet(ctx context.Context, key, field string) (string, error) {
+	return p.Cache.HGet(ctx, p.key(key), field)
+}
+
+// HSet 设置哈希字段值
+func (p *prefixCache) HSet(ctx context.Context, key string, pairs map[string]interface{}) error {
+	return p.Cache.HSet(ctx, p.key(key), pairs)
+}
+
+// HGetAll 获取哈希全部字段
+func (p *prefixCache) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	return p.Cache.HGetAll(ctx, p.key(key))
+}
+
+// HDelete 删除哈希字段
+func (p *prefixCache) HDelete(ctx context.Context, key string, fields ...string) error {
+	return p.Cache.HDelete(ctx, p.key(key), fields...)
+}
+
+// LPush 从列表左侧插入
+func (p *prefixCache) LPush(ctx context.Context, key string, values ...interface{}) error {
+	return p.Cache.LPush(ctx, p.key(key), values...)
+}
+
+// RPush 从列表右侧插入
+func (p *prefixCache) RPush(ctx context.Context, key string, values ...interface{}) error {
+	return p.Cache.RPush(ctx, p.key(key), values...)
+}
+
+// LPop 从列表左侧弹出
+func (p *prefixCache) LPop(ctx context.Context, key string) (string, error) {
+	return p.Cache.LPop(ctx, p.key(key))
+}
+
+// RPop 从列表右侧弹出
+func (p *prefixCache) RPop(ctx context.Context, key string) (string, error) {
+	return p.Cache.RPop(ctx, p.key(key))
+}
+
+// LRange 获取列表范围内的元素
+func (p *prefixCache) LRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
+	return p.Cache.LRange(ctx, p.key(key), start, stop)
+}
+
+// SAdd 添加集合成员
+func (p *prefixCache) SAdd(ctx context.Context, key string, members ...interface{}) error {
+	return p.Cache.SAdd(ctx, p.key(key), members...)
+}
+
+// SRem 删除集合成员
+func (p *prefixCache) SRem(ctx context.Context, key string, members ...interface{}) error {
+	return p.Cache.SRem(ctx, p.key(key), members...)
+}
+
+// SMembers 获取集合全部成员
+func (p *prefixCache) SMembers(ctx context.Context, key string) ([]string, error) {
+	return p.Cache.SMembers(ctx, p.key(key))
+}
+
+// SIsMember 判断是否为集合成员
+func (p *prefixCache) SIsMember(ctx context.Context, key string, member interface{}) (bool, error) {
+	return p.Cache.SIsMember(ctx, p.key(key), member)
+}
+
+// GetOrSet 获取值，未命中时加载并写入缓存
+func (p *prefixCache) GetOrSet(ctx context.Context, key string, expiration time.Duration, loader LoaderFunc) (string, error) {
+	return p.Cache.GetOrSet(ctx, p.key(key), expiration, loader)
+}
+
+// Pipeline 创建命令管道
+func (p *prefixCache) Pipeline() Pipeline {
+	return &prefixPipeline{Pipeline: p.Cache.Pipeline(), cache: p}
+}
+
+// TxPipeline 创建事务管道
+func (p *prefixCache) TxPipeline() Pipeline {
+	return &prefixPipeline{Pipeline: p.Cache.TxPipeline(), cache: p}
+}
+
+// Publish 发布消息
+func (p *prefixCache) Publish(ctx context.Context, channel string, message interface{}) error {
+	return p.Cache.Publish(ctx, p.key(channel), message)
+}
+
+// Subscribe 订阅频道（消息的频道不含前缀）
+func (p *prefixCache) Subscribe(ctx context.Context, channels ...string) (*Subscription, error) {
+	inner, err := p.Cache.Subscribe(ctx, p.keys(channels)...)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := newSubscription()
+	sub.closeFn = inner.Close
+	go func() {
+		defer close(sub.messages)
+		for msg := range inner.Channel() {
+			select {
+			case sub.messages <- &Message{Channel: strings.TrimPrefix(msg.Channel, p.prefix), Payload: msg.Payload}:
+			case <-sub.done:
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+// Close 缓存视图不持有连接，不关闭底层缓存
+func (p *prefixCache) Close() error {
+	return nil
+}
+
+// prefixPipeline 带键前缀的命令管道
+type prefixPipeline struct {
+	Pipeline
+	cache *prefixCache
+}
+
+func (p *prefixPipeline) Set(key string, value interface{}, expiration time.Duration) {
+	p.Pipeline.Set(p.cache.key(key), value, expiration)
+}
+
+func (p *prefixPipeline) Delete(keys ...string) {
+	p.Pipeline.Delete(p.cache.keys(keys)...)
+}
+
+func (p *prefixPipeline) Increment(key string, delta int64) *IntResult {
+	return p.Pipeline.Increment(p.cache.key(key), delta)
+}
+
+func (p *prefixPipeline) Decrement(key string, delta int64) *IntResult {
+	return p.Pipeline.Decrement(p.cache.key(key), delta)
+}
+
+func (p *prefixPipeline) Expire(key string, expiration time.Duration) {
+	p.Pipeline.Expire(p.cache.key(key), expiration)
+}
+
+func (p *prefixPipeline) HSet(key string, pairs map[string]interface{}) {
+	p.Pipeline.HSet(p.cache.key(key), pairs)
+}
+
+func (p *prefixPipeline) HDelete(key string, fields ...string) {
+	p.Pipeline.HDelete(p.cache.key(key), fields...)
+}
+
+func (p *prefixPipeline) LPush(key string, values ...interface{}) {
+	p.Pipeline.LPush(p.cache.key(key), values...)
+}
+
+func (p *prefixPipeline) RPush(key string, values ...interface{}) {
+	p.Pipeline.RPush(p.cache.key(key), values...)
+}
+
+func (p *prefixPipeline) SAdd(key string, members ...interface{}) {
+	p.Pipeline.SAdd(p.cache.key(key), members...)
+}
+
+func (p *prefixPipeline) SRem(key string, members ...interface{}) {
+	p.Pipeline.SRem(p.cache.key(key), members...)
+}
@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+/*
+带键前缀的缓存视图测试
+
+本文件用于测试 WithPrefix 返回的缓存视图与底层缓存、其他前缀之间的隔离。
+
+运行命令：
+go test -v -run "^TestCacheWithPrefix.*$"
+
+测试内容：
+1. 键读写与隔离 (Get, Set, Exists, MGet)
+2. 键扫描与按模式删除 (Keys, DeleteByPattern)
+3. 管道 (Pipeline)
+4. 发布订阅 (Publish, Subscribe)
+*/
+
+func TestCacheWithPrefix(t *testing.T) {
+	c := newTypedTestCache(t)
+	ctx := context.Background()
+	a := WithPrefix(c, "tenant:a")
+	b := WithPrefix(c, "tenant:b")
+
+	if WithPrefix(c, "") != c {
+		t.Error("Expected empty prefix to return the cache itself")
+	}
+
+	if err := a.Set(ctx, "k", "va", time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := b.Set(ctx, "k", "vb", time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if v, err := a.Get(ctx, "k"); err != nil || v != "va" {
+		t.Errorf("Expected va, got %q, %v", v, err)
+	}
+	if v, err := c.Get(ctx, "tenant:b:k"); err != nil || v != "vb" {
+		t.Errorf("Expected vb stored under prefix, got %q, %v", v, err)
+	}
+	if exists, _ := c.Exists(ctx, "k"); exists {
+		t.Error("Expected unprefixed key not to exist")
+	}
+	values, err := a.MGet(ctx, "k", "missing")
+	if err != nil || len(values) != 2 || values[0] != "va" || values[1] != nil {
+		t.Errorf("Unexpected MGet result: %v, %v", values, err)
+	}
+
+	// 键扫描与按模式删除仅作用于前缀下的键
+	if err := a.Set(ctx, "user:1", "1", time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	keys, err := a.Keys(ctx, "*")
+	slices.Sort(keys)
+	if err != nil || !slices.Equal(keys, []string{"k", "user:1"}) {
+		t.Errorf("Unexpected keys: %v, %v", keys, err)
+	}
+	if deleted, err := a.DeleteByPattern(ctx, "*"); err != nil || deleted != 2 {
+		t.Errorf("Expected 2 deleted, got %d, %v", deleted, err)
+	}
+	if v, err := b.Get(ctx, "k"); err != nil || v != "vb" {
+		t.Errorf("Expected other prefix untouched, got %q, %v", v, err)
+	}
+
+	// 管道
+	pipe := a.Pipeline()
+	pipe.Set("p", "1", time.Minute)
+	incr := pipe.Increment("n", 2)
+	if err := pipe.Exec(ctx); err != nil {
+		t.Fatalf("Exec failed: %v", err)
+	}
+	if incr.Val() != 2 {
+		t.Errorf("Expected 2, got %d", incr.Val())
+	}
+	if v, err := c.Get(ctx, "tenant:a:p"); err != nil || v != "1" {
+		t.Errorf("Expected pipeline key under prefix, got %q, %v", v, err)
+	}
+
+	// 视图关闭不影响底层缓存
+	if err := a.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := c.HealthCheck(ctx); err != nil {
+		t.Errorf("Expected underlying cache open, got %v", err)
+	}
+}
+
+func TestCacheWithPrefixPubSub(t *testing.T) {
+	c := newTypedTestCache(t)
+	ctx := context.Background()
+	a := WithPrefix(c, "tenant:a")
+
+	sub, err := a.Subscribe(ctx, "events")
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	defer sub.Close()
+
+	if err := c.Publish(ctx, "events", "unprefixed"); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if err := a.Publish(ctx, "events", "hello"); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	select {
+	case msg := <-sub.Channel():
+		if msg.Channel != "events" || msg.Payload != "hello" {
+			t.Errorf("Unexpected message: %+v", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for message")
+	}
+}
@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"sync"
+)
+
+// subscriptionBuffer 订阅消息缓冲大小
+const subscriptionBuffer = 100
+
+// Message 订阅消息
+type Message struct {
+	Channel string // 频道（不含键前缀）
+	Payload string // 消息内容
+}
+
+// Subscription 频道订阅
+// - 通过 Channel() 接收消息，Close 后消息通道关闭
+type Subscription struct {
+	messages chan *Message
+	done     chan struct{}
+	closeFn  func() error
+	once     sync.Once
+	err      error
+}
+
+// newSubscription 创建订阅
+func newSubscription() *Subscription {
+	return &Subscription{
+		messages: make(chan *Message, subscriptionBuffer),
+		done:     make(chan struct{}),
+	}
+}
+
+// Channel 获取消息通道
+func (s *Subscription) Channel() <-chan *Message {
+	return s.messages
+}
+
+// Close 取消订阅（可重复调用）
+func (s *Subscription) Close() error {
+	s.once.Do(func() {
+		close(s.done)
+		if s.closeFn != nil {
+			s.err = s.closeFn()
+		}
+	})
+	return s.err
+}
+
+// memoryHub 内存驱动的进程内发布订阅
+type memoryHub struct {
+	mutex       sync.RWMutex
+	subscribers map[string]map[*Subscription]struct{}
+}
+
+// newMemoryHub 创建进程内发布订阅
+func newMemoryHub() *memoryHub {
+	return &memoryHub{subscribers: make(map[string]map[*Subscription]struct{})}
+}
+
+// subscribe 订阅频道
+func (h *memoryHub) subscribe(channels ...string) *Subscription {
+	sub := newSubscription()
+
+	h.mutex.Lock()
+	for _, channel := range channels {
+		if h.subscribers[channel] == nil {
+			h.subscribers[channel] = make(map[*Subscription]struct{})
+		}
+		h.subscribers[channel][sub] = struct{}{}
+	}
+	h.mutex.Unlock()
+
+	sub.closeFn = func() error {
+		h.mutex.Lock()
+		defer h.mutex.Unlock()
+		for _, channel := range channels {
+			delete(h.subscribers[channel], sub)
+			if len(h.subscribers[channel]) == 0 {
+				delete(h.subscribers, channel)
+			}
+		}
+		close(sub.messages)
+		return nil
+	}
+	return sub
+}
+
+// publish 发布消息，返回接收的订阅数（订阅方缓冲已满时丢弃该条消息）
+func (h *memoryHub) publish(channel, payload string) (delivered, dropped int) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	for sub := range h.subscribers[channel] {
+		select {
+		case sub.messages <- &Message{Channel: channel, Payload: payload}:
+			delivered++
+		default:
+			dropped++
+		}
+	}
+	return delivered, dropped
+}
+
+// close 关闭所有订阅
+func (h *memoryHub) close() {
+	h.mutex.Lock()
+	subs := make(map[*Subscription]struct{})
+	for _, channelSubs := range h.subscribers {
+		for sub := range channelSubs {
+			subs[sub] = struct{}{}
+		}
+	}
+	h.mutex.Unlock()
+
+	for sub := range subs {
+		_ = sub.Close()
+	}
+}
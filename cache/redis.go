@@ -6,47 +6,44 @@ import (
 	"fmt"
 	"log/slog"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/so68/core/config"
+	"golang.org/x/sync/singleflight"
 )
 
-// RedisCache Redis 缓存实现
+// RedisCache Redis 缓存实现（支持单机、集群与哨兵模式）
 type RedisCache struct {
-	client *redis.Client
+	client redis.UniversalClient
 	config *config.CacheConfig
 	logger *slog.Logger
+	loads  singleflight.Group
 }
 
 // NewRedisCache 创建 Redis 缓存实例
 func NewRedisCache(cfg *config.CacheConfig, logger *slog.Logger) (*RedisCache, error) {
-	rdb := redis.NewClient(&redis.Options{
-		Addr:            fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
-		Password:        cfg.Password,
-		DB:              cfg.Database,
-		MaxRetries:      cfg.MaxRetries,
-		MinRetryBackoff: cfg.MinRetryBackoff,
-		MaxRetryBackoff: cfg.MaxRetryBackoff,
-		DialTimeout:     cfg.DialTimeout,
-		ReadTimeout:     cfg.ReadTimeout,
-		WriteTimeout:    cfg.WriteTimeout,
-		PoolSize:        cfg.PoolSize,
-		MinIdleConns:    cfg.MinIdleConns,
-		PoolTimeout:     cfg.PoolTimeout,
-	})
+	rdb, err := newRedisClient(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	// 测试连接
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	if err := rdb.Ping(ctx).Err(); err != nil {
+		_ = rdb.Close()
 		return nil, fmt.Errorf("failed to connect to redis: %w", err)
 	}
 
 	logger.Info("Redis cache connected successfully",
-		slog.String("host", cfg.Host),
-		slog.Int("port", cfg.Port),
+		slog.String("mode", cfg.Mode),
+		slog.Any("addrs", cfg.RedisAddrs()),
+		slog.String("master_name", cfg.MasterName),
 		slog.String("database", strconv.Itoa(cfg.Database)),
 	)
 
@@ -57,6 +54,76 @@ func NewRedisCache(cfg *config.CacheConfig, logger *slog.Logger) (*RedisCache, e
 	}, nil
 }
 
+// newRedisClient 按部署模式创建 Redis 客户端
+func newRedisClient(cfg *config.CacheConfig) (redis.UniversalClient, error) {
+	switch cfg.Mode {
+	case "", "standalone":
+		return redis.NewClient(&redis.Options{
+			Addr:            fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+			Password:        cfg.Password,
+			DB:              cfg.Database,
+			MaxRetries:      cfg.MaxRetries,
+			MinRetryBackoff: cfg.MinRetryBackoff,
+			MaxRetryBackoff: cfg.MaxRetryBackoff,
+			DialTimeout:     cfg.DialTimeout,
+			ReadTimeout:     cfg.ReadTimeout,
+			WriteTimeout:    cfg.WriteTimeout,
+			PoolSize:        cfg.PoolSize,
+			MinIdleConns:    cfg.MinIdleConns,
+			PoolTimeout:     cfg.PoolTimeout,
+		}), nil
+	case "cluster":
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:           cfg.RedisAddrs(),
+			Password:        cfg.Password,
+			MaxRetries:      cfg.MaxRetries,
+			MinRetryBackoff: cfg.MinRetryBackoff,
+			MaxRetryBackoff: cfg.MaxRetryBackoff,
+			DialTimeout:     cfg.DialTimeout,
+			ReadTimeout:     cfg.ReadTimeout,
+			WriteTimeout:    cfg.WriteTimeout,
+			PoolSize:        cfg.PoolSize,
+			MinIdleConns:    cfg.MinIdleConns,
+			PoolTimeout:     cfg.PoolTimeout,
+		}), nil
+	case "sentinel":
+		if cfg.MasterName == "" {
+			return nil, fmt.Errorf("redis sentinel mode requires masterName")
+		}
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       cfg.MasterName,
+			SentinelAddrs:    cfg.RedisAddrs(),
+			SentinelPassword: cfg.SentinelPassword,
+			Password:         cfg.Password,
+			DB:               cfg.Database,
+			MaxRetries:       cfg.MaxRetries,
+			MinRetryBackoff:  cfg.MinRetryBackoff,
+			MaxRetryBackoff:  cfg.MaxRetryBackoff,
+			DialTimeout:      cfg.DialTimeout,
+			ReadTimeout:      cfg.ReadTimeout,
+			WriteTimeout:     cfg.WriteTimeout,
+			PoolSize:         cfg.PoolSize,
+			MinIdleConns:     cfg.MinIdleConns,
+			PoolTimeout:      cfg.PoolTimeout,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported redis mode: %s", cfg.Mode)
+	}
+}
+
+// Client 获取底层 Redis 客户端（供队列等子系统复用连接）
+func (r *RedisCache) Client() redis.UniversalClient {
+	return r.client
+}
+
+// Mode 获取部署模式
+func (r *RedisCache) Mode() string {
+	if r.config.Mode == "" {
+		return "standalone"
+	}
+	return r.config.Mode
+}
+
 // getKey 获取带前缀的键
 func (r *RedisCache) getKey(key string) string {
 	if r.config.Prefix != "" {
@@ -65,6 +132,14 @@ func (r *RedisCache) getKey(key string) string {
 	return key
 }
 
+// trimKey 去除键前缀
+func (r *RedisCache) trimKey(key string) string {
+	if r.config.Prefix != "" {
+		return strings.TrimPrefix(key, r.config.Prefix+":")
+	}
+	return key
+}
+
 // serialize 序列化值
 func (r *RedisCache) serialize(value interface{}) (string, error) {
 	switch v := value.(type) {
@@ -99,13 +174,18 @@ func (r *RedisCache) Get(ctx context.Context, key string) (string, error) {
 	result := r.client.Get(ctx, r.getKey(key))
 	if err := result.Err(); err != nil {
 		if err == redis.Nil {
-			return "", fmt.Errorf("key not found: %s", key)
+			return "", fmt.Errorf("%w: %s", ErrKeyNotFound, key)
 		}
 		return "", fmt.Errorf("failed to get key %s: %w", key, err)
 	}
 	return result.Val(), nil
 }
 
+// GetOrSet 获取值，不存在时调用 loader 加载并写入缓存（并发加载合并为一次）
+func (r *RedisCache) GetOrSet(ctx context.Context, key string, expiration time.Duration, loader LoaderFunc) (string, error) {
+	return getOrSet(ctx, r, &r.loads, key, expiration, loader, r.serialize)
+}
+
 // Set 设置值
 func (r *RedisCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
 	serialized, err := r.serialize(value)
@@ -145,6 +225,11 @@ func (r *RedisCache) MGet(ctx context.Context, keys ...string) ([]interface{}, e
 		redisKeys[i] = r.getKey(key)
 	}
 
+	// 集群模式下多个键可能位于不同槽位，逐键通过管道读取
+	if _, ok := r.client.(*redis.ClusterClient); ok {
+		return r.clusterMGet(ctx, redisKeys)
+	}
+
 	result := r.client.MGet(ctx, redisKeys...)
 	if err := result.Err(); err != nil {
 		return nil, fmt.Errorf("failed to mget keys: %w", err)
@@ -194,6 +279,18 @@ func (r *RedisCache) MDelete(ctx context.Context, keys ...string) error {
 		redisKeys[i] = r.getKey(key)
 	}
 
+	// 集群模式下多个键可能位于不同槽位，逐键通过管道删除
+	if _, ok := r.client.(*redis.ClusterClient); ok {
+		pipe := r.client.Pipeline()
+		for _, key := range redisKeys {
+			pipe.Del(ctx, key)
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return fmt.Errorf("failed to mdelete keys: %w", err)
+		}
+		return nil
+	}
+
 	err := r.client.Del(ctx, redisKeys...).Err()
 	if err != nil {
 		return fmt.Errorf("failed to mdelete keys: %w", err)
@@ -201,6 +298,100 @@ func (r *RedisCache) MDelete(ctx context.Context, keys ...string) error {
 	return nil
 }
 
+// scanBatch SCAN 每批返回数量提示
+const scanBatch = 500
+
+// scan 遍历匹配模式的键（集群模式下并发遍历各主节点），fn 接收节点客户端与带前缀的键
+func (r *RedisCache) scan(ctx context.Context, pattern string, fn func(ctx context.Context, client redis.Cmdable, keys []string) error) error {
+	match := r.getKey(pattern)
+	scanNode := func(ctx context.Context, client redis.Cmdable) error {
+		var cursor uint64
+		for {
+			keys, next, err := client.Scan(ctx, cursor, match, scanBatch).Result()
+			if err != nil {
+				return err
+			}
+			if len(keys) > 0 {
+				if err := fn(ctx, client, keys); err != nil {
+					return err
+				}
+			}
+			if cursor = next; cursor == 0 {
+				return nil
+			}
+		}
+	}
+
+	if cluster, ok := r.client.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, shard *redis.Client) error {
+			return scanNode(ctx, shard)
+		})
+	}
+	return scanNode(ctx, r.client)
+}
+
+// Keys 获取匹配模式的键（基于 SCAN，返回的键不含前缀）
+func (r *RedisCache) Keys(ctx context.Context, pattern string) ([]string, error) {
+	var mutex sync.Mutex
+	result := make([]string, 0)
+	err := r.scan(ctx, pattern, func(ctx context.Context, client redis.Cmdable, keys []string) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		for _, key := range keys {
+			result = append(result, r.trimKey(key))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan keys %s: %w", pattern, err)
+	}
+	return result, nil
+}
+
+// DeleteByPattern 删除匹配模式的键，返回删除数量
+// - 逐批删除，键可能位于不同槽位，因此通过管道逐键删除
+func (r *RedisCache) DeleteByPattern(ctx context.Context, pattern string) (int64, error) {
+	var deleted atomic.Int64
+	err := r.scan(ctx, pattern, func(ctx context.Context, client redis.Cmdable, keys []string) error {
+		pipe := client.Pipeline()
+		cmds := make([]*redis.IntCmd, len(keys))
+		for i, key := range keys {
+			cmds[i] = pipe.Del(ctx, key)
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return err
+		}
+		for _, cmd := range cmds {
+			deleted.Add(cmd.Val())
+		}
+		return nil
+	})
+	if err != nil {
+		return deleted.Load(), fmt.Errorf("failed to delete keys %s: %w", pattern, err)
+	}
+	return deleted.Load(), nil
+}
+
+// clusterMGet 集群模式批量获取
+func (r *RedisCache) clusterMGet(ctx context.Context, keys []string) ([]interface{}, error) {
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.StringCmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = pipe.Get(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to mget keys: %w", err)
+	}
+
+	values := make([]interface{}, len(keys))
+	for i, cmd := range cmds {
+		if val, err := cmd.Result(); err == nil {
+			values[i] = val
+		}
+	}
+	return values, nil
+}
+
 // Increment 递增
 func (r *RedisCache) Increment(ctx context.Context, key string, delta int64) (int64, error) {
 	result := r.client.IncrBy(ctx, r.getKey(key), delta)
@@ -413,11 +604,269 @@ func (r *RedisCache) SIsMember(ctx context.Context, key string, member interface
 	return result.Val(), nil
 }
 
+// Pipeline 创建命令管道（批量发送，不保证原子性）
+func (r *RedisCache) Pipeline() Pipeline {
+	return &redisPipeline{cache: r, pipe: r.client.Pipeline()}
+}
+
+// TxPipeline 创建事务管道（MULTI/EXEC 原子执行）
+func (r *RedisCache) TxPipeline() Pipeline {
+	return &redisPipeline{cache: r, pipe: r.client.TxPipeline()}
+}
+
+// redisPipeline Redis 命令管道
+type redisPipeline struct {
+	cache   *RedisCache
+	pipe    redis.Pipeliner
+	results []redisIntResult
+	err     error // 入队阶段的序列化错误，Exec 时返回
+}
+
+// redisIntResult 待回填的整数结果
+type redisIntResult struct {
+	cmd    *redis.IntCmd
+	result *IntResult
+}
+
+// serializeAll 批量序列化，失败时记录错误
+func (p *redisPipeline) serializeAll(values []interface{}) ([]interface{}, bool) {
+	serialized := make([]interface{}, len(values))
+	for i, value := range values {
+		v, err := p.cache.serialize(value)
+		if err != nil {
+			if p.err == nil {
+				p.err = err
+			}
+			return nil, false
+		}
+		serialized[i] = v
+	}
+	return serialized, true
+}
+
+// intResult 登记整数结果
+func (p *redisPipeline) intResult(cmd *redis.IntCmd) *IntResult {
+	result := &IntResult{}
+	p.results = append(p.results, redisIntResult{cmd: cmd, result: result})
+	return result
+}
+
+func (p *redisPipeline) Set(key string, value interface{}, expiration time.Duration) {
+	if values, ok := p.serializeAll([]interface{}{value}); ok {
+		p.pipe.Set(context.Background(), p.cache.getKey(key), values[0], expiration)
+	}
+}
+
+func (p *redisPipeline) Delete(keys ...string) {
+	for _, key := range keys {
+		p.pipe.Del(context.Background(), p.cache.getKey(key))
+	}
+}
+
+func (p *redisPipeline) Increment(key string, delta int64) *IntResult {
+	return p.intResult(p.pipe.IncrBy(context.Background(), p.cache.getKey(key), delta))
+}
+
+func (p *redisPipeline) Decrement(key string, delta int64) *IntResult {
+	return p.intResult(p.pipe.DecrBy(context.Background(), p.cache.getKey(key), delta))
+}
+
+func (p *redisPipeline) Expire(key string, expiration time.Duration) {
+	p.pipe.Expire(context.Background(), p.cache.getKey(key), expiration)
+}
+
+func (p *redisPipeline) HSet(key string, pairs map[string]interface{}) {
+	values := make(map[string]interface{}, len(pairs))
+	for field, value := range pairs {
+		serialized, ok := p.serializeAll([]interface{}{value})
+		if !ok {
+			return
+		}
+		values[field] = serialized[0]
+	}
+	p.pipe.HSet(context.Background(), p.cache.getKey(key), values)
+}
+
+func (p *redisPipeline) HDelete(key string, fields ...string) {
+	p.pipe.HDel(context.Background(), p.cache.getKey(key), fields...)
+}
+
+func (p *redisPipeline) LPush(key string, values ...interface{}) {
+	if serialized, ok := p.serializeAll(values); ok {
+		p.pipe.LPush(context.Background(), p.cache.getKey(key), serialized...)
+	}
+}
+
+func (p *redisPipeline) RPush(key string, values ...interface{}) {
+	if serialized, ok := p.serializeAll(values); ok {
+		p.pipe.RPush(context.Background(), p.cache.getKey(key), serialized...)
+	}
+}
+
+func (p *redisPipeline) SAdd(key string, members ...interface{}) {
+	if serialized, ok := p.serializeAll(members); ok {
+		p.pipe.SAdd(context.Background(), p.cache.getKey(key), serialized...)
+	}
+}
+
+func (p *redisPipeline) SRem(key string, members ...interface{}) {
+	if serialized, ok := p.serializeAll(members); ok {
+		p.pipe.SRem(context.Background(), p.cache.getKey(key), serialized...)
+	}
+}
+
+// Len 已入队的命令数
+func (p *redisPipeline) Len() int {
+	return p.pipe.Len()
+}
+
+// Discard 丢弃已入队的命令
+func (p *redisPipeline) Discard() {
+	p.pipe.Discard()
+	p.results = nil
+	p.err = nil
+}
+
+// Exec 提交已入队的命令，入队阶段存在序列化错误时不提交
+func (p *redisPipeline) Exec(ctx context.Context) error {
+	if p.err != nil {
+		err := p.err
+		p.Discard()
+		return err
+	}
+
+	results := p.results
+	p.results = nil
+	if p.pipe.Len() == 0 {
+		return nil
+	}
+
+	_, err := p.pipe.Exec(ctx)
+	for _, r := range results {
+		r.result.val, r.result.err = r.cmd.Result()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to exec pipeline: %w", err)
+	}
+	return nil
+}
+
+// Publish 发布消息（频道名附加键前缀）
+func (r *RedisCache) Publish(ctx context.Context, channel string, message interface{}) error {
+	payload, err := r.serialize(message)
+	if err != nil {
+		return err
+	}
+	if err := r.client.Publish(ctx, r.getKey(channel), payload).Err(); err != nil {
+		return fmt.Errorf("failed to publish to channel %s: %w", channel, err)
+	}
+	return nil
+}
+
+// Subscribe 订阅频道
+func (r *RedisCache) Subscribe(ctx context.Context, channels ...string) (*Subscription, error) {
+	if len(channels) == 0 {
+		return nil, fmt.Errorf("no channels to subscribe")
+	}
+
+	redisChannels := make([]string, len(channels))
+	for i, channel := range channels {
+		redisChannels[i] = r.getKey(channel)
+	}
+
+	pubsub := r.client.Subscribe(ctx, redisChannels...)
+	// 等待订阅确认，确保返回后发布的消息不会丢失
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe channels: %w", err)
+	}
+
+	sub := newSubscription()
+	sub.closeFn = pubsub.Close
+	go func() {
+		defer close(sub.messages)
+		for msg := range pubsub.Channel() {
+			select {
+			case sub.messages <- &Message{Channel: r.trimKey(msg.Channel), Payload: msg.Payload}:
+			case <-sub.done:
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+// Stats 获取缓存统计（服务端全局数据，集群模式下汇总各主节点）
+func (r *RedisCache) Stats(ctx context.Context) (*Stats, error) {
+	var mutex sync.Mutex
+	stats := &Stats{Driver: "redis"}
+	collect := func(ctx context.Context, client redis.Cmdable) error {
+		info, err := client.Info(ctx).Result()
+		if err != nil {
+			return err
+		}
+		keys, err := client.DBSize(ctx).Result()
+		if err != nil {
+			return err
+		}
+
+		values := parseRedisInfo(info)
+		mutex.Lock()
+		defer mutex.Unlock()
+		stats.Hits += uint64(infoInt(values, "keyspace_hits"))
+		stats.Misses += uint64(infoInt(values, "keyspace_misses"))
+		stats.Evictions += uint64(infoInt(values, "evicted_keys"))
+		stats.Expirations += uint64(infoInt(values, "expired_keys"))
+		stats.Memory += infoInt(values, "used_memory")
+		stats.MaxMemory += infoInt(values, "maxmemory")
+		stats.Keys += keys
+		return nil
+	}
+
+	var err error
+	if cluster, ok := r.client.(*redis.ClusterClient); ok {
+		err = cluster.ForEachMaster(ctx, func(ctx context.Context, shard *redis.Client) error {
+			return collect(ctx, shard)
+		})
+	} else {
+		err = collect(ctx, r.client)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redis stats: %w", err)
+	}
+
+	stats.HitRate = hitRate(stats.Hits, stats.Misses)
+	return stats, nil
+}
+
 // HealthCheck 健康检查
+// - 集群模式逐个主节点检查，任一分片不可用即失败
+// - 哨兵模式确认当前连接节点仍为主节点，避免故障转移后仍连接旧主节点
 func (r *RedisCache) HealthCheck(ctx context.Context) error {
-	err := r.client.Ping(ctx).Err()
-	if err != nil {
-		return fmt.Errorf("redis health check failed: %w", err)
+	switch client := r.client.(type) {
+	case *redis.ClusterClient:
+		err := client.ForEachMaster(ctx, func(ctx context.Context, shard *redis.Client) error {
+			if err := shard.Ping(ctx).Err(); err != nil {
+				return fmt.Errorf("shard %s: %w", shard.Options().Addr, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("redis health check failed: %w", err)
+		}
+	default:
+		if err := r.client.Ping(ctx).Err(); err != nil {
+			return fmt.Errorf("redis health check failed: %w", err)
+		}
+		if r.config.Mode == "sentinel" {
+			role, err := r.client.Do(ctx, "ROLE").Slice()
+			if err != nil {
+				return fmt.Errorf("redis health check failed: %w", err)
+			}
+			if len(role) == 0 || role[0] != "master" {
+				return fmt.Errorf("redis health check failed: connected node is not master (role: %v)", role)
+			}
+		}
 	}
 
 	// 检查连接池状态
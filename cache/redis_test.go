@@ -29,6 +29,10 @@ go test -v -run "^Test.*Redis.*$"
 6. 集合操作测试 (SAdd, SRem, SMembers, SIsMember等)
 7. 策略模式测试 (缓存穿透、雪崩、击穿、多级缓存等)
 8. 健康检查和连接管理测试
+9. 发布订阅测试 (Publish, Subscribe)
+10. 管道与事务测试 (Pipeline, TxPipeline)
+11. 键扫描测试 (Keys, DeleteByPattern)
+12. 统计测试 (Stats)
 */
 
 func TestRedisCache_BasicOperations(t *testing.T) {
@@ -863,3 +867,261 @@ func TestRedisCache_StrategyPattern(t *testing.T) {
 		t.Logf("Cache stats - Hits: %s, Misses: %s, Sets: %s", hits, misses, sets)
 	})
 }
+
+func TestRedisCache_NewRedisClient(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *config.CacheConfig
+		expected string
+		wantErr  bool
+	}{
+		{name: "standalone", config: &config.CacheConfig{Mode: "standalone"}, expected: "*redis.Client"},
+		{name: "cluster", config: &config.CacheConfig{Mode: "cluster", Addrs: []string{"127.0.0.1:7000", "127.0.0.1:7001"}}, expected: "*redis.ClusterClient"},
+		{name: "sentinel", config: &config.CacheConfig{Mode: "sentinel", MasterName: "mymaster", Addrs: []string{"127.0.0.1:26379"}}, expected: "*redis.Client"},
+		{name: "sentinel without master", config: &config.CacheConfig{Mode: "sentinel"}, wantErr: true},
+		{name: "unsupported", config: &config.CacheConfig{Mode: "proxy"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.SetDefaults()
+			client, err := newRedisClient(tt.config)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newRedisClient failed: %v", err)
+			}
+			defer client.Close()
+			if got := fmt.Sprintf("%T", client); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRedisCache_PubSub(t *testing.T) {
+	cfg := &config.CacheConfig{
+		Driver:   "redis",
+		Host:     "localhost",
+		Port:     6379,
+		Database: 1,
+		Prefix:   "test",
+	}
+	cfg.SetDefaults()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+	cache, err := NewRedisCache(cfg, logger)
+	if err != nil {
+		t.Skipf("Skipping test: failed to connect to Redis: %v", err)
+	}
+	defer cache.Close()
+
+	ctx := context.Background()
+
+	sub, err := cache.Subscribe(ctx, "cache.invalidate")
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	defer sub.Close()
+
+	if err := cache.Publish(ctx, "cache.invalidate", "user:1"); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	select {
+	case msg := <-sub.Channel():
+		if msg.Channel != "cache.invalidate" || msg.Payload != "user:1" {
+			t.Errorf("Unexpected message: %+v", msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for message")
+	}
+}
+
+func TestRedisCache_Pipeline(t *testing.T) {
+	cfg := &config.CacheConfig{
+		Driver:   "redis",
+		Host:     "localhost",
+		Port:     6379,
+		Database: 1,
+		Prefix:   "test",
+	}
+	cfg.SetDefaults()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+	cache, err := NewRedisCache(cfg, logger)
+	if err != nil {
+		t.Skipf("Skipping test: failed to connect to Redis: %v", err)
+	}
+	defer cache.Close()
+
+	ctx := context.Background()
+	defer cache.MDelete(ctx, "pipe:a", "pipe:hash", "pipe:counter", "pipe:bad")
+
+	tests := []struct {
+		name string
+		pipe Pipeline
+	}{
+		{"Pipeline", cache.Pipeline()},
+		{"TxPipeline", cache.TxPipeline()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = cache.MDelete(ctx, "pipe:a", "pipe:hash", "pipe:counter")
+
+			tt.pipe.Set("pipe:a", "1", time.Minute)
+			tt.pipe.HSet("pipe:hash", map[string]interface{}{"field": "value"})
+			incr := tt.pipe.Increment("pipe:counter", 3)
+			decr := tt.pipe.Decrement("pipe:counter", 1)
+			if tt.pipe.Len() != 4 {
+				t.Errorf("Expected 4 queued commands, got %d", tt.pipe.Len())
+			}
+
+			if err := tt.pipe.Exec(ctx); err != nil {
+				t.Fatalf("Exec failed: %v", err)
+			}
+			if incr.Val() != 3 || decr.Val() != 2 {
+				t.Errorf("Expected 3/2, got %d/%d", incr.Val(), decr.Val())
+			}
+			if value, _ := cache.Get(ctx, "pipe:a"); value != "1" {
+				t.Errorf("Expected '1', got '%s'", value)
+			}
+			if value, _ := cache.HGet(ctx, "pipe:hash", "field"); value != "value" {
+				t.Errorf("Expected 'value', got '%s'", value)
+			}
+		})
+	}
+
+	t.Run("SerializeError", func(t *testing.T) {
+		pipe := cache.TxPipeline()
+		pipe.Set("pipe:bad", make(chan int), 0)
+		if err := pipe.Exec(ctx); err == nil {
+			t.Error("Expected serialize error")
+		}
+		if exists, _ := cache.Exists(ctx, "pipe:bad"); exists {
+			t.Error("Expected nothing to be written")
+		}
+	})
+}
+
+func TestRedisCache_KeysByPattern(t *testing.T) {
+	cfg := &config.CacheConfig{
+		Driver:   "redis",
+		Host:     "localhost",
+		Port:     6379,
+		Database: 1,
+		Prefix:   "test",
+	}
+	cfg.SetDefaults()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+	cache, err := NewRedisCache(cfg, logger)
+	if err != nil {
+		t.Skipf("Skipping test: failed to connect to Redis: %v", err)
+	}
+	defer cache.Close()
+
+	ctx := context.Background()
+	_, _ = cache.DeleteByPattern(ctx, "scan:*")
+	pairs := make(map[string]interface{})
+	for i := 0; i < 1200; i++ {
+		pairs[fmt.Sprintf("scan:user:%d:profile", i)] = i
+	}
+	pairs["scan:user:1:token"] = "t"
+	if err := cache.MSet(ctx, pairs, time.Minute); err != nil {
+		t.Fatalf("MSet failed: %v", err)
+	}
+
+	keys, err := cache.Keys(ctx, "scan:user:*:profile")
+	if err != nil {
+		t.Fatalf("Keys failed: %v", err)
+	}
+	if len(keys) != 1200 {
+		t.Errorf("Expected 1200 keys, got %d", len(keys))
+	}
+	for _, key := range keys {
+		if key[:5] != "scan:" {
+			t.Errorf("Expected key without prefix, got %s", key)
+			break
+		}
+	}
+
+	deleted, err := cache.DeleteByPattern(ctx, "scan:user:*")
+	if err != nil {
+		t.Fatalf("DeleteByPattern failed: %v", err)
+	}
+	if deleted != 1201 {
+		t.Errorf("Expected 1201 deleted keys, got %d", deleted)
+	}
+	if keys, _ := cache.Keys(ctx, "scan:*"); len(keys) != 0 {
+		t.Errorf("Expected no keys left, got %d", len(keys))
+	}
+}
+
+func TestRedisCache_Stats(t *testing.T) {
+	cfg := &config.CacheConfig{
+		Driver:   "redis",
+		Host:     "localhost",
+		Port:     6379,
+		Database: 1,
+		Prefix:   "test",
+	}
+	cfg.SetDefaults()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+	cache, err := NewRedisCache(cfg, logger)
+	if err != nil {
+		t.Skipf("Skipping test: failed to connect to Redis: %v", err)
+	}
+	defer cache.Close()
+
+	ctx := context.Background()
+	_ = cache.Set(ctx, "stats:a", "1", time.Minute)
+	defer cache.Delete(ctx, "stats:a")
+
+	stats, err := cache.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if stats.Driver != "redis" || stats.Keys < 1 {
+		t.Errorf("Expected redis driver with at least 1 key, got %+v", stats)
+	}
+	if stats.HitRate < 0 || stats.HitRate > 1 {
+		t.Errorf("Expected hit rate in [0, 1], got %v", stats.HitRate)
+	}
+}
+
+func TestParseRedisInfo(t *testing.T) {
+	info := "# Stats\r\nkeyspace_hits:30\r\nkeyspace_misses:10\r\nevicted_keys:2\r\n\r\n# Memory\r\nused_memory:1024\r\n"
+	values := parseRedisInfo(info)
+
+	tests := []struct {
+		key  string
+		want int64
+	}{
+		{"keyspace_hits", 30},
+		{"keyspace_misses", 10},
+		{"evicted_keys", 2},
+		{"used_memory", 1024},
+		{"maxmemory", 0},
+	}
+	for _, tt := range tests {
+		if got := infoInt(values, tt.key); got != tt.want {
+			t.Errorf("infoInt(%q) = %d, want %d", tt.key, got, tt.want)
+		}
+	}
+	if rate := hitRate(30, 10); rate != 0.75 {
+		t.Errorf("Expected hit rate 0.75, got %v", rate)
+	}
+}
@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Stats 缓存统计
+// - 内存驱动统计当前实例，内存占用为估算值
+// - Redis 驱动统计服务端全局数据（来自 INFO 与 DBSIZE，不区分键前缀），集群模式下汇总各主节点
+type Stats struct {
+	Driver      string  `json:"driver"`           // 驱动类型
+	Hits        uint64  `json:"hits"`             // 命中次数
+	Misses      uint64  `json:"misses"`           // 未命中次数
+	HitRate     float64 `json:"hit_rate"`         // 命中率（0~1）
+	Keys        int64   `json:"keys"`             // 键数量
+	Memory      int64   `json:"memory"`           // 内存占用（字节）
+	MaxMemory   int64   `json:"max_memory"`       // 内存上限（字节，0 表示不限制）
+	Evictions   uint64  `json:"evictions"`        // 因超出内存上限淘汰的键数
+	Expirations uint64  `json:"expirations"`      // 因过期删除的键数
+	Shards      int     `json:"shards,omitempty"` // 分片数（仅内存驱动）
+}
+
+// hitRate 计算命中率
+func hitRate(hits, misses uint64) float64 {
+	if hits+misses == 0 {
+		return 0
+	}
+	return float64(hits) / float64(hits+misses)
+}
+
+// parseRedisInfo 解析 INFO 命令输出为键值对
+func parseRedisInfo(info string) map[string]string {
+	values := make(map[string]string)
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, ":"); ok {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+// infoInt 读取 INFO 中的整数字段，缺失或格式错误时返回 0
+func infoInt(values map[string]string, key string) int64 {
+	n, _ := strconv.ParseInt(values[key], 10, 64)
+	return n
+}
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// GetJSON 获取 JSON 值并反序列化为 T（键不存在时返回 ErrKeyNotFound）
+func GetJSON[T any](ctx context.Context, c Cache, key string) (T, error) {
+	var value T
+	data, err := c.Get(ctx, key)
+	if err != nil {
+		return value, err
+	}
+	if err := json.Unmarshal([]byte(data), &value); err != nil {
+		return value, fmt.Errorf("failed to deserialize key %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// SetJSON 将 T 序列化为 JSON 后写入缓存
+func SetJSON[T any](ctx context.Context, c Cache, key string, value T, expiration time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to serialize key %s: %w", key, err)
+	}
+	return c.Set(ctx, key, string(data), expiration)
+}
+
+// GetOrSetJSON 获取 JSON 值，不存在时调用 loader 加载并写入缓存（并发加载合并为一次）
+func GetOrSetJSON[T any](ctx context.Context, c Cache, key string, expiration time.Duration, loader func() (T, error)) (T, error) {
+	var value T
+	data, err := c.GetOrSet(ctx, key, expiration, func() (interface{}, error) {
+		loaded, err := loader()
+		if err != nil {
+			return nil, err
+		}
+		encoded, err := json.Marshal(loaded)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize key %s: %w", key, err)
+		}
+		return string(encoded), nil
+	})
+	if err != nil {
+		return value, err
+	}
+	if err := json.Unmarshal([]byte(data), &value); err != nil {
+		return value, fmt.Errorf("failed to deserialize key %s: %w", key, err)
+	}
+	return value, nil
+}
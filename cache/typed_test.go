@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+/*
+泛型缓存辅助函数测试
+
+本文件用于测试 JSON 泛型辅助函数，
+包括结构体读写、键不存在、反序列化失败、加载合并等。
+
+运行命令：
+go test -v -run "^Test.*JSON.*$"
+
+测试内容：
+1. 结构体读写 (GetJSON, SetJSON)
+2. 错误处理 (ErrKeyNotFound, 非 JSON 值)
+3. 加载写入 (GetOrSetJSON)
+*/
+
+// typedTestProfile 测试结构体
+type typedTestProfile struct {
+	ID    uint     `json:"id"`
+	Name  string   `json:"name"`
+	Roles []string `json:"roles"`
+}
+
+// newTypedTestCache 创建测试用内存缓存
+func newTypedTestCache(t *testing.T) Cache {
+	t.Helper()
+	cfg := &config.CacheConfig{Driver: "memory", CleanupInterval: time.Minute}
+	cfg.SetDefaults()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	c, err := NewMemoryCache(cfg, logger)
+	if err != nil {
+		t.Fatalf("Failed to create memory cache: %v", err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestCacheJSON_GetSet(t *testing.T) {
+	c := newTypedTestCache(t)
+	ctx := context.Background()
+
+	profile := typedTestProfile{ID: 1, Name: "admin", Roles: []string{"root"}}
+	if err := SetJSON(ctx, c, "profile:1", profile, time.Minute); err != nil {
+		t.Fatalf("SetJSON failed: %v", err)
+	}
+
+	got, err := GetJSON[typedTestProfile](ctx, c, "profile:1")
+	if err != nil {
+		t.Fatalf("GetJSON failed: %v", err)
+	}
+	if got.ID != profile.ID || got.Name != profile.Name || len(got.Roles) != 1 {
+		t.Errorf("Expected %+v, got %+v", profile, got)
+	}
+}
+
+func TestCacheJSON_Errors(t *testing.T) {
+	c := newTypedTestCache(t)
+	ctx := context.Background()
+
+	if _, err := GetJSON[typedTestProfile](ctx, c, "missing"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Expected ErrKeyNotFound, got %v", err)
+	}
+
+	if err := c.Set(ctx, "plain", "not json", time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if _, err := GetJSON[typedTestProfile](ctx, c, "plain"); err == nil {
+		t.Error("Expected deserialize error")
+	}
+}
+
+func TestCacheJSON_GetOrSet(t *testing.T) {
+	c := newTypedTestCache(t)
+	ctx := context.Background()
+
+	calls := 0
+	loader := func() (*typedTestProfile, error) {
+		calls++
+		return &typedTestProfile{ID: 2, Name: "editor"}, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		got, err := GetOrSetJSON(ctx, c, "profile:2", time.Minute, loader)
+		if err != nil {
+			t.Fatalf("GetOrSetJSON failed: %v", err)
+		}
+		if got == nil || got.Name != "editor" {
+			t.Errorf("Expected editor, got %+v", got)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected loader to be called once, got %d", calls)
+	}
+}
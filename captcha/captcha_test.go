@@ -0,0 +1,210 @@
+package captcha
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image/png"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/so68/core/cache"
+	"github.com/so68/core/config"
+)
+
+/*
+图形验证码功能测试
+
+本文件用于测试验证码驱动与基于缓存的验证码管理，
+包括数字图片验证码、滑动拼图验证码、一次性校验等。
+
+运行命令：
+go test -v -run "^Test.*(Driver|Manager).*$"
+
+测试内容：
+1. 数字图片验证码生成与校验 (DigitDriver)
+2. 滑动拼图验证码生成与误差校验 (SlideDriver)
+3. 驱动选择 (NewDriver)
+4. 验证码保存、校验与一次性使用 (CacheManager)
+*/
+
+// decodeImage 解码 data:image/png;base64 格式的图片并返回尺寸
+func decodeImage(t *testing.T, data string) (int, int) {
+	t.Helper()
+	raw, ok := strings.CutPrefix(data, "data:image/png;base64,")
+	if !ok {
+		t.Fatalf("unexpected image prefix: %.30s", data)
+	}
+	buf, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil {
+		t.Fatalf("decode base64 failed: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(buf))
+	if err != nil {
+		t.Fatalf("decode png failed: %v", err)
+	}
+	return img.Bounds().Dx(), img.Bounds().Dy()
+}
+
+func TestDigitDriver(t *testing.T) {
+	driver := NewDigitDriver(4, 120, 40)
+	challenge, err := driver.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if len(challenge.Answer) != 4 {
+		t.Fatalf("expected 4 digits, got %q", challenge.Answer)
+	}
+	if _, err := strconv.Atoi(challenge.Answer); err != nil {
+		t.Fatalf("expected numeric answer, got %q", challenge.Answer)
+	}
+	if width, height := decodeImage(t, challenge.Captcha.Image); width != 120 || height != 40 {
+		t.Fatalf("unexpected image size %dx%d", width, height)
+	}
+	if challenge.Captcha.Type != "digit" {
+		t.Fatalf("unexpected type %q", challenge.Captcha.Type)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "正确答案", input: challenge.Answer, want: true},
+		{name: "忽略首尾空白", input: " " + challenge.Answer + " ", want: true},
+		{name: "错误答案", input: challenge.Answer + "0", want: false},
+		{name: "空答案", input: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := driver.Match(challenge.Answer, tt.input); got != tt.want {
+				t.Errorf("Match(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlideDriver(t *testing.T) {
+	driver := NewSlideDriver(300, 150, 5)
+	challenge, err := driver.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	x, err := strconv.Atoi(challenge.Answer)
+	if err != nil {
+		t.Fatalf("expected numeric answer, got %q", challenge.Answer)
+	}
+	if x < slidePieceSize || x > 300-slidePieceSize {
+		t.Fatalf("unexpected slide x %d", x)
+	}
+	if width, height := decodeImage(t, challenge.Captcha.Image); width != 300 || height != 150 {
+		t.Fatalf("unexpected image size %dx%d", width, height)
+	}
+	if width, height := decodeImage(t, challenge.Captcha.Thumb); width != slidePieceSize || height != slidePieceSize {
+		t.Fatalf("unexpected thumb size %dx%d", width, height)
+	}
+	if challenge.Captcha.ThumbY < 0 || challenge.Captcha.ThumbY > 150-slidePieceSize {
+		t.Fatalf("unexpected thumb y %d", challenge.Captcha.ThumbY)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "精确位置", input: strconv.Itoa(x), want: true},
+		{name: "误差范围内", input: strconv.Itoa(x + 5), want: true},
+		{name: "小数坐标", input: strconv.Itoa(x-4) + ".6", want: true},
+		{name: "超出误差", input: strconv.Itoa(x - 6), want: false},
+		{name: "非数字", input: "abc", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := driver.Match(challenge.Answer, tt.input); got != tt.want {
+				t.Errorf("Match(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDriver(t *testing.T) {
+	tests := []struct {
+		driver    string
+		wantError bool
+	}{
+		{driver: "digit"},
+		{driver: "slide"},
+		{driver: "audio", wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			cfg := config.DefaultCaptchaConfig()
+			cfg.Driver = tt.driver
+			_, err := NewDriver(cfg)
+			if (err != nil) != tt.wantError {
+				t.Errorf("NewDriver(%q) error = %v, wantError %v", tt.driver, err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestCacheManager(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cacheConfig := &config.CacheConfig{Driver: "memory", CleanupInterval: time.Minute}
+	cacheConfig.SetDefaults()
+	memory, err := cache.NewMemoryCache(cacheConfig, logger)
+	if err != nil {
+		t.Fatalf("create memory cache failed: %v", err)
+	}
+	defer memory.Close()
+	ctx := context.Background()
+
+	if _, err := NewManager(config.DefaultCaptchaConfig(), nil, logger); err == nil {
+		t.Fatal("expected error without cache")
+	}
+	manager, err := NewManager(config.DefaultCaptchaConfig(), memory, logger)
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+
+	t.Run("正确答案仅可使用一次", func(t *testing.T) {
+		captcha, err := manager.Generate(ctx)
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		answer, err := memory.Get(ctx, cacheKeyPrefix+captcha.ID)
+		if err != nil {
+			t.Fatalf("answer not stored: %v", err)
+		}
+		if !manager.Verify(ctx, captcha.ID, answer) {
+			t.Fatal("expected verify success")
+		}
+		if manager.Verify(ctx, captcha.ID, answer) {
+			t.Fatal("expected captcha to be consumed")
+		}
+	})
+
+	t.Run("错误答案后验证码失效", func(t *testing.T) {
+		captcha, err := manager.Generate(ctx)
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		answer, _ := memory.Get(ctx, cacheKeyPrefix+captcha.ID)
+		if manager.Verify(ctx, captcha.ID, "x") {
+			t.Fatal("expected verify failure")
+		}
+		if manager.Verify(ctx, captcha.ID, answer) {
+			t.Fatal("expected captcha to be consumed after failure")
+		}
+	})
+
+	t.Run("未知验证码", func(t *testing.T) {
+		if manager.Verify(ctx, "unknown", "1234") || manager.Verify(ctx, "", "") {
+			t.Fatal("expected verify failure")
+		}
+	})
+}
@@ -0,0 +1,178 @@
+package captcha
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"math/big"
+	"strings"
+)
+
+// digitFont 5x7 点阵数字字体（每行 5 位，高位在左）
+var digitFont = [10][7]uint8{
+	{0x0e, 0x11, 0x13, 0x15, 0x19, 0x11, 0x0e}, // 0
+	{0x04, 0x0c, 0x04, 0x04, 0x04, 0x04, 0x0e}, // 1
+	{0x0e, 0x11, 0x01, 0x02, 0x04, 0x08, 0x1f}, // 2
+	{0x1f, 0x02, 0x04, 0x02, 0x01, 0x11, 0x0e}, // 3
+	{0x02, 0x06, 0x0a, 0x12, 0x1f, 0x02, 0x02}, // 4
+	{0x1f, 0x10, 0x1e, 0x01, 0x01, 0x11, 0x0e}, // 5
+	{0x06, 0x08, 0x10, 0x1e, 0x11, 0x11, 0x0e}, // 6
+	{0x1f, 0x01, 0x02, 0x04, 0x08, 0x08, 0x08}, // 7
+	{0x0e, 0x11, 0x11, 0x0e, 0x11, 0x11, 0x0e}, // 8
+	{0x0e, 0x11, 0x11, 0x0f, 0x01, 0x02, 0x0c}, // 9
+}
+
+// DigitDriver 数字图片验证码驱动
+type DigitDriver struct {
+	length int // 数字位数
+	width  int // 图片宽度
+	height int // 图片高度
+}
+
+// NewDigitDriver 创建数字图片验证码驱动
+func NewDigitDriver(length int, width int, height int) *DigitDriver {
+	return &DigitDriver{length: length, width: width, height: height}
+}
+
+// Generate 生成数字图片验证码（随机位置、颜色，叠加干扰线与噪点）
+func (d *DigitDriver) Generate() (*Challenge, error) {
+	digits := make([]byte, d.length)
+	for i := range digits {
+		digits[i] = byte(randInt(10))
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, d.width, d.height))
+	fillBackground(img, color.RGBA{R: 240, G: 240, B: 240, A: 255})
+
+	// 每个数字占据相同宽度的格子，在格子内随机偏移
+	cell := d.width / d.length
+	scale := max(min(cell/7, d.height*2/3/7), 1)
+	for i, digit := range digits {
+		x := i*cell + randInt(max(cell-5*scale, 1))
+		y := randInt(max(d.height-7*scale, 1))
+		drawDigit(img, digit, x, y, scale, randomColor(20, 120))
+	}
+
+	// 干扰线与噪点
+	for range d.length {
+		drawLine(img, randInt(d.width), randInt(d.height), randInt(d.width), randInt(d.height), randomColor(80, 180))
+	}
+	for range d.width * d.height / 20 {
+		img.Set(randInt(d.width), randInt(d.height), randomColor(60, 200))
+	}
+
+	data, err := encodePNG(img)
+	if err != nil {
+		return nil, err
+	}
+
+	var answer strings.Builder
+	for _, digit := range digits {
+		answer.WriteByte('0' + digit)
+	}
+	return &Challenge{
+		Captcha: &Captcha{Type: "digit", Image: data, Width: d.width, Height: d.height},
+		Answer:  answer.String(),
+	}, nil
+}
+
+// Match 校验输入（忽略首尾空白）
+func (d *DigitDriver) Match(answer string, input string) bool {
+	return answer == strings.TrimSpace(input)
+}
+
+// drawDigit 按点阵绘制数字
+func drawDigit(img *image.RGBA, digit byte, x int, y int, scale int, c color.Color) {
+	for row, bits := range digitFont[digit] {
+		for col := range 5 {
+			if bits&(1<<(4-col)) == 0 {
+				continue
+			}
+			for dy := range scale {
+				for dx := range scale {
+					img.Set(x+col*scale+dx, y+row*scale+dy, c)
+				}
+			}
+		}
+	}
+}
+
+// drawLine 绘制直线（Bresenham 算法）
+func drawLine(img *image.RGBA, x0 int, y0 int, x1 int, y1 int, c color.Color) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+	for {
+		img.Set(x0, y0, c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// fillBackground 填充背景色
+func fillBackground(img *image.RGBA, c color.RGBA) {
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+}
+
+// encodePNG 编码为 data:image/png;base64 格式
+func encodePNG(img image.Image) (string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// randomColor 生成各通道在 [low, high) 之间的随机颜色
+func randomColor(low int, high int) color.RGBA {
+	return color.RGBA{
+		R: uint8(low + randInt(high-low)),
+		G: uint8(low + randInt(high-low)),
+		B: uint8(low + randInt(high-low)),
+		A: 255,
+	}
+}
+
+// randInt 生成 [0, n) 之间的安全随机数
+func randInt(n int) int {
+	if n <= 1 {
+		return 0
+	}
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0
+	}
+	return int(v.Int64())
+}
+
+// abs 绝对值
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,38 @@
+package captcha
+
+import (
+	"context"
+)
+
+// Captcha 验证码（返回给前端展示，不含答案）
+type Captcha struct {
+	ID     string `json:"id"`                // 验证码ID，校验时回传
+	Type   string `json:"type"`              // 验证码类型: digit, slide
+	Image  string `json:"image"`             // 验证码图片（data:image/png;base64 格式），滑动验证码为带缺口的背景图
+	Thumb  string `json:"thumb,omitempty"`   // 滑块图片（仅滑动验证码）
+	ThumbY int    `json:"thumb_y,omitempty"` // 滑块纵坐标（仅滑动验证码）
+	Width  int    `json:"width"`             // 图片宽度
+	Height int    `json:"height"`            // 图片高度
+}
+
+// Challenge 驱动生成的验证码内容与答案
+type Challenge struct {
+	Captcha *Captcha // 验证码
+	Answer  string   // 答案
+}
+
+// Driver 验证码驱动接口
+type Driver interface {
+	// Generate 生成验证码
+	Generate() (*Challenge, error)
+	// Match 校验用户输入是否与答案匹配
+	Match(answer string, input string) bool
+}
+
+// Manager 验证码管理接口（答案保存在缓存中，校验一次后失效）
+type Manager interface {
+	// Generate 生成验证码
+	Generate(ctx context.Context) (*Captcha, error)
+	// Verify 校验验证码（无论是否通过，验证码均失效）
+	Verify(ctx context.Context, id string, input string) bool
+}
@@ -0,0 +1,82 @@
+package captcha
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+
+	"github.com/so68/core/cache"
+	"github.com/so68/core/config"
+)
+
+// cacheKeyPrefix 验证码答案缓存键前缀
+const cacheKeyPrefix = "captcha:"
+
+// CacheManager 基于缓存的验证码管理
+type CacheManager struct {
+	config *config.CaptchaConfig
+	driver Driver
+	cache  cache.Cache
+	logger *slog.Logger
+}
+
+// NewManager 根据配置创建验证码管理
+func NewManager(cfg *config.CaptchaConfig, cache cache.Cache, logger *slog.Logger) (*CacheManager, error) {
+	if cache == nil {
+		return nil, fmt.Errorf("验证码需要启用缓存")
+	}
+	driver, err := NewDriver(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &CacheManager{config: cfg, driver: driver, cache: cache, logger: logger}, nil
+}
+
+// NewDriver 根据配置创建验证码驱动
+func NewDriver(cfg *config.CaptchaConfig) (Driver, error) {
+	switch cfg.Driver {
+	case "digit":
+		return NewDigitDriver(cfg.Length, cfg.Width, cfg.Height), nil
+	case "slide":
+		return NewSlideDriver(cfg.Width, cfg.Height, cfg.SlideTolerance), nil
+	default:
+		return nil, fmt.Errorf("unsupported captcha driver: %s", cfg.Driver)
+	}
+}
+
+// Generate 生成验证码
+func (m *CacheManager) Generate(ctx context.Context) (*Captcha, error) {
+	challenge, err := m.driver.Generate()
+	if err != nil {
+		return nil, fmt.Errorf("生成验证码失败: %w", err)
+	}
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("生成验证码ID失败: %w", err)
+	}
+	challenge.Captcha.ID = hex.EncodeToString(buf)
+	if err := m.cache.Set(ctx, cacheKeyPrefix+challenge.Captcha.ID, challenge.Answer, m.config.TTL); err != nil {
+		return nil, fmt.Errorf("保存验证码失败: %w", err)
+	}
+	return challenge.Captcha, nil
+}
+
+// Verify 校验验证码
+func (m *CacheManager) Verify(ctx context.Context, id string, input string) bool {
+	if id == "" || input == "" {
+		return false
+	}
+	key := cacheKeyPrefix + id
+	answer, err := m.cache.Get(ctx, key)
+	if err != nil || answer == "" {
+		return false
+	}
+	// 一次性使用，防止针对同一验证码反复尝试
+	if err := m.cache.Delete(ctx, key); err != nil {
+		m.logger.Warn("删除验证码失败", "error", err)
+	}
+	return m.driver.Match(answer, input)
+}
@@ -0,0 +1,109 @@
+package captcha
+
+import (
+	"image"
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// slidePieceSize 滑块边长
+const slidePieceSize = 40
+
+// SlideDriver 滑动拼图验证码驱动（答案为缺口横坐标）
+type SlideDriver struct {
+	width     int // 背景图宽度
+	height    int // 背景图高度
+	tolerance int // 允许的横坐标误差(像素)
+}
+
+// NewSlideDriver 创建滑动拼图验证码驱动
+func NewSlideDriver(width int, height int, tolerance int) *SlideDriver {
+	return &SlideDriver{
+		width:     max(width, slidePieceSize*3),
+		height:    max(height, slidePieceSize+10),
+		tolerance: tolerance,
+	}
+}
+
+// Generate 生成滑动拼图验证码（随机背景，在随机位置挖出缺口并生成对应滑块）
+func (d *SlideDriver) Generate() (*Challenge, error) {
+	background := image.NewRGBA(image.Rect(0, 0, d.width, d.height))
+	d.drawBackground(background)
+
+	// 缺口不与左侧滑块起始位置重叠
+	x := slidePieceSize + 10 + randInt(d.width-slidePieceSize*2-20)
+	y := 5 + randInt(d.height-slidePieceSize-10)
+
+	piece := image.NewRGBA(image.Rect(0, 0, slidePieceSize, slidePieceSize))
+	for py := range slidePieceSize {
+		for px := range slidePieceSize {
+			c := background.RGBAAt(x+px, y+py)
+			if isPieceBorder(px, py) {
+				c = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+			}
+			piece.SetRGBA(px, py, c)
+			// 缺口处变暗
+			background.SetRGBA(x+px, y+py, color.RGBA{R: c.R / 3, G: c.G / 3, B: c.B / 3, A: 255})
+		}
+	}
+
+	image, err := encodePNG(background)
+	if err != nil {
+		return nil, err
+	}
+	thumb, err := encodePNG(piece)
+	if err != nil {
+		return nil, err
+	}
+	return &Challenge{
+		Captcha: &Captcha{Type: "slide", Image: image, Thumb: thumb, ThumbY: y, Width: d.width, Height: d.height},
+		Answer:  strconv.Itoa(x),
+	}, nil
+}
+
+// Match 校验滑块横坐标是否在误差范围内
+func (d *SlideDriver) Match(answer string, input string) bool {
+	expected, err := strconv.Atoi(answer)
+	if err != nil {
+		return false
+	}
+	actual, err := strconv.ParseFloat(strings.TrimSpace(input), 64)
+	if err != nil {
+		return false
+	}
+	return abs(int(actual+0.5)-expected) <= d.tolerance
+}
+
+// drawBackground 绘制随机渐变背景并叠加色块，避免缺口位置可以通过纯色背景直接识别
+func (d *SlideDriver) drawBackground(img *image.RGBA) {
+	from, to := randomColor(60, 200), randomColor(60, 200)
+	for x := range d.width {
+		ratio := float64(x) / float64(d.width)
+		c := color.RGBA{
+			R: uint8(float64(from.R)*(1-ratio) + float64(to.R)*ratio),
+			G: uint8(float64(from.G)*(1-ratio) + float64(to.G)*ratio),
+			B: uint8(float64(from.B)*(1-ratio) + float64(to.B)*ratio),
+			A: 255,
+		}
+		for y := range d.height {
+			img.SetRGBA(x, y, c)
+		}
+	}
+	for range 12 {
+		cx, cy, r := randInt(d.width), randInt(d.height), 8+randInt(24)
+		c := randomColor(40, 230)
+		for y := max(cy-r, 0); y < min(cy+r, d.height); y++ {
+			for x := max(cx-r, 0); x < min(cx+r, d.width); x++ {
+				if (x-cx)*(x-cx)+(y-cy)*(y-cy) <= r*r {
+					img.SetRGBA(x, y, c)
+				}
+			}
+		}
+	}
+}
+
+// isPieceBorder 是否为滑块边框
+func isPieceBorder(x int, y int) bool {
+	return x == 0 || y == 0 || x == slidePieceSize-1 || y == slidePieceSize-1
+}
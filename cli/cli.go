@@ -0,0 +1,150 @@
+// Package cli 旧版内置命令行入口
+//
+// Deprecated: 请使用基于 cobra 的 cmd 包（支持 migrate up/down/status、config validate 与自定义命令），
+// 本包仅保留旧版 API 与命令用法，内部委托给 cmd 包执行。
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/so68/core"
+	"github.com/so68/core/cmd"
+)
+
+// HookFunc 命令钩子，接收已初始化的应用
+//
+// Deprecated: 请使用 cmd.HookFunc
+type HookFunc = cmd.HookFunc
+
+// Option CLI 可选项
+//
+// Deprecated: 请使用 cmd.Option
+type Option = cmd.Option
+
+// CLI 内置命令行入口（兼容旧版命令用法的 cmd.CLI）
+// - -config path 等同于 --config path
+// - rollback 等同于 migrate down，未指定子命令的 migrate 等同于 migrate up
+//
+// Deprecated: 请使用 cmd.CLI
+type CLI struct {
+	*cmd.CLI
+}
+
+// WithConfigPath 指定默认配置文件路径（可被 -config 参数覆盖）
+//
+// Deprecated: 请使用 cmd.WithConfigPath
+func WithConfigPath(path string) Option {
+	return cmd.WithConfigPath(path)
+}
+
+// WithAppOptions 追加构造 Application 的可选项
+//
+// Deprecated: 请使用 cmd.WithAppOptions
+func WithAppOptions(opts ...core.Option) Option {
+	return cmd.WithAppOptions(opts...)
+}
+
+// WithModules 注册业务模块
+//
+// Deprecated: 请使用 cmd.WithModules
+func WithModules(modules ...core.Module) Option {
+	return cmd.WithModules(modules...)
+}
+
+// WithSetup 注册模块/路由钩子
+//
+// Deprecated: 请使用 cmd.WithSetup
+func WithSetup(fn HookFunc) Option {
+	return cmd.WithSetup(fn)
+}
+
+// WithMigrate 注册迁移钩子
+//
+// Deprecated: 请使用 cmd.WithMigrate
+func WithMigrate(fn HookFunc) Option {
+	return cmd.WithMigrate(fn)
+}
+
+// WithRollback 注册回滚钩子
+//
+// Deprecated: 请使用 cmd.WithRollback
+func WithRollback(fn HookFunc) Option {
+	return cmd.WithRollback(fn)
+}
+
+// WithSeed 注册数据填充钩子
+//
+// Deprecated: 请使用 cmd.WithSeed
+func WithSeed(fn HookFunc) Option {
+	return cmd.WithSeed(fn)
+}
+
+// WithOutput 指定标准输出与错误输出
+//
+// Deprecated: 请使用 cmd.WithOutput
+func WithOutput(stdout io.Writer, stderr io.Writer) Option {
+	return cmd.WithOutput(stdout, stderr)
+}
+
+// New 创建命令行入口
+//
+// Deprecated: 请使用 cmd.New
+func New(name string, version string, opts ...Option) *CLI {
+	return &CLI{CLI: cmd.New(name, version, opts...)}
+}
+
+// Execute 解析 os.Args 执行命令，失败时输出错误并以非零状态退出
+func (c *CLI) Execute() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	root := c.Root()
+	root.SetArgs(legacyArgs(os.Args[1:]))
+	err := root.ExecuteContext(ctx)
+	stop()
+	if err != nil {
+		fmt.Fprintln(root.ErrOrStderr(), "error:", err)
+		os.Exit(1)
+	}
+}
+
+// Run 执行命令
+// 用法: <name> [-config path] <command> [args]
+func (c *CLI) Run(ctx context.Context, args []string) error {
+	return c.CLI.Run(ctx, legacyArgs(args))
+}
+
+// legacyArgs 将旧版命令用法转换为 cmd 包的用法
+func legacyArgs(args []string) []string {
+	converted := make([]string, 0, len(args)+1)
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "-config" || strings.HasPrefix(arg, "-config=") {
+			arg = "-" + arg
+		}
+		if strings.HasPrefix(arg, "-") {
+			converted = append(converted, arg)
+			// 参数值与参数名分开传递时原样保留
+			if (arg == "--config" || arg == "-c" || arg == "--env" || arg == "-e") && i+1 < len(args) {
+				i++
+				converted = append(converted, args[i])
+			}
+			continue
+		}
+
+		switch {
+		case arg == "rollback":
+			converted = append(converted, "migrate", "down")
+		case arg == "migrate" && i == len(args)-1:
+			converted = append(converted, "migrate", "up")
+		default:
+			converted = append(converted, arg)
+		}
+		return append(converted, args[i+1:]...)
+	}
+	return converted
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"testing"
+)
+
+/*
+旧版命令行入口兼容测试
+
+本文件用于测试旧版 CLI 的命令用法在委托给 cmd 包后保持可用。
+
+运行命令：
+go test -v -run "^Test.*$"
+
+测试内容：
+1. 旧版命令用法转换 (legacyArgs)
+2. 命令执行 (Run)
+*/
+
+func TestLegacyArgs(t *testing.T) {
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{args: []string{"-config", "./app.yaml", "serve"}, want: []string{"--config", "./app.yaml", "serve"}},
+		{args: []string{"-config=./app.yaml", "version"}, want: []string{"--config=./app.yaml", "version"}},
+		{args: []string{"migrate"}, want: []string{"migrate", "up"}},
+		{args: []string{"migrate", "status"}, want: []string{"migrate", "status"}},
+		{args: []string{"--config", "rollback", "rollback"}, want: []string{"--config", "rollback", "migrate", "down"}},
+		{args: []string{"config", "dump"}, want: []string{"config", "dump"}},
+	}
+	for _, tt := range tests {
+		if got := legacyArgs(tt.args); !slices.Equal(got, tt.want) {
+			t.Errorf("legacyArgs(%v) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestCLIRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  string
+		contains string
+	}{
+		{name: "version", args: []string{"version"}, contains: "app v1.2.3"},
+		{name: "config dump", args: []string{"config", "dump"}, contains: "name: Taozijun Network Technology Co., Ltd."},
+		{name: "unknown command", args: []string{"deploy"}, wantErr: "unknown command"},
+		{name: "migrate without hook", args: []string{"migrate"}, wantErr: "no migrations registered"},
+		{name: "rollback without hook", args: []string{"rollback"}, wantErr: "no rollback registered"},
+		{name: "seed without hook", args: []string{"seed"}, wantErr: "no seed hook registered"},
+		{name: "config flag", args: []string{"-config", "./other.yaml", "version"}, contains: "v1.2.3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			c := New("app", "v1.2.3", WithConfigPath("./not-exists.yaml"), WithOutput(stdout, &bytes.Buffer{}))
+			err := c.Run(context.Background(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			if !strings.Contains(stdout.String(), tt.contains) {
+				t.Errorf("Expected output to contain %q, got %q", tt.contains, stdout.String())
+			}
+		})
+	}
+}
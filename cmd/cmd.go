@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/so68/core"
+	"github.com/so68/core/config"
+	"github.com/so68/core/database/migrate"
+	"github.com/spf13/cobra"
+)
+
+// HookFunc 命令钩子，接收已初始化的应用
+type HookFunc func(ctx context.Context, app *core.Application) error
+
+// MigrationsFunc 注册版本化迁移（migrate up/down/status 使用）
+type MigrationsFunc func(m *migrate.Migrator) error
+
+// Option 命令行可选项
+type Option func(*CLI)
+
+// CLI 基于 cobra 的命令行入口
+// 提供 serve、migrate up/down/status、seed、routes list/openapi、config validate/dump、version 子命令，
+// 业务项目只需注册模块、迁移与钩子，也可追加自定义运维命令
+type CLI struct {
+	name       string
+	version    string
+	configPath string
+	env        string
+	appOptions []core.Option
+	modules    []core.Module    // 业务模块（构造 Application 后注册）
+	setup      HookFunc         // 注册路由等（serve、routes 使用）
+	migrations MigrationsFunc   // 版本化迁移
+	migrate    HookFunc         // 迁移钩子（migrate up 最后执行）
+	rollback   HookFunc         // 回滚钩子（migrate down 使用）
+	seed       HookFunc         // 填充数据
+	commands   []*cobra.Command // 自定义命令
+	stdout     io.Writer
+	stderr     io.Writer
+}
+
+// WithConfigPath 指定默认配置文件路径（可被 --config 参数覆盖）
+func WithConfigPath(path string) Option {
+	return func(c *CLI) { c.configPath = path }
+}
+
+// WithAppOptions 追加构造 Application 的可选项
+func WithAppOptions(opts ...core.Option) Option {
+	return func(c *CLI) { c.appOptions = append(c.appOptions, opts...) }
+}
+
+// WithModules 注册业务模块（serve、routes 按生命周期初始化，migrate up 执行模块迁移）
+func WithModules(modules ...core.Module) Option {
+	return func(c *CLI) { c.modules = append(c.modules, modules...) }
+}
+
+// WithSetup 注册路由钩子（在模块注册之后执行）
+func WithSetup(fn HookFunc) Option {
+	return func(c *CLI) { c.setup = fn }
+}
+
+// WithMigrations 注册版本化迁移（迁移历史记录在 schema_migrations 表）
+func WithMigrations(fn MigrationsFunc) Option {
+	return func(c *CLI) { c.migrations = fn }
+}
+
+// WithMigrate 注册迁移钩子
+func WithMigrate(fn HookFunc) Option {
+	return func(c *CLI) { c.migrate = fn }
+}
+
+// WithRollback 注册回滚钩子
+func WithRollback(fn HookFunc) Option {
+	return func(c *CLI) { c.rollback = fn }
+}
+
+// WithSeed 注册数据填充钩子
+func WithSeed(fn HookFunc) Option {
+	return func(c *CLI) { c.seed = fn }
+}
+
+// WithCommands 追加自定义命令（可通过 App 获取应用实例）
+func WithCommands(commands ...*cobra.Command) Option {
+	return func(c *CLI) { c.commands = append(c.commands, commands...) }
+}
+
+// WithOutput 指定标准输出与错误输出
+func WithOutput(stdout io.Writer, stderr io.Writer) Option {
+	return func(c *CLI) {
+		c.stdout = stdout
+		c.stderr = stderr
+	}
+}
+
+// New 创建命令行入口
+func New(name string, version string, opts ...Option) *CLI {
+	c := &CLI{
+		name:       name,
+		version:    version,
+		configPath: "./config.yaml",
+		stdout:     os.Stdout,
+		stderr:     os.Stderr,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Execute 解析 os.Args 执行命令，失败时输出错误并以非零状态退出
+func (c *CLI) Execute() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := c.Run(ctx, os.Args[1:])
+	stop()
+	if err != nil {
+		fmt.Fprintln(c.stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+// Run 执行命令
+// 用法: <name> [--config path] [--env name] <command> [args]
+func (c *CLI) Run(ctx context.Context, args []string) error {
+	root := c.Root()
+	root.SetArgs(args)
+	return root.ExecuteContext(ctx)
+}
+
+// Root 构建根命令（每次调用返回新的命令树）
+func (c *CLI) Root() *cobra.Command {
+	root := &cobra.Command{
+		Use:           c.name,
+		Short:         c.name + " 命令行工具",
+		Version:       c.version,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	root.SetOut(c.stdout)
+	root.SetErr(c.stderr)
+	root.PersistentFlags().StringVarP(&c.configPath, "config", "c", c.configPath, "配置文件路径")
+	root.PersistentFlags().StringVarP(&c.env, "env", "e", "", "运行环境（叠加 config.{env}.yaml，等同于设置 APP_ENV）")
+	root.PersistentPreRunE = func(command *cobra.Command, args []string) error {
+		if c.env == "" {
+			return nil
+		}
+		return os.Setenv(config.ProfileEnvVar, c.env)
+	}
+
+	root.AddCommand(
+		c.serveCommand(),
+		c.migrateCommand(),
+		c.seedCommand(),
+		c.routesCommand(),
+		c.configCommand(),
+		c.versionCommand(),
+	)
+	for _, command := range c.commands {
+		root.AddCommand(command)
+	}
+	return root
+}
+
+// App 为自定义命令构造应用（已注册模块，调用方负责 Close）
+func (c *CLI) App(opts ...core.Option) (*core.Application, error) {
+	return c.newApplication(opts...)
+}
+
+// newApplication 构造应用（命令指定的可选项追加在用户可选项之后，优先生效）
+func (c *CLI) newApplication(opts ...core.Option) (*core.Application, error) {
+	options := append([]core.Option{}, c.appOptions...)
+	options = append(options, opts...)
+	app, err := core.NewApplication(c.configPath, options...)
+	if err != nil {
+		return nil, err
+	}
+	if err := app.RegisterModule(c.modules...); err != nil {
+		_ = app.Close(context.Background())
+		return nil, err
+	}
+	return app, nil
+}
@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core"
+	"github.com/so68/core/database/migrate"
+	"github.com/so68/core/server"
+	"github.com/so68/core/server/openapi"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+/*
+命令行入口功能测试
+
+本文件用于测试基于 cobra 的CLI结构体的各种功能特性，
+包括命令解析、版本输出、配置校验与导出、迁移与回滚、数据填充、自定义命令等。
+
+运行命令：
+go test -v -run "^Test.*$"
+
+测试内容：
+1. 命令解析 (Run)
+2. 版本输出 (version)
+3. 配置校验与导出 (config validate [--strict], config dump [--show-secrets])
+4. 未注册迁移与钩子 (migrate up/down/status, seed)
+5. 版本化迁移 (migrate up, migrate status, migrate down)
+6. 自定义命令 (WithCommands)
+7. 路由列表与 OpenAPI 文档导出 (routes list --json, routes openapi)
+8. 运行环境配置叠加 (--env)
+*/
+
+// newTestCLI 创建测试用命令行入口
+func newTestCLI(opts ...Option) (*CLI, *bytes.Buffer) {
+	stdout := &bytes.Buffer{}
+	opts = append([]Option{WithConfigPath("./not-exists.yaml"), WithOutput(stdout, &bytes.Buffer{})}, opts...)
+	return New("app", "v1.2.3", opts...), stdout
+}
+
+// writeTestConfig 写入使用 SQLite 的测试配置
+func writeTestConfig(t *testing.T) string {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	content := "database:\n  driver: sqlite\n  database: " + filepath.Join(dir, "test.db") + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestCLIRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantErr  string
+		contains string
+	}{
+		{name: "version", args: []string{"version"}, contains: "app v1.2.3"},
+		{name: "version flag", args: []string{"--version"}, contains: "app version v1.2.3"},
+		{name: "config dump", args: []string{"config", "dump"}, contains: "name: Taozijun Network Technology Co., Ltd."},
+		{name: "config validate missing file", args: []string{"config", "validate"}, wantErr: "config file not found: ./not-exists.yaml"},
+		{name: "unknown command", args: []string{"deploy"}, wantErr: `unknown command "deploy"`},
+		{name: "unexpected argument", args: []string{"version", "extra"}, wantErr: "unknown command"},
+		{name: "migrate up without migrations", args: []string{"migrate", "up"}, wantErr: "no migrations registered"},
+		{name: "migrate down without rollback", args: []string{"migrate", "down"}, wantErr: "no rollback registered"},
+		{name: "migrate status without migrations", args: []string{"migrate", "status"}, wantErr: "no migrations registered"},
+		{name: "seed without hook", args: []string{"seed"}, wantErr: "no seed hook registered"},
+		{name: "config flag", args: []string{"--config", "./other.yaml", "version"}, contains: "v1.2.3"},
+		{name: "help", args: []string{"--help"}, contains: "migrate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, stdout := newTestCLI()
+			err := c.Run(context.Background(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			if !strings.Contains(stdout.String(), tt.contains) {
+				t.Errorf("Expected output to contain %q, got %q", tt.contains, stdout.String())
+			}
+		})
+	}
+}
+
+func TestCLIConfigDumpRedacted(t *testing.T) {
+	c, stdout := newTestCLI()
+	if err := c.Run(context.Background(), []string{"config", "dump"}); err != nil {
+		t.Fatalf("config dump failed: %v", err)
+	}
+	if strings.Contains(stdout.String(), "not-secret-key") || !strings.Contains(stdout.String(), "secretKey: '******'") {
+		t.Errorf("Expected secrets to be redacted, got %q", stdout.String())
+	}
+
+	c, stdout = newTestCLI()
+	if err := c.Run(context.Background(), []string{"config", "dump", "--show-secrets"}); err != nil {
+		t.Fatalf("config dump --show-secrets failed: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "secretKey: not-secret-key") {
+		t.Errorf("Expected secrets to be shown, got %q", stdout.String())
+	}
+}
+
+func TestCLIConfigValidate(t *testing.T) {
+	c, stdout := newTestCLI(WithConfigPath(writeTestConfig(t)))
+	if err := c.Run(context.Background(), []string{"config", "validate"}); err != nil {
+		t.Fatalf("config validate failed: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "is valid") {
+		t.Errorf("Unexpected output: %q", stdout.String())
+	}
+
+	// 严格模式拒绝未知配置项
+	path := writeTestConfig(t)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("cahce:\n  host: localhost\n")
+	_ = f.Close()
+	c, _ = newTestCLI(WithConfigPath(path))
+	if err := c.Run(context.Background(), []string{"config", "validate"}); err != nil {
+		t.Fatalf("config validate without --strict failed: %v", err)
+	}
+	c, _ = newTestCLI(WithConfigPath(path))
+	err = c.Run(context.Background(), []string{"config", "validate", "--strict"})
+	if err == nil || !strings.Contains(err.Error(), "cahce: 未知的配置项") {
+		t.Errorf("Expected unknown key error, got %v", err)
+	}
+}
+
+func TestCLIMigrate(t *testing.T) {
+	configPath := writeTestConfig(t)
+	migrations := WithMigrations(func(m *migrate.Migrator) error {
+		return m.Add(&migrate.Migration{
+			Version: "20240101000000",
+			Name:    "create_posts",
+			Up:      func(tx *gorm.DB) error { return tx.Exec("CREATE TABLE posts (id INTEGER PRIMARY KEY)").Error },
+			Down:    func(tx *gorm.DB) error { return tx.Exec("DROP TABLE posts").Error },
+		})
+	})
+	var hooked bool
+	hook := WithMigrate(func(ctx context.Context, app *core.Application) error {
+		hooked = true
+		return nil
+	})
+	ctx := context.Background()
+
+	c, stdout := newTestCLI(WithConfigPath(configPath), migrations, hook)
+	if err := c.Run(ctx, []string{"migrate", "up"}); err != nil {
+		t.Fatalf("migrate up failed: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "migrated 20240101000000") || !hooked {
+		t.Errorf("Unexpected migrate up result: %q, hooked=%v", stdout.String(), hooked)
+	}
+
+	c, stdout = newTestCLI(WithConfigPath(configPath), migrations)
+	if err := c.Run(ctx, []string{"migrate", "status"}); err != nil {
+		t.Fatalf("migrate status failed: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "create_posts") || !strings.Contains(stdout.String(), "applied") {
+		t.Errorf("Unexpected migrate status output: %q", stdout.String())
+	}
+
+	c, stdout = newTestCLI(WithConfigPath(configPath), migrations)
+	if err := c.Run(ctx, []string{"migrate", "down", "--steps", "1"}); err != nil {
+		t.Fatalf("migrate down failed: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "rolled back 20240101000000") {
+		t.Errorf("Unexpected migrate down output: %q", stdout.String())
+	}
+}
+
+func TestCLISeedAndCustomCommand(t *testing.T) {
+	configPath := writeTestConfig(t)
+	var seeded bool
+	c, stdout := newTestCLI(WithConfigPath(configPath), WithSeed(func(ctx context.Context, app *core.Application) error {
+		seeded = app.DB != nil
+		return nil
+	}))
+	if err := c.Run(context.Background(), []string{"seed"}); err != nil {
+		t.Fatalf("seed failed: %v", err)
+	}
+	if !seeded || !strings.Contains(stdout.String(), "seed completed") {
+		t.Errorf("Unexpected seed result: %q, seeded=%v", stdout.String(), seeded)
+	}
+
+	var cli *CLI
+	cleanup := &cobra.Command{
+		Use: "cleanup",
+		RunE: func(command *cobra.Command, args []string) error {
+			app, err := cli.App(core.WithoutServer())
+			if err != nil {
+				return err
+			}
+			defer app.Close(context.Background())
+			command.Println("cleaned", app.Config.Name != "")
+			return nil
+		},
+	}
+	cli, stdout = newTestCLI(WithConfigPath(configPath), WithCommands(cleanup))
+	if err := cli.Run(context.Background(), []string{"cleanup"}); err != nil {
+		t.Fatalf("custom command failed: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "cleaned true") {
+		t.Errorf("Unexpected custom command output: %q", stdout.String())
+	}
+}
+
+func TestCLIRoutesList(t *testing.T) {
+	setup := WithSetup(func(ctx context.Context, app *core.Application) error {
+		group := app.Server.NewGroup("/api")
+		app.Server.Register(group, server.RouterHandler{Name: "文章列表", Method: server.RouterMethodGet, Path: "/posts", Handler: func(c *gin.Context) {}, Doc: server.Doc(nil, []string{})})
+		app.Server.Describe("GET", "/api/posts", server.RouteMeta{Name: "文章列表", Permission: "文章列表"})
+		return nil
+	})
+	c, stdout := newTestCLI(WithConfigPath(writeTestConfig(t)), setup)
+	if err := c.Run(context.Background(), []string{"routes", "list", "--json"}); err != nil {
+		t.Fatalf("routes list failed: %v", err)
+	}
+	var routes []server.RouteInfo
+	if err := json.Unmarshal(stdout.Bytes(), &routes); err != nil {
+		t.Fatalf("Failed to decode routes: %v, output=%q", err, stdout.String())
+	}
+	var found bool
+	for _, route := range routes {
+		if route.Method == "GET" && route.Path == "/api/posts" {
+			found = route.Name == "文章列表" && route.Permission == "文章列表"
+		}
+	}
+	if !found {
+		t.Errorf("Expected /api/posts with name and permission, got %+v", routes)
+	}
+
+	c, stdout = newTestCLI(WithConfigPath(writeTestConfig(t)), setup)
+	if err := c.Run(context.Background(), []string{"routes", "openapi"}); err != nil {
+		t.Fatalf("routes openapi failed: %v", err)
+	}
+	var doc openapi.Document
+	if err := json.Unmarshal(stdout.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to decode openapi document: %v, output=%q", err, stdout.String())
+	}
+	item, ok := doc.Paths["/api/posts"]
+	if !ok || (*item)["get"] == nil || (*item)["get"].Summary != "文章列表" || len((*item)["get"].Security) == 0 {
+		t.Errorf("Expected secured /api/posts operation, got %+v", doc.Paths)
+	}
+}
+
+func TestCLIEnvProfile(t *testing.T) {
+	t.Setenv("APP_ENV", "")
+	configPath := writeTestConfig(t)
+	profile := filepath.Join(filepath.Dir(configPath), "config.prod.yaml")
+	if err := os.WriteFile(profile, []byte("name: prod-app\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write profile: %v", err)
+	}
+
+	c, stdout := newTestCLI(WithConfigPath(configPath))
+	if err := c.Run(context.Background(), []string{"--env", "prod", "config", "dump"}); err != nil {
+		t.Fatalf("config dump failed: %v", err)
+	}
+	if out := stdout.String(); !strings.Contains(out, "name: prod-app") || !strings.Contains(out, "env: prod") {
+		t.Errorf("Expected prod profile in dump, got:\n%s", out)
+	}
+}
@@ -0,0 +1,356 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/so68/core"
+	"github.com/so68/core/config"
+	"github.com/so68/core/database/migrate"
+	"github.com/so68/core/server"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+// serveCommand 启动服务
+func (c *CLI) serveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve",
+		Short: "启动服务（收到 SIGINT/SIGTERM 时优雅关闭）",
+		Args:  cobra.NoArgs,
+		RunE: func(command *cobra.Command, args []string) error {
+			ctx := command.Context()
+			app, err := c.newApplication()
+			if err != nil {
+				return err
+			}
+			if c.setup != nil {
+				if err := c.setup(ctx, app); err != nil {
+					_ = app.Close(context.Background())
+					return fmt.Errorf("setup: %w", err)
+				}
+			}
+			return app.Run(ctx)
+		},
+	}
+}
+
+// migrateCommand 数据库迁移
+func (c *CLI) migrateCommand() *cobra.Command {
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "数据库迁移: migrate up|down|status",
+	}
+
+	up := &cobra.Command{
+		Use:   "up",
+		Short: "执行模块迁移、未执行的版本化迁移与迁移钩子",
+		Args:  cobra.NoArgs,
+		RunE: func(command *cobra.Command, args []string) error {
+			if len(c.modules) == 0 && c.migrations == nil && c.migrate == nil {
+				return errors.New("no migrations registered")
+			}
+			return c.withDatabase(command.Context(), func(ctx context.Context, app *core.Application) error {
+				if err := app.MigrateModules(); err != nil {
+					return err
+				}
+				if c.migrations != nil {
+					migrator, err := c.migrator(app)
+					if err != nil {
+						return err
+					}
+					versions, err := migrator.MigrateUp(ctx)
+					if err != nil {
+						return err
+					}
+					for _, version := range versions {
+						fmt.Fprintf(command.OutOrStdout(), "migrated %s\n", version)
+					}
+				}
+				if c.migrate != nil {
+					if err := c.migrate(ctx, app); err != nil {
+						return err
+					}
+				}
+				fmt.Fprintln(command.OutOrStdout(), "migrate completed")
+				return nil
+			})
+		},
+	}
+
+	var steps int
+	down := &cobra.Command{
+		Use:   "down",
+		Short: "回滚最近的版本化迁移并执行回滚钩子",
+		Args:  cobra.NoArgs,
+		RunE: func(command *cobra.Command, args []string) error {
+			if c.migrations == nil && c.rollback == nil {
+				return errors.New("no rollback registered")
+			}
+			return c.withDatabase(command.Context(), func(ctx context.Context, app *core.Application) error {
+				if c.migrations != nil {
+					migrator, err := c.migrator(app)
+					if err != nil {
+						return err
+					}
+					versions, err := migrator.MigrateDown(ctx, steps)
+					if err != nil && !errors.Is(err, migrate.ErrNoMigrations) {
+						return err
+					}
+					for _, version := range versions {
+						fmt.Fprintf(command.OutOrStdout(), "rolled back %s\n", version)
+					}
+				}
+				if c.rollback != nil {
+					if err := c.rollback(ctx, app); err != nil {
+						return err
+					}
+				}
+				fmt.Fprintln(command.OutOrStdout(), "rollback completed")
+				return nil
+			})
+		},
+	}
+	down.Flags().IntVar(&steps, "steps", 1, "回滚的迁移数量")
+
+	status := &cobra.Command{
+		Use:   "status",
+		Short: "查看版本化迁移状态",
+		Args:  cobra.NoArgs,
+		RunE: func(command *cobra.Command, args []string) error {
+			if c.migrations == nil {
+				return errors.New("no migrations registered")
+			}
+			return c.withDatabase(command.Context(), func(ctx context.Context, app *core.Application) error {
+				migrator, err := c.migrator(app)
+				if err != nil {
+					return err
+				}
+				statuses, err := migrator.Status(ctx)
+				if err != nil {
+					return err
+				}
+				w := tabwriter.NewWriter(command.OutOrStdout(), 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tBATCH")
+				for _, s := range statuses {
+					state := "pending"
+					if s.Missing {
+						state = "missing"
+					} else if s.Applied {
+						state = "applied"
+					}
+					fmt.Fprintf(w, "%s\t%s\t%s\t%d\n", s.Version, s.Name, state, s.Batch)
+				}
+				return w.Flush()
+			})
+		},
+	}
+
+	migrateCmd.AddCommand(up, down, status)
+	return migrateCmd
+}
+
+// seedCommand 填充初始数据
+func (c *CLI) seedCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "seed",
+		Short: "填充初始数据",
+		Args:  cobra.NoArgs,
+		RunE: func(command *cobra.Command, args []string) error {
+			if c.seed == nil {
+				return errors.New("no seed hook registered")
+			}
+			return c.withDatabase(command.Context(), func(ctx context.Context, app *core.Application) error {
+				if err := c.seed(ctx, app); err != nil {
+					return err
+				}
+				fmt.Fprintln(command.OutOrStdout(), "seed completed")
+				return nil
+			})
+		},
+	}
+}
+
+// routesCommand 路由工具
+func (c *CLI) routesCommand() *cobra.Command {
+	routesCmd := &cobra.Command{
+		Use:   "routes",
+		Short: "路由工具: routes list|openapi",
+	}
+	var asJSON bool
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "列出已注册的路由（含名称与所需权限）",
+		Args:  cobra.NoArgs,
+		RunE: func(command *cobra.Command, args []string) error {
+			return c.withRoutes(command.Context(), func(app *core.Application) error {
+				return c.printRoutes(command, app.Server.Routes(), asJSON)
+			})
+		},
+	}
+	list.Flags().BoolVar(&asJSON, "json", false, "以 JSON 格式输出")
+
+	spec := &cobra.Command{
+		Use:   "openapi",
+		Short: "导出 OpenAPI 文档（JSON）",
+		Args:  cobra.NoArgs,
+		RunE: func(command *cobra.Command, args []string) error {
+			return c.withRoutes(command.Context(), func(app *core.Application) error {
+				encoder := json.NewEncoder(command.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(app.Server.OpenAPI())
+			})
+		},
+	}
+
+	routesCmd.AddCommand(list, spec)
+	return routesCmd
+}
+
+// withRoutes 构造应用并注册模块路由后执行（routes 使用）
+func (c *CLI) withRoutes(ctx context.Context, fn func(app *core.Application) error) (err error) {
+	app, err := c.newApplication(core.WithoutQueue(), core.WithoutMailer())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := app.Close(context.Background()); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	if c.setup != nil {
+		if err := c.setup(ctx, app); err != nil {
+			return fmt.Errorf("setup: %w", err)
+		}
+	}
+	if err := app.InitModules(); err != nil {
+		return fmt.Errorf("init modules: %w", err)
+	}
+	return fn(app)
+}
+
+// printRoutes 按路径与方法排序输出路由
+func (c *CLI) printRoutes(command *cobra.Command, routes []server.RouteInfo, asJSON bool) error {
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path == routes[j].Path {
+			return routes[i].Method < routes[j].Method
+		}
+		return routes[i].Path < routes[j].Path
+	})
+	if asJSON {
+		encoder := json.NewEncoder(command.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(routes)
+	}
+	w := tabwriter.NewWriter(command.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "METHOD\tPATH\tNAME\tPERMISSION\tHANDLER")
+	for _, route := range routes {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", route.Method, route.Path, route.Name, route.Permission, route.Handler)
+	}
+	return w.Flush()
+}
+
+// configCommand 配置工具
+func (c *CLI) configCommand() *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "配置工具: config validate|dump",
+	}
+	var strict bool
+	validate := &cobra.Command{
+		Use:   "validate",
+		Short: "校验配置文件（列出全部错误）",
+		Args:  cobra.NoArgs,
+		RunE: func(command *cobra.Command, args []string) error {
+			if _, err := os.Stat(c.configPath); err != nil {
+				return fmt.Errorf("config file not found: %s", c.configPath)
+			}
+			var opts []config.LoadOption
+			if strict {
+				opts = append(opts, config.WithStrict())
+			}
+			cfg, err := config.LoadConfigWithEnv(c.configPath, opts...)
+			if err != nil {
+				return err
+			}
+			if err := config.ValidateConfig(cfg); err != nil {
+				return err
+			}
+			fmt.Fprintf(command.OutOrStdout(), "%s is valid\n", c.configPath)
+			return nil
+		},
+	}
+	validate.Flags().BoolVar(&strict, "strict", false, "存在未知配置项时校验失败")
+
+	var showSecrets bool
+	dump := &cobra.Command{
+		Use:   "dump",
+		Short: "输出生效配置（默认脱敏密码、密钥等敏感字段）",
+		Args:  cobra.NoArgs,
+		RunE: func(command *cobra.Command, args []string) error {
+			cfg, err := config.LoadConfigWithEnv(c.configPath)
+			if err != nil {
+				return err
+			}
+			if !showSecrets {
+				cfg = cfg.Redacted()
+			}
+			data, err := yaml.Marshal(cfg)
+			if err != nil {
+				return fmt.Errorf("marshal config: %w", err)
+			}
+			_, err = command.OutOrStdout().Write(data)
+			return err
+		},
+	}
+	dump.Flags().BoolVar(&showSecrets, "show-secrets", false, "输出未脱敏的敏感字段")
+
+	configCmd.AddCommand(validate, dump)
+	return configCmd
+}
+
+// versionCommand 输出版本信息
+func (c *CLI) versionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "输出版本信息",
+		Args:  cobra.NoArgs,
+		RunE: func(command *cobra.Command, args []string) error {
+			fmt.Fprintf(command.OutOrStdout(), "%s %s (%s %s/%s)\n", c.name, c.version, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+			return nil
+		},
+	}
+}
+
+// withDatabase 构造仅含数据库与缓存的应用并执行（migrate、seed 使用）
+func (c *CLI) withDatabase(ctx context.Context, fn HookFunc) (err error) {
+	app, err := c.newApplication(core.WithoutServer(), core.WithoutQueue(), core.WithoutMailer())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := app.Close(context.Background()); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+	if app.DB == nil {
+		return errors.New("database is not configured")
+	}
+	return fn(ctx, app)
+}
+
+// migrator 创建版本化迁移执行器
+func (c *CLI) migrator(app *core.Application) (*migrate.Migrator, error) {
+	migrator := migrate.New(app.DB.DB(), app.Logger)
+	if err := c.migrations(migrator); err != nil {
+		return nil, fmt.Errorf("register migrations: %w", err)
+	}
+	return migrator, nil
+}
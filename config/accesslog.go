@@ -0,0 +1,33 @@
+package config
+
+import (
+	"time"
+)
+
+// AccessLogConfig 访问日志配置
+type AccessLogConfig struct {
+	Enabled       bool          `yaml:"enabled"`       // 是否启用（调试模式下始终启用）
+	SampleRate    float64       `yaml:"sampleRate"`    // 正常请求采样率 0-1（4xx/5xx 与慢请求始终记录）
+	SlowThreshold time.Duration `yaml:"slowThreshold"` // 慢请求阈值（为 0 时不区分慢请求）
+	ExcludePaths  []string      `yaml:"excludePaths"`  // 排除的路径前缀（例如健康检查、指标接口）
+}
+
+// DefaultAccessLogConfig 返回默认访问日志配置
+func DefaultAccessLogConfig() *AccessLogConfig {
+	return &AccessLogConfig{
+		Enabled:       true,
+		SampleRate:    1,
+		SlowThreshold: time.Second,
+		ExcludePaths:  []string{},
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *AccessLogConfig) SetDefaults() {
+	if c.SampleRate == 0 {
+		c.SampleRate = 1
+	}
+	if c.ExcludePaths == nil {
+		c.ExcludePaths = []string{}
+	}
+}
@@ -13,6 +13,7 @@ type AppConfig struct {
 	Port   int    `yaml:"port"`   // 服务端口
 	Debug  bool   `yaml:"debug"`  // 是否开启调试模式
 	Static string `yaml:"static"` // 静态文件目录（例如：static 或 public）
+	Env    string `yaml:"env"`    // 运行环境（例如 dev、test、prod，加载时叠加 config.{env}.yaml，可由 APP_ENV 指定）
 
 	// 超时配置
 	ReadTimeout  string `yaml:"readTimeout"`  // 读取超时时间
@@ -20,23 +21,119 @@ type AppConfig struct {
 	IdleTimeout  string `yaml:"idleTimeout"`  // 空闲超时时间
 	MaxHeader    int64  `yaml:"maxHeader"`    // 最大请求头大小(bytes)
 
+	// 优雅关闭配置
+	ShutdownTimeout string `yaml:"shutdownTimeout"` // 优雅关闭宽限期，超时后强制释放资源
+
+	// HTTPS 配置
+	TLS *TLSConfig `yaml:"tls"`
+
 	// Cors配置
 	Cors *CorsConfig `yaml:"cors"`
 
 	// 限流配置
 	RateLimit *RateLimitConfig `yaml:"rateLimit"`
 
+	// 访问日志配置
+	AccessLog *AccessLogConfig `yaml:"accessLog"`
+
+	// 后台操作审计日志配置
+	Audit *AuditConfig `yaml:"audit"`
+
+	// 管理员密码策略配置
+	PasswordPolicy *PasswordPolicyConfig `yaml:"passwordPolicy"`
+
+	// 管理员登录安全配置
+	Security *SecurityConfig `yaml:"security"`
+
+	// 管理员登录图形验证码配置
+	Captcha *CaptchaConfig `yaml:"captcha"`
+
+	// 管理员找回密码配置
+	PasswordReset *PasswordResetConfig `yaml:"passwordReset"`
+
+	// 管理员第三方登录配置（OAuth2/OIDC）
+	Auth *AuthConfig `yaml:"auth"`
+
+	// 开放接口请求签名配置
+	Signature *SignatureConfig `yaml:"signature"`
+
+	// 多租户配置
+	Tenant *TenantConfig `yaml:"tenant"`
+
 	// JWT配置
 	JWT *JWTConfig `yaml:"jwt"`
 
 	// 日志配置
 	Logger *logger.Config `yaml:"logger"`
 
+	// 远程日志输出配置
+	LogSinks *LogSinksConfig `yaml:"logSinks"`
+
+	// 日志脱敏配置
+	LogMask *LogMaskConfig `yaml:"logMask"`
+
 	// 缓存配置
 	Cache *CacheConfig `yaml:"cache"`
 
 	// 数据库配置
 	Database *DatabaseConfig `yaml:"database"`
+
+	// 命名数据库配置（主库之外的其他数据库，例如报表库、租户库，名称 main 保留给主库）
+	Databases map[string]*DatabaseConfig `yaml:"databases"`
+
+	// 邮件配置
+	Mailer *MailerConfig `yaml:"mailer"`
+
+	// 短信配置
+	SMS *SMSConfig `yaml:"sms"`
+
+	// 文件存储配置
+	Storage *StorageConfig `yaml:"storage"`
+
+	// 文件上传配置
+	Upload *UploadConfig `yaml:"upload"`
+
+	// 任务队列配置
+	Queue *QueueConfig `yaml:"queue"`
+
+	// 消息队列配置（Kafka/NSQ）
+	MQ *MQConfig `yaml:"mq"`
+
+	// 事件总线配置
+	Event *EventConfig `yaml:"event"`
+
+	// 多语言配置
+	I18n *I18nConfig `yaml:"i18n"`
+
+	// 统一响应格式配置
+	Response *ResponseConfig `yaml:"response"`
+
+	// 链路追踪配置
+	Telemetry *TelemetryConfig `yaml:"telemetry"`
+
+	// 指标配置
+	Metrics *MetricsConfig `yaml:"metrics"`
+
+	// gRPC 服务配置
+	GRPC *GRPCConfig `yaml:"grpc"`
+
+	// 健康检查接口配置
+	Health *HealthConfig `yaml:"health"`
+
+	// OpenAPI 文档配置
+	OpenAPI *OpenAPIConfig `yaml:"openapi"`
+
+	// 内嵌前端配置
+	UI *UIConfig `yaml:"ui"`
+
+	// HTML 模板配置
+	Template *TemplateConfig `yaml:"template"`
+
+	// 外部密钥配置
+	Secret *SecretConfig `yaml:"secret"`
+
+	// 远程配置中心
+	Remote *RemoteConfig `yaml:"remote"`
 }
 
 // CorsConfig Cors配置
@@ -44,26 +141,51 @@ type CorsConfig struct {
 	AllowOrigins     []string `yaml:"allowOrigins"`     // 允许的跨域请求源
 	AllowMethods     []string `yaml:"allowMethods"`     // 允许的请求方法
 	AllowHeaders     []string `yaml:"allowHeaders"`     // 允许的请求头
-	ExposeHeaders    []string `yaml:"expose_headers"`   // 暴露的响应头
+	ExposeHeaders    []string `yaml:"exposeHeaders"`    // 暴露的响应头
 	AllowCredentials bool     `yaml:"allowCredentials"` // 是否允许携带凭证
 	MaxAge           int      `yaml:"maxAge"`           // 预检请求的缓存时间
 }
 
 // JWTConfig JWT配置
 type JWTConfig struct {
-	EnableSingle bool   `yaml:"enableSingle"` // 是否启用单点登录(同一个用户只能在一个设备上登录)
-	ExpiresIn    int    `yaml:"expiresIn"`    // JWT 过期时间(秒)
-	SecretKey    string `yaml:"secretKey"`    // JWT 密钥
+	EnableSingle     bool   `yaml:"enableSingle"`     // 是否启用单点登录(同一个用户只能在一个设备上登录)
+	ExpiresIn        int    `yaml:"expiresIn"`        // JWT 过期时间(秒)
+	SecretKey        string `yaml:"secretKey"`        // JWT 密钥
+	RefreshExpiresIn int    `yaml:"refreshExpiresIn"` // 刷新令牌过期时间(秒)
+	RefreshSecretKey string `yaml:"refreshSecretKey"` // 刷新令牌密钥(为空时由 JWT 密钥派生)
+
+	Algorithm string         `yaml:"algorithm"` // 签名算法: HS256, RS256, ES256
+	Keys      []JWTKeyConfig `yaml:"keys"`      // 签名密钥(第一个用于签发，其余仅用于验证；为空时使用 secretKey 以 HS256 签发)
+	JWKSPath  string         `yaml:"jwksPath"`  // JWKS 公钥发布路径(使用 RS256/ES256 时生效)
+
+	JWKSURL      string        `yaml:"jwksUrl"`      // 外部身份提供方 JWKS 地址(为空时不信任外部 Token)
+	JWKSCacheTTL time.Duration `yaml:"jwksCacheTtl"` // 外部 JWKS 缓存时间
+	Issuer       string        `yaml:"issuer"`       // 外部 Token 签发者(iss)，为空时不校验
+	Audience     string        `yaml:"audience"`     // 外部 Token 受众(aud)，为空时不校验
+}
+
+// JWTKeyConfig JWT 签名密钥配置（密钥内容与文件二选一）
+type JWTKeyConfig struct {
+	ID             string `yaml:"id"`             // 密钥ID(写入 Token 头部 kid)
+	Secret         string `yaml:"secret"`         // HS256 密钥
+	PrivateKey     string `yaml:"privateKey"`     // PEM 格式私钥
+	PrivateKeyFile string `yaml:"privateKeyFile"` // PEM 格式私钥文件
+	PublicKey      string `yaml:"publicKey"`      // PEM 格式公钥（为空时由私钥推导）
+	PublicKeyFile  string `yaml:"publicKeyFile"`  // PEM 格式公钥文件
 }
 
 // RateLimitConfig 限流配置
 type RateLimitConfig struct {
-	Rate         int      `yaml:"rate"`         // 每秒请求数限制
-	Burst        int      `yaml:"burst"`        // 突发请求数限制
-	IncludePaths []string `yaml:"includePaths"` // 包含限流的路径
-	ExcludePaths []string `yaml:"excludePaths"` // 排除限流的路径
+	Rate         int           `yaml:"rate"`         // 每秒请求数限制
+	Burst        int           `yaml:"burst"`        // 突发请求数限制
+	IncludePaths []string      `yaml:"includePaths"` // 包含限流的路径
+	ExcludePaths []string      `yaml:"excludePaths"` // 排除限流的路径
+	IdleTTL      time.Duration `yaml:"idleTtl"`      // 空闲限流器过期时间（超过后回收该 IP 的限流器）
 }
 
+// DefaultJWTSecretKey 默认 JWT 密钥（仅用于开发，管理员模块拒绝以该密钥启动）
+const DefaultJWTSecretKey = "not-secret-key"
+
 // DefaultAppConfig 返回默认应用配置
 func DefaultAppConfig() *AppConfig {
 	return &AppConfig{
@@ -77,27 +199,65 @@ func DefaultAppConfig() *AppConfig {
 		IdleTimeout:  "60s",
 		MaxHeader:    1 << 20, // 1MB
 
+		ShutdownTimeout: "30s",
+
+		TLS: DefaultTLSConfig(),
+
 		Cors: &CorsConfig{
 			AllowOrigins:     []string{"*"},
 			AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-			AllowHeaders:     []string{"Authorization", "Content-Type"},
+			AllowHeaders:     []string{"Authorization", "Content-Type", "X-MFA-Code"},
 			AllowCredentials: false,
 			MaxAge:           600, // 10 minutes
 		},
 
 		JWT: &JWTConfig{
-			ExpiresIn: 3600, // 1 hour
-			SecretKey: "not-secret-key",
+			ExpiresIn:        3600, // 1 hour
+			SecretKey:        DefaultJWTSecretKey,
+			RefreshExpiresIn: 604800, // 7 days
+			Algorithm:        "HS256",
+			JWKSPath:         "/.well-known/jwks.json",
+			JWKSCacheTTL:     time.Hour,
 		},
+		AccessLog:      DefaultAccessLogConfig(),
+		Audit:          DefaultAuditConfig(),
+		PasswordPolicy: DefaultPasswordPolicyConfig(),
+		Security:       DefaultSecurityConfig(),
+		Captcha:        DefaultCaptchaConfig(),
+		PasswordReset:  DefaultPasswordResetConfig(),
+		Auth:           DefaultAuthConfig(),
+		Signature:      DefaultSignatureConfig(),
+		Tenant:         DefaultTenantConfig(),
 		RateLimit: &RateLimitConfig{
 			Rate:         100,
 			Burst:        200,
 			IncludePaths: []string{},
 			ExcludePaths: []string{},
+			IdleTTL:      10 * time.Minute,
 		},
-		Logger:   logger.DefaultConfig(),
-		Cache:    DefaultCacheConfig(),
-		Database: DefaultDatabaseConfig(),
+		Logger:    logger.DefaultConfig(),
+		Cache:     DefaultCacheConfig(),
+		Database:  DefaultDatabaseConfig(),
+		Mailer:    DefaultMailerConfig(),
+		SMS:       DefaultSMSConfig(),
+		Storage:   DefaultStorageConfig(),
+		Upload:    DefaultUploadConfig(),
+		Queue:     DefaultQueueConfig(),
+		MQ:        DefaultMQConfig(),
+		Event:     DefaultEventConfig(),
+		I18n:      DefaultI18nConfig(),
+		Response:  DefaultResponseConfig(),
+		Telemetry: DefaultTelemetryConfig(),
+		Metrics:   DefaultMetricsConfig(),
+		GRPC:      DefaultGRPCConfig(),
+		Health:    DefaultHealthConfig(),
+		OpenAPI:   DefaultOpenAPIConfig(),
+		UI:        DefaultUIConfig(),
+		Template:  DefaultTemplateConfig(),
+		Secret:    DefaultSecretConfig(),
+		Remote:    DefaultRemoteConfig(),
+		LogSinks:  DefaultLogSinksConfig(),
+		LogMask:   DefaultLogMaskConfig(),
 	}
 }
 
@@ -128,6 +288,16 @@ func (c *AppConfig) SetDefaults() {
 	if c.MaxHeader == 0 {
 		c.MaxHeader = 1 << 20 // 1MB
 	}
+	if c.ShutdownTimeout == "" {
+		c.ShutdownTimeout = "30s"
+	}
+
+	// HTTPS 配置
+	if c.TLS != nil {
+		c.TLS.SetDefaults()
+	} else {
+		c.TLS = DefaultTLSConfig()
+	}
 
 	// Cors 配置
 	if c.Cors == nil {
@@ -154,7 +324,26 @@ func (c *AppConfig) SetDefaults() {
 		c.JWT.ExpiresIn = 3600 // 1 hour
 	}
 	if c.JWT.SecretKey == "" {
-		c.JWT.SecretKey = "not-secret-key"
+		c.JWT.SecretKey = DefaultJWTSecretKey
+	}
+	if c.JWT.RefreshExpiresIn == 0 {
+		c.JWT.RefreshExpiresIn = 604800 // 7 days
+	}
+	if c.JWT.Algorithm == "" {
+		c.JWT.Algorithm = "HS256"
+	}
+	if c.JWT.JWKSPath == "" {
+		c.JWT.JWKSPath = "/.well-known/jwks.json"
+	}
+	if c.JWT.JWKSCacheTTL == 0 {
+		c.JWT.JWKSCacheTTL = time.Hour
+	}
+
+	// AccessLog
+	if c.AccessLog != nil {
+		c.AccessLog.SetDefaults()
+	} else {
+		c.AccessLog = DefaultAccessLogConfig()
 	}
 
 	// RateLimit
@@ -173,6 +362,9 @@ func (c *AppConfig) SetDefaults() {
 	if c.RateLimit.ExcludePaths == nil {
 		c.RateLimit.ExcludePaths = []string{}
 	}
+	if c.RateLimit.IdleTTL == 0 {
+		c.RateLimit.IdleTTL = 10 * time.Minute
+	}
 
 	// 子配置默认值
 	if c.Cache != nil {
@@ -185,6 +377,149 @@ func (c *AppConfig) SetDefaults() {
 	} else {
 		c.Database = DefaultDatabaseConfig()
 	}
+	for _, db := range c.Databases {
+		if db != nil {
+			db.SetDefaults()
+		}
+	}
+	if c.Mailer != nil {
+		c.Mailer.SetDefaults()
+	} else {
+		c.Mailer = DefaultMailerConfig()
+	}
+	if c.SMS != nil {
+		c.SMS.SetDefaults()
+	} else {
+		c.SMS = DefaultSMSConfig()
+	}
+	if c.Storage != nil {
+		c.Storage.SetDefaults()
+	} else {
+		c.Storage = DefaultStorageConfig()
+	}
+	if c.Upload != nil {
+		c.Upload.SetDefaults()
+	} else {
+		c.Upload = DefaultUploadConfig()
+	}
+	if c.Queue != nil {
+		c.Queue.SetDefaults()
+	} else {
+		c.Queue = DefaultQueueConfig()
+	}
+	if c.MQ != nil {
+		c.MQ.SetDefaults()
+	} else {
+		c.MQ = DefaultMQConfig()
+	}
+	if c.Event != nil {
+		c.Event.SetDefaults()
+	} else {
+		c.Event = DefaultEventConfig()
+	}
+	if c.I18n != nil {
+		c.I18n.SetDefaults()
+	} else {
+		c.I18n = DefaultI18nConfig()
+	}
+	if c.Response != nil {
+		c.Response.SetDefaults()
+	} else {
+		c.Response = DefaultResponseConfig()
+	}
+	if c.Telemetry != nil {
+		c.Telemetry.SetDefaults()
+	} else {
+		c.Telemetry = DefaultTelemetryConfig()
+	}
+	if c.Metrics != nil {
+		c.Metrics.SetDefaults()
+	} else {
+		c.Metrics = DefaultMetricsConfig()
+	}
+	if c.GRPC != nil {
+		c.GRPC.SetDefaults()
+	} else {
+		c.GRPC = DefaultGRPCConfig()
+	}
+	if c.Health != nil {
+		c.Health.SetDefaults()
+	} else {
+		c.Health = DefaultHealthConfig()
+	}
+	if c.OpenAPI != nil {
+		c.OpenAPI.SetDefaults()
+	} else {
+		c.OpenAPI = DefaultOpenAPIConfig()
+	}
+	if c.UI != nil {
+		c.UI.SetDefaults()
+	} else {
+		c.UI = DefaultUIConfig()
+	}
+	if c.Template == nil {
+		c.Template = DefaultTemplateConfig()
+	}
+	if c.Secret != nil {
+		c.Secret.SetDefaults()
+	} else {
+		c.Secret = DefaultSecretConfig()
+	}
+	if c.Remote != nil {
+		c.Remote.SetDefaults()
+	} else {
+		c.Remote = DefaultRemoteConfig()
+	}
+	if c.LogSinks != nil {
+		c.LogSinks.SetDefaults()
+	} else {
+		c.LogSinks = DefaultLogSinksConfig()
+	}
+	if c.LogMask != nil {
+		c.LogMask.SetDefaults()
+	} else {
+		c.LogMask = DefaultLogMaskConfig()
+	}
+	if c.Audit != nil {
+		c.Audit.SetDefaults()
+	} else {
+		c.Audit = DefaultAuditConfig()
+	}
+	if c.PasswordPolicy != nil {
+		c.PasswordPolicy.SetDefaults()
+	} else {
+		c.PasswordPolicy = DefaultPasswordPolicyConfig()
+	}
+	if c.Security != nil {
+		c.Security.SetDefaults()
+	} else {
+		c.Security = DefaultSecurityConfig()
+	}
+	if c.Captcha != nil {
+		c.Captcha.SetDefaults()
+	} else {
+		c.Captcha = DefaultCaptchaConfig()
+	}
+	if c.PasswordReset != nil {
+		c.PasswordReset.SetDefaults()
+	} else {
+		c.PasswordReset = DefaultPasswordResetConfig()
+	}
+	if c.Auth != nil {
+		c.Auth.SetDefaults()
+	} else {
+		c.Auth = DefaultAuthConfig()
+	}
+	if c.Signature != nil {
+		c.Signature.SetDefaults()
+	} else {
+		c.Signature = DefaultSignatureConfig()
+	}
+	if c.Tenant != nil {
+		c.Tenant.SetDefaults()
+	} else {
+		c.Tenant = DefaultTenantConfig()
+	}
 	if c.Logger != nil {
 		c.Logger.SetDefaults()
 	} else {
@@ -195,6 +530,11 @@ func (c *AppConfig) SetDefaults() {
 	if !c.Debug {
 		c.Logger.Level = logger.LevelInfo
 		c.Database.LogLevel = "warn"
+		for _, db := range c.Databases {
+			if db != nil {
+				db.LogLevel = "warn"
+			}
+		}
 		c.Cors.MaxAge = 86400 // 24 hours
 	}
 }
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"time"
+)
+
+// AuditConfig 后台操作审计日志配置
+type AuditConfig struct {
+	Enabled         bool          `yaml:"enabled"`         // 是否启用（记录已认证管理员的写操作）
+	RetentionDays   int           `yaml:"retentionDays"`   // 保留天数（为 0 时永久保留）
+	CleanupInterval time.Duration `yaml:"cleanupInterval"` // 过期日志清理间隔
+	MaxParamsSize   int           `yaml:"maxParamsSize"`   // 记录的请求参数最大字节数（超出部分截断）
+}
+
+// DefaultAuditConfig 返回默认审计日志配置
+func DefaultAuditConfig() *AuditConfig {
+	return &AuditConfig{
+		Enabled:         true,
+		RetentionDays:   90,
+		CleanupInterval: 24 * time.Hour,
+		MaxParamsSize:   4096,
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *AuditConfig) SetDefaults() {
+	if c.CleanupInterval == 0 {
+		c.CleanupInterval = 24 * time.Hour
+	}
+	if c.MaxParamsSize == 0 {
+		c.MaxParamsSize = 4096
+	}
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"time"
+)
+
+// AuthConfig 管理员第三方登录配置（OAuth2/OIDC）
+type AuthConfig struct {
+	Enabled       bool                           `yaml:"enabled"`       // 是否启用（需要启用缓存）
+	StateTTL      time.Duration                  `yaml:"stateTtl"`      // 登录授权 state 有效期
+	Timeout       time.Duration                  `yaml:"timeout"`       // 请求第三方接口超时
+	AutoProvision bool                           `yaml:"autoProvision"` // 首次登录且未匹配到管理员时自动创建管理员
+	DefaultRole   string                         `yaml:"defaultRole"`   // 自动创建管理员的角色
+	DefaultType   int8                           `yaml:"defaultType"`   // 自动创建管理员的层级: 2 商户管理员, 3 代理管理员（不能为超级管理员，为空时为代理管理员）
+	Providers     map[string]*AuthProviderConfig `yaml:"providers"`     // 登录方式，键为登录方式名称（例如 google、github、wecom）
+}
+
+// AuthProviderConfig 第三方登录方式配置
+type AuthProviderConfig struct {
+	Driver         string   `yaml:"driver"`         // 驱动: google, github, wecom（企业微信）, oidc（通用 OIDC）
+	ClientID       string   `yaml:"clientId"`       // 客户端ID（企业微信为 CorpID）
+	ClientSecret   string   `yaml:"clientSecret"`   // 客户端密钥（企业微信为应用 Secret）
+	AgentID        string   `yaml:"agentId"`        // 企业微信应用 AgentID
+	RedirectURL    string   `yaml:"redirectUrl"`    // 授权回调地址
+	Scopes         []string `yaml:"scopes"`         // 授权范围（为空时使用驱动默认值）
+	Issuer         string   `yaml:"issuer"`         // OIDC 签发方，未配置接口地址时通过 /.well-known/openid-configuration 发现
+	AuthURL        string   `yaml:"authUrl"`        // 授权地址（为空时使用驱动默认地址）
+	TokenURL       string   `yaml:"tokenUrl"`       // 令牌地址（为空时使用驱动默认地址）
+	UserInfoURL    string   `yaml:"userInfoUrl"`    // 用户信息地址（为空时使用驱动默认地址）
+	Endpoint       string   `yaml:"endpoint"`       // 接口地址（GitHub、企业微信 API 地址，为空时使用默认地址）
+	AllowedDomains []string `yaml:"allowedDomains"` // 仅允许指定邮箱域名的账号登录（为空时不限制）
+	LinkByEmail    bool     `yaml:"linkByEmail"`    // 未绑定时按已验证的邮箱关联已有管理员（不关联超级管理员，须确认第三方平台的邮箱可信）
+}
+
+// DefaultAuthConfig 返回默认第三方登录配置
+func DefaultAuthConfig() *AuthConfig {
+	return &AuthConfig{
+		Enabled:     false,
+		StateTTL:    10 * time.Minute,
+		Timeout:     10 * time.Second,
+		DefaultType: 3,
+		Providers:   map[string]*AuthProviderConfig{},
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *AuthConfig) SetDefaults() {
+	if c.StateTTL == 0 {
+		c.StateTTL = 10 * time.Minute
+	}
+	if c.Timeout == 0 {
+		c.Timeout = 10 * time.Second
+	}
+	if c.DefaultType == 0 {
+		c.DefaultType = 3
+	}
+	if c.Providers == nil {
+		c.Providers = map[string]*AuthProviderConfig{}
+	}
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -9,12 +10,18 @@ type CacheConfig struct {
 	Driver string `yaml:"driver"` // 缓存驱动: redis, memory
 
 	// Redis 配置
+	Mode     string `yaml:"mode"`     // 部署模式: standalone, cluster, sentinel
 	Host     string `yaml:"host"`     // Redis 主机
 	Port     int    `yaml:"port"`     // Redis 端口
 	Password string `yaml:"password"` // Redis 密码
 	Database int    `yaml:"database"` // Redis 数据库编号
 	Prefix   string `yaml:"prefix"`   // 键前缀
 
+	// 集群/哨兵配置
+	Addrs            []string `yaml:"addrs"`            // 集群节点或哨兵地址（为空时使用 host:port）
+	MasterName       string   `yaml:"masterName"`       // 哨兵模式主节点名称
+	SentinelPassword string   `yaml:"sentinelPassword"` // 哨兵密码
+
 	// 连接池配置
 	MaxRetries      int           `yaml:"maxRetries"`      // 最大重试次数
 	MinRetryBackoff time.Duration `yaml:"minRetryBackoff"` // 最小重试间隔
@@ -32,12 +39,14 @@ type CacheConfig struct {
 	// 内存缓存配置
 	MaxMemory       int64         `yaml:"maxMemory"`       // 最大内存使用量（字节）
 	CleanupInterval time.Duration `yaml:"cleanupInterval"` // 清理间隔
+	Shards          int           `yaml:"shards"`          // 分片数（每个分片独立加锁）
 }
 
 // DefaultCacheConfig 返回默认缓存配置
 func DefaultCacheConfig() *CacheConfig {
 	return &CacheConfig{
 		Driver:   "redis",
+		Mode:     "standalone",
 		Host:     "localhost",
 		Port:     6379,
 		Database: 0,
@@ -58,6 +67,7 @@ func DefaultCacheConfig() *CacheConfig {
 
 		MaxMemory:       100 * 1024 * 1024, // 100MB
 		CleanupInterval: 10 * time.Minute,
+		Shards:          32,
 	}
 }
 
@@ -66,6 +76,9 @@ func (c *CacheConfig) SetDefaults() {
 	if c.Driver == "" {
 		c.Driver = "redis"
 	}
+	if c.Mode == "" {
+		c.Mode = "standalone"
+	}
 	if c.Host == "" {
 		c.Host = "localhost"
 	}
@@ -117,4 +130,15 @@ func (c *CacheConfig) SetDefaults() {
 	if c.CleanupInterval == 0 {
 		c.CleanupInterval = 10 * time.Minute
 	}
+	if c.Shards == 0 {
+		c.Shards = 32
+	}
+}
+
+// RedisAddrs 获取 Redis 节点地址（集群节点或哨兵地址）
+func (c *CacheConfig) RedisAddrs() []string {
+	if len(c.Addrs) > 0 {
+		return c.Addrs
+	}
+	return []string{fmt.Sprintf("%s:%d", c.Host, c.Port)}
 }
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"time"
+)
+
+// CaptchaConfig 管理员登录图形验证码配置
+type CaptchaConfig struct {
+	Enabled         bool          `yaml:"enabled"`         // 是否启用（需要启用缓存）
+	Driver          string        `yaml:"driver"`          // 验证码类型: digit(数字图片), slide(滑动拼图)
+	Length          int           `yaml:"length"`          // 数字位数（仅 digit）
+	Width           int           `yaml:"width"`           // 图片宽度
+	Height          int           `yaml:"height"`          // 图片高度
+	TTL             time.Duration `yaml:"ttl"`             // 验证码有效期
+	FailedThreshold int           `yaml:"failedThreshold"` // 同一IP或账号登录失败次数达到该值后要求验证码（为 0 时始终要求）
+	FailureWindow   time.Duration `yaml:"failureWindow"`   // 登录失败次数统计窗口
+	SlideTolerance  int           `yaml:"slideTolerance"`  // 滑块横坐标允许误差(像素)（仅 slide）
+}
+
+// DefaultCaptchaConfig 返回默认图形验证码配置
+func DefaultCaptchaConfig() *CaptchaConfig {
+	return &CaptchaConfig{
+		Enabled:         false,
+		Driver:          "digit",
+		Length:          4,
+		Width:           120,
+		Height:          40,
+		TTL:             5 * time.Minute,
+		FailedThreshold: 3,
+		FailureWindow:   time.Hour,
+		SlideTolerance:  5,
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *CaptchaConfig) SetDefaults() {
+	if c.Driver == "" {
+		c.Driver = "digit"
+	}
+	if c.Length == 0 {
+		c.Length = 4
+	}
+	if c.Width == 0 {
+		c.Width = 120
+	}
+	if c.Height == 0 {
+		c.Height = 40
+	}
+	if c.TTL == 0 {
+		c.TTL = 5 * time.Minute
+	}
+	if c.FailureWindow == 0 {
+		c.FailureWindow = time.Hour
+	}
+	if c.SlideTolerance == 0 {
+		c.SlideTolerance = 5
+	}
+}
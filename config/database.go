@@ -2,17 +2,21 @@ package config
 
 import (
 	"strconv"
+	"strings"
 	"time"
 )
 
+// MainDatabase 主库名称
+const MainDatabase = "main"
+
 // DatabaseConfig 数据库配置
 type DatabaseConfig struct {
-	Driver   string `yaml:"driver"`   // 数据库驱动: mysql, postgres
+	Driver   string `yaml:"driver"`   // 数据库驱动: mysql, postgres, sqlite
 	Host     string `yaml:"host"`     // 数据库主机
 	Port     int    `yaml:"port"`     // 数据库端口
 	Username string `yaml:"username"` // 用户名
 	Password string `yaml:"password"` // 密码
-	Database string `yaml:"database"` // 数据库名
+	Database string `yaml:"database"` // 数据库名（sqlite 为文件路径，:memory: 为内存库）
 	Charset  string `yaml:"charset"`  // 字符集
 	Timezone string `yaml:"timezone"` // 时区
 	SSLMode  string `yaml:"sslMode"`  // SSL模式 (postgres)
@@ -23,11 +27,35 @@ type DatabaseConfig struct {
 	ConnMaxLifetime time.Duration `yaml:"connMaxLifetime"` // 连接最大生存时间
 	ConnMaxIdleTime time.Duration `yaml:"connMaxIdleTime"` // 连接最大空闲时间
 
+	// 延迟连接：启动时数据库不可用不阻止应用启动（适用于基础设施维护期间），后台定期重连
+	Lazy              bool          `yaml:"lazy"`              // 启动时连接失败是否继续启动（健康检查显示为降级，sqlite 不支持）
+	ReconnectInterval time.Duration `yaml:"reconnectInterval"` // 延迟连接模式下后台检测连接的间隔
+
 	// GORM 配置
 	LogLevel                                 string        `yaml:"logLevel"`                                 // 日志级别: silent, error, warn, info
 	SlowThreshold                            time.Duration `yaml:"slowThreshold"`                            // 慢查询阈值
 	PrepareStmt                              bool          `yaml:"prepareStmt"`                              // 是否预编译语句
 	DisableForeignKeyConstraintWhenMigrating bool          `yaml:"disableForeignKeyConstraintWhenMigrating"` // 迁移时是否禁用外键约束
+
+	// 查询超时：避免失控的查询长期占用连接与请求协程
+	QueryTimeout  time.Duration `yaml:"queryTimeout"`  // 单条 SQL 执行超时（0 表示不限制），超时后查询被取消并返回 context.DeadlineExceeded
+	DriverTimeout bool          `yaml:"driverTimeout"` // 同时设置驱动层超时（MySQL readTimeout/writeTimeout，PostgreSQL statement_timeout），QueryTimeout 大于 0 时生效
+
+	// SQL 日志脱敏：避免参数中的密码、手机号等敏感信息写入日志
+	LogPlaceholders bool     `yaml:"logPlaceholders"` // 日志中的 SQL 保留占位符，不插入参数值
+	LogMaskColumns  []string `yaml:"logMaskColumns"`  // 参数值在日志中替换为 ****** 的列（例如 password_hash，忽略大小写）
+
+	// 读写分离配置：配置从库后读操作路由到从库，写操作与事务使用主库
+	Replicas []*DatabaseReplicaConfig `yaml:"replicas"`
+}
+
+// DatabaseReplicaConfig 从库配置（未配置的字段沿用主库配置）
+type DatabaseReplicaConfig struct {
+	Host     string `yaml:"host"`     // 数据库主机
+	Port     int    `yaml:"port"`     // 数据库端口
+	Username string `yaml:"username"` // 用户名
+	Password string `yaml:"password"` // 密码
+	Database string `yaml:"database"` // 数据库名（sqlite 为文件路径）
 }
 
 // DefaultDatabaseConfig 返回默认数据库配置
@@ -48,6 +76,8 @@ func DefaultDatabaseConfig() *DatabaseConfig {
 		ConnMaxLifetime: time.Hour,
 		ConnMaxIdleTime: time.Minute * 10,
 
+		ReconnectInterval: 5 * time.Second,
+
 		LogLevel:                                 "info",
 		SlowThreshold:                            time.Second,
 		PrepareStmt:                              true,
@@ -62,6 +92,8 @@ func (c *DatabaseConfig) GetDSN() string {
 		return c.getMySQLDSN()
 	case "postgres":
 		return c.getPostgresDSN()
+	case "sqlite":
+		return c.getSQLiteDSN()
 	default:
 		return ""
 	}
@@ -76,6 +108,10 @@ func (c *DatabaseConfig) getMySQLDSN() string {
 		dsn += "&parseTime=True&loc=" + c.Timezone
 	}
 
+	if c.DriverTimeout && c.QueryTimeout > 0 {
+		dsn += "&readTimeout=" + c.QueryTimeout.String() + "&writeTimeout=" + c.QueryTimeout.String()
+	}
+
 	return dsn
 }
 
@@ -89,9 +125,59 @@ func (c *DatabaseConfig) getPostgresDSN() string {
 		dsn += " timezone=" + c.Timezone
 	}
 
+	if c.DriverTimeout && c.QueryTimeout > 0 {
+		dsn += " statement_timeout=" + strconv.FormatInt(c.QueryTimeout.Milliseconds(), 10)
+	}
+
 	return dsn
 }
 
+// getSQLiteDSN 获取 SQLite 连接字符串（默认启用外键约束并设置忙等待超时）
+func (c *DatabaseConfig) getSQLiteDSN() string {
+	dsn := c.Database
+	if dsn == "" {
+		dsn = ":memory:"
+	}
+	if strings.Contains(dsn, "?") {
+		return dsn
+	}
+	return dsn + "?_foreign_keys=on&_busy_timeout=5000"
+}
+
+// IsSQLiteMemory 是否为 SQLite 内存库
+func (c *DatabaseConfig) IsSQLiteMemory() bool {
+	return c.Driver == "sqlite" && (c.Database == "" || strings.Contains(c.Database, ":memory:") || strings.Contains(c.Database, "mode=memory"))
+}
+
+// ReplicaConfigs 获取从库的完整配置（未配置的字段沿用主库配置）
+func (c *DatabaseConfig) ReplicaConfigs() []*DatabaseConfig {
+	configs := make([]*DatabaseConfig, 0, len(c.Replicas))
+	for _, replica := range c.Replicas {
+		if replica == nil {
+			continue
+		}
+		cfg := *c
+		cfg.Replicas = nil
+		if replica.Host != "" {
+			cfg.Host = replica.Host
+		}
+		if replica.Port != 0 {
+			cfg.Port = replica.Port
+		}
+		if replica.Username != "" {
+			cfg.Username = replica.Username
+		}
+		if replica.Password != "" {
+			cfg.Password = replica.Password
+		}
+		if replica.Database != "" {
+			cfg.Database = replica.Database
+		}
+		configs = append(configs, &cfg)
+	}
+	return configs
+}
+
 // SetDefaults 设置默认配置值
 func (c *DatabaseConfig) SetDefaults() {
 	if c.Charset == "" {
@@ -115,6 +201,9 @@ func (c *DatabaseConfig) SetDefaults() {
 	if c.ConnMaxIdleTime == 0 {
 		c.ConnMaxIdleTime = time.Minute * 10
 	}
+	if c.ReconnectInterval == 0 {
+		c.ReconnectInterval = 5 * time.Second
+	}
 	if c.LogLevel == "" {
 		c.LogLevel = "info"
 	}
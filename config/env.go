@@ -0,0 +1,291 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// EnvPrefix 环境变量覆盖配置的前缀
+const EnvPrefix = "APP"
+
+// ApplyEnv 按结构体标签将环境变量覆盖到配置（target 为结构体指针）
+// - 变量名由前缀与各级 yaml 标签（驼峰转大写下划线）组成，例如 jwt.secretKey 对应 APP_JWT_SECRET_KEY
+// - env 标签可指定名称（env:"-" 表示不允许覆盖）
+// - 列表使用逗号分隔（APP_CORS_ALLOW_ORIGINS=https://a.com,https://b.com），
+// 字符串映射使用 key=value 逗号分隔；结构体列表与映射按已有的下标或键覆盖（APP_DATABASES_REPORT_HOST）
+// - 时长支持 30s、5m 等格式，纯数字按秒处理；值为空的变量视为未设置
+func ApplyEnv(prefix string, target interface{}) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return errors.New("环境变量覆盖的目标必须是非空指针")
+	}
+	_, err := applyEnvValue(v.Elem(), prefix)
+	return err
+}
+
+// applyEnvValue 递归覆盖配置值，返回是否有字段被覆盖
+func applyEnvValue(v reflect.Value, name string) (bool, error) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.Type().Elem().Kind() != reflect.Struct {
+			return applyEnvScalar(v, name)
+		}
+		// 未初始化的配置段在存在对应环境变量时创建
+		elem := v
+		if v.IsNil() {
+			if !hasEnvPrefix(name + "_") {
+				return false, nil
+			}
+			elem = reflect.New(v.Type().Elem())
+		}
+		changed, err := applyEnvValue(elem.Elem(), name)
+		if changed && v.IsNil() {
+			v.Set(elem)
+		}
+		return changed, err
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(time.Time{}) {
+			return applyEnvScalar(v, name)
+		}
+		changed := false
+		var errs []error
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			segment := envSegment(field)
+			if segment == "" {
+				continue
+			}
+			// 未指定名称的匿名嵌入字段展开到外层
+			fieldName := name + "_" + segment
+			if yamlName, _, _ := strings.Cut(field.Tag.Get("yaml"), ","); field.Anonymous && yamlName == "" && field.Tag.Get("env") == "" {
+				fieldName = name
+			}
+			c, err := applyEnvValue(v.Field(i), fieldName)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			changed = changed || c
+		}
+		return changed, errors.Join(errs...)
+	case reflect.Slice:
+		if isStructElem(v.Type().Elem()) {
+			changed := false
+			var errs []error
+			for i := 0; i < v.Len(); i++ {
+				c, err := applyEnvValue(v.Index(i), name+"_"+strconv.Itoa(i))
+				if err != nil {
+					errs = append(errs, err)
+				}
+				changed = changed || c
+			}
+			return changed, errors.Join(errs...)
+		}
+		return applyEnvScalar(v, name)
+	case reflect.Map:
+		if isStructElem(v.Type().Elem()) {
+			changed := false
+			var errs []error
+			iter := v.MapRange()
+			for iter.Next() {
+				elem := reflect.New(v.Type().Elem()).Elem()
+				elem.Set(iter.Value())
+				c, err := applyEnvValue(elem, name+"_"+envName(fmt.Sprint(iter.Key().Interface())))
+				if err != nil {
+					errs = append(errs, err)
+				}
+				if c {
+					v.SetMapIndex(iter.Key(), elem)
+				}
+				changed = changed || c
+			}
+			return changed, errors.Join(errs...)
+		}
+		return applyEnvScalar(v, name)
+	default:
+		return applyEnvScalar(v, name)
+	}
+}
+
+// applyEnvScalar 解析环境变量并设置基础类型的值
+func applyEnvScalar(v reflect.Value, name string) (bool, error) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return false, nil
+	}
+	if err := setEnvValue(v, raw); err != nil {
+		return false, fmt.Errorf("环境变量 %s 的值无效: %w", name, err)
+	}
+	return true, nil
+}
+
+// setEnvValue 按类型转换并设置值
+func setEnvValue(v reflect.Value, raw string) error {
+	if v.Kind() == reflect.Pointer {
+		elem := reflect.New(v.Type().Elem())
+		if err := setEnvValue(elem.Elem(), raw); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := parseEnvDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	if v.Type() == reflect.TypeOf(time.Time{}) {
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		parts := splitEnvList(raw)
+		slice := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setEnvValue(slice.Index(i), part); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("不支持的映射类型 %s", v.Type())
+		}
+		m := reflect.MakeMap(v.Type())
+		for _, part := range splitEnvList(raw) {
+			key, value, ok := strings.Cut(part, "=")
+			if !ok {
+				return fmt.Errorf("映射项 %q 缺少 =", part)
+			}
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := setEnvValue(elem, strings.TrimSpace(value)); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(strings.TrimSpace(key)).Convert(v.Type().Key()), elem)
+		}
+		v.Set(m)
+	default:
+		return fmt.Errorf("不支持的类型 %s", v.Type())
+	}
+	return nil
+}
+
+// parseEnvDuration 解析时长（纯数字按秒处理）
+func parseEnvDuration(raw string) (time.Duration, error) {
+	if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return time.Duration(n) * time.Second, nil
+	}
+	return time.ParseDuration(raw)
+}
+
+// splitEnvList 按逗号拆分列表（去除空白与空项）
+func splitEnvList(raw string) []string {
+	var parts []string
+	for _, part := range strings.Split(raw, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// envSegment 字段对应的变量名片段（env 标签优先，其次 yaml 标签，"-" 或不允许覆盖时返回空）
+func envSegment(field reflect.StructField) string {
+	if tag, ok := field.Tag.Lookup("env"); ok {
+		if tag == "-" {
+			return ""
+		}
+		return tag
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		name = field.Name
+	}
+	return envName(name)
+}
+
+// envName 将驼峰名称转换为大写下划线格式（secretKey → SECRET_KEY，cacheTTL → CACHE_TTL）
+func envName(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if r == '-' || r == '.' || r == ' ' {
+			b.WriteByte('_')
+			continue
+		}
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+// isStructElem 元素是否为结构体（或结构体指针）
+func isStructElem(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t != reflect.TypeOf(time.Time{})
+}
+
+// hasEnvPrefix 是否存在以 prefix 开头且值不为空的环境变量
+func hasEnvPrefix(prefix string) bool {
+	for _, kv := range os.Environ() {
+		if name, value, _ := strings.Cut(kv, "="); strings.HasPrefix(name, prefix) && value != "" {
+			return true
+		}
+	}
+	return false
+}
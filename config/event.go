@@ -0,0 +1,31 @@
+package config
+
+// EventConfig 事件总线配置
+type EventConfig struct {
+	Workers     int    `yaml:"workers"`     // 异步分发 worker 数量
+	BufferSize  int    `yaml:"bufferSize"`  // 事件缓冲队列大小
+	Distributed bool   `yaml:"distributed"` // 是否通过缓存发布订阅在多个节点间分发事件（Redis 驱动跨进程，内存驱动仅进程内）
+	Channel     string `yaml:"channel"`     // 分发事件使用的发布订阅频道
+}
+
+// DefaultEventConfig 返回默认事件总线配置
+func DefaultEventConfig() *EventConfig {
+	return &EventConfig{
+		Workers:    4,
+		BufferSize: 1024,
+		Channel:    "events",
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *EventConfig) SetDefaults() {
+	if c.Workers == 0 {
+		c.Workers = 4
+	}
+	if c.BufferSize == 0 {
+		c.BufferSize = 1024
+	}
+	if c.Channel == "" {
+		c.Channel = "events"
+	}
+}
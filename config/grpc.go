@@ -0,0 +1,50 @@
+package config
+
+import (
+	"time"
+)
+
+// GRPCConfig gRPC 服务配置
+type GRPCConfig struct {
+	Enabled bool   `yaml:"enabled"` // 是否启用
+	Host    string `yaml:"host"`    // 监听地址（为空时监听所有地址）
+	Port    int    `yaml:"port"`    // 监听端口
+
+	// TLS 配置
+	TLS      bool   `yaml:"tls"`      // 是否启用 TLS
+	CertFile string `yaml:"certFile"` // 证书文件
+	KeyFile  string `yaml:"keyFile"`  // 私钥文件
+
+	MaxRecvMsgSize    int           `yaml:"maxRecvMsgSize"`    // 最大接收消息大小（字节）
+	MaxSendMsgSize    int           `yaml:"maxSendMsgSize"`    // 最大发送消息大小（字节）
+	ConnectionTimeout time.Duration `yaml:"connectionTimeout"` // 连接建立（含 TLS 握手）超时
+	Reflection        bool          `yaml:"reflection"`        // 是否启用服务反射（便于 grpcurl 等工具调试）
+}
+
+// DefaultGRPCConfig 返回默认 gRPC 服务配置
+func DefaultGRPCConfig() *GRPCConfig {
+	return &GRPCConfig{
+		Enabled: false,
+		Port:    9090,
+
+		MaxRecvMsgSize:    4 * 1024 * 1024,
+		MaxSendMsgSize:    4 * 1024 * 1024,
+		ConnectionTimeout: 120 * time.Second,
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *GRPCConfig) SetDefaults() {
+	if c.Port == 0 {
+		c.Port = 9090
+	}
+	if c.MaxRecvMsgSize == 0 {
+		c.MaxRecvMsgSize = 4 * 1024 * 1024
+	}
+	if c.MaxSendMsgSize == 0 {
+		c.MaxSendMsgSize = 4 * 1024 * 1024
+	}
+	if c.ConnectionTimeout == 0 {
+		c.ConnectionTimeout = 120 * time.Second
+	}
+}
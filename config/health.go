@@ -0,0 +1,30 @@
+package config
+
+// HealthConfig 健康检查接口配置
+type HealthConfig struct {
+	Path          string `yaml:"path"`          // 完整健康报告路径
+	LivenessPath  string `yaml:"livenessPath"`  // 存活探针路径（进程存活即返回 200）
+	ReadinessPath string `yaml:"readinessPath"` // 就绪探针路径（关键组件可用时返回 200）
+}
+
+// DefaultHealthConfig 返回默认健康检查接口配置
+func DefaultHealthConfig() *HealthConfig {
+	return &HealthConfig{
+		Path:          "/health",
+		LivenessPath:  "/healthz",
+		ReadinessPath: "/readyz",
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *HealthConfig) SetDefaults() {
+	if c.Path == "" {
+		c.Path = "/health"
+	}
+	if c.LivenessPath == "" {
+		c.LivenessPath = "/healthz"
+	}
+	if c.ReadinessPath == "" {
+		c.ReadinessPath = "/readyz"
+	}
+}
@@ -0,0 +1,26 @@
+package config
+
+// I18nConfig 多语言配置
+type I18nConfig struct {
+	DefaultLocale string `yaml:"defaultLocale"` // 默认语言，例如 zh-CN、en-US
+	QueryParam    string `yaml:"queryParam"`    // 查询参数名称，优先级高于 Accept-Language 请求头
+	Dir           string `yaml:"dir"`           // 消息文件目录（文件名为语言标识，例如 en-US.yaml），可覆盖内置译文或新增语言
+}
+
+// DefaultI18nConfig 返回默认多语言配置
+func DefaultI18nConfig() *I18nConfig {
+	return &I18nConfig{
+		DefaultLocale: "zh-CN",
+		QueryParam:    "lang",
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *I18nConfig) SetDefaults() {
+	if c.DefaultLocale == "" {
+		c.DefaultLocale = "zh-CN"
+	}
+	if c.QueryParam == "" {
+		c.QueryParam = "lang"
+	}
+}
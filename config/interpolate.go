@@ -0,0 +1,164 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// ExpandEnv 展开 YAML 配置值中的环境变量占位符
+// - ${VAR}：变量未设置时为空字符串
+// - ${VAR:-default}：变量未设置或为空时使用默认值；${VAR-default} 仅在未设置时使用默认值（默认值中可继续使用占位符）
+// - ${VAR:?message}：变量未设置或为空时返回错误
+// - $${：输出字面量 ${
+// 只展开值，不展开键与注释；未加引号的值展开后按 YAML 规则推断类型（例如 port: ${PORT:-8080} 为整数）
+func ExpandEnv(data []byte) ([]byte, error) {
+	return expandYAML(data, os.LookupEnv)
+}
+
+// expandYAML 展开 YAML 文档中的占位符（不含占位符时原样返回，YAML 语法错误交由调用方解析时报告）
+func expandYAML(data []byte, lookup func(string) (string, bool)) ([]byte, error) {
+	if !strings.Contains(string(data), "${") {
+		return data, nil
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return data, nil
+	}
+	changed, err := expandNode(&root, lookup)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return data, nil
+	}
+	return yaml.Marshal(&root)
+}
+
+// expandNode 递归展开节点中的标量值
+func expandNode(node *yaml.Node, lookup func(string) (string, bool)) (bool, error) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		changed := false
+		for _, child := range node.Content {
+			c, err := expandNode(child, lookup)
+			if err != nil {
+				return false, err
+			}
+			changed = changed || c
+		}
+		return changed, nil
+	case yaml.MappingNode:
+		changed := false
+		for i := 1; i < len(node.Content); i += 2 {
+			c, err := expandNode(node.Content[i], lookup)
+			if err != nil {
+				return false, fmt.Errorf("%s: %w", node.Content[i-1].Value, err)
+			}
+			changed = changed || c
+		}
+		return changed, nil
+	case yaml.ScalarNode:
+		if !strings.Contains(node.Value, "$") {
+			return false, nil
+		}
+		value, err := Interpolate(node.Value, lookup)
+		if err != nil {
+			return false, err
+		}
+		if value == node.Value {
+			return false, nil
+		}
+		node.Value = value
+		// 未加引号的值重新推断类型
+		if node.Style == 0 && node.Tag == "!!str" {
+			node.Tag = ""
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// Interpolate 展开字符串中的 ${VAR} 占位符（lookup 为变量查找函数，例如 os.LookupEnv）
+func Interpolate(s string, lookup func(string) (string, bool)) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		switch {
+		case strings.HasPrefix(s[i:], "$${"):
+			b.WriteString("${")
+			i += 3
+		case strings.HasPrefix(s[i:], "${"):
+			end := closingBrace(s, i+2)
+			if end < 0 {
+				return "", fmt.Errorf("占位符未闭合: %s", s[i:])
+			}
+			value, err := expandPlaceholder(s[i+2:end], lookup)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(value)
+			i = end + 1
+		default:
+			b.WriteByte(s[i])
+			i++
+		}
+	}
+	return b.String(), nil
+}
+
+// closingBrace 查找与占位符开头匹配的 }（支持默认值中嵌套占位符）
+func closingBrace(s string, start int) int {
+	depth := 1
+	for i := start; i < len(s); i++ {
+		switch {
+		case strings.HasPrefix(s[i:], "${"):
+			depth++
+			i++
+		case s[i] == '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// expandPlaceholder 展开单个占位符（expr 为 ${ 与 } 之间的内容）
+func expandPlaceholder(expr string, lookup func(string) (string, bool)) (string, error) {
+	n := 0
+	for n < len(expr) && (expr[n] == '_' || expr[n] >= 'A' && expr[n] <= 'Z' || expr[n] >= 'a' && expr[n] <= 'z' || n > 0 && expr[n] >= '0' && expr[n] <= '9') {
+		n++
+	}
+	name, op := expr[:n], expr[n:]
+	if name == "" {
+		return "", fmt.Errorf("无效的占位符: ${%s}", expr)
+	}
+	value, ok := lookup(name)
+
+	switch {
+	case op == "":
+		return value, nil
+	case strings.HasPrefix(op, ":-"):
+		if value == "" {
+			return Interpolate(op[2:], lookup)
+		}
+	case strings.HasPrefix(op, ":?"):
+		if value == "" {
+			message := op[2:]
+			if message == "" {
+				message = "未设置或为空"
+			}
+			return "", fmt.Errorf("环境变量 %s %s", name, message)
+		}
+	case strings.HasPrefix(op, "-"):
+		if !ok {
+			return Interpolate(op[1:], lookup)
+		}
+	default:
+		return "", fmt.Errorf("无效的占位符: ${%s}", expr)
+	}
+	return value, nil
+}
@@ -1,17 +1,64 @@
 package config
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/so68/utils/logger"
 	"github.com/spf13/viper"
 )
 
-// LoadConfig 从指定路径加载配置文件
-func LoadConfig(configPath string) (*AppConfig, error) {
+// LoadOption 加载配置可选项
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	ctx        context.Context
+	remote     RemoteProvider
+	remoteData []byte
+	strict     bool
+}
+
+// WithRemote 指定远程配置中心（未指定时按本地配置的 remote 段创建）
+func WithRemote(provider RemoteProvider) LoadOption {
+	return func(o *loadOptions) { o.remote = provider }
+}
+
+// WithRemoteData 使用已读取的远程配置内容（订阅到变更后重新加载配置时使用）
+func WithRemoteData(data []byte) LoadOption {
+	return func(o *loadOptions) { o.remoteData = data }
+}
+
+// WithContext 指定读取远程配置的上下文
+func WithContext(ctx context.Context) LoadOption {
+	return func(o *loadOptions) { o.ctx = ctx }
+}
+
+// WithStrict 严格模式：配置文件（含运行环境配置与远程配置）中存在未知配置项时返回错误
+// - 用于发现拼写错误的配置项（例如 databse、poolsize 写在错误的层级）
+func WithStrict() LoadOption {
+	return func(o *loadOptions) { o.strict = true }
+}
+
+// newLoadOptions 合并加载可选项
+func newLoadOptions(opts []LoadOption) *loadOptions {
+	o := &loadOptions{ctx: context.Background()}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// hasRemote 是否指定了远程配置
+func (o *loadOptions) hasRemote() bool {
+	return o.remote != nil || o.remoteData != nil
+}
+
+// LoadConfig 从指定路径加载配置文件（本地配置启用 remote 或指定 WithRemote 时叠加远程配置）
+func LoadConfig(configPath string, opts ...LoadOption) (*AppConfig, error) {
+	o := newLoadOptions(opts)
 	// 创建默认配置
 	config := DefaultAppConfig()
 
@@ -21,30 +68,52 @@ func LoadConfig(configPath string) (*AppConfig, error) {
 	}
 
 	// 检查配置文件是否存在
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+	_, statErr := os.Stat(configPath)
+	exists := !os.IsNotExist(statErr)
+	if !exists && !o.hasRemote() {
 		// 如果配置文件不存在，返回默认配置
 		config.SetDefaults()
 		return config, nil
 	}
 
-	// 设置 viper 配置
-	viper.SetConfigFile(configPath)
-	viper.SetConfigType("yaml")
+	// 创建新的 viper 实例（不使用全局单例，避免多次或并发加载之间互相影响）
+	v := viper.New()
+	v.SetConfigFile(configPath)
+	v.SetConfigType("yaml")
 
 	// 设置环境变量前缀
-	viper.SetEnvPrefix("APP")
-	viper.AutomaticEnv()
-	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
-
-	// 读取配置文件
-	if err := viper.ReadInConfig(); err != nil {
+	v.SetEnvPrefix(EnvPrefix)
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+
+	// 读取配置文件并叠加运行环境配置（展开 ${VAR} 占位符）
+	var env string
+	if exists {
+		var err error
+		if env, err = readConfigLayers(v, configPath); err != nil {
+			return config, fmt.Errorf("读取配置文件失败: %w", err)
+		}
+	} else if err := v.ReadConfig(strings.NewReader("")); err != nil {
 		return config, fmt.Errorf("读取配置文件失败: %w", err)
 	}
 
+	// 叠加远程配置
+	if err := mergeRemote(v, o); err != nil {
+		return config, err
+	}
+
+	// 严格模式下检查未知配置项
+	if o.strict {
+		if err := checkUnknownKeys(v); err != nil {
+			return config, err
+		}
+	}
+
 	// 将配置绑定到结构体
-	if err := viper.Unmarshal(config); err != nil {
+	if err := v.Unmarshal(config); err != nil {
 		return config, fmt.Errorf("解析配置文件失败: %w", err)
 	}
+	config.Env = env
 
 	// 设置默认值（只设置未配置的字段）
 	config.SetDefaults()
@@ -53,15 +122,46 @@ func LoadConfig(configPath string) (*AppConfig, error) {
 }
 
 // LoadConfigWithoutDefaults 从指定路径加载配置文件，不设置默认值
-func LoadConfigWithoutDefaults(configPath string) (*AppConfig, error) {
+func LoadConfigWithoutDefaults(configPath string, opts ...LoadOption) (*AppConfig, error) {
+	o := newLoadOptions(opts)
 	// 创建空配置，但初始化嵌套结构
 	config := &AppConfig{
-		Cors:      &CorsConfig{},
-		JWT:       &JWTConfig{},
-		RateLimit: &RateLimitConfig{},
-		Logger:    logger.DefaultConfig(),
-		Cache:     &CacheConfig{},
-		Database:  &DatabaseConfig{},
+		Cors:           &CorsConfig{},
+		TLS:            &TLSConfig{},
+		JWT:            &JWTConfig{},
+		RateLimit:      &RateLimitConfig{},
+		AccessLog:      &AccessLogConfig{},
+		Audit:          &AuditConfig{},
+		PasswordPolicy: &PasswordPolicyConfig{},
+		Security:       &SecurityConfig{},
+		Captcha:        &CaptchaConfig{},
+		PasswordReset:  &PasswordResetConfig{},
+		Auth:           &AuthConfig{},
+		Signature:      &SignatureConfig{},
+		Tenant:         &TenantConfig{},
+		Logger:         logger.DefaultConfig(),
+		Cache:          &CacheConfig{},
+		Database:       &DatabaseConfig{},
+		Mailer:         &MailerConfig{},
+		SMS:            &SMSConfig{},
+		Storage:        &StorageConfig{},
+		Upload:         &UploadConfig{},
+		Queue:          &QueueConfig{},
+		MQ:             &MQConfig{},
+		Event:          &EventConfig{},
+		I18n:           &I18nConfig{},
+		Response:       &ResponseConfig{},
+		Telemetry:      &TelemetryConfig{},
+		Metrics:        &MetricsConfig{},
+		GRPC:           &GRPCConfig{},
+		Health:         &HealthConfig{},
+		OpenAPI:        &OpenAPIConfig{},
+		UI:             &UIConfig{},
+		Template:       &TemplateConfig{},
+		Secret:         &SecretConfig{},
+		Remote:         &RemoteConfig{},
+		LogSinks:       &LogSinksConfig{},
+		LogMask:        &LogMaskConfig{},
 	}
 
 	// 如果配置文件路径为空，尝试从环境变量或默认位置查找
@@ -80,15 +180,29 @@ func LoadConfigWithoutDefaults(configPath string) (*AppConfig, error) {
 	v.SetConfigFile(configPath)
 	v.SetConfigType("yaml")
 
-	// 读取配置文件
-	if err := v.ReadInConfig(); err != nil {
+	// 读取配置文件并叠加运行环境配置（展开 ${VAR} 占位符）
+	env, err := readConfigLayers(v, configPath)
+	if err != nil {
 		return DefaultAppConfig(), fmt.Errorf("读取配置文件失败: %w", err)
 	}
 
+	// 叠加远程配置
+	if err := mergeRemote(v, o); err != nil {
+		return DefaultAppConfig(), err
+	}
+
+	// 严格模式下检查未知配置项
+	if o.strict {
+		if err := checkUnknownKeys(v); err != nil {
+			return DefaultAppConfig(), err
+		}
+	}
+
 	// 将配置绑定到结构体
 	if err := v.Unmarshal(config); err != nil {
 		return DefaultAppConfig(), fmt.Errorf("解析配置文件失败: %w", err)
 	}
+	config.Env = env
 
 	return config, nil
 }
@@ -97,20 +211,54 @@ func LoadConfigWithoutDefaults(configPath string) (*AppConfig, error) {
 func LoadConfigFromBytesWithoutDefaults(data []byte) (*AppConfig, error) {
 	// 创建空配置，但初始化嵌套结构
 	config := &AppConfig{
-		Cors:      &CorsConfig{},
-		JWT:       &JWTConfig{},
-		RateLimit: &RateLimitConfig{},
-		Logger:    logger.DefaultConfig(),
-		Cache:     &CacheConfig{},
-		Database:  &DatabaseConfig{},
+		Cors:           &CorsConfig{},
+		TLS:            &TLSConfig{},
+		JWT:            &JWTConfig{},
+		RateLimit:      &RateLimitConfig{},
+		AccessLog:      &AccessLogConfig{},
+		Audit:          &AuditConfig{},
+		PasswordPolicy: &PasswordPolicyConfig{},
+		Security:       &SecurityConfig{},
+		Captcha:        &CaptchaConfig{},
+		PasswordReset:  &PasswordResetConfig{},
+		Auth:           &AuthConfig{},
+		Signature:      &SignatureConfig{},
+		Tenant:         &TenantConfig{},
+		Logger:         logger.DefaultConfig(),
+		Cache:          &CacheConfig{},
+		Database:       &DatabaseConfig{},
+		Mailer:         &MailerConfig{},
+		SMS:            &SMSConfig{},
+		Storage:        &StorageConfig{},
+		Upload:         &UploadConfig{},
+		Queue:          &QueueConfig{},
+		MQ:             &MQConfig{},
+		Event:          &EventConfig{},
+		I18n:           &I18nConfig{},
+		Response:       &ResponseConfig{},
+		Telemetry:      &TelemetryConfig{},
+		Metrics:        &MetricsConfig{},
+		GRPC:           &GRPCConfig{},
+		Health:         &HealthConfig{},
+		OpenAPI:        &OpenAPIConfig{},
+		UI:             &UIConfig{},
+		Template:       &TemplateConfig{},
+		Secret:         &SecretConfig{},
+		Remote:         &RemoteConfig{},
+		LogSinks:       &LogSinksConfig{},
+		LogMask:        &LogMaskConfig{},
 	}
 
 	// 创建新的 viper 实例
 	v := viper.New()
 	v.SetConfigType("yaml")
 
-	// 从字节数据读取配置
-	if err := v.ReadConfig(strings.NewReader(string(data))); err != nil {
+	// 从字节数据读取配置（展开 ${VAR} 占位符）
+	data, err := ExpandEnv(data)
+	if err != nil {
+		return DefaultAppConfig(), fmt.Errorf("读取配置数据失败: %w", err)
+	}
+	if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
 		return DefaultAppConfig(), fmt.Errorf("读取配置数据失败: %w", err)
 	}
 
@@ -122,15 +270,17 @@ func LoadConfigFromBytesWithoutDefaults(data []byte) (*AppConfig, error) {
 	return config, nil
 }
 
-// LoadConfigWithEnv 从环境变量和配置文件加载配置
-func LoadConfigWithEnv(configPath string) (*AppConfig, error) {
-	config, err := LoadConfig(configPath)
+// LoadConfigWithEnv 从环境变量和配置文件加载配置（优先级：配置文件 < 运行环境配置 < 远程配置 < 环境变量）
+func LoadConfigWithEnv(configPath string, opts ...LoadOption) (*AppConfig, error) {
+	config, err := LoadConfig(configPath, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	// 从环境变量覆盖配置
-	overrideFromEnv(config)
+	if err := overrideFromEnv(config); err != nil {
+		return nil, err
+	}
 
 	return config, nil
 }
@@ -140,16 +290,21 @@ func LoadConfigFromBytes(data []byte) (*AppConfig, error) {
 	// 创建默认配置
 	config := DefaultAppConfig()
 
-	// 设置 viper 配置
-	viper.SetConfigType("yaml")
+	// 创建新的 viper 实例
+	v := viper.New()
+	v.SetConfigType("yaml")
 
-	// 从字节数据读取配置
-	if err := viper.ReadConfig(strings.NewReader(string(data))); err != nil {
+	// 从字节数据读取配置（展开 ${VAR} 占位符）
+	data, err := ExpandEnv(data)
+	if err != nil {
+		return config, fmt.Errorf("读取配置数据失败: %w", err)
+	}
+	if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
 		return config, fmt.Errorf("读取配置数据失败: %w", err)
 	}
 
 	// 将配置绑定到结构体
-	if err := viper.Unmarshal(config); err != nil {
+	if err := v.Unmarshal(config); err != nil {
 		return config, fmt.Errorf("解析配置数据失败: %w", err)
 	}
 
@@ -159,6 +314,15 @@ func LoadConfigFromBytes(data []byte) (*AppConfig, error) {
 	return config, nil
 }
 
+// readConfigFile 读取配置文件并展开环境变量占位符
+func readConfigFile(configPath string) ([]byte, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	return ExpandEnv(data)
+}
+
 // getDefaultConfigPath 获取默认配置文件路径
 func getDefaultConfigPath() string {
 	// 按优先级查找配置文件
@@ -179,188 +343,7 @@ func getDefaultConfigPath() string {
 	return "./config.yaml"
 }
 
-// overrideFromEnv 从环境变量覆盖配置
-func overrideFromEnv(config *AppConfig) {
-	// 应用基础配置
-	if val := os.Getenv("APP_NAME"); val != "" {
-		config.Name = val
-	}
-	if val := os.Getenv("APP_HOST"); val != "" {
-		config.Host = val
-	}
-	if val := os.Getenv("APP_PORT"); val != "" {
-		if _, err := fmt.Sscanf(val, "%d", &config.Port); err == nil {
-			// 成功解析端口
-		}
-	}
-	if val := os.Getenv("APP_DEBUG"); val != "" {
-		config.Debug = val == "true" || val == "1"
-	}
-	if val := os.Getenv("APP_STATIC"); val != "" {
-		config.Static = val
-	}
-	if val := os.Getenv("APP_READ_TIMEOUT"); val != "" {
-		config.ReadTimeout = val
-	}
-	if val := os.Getenv("APP_WRITE_TIMEOUT"); val != "" {
-		config.WriteTimeout = val
-	}
-	if val := os.Getenv("APP_IDLE_TIMEOUT"); val != "" {
-		config.IdleTimeout = val
-	}
-	if val := os.Getenv("APP_MAX_HEADER"); val != "" {
-		if _, err := fmt.Sscanf(val, "%d", &config.MaxHeader); err == nil {
-			// 成功解析最大请求头
-		}
-	}
-
-	// JWT 配置
-	if config.JWT != nil {
-		if val := os.Getenv("APP_JWT_SECRET_KEY"); val != "" {
-			config.JWT.SecretKey = val
-		}
-		if val := os.Getenv("APP_JWT_EXPIRES_IN"); val != "" {
-			if _, err := fmt.Sscanf(val, "%d", &config.JWT.ExpiresIn); err == nil {
-				// 成功解析过期时间
-			}
-		}
-	}
-
-	// 数据库配置
-	if config.Database != nil {
-		if val := os.Getenv("APP_DATABASE_HOST"); val != "" {
-			config.Database.Host = val
-		}
-		if val := os.Getenv("APP_DATABASE_PORT"); val != "" {
-			if _, err := fmt.Sscanf(val, "%d", &config.Database.Port); err == nil {
-				// 成功解析端口
-			}
-		}
-		if val := os.Getenv("APP_DATABASE_USERNAME"); val != "" {
-			config.Database.Username = val
-		}
-		if val := os.Getenv("APP_DATABASE_PASSWORD"); val != "" {
-			config.Database.Password = val
-		}
-		if val := os.Getenv("APP_DATABASE_DATABASE"); val != "" {
-			config.Database.Database = val
-		}
-	}
-
-	// 缓存配置
-	if config.Cache != nil {
-		if val := os.Getenv("APP_CACHE_HOST"); val != "" {
-			config.Cache.Host = val
-		}
-		if val := os.Getenv("APP_CACHE_PORT"); val != "" {
-			if _, err := fmt.Sscanf(val, "%d", &config.Cache.Port); err == nil {
-				// 成功解析端口
-			}
-		}
-		if val := os.Getenv("APP_CACHE_PASSWORD"); val != "" {
-			config.Cache.Password = val
-		}
-	}
-}
-
-// ValidateConfig 验证配置的有效性
-func ValidateConfig(config *AppConfig) error {
-	if config == nil {
-		return fmt.Errorf("配置对象不能为空")
-	}
-
-	// 验证端口范围
-	if config.Port <= 0 || config.Port > 65535 {
-		return fmt.Errorf("无效的端口号: %d", config.Port)
-	}
-
-	// 验证 JWT 配置
-	if config.JWT != nil {
-		if config.JWT.SecretKey == "" {
-			return fmt.Errorf("JWT 密钥不能为空")
-		}
-		if config.JWT.ExpiresIn <= 0 {
-			return fmt.Errorf("JWT 过期时间必须大于 0")
-		}
-	}
-
-	// 验证数据库配置
-	if config.Database != nil {
-		if config.Database.Host == "" {
-			return fmt.Errorf("数据库主机不能为空")
-		}
-		if config.Database.Port <= 0 || config.Database.Port > 65535 {
-			return fmt.Errorf("无效的数据库端口号: %d", config.Database.Port)
-		}
-		if config.Database.Username == "" {
-			return fmt.Errorf("数据库用户名不能为空")
-		}
-		if config.Database.Database == "" {
-			return fmt.Errorf("数据库名称不能为空")
-		}
-	}
-
-	// 验证缓存配置
-	if config.Cache != nil {
-		if config.Cache.Host == "" {
-			return fmt.Errorf("缓存主机不能为空")
-		}
-		if config.Cache.Port <= 0 || config.Cache.Port > 65535 {
-			return fmt.Errorf("无效的缓存端口号: %d", config.Cache.Port)
-		}
-	}
-
-	return nil
-}
-
-// SaveConfig 保存配置到文件
-func SaveConfig(config *AppConfig, filePath string) error {
-	// 确保目录存在
-	dir := filepath.Dir(filePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("创建目录失败: %w", err)
-	}
-
-	// 创建新的 viper 实例
-	v := viper.New()
-	v.SetConfigFile(filePath)
-	v.SetConfigType("yaml")
-
-	// 直接设置配置值
-	v.Set("name", config.Name)
-	v.Set("host", config.Host)
-	v.Set("port", config.Port)
-	v.Set("debug", config.Debug)
-	v.Set("static", config.Static)
-	v.Set("read_timeout", config.ReadTimeout)
-	v.Set("write_timeout", config.WriteTimeout)
-	v.Set("idle_timeout", config.IdleTimeout)
-	v.Set("max_header", config.MaxHeader)
-
-	// 设置子配置
-	if config.Cors != nil {
-		v.Set("cors", config.Cors)
-	}
-	if config.JWT != nil {
-		v.Set("jwt", config.JWT)
-	}
-	if config.RateLimit != nil {
-		v.Set("rate_limit", config.RateLimit)
-	}
-	if config.Logger != nil {
-		v.Set("logger", config.Logger)
-	}
-	if config.Cache != nil {
-		v.Set("cache", config.Cache)
-	}
-	if config.Database != nil {
-		v.Set("database", config.Database)
-	}
-
-	// 写入文件
-	if err := v.WriteConfig(); err != nil {
-		return fmt.Errorf("写入配置文件失败: %w", err)
-	}
-
-	return nil
+// overrideFromEnv 从环境变量覆盖配置（APP_ 前缀，变量名由 yaml 标签生成，参见 ApplyEnv）
+func overrideFromEnv(config *AppConfig) error {
+	return ApplyEnv(EnvPrefix, config)
 }
@@ -0,0 +1,57 @@
+package config
+
+import "sync"
+
+// Loader 配置加载器（可并发使用）
+// - 每次加载使用独立的 viper 实例，多次或并发加载之间互不影响
+// - 保存最近一次成功加载的配置，重新加载失败时保留原配置
+type Loader struct {
+	path string
+	opts []LoadOption
+
+	mu     sync.RWMutex
+	seq    uint64     // 已发起的加载次数
+	loaded uint64     // 当前配置对应的加载序号（并发加载时只保留最后发起的一次）
+	config *AppConfig // 最近一次成功加载的配置
+}
+
+// NewLoader 创建配置加载器，opts 应用于每次加载
+func NewLoader(configPath string, opts ...LoadOption) *Loader {
+	return &Loader{path: configPath, opts: opts}
+}
+
+// Path 返回配置文件路径
+func (l *Loader) Path() string {
+	return l.path
+}
+
+// Load 加载配置（优先级同 LoadConfigWithEnv），opts 追加在创建加载器时的可选项之后
+func (l *Loader) Load(opts ...LoadOption) (*AppConfig, error) {
+	l.mu.Lock()
+	l.seq++
+	seq := l.seq
+	l.mu.Unlock()
+
+	all := make([]LoadOption, 0, len(l.opts)+len(opts))
+	all = append(all, l.opts...)
+	all = append(all, opts...)
+	cfg, err := LoadConfigWithEnv(l.path, all...)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	if seq > l.loaded {
+		l.loaded = seq
+		l.config = cfg
+	}
+	l.mu.Unlock()
+	return cfg, nil
+}
+
+// Config 返回最近一次成功加载的配置（尚未加载时返回 nil）
+func (l *Loader) Config() *AppConfig {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.config
+}
@@ -0,0 +1,39 @@
+package config
+
+// LogMaskConfig 日志脱敏配置
+type LogMaskConfig struct {
+	Enabled     bool     `yaml:"enabled"`     // 是否启用
+	Fields      []string `yaml:"fields"`      // 需要脱敏的字段名（忽略大小写与 _/-，按后缀匹配，例如 token 匹配 access_token）
+	Patterns    []string `yaml:"patterns"`    // 额外的脱敏正则（匹配内容整体替换）
+	Replacement string   `yaml:"replacement"` // 替换文本
+	MaskCards   bool     `yaml:"maskCards"`   // 是否脱敏银行卡号（Luhn 校验，保留后 4 位）
+	MaskPhones  bool     `yaml:"maskPhones"`  // 是否脱敏手机号（保留前 3 位与后 4 位）
+}
+
+// DefaultLogMaskFields 默认脱敏字段
+var DefaultLogMaskFields = []string{
+	"password", "passwd", "pwd",
+	"token", "secret", "secret_key", "api_key", "authorization",
+	"mfa_code", "otp", "totp", "mfa_secret",
+}
+
+// DefaultLogMaskConfig 返回默认日志脱敏配置
+func DefaultLogMaskConfig() *LogMaskConfig {
+	return &LogMaskConfig{
+		Enabled:     true,
+		Fields:      DefaultLogMaskFields,
+		Replacement: "******",
+		MaskCards:   true,
+		MaskPhones:  true,
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *LogMaskConfig) SetDefaults() {
+	if len(c.Fields) == 0 {
+		c.Fields = DefaultLogMaskFields
+	}
+	if c.Replacement == "" {
+		c.Replacement = "******"
+	}
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"time"
+)
+
+// LogSinksConfig 远程日志输出配置（在 logger 的 stdout/文件输出之外追加）
+type LogSinksConfig struct {
+	Syslog *SyslogSinkConfig `yaml:"syslog"` // syslog 输出
+	Loki   *LokiSinkConfig   `yaml:"loki"`   // Loki 输出
+}
+
+// SyslogSinkConfig syslog 输出配置
+type SyslogSinkConfig struct {
+	Enabled bool   `yaml:"enabled"` // 是否启用
+	Level   string `yaml:"level"`   // 最低日志级别: debug, info, warn, error
+	Network string `yaml:"network"` // 网络类型: udp, tcp，为空时使用本机 syslog
+	Address string `yaml:"address"` // syslog 服务地址，例如 127.0.0.1:514
+	Tag     string `yaml:"tag"`     // 日志标签，为空时使用应用名称
+}
+
+// LokiSinkConfig Loki 输出配置
+type LokiSinkConfig struct {
+	Enabled       bool              `yaml:"enabled"`       // 是否启用
+	Level         string            `yaml:"level"`         // 最低日志级别: debug, info, warn, error
+	URL           string            `yaml:"url"`           // 推送地址，例如 http://localhost:3100/loki/api/v1/push
+	Labels        map[string]string `yaml:"labels"`        // 流标签（默认附加 app 标签）
+	Username      string            `yaml:"username"`      // Basic Auth 用户名
+	Password      string            `yaml:"password"`      // Basic Auth 密码
+	BatchSize     int               `yaml:"batchSize"`     // 批量推送条数
+	BufferSize    int               `yaml:"bufferSize"`    // 缓冲队列大小（队列满时丢弃日志，避免阻塞业务）
+	FlushInterval time.Duration     `yaml:"flushInterval"` // 推送间隔
+	Timeout       time.Duration     `yaml:"timeout"`       // 推送超时
+}
+
+// DefaultLogSinksConfig 返回默认远程日志输出配置
+func DefaultLogSinksConfig() *LogSinksConfig {
+	return &LogSinksConfig{
+		Syslog: &SyslogSinkConfig{
+			Level:   "info",
+			Network: "udp",
+			Address: "127.0.0.1:514",
+		},
+		Loki: &LokiSinkConfig{
+			Level:         "info",
+			URL:           "http://localhost:3100/loki/api/v1/push",
+			BatchSize:     100,
+			BufferSize:    10000,
+			FlushInterval: time.Second,
+			Timeout:       5 * time.Second,
+		},
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *LogSinksConfig) SetDefaults() {
+	defaults := DefaultLogSinksConfig()
+	if c.Syslog == nil {
+		c.Syslog = defaults.Syslog
+	}
+	if c.Syslog.Level == "" {
+		c.Syslog.Level = "info"
+	}
+	if c.Loki == nil {
+		c.Loki = defaults.Loki
+	}
+	if c.Loki.Level == "" {
+		c.Loki.Level = "info"
+	}
+	if c.Loki.URL == "" {
+		c.Loki.URL = defaults.Loki.URL
+	}
+	if c.Loki.BatchSize == 0 {
+		c.Loki.BatchSize = 100
+	}
+	if c.Loki.BufferSize == 0 {
+		c.Loki.BufferSize = 10000
+	}
+	if c.Loki.FlushInterval == 0 {
+		c.Loki.FlushInterval = time.Second
+	}
+	if c.Loki.Timeout == 0 {
+		c.Loki.Timeout = 5 * time.Second
+	}
+}
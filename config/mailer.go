@@ -0,0 +1,49 @@
+package config
+
+import (
+	"time"
+)
+
+// MailerConfig 邮件配置
+type MailerConfig struct {
+	Driver string `yaml:"driver"` // 邮件驱动: smtp, log（仅记录日志，用于开发环境）
+
+	// SMTP 配置
+	Host     string        `yaml:"host"`     // SMTP 主机
+	Port     int           `yaml:"port"`     // SMTP 端口
+	Username string        `yaml:"username"` // 用户名
+	Password string        `yaml:"password"` // 密码
+	From     string        `yaml:"from"`     // 发件人地址
+	FromName string        `yaml:"fromName"` // 发件人名称
+	TLS      bool          `yaml:"tls"`      // 是否使用隐式 TLS（通常为 465 端口），否则尝试 STARTTLS
+	Timeout  time.Duration `yaml:"timeout"`  // 连接超时
+}
+
+// DefaultMailerConfig 返回默认邮件配置
+func DefaultMailerConfig() *MailerConfig {
+	return &MailerConfig{
+		Driver:  "log",
+		Host:    "localhost",
+		Port:    25,
+		Timeout: 10 * time.Second,
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *MailerConfig) SetDefaults() {
+	if c.Driver == "" {
+		c.Driver = "log"
+	}
+	if c.Host == "" {
+		c.Host = "localhost"
+	}
+	if c.Port == 0 {
+		c.Port = 25
+	}
+	if c.Timeout == 0 {
+		c.Timeout = 10 * time.Second
+	}
+	if c.From == "" {
+		c.From = c.Username
+	}
+}
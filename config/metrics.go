@@ -0,0 +1,27 @@
+package config
+
+// MetricsConfig 指标配置（Prometheus）
+type MetricsConfig struct {
+	Enabled   bool   `yaml:"enabled"`   // 是否启用
+	Path      string `yaml:"path"`      // 指标暴露路径
+	Namespace string `yaml:"namespace"` // 指标命名空间（前缀）
+}
+
+// DefaultMetricsConfig 返回默认指标配置
+func DefaultMetricsConfig() *MetricsConfig {
+	return &MetricsConfig{
+		Enabled:   false,
+		Path:      "/metrics",
+		Namespace: "app",
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *MetricsConfig) SetDefaults() {
+	if c.Path == "" {
+		c.Path = "/metrics"
+	}
+	if c.Namespace == "" {
+		c.Namespace = "app"
+	}
+}
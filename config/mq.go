@@ -0,0 +1,129 @@
+package config
+
+import (
+	"time"
+)
+
+// MQConfig 消息队列配置（Kafka/NSQ，用于跨服务的消息发布与消费组订阅）
+type MQConfig struct {
+	Enabled bool   `yaml:"enabled"` // 是否启用
+	Driver  string `yaml:"driver"`  // 消息队列驱动: kafka, nsq, memory
+	Group   string `yaml:"group"`   // 消费组（Kafka consumer group / NSQ channel），同组内每条消息只被一个实例处理
+
+	// 消费配置
+	Concurrency    int           `yaml:"concurrency"`    // 每个主题的并发处理数（Kafka 按拉取顺序逐条处理，忽略该配置）
+	MaxAttempts    int           `yaml:"maxAttempts"`    // 最大投递次数（超过后丢弃并记录日志）
+	RetryBackoff   time.Duration `yaml:"retryBackoff"`   // 处理失败后重新投递的延迟
+	MessageTimeout time.Duration `yaml:"messageTimeout"` // 单条消息处理超时
+
+	Kafka *KafkaConfig `yaml:"kafka"` // Kafka 驱动配置
+	NSQ   *NSQConfig   `yaml:"nsq"`   // NSQ 驱动配置
+}
+
+// KafkaConfig Kafka 驱动配置（通过 Kafka REST Proxy v2 接入）
+type KafkaConfig struct {
+	RestURL         string        `yaml:"restUrl"`         // REST Proxy 地址，例如 http://kafka-rest:8082
+	Username        string        `yaml:"username"`        // Basic 认证用户名（可选）
+	Password        string        `yaml:"password"`        // Basic 认证密码（可选）
+	Timeout         time.Duration `yaml:"timeout"`         // 请求超时
+	PollTimeout     time.Duration `yaml:"pollTimeout"`     // 拉取消息的长轮询时长
+	AutoOffsetReset string        `yaml:"autoOffsetReset"` // 消费组无已提交偏移量时的起始位置: earliest, latest
+}
+
+// NSQConfig NSQ 驱动配置（TCP 协议）
+type NSQConfig struct {
+	Addr           string        `yaml:"addr"`           // nsqd TCP 地址，用于发布消息（未配置 lookupd 时也用于消费）
+	LookupdAddrs   []string      `yaml:"lookupdAddrs"`   // nsqlookupd HTTP 地址，配置后消费时自动发现 nsqd
+	LookupInterval time.Duration `yaml:"lookupInterval"` // 重新发现 nsqd 的间隔
+	MaxInFlight    int           `yaml:"maxInFlight"`    // 单个连接同时处理的消息数（为 0 时使用 concurrency）
+	DialTimeout    time.Duration `yaml:"dialTimeout"`    // 连接超时
+}
+
+// DefaultMQConfig 返回默认消息队列配置
+func DefaultMQConfig() *MQConfig {
+	return &MQConfig{
+		Enabled: false,
+		Driver:  "memory",
+		Group:   "default",
+
+		Concurrency:    5,
+		MaxAttempts:    5,
+		RetryBackoff:   5 * time.Second,
+		MessageTimeout: time.Minute,
+
+		Kafka: DefaultKafkaConfig(),
+		NSQ:   DefaultNSQConfig(),
+	}
+}
+
+// DefaultKafkaConfig 返回默认 Kafka 驱动配置
+func DefaultKafkaConfig() *KafkaConfig {
+	return &KafkaConfig{
+		Timeout:         10 * time.Second,
+		PollTimeout:     time.Second,
+		AutoOffsetReset: "earliest",
+	}
+}
+
+// DefaultNSQConfig 返回默认 NSQ 驱动配置
+func DefaultNSQConfig() *NSQConfig {
+	return &NSQConfig{
+		LookupInterval: time.Minute,
+		DialTimeout:    5 * time.Second,
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *MQConfig) SetDefaults() {
+	if c.Driver == "" {
+		c.Driver = "memory"
+	}
+	if c.Group == "" {
+		c.Group = "default"
+	}
+	if c.Concurrency == 0 {
+		c.Concurrency = 5
+	}
+	if c.MaxAttempts == 0 {
+		c.MaxAttempts = 5
+	}
+	if c.RetryBackoff == 0 {
+		c.RetryBackoff = 5 * time.Second
+	}
+	if c.MessageTimeout == 0 {
+		c.MessageTimeout = time.Minute
+	}
+	if c.Kafka != nil {
+		c.Kafka.SetDefaults()
+	} else {
+		c.Kafka = DefaultKafkaConfig()
+	}
+	if c.NSQ != nil {
+		c.NSQ.SetDefaults()
+	} else {
+		c.NSQ = DefaultNSQConfig()
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *KafkaConfig) SetDefaults() {
+	if c.Timeout == 0 {
+		c.Timeout = 10 * time.Second
+	}
+	if c.PollTimeout == 0 {
+		c.PollTimeout = time.Second
+	}
+	if c.AutoOffsetReset == "" {
+		c.AutoOffsetReset = "earliest"
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *NSQConfig) SetDefaults() {
+	if c.LookupInterval == 0 {
+		c.LookupInterval = time.Minute
+	}
+	if c.DialTimeout == 0 {
+		c.DialTimeout = 5 * time.Second
+	}
+}
@@ -0,0 +1,41 @@
+package config
+
+// OpenAPIConfig OpenAPI 文档配置（根据路由注册时描述的请求与响应 DTO 生成）
+type OpenAPIConfig struct {
+	Enabled     bool   `yaml:"enabled"`     // 是否启用
+	Path        string `yaml:"path"`        // 文档路径
+	SwaggerUI   bool   `yaml:"swaggerUI"`   // 是否启用 Swagger UI
+	SwaggerPath string `yaml:"swaggerPath"` // Swagger UI 路径
+	SwaggerCDN  string `yaml:"swaggerCDN"`  // Swagger UI 静态资源地址（swagger-ui-dist）
+	Title       string `yaml:"title"`       // 文档标题（为空时使用应用名称）
+	Version     string `yaml:"version"`     // 接口版本
+	Description string `yaml:"description"` // 文档描述
+}
+
+// DefaultOpenAPIConfig 返回默认 OpenAPI 文档配置
+func DefaultOpenAPIConfig() *OpenAPIConfig {
+	return &OpenAPIConfig{
+		Enabled:     false,
+		Path:        "/openapi.json",
+		SwaggerUI:   false,
+		SwaggerPath: "/swagger",
+		SwaggerCDN:  "https://unpkg.com/swagger-ui-dist@5",
+		Version:     "1.0.0",
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *OpenAPIConfig) SetDefaults() {
+	if c.Path == "" {
+		c.Path = "/openapi.json"
+	}
+	if c.SwaggerPath == "" {
+		c.SwaggerPath = "/swagger"
+	}
+	if c.SwaggerCDN == "" {
+		c.SwaggerCDN = "https://unpkg.com/swagger-ui-dist@5"
+	}
+	if c.Version == "" {
+		c.Version = "1.0.0"
+	}
+}
@@ -0,0 +1,29 @@
+package config
+
+// PasswordPolicyConfig 管理员密码策略配置
+type PasswordPolicyConfig struct {
+	MinLength     int  `yaml:"minLength"`     // 最小长度
+	RequireUpper  bool `yaml:"requireUpper"`  // 必须包含大写字母
+	RequireLower  bool `yaml:"requireLower"`  // 必须包含小写字母
+	RequireDigit  bool `yaml:"requireDigit"`  // 必须包含数字
+	RequireSymbol bool `yaml:"requireSymbol"` // 必须包含特殊字符
+	HistoryCount  int  `yaml:"historyCount"`  // 禁止重复使用最近 N 次的密码（为 0 时不限制）
+	ExpireDays    int  `yaml:"expireDays"`    // 密码有效天数，过期后须修改密码才能登录（为 0 时永不过期）
+}
+
+// DefaultPasswordPolicyConfig 返回默认密码策略配置
+func DefaultPasswordPolicyConfig() *PasswordPolicyConfig {
+	return &PasswordPolicyConfig{
+		MinLength:    8,
+		RequireLower: true,
+		RequireDigit: true,
+		HistoryCount: 5,
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *PasswordPolicyConfig) SetDefaults() {
+	if c.MinLength == 0 {
+		c.MinLength = 8
+	}
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"time"
+)
+
+// PasswordResetConfig 管理员找回密码配置
+type PasswordResetConfig struct {
+	Enabled      bool          `yaml:"enabled"`      // 是否启用（需要启用缓存，并配置邮件或短信）
+	TokenTTL     time.Duration `yaml:"tokenTtl"`     // 邮件重置令牌有效期
+	Interval     time.Duration `yaml:"interval"`     // 同一账号重新申请间隔
+	DailyLimit   int           `yaml:"dailyLimit"`   // 同一账号每日申请上限（0 表示不限制）
+	IPLimit      int           `yaml:"ipLimit"`      // 同一IP每小时申请上限（0 表示不限制）
+	AttemptLimit int           `yaml:"attemptLimit"` // 同一账号或同一IP每小时重置密码尝试上限（0 表示不限制）
+	ResetURL     string        `yaml:"resetUrl"`     // 邮件中的重置链接，${token} 替换为重置令牌（为空时邮件仅包含令牌）
+}
+
+// DefaultPasswordResetConfig 返回默认找回密码配置
+func DefaultPasswordResetConfig() *PasswordResetConfig {
+	return &PasswordResetConfig{
+		Enabled:      false,
+		TokenTTL:     30 * time.Minute,
+		Interval:     time.Minute,
+		DailyLimit:   5,
+		IPLimit:      20,
+		AttemptLimit: 10,
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *PasswordResetConfig) SetDefaults() {
+	if c.TokenTTL == 0 {
+		c.TokenTTL = 30 * time.Minute
+	}
+	if c.Interval == 0 {
+		c.Interval = time.Minute
+	}
+}
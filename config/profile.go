@@ -0,0 +1,62 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// ProfileEnvVar 指定运行环境的环境变量
+const ProfileEnvVar = "APP_ENV"
+
+// profilePattern 运行环境名称允许的字符
+var profilePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ProfilePath 运行环境配置文件路径（config.yaml 对应 config.{env}.yaml）
+func ProfilePath(configPath string, env string) string {
+	ext := filepath.Ext(configPath)
+	return strings.TrimSuffix(configPath, ext) + "." + env + ext
+}
+
+// readConfigLayers 读取基础配置文件并叠加运行环境配置文件，返回生效的运行环境
+// - 运行环境取自 APP_ENV 环境变量，未设置时使用基础配置中的 env
+// - 运行环境配置文件不存在时只使用基础配置
+// - 优先级：基础配置 < 运行环境配置 < 环境变量（LoadConfigWithEnv）
+func readConfigLayers(v *viper.Viper, configPath string) (string, error) {
+	data, err := readConfigFile(configPath)
+	if err != nil {
+		return "", err
+	}
+	if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+		return "", err
+	}
+
+	env := os.Getenv(ProfileEnvVar)
+	if env == "" {
+		env = v.GetString("env")
+	}
+	if env == "" {
+		return "", nil
+	}
+	if !profilePattern.MatchString(env) {
+		return "", fmt.Errorf("无效的运行环境名称: %s", env)
+	}
+
+	profilePath := ProfilePath(configPath, env)
+	if _, err := os.Stat(profilePath); os.IsNotExist(err) {
+		return env, nil
+	}
+	data, err = readConfigFile(profilePath)
+	if err != nil {
+		return "", err
+	}
+	if err := v.MergeConfig(bytes.NewReader(data)); err != nil {
+		return "", fmt.Errorf("%s: %w", profilePath, err)
+	}
+	return env, nil
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"time"
+)
+
+// QueueConfig 任务队列配置
+type QueueConfig struct {
+	Driver string `yaml:"driver"` // 队列驱动: redis, memory
+	Name   string `yaml:"name"`   // 队列名称
+	Prefix string `yaml:"prefix"` // 键前缀
+
+	// 消费配置
+	Concurrency     int           `yaml:"concurrency"`     // 并发 worker 数量
+	MaxRetries      int           `yaml:"maxRetries"`      // 最大重试次数（超过后进入死信队列）
+	RetryBackoff    time.Duration `yaml:"retryBackoff"`    // 重试初始间隔（指数退避）
+	MaxRetryBackoff time.Duration `yaml:"maxRetryBackoff"` // 重试最大间隔
+	PollInterval    time.Duration `yaml:"pollInterval"`    // 延迟任务轮询间隔
+	JobTimeout      time.Duration `yaml:"jobTimeout"`      // 单个任务执行超时
+}
+
+// DefaultQueueConfig 返回默认任务队列配置
+func DefaultQueueConfig() *QueueConfig {
+	return &QueueConfig{
+		Driver: "redis",
+		Name:   "default",
+		Prefix: "queue",
+
+		Concurrency:     5,
+		MaxRetries:      3,
+		RetryBackoff:    time.Second,
+		MaxRetryBackoff: 10 * time.Minute,
+		PollInterval:    time.Second,
+		JobTimeout:      5 * time.Minute,
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *QueueConfig) SetDefaults() {
+	if c.Driver == "" {
+		c.Driver = "redis"
+	}
+	if c.Name == "" {
+		c.Name = "default"
+	}
+	if c.Prefix == "" {
+		c.Prefix = "queue"
+	}
+	if c.Concurrency == 0 {
+		c.Concurrency = 5
+	}
+	if c.MaxRetries == 0 {
+		c.MaxRetries = 3
+	}
+	if c.RetryBackoff == 0 {
+		c.RetryBackoff = time.Second
+	}
+	if c.MaxRetryBackoff == 0 {
+		c.MaxRetryBackoff = 10 * time.Minute
+	}
+	if c.PollInterval == 0 {
+		c.PollInterval = time.Second
+	}
+	if c.JobTimeout == 0 {
+		c.JobTimeout = 5 * time.Minute
+	}
+}
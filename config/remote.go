@@ -0,0 +1,157 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// RemoteConfig 远程配置中心（本地配置中的 remote 段，远程配置内容为 YAML，叠加在本地配置之上）
+type RemoteConfig struct {
+	Enabled   bool          `yaml:"enabled"`   // 是否启用
+	Provider  string        `yaml:"provider"`  // 配置中心: etcd, consul, nacos
+	Endpoints []string      `yaml:"endpoints"` // 服务地址（多个地址依次重试）
+	Key       string        `yaml:"key"`       // 配置键（etcd、consul 为键名，nacos 为 dataId）
+	Group     string        `yaml:"group"`     // 配置分组（nacos）
+	Namespace string        `yaml:"namespace"` // 命名空间（nacos 为命名空间ID，consul 为企业版命名空间）
+	Username  string        `yaml:"username"`  // 用户名（etcd、nacos 开启鉴权时使用）
+	Password  string        `yaml:"password"`  // 密码
+	Token     string        `yaml:"token"`     // 访问令牌（consul ACL）
+	Timeout   time.Duration `yaml:"timeout"`   // 请求超时
+	Watch     bool          `yaml:"watch"`     // 是否订阅配置变更
+	Interval  time.Duration `yaml:"interval"`  // 轮询间隔（etcd）与失败重试间隔
+}
+
+// DefaultRemoteConfig 返回默认远程配置中心配置
+func DefaultRemoteConfig() *RemoteConfig {
+	return &RemoteConfig{
+		Enabled:  false,
+		Provider: "etcd",
+		Group:    "DEFAULT_GROUP",
+		Timeout:  5 * time.Second,
+		Watch:    true,
+		Interval: 10 * time.Second,
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *RemoteConfig) SetDefaults() {
+	if c.Provider == "" {
+		c.Provider = "etcd"
+	}
+	if c.Group == "" {
+		c.Group = "DEFAULT_GROUP"
+	}
+	if c.Timeout == 0 {
+		c.Timeout = 5 * time.Second
+	}
+	if c.Interval == 0 {
+		c.Interval = 10 * time.Second
+	}
+}
+
+// RemoteProvider 远程配置中心
+type RemoteProvider interface {
+	// Fetch 读取配置内容
+	Fetch(ctx context.Context) ([]byte, error)
+	// Watch 订阅配置变更，内容变化时调用 onChange，阻塞直到 ctx 取消
+	Watch(ctx context.Context, onChange func(data []byte)) error
+}
+
+// RemoteProviderFactory 根据配置创建远程配置中心
+type RemoteProviderFactory func(cfg *RemoteConfig) (RemoteProvider, error)
+
+var (
+	remoteMu        sync.RWMutex
+	remoteFactories = make(map[string]RemoteProviderFactory)
+)
+
+// RegisterRemoteProvider 注册远程配置中心（内置的 etcd、consul、nacos 由 config/remote 包注册）
+func RegisterRemoteProvider(name string, factory RemoteProviderFactory) {
+	remoteMu.Lock()
+	defer remoteMu.Unlock()
+	remoteFactories[name] = factory
+}
+
+// NewRemoteProvider 根据配置创建已注册的远程配置中心
+func NewRemoteProvider(cfg *RemoteConfig) (RemoteProvider, error) {
+	remoteMu.RLock()
+	factory, ok := remoteFactories[cfg.Provider]
+	remoteMu.RUnlock()
+	if !ok {
+		remoteMu.RLock()
+		names := make([]string, 0, len(remoteFactories))
+		for name := range remoteFactories {
+			names = append(names, name)
+		}
+		remoteMu.RUnlock()
+		sort.Strings(names)
+		return nil, fmt.Errorf("不支持的远程配置中心: %s（已注册: %s）", cfg.Provider, strings.Join(names, ", "))
+	}
+	return factory(cfg)
+}
+
+// RemoteSettings 读取本地配置文件（含运行环境配置）中的远程配置中心设置，未启用时返回 nil
+func RemoteSettings(configPath string) (*RemoteConfig, error) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if _, err := readConfigLayers(v, configPath); err != nil {
+		return nil, err
+	}
+	return remoteSettings(v)
+}
+
+// remoteSettings 解析远程配置中心设置
+func remoteSettings(v *viper.Viper) (*RemoteConfig, error) {
+	if !v.IsSet("remote") {
+		return nil, nil
+	}
+	cfg := &RemoteConfig{}
+	if err := v.UnmarshalKey("remote", cfg); err != nil {
+		return nil, fmt.Errorf("解析远程配置中心设置失败: %w", err)
+	}
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	cfg.SetDefaults()
+	return cfg, nil
+}
+
+// mergeRemote 叠加远程配置内容（优先级高于本地配置文件，低于环境变量）
+func mergeRemote(v *viper.Viper, o *loadOptions) error {
+	data := o.remoteData
+	if data == nil {
+		provider := o.remote
+		if provider == nil {
+			cfg, err := remoteSettings(v)
+			if err != nil || cfg == nil {
+				return err
+			}
+			if provider, err = NewRemoteProvider(cfg); err != nil {
+				return err
+			}
+		}
+		fetched, err := provider.Fetch(o.ctx)
+		if err != nil {
+			return fmt.Errorf("读取远程配置失败: %w", err)
+		}
+		data = fetched
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	expanded, err := ExpandEnv(data)
+	if err != nil {
+		return fmt.Errorf("远程配置: %w", err)
+	}
+	if err := v.MergeConfig(bytes.NewReader(expanded)); err != nil {
+		return fmt.Errorf("解析远程配置失败: %w", err)
+	}
+	return nil
+}
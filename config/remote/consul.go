@@ -0,0 +1,124 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+// consulWait 阻塞查询的服务端等待时间
+const consulWait = 55 * time.Second
+
+// Consul Consul KV 配置中心（阻塞查询订阅变更）
+type Consul struct {
+	client    *client
+	key       string
+	token     string
+	namespace string
+	interval  time.Duration
+}
+
+// NewConsul 创建 Consul KV 配置中心
+func NewConsul(cfg *config.RemoteConfig) (*Consul, error) {
+	c, err := newClient(cfg, "http://127.0.0.1:8500")
+	if err != nil {
+		return nil, err
+	}
+	return &Consul{
+		client:    c,
+		key:       strings.TrimPrefix(cfg.Key, "/"),
+		token:     cfg.Token,
+		namespace: cfg.Namespace,
+		interval:  cfg.Interval,
+	}, nil
+}
+
+// Fetch 读取配置内容
+func (c *Consul) Fetch(ctx context.Context) ([]byte, error) {
+	data, _, err := c.get(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, fmt.Errorf("%w: consul %s", ErrNotFound, c.key)
+	}
+	return data, nil
+}
+
+// Watch 通过阻塞查询订阅配置变更（键被删除时保留原配置）
+func (c *Consul) Watch(ctx context.Context, onChange func(data []byte)) error {
+	var last []byte
+	var index uint64
+	initialized := false
+	for {
+		data, next, err := c.get(ctx, index)
+		// 请求失败或未返回索引时等待后重试，避免空转
+		if err != nil || next == 0 {
+			if ctx.Err() != nil || !sleep(ctx, c.interval) {
+				return nil
+			}
+			if err != nil {
+				continue
+			}
+		}
+		// 索引回退时（例如 Consul 重建快照）重新开始
+		if next < index {
+			next = 0
+		}
+		if initialized && data != nil && !bytes.Equal(data, last) {
+			onChange(data)
+		}
+		if data != nil {
+			last = data
+		}
+		initialized = true
+		index = next
+	}
+}
+
+// get 读取键值（index 大于 0 时为阻塞查询），键不存在时返回 nil
+func (c *Consul) get(ctx context.Context, index uint64) ([]byte, uint64, error) {
+	wait := time.Duration(0)
+	if index > 0 {
+		wait = consulWait
+	}
+	resp, err := c.client.do(ctx, wait, func(ctx context.Context, base *url.URL) (*http.Request, error) {
+		u := base.JoinPath("v1/kv", c.key)
+		query := url.Values{"raw": {""}}
+		if index > 0 {
+			query.Set("index", strconv.FormatUint(index, 10))
+			query.Set("wait", fmt.Sprintf("%ds", int(consulWait.Seconds())))
+		}
+		if c.namespace != "" {
+			query.Set("ns", c.namespace)
+		}
+		u.RawQuery = query.Encode()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if c.token != "" {
+			req.Header.Set("X-Consul-Token", c.token)
+		}
+		return req, nil
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("consul: %w", err)
+	}
+	next, _ := strconv.ParseUint(resp.header.Get("X-Consul-Index"), 10, 64)
+	switch resp.status {
+	case http.StatusOK:
+		return resp.body, next, nil
+	case http.StatusNotFound:
+		return nil, next, nil
+	default:
+		return nil, 0, fmt.Errorf("consul: unexpected status %d: %s", resp.status, truncate(resp.body))
+	}
+}
@@ -0,0 +1,155 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+// Etcd etcd v3 配置中心（通过 gRPC 网关的 JSON 接口读取，按间隔轮询修订号订阅变更）
+type Etcd struct {
+	client   *client
+	key      string
+	username string
+	password string
+	interval time.Duration
+
+	mu    sync.Mutex
+	token string
+}
+
+// etcdRangeResponse 读取键值响应（int64 字段以字符串编码）
+type etcdRangeResponse struct {
+	Kvs []struct {
+		Value       string `json:"value"`
+		ModRevision string `json:"mod_revision"`
+	} `json:"kvs"`
+}
+
+// NewEtcd 创建 etcd 配置中心
+func NewEtcd(cfg *config.RemoteConfig) (*Etcd, error) {
+	c, err := newClient(cfg, "http://127.0.0.1:2379")
+	if err != nil {
+		return nil, err
+	}
+	return &Etcd{
+		client:   c,
+		key:      cfg.Key,
+		username: cfg.Username,
+		password: cfg.Password,
+		interval: cfg.Interval,
+	}, nil
+}
+
+// Fetch 读取配置内容
+func (e *Etcd) Fetch(ctx context.Context) ([]byte, error) {
+	data, _, err := e.get(ctx)
+	return data, err
+}
+
+// Watch 按间隔轮询修订号，变化时通知（读取失败或键被删除时保留原配置）
+func (e *Etcd) Watch(ctx context.Context, onChange func(data []byte)) error {
+	var last []byte
+	var revision string
+	for {
+		data, rev, err := e.get(ctx)
+		if err == nil {
+			if revision != "" && rev != revision && !bytes.Equal(data, last) {
+				onChange(data)
+			}
+			last, revision = data, rev
+		}
+		if !sleep(ctx, e.interval) {
+			return nil
+		}
+	}
+}
+
+// get 读取键值与修订号（令牌过期时重新认证一次）
+func (e *Etcd) get(ctx context.Context) ([]byte, string, error) {
+	payload, _ := json.Marshal(map[string]string{"key": base64.StdEncoding.EncodeToString([]byte(e.key))})
+	resp, err := e.post(ctx, "v3/kv/range", payload, true)
+	if err != nil {
+		return nil, "", err
+	}
+	var result etcdRangeResponse
+	if err := json.Unmarshal(resp.body, &result); err != nil {
+		return nil, "", fmt.Errorf("etcd: decode response failed: %w", err)
+	}
+	if len(result.Kvs) == 0 {
+		return nil, "", fmt.Errorf("%w: etcd %s", ErrNotFound, e.key)
+	}
+	data, err := base64.StdEncoding.DecodeString(result.Kvs[0].Value)
+	if err != nil {
+		return nil, "", fmt.Errorf("etcd: decode value failed: %w", err)
+	}
+	return data, result.Kvs[0].ModRevision, nil
+}
+
+// post 发送 JSON 请求（开启鉴权时携带令牌）
+func (e *Etcd) post(ctx context.Context, path string, payload []byte, auth bool) (*response, error) {
+	token := ""
+	if auth && e.username != "" {
+		var err error
+		if token, err = e.authToken(ctx, false); err != nil {
+			return nil, err
+		}
+	}
+	send := func(token string) (*response, error) {
+		return e.client.do(ctx, 0, func(ctx context.Context, base *url.URL) (*http.Request, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, base.JoinPath(path).String(), bytes.NewReader(payload))
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("Content-Type", "application/json")
+			if token != "" {
+				req.Header.Set("Authorization", token)
+			}
+			return req, nil
+		})
+	}
+	resp, err := send(token)
+	if err == nil && auth && token != "" && resp.status == http.StatusUnauthorized {
+		if token, err = e.authToken(ctx, true); err != nil {
+			return nil, err
+		}
+		resp, err = send(token)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("etcd: %w", err)
+	}
+	if resp.status != http.StatusOK {
+		return nil, fmt.Errorf("etcd: unexpected status %d: %s", resp.status, truncate(resp.body))
+	}
+	return resp, nil
+}
+
+// authToken 获取认证令牌（refresh 为 true 时重新认证）
+func (e *Etcd) authToken(ctx context.Context, refresh bool) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.token != "" && !refresh {
+		return e.token, nil
+	}
+	payload, _ := json.Marshal(map[string]string{"name": e.username, "password": e.password})
+	resp, err := e.post(ctx, "v3/auth/authenticate", payload, false)
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(resp.body, &result); err != nil || result.Token == "" {
+		return "", fmt.Errorf("etcd: authenticate failed: %s", truncate(resp.body))
+	}
+	e.token = result.Token
+	return e.token, nil
+}
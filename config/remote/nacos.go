@@ -0,0 +1,217 @@
+package remote
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+// nacosPollTimeout 长轮询的服务端等待时间
+const nacosPollTimeout = 30 * time.Second
+
+// Nacos Nacos 配置中心（v1 开放接口，长轮询订阅变更）
+type Nacos struct {
+	client   *client
+	dataID   string
+	group    string
+	tenant   string
+	username string
+	password string
+	interval time.Duration
+	now      func() time.Time
+
+	mu           sync.Mutex
+	token        string
+	tokenExpires time.Time
+}
+
+// NewNacos 创建 Nacos 配置中心（key 为 dataId）
+func NewNacos(cfg *config.RemoteConfig) (*Nacos, error) {
+	c, err := newClient(cfg, "http://127.0.0.1:8848")
+	if err != nil {
+		return nil, err
+	}
+	group := cfg.Group
+	if group == "" {
+		group = "DEFAULT_GROUP"
+	}
+	return &Nacos{
+		client:   c,
+		dataID:   cfg.Key,
+		group:    group,
+		tenant:   cfg.Namespace,
+		username: cfg.Username,
+		password: cfg.Password,
+		interval: cfg.Interval,
+		now:      time.Now,
+	}, nil
+}
+
+// Fetch 读取配置内容
+func (n *Nacos) Fetch(ctx context.Context) ([]byte, error) {
+	query := url.Values{"dataId": {n.dataID}, "group": {n.group}}
+	if n.tenant != "" {
+		query.Set("tenant", n.tenant)
+	}
+	resp, err := n.request(ctx, http.MethodGet, "nacos/v1/cs/configs", query, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.status {
+	case http.StatusOK:
+		return resp.body, nil
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%w: nacos %s/%s", ErrNotFound, n.group, n.dataID)
+	default:
+		return nil, fmt.Errorf("nacos: unexpected status %d: %s", resp.status, truncate(resp.body))
+	}
+}
+
+// Watch 通过长轮询订阅配置变更
+func (n *Nacos) Watch(ctx context.Context, onChange func(data []byte)) error {
+	var last []byte
+	for {
+		data, err := n.Fetch(ctx)
+		if err == nil {
+			last = data
+			break
+		}
+		if !sleep(ctx, n.interval) {
+			return nil
+		}
+	}
+
+	for {
+		changed, err := n.listen(ctx, last)
+		if err != nil {
+			if ctx.Err() != nil || !sleep(ctx, n.interval) {
+				return nil
+			}
+			continue
+		}
+		if !changed {
+			continue
+		}
+		data, err := n.Fetch(ctx)
+		if err != nil {
+			if ctx.Err() != nil || !sleep(ctx, n.interval) {
+				return nil
+			}
+			continue
+		}
+		if string(data) != string(last) {
+			last = data
+			onChange(data)
+		}
+	}
+}
+
+// listen 长轮询监听配置（服务端内容的 MD5 与本地不同时返回 true）
+func (n *Nacos) listen(ctx context.Context, current []byte) (bool, error) {
+	sum := md5.Sum(current)
+	listening := n.dataID + "\x02" + n.group + "\x02" + hex.EncodeToString(sum[:])
+	if n.tenant != "" {
+		listening += "\x02" + n.tenant
+	}
+	form := url.Values{"Listening-Configs": {listening + "\x01"}}
+	resp, err := n.request(ctx, http.MethodPost, "nacos/v1/cs/configs/listener", nil, form, nacosPollTimeout)
+	if err != nil {
+		return false, err
+	}
+	if resp.status != http.StatusOK {
+		return false, fmt.Errorf("nacos: unexpected status %d: %s", resp.status, truncate(resp.body))
+	}
+	return strings.TrimSpace(string(resp.body)) != "", nil
+}
+
+// request 发送请求（开启鉴权时携带令牌，令牌失效时重新登录一次）
+func (n *Nacos) request(ctx context.Context, method string, path string, query url.Values, form url.Values, wait time.Duration) (*response, error) {
+	send := func(token string) (*response, error) {
+		return n.client.do(ctx, wait, func(ctx context.Context, base *url.URL) (*http.Request, error) {
+			u := base.JoinPath(path)
+			q := url.Values{}
+			for key, values := range query {
+				q[key] = values
+			}
+			if token != "" {
+				q.Set("accessToken", token)
+			}
+			u.RawQuery = q.Encode()
+			body := ""
+			if form != nil {
+				body = form.Encode()
+			}
+			req, err := http.NewRequestWithContext(ctx, method, u.String(), strings.NewReader(body))
+			if err != nil {
+				return nil, err
+			}
+			if form != nil {
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+			if wait > 0 {
+				req.Header.Set("Long-Pulling-Timeout", fmt.Sprint(wait.Milliseconds()))
+			}
+			return req, nil
+		})
+	}
+
+	token, err := n.accessToken(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := send(token)
+	if err == nil && token != "" && resp.status == http.StatusForbidden {
+		if token, err = n.accessToken(ctx, true); err != nil {
+			return nil, err
+		}
+		resp, err = send(token)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("nacos: %w", err)
+	}
+	return resp, nil
+}
+
+// accessToken 获取访问令牌（未配置用户名时为空，令牌在有效期的 90% 后刷新）
+func (n *Nacos) accessToken(ctx context.Context, refresh bool) (string, error) {
+	if n.username == "" {
+		return "", nil
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !refresh && n.token != "" && n.now().Before(n.tokenExpires) {
+		return n.token, nil
+	}
+
+	form := url.Values{"username": {n.username}, "password": {n.password}}
+	resp, err := n.client.do(ctx, 0, func(ctx context.Context, base *url.URL) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, base.JoinPath("nacos/v1/auth/login").String(), strings.NewReader(form.Encode()))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("nacos: login failed: %w", err)
+	}
+	var result struct {
+		AccessToken string `json:"accessToken"`
+		TokenTTL    int64  `json:"tokenTtl"`
+	}
+	if resp.status != http.StatusOK || json.Unmarshal(resp.body, &result) != nil || result.AccessToken == "" {
+		return "", fmt.Errorf("nacos: login failed: status %d: %s", resp.status, truncate(resp.body))
+	}
+	n.token = result.AccessToken
+	n.tokenExpires = n.now().Add(time.Duration(result.TokenTTL) * time.Second * 9 / 10)
+	return n.token, nil
+}
@@ -0,0 +1,123 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+// ErrNotFound 远程配置不存在
+var ErrNotFound = errors.New("remote config not found")
+
+// 注册内置配置中心（导入本包后 config.LoadConfig 可按 remote.provider 创建）
+func init() {
+	config.RegisterRemoteProvider("etcd", func(cfg *config.RemoteConfig) (config.RemoteProvider, error) {
+		return NewEtcd(cfg)
+	})
+	config.RegisterRemoteProvider("consul", func(cfg *config.RemoteConfig) (config.RemoteProvider, error) {
+		return NewConsul(cfg)
+	})
+	config.RegisterRemoteProvider("nacos", func(cfg *config.RemoteConfig) (config.RemoteProvider, error) {
+		return NewNacos(cfg)
+	})
+}
+
+// client 配置中心 HTTP 客户端（多个服务地址依次重试）
+type client struct {
+	endpoints []*url.URL
+	timeout   time.Duration
+	http      *http.Client
+}
+
+// response 已读取的响应
+type response struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+// newClient 创建客户端（未配置服务地址时使用默认地址）
+func newClient(cfg *config.RemoteConfig, defaultEndpoint string) (*client, error) {
+	if cfg.Key == "" {
+		return nil, errors.New("remote config key is required")
+	}
+	endpoints := cfg.Endpoints
+	if len(endpoints) == 0 {
+		endpoints = []string{defaultEndpoint}
+	}
+	c := &client{timeout: cfg.Timeout, http: &http.Client{}}
+	for _, endpoint := range endpoints {
+		u, err := url.Parse(endpoint)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid remote config endpoint: %s", endpoint)
+		}
+		c.endpoints = append(c.endpoints, u)
+	}
+	return c, nil
+}
+
+// do 依次向各服务地址发送请求，网络错误或 5xx 响应时尝试下一个地址
+// - wait 为长轮询的服务端等待时间，请求超时为 wait 加上配置的超时时间
+func (c *client) do(ctx context.Context, wait time.Duration, build func(ctx context.Context, base *url.URL) (*http.Request, error)) (*response, error) {
+	var lastErr error
+	for _, base := range c.endpoints {
+		resp, err := c.send(ctx, wait, base, build)
+		if err == nil && resp.status < http.StatusInternalServerError {
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err == nil {
+			err = fmt.Errorf("unexpected status %d from %s: %s", resp.status, base.Host, truncate(resp.body))
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// send 发送单个请求并读取响应
+func (c *client) send(ctx context.Context, wait time.Duration, base *url.URL, build func(ctx context.Context, base *url.URL) (*http.Request, error)) (*response, error) {
+	ctx, cancel := context.WithTimeout(ctx, wait+c.timeout)
+	defer cancel()
+	req, err := build(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 8<<20))
+	if err != nil {
+		return nil, err
+	}
+	return &response{status: resp.StatusCode, header: resp.Header, body: body}, nil
+}
+
+// truncate 截断错误响应内容
+func truncate(body []byte) string {
+	if len(body) > 256 {
+		return string(body[:256]) + "..."
+	}
+	return string(body)
+}
+
+// sleep 等待指定时间，上下文取消时返回 false
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
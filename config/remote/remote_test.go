@@ -0,0 +1,357 @@
+package remote
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+/*
+远程配置中心测试
+
+本文件用于测试 etcd、Consul、Nacos 配置中心的读取与变更订阅，以及通过本地配置的 remote 段加载远程配置。
+
+运行命令：
+go test -v -run "^Test(Consul|Etcd|Nacos|LoadConfig).*$"
+
+测试内容：
+1. Consul KV 读取、多地址重试与阻塞查询订阅 (Consul)
+2. etcd 读取、令牌过期重新认证与修订号轮询订阅 (Etcd)
+3. Nacos 登录、读取与长轮询订阅 (Nacos)
+4. 按本地配置的 remote 段创建配置中心并叠加远程配置 (LoadConfig)
+*/
+
+// kvStore 测试用键值存储（值变化时修订号递增）
+type kvStore struct {
+	mu       sync.Mutex
+	value    []byte
+	revision int
+}
+
+func (s *kvStore) set(value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.value = []byte(value)
+	s.revision++
+}
+
+func (s *kvStore) get() ([]byte, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.value, s.revision
+}
+
+// waitChange 等待修订号不同于 revision（最长 timeout）
+func (s *kvStore) waitChange(revision int, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if _, rev := s.get(); rev != revision {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// watchOnce 启动订阅并返回接收变更内容的通道与停止函数
+func watchOnce(t *testing.T, p config.RemoteProvider) (<-chan string, func()) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	changes := make(chan string, 10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = p.Watch(ctx, func(data []byte) { changes <- string(data) })
+	}()
+	return changes, func() {
+		cancel()
+		<-done
+	}
+}
+
+func expectChange(t *testing.T, changes <-chan string, want string) {
+	t.Helper()
+	select {
+	case got := <-changes:
+		if got != want {
+			t.Errorf("变更内容错误: got %q, want %q", got, want)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatalf("未收到配置变更: %q", want)
+	}
+}
+
+func newConsulServer(t *testing.T, store *kvStore) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/kv/config/app.yaml" || r.Header.Get("X-Consul-Token") != "acl-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if index, _ := strconv.Atoi(r.URL.Query().Get("index")); index > 0 {
+			store.waitChange(index, time.Second)
+		}
+		value, rev := store.get()
+		w.Header().Set("X-Consul-Index", strconv.Itoa(rev))
+		if value == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(value)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestConsul(t *testing.T) {
+	store := &kvStore{}
+	srv := newConsulServer(t, store)
+
+	// 第一个地址不可用时重试下一个地址
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	p, err := NewConsul(&config.RemoteConfig{
+		Endpoints: []string{down.URL, srv.URL},
+		Key:       "/config/app.yaml",
+		Token:     "acl-token",
+		Timeout:   time.Second,
+		Interval:  20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := p.Fetch(context.Background()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("键不存在期望 ErrNotFound: %v", err)
+	}
+	store.set("name: v1\n")
+	data, err := p.Fetch(context.Background())
+	if err != nil || string(data) != "name: v1\n" {
+		t.Fatalf("读取配置失败: %q, %v", data, err)
+	}
+
+	changes, stop := watchOnce(t, p)
+	defer stop()
+	time.Sleep(50 * time.Millisecond)
+	store.set("name: v2\n")
+	expectChange(t, changes, "name: v2\n")
+	// 内容未变化时不通知
+	store.set("name: v2\n")
+	store.set("name: v3\n")
+	expectChange(t, changes, "name: v3\n")
+}
+
+func TestEtcd(t *testing.T) {
+	store := &kvStore{}
+	store.set("name: v1\n")
+	var mu sync.Mutex
+	token, auths := "token-1", 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/v3/auth/authenticate":
+			var req map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req["name"] != "root" || req["password"] != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			auths++
+			token = "token-" + strconv.Itoa(auths)
+			_ = json.NewEncoder(w).Encode(map[string]string{"token": token})
+		case "/v3/kv/range":
+			if r.Header.Get("Authorization") != token {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			var req map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if key, _ := base64.StdEncoding.DecodeString(req["key"]); string(key) != "/config/app.yaml" {
+				_ = json.NewEncoder(w).Encode(map[string]any{})
+				return
+			}
+			value, rev := store.get()
+			_ = json.NewEncoder(w).Encode(map[string]any{"kvs": []map[string]string{{
+				"value":        base64.StdEncoding.EncodeToString(value),
+				"mod_revision": strconv.Itoa(rev),
+			}}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	p, err := NewEtcd(&config.RemoteConfig{
+		Endpoints: []string{srv.URL},
+		Key:       "/config/app.yaml",
+		Username:  "root",
+		Password:  "secret",
+		Timeout:   time.Second,
+		Interval:  20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := p.Fetch(context.Background())
+	if err != nil || string(data) != "name: v1\n" {
+		t.Fatalf("读取配置失败: %q, %v", data, err)
+	}
+
+	// 令牌失效后重新认证
+	mu.Lock()
+	token = "rotated"
+	mu.Unlock()
+	if _, err := p.Fetch(context.Background()); err != nil {
+		t.Fatalf("令牌失效后应重新认证: %v", err)
+	}
+	if auths != 2 {
+		t.Errorf("认证次数错误: %d", auths)
+	}
+
+	changes, stop := watchOnce(t, p)
+	defer stop()
+	time.Sleep(50 * time.Millisecond)
+	store.set("name: v2\n")
+	expectChange(t, changes, "name: v2\n")
+
+	missing, _ := NewEtcd(&config.RemoteConfig{Endpoints: []string{srv.URL}, Key: "/missing", Username: "root", Password: "secret", Timeout: time.Second})
+	if _, err := missing.Fetch(context.Background()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("键不存在期望 ErrNotFound: %v", err)
+	}
+}
+
+func TestNacos(t *testing.T) {
+	store := &kvStore{}
+	store.set("name: v1\n")
+	var logins int
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/nacos/v1/auth/login" {
+			if r.FormValue("username") != "nacos" || r.FormValue("password") != "secret" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			mu.Lock()
+			logins++
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(map[string]any{"accessToken": "nacos-token", "tokenTtl": 18000})
+			return
+		}
+		if r.URL.Query().Get("accessToken") != "nacos-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/nacos/v1/cs/configs":
+			q := r.URL.Query()
+			if q.Get("dataId") != "app.yaml" || q.Get("group") != "APP" || q.Get("tenant") != "prod" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			value, _ := store.get()
+			_, _ = w.Write(value)
+		case "/nacos/v1/cs/configs/listener":
+			if r.Header.Get("Long-Pulling-Timeout") == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			parts := strings.Split(strings.TrimSuffix(r.FormValue("Listening-Configs"), "\x01"), "\x02")
+			if len(parts) != 4 || parts[0] != "app.yaml" || parts[1] != "APP" || parts[3] != "prod" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, rev := store.get()
+			for i := 0; i < 2; i++ {
+				value, _ := store.get()
+				sum := md5.Sum(value)
+				if hex.EncodeToString(sum[:]) != parts[2] {
+					_, _ = w.Write([]byte("app.yaml%02APP%02prod%01"))
+					return
+				}
+				store.waitChange(rev, time.Second)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	p, err := NewNacos(&config.RemoteConfig{
+		Endpoints: []string{srv.URL},
+		Key:       "app.yaml",
+		Group:     "APP",
+		Namespace: "prod",
+		Username:  "nacos",
+		Password:  "secret",
+		Timeout:   time.Second,
+		Interval:  20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := p.Fetch(context.Background())
+	if err != nil || string(data) != "name: v1\n" {
+		t.Fatalf("读取配置失败: %q, %v", data, err)
+	}
+
+	changes, stop := watchOnce(t, p)
+	defer stop()
+	time.Sleep(50 * time.Millisecond)
+	store.set("name: v2\n")
+	expectChange(t, changes, "name: v2\n")
+
+	// 令牌在有效期内复用
+	mu.Lock()
+	defer mu.Unlock()
+	if logins != 1 {
+		t.Errorf("登录次数错误: %d", logins)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	store := &kvStore{}
+	store.set("port: 9000\ndatabase:\n  host: remote-db\n")
+	srv := newConsulServer(t, store)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "name: local-app\nport: 8000\ndatabase:\n  host: local-db\n  username: local-user\n" +
+		"remote:\n  enabled: true\n  provider: consul\n  endpoints: [\"" + srv.URL + "\"]\n  key: config/app.yaml\n  token: acl-token\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	if cfg.Name != "local-app" || cfg.Port != 9000 {
+		t.Errorf("远程配置未叠加: name=%q port=%d", cfg.Name, cfg.Port)
+	}
+	if cfg.Database.Host != "remote-db" || cfg.Database.Username != "local-user" {
+		t.Errorf("远程配置应按字段合并: %+v", cfg.Database)
+	}
+	if cfg.Remote == nil || !cfg.Remote.Enabled || cfg.Remote.Provider != "consul" {
+		t.Errorf("远程配置中心设置错误: %+v", cfg.Remote)
+	}
+
+	p, err := config.NewRemoteProvider(cfg.Remote)
+	if err != nil {
+		t.Fatalf("创建配置中心失败: %v", err)
+	}
+	if _, ok := p.(*Consul); !ok {
+		t.Errorf("配置中心类型错误: %T", p)
+	}
+}
@@ -0,0 +1,34 @@
+package config
+
+// 响应字段命名方式
+const (
+	ResponseFieldCaseSnake = "snake" // 下划线命名，例如 next_cursor
+	ResponseFieldCaseCamel = "camel" // 驼峰命名，例如 nextCursor
+)
+
+// ResponseConfig 统一响应格式配置
+type ResponseConfig struct {
+	SuccessCode int    `yaml:"successCode"` // 成功时的业务状态码
+	ErrorCode   int    `yaml:"errorCode"`   // 失败时的业务状态码（带错误码的错误使用其错误码）
+	TraceID     bool   `yaml:"traceId"`     // 响应中包含链路追踪ID（未启用链路追踪时为请求ID）
+	FieldCase   string `yaml:"fieldCase"`   // 响应外层与分页字段命名: snake, camel
+}
+
+// DefaultResponseConfig 返回默认统一响应格式配置
+func DefaultResponseConfig() *ResponseConfig {
+	return &ResponseConfig{
+		SuccessCode: 0,
+		ErrorCode:   -1,
+		FieldCase:   ResponseFieldCaseSnake,
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *ResponseConfig) SetDefaults() {
+	if c.SuccessCode == 0 && c.ErrorCode == 0 {
+		c.ErrorCode = -1
+	}
+	if c.FieldCase == "" {
+		c.FieldCase = ResponseFieldCaseSnake
+	}
+}
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// SaveOption 保存配置可选项
+type SaveOption func(*saveOptions)
+
+type saveOptions struct {
+	preserveComments bool
+}
+
+// WithPreserveComments 保留目标文件中已有的注释（按键路径匹配，新增的键没有注释）
+func WithPreserveComments() SaveOption {
+	return func(o *saveOptions) { o.preserveComments = true }
+}
+
+// SaveConfig 保存配置到文件
+// - 按 yaml 标签序列化完整配置，键名与 LoadConfig 读取时一致，未设置（nil）的配置段不写入
+// - 字符串值中的 ${ 写为 $${，重新加载后保持原值
+// - 先写入同目录下的临时文件再重命名，写入失败时原文件保持不变
+// - 文件已存在时沿用原文件权限，否则为 0644
+func SaveConfig(config *AppConfig, filePath string, opts ...SaveOption) error {
+	if config == nil {
+		return errors.New("配置不能为空")
+	}
+	o := &saveOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var root yaml.Node
+	if err := root.Encode(config); err != nil {
+		return fmt.Errorf("序列化配置失败: %w", err)
+	}
+	pruneNull(&root)
+	escapePlaceholders(&root)
+	doc := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{&root}}
+
+	if o.preserveComments {
+		if data, err := os.ReadFile(filePath); err == nil {
+			var old yaml.Node
+			if err := yaml.Unmarshal(data, &old); err != nil {
+				return fmt.Errorf("解析原配置文件失败: %w", err)
+			}
+			copyComments(&old, doc)
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("读取原配置文件失败: %w", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("序列化配置失败: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("序列化配置失败: %w", err)
+	}
+
+	if err := writeFileAtomic(filePath, buf.Bytes()); err != nil {
+		return fmt.Errorf("写入配置文件失败: %w", err)
+	}
+	return nil
+}
+
+// pruneNull 删除映射中值为 null 的键（未设置的配置段）
+func pruneNull(n *yaml.Node) {
+	switch n.Kind {
+	case yaml.MappingNode:
+		content := n.Content[:0]
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			if value.Kind == yaml.ScalarNode && value.Tag == "!!null" {
+				continue
+			}
+			pruneNull(value)
+			content = append(content, key, value)
+		}
+		n.Content = content
+	case yaml.SequenceNode, yaml.DocumentNode:
+		for _, c := range n.Content {
+			pruneNull(c)
+		}
+	}
+}
+
+// escapePlaceholders 转义字符串值中的 ${（写为 $${），避免重新加载时被当作环境变量占位符展开
+func escapePlaceholders(n *yaml.Node) {
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!str" {
+		n.Value = strings.ReplaceAll(n.Value, "${", "$${")
+	}
+	for _, c := range n.Content {
+		escapePlaceholders(c)
+	}
+}
+
+// copyComments 将原节点树的注释复制到新节点树（映射按键名匹配，序列按下标匹配）
+func copyComments(from, to *yaml.Node) {
+	if from.Kind != to.Kind {
+		return
+	}
+	to.HeadComment, to.LineComment, to.FootComment = from.HeadComment, from.LineComment, from.FootComment
+	switch to.Kind {
+	case yaml.MappingNode:
+		keys := make(map[string]int, len(from.Content)/2)
+		for i := 0; i+1 < len(from.Content); i += 2 {
+			keys[from.Content[i].Value] = i
+		}
+		for i := 0; i+1 < len(to.Content); i += 2 {
+			j, ok := keys[to.Content[i].Value]
+			if !ok {
+				continue
+			}
+			copyComments(from.Content[j], to.Content[i])
+			copyComments(from.Content[j+1], to.Content[i+1])
+		}
+	case yaml.SequenceNode, yaml.DocumentNode:
+		for i := 0; i < len(to.Content) && i < len(from.Content); i++ {
+			copyComments(from.Content[i], to.Content[i])
+		}
+	}
+}
+
+// writeFileAtomic 原子写入文件（临时文件写入并同步后重命名；路径为符号链接时写入链接目标）
+func writeFileAtomic(filePath string, data []byte) (err error) {
+	if target, err := filepath.EvalSymlinks(filePath); err == nil {
+		filePath = target
+	}
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("创建目录失败: %w", err)
+	}
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(filePath); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(filePath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+	if _, err = tmp.Write(data); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Chmod(mode); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filePath)
+}
@@ -0,0 +1,61 @@
+package config
+
+import "time"
+
+// SecretConfig 外部密钥配置（配置值形如 vault:secret/data/app#jwt_secret、aws-sm:prod/app#jwt_secret 时，启动时从密钥服务读取）
+type SecretConfig struct {
+	Enabled       bool          `yaml:"enabled"`       // 是否启用
+	CacheTTL      time.Duration `yaml:"cacheTTL"`      // 密钥缓存时间（Vault 租约更短时以租约为准）
+	RenewInterval time.Duration `yaml:"renewInterval"` // 检查缓存过期并重新读取的间隔（0 表示不续期）
+	Timeout       time.Duration `yaml:"timeout"`       // 请求超时
+
+	// HashiCorp Vault
+	Vault *VaultSecretConfig `yaml:"vault"`
+	// AWS Secrets Manager
+	AWS *AWSSecretConfig `yaml:"aws"`
+}
+
+// VaultSecretConfig Vault 配置（为空的字段从 VAULT_ADDR、VAULT_TOKEN、VAULT_NAMESPACE 环境变量读取）
+type VaultSecretConfig struct {
+	Address   string `yaml:"address"`   // 服务地址（例如 https://vault.example.com:8200）
+	Token     string `yaml:"token"`     // 访问令牌（建议通过环境变量或令牌文件提供）
+	TokenFile string `yaml:"tokenFile"` // 令牌文件（例如 Vault Agent 写入的令牌）
+	Namespace string `yaml:"namespace"` // 命名空间（Vault Enterprise）
+}
+
+// AWSSecretConfig AWS Secrets Manager 配置（为空的字段从 AWS_REGION、AWS_ACCESS_KEY_ID、AWS_SECRET_ACCESS_KEY、AWS_SESSION_TOKEN 环境变量读取）
+type AWSSecretConfig struct {
+	Region          string `yaml:"region"`          // 区域
+	Endpoint        string `yaml:"endpoint"`        // 接口地址（为空时使用 https://secretsmanager.{region}.amazonaws.com）
+	AccessKeyID     string `yaml:"accessKeyId"`     // 访问密钥ID
+	SecretAccessKey string `yaml:"secretAccessKey"` // 访问密钥
+	SessionToken    string `yaml:"sessionToken"`    // 临时凭证令牌
+}
+
+// DefaultSecretConfig 返回默认外部密钥配置
+func DefaultSecretConfig() *SecretConfig {
+	return &SecretConfig{
+		Enabled:       false,
+		CacheTTL:      time.Hour,
+		RenewInterval: time.Minute,
+		Timeout:       10 * time.Second,
+		Vault:         &VaultSecretConfig{},
+		AWS:           &AWSSecretConfig{},
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *SecretConfig) SetDefaults() {
+	if c.CacheTTL == 0 {
+		c.CacheTTL = time.Hour
+	}
+	if c.Timeout == 0 {
+		c.Timeout = 10 * time.Second
+	}
+	if c.Vault == nil {
+		c.Vault = &VaultSecretConfig{}
+	}
+	if c.AWS == nil {
+		c.AWS = &AWSSecretConfig{}
+	}
+}
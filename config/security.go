@@ -0,0 +1,51 @@
+package config
+
+import (
+	"time"
+)
+
+// SecurityConfig 管理员登录安全配置
+type SecurityConfig struct {
+	MaxLoginAttempts   int           `yaml:"maxLoginAttempts"`   // 连续登录失败次数达到该值后锁定账户
+	LockoutDuration    time.Duration `yaml:"lockoutDuration"`    // 首次锁定时长
+	BackoffMultiplier  float64       `yaml:"backoffMultiplier"`  // 再次锁定时长倍数（指数退避，为 1 时每次锁定时长相同）
+	MaxLockoutDuration time.Duration `yaml:"maxLockoutDuration"` // 最长锁定时长
+}
+
+// DefaultSecurityConfig 返回默认登录安全配置
+func DefaultSecurityConfig() *SecurityConfig {
+	return &SecurityConfig{
+		MaxLoginAttempts:   5,
+		LockoutDuration:    5 * time.Minute,
+		BackoffMultiplier:  2,
+		MaxLockoutDuration: 24 * time.Hour,
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *SecurityConfig) SetDefaults() {
+	if c.MaxLoginAttempts == 0 {
+		c.MaxLoginAttempts = 5
+	}
+	if c.LockoutDuration == 0 {
+		c.LockoutDuration = 5 * time.Minute
+	}
+	if c.BackoffMultiplier == 0 {
+		c.BackoffMultiplier = 2
+	}
+	if c.MaxLockoutDuration == 0 {
+		c.MaxLockoutDuration = 24 * time.Hour
+	}
+}
+
+// LockoutDurationFor 计算第 n 次（从 1 开始）连续锁定的锁定时长
+func (c *SecurityConfig) LockoutDurationFor(n int) time.Duration {
+	duration := c.LockoutDuration
+	for i := 1; i < n && duration < c.MaxLockoutDuration; i++ {
+		duration = time.Duration(float64(duration) * c.BackoffMultiplier)
+	}
+	if c.MaxLockoutDuration > 0 && duration > c.MaxLockoutDuration {
+		duration = c.MaxLockoutDuration
+	}
+	return duration
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"time"
+)
+
+// SignatureConfig 开放接口请求签名配置（HMAC-SHA256）
+type SignatureConfig struct {
+	Enabled     bool              `yaml:"enabled"`     // 是否启用（需要启用缓存，用于防重放）
+	Window      time.Duration     `yaml:"window"`      // 请求时间戳允许的偏差，同时作为 nonce 的保留时长
+	MaxBodySize int64             `yaml:"maxBodySize"` // 参与签名的请求体大小上限(bytes)
+	Clients     map[string]string `yaml:"clients"`     // 接入方密钥，键为 AppID，值为签名密钥
+}
+
+// DefaultSignatureConfig 返回默认请求签名配置
+func DefaultSignatureConfig() *SignatureConfig {
+	return &SignatureConfig{
+		Enabled:     false,
+		Window:      5 * time.Minute,
+		MaxBodySize: 10 << 20,
+		Clients:     map[string]string{},
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *SignatureConfig) SetDefaults() {
+	if c.Window == 0 {
+		c.Window = 5 * time.Minute
+	}
+	if c.MaxBodySize == 0 {
+		c.MaxBodySize = 10 << 20
+	}
+	if c.Clients == nil {
+		c.Clients = map[string]string{}
+	}
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"time"
+)
+
+// SMSConfig 短信配置
+type SMSConfig struct {
+	Driver   string        `yaml:"driver"`   // 短信驱动: aliyun, twilio, log（仅记录日志，用于开发环境）
+	Endpoint string        `yaml:"endpoint"` // 接口地址（为空时使用服务商默认地址）
+	Timeout  time.Duration `yaml:"timeout"`  // 请求超时
+
+	// 阿里云短信配置
+	AccessKeyID     string `yaml:"accessKeyId"`     // 访问密钥ID
+	AccessKeySecret string `yaml:"accessKeySecret"` // 访问密钥
+	SignName        string `yaml:"signName"`        // 短信签名
+	Region          string `yaml:"region"`          // 区域
+
+	// Twilio 配置
+	AccountSID string `yaml:"accountSid"` // 账号SID
+	AuthToken  string `yaml:"authToken"`  // 认证令牌
+	From       string `yaml:"from"`       // 发送号码
+
+	// 验证码配置
+	Code *SMSCodeConfig `yaml:"code"`
+}
+
+// SMSCodeConfig 短信验证码配置
+type SMSCodeConfig struct {
+	Template    string        `yaml:"template"`    // 验证码模板（阿里云为模板编号，其他驱动为短信内容，${code} 替换为验证码）
+	Length      int           `yaml:"length"`      // 验证码位数
+	TTL         time.Duration `yaml:"ttl"`         // 验证码有效期
+	Interval    time.Duration `yaml:"interval"`    // 同一手机号重新发送间隔
+	DailyLimit  int           `yaml:"dailyLimit"`  // 同一手机号每日发送上限（0 表示不限制）
+	MaxAttempts int           `yaml:"maxAttempts"` // 验证码最多校验次数，超过后失效
+}
+
+// DefaultSMSConfig 返回默认短信配置
+func DefaultSMSConfig() *SMSConfig {
+	return &SMSConfig{
+		Driver:  "log",
+		Timeout: 10 * time.Second,
+		Region:  "cn-hangzhou",
+		Code:    DefaultSMSCodeConfig(),
+	}
+}
+
+// DefaultSMSCodeConfig 返回默认短信验证码配置
+func DefaultSMSCodeConfig() *SMSCodeConfig {
+	return &SMSCodeConfig{
+		Template:    "Your verification code is ${code}",
+		Length:      6,
+		TTL:         5 * time.Minute,
+		Interval:    time.Minute,
+		DailyLimit:  10,
+		MaxAttempts: 5,
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *SMSConfig) SetDefaults() {
+	if c.Driver == "" {
+		c.Driver = "log"
+	}
+	if c.Timeout == 0 {
+		c.Timeout = 10 * time.Second
+	}
+	if c.Region == "" {
+		c.Region = "cn-hangzhou"
+	}
+	if c.Code != nil {
+		c.Code.SetDefaults()
+	} else {
+		c.Code = DefaultSMSCodeConfig()
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *SMSCodeConfig) SetDefaults() {
+	if c.Template == "" {
+		c.Template = "Your verification code is ${code}"
+	}
+	if c.Length == 0 {
+		c.Length = 6
+	}
+	if c.TTL == 0 {
+		c.TTL = 5 * time.Minute
+	}
+	if c.Interval == 0 {
+		c.Interval = time.Minute
+	}
+	if c.MaxAttempts == 0 {
+		c.MaxAttempts = 5
+	}
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"time"
+)
+
+// StorageConfig 文件存储配置
+type StorageConfig struct {
+	Driver string `yaml:"driver"` // 存储驱动: local, s3（兼容 MinIO 等 S3 协议服务）, oss（阿里云对象存储）
+
+	// 本地存储配置
+	Root string `yaml:"root"` // 本地存储根目录
+
+	// 对象存储配置
+	Endpoint        string        `yaml:"endpoint"`        // 服务地址，例如 https://s3.us-east-1.amazonaws.com、https://oss-cn-hangzhou.aliyuncs.com
+	Region          string        `yaml:"region"`          // 区域（S3 签名使用）
+	Bucket          string        `yaml:"bucket"`          // 存储桶
+	AccessKeyID     string        `yaml:"accessKeyId"`     // 访问密钥ID
+	AccessKeySecret string        `yaml:"accessKeySecret"` // 访问密钥
+	PathStyle       bool          `yaml:"pathStyle"`       // S3 使用路径风格地址（endpoint/bucket/key），MinIO 等通常需要开启
+	Timeout         time.Duration `yaml:"timeout"`         // 请求超时
+
+	BaseURL string `yaml:"baseUrl"` // 文件访问地址前缀（本地存储为静态文件路由，对象存储可设置为 CDN 域名，为空时使用存储桶地址）
+}
+
+// DefaultStorageConfig 返回默认文件存储配置
+func DefaultStorageConfig() *StorageConfig {
+	return &StorageConfig{
+		Driver:  "local",
+		Root:    "static/uploads",
+		BaseURL: "/static/uploads",
+		Region:  "us-east-1",
+		Timeout: 30 * time.Second,
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *StorageConfig) SetDefaults() {
+	if c.Driver == "" {
+		c.Driver = "local"
+	}
+	if c.Driver == "local" {
+		if c.Root == "" {
+			c.Root = "static/uploads"
+		}
+		if c.BaseURL == "" {
+			c.BaseURL = "/" + c.Root
+		}
+	}
+	if c.Region == "" {
+		c.Region = "us-east-1"
+	}
+	if c.Timeout == 0 {
+		c.Timeout = 30 * time.Second
+	}
+}
@@ -0,0 +1,36 @@
+package config
+
+// TelemetryConfig 链路追踪配置（OpenTelemetry）
+type TelemetryConfig struct {
+	Enabled     bool              `yaml:"enabled"`     // 是否启用
+	ServiceName string            `yaml:"serviceName"` // 服务名称，为空时使用应用名称
+	Exporter    string            `yaml:"exporter"`    // 导出器: otlp-grpc, otlp-http, jaeger, stdout
+	Endpoint    string            `yaml:"endpoint"`    // OTLP 接收地址，例如 localhost:4317
+	Insecure    bool              `yaml:"insecure"`    // 是否使用非 TLS 连接
+	Headers     map[string]string `yaml:"headers"`     // OTLP 请求头（例如鉴权 Token）
+	SampleRatio float64           `yaml:"sampleRatio"` // 采样率 0-1（父 Span 已采样时始终采样）
+}
+
+// DefaultTelemetryConfig 返回默认链路追踪配置
+func DefaultTelemetryConfig() *TelemetryConfig {
+	return &TelemetryConfig{
+		Enabled:     false,
+		Exporter:    "otlp-grpc",
+		Endpoint:    "localhost:4317",
+		Insecure:    true,
+		SampleRatio: 1,
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *TelemetryConfig) SetDefaults() {
+	if c.Exporter == "" {
+		c.Exporter = "otlp-grpc"
+	}
+	if c.Endpoint == "" {
+		c.Endpoint = "localhost:4317"
+	}
+	if c.SampleRatio == 0 {
+		c.SampleRatio = 1
+	}
+}
@@ -0,0 +1,19 @@
+package config
+
+// TemplateConfig HTML 模板配置（服务端渲染页面，例如邮件预览与简单的管理工具）
+type TemplateConfig struct {
+	Enabled bool   `yaml:"enabled"` // 是否启用
+	Dir     string `yaml:"dir"`     // 模板目录（设置后优先于内嵌模板）
+	Layout  string `yaml:"layout"`  // 默认布局（例如 layouts/base.html，为空时不使用布局）
+	Reload  bool   `yaml:"reload"`  // 每次渲染前重新加载模板（开发时使用）
+}
+
+// DefaultTemplateConfig 返回默认 HTML 模板配置
+func DefaultTemplateConfig() *TemplateConfig {
+	return &TemplateConfig{
+		Enabled: false,
+		Dir:     "",
+		Layout:  "",
+		Reload:  false,
+	}
+}
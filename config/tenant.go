@@ -0,0 +1,31 @@
+package config
+
+// TenantConfig 多租户配置（按租户ID字段隔离数据与缓存，不支持按数据库 schema 隔离）
+// - 请求的租户按 sources 顺序从请求头、域名中解析，登录 Token、机器令牌与 API Key 的租户优先且必须与请求一致
+// - 启用后认证凭据必须属于租户（管理员在租户请求中登录，签发的 Token 绑定该租户），不属于任何租户的凭据被拒绝
+// - GormBuilder 自动按租户字段（默认 tenant_id）过滤并在创建时赋值，缓存可按租户加键前缀
+type TenantConfig struct {
+	Enabled bool     `yaml:"enabled"` // 是否启用
+	Sources []string `yaml:"sources"` // 解析来源（按顺序）：header, domain
+	Header  string   `yaml:"header"`  // 租户ID请求头
+	Domain  string   `yaml:"domain"`  // 根域名（例如 example.com，acme.example.com 解析为租户 acme）
+}
+
+// DefaultTenantConfig 返回默认多租户配置
+func DefaultTenantConfig() *TenantConfig {
+	return &TenantConfig{
+		Enabled: false,
+		Sources: []string{"header"},
+		Header:  "X-Tenant-ID",
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *TenantConfig) SetDefaults() {
+	if len(c.Sources) == 0 {
+		c.Sources = []string{"header"}
+	}
+	if c.Header == "" {
+		c.Header = "X-Tenant-ID"
+	}
+}
@@ -0,0 +1,43 @@
+package config
+
+// TLSConfig HTTPS 配置
+// - 手动证书：配置 CertFile/KeyFile
+// - 自动证书：启用 AutoCert 并配置 Domains，通过 Let's Encrypt 自动申请与续期（需公网可访问 443 或 HTTP 端口）
+type TLSConfig struct {
+	Enabled  bool   `yaml:"enabled"`  // 是否启用 HTTPS
+	CertFile string `yaml:"certFile"` // 证书文件
+	KeyFile  string `yaml:"keyFile"`  // 私钥文件
+
+	// 自动证书（Let's Encrypt）
+	AutoCert bool     `yaml:"autoCert"` // 是否自动申请证书（启用时忽略 CertFile/KeyFile）
+	Domains  []string `yaml:"domains"`  // 允许申请证书的域名
+	Email    string   `yaml:"email"`    // 证书到期提醒邮箱（可选）
+	CacheDir string   `yaml:"cacheDir"` // 证书缓存目录
+
+	// HTTP 跳转
+	RedirectHTTP bool `yaml:"redirectHttp"` // 是否监听 HTTP 端口并跳转到 HTTPS（自动证书时同时处理 HTTP-01 验证）
+	HTTPPort     int  `yaml:"httpPort"`     // HTTP 监听端口
+}
+
+// DefaultTLSConfig 返回默认 HTTPS 配置
+func DefaultTLSConfig() *TLSConfig {
+	return &TLSConfig{
+		Enabled:  false,
+		Domains:  []string{},
+		CacheDir: "certs",
+		HTTPPort: 80,
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *TLSConfig) SetDefaults() {
+	if c.Domains == nil {
+		c.Domains = []string{}
+	}
+	if c.CacheDir == "" {
+		c.CacheDir = "certs"
+	}
+	if c.HTTPPort == 0 {
+		c.HTTPPort = 80
+	}
+}
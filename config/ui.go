@@ -0,0 +1,35 @@
+package config
+
+import "time"
+
+// UIConfig 内嵌前端配置（单页应用，history 模式下未匹配的页面路径返回入口文件）
+type UIConfig struct {
+	Enabled bool          `yaml:"enabled"` // 是否启用
+	Path    string        `yaml:"path"`    // 访问路径（"/" 时作为未匹配路由的兜底）
+	Dir     string        `yaml:"dir"`     // 本地构建目录（设置后优先于内嵌文件，便于前端单独发布）
+	Index   string        `yaml:"index"`   // 入口文件
+	MaxAge  time.Duration `yaml:"maxAge"`  // 静态资源缓存时间（入口文件不缓存）
+}
+
+// DefaultUIConfig 返回默认内嵌前端配置
+func DefaultUIConfig() *UIConfig {
+	return &UIConfig{
+		Enabled: false,
+		Path:    "/console",
+		Index:   "index.html",
+		MaxAge:  7 * 24 * time.Hour,
+	}
+}
+
+// SetDefaults 设置默认配置值
+func (c *UIConfig) SetDefaults() {
+	if c.Path == "" {
+		c.Path = "/console"
+	}
+	if c.Index == "" {
+		c.Index = "index.html"
+	}
+	if c.MaxAge == 0 {
+		c.MaxAge = 7 * 24 * time.Hour
+	}
+}
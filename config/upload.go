@@ -0,0 +1,87 @@
+package config
+
+import (
+	"time"
+)
+
+// UploadConfig 文件上传配置
+type UploadConfig struct {
+	MaxSize      int64    `yaml:"maxSize"`      // 单个文件最大字节数
+	AllowedExts  []string `yaml:"allowedExts"`  // 允许的扩展名（含点，不区分大小写）
+	AllowedTypes []string `yaml:"allowedTypes"` // 允许的内容类型（按文件内容识别，支持 image/* 形式的通配）
+
+	// 图片尺寸限制（像素，0 表示不限制），防止超大图片解码耗尽内存
+	MaxImageWidth  int `yaml:"maxImageWidth"`
+	MaxImageHeight int `yaml:"maxImageHeight"`
+
+	// 分片上传配置
+	ChunkSize   int64         `yaml:"chunkSize"`   // 分片大小（最后一片可以更小）
+	ChunkDir    string        `yaml:"chunkDir"`    // 分片临时目录（为空时使用系统临时目录，多实例部署时须为共享目录）
+	ChunkExpire time.Duration `yaml:"chunkExpire"` // 未完成的分片上传保留时间，过期后清理
+
+	// 图片处理配置（按上传端点的配置名称，default 为内置上传端点使用的配置，未配置时不处理）
+	Images map[string]*ImageProcessConfig `yaml:"images"`
+}
+
+// DefaultImageProfile 内置上传端点使用的图片处理配置名称
+const DefaultImageProfile = "default"
+
+// ImageProcessConfig 上传图片处理配置
+type ImageProcessConfig struct {
+	StripExif  bool              `yaml:"stripExif"`  // 去除 EXIF 等元数据（按 EXIF 方向旋转后重新编码）
+	Format     string            `yaml:"format"`     // 转换格式: jpeg, png, webp（须注册编码器），为空时保持原格式
+	Quality    int               `yaml:"quality"`    // 有损编码质量（1-100）
+	Thumbnails []ThumbnailConfig `yaml:"thumbnails"` // 缩略图
+}
+
+// ThumbnailConfig 缩略图配置（等比缩放至宽高范围内，不放大）
+type ThumbnailConfig struct {
+	Name   string `yaml:"name"`   // 名称（追加到文件名后，如 a_small.jpg）
+	Width  int    `yaml:"width"`  // 最大宽度（0 表示按高度缩放）
+	Height int    `yaml:"height"` // 最大高度（0 表示按宽度缩放）
+}
+
+// SetDefaults 设置默认配置值
+func (c *ImageProcessConfig) SetDefaults() {
+	if c.Quality == 0 {
+		c.Quality = 85
+	}
+}
+
+// DefaultUploadConfig 返回默认文件上传配置
+func DefaultUploadConfig() *UploadConfig {
+	return &UploadConfig{
+		MaxSize:        500 << 20,
+		AllowedExts:    []string{".jpg", ".jpeg", ".png", ".gif", ".webp", ".pdf", ".txt", ".csv", ".doc", ".docx", ".xls", ".xlsx", ".zip", ".mp4"},
+		AllowedTypes:   []string{"image/*", "video/mp4", "application/pdf", "text/plain", "application/zip", "application/octet-stream"},
+		MaxImageWidth:  10000,
+		MaxImageHeight: 10000,
+		ChunkSize:      5 << 20,
+		ChunkExpire:    24 * time.Hour,
+	}
+}
+
+// SetDefaults 设置默认配置值（未配置白名单时使用默认白名单）
+func (c *UploadConfig) SetDefaults() {
+	defaults := DefaultUploadConfig()
+	if c.MaxSize == 0 {
+		c.MaxSize = defaults.MaxSize
+	}
+	if len(c.AllowedExts) == 0 {
+		c.AllowedExts = defaults.AllowedExts
+	}
+	if len(c.AllowedTypes) == 0 {
+		c.AllowedTypes = defaults.AllowedTypes
+	}
+	if c.ChunkSize == 0 {
+		c.ChunkSize = defaults.ChunkSize
+	}
+	if c.ChunkExpire == 0 {
+		c.ChunkExpire = defaults.ChunkExpire
+	}
+	for _, image := range c.Images {
+		if image != nil {
+			image.SetDefaults()
+		}
+	}
+}
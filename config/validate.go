@@ -0,0 +1,881 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// FieldError 配置项校验错误
+type FieldError struct {
+	Field   string // 配置项路径（yaml 字段名，例如 database.port、jwt.keys[0].id、databases.report.host）
+	Message string // 错误信息
+}
+
+// Error 实现 error 接口
+func (e *FieldError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// ValidationErrors 配置校验错误列表（一次返回全部错误）
+type ValidationErrors []*FieldError
+
+// Error 实现 error 接口（每行一个错误）
+func (e ValidationErrors) Error() string {
+	lines := make([]string, 0, len(e)+1)
+	lines = append(lines, fmt.Sprintf("配置校验失败（%d 项）:", len(e)))
+	for _, err := range e {
+		lines = append(lines, "  - "+err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap 支持 errors.As 取出单个 *FieldError
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Fields 返回出错的配置项路径
+func (e ValidationErrors) Fields() []string {
+	fields := make([]string, len(e))
+	for i, err := range e {
+		fields[i] = err.Field
+	}
+	return fields
+}
+
+// validator 收集配置校验错误
+type validator struct {
+	errs ValidationErrors
+}
+
+// add 记录错误
+func (v *validator) add(field string, format string, args ...any) {
+	v.errs = append(v.errs, &FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// err 返回收集到的错误，没有错误时返回 nil
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+// port 校验端口范围
+func (v *validator) port(field string, port int, label string) {
+	if port <= 0 || port > 65535 {
+		v.add(field, "无效的%s端口号: %d", label, port)
+	}
+}
+
+// duration 校验字符串形式的时长（为空时跳过）
+func (v *validator) duration(field string, value string) {
+	if value == "" {
+		return
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		v.add(field, "无效的时长: %q（例如 30s、1m）", value)
+		return
+	}
+	if d < 0 {
+		v.add(field, "时长不能为负数: %s", value)
+	}
+}
+
+// nonNegative 校验时长不为负数
+func (v *validator) nonNegative(field string, d time.Duration) {
+	if d < 0 {
+		v.add(field, "时长不能为负数: %s", d)
+	}
+}
+
+// path 校验路由路径以 / 开头
+func (v *validator) path(field string, value string, label string) {
+	if !strings.HasPrefix(value, "/") {
+		v.add(field, "%s必须以 / 开头: %s", label, value)
+	}
+}
+
+// endpoint 校验服务地址（须包含主机）
+func (v *validator) endpoint(field string, value string, label string) {
+	if u, err := url.Parse(value); err != nil || u.Host == "" {
+		v.add(field, "无效的%s: %s", label, value)
+	}
+}
+
+// ValidateConfig 验证配置的有效性，返回 ValidationErrors 列出全部错误
+// - 未配置（nil）的配置段不校验；零值字段视为使用默认值
+func ValidateConfig(config *AppConfig) error {
+	if config == nil {
+		return fmt.Errorf("配置对象不能为空")
+	}
+	v := &validator{}
+
+	// 验证服务配置
+	v.port("port", config.Port, "")
+	v.duration("readTimeout", config.ReadTimeout)
+	v.duration("writeTimeout", config.WriteTimeout)
+	v.duration("idleTimeout", config.IdleTimeout)
+	v.duration("shutdownTimeout", config.ShutdownTimeout)
+	if config.MaxHeader < 0 {
+		v.add("maxHeader", "最大请求头大小不能为负数: %d", config.MaxHeader)
+	}
+
+	// 验证 HTTPS 配置
+	if config.TLS != nil && config.TLS.Enabled {
+		if config.TLS.AutoCert {
+			if len(config.TLS.Domains) == 0 {
+				v.add("tls.domains", "自动证书的域名不能为空")
+			}
+		} else {
+			if config.TLS.CertFile == "" {
+				v.add("tls.certFile", "HTTPS 证书文件不能为空")
+			}
+			if config.TLS.KeyFile == "" {
+				v.add("tls.keyFile", "HTTPS 私钥文件不能为空")
+			}
+		}
+		if config.TLS.RedirectHTTP && (config.TLS.HTTPPort <= 0 || config.TLS.HTTPPort > 65535 || config.TLS.HTTPPort == config.Port) {
+			v.add("tls.httpPort", "无效的 HTTP 跳转端口号: %d", config.TLS.HTTPPort)
+		}
+	}
+
+	validateCors(v, config.Cors)
+
+	// 验证限流配置
+	if config.RateLimit != nil {
+		if config.RateLimit.Rate < 0 {
+			v.add("rateLimit.rate", "每秒请求数限制不能为负数: %d", config.RateLimit.Rate)
+		}
+		if config.RateLimit.Burst < 0 || (config.RateLimit.Rate > 0 && config.RateLimit.Burst == 0) {
+			v.add("rateLimit.burst", "启用限流时突发请求数限制必须大于 0: %d", config.RateLimit.Burst)
+		}
+		for i, p := range config.RateLimit.IncludePaths {
+			v.path(fmt.Sprintf("rateLimit.includePaths[%d]", i), p, "限流路径")
+		}
+		for i, p := range config.RateLimit.ExcludePaths {
+			v.path(fmt.Sprintf("rateLimit.excludePaths[%d]", i), p, "限流路径")
+		}
+		v.nonNegative("rateLimit.idleTtl", config.RateLimit.IdleTTL)
+	}
+
+	// 验证访问日志配置
+	if config.AccessLog != nil && (config.AccessLog.SampleRate < 0 || config.AccessLog.SampleRate > 1) {
+		v.add("accessLog.sampleRate", "访问日志采样率必须在 0-1 之间: %v", config.AccessLog.SampleRate)
+	}
+
+	// 验证审计日志配置
+	if config.Audit != nil {
+		if config.Audit.RetentionDays < 0 {
+			v.add("audit.retentionDays", "审计日志保留天数不能为负数: %d", config.Audit.RetentionDays)
+		}
+		if config.Audit.MaxParamsSize < 0 {
+			v.add("audit.maxParamsSize", "审计日志参数最大字节数不能为负数: %d", config.Audit.MaxParamsSize)
+		}
+	}
+
+	// 验证密码策略配置
+	if config.PasswordPolicy != nil {
+		if config.PasswordPolicy.MinLength < 0 || config.PasswordPolicy.MinLength > 64 {
+			v.add("passwordPolicy.minLength", "密码最小长度必须在 0-64 之间: %d", config.PasswordPolicy.MinLength)
+		}
+		if config.PasswordPolicy.HistoryCount < 0 {
+			v.add("passwordPolicy.historyCount", "密码历史记录数不能为负数: %d", config.PasswordPolicy.HistoryCount)
+		}
+		if config.PasswordPolicy.ExpireDays < 0 {
+			v.add("passwordPolicy.expireDays", "密码有效天数不能为负数: %d", config.PasswordPolicy.ExpireDays)
+		}
+	}
+
+	// 验证登录安全配置
+	if config.Security != nil {
+		if config.Security.MaxLoginAttempts < 0 {
+			v.add("security.maxLoginAttempts", "登录失败锁定次数不能为负数: %d", config.Security.MaxLoginAttempts)
+		}
+		if config.Security.LockoutDuration < 0 {
+			v.add("security.lockoutDuration", "账户锁定时长不能为负数")
+		}
+		if config.Security.MaxLockoutDuration < 0 {
+			v.add("security.maxLockoutDuration", "账户锁定时长不能为负数")
+		}
+		if config.Security.BackoffMultiplier != 0 && config.Security.BackoffMultiplier < 1 {
+			v.add("security.backoffMultiplier", "账户锁定时长倍数不能小于 1: %v", config.Security.BackoffMultiplier)
+		}
+	}
+
+	// 验证图形验证码配置
+	if config.Captcha != nil && config.Captcha.Enabled {
+		if config.Captcha.Driver != "digit" && config.Captcha.Driver != "slide" {
+			v.add("captcha.driver", "不支持的验证码类型: %s", config.Captcha.Driver)
+		}
+		if config.Captcha.Driver == "digit" && config.Captcha.Length <= 0 {
+			v.add("captcha.length", "验证码位数必须大于 0: %d", config.Captcha.Length)
+		}
+		if config.Captcha.Width <= 0 || config.Captcha.Height <= 0 {
+			v.add("captcha.width", "验证码图片尺寸必须大于 0")
+		}
+		if config.Captcha.TTL <= 0 {
+			v.add("captcha.ttl", "验证码有效期必须大于 0")
+		}
+		if config.Captcha.FailedThreshold < 0 {
+			v.add("captcha.failedThreshold", "验证码触发失败次数不能为负数: %d", config.Captcha.FailedThreshold)
+		}
+	}
+
+	// 验证找回密码配置
+	if config.PasswordReset != nil && config.PasswordReset.Enabled {
+		if config.PasswordReset.TokenTTL <= 0 {
+			v.add("passwordReset.tokenTtl", "重置令牌有效期必须大于 0")
+		}
+		if config.PasswordReset.Interval < 0 {
+			v.add("passwordReset.interval", "找回密码申请间隔不能为负数")
+		}
+		if config.PasswordReset.DailyLimit < 0 || config.PasswordReset.IPLimit < 0 {
+			v.add("passwordReset.dailyLimit", "找回密码申请上限不能为负数")
+		}
+	}
+
+	validateAuth(v, config.Auth)
+
+	// 验证消息队列配置
+	if config.MQ != nil && config.MQ.Enabled {
+		switch config.MQ.Driver {
+		case "memory":
+		case "kafka":
+			if config.MQ.Kafka == nil || config.MQ.Kafka.RestURL == "" {
+				v.add("mq.kafka.restUrl", "Kafka 消息队列必须配置 REST Proxy 地址")
+			}
+			if config.MQ.Kafka != nil {
+				if reset := config.MQ.Kafka.AutoOffsetReset; reset != "" && reset != "earliest" && reset != "latest" {
+					v.add("mq.kafka.autoOffsetReset", "不支持的 Kafka 起始消费位置: %s", reset)
+				}
+			}
+		case "nsq":
+			if config.MQ.NSQ == nil || (config.MQ.NSQ.Addr == "" && len(config.MQ.NSQ.LookupdAddrs) == 0) {
+				v.add("mq.nsq.addr", "NSQ 消息队列必须配置 nsqd 或 nsqlookupd 地址")
+			}
+		default:
+			v.add("mq.driver", "不支持的消息队列驱动: %s", config.MQ.Driver)
+		}
+		if config.MQ.Group == "" {
+			v.add("mq.group", "消息队列消费组不能为空")
+		}
+		if config.MQ.Concurrency < 0 {
+			v.add("mq.concurrency", "消息队列并发数不能为负数: %d", config.MQ.Concurrency)
+		}
+		if config.MQ.MaxAttempts < 0 {
+			v.add("mq.maxAttempts", "消息队列最大投递次数不能为负数: %d", config.MQ.MaxAttempts)
+		}
+	}
+
+	// 验证请求签名配置
+	if config.Signature != nil && config.Signature.Enabled {
+		if config.Signature.Window <= 0 {
+			v.add("signature.window", "请求签名时间窗口必须大于 0")
+		}
+		if len(config.Signature.Clients) == 0 {
+			v.add("signature.clients", "启用请求签名时必须配置接入方密钥")
+		}
+		for appID, secret := range config.Signature.Clients {
+			if secret == "" {
+				v.add("signature.clients."+appID, "接入方 %s 的签名密钥不能为空", appID)
+			}
+		}
+	}
+
+	// 验证多租户配置
+	if config.Tenant != nil && config.Tenant.Enabled {
+		for i, source := range config.Tenant.Sources {
+			switch source {
+			case "header", "domain":
+			default:
+				v.add(fmt.Sprintf("tenant.sources[%d]", i), "不支持的租户解析来源: %s", source)
+			}
+		}
+		if slices.Contains(config.Tenant.Sources, "domain") && config.Tenant.Domain == "" {
+			v.add("tenant.domain", "按域名解析租户时根域名不能为空")
+		}
+	}
+
+	// 验证邮件配置
+	if config.Mailer != nil {
+		switch config.Mailer.Driver {
+		case "", "log":
+		case "smtp":
+			if config.Mailer.Host == "" {
+				v.add("mailer.host", "SMTP 主机不能为空")
+			}
+			v.port("mailer.port", config.Mailer.Port, " SMTP ")
+		default:
+			v.add("mailer.driver", "不支持的邮件驱动: %s", config.Mailer.Driver)
+		}
+		v.nonNegative("mailer.timeout", config.Mailer.Timeout)
+	}
+
+	// 验证短信配置
+	if config.SMS != nil {
+		switch config.SMS.Driver {
+		case "log":
+		case "aliyun":
+			if config.SMS.AccessKeyID == "" || config.SMS.AccessKeySecret == "" || config.SMS.SignName == "" {
+				v.add("sms.accessKeyId", "阿里云短信访问密钥与短信签名不能为空")
+			}
+		case "twilio":
+			if config.SMS.AccountSID == "" || config.SMS.AuthToken == "" || config.SMS.From == "" {
+				v.add("sms.accountSid", "短信服务 Twilio 的账号SID、认证令牌与发送号码不能为空")
+			}
+		default:
+			v.add("sms.driver", "不支持的短信驱动: %s", config.SMS.Driver)
+		}
+		if config.SMS.Code != nil && (config.SMS.Code.Length < 4 || config.SMS.Code.Length > 10) {
+			v.add("sms.code.length", "短信验证码位数必须在4-10之间")
+		}
+	}
+
+	// 验证文件存储配置
+	if config.Storage != nil {
+		switch config.Storage.Driver {
+		case "local":
+			if config.Storage.Root == "" {
+				v.add("storage.root", "本地存储根目录不能为空")
+			}
+		case "s3", "oss":
+			if config.Storage.Endpoint == "" {
+				v.add("storage.endpoint", "对象存储服务地址不能为空")
+			}
+			if config.Storage.Bucket == "" {
+				v.add("storage.bucket", "对象存储存储桶不能为空")
+			}
+			if config.Storage.AccessKeyID == "" || config.Storage.AccessKeySecret == "" {
+				v.add("storage.accessKeyId", "对象存储访问密钥不能为空")
+			}
+		default:
+			v.add("storage.driver", "不支持的存储驱动: %s", config.Storage.Driver)
+		}
+	}
+
+	validateUpload(v, config.Upload)
+
+	// 验证任务队列配置
+	if config.Queue != nil {
+		switch config.Queue.Driver {
+		case "", "redis", "memory":
+		default:
+			v.add("queue.driver", "不支持的任务队列驱动: %s", config.Queue.Driver)
+		}
+		if config.Queue.Concurrency < 0 {
+			v.add("queue.concurrency", "任务队列并发数不能为负数: %d", config.Queue.Concurrency)
+		}
+		if config.Queue.MaxRetries < 0 {
+			v.add("queue.maxRetries", "任务最大重试次数不能为负数: %d", config.Queue.MaxRetries)
+		}
+		v.nonNegative("queue.retryBackoff", config.Queue.RetryBackoff)
+		v.nonNegative("queue.maxRetryBackoff", config.Queue.MaxRetryBackoff)
+		v.nonNegative("queue.pollInterval", config.Queue.PollInterval)
+		v.nonNegative("queue.jobTimeout", config.Queue.JobTimeout)
+	}
+
+	// 验证事件总线配置
+	if config.Event != nil {
+		if config.Event.Workers < 0 {
+			v.add("event.workers", "事件分发 worker 数量不能为负数: %d", config.Event.Workers)
+		}
+		if config.Event.BufferSize < 0 {
+			v.add("event.bufferSize", "事件缓冲队列大小不能为负数: %d", config.Event.BufferSize)
+		}
+	}
+
+	// 验证统一响应格式配置
+	if config.Response != nil {
+		if config.Response.SuccessCode == config.Response.ErrorCode {
+			v.add("response.errorCode", "响应成功与失败的业务状态码不能相同: %d", config.Response.SuccessCode)
+		}
+		if config.Response.FieldCase != ResponseFieldCaseSnake && config.Response.FieldCase != ResponseFieldCaseCamel {
+			v.add("response.fieldCase", "不支持的响应字段命名方式: %s", config.Response.FieldCase)
+		}
+	}
+
+	// 验证 JWT 配置
+	if config.JWT != nil {
+		if config.JWT.SecretKey == "" {
+			v.add("jwt.secretKey", "JWT 密钥不能为空")
+		}
+		if config.JWT.ExpiresIn <= 0 {
+			v.add("jwt.expiresIn", "JWT 过期时间必须大于 0")
+		}
+		if config.JWT.RefreshExpiresIn < 0 {
+			v.add("jwt.refreshExpiresIn", "JWT 刷新令牌过期时间不能为负数")
+		}
+		if config.JWT.RefreshExpiresIn > 0 && config.JWT.RefreshExpiresIn <= config.JWT.ExpiresIn {
+			v.add("jwt.refreshExpiresIn", "JWT 刷新令牌过期时间必须大于访问令牌过期时间")
+		}
+		if config.JWT.RefreshSecretKey != "" && config.JWT.RefreshSecretKey == config.JWT.SecretKey {
+			v.add("jwt.refreshSecretKey", "JWT 刷新令牌密钥不能与访问令牌密钥相同")
+		}
+		validateJWTKeys(v, config.JWT)
+		if config.JWT.JWKSURL != "" {
+			if u, err := url.Parse(config.JWT.JWKSURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				v.add("jwt.jwksUrl", "无效的 JWKS 地址: %s", config.JWT.JWKSURL)
+			}
+		}
+		v.nonNegative("jwt.jwksCacheTtl", config.JWT.JWKSCacheTTL)
+	}
+
+	// 验证数据库配置
+	if config.Database != nil {
+		validateDatabaseConfig(v, "database", config.Database)
+	}
+	for name, db := range config.Databases {
+		field := "databases." + name
+		if name == MainDatabase {
+			v.add(field, "数据库名称 %s 为主库保留名称", name)
+			continue
+		}
+		if db == nil {
+			v.add(field, "数据库 %s 配置不能为空", name)
+			continue
+		}
+		validateDatabaseConfig(v, field, db)
+	}
+
+	// 验证链路追踪配置
+	if config.Telemetry != nil && config.Telemetry.Enabled {
+		switch config.Telemetry.Exporter {
+		case "otlp-grpc", "otlp-http", "jaeger", "stdout":
+		default:
+			v.add("telemetry.exporter", "不支持的链路追踪导出器: %s", config.Telemetry.Exporter)
+		}
+		if config.Telemetry.SampleRatio < 0 || config.Telemetry.SampleRatio > 1 {
+			v.add("telemetry.sampleRatio", "链路追踪采样率必须在 0-1 之间: %v", config.Telemetry.SampleRatio)
+		}
+	}
+
+	// 验证指标配置
+	if config.Metrics != nil && config.Metrics.Enabled {
+		v.path("metrics.path", config.Metrics.Path, "指标暴露路径")
+	}
+
+	// 验证健康检查接口配置
+	if config.Health != nil {
+		if config.Health.Path != "" {
+			v.path("health.path", config.Health.Path, "健康检查路径")
+		}
+		if config.Health.LivenessPath != "" {
+			v.path("health.livenessPath", config.Health.LivenessPath, "存活探针路径")
+		}
+		if config.Health.ReadinessPath != "" {
+			v.path("health.readinessPath", config.Health.ReadinessPath, "就绪探针路径")
+		}
+	}
+
+	// 验证 gRPC 配置
+	if config.GRPC != nil && config.GRPC.Enabled {
+		v.port("grpc.port", config.GRPC.Port, " gRPC ")
+		if config.GRPC.TLS && (config.GRPC.CertFile == "" || config.GRPC.KeyFile == "") {
+			v.add("grpc.certFile", "gRPC 启用 TLS 时证书与私钥文件不能为空")
+		}
+	}
+
+	// 验证 OpenAPI 文档配置
+	if config.OpenAPI != nil && config.OpenAPI.Enabled {
+		v.path("openapi.path", config.OpenAPI.Path, "OpenAPI 文档路径")
+		if config.OpenAPI.SwaggerUI {
+			v.path("openapi.swaggerPath", config.OpenAPI.SwaggerPath, "Swagger UI 路径")
+		}
+	}
+
+	// 验证内嵌前端配置
+	if config.UI != nil && config.UI.Enabled {
+		v.path("ui.path", config.UI.Path, "前端访问路径")
+		if config.UI.Dir != "" {
+			if info, err := os.Stat(config.UI.Dir); err != nil || !info.IsDir() {
+				v.add("ui.dir", "前端构建目录不存在: %s", config.UI.Dir)
+			}
+		}
+	}
+
+	// 验证 HTML 模板配置
+	if config.Template != nil && config.Template.Enabled && config.Template.Dir != "" {
+		if info, err := os.Stat(config.Template.Dir); err != nil || !info.IsDir() {
+			v.add("template.dir", "模板目录不存在: %s", config.Template.Dir)
+		}
+	}
+
+	// 验证外部密钥配置
+	if config.Secret != nil && config.Secret.Enabled {
+		v.nonNegative("secret.cacheTTL", config.Secret.CacheTTL)
+		v.nonNegative("secret.renewInterval", config.Secret.RenewInterval)
+		if config.Secret.Vault != nil && config.Secret.Vault.Address != "" {
+			v.endpoint("secret.vault.address", config.Secret.Vault.Address, " Vault 地址")
+		}
+	}
+
+	// 验证远程配置中心
+	if config.Remote != nil && config.Remote.Enabled {
+		if config.Remote.Key == "" {
+			v.add("remote.key", "远程配置中心必须配置 key")
+		}
+		for i, endpoint := range config.Remote.Endpoints {
+			v.endpoint(fmt.Sprintf("remote.endpoints[%d]", i), endpoint, "远程配置中心地址")
+		}
+		v.nonNegative("remote.timeout", config.Remote.Timeout)
+		v.nonNegative("remote.interval", config.Remote.Interval)
+	}
+
+	// 验证远程日志输出配置
+	if config.LogSinks != nil {
+		if sink := config.LogSinks.Loki; sink != nil && sink.Enabled {
+			v.endpoint("logSinks.loki.url", sink.URL, " Loki 推送地址")
+			if sink.BatchSize < 0 || sink.BufferSize < 0 {
+				v.add("logSinks.loki.batchSize", "Loki 批量推送条数与缓冲队列大小不能为负数")
+			}
+		}
+		if sink := config.LogSinks.Syslog; sink != nil && sink.Enabled {
+			switch sink.Network {
+			case "", "udp", "tcp":
+			default:
+				v.add("logSinks.syslog.network", "不支持的 syslog 网络类型: %s", sink.Network)
+			}
+		}
+	}
+
+	validateCache(v, config.Cache)
+
+	return v.err()
+}
+
+// validateCors 验证跨域配置（gin-contrib/cors 在配置无效时会在启动时 panic）
+func validateCors(v *validator, cors *CorsConfig) {
+	if cors == nil {
+		return
+	}
+	if len(cors.AllowOrigins) == 0 {
+		v.add("cors.allowOrigins", "跨域请求源不能为空（允许全部请使用 *）")
+	}
+	for i, origin := range cors.AllowOrigins {
+		field := fmt.Sprintf("cors.allowOrigins[%d]", i)
+		if origin == "*" {
+			if cors.AllowCredentials {
+				v.add(field, "允许携带凭证时跨域请求源不能为 *")
+			}
+			continue
+		}
+		if u, err := url.Parse(origin); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || strings.TrimSuffix(u.Path, "/") != "" {
+			v.add(field, "无效的跨域请求源: %s（例如 https://example.com）", origin)
+		}
+	}
+	for i, method := range cors.AllowMethods {
+		switch strings.ToUpper(method) {
+		case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+			http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		default:
+			v.add(fmt.Sprintf("cors.allowMethods[%d]", i), "无效的请求方法: %s", method)
+		}
+	}
+	if cors.MaxAge < 0 {
+		v.add("cors.maxAge", "预检请求缓存时间不能为负数: %d", cors.MaxAge)
+	}
+}
+
+// validateAuth 验证第三方登录配置
+func validateAuth(v *validator, auth *AuthConfig) {
+	if auth == nil || !auth.Enabled {
+		return
+	}
+	if auth.AutoProvision && auth.DefaultRole == "" {
+		v.add("auth.defaultRole", "自动创建管理员时必须配置默认角色")
+	}
+	for name, provider := range auth.Providers {
+		field := "auth.providers." + name
+		if provider == nil {
+			v.add(field, "第三方登录方式 %s 配置为空", name)
+			continue
+		}
+		switch provider.Driver {
+		case "google", "github":
+		case "wecom":
+			if provider.AgentID == "" {
+				v.add(field+".agentId", "企业微信登录方式 %s 必须配置 agentId", name)
+			}
+		case "oidc":
+			if provider.Issuer == "" && (provider.AuthURL == "" || provider.TokenURL == "" || provider.UserInfoURL == "") {
+				v.add(field+".issuer", "OIDC 登录方式 %s 必须配置 issuer 或授权、令牌、用户信息地址", name)
+			}
+		default:
+			v.add(field+".driver", "不支持的第三方登录驱动: %s", provider.Driver)
+		}
+		if provider.ClientID == "" || provider.ClientSecret == "" {
+			v.add(field+".clientId", "第三方登录方式 %s 必须配置 clientId 与 clientSecret", name)
+		}
+		if provider.RedirectURL == "" {
+			v.add(field+".redirectUrl", "第三方登录方式 %s 必须配置回调地址", name)
+		}
+	}
+}
+
+// validateUpload 验证文件上传配置
+func validateUpload(v *validator, upload *UploadConfig) {
+	if upload == nil {
+		return
+	}
+	if upload.MaxSize <= 0 {
+		v.add("upload.maxSize", "上传文件大小限制必须大于0")
+	}
+	if upload.ChunkSize <= 0 || upload.ChunkSize > upload.MaxSize {
+		v.add("upload.chunkSize", "分片大小必须大于0且不超过上传文件大小限制")
+	}
+	for profile, image := range upload.Images {
+		if image == nil {
+			continue
+		}
+		field := "upload.images." + profile
+		switch image.Format {
+		case "", "jpeg", "png", "webp":
+		default:
+			v.add(field+".format", "图片处理配置 %s 不支持的转换格式: %s", profile, image.Format)
+		}
+		if image.Quality < 0 || image.Quality > 100 {
+			v.add(field+".quality", "图片处理配置 %s 的编码质量必须在1-100之间", profile)
+		}
+		names := make(map[string]bool, len(image.Thumbnails))
+		for i, thumbnail := range image.Thumbnails {
+			thumbField := fmt.Sprintf("%s.thumbnails[%d]", field, i)
+			if thumbnail.Name == "" || names[thumbnail.Name] {
+				v.add(thumbField+".name", "图片处理配置 %s 的缩略图名称不能为空且不能重复", profile)
+			}
+			if thumbnail.Width <= 0 && thumbnail.Height <= 0 {
+				v.add(thumbField+".width", "图片处理配置 %s 的缩略图 %s 须设置宽度或高度", profile, thumbnail.Name)
+			}
+			names[thumbnail.Name] = true
+		}
+	}
+}
+
+// validateCache 验证缓存配置（含连接池大小）
+func validateCache(v *validator, cache *CacheConfig) {
+	if cache == nil {
+		return
+	}
+	switch cache.Driver {
+	case "", "redis", "memory":
+	default:
+		v.add("cache.driver", "不支持的缓存驱动: %s", cache.Driver)
+	}
+	if cache.Host == "" {
+		v.add("cache.host", "缓存主机不能为空")
+	}
+	v.port("cache.port", cache.Port, "缓存")
+	switch cache.Mode {
+	case "", "standalone", "cluster":
+	case "sentinel":
+		if cache.MasterName == "" {
+			v.add("cache.masterName", "哨兵模式必须配置主节点名称")
+		}
+	default:
+		v.add("cache.mode", "不支持的 Redis 部署模式: %s", cache.Mode)
+	}
+	if cache.PoolSize < 0 {
+		v.add("cache.poolSize", "连接池大小不能为负数: %d", cache.PoolSize)
+	}
+	if cache.MinIdleConns < 0 {
+		v.add("cache.minIdleConns", "最小空闲连接数不能为负数: %d", cache.MinIdleConns)
+	}
+	if cache.PoolSize > 0 && cache.MinIdleConns > cache.PoolSize {
+		v.add("cache.minIdleConns", "最小空闲连接数不能大于连接池大小: %d > %d", cache.MinIdleConns, cache.PoolSize)
+	}
+	// go-redis 中 -1 表示不重试
+	if cache.MaxRetries < -1 {
+		v.add("cache.maxRetries", "最大重试次数不能小于 -1: %d", cache.MaxRetries)
+	}
+	if cache.MinRetryBackoff > 0 && cache.MaxRetryBackoff > 0 && cache.MinRetryBackoff > cache.MaxRetryBackoff {
+		v.add("cache.minRetryBackoff", "最小重试间隔不能大于最大重试间隔")
+	}
+	v.nonNegative("cache.dialTimeout", cache.DialTimeout)
+	v.nonNegative("cache.poolTimeout", cache.PoolTimeout)
+	v.nonNegative("cache.maxConnAge", cache.MaxConnAge)
+	if cache.MaxMemory < 0 {
+		v.add("cache.maxMemory", "最大内存使用量不能为负数: %d", cache.MaxMemory)
+	}
+	if cache.Shards < 0 {
+		v.add("cache.shards", "分片数不能为负数: %d", cache.Shards)
+	}
+}
+
+// validateJWTKeys 验证 JWT 签名算法与密钥配置
+func validateJWTKeys(v *validator, cfg *JWTConfig) {
+	switch cfg.Algorithm {
+	case "", "HS256":
+	case "RS256", "ES256":
+		if len(cfg.Keys) == 0 {
+			v.add("jwt.keys", "JWT 签名算法 %s 需要配置密钥", cfg.Algorithm)
+		}
+	default:
+		v.add("jwt.algorithm", "不支持的 JWT 签名算法: %s", cfg.Algorithm)
+		return
+	}
+
+	ids := make(map[string]bool, len(cfg.Keys))
+	for i, key := range cfg.Keys {
+		field := fmt.Sprintf("jwt.keys[%d]", i)
+		if key.ID == "" {
+			v.add(field+".id", "JWT 密钥 ID 不能为空")
+		} else if ids[key.ID] {
+			v.add(field+".id", "JWT 密钥 ID 重复: %s", key.ID)
+		}
+		ids[key.ID] = true
+
+		if cfg.Algorithm == "" || cfg.Algorithm == "HS256" {
+			if key.Secret == "" {
+				v.add(field+".secret", "JWT 密钥 %s 未配置 secret", key.ID)
+			}
+			continue
+		}
+		hasPrivate := key.PrivateKey != "" || key.PrivateKeyFile != ""
+		hasPublic := key.PublicKey != "" || key.PublicKeyFile != ""
+		if i == 0 && !hasPrivate {
+			v.add(field+".privateKey", "JWT 签发密钥 %s 未配置私钥", key.ID)
+		} else if !hasPrivate && !hasPublic {
+			v.add(field+".publicKey", "JWT 密钥 %s 未配置私钥或公钥", key.ID)
+		}
+	}
+}
+
+// validateDatabaseConfig 验证单个数据库配置（sqlite 仅需文件路径）
+func validateDatabaseConfig(v *validator, field string, db *DatabaseConfig) {
+	switch db.Driver {
+	case "", "mysql", "postgres":
+		if db.Host == "" {
+			v.add(field+".host", "数据库主机不能为空")
+		}
+		v.port(field+".port", db.Port, "数据库")
+		if db.Username == "" {
+			v.add(field+".username", "数据库用户名不能为空")
+		}
+		if db.Database == "" {
+			v.add(field+".database", "数据库名称不能为空")
+		}
+	case "sqlite":
+	default:
+		v.add(field+".driver", "不支持的数据库驱动: %s", db.Driver)
+	}
+	if db.MaxOpenConns < 0 {
+		v.add(field+".maxOpenConns", "最大打开连接数不能为负数: %d", db.MaxOpenConns)
+	}
+	if db.MaxIdleConns < 0 {
+		v.add(field+".maxIdleConns", "最大空闲连接数不能为负数: %d", db.MaxIdleConns)
+	}
+	if db.MaxOpenConns > 0 && db.MaxIdleConns > db.MaxOpenConns {
+		v.add(field+".maxIdleConns", "最大空闲连接数不能大于最大打开连接数: %d > %d", db.MaxIdleConns, db.MaxOpenConns)
+	}
+	v.nonNegative(field+".connMaxLifetime", db.ConnMaxLifetime)
+	v.nonNegative(field+".connMaxIdleTime", db.ConnMaxIdleTime)
+	v.nonNegative(field+".reconnectInterval", db.ReconnectInterval)
+	v.nonNegative(field+".queryTimeout", db.QueryTimeout)
+	for i, replica := range db.Replicas {
+		if replica != nil && replica.Port != 0 {
+			v.port(fmt.Sprintf("%s.replicas[%d].port", field, i), replica.Port, "从库")
+		}
+	}
+}
+
+// checkUnknownKeys 检查未对应到 AppConfig 字段的配置项（配置项名称与 Unmarshal 一致，忽略大小写）
+func checkUnknownKeys(v *viper.Viper) error {
+	keys := unknownKeys(v.AllSettings(), reflect.TypeOf(AppConfig{}), "")
+	if len(keys) == 0 {
+		return nil
+	}
+	errs := make(ValidationErrors, len(keys))
+	for i, key := range keys {
+		errs[i] = &FieldError{Field: key, Message: "未知的配置项"}
+	}
+	return errs
+}
+
+// unknownKeys 递归比对配置内容与结构体字段，返回未知配置项的路径
+func unknownKeys(value any, t reflect.Type, prefix string) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	var unknown []string
+	switch t.Kind() {
+	case reflect.Struct:
+		settings, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		fields := make(map[string]reflect.Type, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := field.Name
+			if tag := strings.Split(field.Tag.Get("mapstructure"), ",")[0]; tag == "-" {
+				continue
+			} else if tag != "" {
+				name = tag
+			}
+			fields[strings.ToLower(name)] = field.Type
+		}
+		for _, key := range sortedKeys(settings) {
+			fieldType, ok := fields[strings.ToLower(key)]
+			if !ok {
+				unknown = append(unknown, joinPath(prefix, key))
+				continue
+			}
+			unknown = append(unknown, unknownKeys(settings[key], fieldType, joinPath(prefix, key))...)
+		}
+	case reflect.Map:
+		settings, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		for _, key := range sortedKeys(settings) {
+			unknown = append(unknown, unknownKeys(settings[key], t.Elem(), joinPath(prefix, key))...)
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]any)
+		if !ok {
+			return nil
+		}
+		for i, item := range items {
+			unknown = append(unknown, unknownKeys(item, t.Elem(), fmt.Sprintf("%s[%d]", prefix, i))...)
+		}
+	}
+	return unknown
+}
+
+// sortedKeys 返回排序后的键
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// joinPath 拼接配置项路径
+func joinPath(prefix string, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
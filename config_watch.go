@@ -0,0 +1,111 @@
+package core
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/so68/core/config"
+	_ "github.com/so68/core/config/remote" // 注册内置远程配置中心（etcd、consul、nacos）
+)
+
+// configWatcher 远程配置订阅（配置变更时重新加载本地配置并叠加新的远程内容）
+type configWatcher struct {
+	loader   *config.Loader
+	provider config.RemoteProvider
+
+	mu       sync.Mutex
+	handlers []func(cfg *config.AppConfig)
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// newConfigWatcher 创建远程配置订阅（未启用远程配置或未开启订阅时返回 nil）
+func newConfigWatcher(loader *config.Loader, cfg *config.AppConfig) (*configWatcher, error) {
+	if cfg.Remote == nil || !cfg.Remote.Enabled || !cfg.Remote.Watch {
+		return nil, nil
+	}
+	provider, err := config.NewRemoteProvider(cfg.Remote)
+	if err != nil {
+		return nil, err
+	}
+	return &configWatcher{loader: loader, provider: provider}, nil
+}
+
+// OnConfigChange 注册远程配置变更处理函数
+// - 新配置已叠加本地配置文件与环境变量并通过校验，处理函数自行决定如何应用（例如调整日志级别、限流阈值）
+// - 已创建的组件不会自动重建，Application.Config 保持为启动时的配置
+func (a *Application) OnConfigChange(fn func(cfg *config.AppConfig)) {
+	if a.configWatcher == nil {
+		return
+	}
+	a.configWatcher.mu.Lock()
+	defer a.configWatcher.mu.Unlock()
+	a.configWatcher.handlers = append(a.configWatcher.handlers, fn)
+}
+
+// startConfigWatch 启动远程配置订阅（重复调用无副作用）
+func (a *Application) startConfigWatch() {
+	w := a.configWatcher
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.done = make(chan struct{})
+	go func() {
+		defer close(w.done)
+		if err := w.provider.Watch(ctx, func(data []byte) { a.reloadConfig(ctx, data) }); err != nil {
+			a.Logger.Error("watch remote config failed", slog.Any("error", err))
+		}
+	}()
+}
+
+// stopConfigWatch 停止远程配置订阅并等待订阅协程退出
+func (a *Application) stopConfigWatch() {
+	w := a.configWatcher
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	cancel, done := w.cancel, w.done
+	w.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// reloadConfig 使用新的远程配置内容重新加载配置并通知处理函数（加载或校验失败时保留原配置）
+func (a *Application) reloadConfig(ctx context.Context, data []byte) {
+	w := a.configWatcher
+	cfg, err := w.loader.Load(config.WithRemoteData(data), config.WithContext(ctx))
+	if err != nil {
+		a.Logger.Warn("reload remote config failed", slog.Any("error", err))
+		return
+	}
+	if err := config.ValidateConfig(cfg); err != nil {
+		a.Logger.Warn("remote config is invalid", slog.Any("error", err))
+		return
+	}
+	if a.Secrets != nil {
+		if err := a.Secrets.Resolve(ctx, cfg); err != nil {
+			a.Logger.Warn("resolve secrets of remote config failed", slog.Any("error", err))
+			return
+		}
+	}
+	a.Logger.Info("remote config changed", slog.String("provider", cfg.Remote.Provider), slog.String("key", cfg.Remote.Key))
+
+	w.mu.Lock()
+	handlers := append([]func(cfg *config.AppConfig){}, w.handlers...)
+	w.mu.Unlock()
+	for _, fn := range handlers {
+		fn(cfg)
+	}
+}
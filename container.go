@@ -0,0 +1,197 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/so68/core/cache"
+	"github.com/so68/core/config"
+	"github.com/so68/core/database"
+	"github.com/so68/core/event"
+	"github.com/so68/core/mailer"
+	"github.com/so68/core/mq"
+	"github.com/so68/core/queue"
+	"github.com/so68/core/secret"
+	"github.com/so68/core/server"
+	"github.com/so68/core/sms"
+	"github.com/so68/core/storage"
+	"github.com/so68/core/stream"
+	"gorm.io/gorm"
+)
+
+// errorType error 接口类型
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// provider 服务构造函数（惰性单例，首次解析时构造并缓存）
+type provider struct {
+	constructor reflect.Value
+	built       bool
+	value       reflect.Value
+}
+
+// container 依赖注入容器
+// - 以构造函数返回值类型为键，每个类型只允许一个构造函数
+// - 构造函数的参数按类型自动解析，实例在首次解析时创建并复用
+type container struct {
+	mu        sync.Mutex
+	providers map[reflect.Type]*provider
+}
+
+// Provide 注册服务构造函数（惰性单例）
+// - 构造函数形如 func(deps...) T 或 func(deps...) (T, error)，参数按类型从容器解析
+// - 返回值类型作为服务键（如 service.NewCasbinService 以 service.CasbinService 注册）
+// - 构造函数中不应调用 Invoke/Resolve，依赖须声明为参数
+func (a *Application) Provide(constructors ...interface{}) error {
+	c := a.di()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, constructor := range constructors {
+		fn := reflect.ValueOf(constructor)
+		if fn.Kind() != reflect.Func {
+			return fmt.Errorf("provide: %T is not a function", constructor)
+		}
+		fnType := fn.Type()
+		if fnType.NumOut() == 0 || fnType.NumOut() > 2 || (fnType.NumOut() == 2 && fnType.Out(1) != errorType) {
+			return fmt.Errorf("provide: %s must return T or (T, error)", fnType)
+		}
+		key := fnType.Out(0)
+		if _, ok := c.providers[key]; ok {
+			return fmt.Errorf("provide: %s already provided", key)
+		}
+		c.providers[key] = &provider{constructor: fn}
+	}
+	return nil
+}
+
+// Invoke 解析函数参数并调用，函数返回 error 时将其返回
+// 例如：app.Invoke(func(casbin service.CasbinService, logger *slog.Logger) { ... })
+func (a *Application) Invoke(function interface{}) error {
+	fn := reflect.ValueOf(function)
+	if fn.Kind() != reflect.Func {
+		return fmt.Errorf("invoke: %T is not a function", function)
+	}
+
+	c := a.di()
+	c.mu.Lock()
+	args, err := c.args(fn.Type(), nil)
+	c.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("invoke: %w", err)
+	}
+
+	for _, out := range fn.Call(args) {
+		if out.Type() == errorType && !out.IsNil() {
+			return out.Interface().(error)
+		}
+	}
+	return nil
+}
+
+// Resolve 按类型获取服务实例（Go 方法不支持类型参数，因此以函数形式提供）
+// 例如：casbin, err := core.Resolve[service.CasbinService](app)
+func Resolve[T any](a *Application) (T, error) {
+	var zero T
+	c := a.di()
+	c.mu.Lock()
+	value, err := c.resolve(reflect.TypeOf((*T)(nil)).Elem(), nil)
+	c.mu.Unlock()
+	if err != nil {
+		return zero, err
+	}
+	// 构造函数返回 nil 接口时返回零值
+	result, _ := value.Interface().(T)
+	return result, nil
+}
+
+// MustResolve 按类型获取服务实例，失败时 panic（适用于启动阶段）
+func MustResolve[T any](a *Application) T {
+	value, err := Resolve[T](a)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// di 获取依赖注入容器（延迟创建）
+func (a *Application) di() *container {
+	a.containerOnce.Do(func() {
+		a.container = &container{providers: make(map[reflect.Type]*provider)}
+	})
+	return a.container
+}
+
+// provideBuiltins 注册核心组件（未启用的组件解析结果为 nil，*gorm.DB 仅在配置数据库时注册）
+func (a *Application) provideBuiltins() error {
+	builtins := []interface{}{
+		func() *Application { return a },
+		func() *slog.Logger { return a.Logger },
+		func() *config.AppConfig { return a.Config },
+		func() database.Database { return a.DB },
+		func() cache.Cache { return a.Cache },
+		func() server.Server { return a.Server },
+		func() mailer.Mailer { return a.Mailer },
+		func() sms.Sender { return a.SMS },
+		func() storage.Storage { return a.Storage },
+		func() queue.Queue { return a.Queue },
+		func() mq.MQ { return a.MQ },
+		func() *event.Bus { return a.Events },
+		func() *stream.Streams { return a.Streams },
+		func() *secret.Resolver { return a.Secrets },
+	}
+	if a.DB != nil {
+		builtins = append(builtins, func() *gorm.DB { return a.DB.DB() })
+	}
+	return a.Provide(builtins...)
+}
+
+// args 解析函数的全部参数
+func (c *container) args(fnType reflect.Type, path []reflect.Type) ([]reflect.Value, error) {
+	args := make([]reflect.Value, fnType.NumIn())
+	for i := range args {
+		value, err := c.resolve(fnType.In(i), path)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = value
+	}
+	return args, nil
+}
+
+// resolve 解析指定类型的实例（path 为当前解析链，用于检测循环依赖）
+func (c *container) resolve(key reflect.Type, path []reflect.Type) (reflect.Value, error) {
+	p, ok := c.providers[key]
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("no provider for %s", key)
+	}
+	if p.built {
+		return p.value, nil
+	}
+
+	for _, t := range path {
+		if t == key {
+			names := make([]string, 0, len(path)+1)
+			for _, t := range path {
+				names = append(names, t.String())
+			}
+			names = append(names, key.String())
+			return reflect.Value{}, errors.New("dependency cycle: " + strings.Join(names, " -> "))
+		}
+	}
+
+	args, err := c.args(p.constructor.Type(), append(path, key))
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	out := p.constructor.Call(args)
+	if len(out) == 2 && !out[1].IsNil() {
+		return reflect.Value{}, fmt.Errorf("construct %s: %w", key, out[1].Interface().(error))
+	}
+	p.built = true
+	p.value = out[0]
+	return p.value, nil
+}
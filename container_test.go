@@ -0,0 +1,130 @@
+package core
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+/*
+依赖注入容器功能测试
+
+本文件用于测试Application的依赖注入功能，
+包括构造函数注册、按类型解析、单例复用、构造错误与循环依赖检测。
+
+运行命令：
+go test -v -run "^TestContainer.*$"
+
+测试内容：
+1. 注册与解析 (Provide, Resolve, Invoke)
+2. 单例复用 (Resolve)
+3. 重复注册与非法构造函数 (Provide)
+4. 构造错误、缺失依赖与循环依赖 (Resolve)
+*/
+
+// testRepo 测试用仓储
+type testRepo struct{ name string }
+
+// testService 测试用服务接口
+type testService interface{ Name() string }
+
+// testServiceImpl 测试用服务实现
+type testServiceImpl struct{ repo *testRepo }
+
+func (s *testServiceImpl) Name() string { return "service:" + s.repo.name }
+
+func TestContainerProvideAndResolve(t *testing.T) {
+	app := &Application{Logger: slog.Default()}
+
+	var builds int
+	if err := app.Provide(
+		func() *testRepo {
+			builds++
+			return &testRepo{name: "repo"}
+		},
+		func(repo *testRepo, logger *slog.Logger) testService {
+			return &testServiceImpl{repo: repo}
+		},
+		func() *slog.Logger { return app.Logger },
+	); err != nil {
+		t.Fatalf("Provide failed: %v", err)
+	}
+
+	svc, err := Resolve[testService](app)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if svc.Name() != "service:repo" {
+		t.Errorf("Unexpected service name: %s", svc.Name())
+	}
+
+	var invoked testService
+	if err := app.Invoke(func(s testService, repo *testRepo) {
+		invoked = s
+	}); err != nil {
+		t.Fatalf("Invoke failed: %v", err)
+	}
+	if invoked != svc {
+		t.Error("Expected the same service instance")
+	}
+	if builds != 1 {
+		t.Errorf("Expected repo built once, got %d", builds)
+	}
+
+	wantErr := errors.New("invoke failed")
+	if err := app.Invoke(func(s testService) error { return wantErr }); !errors.Is(err, wantErr) {
+		t.Errorf("Expected invoke error, got %v", err)
+	}
+}
+
+func TestContainerProvideErrors(t *testing.T) {
+	app := &Application{}
+
+	if err := app.Provide(func() *testRepo { return &testRepo{} }); err != nil {
+		t.Fatalf("Provide failed: %v", err)
+	}
+	tests := []struct {
+		name        string
+		constructor interface{}
+		wantErr     string
+	}{
+		{name: "duplicate", constructor: func() *testRepo { return nil }, wantErr: "already provided"},
+		{name: "not a function", constructor: &testRepo{}, wantErr: "is not a function"},
+		{name: "no result", constructor: func() {}, wantErr: "must return T or (T, error)"},
+		{name: "second result not error", constructor: func() (*testRepo, int) { return nil, 0 }, wantErr: "must return T or (T, error)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := app.Provide(tt.constructor)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// cycleA、cycleB 循环依赖测试类型
+type cycleA struct{}
+type cycleB struct{}
+
+func TestContainerResolveErrors(t *testing.T) {
+	app := &Application{}
+	if err := app.Provide(
+		func() (*testRepo, error) { return nil, errors.New("connection refused") },
+		func(b *cycleB) *cycleA { return &cycleA{} },
+		func(a *cycleA) *cycleB { return &cycleB{} },
+	); err != nil {
+		t.Fatalf("Provide failed: %v", err)
+	}
+
+	if _, err := Resolve[*testRepo](app); err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("Expected construct error, got %v", err)
+	}
+	if _, err := Resolve[testService](app); err == nil || !strings.Contains(err.Error(), "no provider for core.testService") {
+		t.Errorf("Expected missing provider error, got %v", err)
+	}
+	if _, err := Resolve[*cycleA](app); err == nil || !strings.Contains(err.Error(), "dependency cycle: *core.cycleA -> *core.cycleB -> *core.cycleA") {
+		t.Errorf("Expected cycle error, got %v", err)
+	}
+}
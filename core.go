@@ -4,37 +4,93 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 	"github.com/so68/core/cache"
 	"github.com/so68/core/config"
 	"github.com/so68/core/database"
+	"github.com/so68/core/event"
+	"github.com/so68/core/grpcserver"
+	"github.com/so68/core/i18n"
+	"github.com/so68/core/logging"
+	"github.com/so68/core/mailer"
+	"github.com/so68/core/metrics"
+	"github.com/so68/core/mq"
+	"github.com/so68/core/queue"
+	"github.com/so68/core/secret"
 	"github.com/so68/core/server"
-	"github.com/so68/utils/logger"
+	"github.com/so68/core/signature"
+	"github.com/so68/core/sms"
+	"github.com/so68/core/storage"
+	"github.com/so68/core/stream"
+	"github.com/so68/core/telemetry"
 )
 
 // Application 应用
 type Application struct {
-	Config *config.AppConfig // 配置
-	Logger *slog.Logger      // 日志
-	DB     database.Database // 数据库
-	Cache  cache.Cache       // 缓存
-	Server server.Server     // 服务器
+	Config  *config.AppConfig // 配置
+	Logger  *slog.Logger      // 日志
+	DB      database.Database // 数据库
+	Cache   cache.Cache       // 缓存
+	Server  server.Server     // 服务器
+	Mailer  mailer.Mailer     // 邮件
+	SMS     sms.Sender        // 短信
+	Storage storage.Storage   // 文件存储
+	Queue   queue.Queue       // 任务队列
+	MQ      mq.MQ             // 消息队列（未启用时为 nil）
+	Events  *event.Bus        // 事件总线
 
-	serverErrChan <-chan error // 服务器错误通道（StartAsync 使用）
+	Telemetry *telemetry.Provider // 链路追踪（未启用时为 nil）
+	Metrics   *metrics.Registry   // 指标注册表（未启用时为 nil）
+	GRPC      *grpcserver.Server  // gRPC 服务（未启用时为 nil）
+	Signature *signature.Verifier // 开放接口请求签名校验（未启用时为 nil）
+	Streams   *stream.Streams     // Redis Streams（缓存驱动非 redis 时为 nil）
+	Secrets   *secret.Resolver    // 外部密钥解析器（未启用时为 nil）
+
+	databases     map[string]database.Database // 命名数据库（不含主库）
+	serverErrChan <-chan error                 // 服务器错误通道（StartAsync 使用）
+	grpcErrChan   <-chan error                 // gRPC 服务错误通道
+	handleSignals bool                         // Run 是否处理系统信号
+	logCloser     io.Closer                    // 远程日志输出（关闭时刷新缓冲）
+	logLevel      *slog.LevelVar               // 应用日志级别（传入自定义日志器时为 nil）
+	closing       atomic.Bool                  // 是否正在关闭（就绪探针据此返回 503）
+	modules       []*moduleEntry               // 已注册模块（注册顺序）
+	modulesMu     sync.Mutex                   // 保护模块列表与生命周期状态
+	container     *container                   // 依赖注入容器
+	containerOnce sync.Once                    // 延迟创建依赖注入容器
+	started       atomic.Bool                  // 是否已发布服务启动事件（Run 会再次调用 Start）
+	configWatcher *configWatcher               // 远程配置订阅（未启用时为 nil）
 }
 
 // Option 构造可选项
 type Option func(*coreOptions)
 
 type coreOptions struct {
-	configPath   string
-	cfg          *config.AppConfig
-	logger       *slog.Logger
-	enableDB     bool
-	enableCache  bool
-	enableServer bool
+	configPath    string
+	cfg           *config.AppConfig
+	logger        *slog.Logger
+	enableDB      bool
+	enableCache   bool
+	enableServer  bool
+	enableMailer  bool
+	enableSMS     bool
+	enableStorage bool
+	enableQueue   bool
+	enableSignal  bool
+	ui            fs.FS
+	templates     fs.FS
+	secrets       map[string]secret.Provider
 }
 
 // WithConfigPath 指定配置文件路径
@@ -62,11 +118,62 @@ func WithoutCache() Option {
 	return func(o *coreOptions) { o.enableCache = false }
 }
 
-// WithoutServer 禁用服务器
+// WithoutServer 禁用服务器（同时禁用 gRPC 服务）
 func WithoutServer() Option {
 	return func(o *coreOptions) { o.enableServer = false }
 }
 
+// WithoutMailer 禁用邮件
+func WithoutMailer() Option {
+	return func(o *coreOptions) { o.enableMailer = false }
+}
+
+// WithoutSMS 禁用短信
+func WithoutSMS() Option {
+	return func(o *coreOptions) { o.enableSMS = false }
+}
+
+// WithoutStorage 禁用文件存储
+func WithoutStorage() Option {
+	return func(o *coreOptions) { o.enableStorage = false }
+}
+
+// WithoutQueue 禁用任务队列
+func WithoutQueue() Option {
+	return func(o *coreOptions) { o.enableQueue = false }
+}
+
+// WithUI 指定内嵌前端构建产物（ui.enabled 开启时按 ui.path 托管，ui.dir 不为空时优先使用本地目录）
+// 例如：
+//
+//	//go:embed all:dist
+//	var dist embed.FS
+//	sub, _ := fs.Sub(dist, "dist")
+//	core.NewApplication(path, core.WithUI(sub))
+func WithUI(fsys fs.FS) Option {
+	return func(o *coreOptions) { o.ui = fsys }
+}
+
+// WithTemplates 指定内嵌 HTML 模板（template.enabled 开启时加载，template.dir 不为空时优先使用本地目录）
+func WithTemplates(fsys fs.FS) Option {
+	return func(o *coreOptions) { o.templates = fsys }
+}
+
+// WithSecretProvider 注册自定义密钥服务（secret.enabled 开启时，配置中 scheme: 开头的值从该服务读取）
+func WithSecretProvider(scheme string, provider secret.Provider) Option {
+	return func(o *coreOptions) {
+		if o.secrets == nil {
+			o.secrets = make(map[string]secret.Provider)
+		}
+		o.secrets[scheme] = provider
+	}
+}
+
+// WithoutSignalHandling 禁用 Run 内置的信号处理（由调用方自行处理 SIGINT/SIGTERM）
+func WithoutSignalHandling() Option {
+	return func(o *coreOptions) { o.enableSignal = false }
+}
+
 // NewApplication 初始化应用
 func NewApplication(configPath string, opts ...Option) (*Application, error) {
 	return NewApplicationWithOptions(append(opts, WithConfigPath(configPath))...)
@@ -76,9 +183,14 @@ func NewApplication(configPath string, opts ...Option) (*Application, error) {
 func NewApplicationWithOptions(opts ...Option) (*Application, error) {
 	// 解析可选项
 	o := &coreOptions{
-		enableDB:     true,
-		enableCache:  true,
-		enableServer: true,
+		enableDB:      true,
+		enableCache:   true,
+		enableServer:  true,
+		enableMailer:  true,
+		enableSMS:     true,
+		enableStorage: true,
+		enableQueue:   true,
+		enableSignal:  true,
 	}
 	for _, opt := range opts {
 		opt(o)
@@ -86,30 +198,87 @@ func NewApplicationWithOptions(opts ...Option) (*Application, error) {
 
 	// 加载配置
 	var cfg *config.AppConfig
+	var loader *config.Loader
 	if o.cfg != nil {
 		cfg = o.cfg
 	} else {
-		loaded, err := config.LoadConfig(o.configPath)
+		loader = config.NewLoader(o.configPath)
+		loaded, err := loader.Load()
 		if err != nil {
 			return nil, fmt.Errorf("load config: %w", err)
 		}
 		cfg = loaded
 	}
 
+	// 解析外部密钥引用（须早于读取配置的组件）
+	var secrets *secret.Resolver
+	if cfg.Secret != nil && cfg.Secret.Enabled {
+		resolver, err := secret.NewResolver(cfg.Secret, o.logger)
+		if err != nil {
+			return nil, fmt.Errorf("init secrets: %w", err)
+		}
+		for scheme, provider := range o.secrets {
+			resolver.Register(scheme, provider)
+		}
+		if err := resolver.Resolve(context.Background(), cfg); err != nil {
+			return nil, fmt.Errorf("resolve secrets: %w", err)
+		}
+		secrets = resolver
+	}
+
+	// 远程配置订阅（仅从配置文件加载时支持重新加载）
+	var watcher *configWatcher
+	if loader != nil {
+		created, err := newConfigWatcher(loader, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("init remote config: %w", err)
+		}
+		watcher = created
+	}
+
 	// 初始化日志
 	var slogLogger *slog.Logger
+	var logCloser io.Closer
+	var logLevel *slog.LevelVar
 	if o.logger != nil {
 		slogLogger = o.logger
 	} else {
-		l, err := logger.NewLogger(cfg.Logger)
+		logLevel = new(slog.LevelVar)
+		l, closer, err := logging.NewLoggerWithLevel(cfg, logLevel)
 		if err != nil {
 			return nil, fmt.Errorf("init logger: %w", err)
 		}
 		slogLogger = l
+		logCloser = closer
+	}
+	logging.SetDefault(slogLogger)
+
+	// 加载多语言消息文件
+	if cfg.I18n != nil && cfg.I18n.Dir != "" {
+		if err := i18n.LoadDir(cfg.I18n.Dir); err != nil {
+			return nil, fmt.Errorf("init i18n: %w", err)
+		}
 	}
 
-	// 初始化数据库
+	// 初始化链路追踪（需早于其他组件，以便自动埋点）
+	var tp *telemetry.Provider
+	if cfg.Telemetry != nil && cfg.Telemetry.Enabled {
+		createdProvider, err := telemetry.NewProvider(cfg.Telemetry, cfg.Name, slogLogger)
+		if err != nil {
+			return nil, fmt.Errorf("init telemetry: %w", err)
+		}
+		tp = createdProvider
+	}
+
+	// 初始化指标注册表
+	var registry *metrics.Registry
+	if cfg.Metrics != nil && cfg.Metrics.Enabled {
+		registry = metrics.NewRegistry(cfg.Metrics)
+	}
+
+	// 初始化数据库（主库与命名数据库）
 	var db database.Database
+	databases := make(map[string]database.Database, len(cfg.Databases))
 	if o.enableDB {
 		dbFactory := database.NewFactory(slogLogger)
 		createdDB, err := dbFactory.CreateDatabase(cfg.Database)
@@ -117,6 +286,34 @@ func NewApplicationWithOptions(opts ...Option) (*Application, error) {
 			return nil, fmt.Errorf("init database: %w", err)
 		}
 		db = createdDB
+		if tp != nil {
+			if err := telemetry.InstrumentGORM(db.DB()); err != nil {
+				return nil, fmt.Errorf("init telemetry: %w", err)
+			}
+		}
+		if registry != nil {
+			if err := database.SetQueryObserver(db, registry.GORMObserver(config.MainDatabase)); err != nil {
+				return nil, fmt.Errorf("init metrics: %w", err)
+			}
+		}
+
+		for name, dbCfg := range cfg.Databases {
+			createdDB, err := dbFactory.CreateDatabase(dbCfg)
+			if err != nil {
+				return nil, fmt.Errorf("init database %s: %w", name, err)
+			}
+			databases[name] = createdDB
+			if tp != nil {
+				if err := telemetry.InstrumentGORM(createdDB.DB()); err != nil {
+					return nil, fmt.Errorf("init telemetry: %w", err)
+				}
+			}
+			if registry != nil {
+				if err := database.SetQueryObserver(createdDB, registry.GORMObserver(name)); err != nil {
+					return nil, fmt.Errorf("init metrics: %w", err)
+				}
+			}
+		}
 	}
 
 	// 初始化缓存
@@ -128,26 +325,181 @@ func NewApplicationWithOptions(opts ...Option) (*Application, error) {
 			return nil, fmt.Errorf("init cache: %w", err)
 		}
 		c = createdCache
+		if redisCache, ok := c.(*cache.RedisCache); ok && tp != nil {
+			if err := telemetry.InstrumentRedis(redisCache.Client()); err != nil {
+				return nil, fmt.Errorf("init telemetry: %w", err)
+			}
+		}
+	}
+
+	// 初始化邮件
+	var m mailer.Mailer
+	if o.enableMailer && cfg.Mailer != nil {
+		mailerFactory := mailer.NewFactory(slogLogger)
+		createdMailer, err := mailerFactory.CreateMailer(cfg.Mailer)
+		if err != nil {
+			return nil, fmt.Errorf("init mailer: %w", err)
+		}
+		m = createdMailer
+	}
+
+	// 初始化短信
+	var sm sms.Sender
+	if o.enableSMS && cfg.SMS != nil {
+		createdSender, err := sms.NewFactory(slogLogger).CreateSender(cfg.SMS)
+		if err != nil {
+			return nil, fmt.Errorf("init sms: %w", err)
+		}
+		sm = createdSender
+	}
+
+	// 初始化文件存储
+	var st storage.Storage
+	if o.enableStorage && cfg.Storage != nil {
+		createdStorage, err := storage.NewFactory(slogLogger).CreateStorage(cfg.Storage)
+		if err != nil {
+			return nil, fmt.Errorf("init storage: %w", err)
+		}
+		st = createdStorage
+	}
+
+	// 初始化任务队列（redis 驱动复用 Redis 缓存连接）
+	var q queue.Queue
+	if o.enableQueue && cfg.Queue != nil {
+		var client redis.UniversalClient
+		if redisCache, ok := c.(*cache.RedisCache); ok {
+			client = redisCache.Client()
+		}
+		if cfg.Queue.Driver == "redis" && client == nil {
+			slogLogger.Warn("queue disabled: redis queue requires redis cache driver", slog.String("component", "queue"))
+		} else {
+			createdQueue, err := queue.NewFactory(slogLogger).CreateQueue(cfg.Queue, client)
+			if err != nil {
+				return nil, fmt.Errorf("init queue: %w", err)
+			}
+			q = createdQueue
+		}
+	}
+
+	// 初始化 Redis Streams（复用 Redis 缓存连接）
+	var streams *stream.Streams
+	if redisCache, ok := c.(*cache.RedisCache); ok {
+		createdStreams, err := stream.New(redisCache.Client(), slogLogger, stream.WithPrefix(cfg.Cache.Prefix))
+		if err != nil {
+			return nil, fmt.Errorf("init streams: %w", err)
+		}
+		streams = createdStreams
+	}
+
+	// 初始化开放接口请求签名校验（依赖缓存防重放）
+	var sv *signature.Verifier
+	if cfg.Signature != nil && cfg.Signature.Enabled {
+		createdVerifier, err := signature.NewVerifier(cfg.Signature, c)
+		if err != nil {
+			return nil, fmt.Errorf("init signature: %w", err)
+		}
+		sv = createdVerifier
+	}
+
+	// 初始化消息队列（Kafka/NSQ）
+	var mqueue mq.MQ
+	if cfg.MQ != nil && cfg.MQ.Enabled {
+		createdMQ, err := mq.NewFactory(slogLogger).CreateMQ(cfg.MQ)
+		if err != nil {
+			return nil, fmt.Errorf("init mq: %w", err)
+		}
+		mqueue = createdMQ
+	}
+
+	// 初始化事件总线（进程内，无外部依赖）
+	events := event.NewBus(cfg.Event, slogLogger)
+	// 跨节点分发事件（复用缓存的发布订阅）
+	if cfg.Event.Distributed {
+		if c == nil {
+			slogLogger.Warn("event distribution disabled: requires cache", slog.String("component", "event"))
+		} else if err := events.Distribute(context.Background(), c, cfg.Event.Channel); err != nil {
+			return nil, fmt.Errorf("init events: %w", err)
+		}
 	}
 
 	// 初始化服务器（仅构建，不启动）
 	var s server.Server
+	var g *grpcserver.Server
 	if o.enableServer {
 		s = server.NewServer(slogLogger, cfg)
+
+		if cfg.GRPC != nil && cfg.GRPC.Enabled {
+			createdGRPC, err := grpcserver.New(cfg.GRPC, slogLogger)
+			if err != nil {
+				return nil, fmt.Errorf("init grpc: %w", err)
+			}
+			g = createdGRPC
+		}
+	}
+
+	app := &Application{
+		Config:  cfg,
+		Logger:  slogLogger,
+		DB:      db,
+		Cache:   c,
+		Server:  s,
+		Mailer:  m,
+		SMS:     sm,
+		Storage: st,
+		Queue:   q,
+		MQ:      mqueue,
+		Events:  events,
+
+		Telemetry: tp,
+		Metrics:   registry,
+		GRPC:      g,
+		Signature: sv,
+		Streams:   streams,
+		Secrets:   secrets,
+
+		databases:     databases,
+		logLevel:      logLevel,
+		handleSignals: o.enableSignal,
+		logCloser:     logCloser,
+		configWatcher: watcher,
 	}
 
-	return &Application{
-		Config: cfg,
-		Logger: slogLogger,
-		DB:     db,
-		Cache:  c,
-		Server: s,
-	}, nil
+	// 注册核心组件到依赖注入容器
+	if err := app.provideBuiltins(); err != nil {
+		return nil, fmt.Errorf("init container: %w", err)
+	}
+
+	// 注册 HTTP 指标中间件与指标接口
+	if app.Server != nil && app.Metrics != nil {
+		app.Server.Use(app.Metrics.GinMiddleware())
+		app.Server.NewGroup("").GET(cfg.Metrics.Path, gin.WrapH(app.Metrics.Handler()))
+	}
+	if app.GRPC != nil && app.Metrics != nil {
+		app.GRPC.Use(app.Metrics.GRPCUnaryInterceptor())
+		app.GRPC.UseStream(app.Metrics.GRPCStreamInterceptor())
+	}
+
+	// 注册健康检查接口
+	if app.Server != nil {
+		app.registerHealthRoutes()
+	}
+	// 托管内嵌前端
+	if app.Server != nil && cfg.UI != nil && cfg.UI.Enabled {
+		app.registerUI(o.ui)
+	}
+	// 加载 HTML 模板
+	if app.Server != nil && cfg.Template != nil && cfg.Template.Enabled {
+		if err := app.loadTemplates(o.templates); err != nil {
+			return nil, fmt.Errorf("init templates: %w", err)
+		}
+	}
+	return app, nil
 }
 
 // Close 统一释放资源
 func (a *Application) Close(ctx context.Context) error {
 	var firstErr error
+	a.closing.Store(true)
 
 	// 先停服务
 	if a.Server != nil {
@@ -155,6 +507,43 @@ func (a *Application) Close(ctx context.Context) error {
 			firstErr = fmt.Errorf("shutdown server: %w", err)
 		}
 	}
+	if a.GRPC != nil {
+		if err := a.GRPC.Shutdown(ctx); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("shutdown grpc: %w", err)
+		}
+	}
+
+	// 停止远程配置订阅与密钥续期
+	a.stopConfigWatch()
+	if a.Secrets != nil {
+		a.Secrets.Stop()
+	}
+
+	// 按依赖的相反顺序停止模块
+	if err := a.stopModules(ctx); err != nil && firstErr == nil {
+		firstErr = err
+	}
+
+	// 再停止任务队列，等待执行中的任务完成
+	if a.Queue != nil {
+		if err := a.Queue.Stop(ctx); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("stop queue: %w", err)
+		}
+	}
+
+	// 停止消息消费，等待处理中的消息完成并离开消费组
+	if a.MQ != nil {
+		if err := a.MQ.Stop(ctx); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("stop mq: %w", err)
+		}
+	}
+
+	// 等待已发布的事件分发完成
+	if a.Events != nil {
+		if err := a.Events.Close(ctx); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("close events: %w", err)
+		}
+	}
 
 	if a.DB != nil {
 		if firstErr == nil {
@@ -164,6 +553,14 @@ func (a *Application) Close(ctx context.Context) error {
 		}
 	}
 
+	for name, db := range a.databases {
+		if firstErr == nil {
+			if err := db.Close(ctx); err != nil {
+				firstErr = fmt.Errorf("close db %s: %w", name, err)
+			}
+		}
+	}
+
 	if a.Cache != nil {
 		if firstErr == nil {
 			if err := a.Cache.Close(); err != nil {
@@ -172,63 +569,168 @@ func (a *Application) Close(ctx context.Context) error {
 		}
 	}
 
+	if a.Mailer != nil {
+		if firstErr == nil {
+			if err := a.Mailer.Close(); err != nil {
+				firstErr = fmt.Errorf("close mailer: %w", err)
+			}
+		}
+	}
+
+	// 最后关闭链路追踪，确保关闭过程中的 Span 被导出
+	if a.Telemetry != nil {
+		if err := a.Telemetry.Shutdown(ctx); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("shutdown telemetry: %w", err)
+		}
+	}
+
+	// 刷新远程日志输出
+	if a.logCloser != nil {
+		if err := a.logCloser.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("close log sinks: %w", err)
+		}
+	}
+
 	return firstErr
 }
 
+// DBByName 按名称获取数据库，main 返回主库，未配置时返回 nil
+func (a *Application) DBByName(name string) database.Database {
+	if name == config.MainDatabase {
+		return a.DB
+	}
+	return a.databases[name]
+}
+
 // Start 启动核心组件（非阻塞启动 Server）
 func (a *Application) Start(ctx context.Context) error {
+	// 初始化模块（模块在 Init 中注册任务处理器与消息订阅，须先于队列启动）
+	if err := a.InitModules(); err != nil {
+		return fmt.Errorf("init modules: %w", err)
+	}
+	if a.Secrets != nil {
+		a.Secrets.Start()
+	}
+	a.startConfigWatch()
+	if a.Queue != nil {
+		if err := a.Queue.Start(ctx); err != nil {
+			return fmt.Errorf("start queue: %w", err)
+		}
+	}
+	if a.MQ != nil {
+		if err := a.MQ.Start(ctx); err != nil {
+			return fmt.Errorf("start mq: %w", err)
+		}
+	}
+	if err := a.startModules(ctx); err != nil {
+		return fmt.Errorf("start modules: %w", err)
+	}
 	if a.Server != nil && a.serverErrChan == nil {
 		a.serverErrChan = a.Server.StartAsync()
 	}
+	if a.GRPC != nil && a.grpcErrChan == nil {
+		a.grpcErrChan = a.GRPC.StartAsync()
+	}
+
+	// 发布服务启动事件
+	if a.Events != nil && a.started.CompareAndSwap(false, true) {
+		started := &event.ServerStarted{Name: a.Config.Name}
+		if a.Server != nil {
+			started.Addr = fmt.Sprintf("%s:%d", a.Config.Host, a.Config.Port)
+		}
+		if a.GRPC != nil {
+			started.GRPCAddr = fmt.Sprintf("%s:%d", a.Config.GRPC.Host, a.Config.GRPC.Port)
+		}
+		if err := event.Publish(ctx, a.Events, event.TopicServerStarted, started); err != nil {
+			a.Logger.Warn("publish server started event failed", slog.Any("error", err))
+		}
+	}
 	return nil
 }
 
-// Run 运行直到上下文取消或服务器报错（优雅关闭）
+// Run 运行直到上下文取消、收到 SIGINT/SIGTERM 或服务器报错，随后在宽限期内优雅关闭
+// - 关闭期间再次收到信号将立即强制退出进程
 func (a *Application) Run(ctx context.Context) error {
 	// 确保已启动
 	if err := a.Start(ctx); err != nil {
 		return err
 	}
 
-	if a.Server == nil {
-		// 没有 Server，直接等待 ctx 结束
-		<-ctx.Done()
-		return a.Close(ctx)
+	signals := make(chan os.Signal, 2)
+	if a.handleSignals {
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(signals)
 	}
 
 	// 等待退出或错误
+	var runErr error
 	select {
 	case <-ctx.Done():
-		return a.Close(ctx)
+	case sig := <-signals:
+		a.Logger.Info("received signal, shutting down", slog.String("signal", sig.String()))
 	case err, ok := <-a.serverErrChan:
-		if !ok {
-			return nil
-		}
 		// http.ErrServerClosed 视为正常退出
-		if errors.Is(err, http.ErrServerClosed) {
-			return nil
+		if ok && err != nil && !errors.Is(err, http.ErrServerClosed) {
+			runErr = err
+		}
+	case err, ok := <-a.grpcErrChan:
+		if ok && err != nil {
+			runErr = fmt.Errorf("grpc server: %w", err)
 		}
-		return err
 	}
-}
 
-// Health 聚合健康检查
-func (a *Application) Health(ctx context.Context) error {
-	if a.DB != nil {
-		if err := a.DB.HealthCheck(); err != nil {
-			a.Logger.Error("db health check failed", slog.String("component", "db"), slog.Any("error", err))
-			return fmt.Errorf("db unhealthy: %w", err)
+	// 宽限期内关闭（不继承已取消的 ctx）
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), a.shutdownTimeout())
+	defer cancel()
+	go func() {
+		select {
+		case sig := <-signals:
+			a.Logger.Error("received second signal, force quit", slog.String("signal", sig.String()))
+			os.Exit(1)
+		case <-shutdownCtx.Done():
 		}
-	} else {
-		a.Logger.Info("db disabled or not initialized", slog.String("component", "db"))
+	}()
+
+	if err := a.Close(shutdownCtx); err != nil && runErr == nil {
+		runErr = err
 	}
-	if a.Cache != nil {
-		if err := a.Cache.HealthCheck(ctx); err != nil {
-			a.Logger.Error("cache health check failed", slog.String("component", "cache"), slog.Any("error", err))
-			return fmt.Errorf("cache unhealthy: %w", err)
-		}
-	} else {
-		a.Logger.Info("cache disabled or not initialized", slog.String("component", "cache"))
+	return runErr
+}
+
+// shutdownTimeout 优雅关闭宽限期
+func (a *Application) shutdownTimeout() time.Duration {
+	if a.Config == nil || a.Config.ShutdownTimeout == "" {
+		return 30 * time.Second
+	}
+	return a.Config.ParseDuration(a.Config.ShutdownTimeout)
+}
+
+// registerUI 托管内嵌前端（本地构建目录优先）
+func (a *Application) registerUI(fsys fs.FS) {
+	cfg := a.Config.UI
+	if cfg.Dir != "" {
+		fsys = os.DirFS(cfg.Dir)
+	}
+	if fsys == nil {
+		a.Logger.Warn("UI is enabled but no files are provided, use core.WithUI or ui.dir")
+		return
+	}
+	a.Server.SPA(cfg.Path, fsys)
+	a.Logger.Info("UI registered", "path", cfg.Path, "dir", cfg.Dir)
+}
+
+// loadTemplates 加载 HTML 模板（本地模板目录优先）
+func (a *Application) loadTemplates(fsys fs.FS) error {
+	if dir := a.Config.Template.Dir; dir != "" {
+		fsys = os.DirFS(dir)
+	}
+	if fsys == nil {
+		a.Logger.Warn("Templates are enabled but no files are provided, use core.WithTemplates or template.dir")
+		return nil
+	}
+	if err := a.Server.LoadTemplates(fsys); err != nil {
+		return err
 	}
+	a.Logger.Info("Templates loaded", "count", len(a.Server.Views().Names()), "dir", a.Config.Template.Dir)
 	return nil
 }
@@ -20,12 +20,23 @@ func NewFactory(logger *slog.Logger) *Factory {
 }
 
 // CreateDatabase 根据配置创建数据库连接
+// - 启用延迟连接（lazy）时数据库不可用不返回错误，在后台重连
 func (f *Factory) CreateDatabase(cfg *config.DatabaseConfig) (Database, error) {
+	if cfg.Lazy {
+		return f.createLazyDatabase(cfg)
+	}
+	return f.createDatabase(cfg)
+}
+
+// createDatabase 按驱动创建数据库连接
+func (f *Factory) createDatabase(cfg *config.DatabaseConfig) (Database, error) {
 	switch cfg.Driver {
 	case "mysql":
 		return NewMySQLDatabase(cfg, f.logger)
 	case "postgres":
 		return NewPostgreSQLDatabase(cfg, f.logger)
+	case "sqlite":
+		return NewSQLiteDatabase(cfg, f.logger)
 	default:
 		return nil, fmt.Errorf("unsupported database driver: %s", cfg.Driver)
 	}
@@ -85,3 +96,16 @@ func (f *Factory) CreatePostgreSQLDatabase(host string, port int, username, pass
 
 	return f.CreateDatabase(cfg)
 }
+
+// CreateSQLiteDatabase 创建 SQLite 数据库连接（path 为 :memory: 时使用内存库）
+func (f *Factory) CreateSQLiteDatabase(path string) (Database, error) {
+	cfg := &config.DatabaseConfig{
+		Driver:   "sqlite",
+		Database: path,
+	}
+
+	// 设置默认值
+	cfg.SetDefaults()
+
+	return f.CreateDatabase(cfg)
+}
@@ -12,6 +12,9 @@ type Database interface {
 	// 基础操作
 	DB() *gorm.DB
 
+	// 事务：上下文中已存在事务时加入外层事务，否则开启新事务
+	Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error
+
 	// 健康检查
 	HealthCheck() error
 
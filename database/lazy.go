@@ -0,0 +1,138 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+// lazyDatabase 延迟连接模式的数据库
+// - 启动时数据库不可用不返回错误，连接池在数据库恢复后自动建立连接
+// - 后台按间隔检测连接状态，状态变化时记录日志
+type lazyDatabase struct {
+	Database
+	driver    string
+	logger    *slog.Logger
+	interval  time.Duration
+	connected atomic.Bool
+	stop      chan struct{}
+	done      chan struct{}
+	stopOnce  sync.Once
+}
+
+// createLazyDatabase 以延迟连接模式创建数据库（sqlite 连接失败时直接返回错误）
+func (f *Factory) createLazyDatabase(cfg *config.DatabaseConfig) (Database, error) {
+	db, err := f.createDatabase(cfg)
+	connected := err == nil
+	if err != nil {
+		var unverified Database
+		switch cfg.Driver {
+		case "mysql":
+			unverified, err = newMySQLDatabase(cfg, f.logger, false)
+		case "postgres":
+			unverified, err = newPostgreSQLDatabase(cfg, f.logger, false)
+		default:
+			return nil, err
+		}
+		if err != nil {
+			return nil, err
+		}
+		f.logger.Warn("database unavailable, reconnecting in background",
+			slog.String("driver", cfg.Driver),
+			slog.String("host", cfg.Host),
+			slog.String("database", cfg.Database),
+		)
+		db = unverified
+	}
+
+	interval := cfg.ReconnectInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	l := &lazyDatabase{
+		Database: db,
+		driver:   cfg.Driver,
+		logger:   f.logger,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	l.connected.Store(connected)
+	go l.monitor()
+	return l, nil
+}
+
+// IsLazy 是否为延迟连接模式的数据库（数据库不可用时应用仍可运行）
+func IsLazy(db Database) bool {
+	_, ok := db.(*lazyDatabase)
+	return ok
+}
+
+// Connected 数据库最近一次检测是否可用（非延迟连接模式始终为 true）
+func Connected(db Database) bool {
+	if l, ok := db.(*lazyDatabase); ok {
+		return l.connected.Load()
+	}
+	return true
+}
+
+// HealthCheck 健康检查（同时更新连接状态）
+func (l *lazyDatabase) HealthCheck() error {
+	err := l.Database.HealthCheck()
+	l.setConnected(err)
+	return err
+}
+
+// Close 停止后台检测并关闭数据库连接
+func (l *lazyDatabase) Close(ctx context.Context) error {
+	l.stopOnce.Do(func() { close(l.stop) })
+	<-l.done
+	return l.Database.Close(ctx)
+}
+
+// monitor 按间隔检测连接（不可用时连接池随检测重新建立连接）
+func (l *lazyDatabase) monitor() {
+	defer close(l.done)
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+			l.setConnected(l.ping())
+		}
+	}
+}
+
+// ping 检测连接（超时不超过检测间隔）
+func (l *lazyDatabase) ping() error {
+	sqlDB, err := l.DB().DB()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), l.interval)
+	defer cancel()
+	return sqlDB.PingContext(ctx)
+}
+
+// setConnected 更新连接状态，状态变化时记录日志
+func (l *lazyDatabase) setConnected(err error) {
+	connected := err == nil
+	if l.connected.Swap(connected) == connected {
+		return
+	}
+	if connected {
+		l.logger.Info("database reconnected", slog.String("driver", l.driver))
+		return
+	}
+	l.logger.Warn("database unavailable, reconnecting in background",
+		slog.String("driver", l.driver),
+		slog.String("error", fmt.Sprint(err)),
+	)
+}
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+/*
+延迟连接模式功能测试
+
+本文件用于测试数据库启用延迟连接（lazy）时的启动与后台重连。
+
+运行命令：
+go test -v -run "^Test.*Lazy.*$"
+
+测试内容：
+1. 数据库不可用时仍创建成功 (CreateDatabase, IsLazy, Connected)
+2. 后台检测到数据库恢复 (monitor, HealthCheck)
+*/
+
+// TestFactoryCreateLazyDatabase 测试数据库不可用时以延迟连接模式创建
+func TestFactoryCreateLazyDatabase(t *testing.T) {
+	output := &bytes.Buffer{}
+	factory := NewFactory(slog.New(slog.NewTextHandler(output, nil)))
+	cfg := &config.DatabaseConfig{
+		Driver:   "mysql",
+		Host:     "127.0.0.1",
+		Port:     1,
+		Username: "root",
+		Database: "test",
+		LogLevel: "silent",
+	}
+	cfg.SetDefaults()
+
+	if _, err := factory.CreateDatabase(cfg); err == nil {
+		t.Fatal("Expected error without lazy mode")
+	}
+
+	cfg.Lazy = true
+	cfg.ReconnectInterval = time.Hour
+	db, err := factory.CreateDatabase(cfg)
+	if err != nil {
+		t.Fatalf("CreateDatabase failed: %v", err)
+	}
+	defer db.Close(context.Background())
+
+	if !IsLazy(db) || Connected(db) {
+		t.Errorf("Expected lazy and disconnected, got lazy=%v connected=%v", IsLazy(db), Connected(db))
+	}
+	if !strings.Contains(output.String(), "database unavailable") {
+		t.Errorf("Expected unavailable log, got %q", output.String())
+	}
+	if err := db.HealthCheck(); err == nil {
+		t.Error("Expected health check error")
+	}
+	var n int
+	if err := db.DB().Raw("SELECT 1").Scan(&n).Error; err == nil {
+		t.Error("Expected query error while disconnected")
+	}
+}
+
+// TestLazyDatabaseReconnect 测试后台检测到数据库恢复
+func TestLazyDatabaseReconnect(t *testing.T) {
+	output := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(output, nil))
+	sqliteDB, err := NewSQLiteDatabase(&config.DatabaseConfig{Driver: "sqlite", Database: ":memory:", LogLevel: "silent"}, logger)
+	if err != nil {
+		t.Fatalf("NewSQLiteDatabase failed: %v", err)
+	}
+
+	// 以未连接状态启动，模拟数据库恢复
+	db := &lazyDatabase{
+		Database: sqliteDB,
+		driver:   "sqlite",
+		logger:   logger,
+		interval: 10 * time.Millisecond,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go db.monitor()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !Connected(db) && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if !Connected(db) {
+		t.Fatal("Expected reconnected")
+	}
+	if err := db.HealthCheck(); err != nil {
+		t.Errorf("HealthCheck failed: %v", err)
+	}
+
+	if err := db.Close(context.Background()); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if !strings.Contains(output.String(), "database reconnected") {
+		t.Errorf("Expected reconnected log, got %q", output.String())
+	}
+	if err := db.HealthCheck(); err == nil || Connected(db) {
+		t.Errorf("Expected disconnected after close, got %v", err)
+	}
+}
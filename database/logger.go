@@ -8,9 +8,13 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/so68/core/config"
+	"github.com/so68/core/logging"
 	"gorm.io/gorm"
 	gormlogger "gorm.io/gorm/logger"
 )
@@ -18,77 +22,191 @@ import (
 // GormLogger 实现 gorm.Logger 接口
 type GormLogger struct {
 	logger               *slog.Logger
-	LogLevel             gormlogger.LogLevel
+	level                *atomic.Int32       // 运行时日志级别（SetLevel 调整，LogMode 返回的副本固定为 LogLevel）
+	LogLevel             gormlogger.LogLevel // 初始日志级别（NewGormLogger 创建的日志器运行时以 Level 为准）
 	SlowThreshold        time.Duration
 	IgnoreRecordNotFound bool
 	EnableGormSource     bool
 	Colorful             bool
 	LogSQL               bool
+	Placeholders         bool          // 日志中的 SQL 保留占位符，不插入参数值
+	MaskColumns          []string      // 参数值在日志中脱敏的列（忽略大小写）
+	Observer             QueryObserver // SQL 执行观察者（用于导出指标，需在执行查询前设置，不受日志级别影响）
+}
+
+// QueryInfo SQL 执行信息
+type QueryInfo struct {
+	Operation string        // 操作: select, insert, update, delete, other
+	Table     string        // 表名（无法从 SQL 中解析时为空）
+	Duration  time.Duration // 耗时
+	Rows      int64         // 影响行数
+	Slow      bool          // 是否为慢查询（超过 SlowThreshold）
+	Err       error         // 执行错误（记录不存在不视为错误）
+}
+
+// QueryObserver SQL 执行观察者
+type QueryObserver interface {
+	ObserveQuery(ctx context.Context, info QueryInfo)
 }
 
 // NewGormLogger 创建一个新的 GORM 日志记录器
 func NewGormLogger(cfg *config.DatabaseConfig, slogLogger *slog.Logger) *GormLogger {
-	var gormLogLevel gormlogger.LogLevel
-	switch cfg.LogLevel {
-	case "silent":
-		gormLogLevel = gormlogger.Silent
-	case "error":
-		gormLogLevel = gormlogger.Error
-	case "warn":
-		gormLogLevel = gormlogger.Warn
-	case "info":
-		gormLogLevel = gormlogger.Info
-	default:
+	gormLogLevel, err := ParseLogLevel(cfg.LogLevel)
+	if err != nil {
 		gormLogLevel = gormlogger.Info
 	}
+	level := &atomic.Int32{}
+	level.Store(int32(gormLogLevel))
 
 	return &GormLogger{
 		logger:               slogLogger,
+		level:                level,
 		LogLevel:             gormLogLevel,
 		SlowThreshold:        cfg.SlowThreshold,
 		IgnoreRecordNotFound: true,
 		EnableGormSource:     true,
 		Colorful:             true,
 		LogSQL:               true,
+		Placeholders:         cfg.LogPlaceholders,
+		MaskColumns:          cfg.LogMaskColumns,
+	}
+}
+
+// gormLogLevels GORM 日志级别名称
+var gormLogLevels = map[string]gormlogger.LogLevel{
+	"silent": gormlogger.Silent,
+	"error":  gormlogger.Error,
+	"warn":   gormlogger.Warn,
+	"info":   gormlogger.Info,
+}
+
+// ParseLogLevel 解析 GORM 日志级别（silent, error, warn, info）
+func ParseLogLevel(level string) (gormlogger.LogLevel, error) {
+	if l, ok := gormLogLevels[strings.ToLower(level)]; ok {
+		return l, nil
+	}
+	return gormlogger.Info, fmt.Errorf("invalid gorm log level %q", level)
+}
+
+// LogLevelName 返回 GORM 日志级别名称
+func LogLevelName(level gormlogger.LogLevel) string {
+	for name, l := range gormLogLevels {
+		if l == level {
+			return name
+		}
+	}
+	return strconv.Itoa(int(level))
+}
+
+// Level 当前日志级别
+func (l *GormLogger) Level() gormlogger.LogLevel {
+	if l.level != nil {
+		return gormlogger.LogLevel(l.level.Load())
+	}
+	return l.LogLevel
+}
+
+// SetLevel 运行时调整日志级别（可并发调用，对 LogMode 返回的副本无效）
+func (l *GormLogger) SetLevel(level gormlogger.LogLevel) {
+	if l.level != nil {
+		l.level.Store(int32(level))
+		return
+	}
+	l.LogLevel = level
+}
+
+// SetLogLevel 运行时调整数据库的 GORM 日志级别（silent, error, warn, info）
+func SetLogLevel(db Database, level string) error {
+	l, err := ParseLogLevel(level)
+	if err != nil {
+		return err
+	}
+	gl, ok := db.DB().Config.Logger.(*GormLogger)
+	if !ok {
+		return errors.New("database logger does not support changing level")
+	}
+	gl.SetLevel(l)
+	return nil
+}
+
+// LogLevel 返回数据库当前的 GORM 日志级别名称（日志器不是 GormLogger 时为空）
+func LogLevel(db Database) string {
+	gl, ok := db.DB().Config.Logger.(*GormLogger)
+	if !ok {
+		return ""
+	}
+	return LogLevelName(gl.Level())
+}
+
+// SetQueryObserver 为数据库设置 SQL 执行观察者（用于导出指标，需在执行查询前设置）
+func SetQueryObserver(db Database, observer QueryObserver) error {
+	gl, ok := db.DB().Config.Logger.(*GormLogger)
+	if !ok {
+		return errors.New("database logger does not support query observer")
+	}
+	gl.Observer = observer
+	return nil
+}
+
+// loggerFor 优先使用上下文中的请求日志器（携带请求ID、链路追踪ID等关联字段），否则使用数据库日志器
+func (l *GormLogger) loggerFor(ctx context.Context) *slog.Logger {
+	if logger, ok := logging.Lookup(ctx); ok {
+		return logger
 	}
+	return l.logger
 }
 
 // LogMode 实现 gorm.Logger 接口
 func (l *GormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
 	newLogger := *l
+	newLogger.level = nil
 	newLogger.LogLevel = level
 	return &newLogger
 }
 
 // Info 实现 gorm.Logger 接口
 func (l *GormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
-	if l.LogLevel >= gormlogger.Info {
-		l.logger.Info(fmt.Sprintf(msg, data...))
+	if l.Level() >= gormlogger.Info {
+		l.loggerFor(ctx).Info(fmt.Sprintf(msg, data...))
 	}
 }
 
 // Warn 实现 gorm.Logger 接口
 func (l *GormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
-	if l.LogLevel >= gormlogger.Warn {
-		l.logger.Warn(fmt.Sprintf(msg, data...))
+	if l.Level() >= gormlogger.Warn {
+		l.loggerFor(ctx).Warn(fmt.Sprintf(msg, data...))
 	}
 }
 
 // Error 实现 gorm.Logger 接口
 func (l *GormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
-	if l.LogLevel >= gormlogger.Error {
-		l.logger.Error(fmt.Sprintf(msg, data...))
+	if l.Level() >= gormlogger.Error {
+		l.loggerFor(ctx).Error(fmt.Sprintf(msg, data...))
 	}
 }
 
 // Trace 实现 gorm.Logger 接口
 func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
-	if l.LogLevel <= gormlogger.Silent {
+	level := l.Level()
+	if level <= gormlogger.Silent && l.Observer == nil {
 		return
 	}
 
 	elapsed := time.Since(begin)
 	sql, rows := fc()
+	slow := l.SlowThreshold != 0 && elapsed > l.SlowThreshold
+	if l.Observer != nil {
+		info := QueryInfo{Duration: elapsed, Rows: rows, Slow: slow}
+		info.Operation, info.Table = parseSQL(sql)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			info.Err = err
+		}
+		l.Observer.ObserveQuery(ctx, info)
+	}
+	if level <= gormlogger.Silent {
+		return
+	}
+	logger := l.loggerFor(ctx)
 
 	// 构建日志消息
 	var msg string
@@ -102,10 +220,10 @@ func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (stri
 	switch {
 	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
 		// 记录错误
-		l.logger.Error(msg, "error", err)
-	case l.SlowThreshold != 0 && elapsed > l.SlowThreshold:
+		logger.Error(msg, "error", err)
+	case slow:
 		// 记录慢查询
-		l.logger.Warn(msg, "slow_query", fmt.Sprintf(">%v", l.SlowThreshold))
+		logger.Warn(msg, "slow_query", fmt.Sprintf(">%v", l.SlowThreshold))
 	case l.LogSQL:
 		// 记录普通 SQL，使用自定义的调用栈深度
 		if pc, file, line, ok := runtime.Caller(5); ok {
@@ -121,6 +239,36 @@ func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (stri
 			// 添加到日志行
 			msg = fmt.Sprintf("%s | %s:%d | %s", msg, relFile, line, funcName)
 		}
-		l.logger.Info(msg)
+		logger.Info(msg)
+	}
+}
+
+// sqlOperations 统计的 SQL 操作（其他语句记为 other，避免标签基数膨胀）
+var sqlOperations = map[string]string{
+	"select":  "FROM",
+	"delete":  "FROM",
+	"insert":  "INTO",
+	"replace": "INTO",
+	"update":  "UPDATE",
+}
+
+// parseSQL 从 SQL 中解析操作与表名（例如 SELECT * FROM `users` WHERE ... 返回 select, users）
+func parseSQL(sql string) (operation, table string) {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "other", ""
+	}
+	operation = strings.ToLower(fields[0])
+	keyword, ok := sqlOperations[operation]
+	if !ok {
+		return "other", ""
+	}
+	for i := 0; i+1 < len(fields); i++ {
+		if strings.EqualFold(fields[i], keyword) {
+			name, _, _ := strings.Cut(fields[i+1], "(")
+			name = strings.TrimRight(name, ",;")
+			return operation, strings.NewReplacer("`", "", `"`, "", "[", "", "]", "").Replace(name)
+		}
 	}
+	return operation, ""
 }
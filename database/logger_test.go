@@ -0,0 +1,179 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/so68/core/config"
+	"github.com/so68/core/logging"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+/*
+GORM 日志记录器功能测试
+
+本文件用于测试GormLogger结构体的各种功能特性，
+包括请求上下文日志器关联、日志级别过滤等。
+
+运行命令：
+go test -v -run "^TestGormLogger.*$"
+
+测试内容：
+1. SQL 日志携带请求上下文关联字段 (Trace)
+2. 日志级别过滤 (LogMode)
+3. 运行时调整日志级别 (SetLevel, SetLogLevel)
+4. SQL 执行观察者与 SQL 解析 (SetQueryObserver, parseSQL)
+*/
+
+func TestGormLoggerContext(t *testing.T) {
+	dbOutput := &bytes.Buffer{}
+	requestOutput := &bytes.Buffer{}
+	dbLogger := slog.New(slog.NewJSONHandler(dbOutput, nil))
+	requestLogger := slog.New(slog.NewJSONHandler(requestOutput, nil)).With(logging.KeyRequestID, "req-1")
+
+	db, err := NewSQLiteDatabase(&config.DatabaseConfig{Driver: "sqlite", Database: ":memory:", LogLevel: "info"}, dbLogger)
+	if err != nil {
+		t.Fatalf("NewSQLiteDatabase failed: %v", err)
+	}
+	defer db.Close(context.Background())
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		output   *bytes.Buffer
+		expected string
+	}{
+		{name: "request context", ctx: logging.WithContext(context.Background(), requestLogger), output: requestOutput, expected: `"request_id":"req-1"`},
+		{name: "background context", ctx: context.Background(), output: dbOutput, expected: "SELECT 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbOutput.Reset()
+			requestOutput.Reset()
+			if err := db.DB().WithContext(tt.ctx).Exec("SELECT 1").Error; err != nil {
+				t.Fatalf("Exec failed: %v", err)
+			}
+			if !strings.Contains(tt.output.String(), tt.expected) || !strings.Contains(tt.output.String(), "SELECT 1") {
+				t.Errorf("Expected SQL log containing %s, got %q", tt.expected, tt.output.String())
+			}
+		})
+	}
+}
+
+func TestGormLoggerLogMode(t *testing.T) {
+	output := &bytes.Buffer{}
+	logger := NewGormLogger(&config.DatabaseConfig{LogLevel: "info"}, slog.New(slog.NewJSONHandler(output, nil)))
+
+	logger.LogMode(gormlogger.Silent).Info(context.Background(), "silent %s", "message")
+	if output.Len() != 0 {
+		t.Errorf("Expected no output in silent mode, got %q", output.String())
+	}
+
+	logger.Info(context.Background(), "info %s", "message")
+	if !strings.Contains(output.String(), "info message") {
+		t.Errorf("Expected info output, got %q", output.String())
+	}
+}
+
+func TestGormLoggerSetLevel(t *testing.T) {
+	output := &bytes.Buffer{}
+	db, err := NewSQLiteDatabase(&config.DatabaseConfig{Driver: "sqlite", Database: ":memory:", LogLevel: "silent"}, slog.New(slog.NewJSONHandler(output, nil)))
+	if err != nil {
+		t.Fatalf("NewSQLiteDatabase failed: %v", err)
+	}
+	defer db.Close(context.Background())
+
+	output.Reset()
+	if err := db.DB().Exec("SELECT 1").Error; err != nil || output.Len() != 0 {
+		t.Fatalf("Expected no output in silent mode, got %q, %v", output.String(), err)
+	}
+	if err := SetLogLevel(db, "info"); err != nil {
+		t.Fatalf("SetLogLevel failed: %v", err)
+	}
+	if LogLevel(db) != "info" {
+		t.Errorf("Expected level info, got %q", LogLevel(db))
+	}
+	if err := db.DB().Exec("SELECT 2").Error; err != nil || !strings.Contains(output.String(), "SELECT 2") {
+		t.Errorf("Expected SQL output after level change, got %q, %v", output.String(), err)
+	}
+	if err := SetLogLevel(db, "verbose"); err == nil {
+		t.Error("Expected error for invalid level")
+	}
+
+	// LogMode 返回的副本不受运行时调整影响
+	logger := NewGormLogger(&config.DatabaseConfig{LogLevel: "info"}, slog.New(slog.NewJSONHandler(output, nil)))
+	silent := logger.LogMode(gormlogger.Silent)
+	logger.SetLevel(gormlogger.Info)
+	output.Reset()
+	silent.Info(context.Background(), "copy %s", "message")
+	if output.Len() != 0 {
+		t.Errorf("Expected LogMode copy to stay silent, got %q", output.String())
+	}
+}
+
+// recordObserver 记录 SQL 执行信息
+type recordObserver struct {
+	infos []QueryInfo
+}
+
+func (o *recordObserver) ObserveQuery(ctx context.Context, info QueryInfo) {
+	o.infos = append(o.infos, info)
+}
+
+func TestGormLoggerObserver(t *testing.T) {
+	db, err := NewSQLiteDatabase(&config.DatabaseConfig{Driver: "sqlite", Database: ":memory:", LogLevel: "silent"}, slog.New(slog.NewJSONHandler(&bytes.Buffer{}, nil)))
+	if err != nil {
+		t.Fatalf("NewSQLiteDatabase failed: %v", err)
+	}
+	defer db.Close(context.Background())
+
+	observer := &recordObserver{}
+	if err := SetQueryObserver(db, observer); err != nil {
+		t.Fatalf("SetQueryObserver failed: %v", err)
+	}
+	if err := db.DB().Exec("CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)").Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.DB().Exec("INSERT INTO users (name) VALUES (?)", "alice").Error; err != nil {
+		t.Fatal(err)
+	}
+	var name string
+	db.DB().Raw("SELECT name FROM missing").Scan(&name)
+
+	if len(observer.infos) != 3 {
+		t.Fatalf("Expected 3 observed queries in silent mode, got %d", len(observer.infos))
+	}
+	if info := observer.infos[1]; info.Operation != "insert" || info.Table != "users" || info.Rows != 1 || info.Err != nil {
+		t.Errorf("Unexpected insert info: %+v", info)
+	}
+	if info := observer.infos[2]; info.Operation != "select" || info.Table != "missing" || info.Err == nil {
+		t.Errorf("Expected select error on missing table: %+v", info)
+	}
+}
+
+func TestParseSQL(t *testing.T) {
+	tests := []struct {
+		sql       string
+		operation string
+		table     string
+	}{
+		{sql: "SELECT * FROM `users` WHERE `users`.`id` = 1", operation: "select", table: "users"},
+		{sql: `select count(*) from "public"."orders"`, operation: "select", table: "public.orders"},
+		{sql: "INSERT INTO `users` (`name`) VALUES ('a')", operation: "insert", table: "users"},
+		{sql: "INSERT INTO users(name) VALUES ('a')", operation: "insert", table: "users"},
+		{sql: "UPDATE `users` SET `name`='b'", operation: "update", table: "users"},
+		{sql: "DELETE FROM users WHERE id = 1", operation: "delete", table: "users"},
+		{sql: "SELECT 1", operation: "select", table: ""},
+		{sql: "PRAGMA foreign_keys = ON", operation: "other", table: ""},
+		{sql: "", operation: "other", table: ""},
+	}
+	for _, tt := range tests {
+		operation, table := parseSQL(tt.sql)
+		if operation != tt.operation || table != tt.table {
+			t.Errorf("parseSQL(%q) = %q, %q, want %q, %q", tt.sql, operation, table, tt.operation, tt.table)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DefaultTable 默认迁移历史表名
+const DefaultTable = "schema_migrations"
+
+// ErrNoMigrations 没有可回滚的迁移
+var ErrNoMigrations = errors.New("no applied migrations")
+
+// Migration 版本化迁移
+// - Version 按字典序决定执行顺序，建议使用时间戳（例如 20240101120000）
+// - Up/Down 在事务中执行（MySQL 的 DDL 会隐式提交，无法整体回滚）
+type Migration struct {
+	Version string
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// History 迁移历史记录
+type History struct {
+	Version   string    `gorm:"primaryKey;size:64"`
+	Name      string    `gorm:"size:255"`
+	Batch     int       `gorm:"index"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+// Status 迁移状态
+type Status struct {
+	Version   string     `json:"version"`              // 版本号
+	Name      string     `json:"name"`                 // 名称
+	Applied   bool       `json:"applied"`              // 是否已执行
+	Batch     int        `json:"batch,omitempty"`      // 执行批次（同一次 MigrateUp 执行的迁移批次相同）
+	AppliedAt *time.Time `json:"applied_at,omitempty"` // 执行时间
+	Missing   bool       `json:"missing,omitempty"`    // 已执行但未注册（迁移代码或文件已被删除）
+}
+
+// Option 迁移执行器可选项
+type Option func(*Migrator)
+
+// WithTable 指定迁移历史表名
+func WithTable(table string) Option {
+	return func(m *Migrator) { m.table = table }
+}
+
+// Migrator 迁移执行器
+type Migrator struct {
+	db         *gorm.DB
+	logger     *slog.Logger
+	table      string
+	migrations map[string]*Migration
+}
+
+// New 创建迁移执行器
+func New(db *gorm.DB, logger *slog.Logger, opts ...Option) *Migrator {
+	m := &Migrator{
+		db:         db,
+		logger:     logger,
+		table:      DefaultTable,
+		migrations: make(map[string]*Migration),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Add 注册迁移，版本号重复时返回错误
+func (m *Migrator) Add(migrations ...*Migration) error {
+	for _, migration := range migrations {
+		if migration.Version == "" {
+			return fmt.Errorf("migration version is required")
+		}
+		if migration.Up == nil {
+			return fmt.Errorf("migration %s: up is required", migration.Version)
+		}
+		if _, exists := m.migrations[migration.Version]; exists {
+			return fmt.Errorf("duplicate migration version: %s", migration.Version)
+		}
+		m.migrations[migration.Version] = migration
+	}
+	return nil
+}
+
+// MigrateUp 按版本顺序执行所有未执行的迁移，返回本次执行的版本号
+func (m *Migrator) MigrateUp(ctx context.Context) ([]string, error) {
+	db, err := m.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	histories, err := m.histories(db)
+	if err != nil {
+		return nil, err
+	}
+	applied := make(map[string]bool, len(histories))
+	batch := 0
+	for _, history := range histories {
+		applied[history.Version] = true
+		if history.Batch > batch {
+			batch = history.Batch
+		}
+	}
+	batch++
+
+	executed := make([]string, 0)
+	for _, migration := range m.sorted() {
+		if applied[migration.Version] {
+			continue
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Up(tx); err != nil {
+				return err
+			}
+			return tx.Table(m.table).Create(&History{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				Batch:     batch,
+				AppliedAt: time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return executed, fmt.Errorf("migrate up %s: %w", migration.Version, err)
+		}
+		executed = append(executed, migration.Version)
+		m.logger.Info("migration applied", slog.String("version", migration.Version), slog.String("name", migration.Name), slog.Int("batch", batch))
+	}
+	return executed, nil
+}
+
+// MigrateDown 按版本倒序回滚最近执行的 steps 个迁移（steps <= 0 时回滚 1 个），返回回滚的版本号
+func (m *Migrator) MigrateDown(ctx context.Context, steps int) ([]string, error) {
+	if steps <= 0 {
+		steps = 1
+	}
+	db, err := m.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	histories, err := m.histories(db)
+	if err != nil {
+		return nil, err
+	}
+	if len(histories) == 0 {
+		return nil, ErrNoMigrations
+	}
+
+	rolledBack := make([]string, 0, steps)
+	for i := len(histories) - 1; i >= 0 && len(rolledBack) < steps; i-- {
+		history := histories[i]
+		migration, ok := m.migrations[history.Version]
+		if !ok {
+			return rolledBack, fmt.Errorf("migrate down %s: migration not registered", history.Version)
+		}
+		if migration.Down == nil {
+			return rolledBack, fmt.Errorf("migrate down %s: down is not defined", history.Version)
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Down(tx); err != nil {
+				return err
+			}
+			return tx.Table(m.table).Where("version = ?", history.Version).Delete(&History{}).Error
+		})
+		if err != nil {
+			return rolledBack, fmt.Errorf("migrate down %s: %w", history.Version, err)
+		}
+		rolledBack = append(rolledBack, history.Version)
+		m.logger.Info("migration rolled back", slog.String("version", history.Version), slog.String("name", migration.Name))
+	}
+	return rolledBack, nil
+}
+
+// Status 获取所有迁移的执行状态（按版本排序，包含已执行但未注册的迁移）
+func (m *Migrator) Status(ctx context.Context) ([]*Status, error) {
+	db, err := m.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	histories, err := m.histories(db)
+	if err != nil {
+		return nil, err
+	}
+	applied := make(map[string]*History, len(histories))
+	for _, history := range histories {
+		applied[history.Version] = history
+	}
+
+	statuses := make([]*Status, 0, len(m.migrations)+len(histories))
+	for _, migration := range m.sorted() {
+		status := &Status{Version: migration.Version, Name: migration.Name}
+		if history, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.Batch = history.Batch
+			status.AppliedAt = &history.AppliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	for _, history := range histories {
+		if _, ok := m.migrations[history.Version]; !ok {
+			statuses = append(statuses, &Status{
+				Version:   history.Version,
+				Name:      history.Name,
+				Applied:   true,
+				Batch:     history.Batch,
+				AppliedAt: &history.AppliedAt,
+				Missing:   true,
+			})
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// prepare 绑定上下文并确保迁移历史表存在
+func (m *Migrator) prepare(ctx context.Context) (*gorm.DB, error) {
+	db := m.db.WithContext(ctx)
+	if err := db.Table(m.table).AutoMigrate(&History{}); err != nil {
+		return nil, fmt.Errorf("create migration table: %w", err)
+	}
+	return db, nil
+}
+
+// histories 已执行的迁移（按版本排序）
+func (m *Migrator) histories(db *gorm.DB) ([]*History, error) {
+	histories := make([]*History, 0)
+	if err := db.Table(m.table).Order("version").Find(&histories).Error; err != nil {
+		return nil, fmt.Errorf("load migration history: %w", err)
+	}
+	return histories, nil
+}
+
+// sorted 按版本排序的已注册迁移
+func (m *Migrator) sorted() []*Migration {
+	migrations := make([]*Migration, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations
+}
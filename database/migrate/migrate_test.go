@@ -0,0 +1,279 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"gorm.io/gorm"
+
+	"github.com/so68/core/config"
+	"github.com/so68/core/database"
+)
+
+/*
+数据库迁移功能测试
+
+本文件用于测试Migrator结构体的各种功能特性，
+包括迁移注册、执行、回滚、状态查询与 SQL 文件加载等。
+
+运行命令：
+go test -v -run "^Test.*Migrat.*$"
+
+测试内容：
+1. 迁移注册校验 (Add)
+2. 执行与回滚 (MigrateUp, MigrateDown)
+3. 状态查询 (Status)
+4. SQL 文件加载 (AddFS, splitStatements)
+*/
+
+// newTestDB 创建 SQLite 内存库
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := database.NewSQLiteDatabase(&config.DatabaseConfig{Driver: "sqlite", Database: ":memory:", LogLevel: "silent"}, newTestLogger())
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close(context.Background()) })
+	return db.DB()
+}
+
+// newTestLogger 创建测试用日志器
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelWarn}))
+}
+
+// createTable 创建表的 Go 迁移
+func createTable(version string, table string) *Migration {
+	return &Migration{
+		Version: version,
+		Name:    "create_" + table,
+		Up: func(tx *gorm.DB) error {
+			return tx.Exec("CREATE TABLE " + table + " (id INTEGER PRIMARY KEY)").Error
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Exec("DROP TABLE " + table).Error
+		},
+	}
+}
+
+func TestMigratorAdd(t *testing.T) {
+	tests := []struct {
+		name       string
+		migrations []*Migration
+		wantErr    bool
+	}{
+		{name: "valid", migrations: []*Migration{createTable("001", "a"), createTable("002", "b")}},
+		{name: "missing version", migrations: []*Migration{{Up: func(tx *gorm.DB) error { return nil }}}, wantErr: true},
+		{name: "missing up", migrations: []*Migration{{Version: "001"}}, wantErr: true},
+		{name: "duplicate version", migrations: []*Migration{createTable("001", "a"), createTable("001", "b")}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := New(nil, newTestLogger()).Add(tt.migrations...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestMigratorUpDown(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	m := New(db, newTestLogger())
+	if err := m.Add(createTable("002", "posts"), createTable("001", "users")); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	// 按版本顺序执行
+	applied, err := m.MigrateUp(ctx)
+	if err != nil {
+		t.Fatalf("MigrateUp failed: %v", err)
+	}
+	if !reflect.DeepEqual(applied, []string{"001", "002"}) {
+		t.Errorf("expected [001 002], got %v", applied)
+	}
+	if !db.Migrator().HasTable("users") || !db.Migrator().HasTable("posts") {
+		t.Fatal("expected tables to be created")
+	}
+
+	// 重复执行无待执行迁移
+	if applied, _ := m.MigrateUp(ctx); len(applied) != 0 {
+		t.Errorf("expected no pending migrations, got %v", applied)
+	}
+
+	// 新增迁移属于新批次
+	if err := m.Add(createTable("003", "tags")); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if applied, _ := m.MigrateUp(ctx); !reflect.DeepEqual(applied, []string{"003"}) {
+		t.Errorf("expected [003], got %v", applied)
+	}
+
+	// 倒序回滚
+	rolledBack, err := m.MigrateDown(ctx, 2)
+	if err != nil {
+		t.Fatalf("MigrateDown failed: %v", err)
+	}
+	if !reflect.DeepEqual(rolledBack, []string{"003", "002"}) {
+		t.Errorf("expected [003 002], got %v", rolledBack)
+	}
+	if db.Migrator().HasTable("posts") || !db.Migrator().HasTable("users") {
+		t.Error("expected posts dropped and users kept")
+	}
+
+	if _, err := m.MigrateDown(ctx, 0); err != nil {
+		t.Fatalf("MigrateDown failed: %v", err)
+	}
+	if _, err := m.MigrateDown(ctx, 1); !errors.Is(err, ErrNoMigrations) {
+		t.Errorf("expected ErrNoMigrations, got %v", err)
+	}
+}
+
+func TestMigratorUpFailure(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	m := New(db, newTestLogger(), WithTable("migrations"))
+	failing := &Migration{
+		Version: "002",
+		Name:    "broken",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Exec("CREATE TABLE partial (id INTEGER)").Error; err != nil {
+				return err
+			}
+			return errors.New("boom")
+		},
+	}
+	if err := m.Add(createTable("001", "users"), failing); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	applied, err := m.MigrateUp(ctx)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !reflect.DeepEqual(applied, []string{"001"}) {
+		t.Errorf("expected [001], got %v", applied)
+	}
+	// 失败的迁移在事务中回滚，且不记录历史
+	if db.Migrator().HasTable("partial") {
+		t.Error("expected failed migration to be rolled back")
+	}
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if len(statuses) != 2 || !statuses[0].Applied || statuses[1].Applied {
+		t.Errorf("unexpected statuses: %+v, %+v", statuses[0], statuses[1])
+	}
+}
+
+func TestMigratorStatus(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	m := New(db, newTestLogger())
+	_ = m.Add(createTable("001", "users"))
+	if _, err := m.MigrateUp(ctx); err != nil {
+		t.Fatalf("MigrateUp failed: %v", err)
+	}
+
+	// 新的执行器未注册 001，已执行的迁移标记为 missing
+	other := New(db, newTestLogger())
+	_ = other.Add(createTable("002", "posts"))
+	statuses, err := other.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+
+	tests := []struct {
+		version string
+		applied bool
+		missing bool
+	}{
+		{version: "001", applied: true, missing: true},
+		{version: "002", applied: false, missing: false},
+	}
+	if len(statuses) != len(tests) {
+		t.Fatalf("expected %d statuses, got %d", len(tests), len(statuses))
+	}
+	for i, tt := range tests {
+		status := statuses[i]
+		if status.Version != tt.version || status.Applied != tt.applied || status.Missing != tt.missing {
+			t.Errorf("status %d: expected %+v, got %+v", i, tt, status)
+		}
+		if tt.applied && (status.AppliedAt == nil || status.Batch != 1) {
+			t.Errorf("status %d: expected applied time and batch", i)
+		}
+	}
+}
+
+func TestMigratorAddFS(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	fsys := fstest.MapFS{
+		"migrations/20240101000000_create_users.up.sql": {Data: []byte(`
+-- 用户表
+CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT);
+INSERT INTO users (name) VALUES ('a;b');
+`)},
+		"migrations/20240101000000_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+		"migrations/20240102000000_create_posts.up.sql":   {Data: []byte("/* 文章表 */ CREATE TABLE posts (id INTEGER PRIMARY KEY)")},
+		"migrations/README.md":                            {Data: []byte("ignored")},
+	}
+
+	m := New(db, newTestLogger())
+	if err := m.AddFS(fsys, "migrations"); err != nil {
+		t.Fatalf("AddFS failed: %v", err)
+	}
+	applied, err := m.MigrateUp(ctx)
+	if err != nil {
+		t.Fatalf("MigrateUp failed: %v", err)
+	}
+	if len(applied) != 2 {
+		t.Fatalf("expected 2 migrations, got %v", applied)
+	}
+
+	var name string
+	db.Raw("SELECT name FROM users").Scan(&name)
+	if name != "a;b" {
+		t.Errorf("expected 'a;b', got %q", name)
+	}
+
+	// 缺少 down 文件的迁移无法回滚
+	if _, err := m.MigrateDown(ctx, 1); err == nil {
+		t.Error("expected error for missing down migration")
+	}
+
+	// 缺少 up 文件
+	broken := fstest.MapFS{"m/001_x.down.sql": {Data: []byte("SELECT 1")}}
+	if err := New(db, newTestLogger()).AddFS(broken, "m"); err == nil {
+		t.Error("expected error for missing up file")
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want []string
+	}{
+		{name: "single", sql: "SELECT 1", want: []string{"SELECT 1"}},
+		{name: "multiple", sql: "SELECT 1; SELECT 2;", want: []string{"SELECT 1", "SELECT 2"}},
+		{name: "quoted semicolon", sql: "INSERT INTO t VALUES ('a;b'); SELECT 1", want: []string{"INSERT INTO t VALUES ('a;b')", "SELECT 1"}},
+		{name: "comments", sql: "-- x;\nSELECT 1; /* y; */ SELECT 2", want: []string{"SELECT 1", "SELECT 2"}},
+		{name: "empty", sql: " ; ;", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitStatements(tt.sql); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package migrate
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// AddFS 从文件系统加载 SQL 迁移（可配合 embed.FS 使用）
+// - 文件名格式：<version>_<name>.up.sql 与 <version>_<name>.down.sql，down 文件可省略
+// - 文件内多条语句以分号分隔，逐条执行
+func (m *Migrator) AddFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return fmt.Errorf("read migration dir: %w", err)
+	}
+
+	migrations := make(map[string]*Migration)
+	order := make([]string, 0)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		version, name, direction, ok := parseFilename(entry.Name())
+		if !ok {
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("read migration %s: %w", entry.Name(), err)
+		}
+
+		migration, exists := migrations[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: name}
+			migrations[version] = migration
+			order = append(order, version)
+		}
+		statements := splitStatements(string(data))
+		if direction == "up" {
+			migration.Up = execStatements(statements)
+		} else {
+			migration.Down = execStatements(statements)
+		}
+	}
+
+	for _, version := range order {
+		if migrations[version].Up == nil {
+			return fmt.Errorf("migration %s: missing up file", version)
+		}
+		if err := m.Add(migrations[version]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseFilename 解析迁移文件名
+func parseFilename(filename string) (version string, name string, direction string, ok bool) {
+	switch {
+	case strings.HasSuffix(filename, ".up.sql"):
+		direction = "up"
+	case strings.HasSuffix(filename, ".down.sql"):
+		direction = "down"
+	default:
+		return "", "", "", false
+	}
+	base := strings.TrimSuffix(filename, "."+direction+".sql")
+	version, name, _ = strings.Cut(base, "_")
+	return version, name, direction, version != ""
+}
+
+// execStatements 逐条执行 SQL 语句
+func execStatements(statements []string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// splitStatements 按分号拆分 SQL 语句（忽略引号内的分号与注释）
+func splitStatements(sql string) []string {
+	statements := make([]string, 0)
+	var current strings.Builder
+	var quote byte
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case quote != 0:
+			current.WriteByte(c)
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+			current.WriteByte(c)
+		case c == '-' && i+1 < len(sql) && sql[i+1] == '-':
+			// 跳过行注释
+			for i < len(sql) && sql[i] != '\n' {
+				i++
+			}
+			current.WriteByte('\n')
+		case c == '/' && i+1 < len(sql) && sql[i+1] == '*':
+			// 跳过块注释
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += end + 3
+			}
+		case c == ';':
+			if statement := strings.TrimSpace(current.String()); statement != "" {
+				statements = append(statements, statement)
+			}
+			current.Reset()
+		default:
+			current.WriteByte(c)
+		}
+	}
+	if statement := strings.TrimSpace(current.String()); statement != "" {
+		statements = append(statements, statement)
+	}
+	return statements
+}
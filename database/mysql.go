@@ -20,6 +20,11 @@ type MySQLDatabase struct {
 
 // NewMySQLDatabase 创建 MySQL 数据库连接
 func NewMySQLDatabase(cfg *config.DatabaseConfig, slogLogger *slog.Logger) (*MySQLDatabase, error) {
+	return newMySQLDatabase(cfg, slogLogger, true)
+}
+
+// newMySQLDatabase 创建 MySQL 数据库连接（verify 为 false 时不检测连接，连接在首次使用时建立，用于延迟连接模式）
+func newMySQLDatabase(cfg *config.DatabaseConfig, slogLogger *slog.Logger, verify bool) (*MySQLDatabase, error) {
 	if cfg.Driver != "mysql" {
 		return nil, fmt.Errorf("invalid driver: expected mysql, got %s", cfg.Driver)
 	}
@@ -29,14 +34,25 @@ func NewMySQLDatabase(cfg *config.DatabaseConfig, slogLogger *slog.Logger) (*MyS
 		Logger:                                   NewGormLogger(cfg, slogLogger),
 		PrepareStmt:                              cfg.PrepareStmt,
 		DisableForeignKeyConstraintWhenMigrating: cfg.DisableForeignKeyConstraintWhenMigrating,
+		DisableAutomaticPing:                     !verify,
+	}
+
+	// 不检测连接时跳过服务器版本查询（按较新版本的特性处理）
+	open := func(dsn string) gorm.Dialector {
+		return mysql.New(mysql.Config{DSN: dsn, SkipInitializeWithVersion: !verify})
 	}
 
 	// 连接数据库
-	db, err := gorm.Open(mysql.Open(cfg.GetDSN()), gormConfig)
+	db, err := gorm.Open(open(cfg.GetDSN()), gormConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to MySQL: %w", err)
 	}
 
+	// 设置查询超时
+	if err := registerQueryTimeout(db, cfg.QueryTimeout); err != nil {
+		return nil, err
+	}
+
 	// 获取底层 sql.DB 进行连接池配置
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -49,9 +65,16 @@ func NewMySQLDatabase(cfg *config.DatabaseConfig, slogLogger *slog.Logger) (*MyS
 	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
 	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 
+	// 注册从库（读写分离）
+	if err := registerReplicas(db, cfg, open); err != nil {
+		return nil, err
+	}
+
 	// 测试连接
-	if err := sqlDB.Ping(); err != nil {
-		return nil, fmt.Errorf("failed to ping MySQL: %w", err)
+	if verify {
+		if err := sqlDB.Ping(); err != nil {
+			return nil, fmt.Errorf("failed to ping MySQL: %w", err)
+		}
 	}
 
 	mysqlDB := &MySQLDatabase{
@@ -60,13 +83,16 @@ func NewMySQLDatabase(cfg *config.DatabaseConfig, slogLogger *slog.Logger) (*MyS
 		logger: slogLogger,
 	}
 
-	mysqlDB.logger.Info("MySQL database connected successfully",
-		slog.String("host", cfg.Host),
-		slog.Int("port", cfg.Port),
-		slog.String("database", cfg.Database),
-		slog.Int("max_open_conns", cfg.MaxOpenConns),
-		slog.Int("max_idle_conns", cfg.MaxIdleConns),
-	)
+	if verify {
+		mysqlDB.logger.Info("MySQL database connected successfully",
+			slog.String("host", cfg.Host),
+			slog.Int("port", cfg.Port),
+			slog.String("database", cfg.Database),
+			slog.Int("max_open_conns", cfg.MaxOpenConns),
+			slog.Int("max_idle_conns", cfg.MaxIdleConns),
+			slog.Int("replicas", len(cfg.Replicas)),
+		)
+	}
 
 	return mysqlDB, nil
 }
@@ -76,6 +102,11 @@ func (m *MySQLDatabase) DB() *gorm.DB {
 	return m.db
 }
 
+// Transaction 在事务中执行（上下文中已存在事务时加入外层事务）
+func (m *MySQLDatabase) Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return Transaction(ctx, m.db, fn)
+}
+
 // HealthCheck 健康检查
 func (m *MySQLDatabase) HealthCheck() error {
 	sqlDB, err := m.db.DB()
@@ -356,6 +356,35 @@ func TestMySQLDatabase_DSN(t *testing.T) {
 			},
 			expected: "root:@tcp(localhost:3306)/testdb?charset=utf8mb4",
 		},
+		{
+			name: "dsn_with_driver_timeout",
+			config: &config.DatabaseConfig{
+				Driver:        "mysql",
+				Host:          "localhost",
+				Port:          3306,
+				Username:      "root",
+				Password:      "password",
+				Database:      "testdb",
+				Charset:       "utf8mb4",
+				QueryTimeout:  30 * time.Second,
+				DriverTimeout: true,
+			},
+			expected: "root:password@tcp(localhost:3306)/testdb?charset=utf8mb4&readTimeout=30s&writeTimeout=30s",
+		},
+		{
+			name: "dsn_without_driver_timeout",
+			config: &config.DatabaseConfig{
+				Driver:       "mysql",
+				Host:         "localhost",
+				Port:         3306,
+				Username:     "root",
+				Password:     "password",
+				Database:     "testdb",
+				Charset:      "utf8mb4",
+				QueryTimeout: 30 * time.Second,
+			},
+			expected: "root:password@tcp(localhost:3306)/testdb?charset=utf8mb4",
+		},
 	}
 
 	for _, tt := range tests {
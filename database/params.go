@@ -0,0 +1,215 @@
+package database
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// logMaskedValue 日志中脱敏参数的占位值
+const logMaskedValue = "******"
+
+// ParamsFilter 实现 gorm.ParamsFilter 接口，在写入日志前处理 SQL 参数
+// - Placeholders 为 true 时保留占位符，不插入任何参数值
+// - 否则 MaskColumns 中的列对应的参数值替换为 ******
+func (l *GormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if l.Placeholders {
+		return sql, nil
+	}
+	if len(l.MaskColumns) == 0 || len(params) == 0 {
+		return sql, params
+	}
+
+	var masked []interface{}
+	for i, column := range paramColumns(sql, len(params)) {
+		if !l.maskColumn(column) {
+			continue
+		}
+		if masked == nil {
+			masked = append([]interface{}(nil), params...)
+		}
+		masked[i] = logMaskedValue
+	}
+	if masked == nil {
+		return sql, params
+	}
+	return sql, masked
+}
+
+// maskColumn 列的参数值是否需要脱敏
+func (l *GormLogger) maskColumn(column string) bool {
+	if column == "" {
+		return false
+	}
+	for _, c := range l.MaskColumns {
+		if strings.EqualFold(c, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// sqlKeywords 解析参数所属列时跳过的关键字（占位符归属于其前最近的列名，例如 password = ?、id IN (?, ?)）
+var sqlKeywords = map[string]bool{
+	"select": true, "insert": true, "replace": true, "update": true, "delete": true, "into": true, "from": true,
+	"and": true, "or": true, "not": true, "in": true, "like": true, "ilike": true, "between": true, "is": true,
+	"null": true, "true": true, "false": true, "escape": true, "exists": true, "any": true, "all": true,
+	"as": true, "on": true, "join": true, "left": true, "right": true, "inner": true, "outer": true,
+	"order": true, "group": true, "by": true, "having": true, "asc": true, "desc": true, "distinct": true,
+	"case": true, "when": true, "then": true, "else": true, "end": true, "returning": true,
+	"conflict": true, "do": true, "duplicate": true, "key": true, "nothing": true,
+}
+
+// sqlClauseKeywords 开始新子句的关键字（之后的占位符不再归属于之前的列，例如 LIMIT ?）
+var sqlClauseKeywords = map[string]bool{
+	"where": true, "set": true, "values": true, "limit": true, "offset": true, "fetch": true,
+}
+
+// paramColumns 解析每个参数所属的列名（小写，无法确定时为空）
+// - INSERT 语句按列清单与 VALUES 中的位置对应
+// - 其他语句取占位符之前最近的列名（表名前缀与引号会被去除）
+// - 同时支持 ? 与 $n 占位符
+func paramColumns(sql string, n int) []string {
+	columns := make([]string, n)
+	var (
+		index      int      // 下一个 ? 占位符的序号
+		last       string   // 最近的列名
+		first      string   // 第一个关键字
+		insertCols []string // INSERT 列清单
+		inCols     bool     // 是否正在读取 INSERT 列清单
+		inValues   bool     // 是否处于 INSERT VALUES 中
+		depth      int      // VALUES 中的括号层级
+		position   int      // VALUES 当前元组中的位置
+	)
+	assign := func(i int) {
+		if i < 0 || i >= n {
+			return
+		}
+		if inValues && depth > 0 {
+			if position < len(insertCols) {
+				columns[i] = insertCols[position]
+			}
+			return
+		}
+		columns[i] = last
+	}
+	ident := func(name string) {
+		name = strings.ToLower(name)
+		if inCols {
+			insertCols = append(insertCols, name)
+		}
+		last = name
+	}
+
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		switch {
+		case c == '\'':
+			// 字符串字面量（'' 为转义的单引号）
+			i++
+			for i < len(sql) {
+				if sql[i] == '\'' {
+					if i+1 < len(sql) && sql[i+1] == '\'' {
+						i += 2
+						continue
+					}
+					break
+				}
+				i++
+			}
+			i++
+		case c == '`' || c == '"':
+			end := strings.IndexByte(sql[i+1:], c)
+			if end < 0 {
+				return columns
+			}
+			ident(sql[i+1 : i+1+end])
+			i += end + 2
+		case c == '-' && strings.HasPrefix(sql[i:], "--"):
+			if end := strings.IndexByte(sql[i:], '\n'); end >= 0 {
+				i += end
+			} else {
+				i = len(sql)
+			}
+		case c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			if end := strings.Index(sql[i+2:], "*/"); end >= 0 {
+				i += end + 4
+			} else {
+				i = len(sql)
+			}
+		case c == '?':
+			assign(index)
+			index++
+			i++
+		case c == '$' && i+1 < len(sql) && isDigit(sql[i+1]):
+			j := i + 1
+			for j < len(sql) && isDigit(sql[j]) {
+				j++
+			}
+			num, _ := strconv.Atoi(sql[i+1 : j])
+			assign(num - 1)
+			i = j
+		case isDigit(c):
+			// 数字字面量（例如 1、1.5、1e3）
+			for i < len(sql) && (isDigit(sql[i]) || isWordStart(sql[i]) || sql[i] == '.') {
+				i++
+			}
+		case isWordStart(c):
+			j := i + 1
+			for j < len(sql) && (isWordStart(sql[j]) || isDigit(sql[j]) || sql[j] == '$') {
+				j++
+			}
+			word := strings.ToLower(sql[i:j])
+			i = j
+			if first == "" {
+				first = word
+			}
+			switch {
+			case word == "values" && (first == "insert" || first == "replace"):
+				inValues, depth = true, 0
+				last = ""
+			case sqlClauseKeywords[word]:
+				last = ""
+			case sqlKeywords[word]:
+				if inValues && depth == 0 {
+					inValues = false
+				}
+			default:
+				ident(word)
+			}
+		case c == '(':
+			if inValues {
+				depth++
+				if depth == 1 {
+					position = 0
+				}
+			} else if (first == "insert" || first == "replace") && insertCols == nil && last != "" {
+				inCols = true
+				insertCols = []string{}
+			}
+			i++
+		case c == ')':
+			if inValues && depth > 0 {
+				depth--
+			}
+			inCols = false
+			i++
+		case c == ',':
+			if inValues && depth == 1 {
+				position++
+			}
+			i++
+		default:
+			i++
+		}
+	}
+	return columns
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
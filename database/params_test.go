@@ -0,0 +1,93 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/so68/core/config"
+)
+
+/*
+SQL 日志参数脱敏功能测试
+
+本文件用于测试GormLogger在写入日志前对SQL参数的处理，
+包括保留占位符、按列脱敏以及参数所属列的解析。
+
+运行命令：
+go test -v -run "^Test(ParamColumns|GormLoggerParams).*$"
+
+测试内容：
+1. 参数所属列解析 (paramColumns)
+2. 保留占位符与按列脱敏 (ParamsFilter)
+*/
+
+func TestParamColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		sql      string
+		expected []string
+	}{
+		{name: "where", sql: "SELECT * FROM `users` WHERE `users`.`email` = ? AND `password` = ? LIMIT ?", expected: []string{"email", "password", ""}},
+		{name: "in and between", sql: "SELECT * FROM users WHERE id IN (?,?) AND created_at BETWEEN ? AND ?", expected: []string{"id", "id", "created_at", "created_at"}},
+		{name: "function", sql: "SELECT * FROM users WHERE LOWER(email) = ? OFFSET ?", expected: []string{"email", ""}},
+		{name: "update", sql: "UPDATE `users` SET `password_hash`=?,`updated_at`=? WHERE `id` = ?", expected: []string{"password_hash", "updated_at", "id"}},
+		{name: "insert batch", sql: "INSERT INTO `users` (`name`,`password_hash`) VALUES (?,?),(?,?)", expected: []string{"name", "password_hash", "name", "password_hash"}},
+		{name: "insert upsert", sql: `INSERT INTO "users" ("name","token") VALUES ($1,$2) ON CONFLICT ("id") DO UPDATE SET "token"=$3 RETURNING "id"`, expected: []string{"name", "token", "token"}},
+		{name: "literals", sql: "SELECT * FROM users WHERE note = 'a = ?' AND score > 1.5 -- id = ?\n AND secret = ?", expected: []string{"secret"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns := paramColumns(tt.sql, len(tt.expected))
+			if !reflect.DeepEqual(columns, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, columns)
+			}
+		})
+	}
+}
+
+func TestGormLoggerParamsFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *config.DatabaseConfig
+		contains []string
+		excludes []string
+	}{
+		{name: "interpolated", cfg: &config.DatabaseConfig{}, contains: []string{"alice", "s3cret"}},
+		{name: "placeholders", cfg: &config.DatabaseConfig{LogPlaceholders: true}, contains: []string{"VALUES (?,?)"}, excludes: []string{"alice", "s3cret"}},
+		{name: "mask columns", cfg: &config.DatabaseConfig{LogMaskColumns: []string{"PASSWORD_HASH"}}, contains: []string{"alice", logMaskedValue}, excludes: []string{"s3cret"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			cfg := *tt.cfg
+			cfg.Driver, cfg.Database, cfg.LogLevel = "sqlite", ":memory:", "info"
+			db, err := NewSQLiteDatabase(&cfg, slog.New(slog.NewJSONHandler(output, nil)))
+			if err != nil {
+				t.Fatalf("NewSQLiteDatabase failed: %v", err)
+			}
+			defer db.Close(context.Background())
+			if err := db.DB().Exec("CREATE TABLE users (name TEXT, password_hash TEXT)").Error; err != nil {
+				t.Fatal(err)
+			}
+
+			output.Reset()
+			if err := db.DB().Exec("INSERT INTO users (name, password_hash) VALUES (?,?)", "alice", "s3cret").Error; err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range tt.contains {
+				if !strings.Contains(output.String(), s) {
+					t.Errorf("Expected log to contain %q, got %q", s, output.String())
+				}
+			}
+			for _, s := range tt.excludes {
+				if strings.Contains(output.String(), s) {
+					t.Errorf("Expected log not to contain %q, got %q", s, output.String())
+				}
+			}
+		})
+	}
+}
@@ -20,6 +20,11 @@ type PostgreSQLDatabase struct {
 
 // NewPostgreSQLDatabase 创建 PostgreSQL 数据库连接
 func NewPostgreSQLDatabase(cfg *config.DatabaseConfig, slogLogger *slog.Logger) (*PostgreSQLDatabase, error) {
+	return newPostgreSQLDatabase(cfg, slogLogger, true)
+}
+
+// newPostgreSQLDatabase 创建 PostgreSQL 数据库连接（verify 为 false 时不检测连接，连接在首次使用时建立，用于延迟连接模式）
+func newPostgreSQLDatabase(cfg *config.DatabaseConfig, slogLogger *slog.Logger, verify bool) (*PostgreSQLDatabase, error) {
 	if cfg.Driver != "postgres" {
 		return nil, fmt.Errorf("invalid driver: expected postgres, got %s", cfg.Driver)
 	}
@@ -29,6 +34,7 @@ func NewPostgreSQLDatabase(cfg *config.DatabaseConfig, slogLogger *slog.Logger)
 		Logger:                                   NewGormLogger(cfg, slogLogger),
 		PrepareStmt:                              cfg.PrepareStmt,
 		DisableForeignKeyConstraintWhenMigrating: cfg.DisableForeignKeyConstraintWhenMigrating,
+		DisableAutomaticPing:                     !verify,
 	}
 
 	// 连接数据库
@@ -37,6 +43,11 @@ func NewPostgreSQLDatabase(cfg *config.DatabaseConfig, slogLogger *slog.Logger)
 		return nil, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
 	}
 
+	// 设置查询超时
+	if err := registerQueryTimeout(db, cfg.QueryTimeout); err != nil {
+		return nil, err
+	}
+
 	// 获取底层 sql.DB 进行连接池配置
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -49,9 +60,16 @@ func NewPostgreSQLDatabase(cfg *config.DatabaseConfig, slogLogger *slog.Logger)
 	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
 	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 
+	// 注册从库（读写分离）
+	if err := registerReplicas(db, cfg, postgres.Open); err != nil {
+		return nil, err
+	}
+
 	// 测试连接
-	if err := sqlDB.Ping(); err != nil {
-		return nil, fmt.Errorf("failed to ping PostgreSQL: %w", err)
+	if verify {
+		if err := sqlDB.Ping(); err != nil {
+			return nil, fmt.Errorf("failed to ping PostgreSQL: %w", err)
+		}
 	}
 
 	postgresDB := &PostgreSQLDatabase{
@@ -60,14 +78,17 @@ func NewPostgreSQLDatabase(cfg *config.DatabaseConfig, slogLogger *slog.Logger)
 		logger: slogLogger,
 	}
 
-	postgresDB.logger.Info("PostgreSQL database connected successfully",
-		slog.String("host", cfg.Host),
-		slog.Int("port", cfg.Port),
-		slog.String("database", cfg.Database),
-		slog.String("ssl_mode", cfg.SSLMode),
-		slog.Int("max_open_conns", cfg.MaxOpenConns),
-		slog.Int("max_idle_conns", cfg.MaxIdleConns),
-	)
+	if verify {
+		postgresDB.logger.Info("PostgreSQL database connected successfully",
+			slog.String("host", cfg.Host),
+			slog.Int("port", cfg.Port),
+			slog.String("database", cfg.Database),
+			slog.String("ssl_mode", cfg.SSLMode),
+			slog.Int("max_open_conns", cfg.MaxOpenConns),
+			slog.Int("max_idle_conns", cfg.MaxIdleConns),
+			slog.Int("replicas", len(cfg.Replicas)),
+		)
+	}
 
 	return postgresDB, nil
 }
@@ -77,6 +98,11 @@ func (p *PostgreSQLDatabase) DB() *gorm.DB {
 	return p.db
 }
 
+// Transaction 在事务中执行（上下文中已存在事务时加入外层事务）
+func (p *PostgreSQLDatabase) Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return Transaction(ctx, p.db, fn)
+}
+
 // HealthCheck 健康检查
 func (p *PostgreSQLDatabase) HealthCheck() error {
 	sqlDB, err := p.db.DB()
@@ -370,6 +370,21 @@ func TestPostgreSQLDatabase_DSN(t *testing.T) {
 			},
 			expected: "host=localhost port=5432 user=postgres password=password dbname=testdb sslmode=require",
 		},
+		{
+			name: "dsn_with_driver_timeout",
+			config: &config.DatabaseConfig{
+				Driver:        "postgres",
+				Host:          "localhost",
+				Port:          5432,
+				Username:      "postgres",
+				Password:      "password",
+				Database:      "testdb",
+				SSLMode:       "disable",
+				QueryTimeout:  1500 * time.Millisecond,
+				DriverTimeout: true,
+			},
+			expected: "host=localhost port=5432 user=postgres password=password dbname=testdb sslmode=disable statement_timeout=1500",
+		},
 	}
 
 	for _, tt := range tests {
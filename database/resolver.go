@@ -0,0 +1,43 @@
+package database
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/so68/core/config"
+)
+
+// UsePrimary 强制使用主库执行查询（例如写后立即读，避免从库复制延迟）
+func UsePrimary(db *gorm.DB) *gorm.DB {
+	return db.Clauses(dbresolver.Write)
+}
+
+// registerReplicas 注册从库，读操作随机路由到从库，写操作与事务使用主库
+// - 从库连接池沿用主库的连接池配置
+func registerReplicas(db *gorm.DB, cfg *config.DatabaseConfig, open func(dsn string) gorm.Dialector) error {
+	replicaConfigs := cfg.ReplicaConfigs()
+	if len(replicaConfigs) == 0 {
+		return nil
+	}
+
+	replicas := make([]gorm.Dialector, 0, len(replicaConfigs))
+	for _, replica := range replicaConfigs {
+		replicas = append(replicas, open(replica.GetDSN()))
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: replicas,
+		Policy:   dbresolver.RandomPolicy{},
+	}).
+		SetMaxOpenConns(cfg.MaxOpenConns).
+		SetMaxIdleConns(cfg.MaxIdleConns).
+		SetConnMaxLifetime(cfg.ConnMaxLifetime).
+		SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	if err := db.Use(resolver); err != nil {
+		return fmt.Errorf("failed to register replicas: %w", err)
+	}
+	return nil
+}
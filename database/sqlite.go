@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/so68/core/config"
+)
+
+// SQLiteDatabase SQLite 数据库实现
+// - 适用于集成测试与小规模部署，无需独立数据库服务
+type SQLiteDatabase struct {
+	db     *gorm.DB
+	config *config.DatabaseConfig
+	logger *slog.Logger
+}
+
+// NewSQLiteDatabase 创建 SQLite 数据库连接
+func NewSQLiteDatabase(cfg *config.DatabaseConfig, slogLogger *slog.Logger) (*SQLiteDatabase, error) {
+	if cfg.Driver != "sqlite" {
+		return nil, fmt.Errorf("invalid driver: expected sqlite, got %s", cfg.Driver)
+	}
+
+	// 文件库需确保目录存在
+	if !cfg.IsSQLiteMemory() {
+		path := strings.TrimPrefix(strings.SplitN(cfg.Database, "?", 2)[0], "file:")
+		if dir := filepath.Dir(path); dir != "." {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return nil, fmt.Errorf("failed to create SQLite directory: %w", err)
+			}
+		}
+	}
+
+	// 配置 GORM
+	gormConfig := &gorm.Config{
+		Logger:                                   NewGormLogger(cfg, slogLogger),
+		PrepareStmt:                              cfg.PrepareStmt,
+		DisableForeignKeyConstraintWhenMigrating: cfg.DisableForeignKeyConstraintWhenMigrating,
+	}
+
+	// 连接数据库
+	db, err := gorm.Open(sqlite.Open(cfg.GetDSN()), gormConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SQLite: %w", err)
+	}
+
+	// 设置查询超时
+	if err := registerQueryTimeout(db, cfg.QueryTimeout); err != nil {
+		return nil, err
+	}
+
+	// 获取底层 sql.DB 进行连接池配置
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sql.DB: %w", err)
+	}
+
+	// 配置连接池（内存库每个连接相互独立，只能使用单连接）
+	maxOpenConns, maxIdleConns := cfg.MaxOpenConns, cfg.MaxIdleConns
+	if cfg.IsSQLiteMemory() {
+		maxOpenConns, maxIdleConns = 1, 1
+	}
+	sqlDB.SetMaxOpenConns(maxOpenConns)
+	sqlDB.SetMaxIdleConns(maxIdleConns)
+	if !cfg.IsSQLiteMemory() {
+		sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+		sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	}
+
+	// 注册从库（读写分离）
+	if err := registerReplicas(db, cfg, sqlite.Open); err != nil {
+		return nil, err
+	}
+
+	// 测试连接
+	if err := sqlDB.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping SQLite: %w", err)
+	}
+
+	sqliteDB := &SQLiteDatabase{
+		db:     db,
+		config: cfg,
+		logger: slogLogger,
+	}
+
+	sqliteDB.logger.Info("SQLite database connected successfully",
+		slog.String("database", cfg.Database),
+		slog.Bool("memory", cfg.IsSQLiteMemory()),
+		slog.Int("max_open_conns", maxOpenConns),
+		slog.Int("max_idle_conns", maxIdleConns),
+		slog.Int("replicas", len(cfg.Replicas)),
+	)
+
+	return sqliteDB, nil
+}
+
+// DB 获取 GORM DB 实例
+func (s *SQLiteDatabase) DB() *gorm.DB {
+	return s.db
+}
+
+// Transaction 在事务中执行（上下文中已存在事务时加入外层事务）
+func (s *SQLiteDatabase) Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return Transaction(ctx, s.db, fn)
+}
+
+// HealthCheck 健康检查
+func (s *SQLiteDatabase) HealthCheck() error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Ping()
+}
+
+// Close 关闭数据库连接
+func (s *SQLiteDatabase) Close(ctx context.Context) error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
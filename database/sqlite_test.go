@@ -0,0 +1,249 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/so68/core/config"
+)
+
+/*
+SQLite数据库连接功能测试
+
+本文件用于测试SQLiteDatabase结构体的各种功能特性，
+包括内存库与文件库连接、读写、健康检查、工厂创建等。
+
+运行命令：
+go test -v -run "^Test.*SQLite.*$"
+
+测试内容：
+1. 数据库连接创建和配置 (NewSQLiteDatabase, 驱动校验)
+2. 内存库与文件库读写 (DB, AutoMigrate, Create, First)
+3. 健康检查与关闭 (HealthCheck, Close)
+4. 工厂创建 (Factory.CreateDatabase, CreateSQLiteDatabase)
+5. 读写分离 (Replicas, UsePrimary)
+6. 查询超时 (QueryTimeout)
+*/
+
+// testRecord 测试模型
+type testRecord struct {
+	BaseModel
+	Name string
+}
+
+// newSQLiteTestLogger 创建测试用日志器
+func newSQLiteTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelWarn}))
+}
+
+// TestNewSQLiteDatabase 测试创建 SQLite 数据库连接
+func TestNewSQLiteDatabase(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *config.DatabaseConfig
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:   "memory",
+			config: &config.DatabaseConfig{Driver: "sqlite", Database: ":memory:"},
+		},
+		{
+			name:   "file",
+			config: &config.DatabaseConfig{Driver: "sqlite", Database: filepath.Join(t.TempDir(), "data", "test.db")},
+		},
+		{
+			name:        "invalid_driver",
+			config:      &config.DatabaseConfig{Driver: "mysql", Database: ":memory:"},
+			expectError: true,
+			errorMsg:    "invalid driver: expected sqlite, got mysql",
+		},
+	}
+
+	logger := newSQLiteTestLogger()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.SetDefaults()
+			sqliteDB, err := NewSQLiteDatabase(tt.config, logger)
+			if tt.expectError {
+				if err == nil || err.Error() != tt.errorMsg {
+					t.Errorf("Expected error %q, got %v", tt.errorMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewSQLiteDatabase failed: %v", err)
+			}
+			defer sqliteDB.Close(context.Background())
+
+			db := sqliteDB.DB()
+			if err := db.AutoMigrate(&testRecord{}); err != nil {
+				t.Fatalf("AutoMigrate failed: %v", err)
+			}
+			if err := db.Create(&testRecord{Name: "admin"}).Error; err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+			var got testRecord
+			if err := db.First(&got).Error; err != nil {
+				t.Fatalf("First failed: %v", err)
+			}
+			if got.Name != "admin" {
+				t.Errorf("Expected name admin, got %s", got.Name)
+			}
+			if err := sqliteDB.HealthCheck(); err != nil {
+				t.Errorf("HealthCheck failed: %v", err)
+			}
+		})
+	}
+}
+
+// TestFactoryCreateSQLiteDatabase 测试通过工厂创建 SQLite 连接
+func TestFactoryCreateSQLiteDatabase(t *testing.T) {
+	factory := NewFactory(newSQLiteTestLogger())
+
+	db, err := factory.CreateSQLiteDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("CreateSQLiteDatabase failed: %v", err)
+	}
+	if _, ok := db.(*SQLiteDatabase); !ok {
+		t.Errorf("Expected *SQLiteDatabase, got %T", db)
+	}
+	if err := db.Close(context.Background()); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+}
+
+// TestSQLiteDatabaseReplicas 测试读写分离：读操作走从库，写操作与 UsePrimary 走主库
+func TestSQLiteDatabaseReplicas(t *testing.T) {
+	dir := t.TempDir()
+	primaryPath := filepath.Join(dir, "primary.db")
+	replicaPath := filepath.Join(dir, "replica.db")
+
+	// 分别初始化主库与从库数据，用于区分查询路由
+	for path, name := range map[string]string{primaryPath: "primary", replicaPath: "replica"} {
+		seed, err := NewSQLiteDatabase(&config.DatabaseConfig{Driver: "sqlite", Database: path}, newSQLiteTestLogger())
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", path, err)
+		}
+		if err := seed.DB().AutoMigrate(&testRecord{}); err != nil {
+			t.Fatalf("AutoMigrate failed: %v", err)
+		}
+		if err := seed.DB().Create(&testRecord{Name: name}).Error; err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		_ = seed.Close(context.Background())
+	}
+
+	cfg := &config.DatabaseConfig{
+		Driver:   "sqlite",
+		Database: primaryPath,
+		Replicas: []*config.DatabaseReplicaConfig{{Database: replicaPath}},
+	}
+	cfg.SetDefaults()
+	db, err := NewSQLiteDatabase(cfg, newSQLiteTestLogger())
+	if err != nil {
+		t.Fatalf("NewSQLiteDatabase failed: %v", err)
+	}
+	defer db.Close(context.Background())
+
+	tests := []struct {
+		name     string
+		query    func() *gorm.DB
+		expected string
+	}{
+		{name: "read_from_replica", query: func() *gorm.DB { return db.DB() }, expected: "replica"},
+		{name: "use_primary", query: func() *gorm.DB { return UsePrimary(db.DB()) }, expected: "primary"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var record testRecord
+			if err := tt.query().First(&record).Error; err != nil {
+				t.Fatalf("First failed: %v", err)
+			}
+			if record.Name != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, record.Name)
+			}
+		})
+	}
+
+	// 写操作走主库
+	if err := db.DB().Create(&testRecord{Name: "written"}).Error; err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	var count int64
+	UsePrimary(db.DB()).Model(&testRecord{}).Where("name = ?", "written").Count(&count)
+	if count != 1 {
+		t.Errorf("expected write on primary, got count %d", count)
+	}
+	db.DB().Model(&testRecord{}).Where("name = ?", "written").Count(&count)
+	if count != 0 {
+		t.Errorf("expected replica untouched, got count %d", count)
+	}
+}
+
+// TestSQLiteDatabaseQueryTimeout 测试查询超时
+func TestSQLiteDatabaseQueryTimeout(t *testing.T) {
+	cfg := &config.DatabaseConfig{Driver: "sqlite", Database: ":memory:", QueryTimeout: time.Minute}
+	cfg.SetDefaults()
+	db, err := NewSQLiteDatabase(cfg, newSQLiteTestLogger())
+	if err != nil {
+		t.Fatalf("NewSQLiteDatabase failed: %v", err)
+	}
+	defer db.Close(context.Background())
+	if err := db.DB().AutoMigrate(&testRecord{}); err != nil {
+		t.Fatalf("AutoMigrate failed: %v", err)
+	}
+
+	// 执行时语句上下文带有截止时间，执行结束后释放
+	var (
+		deadline time.Time
+		stmtCtx  context.Context
+	)
+	err = db.DB().Callback().Query().Before("gorm:query").Register("test:deadline", func(tx *gorm.DB) {
+		stmtCtx = tx.Statement.Context
+		deadline, _ = stmtCtx.Deadline()
+	})
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	var records []testRecord
+	if err := db.DB().Find(&records).Error; err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if remaining := time.Until(deadline); remaining <= 0 || remaining > time.Minute {
+		t.Errorf("expected deadline within 1m, got %v", remaining)
+	}
+	if stmtCtx.Err() == nil {
+		t.Error("expected statement context released after query")
+	}
+
+	// 调用方上下文的截止时间更早时以调用方为准
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	want, _ := ctx.Deadline()
+	if err := db.DB().WithContext(ctx).Find(&records).Error; err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if !deadline.Equal(want) {
+		t.Errorf("expected caller deadline %v, got %v", want, deadline)
+	}
+
+	// 超时后查询被取消
+	cfg.QueryTimeout = time.Nanosecond
+	short, err := NewSQLiteDatabase(cfg, newSQLiteTestLogger())
+	if err != nil {
+		t.Fatalf("NewSQLiteDatabase failed: %v", err)
+	}
+	defer short.Close(context.Background())
+	err = short.DB().Exec("SELECT 1").Error
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// queryTimeoutCancelKey 语句实例中保存超时上下文取消函数的键
+const queryTimeoutCancelKey = "core:query_timeout_cancel"
+
+// registerQueryTimeout 注册查询超时回调（timeout 为 0 时不注册）
+// - 语句执行前为上下文设置截止时间，执行结束后释放；调用方上下文的截止时间更早时以调用方为准
+// - 作用于创建、查询（含预加载）、更新、删除与原生执行（Exec）
+// - Row/Rows 返回的结果集在回调结束后才读取，不设置超时（可由调用方通过 WithContext 控制）
+func registerQueryTimeout(db *gorm.DB, timeout time.Duration) error {
+	if timeout <= 0 {
+		return nil
+	}
+
+	begin := func(tx *gorm.DB) {
+		ctx := tx.Statement.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		tx.Statement.Context = ctx
+		tx.InstanceSet(queryTimeoutCancelKey, cancel)
+	}
+	end := func(tx *gorm.DB) {
+		if cancel, ok := tx.InstanceGet(queryTimeoutCancelKey); ok {
+			cancel.(context.CancelFunc)()
+		}
+	}
+
+	callback := db.Callback()
+	registers := map[string][2]func(string, func(*gorm.DB)) error{
+		"create": {callback.Create().Before("*").Register, callback.Create().After("*").Register},
+		"query":  {callback.Query().Before("*").Register, callback.Query().After("*").Register},
+		"update": {callback.Update().Before("*").Register, callback.Update().After("*").Register},
+		"delete": {callback.Delete().Before("*").Register, callback.Delete().After("*").Register},
+		"raw":    {callback.Raw().Before("*").Register, callback.Raw().After("*").Register},
+	}
+	for name, register := range registers {
+		if err := register[0]("core:query_timeout_begin", begin); err != nil {
+			return fmt.Errorf("failed to register %s query timeout: %w", name, err)
+		}
+		if err := register[1]("core:query_timeout_end", end); err != nil {
+			return fmt.Errorf("failed to register %s query timeout: %w", name, err)
+		}
+	}
+	return nil
+}
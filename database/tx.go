@@ -0,0 +1,48 @@
+package database
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// txKey 上下文中的事务键
+type txKey struct{}
+
+// WithTx 将事务写入上下文
+func WithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// TxFromContext 获取上下文中的事务
+func TxFromContext(ctx context.Context) (*gorm.DB, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	tx, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return tx, ok
+}
+
+// Conn 获取当前上下文应使用的连接：存在外层事务时加入该事务，否则使用 db
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	if ctx == nil {
+		return db
+	}
+	return db.WithContext(ctx)
+}
+
+// Transaction 在事务中执行 fn
+// - 上下文中已存在事务时直接加入外层事务（由外层统一提交或回滚）
+// - 否则开启新事务，fn 返回错误或 panic 时回滚
+// - fn 收到的 tx 携带包含该事务的上下文，通过 tx.Statement.Context 传递给仓储层即可自动加入事务
+func Transaction(ctx context.Context, db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	if tx, ok := TxFromContext(ctx); ok {
+		return fn(tx.WithContext(ctx))
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(tx.WithContext(WithTx(ctx, tx)))
+	})
+}
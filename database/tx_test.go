@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/so68/core/config"
+)
+
+/*
+事务管理功能测试
+
+本文件用于测试事务辅助函数的各种功能特性，
+包括事务提交与回滚、上下文传播、嵌套事务加入外层事务等。
+
+运行命令：
+go test -v -run "^Test.*Transaction.*$"
+
+测试内容：
+1. 事务提交与回滚 (Transaction)
+2. 上下文事务传播 (WithTx, TxFromContext, Conn)
+3. 嵌套调用加入外层事务
+*/
+
+// newTxTestDatabase 创建事务测试用 SQLite 内存库
+func newTxTestDatabase(t *testing.T) Database {
+	t.Helper()
+	db, err := NewSQLiteDatabase(&config.DatabaseConfig{Driver: "sqlite", Database: ":memory:", LogLevel: "silent"}, newSQLiteTestLogger())
+	if err != nil {
+		t.Fatalf("NewSQLiteDatabase failed: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close(context.Background()) })
+	if err := db.DB().AutoMigrate(&testRecord{}); err != nil {
+		t.Fatalf("AutoMigrate failed: %v", err)
+	}
+	return db
+}
+
+// createRecord 模拟仓储层方法：通过 Conn 自动加入上下文中的事务
+func createRecord(ctx context.Context, db *gorm.DB, name string) error {
+	return Conn(ctx, db).Create(&testRecord{Name: name}).Error
+}
+
+// countRecords 统计记录数
+func countRecords(t *testing.T, db *gorm.DB) int64 {
+	t.Helper()
+	var count int64
+	if err := db.Model(&testRecord{}).Count(&count).Error; err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	return count
+}
+
+func TestTransaction(t *testing.T) {
+	errRollback := errors.New("rollback")
+	tests := []struct {
+		name     string
+		fn       func(db Database) func(tx *gorm.DB) error
+		wantErr  error
+		expected int64
+	}{
+		{
+			name: "commit",
+			fn: func(db Database) func(tx *gorm.DB) error {
+				return func(tx *gorm.DB) error {
+					ctx := tx.Statement.Context
+					if err := createRecord(ctx, db.DB(), "a"); err != nil {
+						return err
+					}
+					return createRecord(ctx, db.DB(), "b")
+				}
+			},
+			expected: 2,
+		},
+		{
+			name: "rollback",
+			fn: func(db Database) func(tx *gorm.DB) error {
+				return func(tx *gorm.DB) error {
+					if err := createRecord(tx.Statement.Context, db.DB(), "a"); err != nil {
+						return err
+					}
+					return errRollback
+				}
+			},
+			wantErr:  errRollback,
+			expected: 0,
+		},
+		{
+			name: "nested joins outer",
+			fn: func(db Database) func(tx *gorm.DB) error {
+				return func(tx *gorm.DB) error {
+					ctx := tx.Statement.Context
+					if err := createRecord(ctx, db.DB(), "outer"); err != nil {
+						return err
+					}
+					// 内层事务加入外层事务，外层失败时一并回滚
+					if err := db.Transaction(ctx, func(inner *gorm.DB) error {
+						return createRecord(inner.Statement.Context, db.DB(), "inner")
+					}); err != nil {
+						return err
+					}
+					return errRollback
+				}
+			},
+			wantErr:  errRollback,
+			expected: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTxTestDatabase(t)
+			err := db.Transaction(context.Background(), tt.fn(db))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if count := countRecords(t, db.DB()); count != tt.expected {
+				t.Errorf("expected %d records, got %d", tt.expected, count)
+			}
+		})
+	}
+}
+
+func TestTransactionContext(t *testing.T) {
+	db := newTxTestDatabase(t)
+	ctx := context.Background()
+
+	if _, ok := TxFromContext(ctx); ok {
+		t.Error("expected no transaction in background context")
+	}
+	if _, ok := TxFromContext(nil); ok {
+		t.Error("expected no transaction in nil context")
+	}
+
+	err := db.Transaction(ctx, func(tx *gorm.DB) error {
+		ambient, ok := TxFromContext(tx.Statement.Context)
+		if !ok {
+			t.Fatal("expected transaction in context")
+		}
+		if ambient.Statement.ConnPool != tx.Statement.ConnPool {
+			t.Error("expected context transaction to share the connection")
+		}
+		if Conn(tx.Statement.Context, db.DB()).Statement.ConnPool != tx.Statement.ConnPool {
+			t.Error("expected Conn to join the ambient transaction")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Transaction failed: %v", err)
+	}
+}
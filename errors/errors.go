@@ -0,0 +1,197 @@
+// Package errors 带错误码的业务错误
+//
+// 错误码决定响应的业务状态码与 HTTP 状态码（见 Code.HTTPStatus），
+// 处理函数中使用 utils.Fail(c, err) 返回；未携带错误码的普通错误保持原有响应（code -1，HTTP 200）。
+//
+// 调试模式下（SetStackCapture(true)）创建错误时记录调用栈，便于排查内部错误。
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+// Code 错误码
+type Code int
+
+const (
+	CodeUnknown      Code = -1    // 未分类的业务错误（兼容原有响应）
+	CodeValidation   Code = 40000 // 参数校验失败
+	CodeUnauthorized Code = 40100 // 未认证或认证已失效
+	CodeForbidden    Code = 40300 // 无权限
+	CodeNotFound     Code = 40400 // 资源不存在
+	CodeConflict     Code = 40900 // 资源冲突（如唯一字段重复）
+	CodeInternal     Code = 50000 // 服务器内部错误（不向客户端暴露原因）
+)
+
+// codeStatuses 错误码对应的 HTTP 状态码
+var codeStatuses = map[Code]int{
+	CodeValidation:   http.StatusBadRequest,
+	CodeUnauthorized: http.StatusUnauthorized,
+	CodeForbidden:    http.StatusForbidden,
+	CodeNotFound:     http.StatusNotFound,
+	CodeConflict:     http.StatusConflict,
+	CodeInternal:     http.StatusInternalServerError,
+}
+
+// HTTPStatus 错误码对应的 HTTP 状态码（未分类的错误为 200）
+func (c Code) HTTPStatus() int {
+	if status, ok := codeStatuses[c]; ok {
+		return status
+	}
+	return http.StatusOK
+}
+
+// captureStack 是否在创建错误时记录调用栈
+var captureStack atomic.Bool
+
+// SetStackCapture 设置是否在创建错误时记录调用栈（建议仅在调试模式下开启）
+func SetStackCapture(enabled bool) {
+	captureStack.Store(enabled)
+}
+
+// Error 带错误码的错误
+type Error struct {
+	code    Code
+	message string    // 提示信息（同时作为多语言消息键）
+	cause   error     // 原始错误
+	stack   []uintptr // 调用栈（未开启记录时为空）
+}
+
+// New 创建带错误码的错误
+func New(code Code, message string) *Error {
+	return newError(code, message, nil)
+}
+
+// Newf 创建带错误码的格式化错误
+func Newf(code Code, format string, args ...interface{}) *Error {
+	return newError(code, fmt.Sprintf(format, args...), nil)
+}
+
+// Wrap 为原始错误附加错误码与提示信息（err 为空时返回 nil）
+func Wrap(err error, code Code, message string) *Error {
+	if err == nil {
+		return nil
+	}
+	return newError(code, message, err)
+}
+
+// Validation 参数校验失败
+func Validation(message string) *Error {
+	return newError(CodeValidation, message, nil)
+}
+
+// Unauthorized 未认证或认证已失效
+func Unauthorized(message string) *Error {
+	return newError(CodeUnauthorized, message, nil)
+}
+
+// Forbidden 无权限
+func Forbidden(message string) *Error {
+	return newError(CodeForbidden, message, nil)
+}
+
+// NotFound 资源不存在
+func NotFound(message string) *Error {
+	return newError(CodeNotFound, message, nil)
+}
+
+// Conflict 资源冲突
+func Conflict(message string) *Error {
+	return newError(CodeConflict, message, nil)
+}
+
+// Internal 服务器内部错误（err 为原始错误，仅记录日志，不返回给客户端）
+func Internal(err error) *Error {
+	return newError(CodeInternal, "服务器内部错误", err)
+}
+
+// newError 创建错误并按需记录调用栈（跳过 newError 与导出的构造函数）
+func newError(code Code, message string, cause error) *Error {
+	e := &Error{code: code, message: message, cause: cause}
+	if captureStack.Load() {
+		pcs := make([]uintptr, 32)
+		n := runtime.Callers(3, pcs)
+		e.stack = pcs[:n]
+	}
+	return e
+}
+
+// Error 错误信息（包含原始错误）
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.message + ": " + e.cause.Error()
+	}
+	return e.message
+}
+
+// Unwrap 原始错误
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Code 错误码
+func (e *Error) Code() Code {
+	return e.code
+}
+
+// Message 提示信息（不包含原始错误）
+func (e *Error) Message() string {
+	return e.message
+}
+
+// HTTPStatus 对应的 HTTP 状态码
+func (e *Error) HTTPStatus() int {
+	return e.code.HTTPStatus()
+}
+
+// Stack 创建错误时的调用栈（未开启记录时为空字符串）
+func (e *Error) Stack() string {
+	if len(e.stack) == 0 {
+		return ""
+	}
+	var builder strings.Builder
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&builder, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return builder.String()
+}
+
+// From 从错误链中查找带错误码的错误
+func From(err error) (*Error, bool) {
+	var e *Error
+	if stderrors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+// CodeOf 错误码（err 为空时返回 0，未携带错误码时返回 CodeUnknown）
+func CodeOf(err error) Code {
+	if err == nil {
+		return 0
+	}
+	if e, ok := From(err); ok {
+		return e.code
+	}
+	return CodeUnknown
+}
+
+// Is 同标准库 errors.Is
+func Is(err, target error) bool {
+	return stderrors.Is(err, target)
+}
+
+// As 同标准库 errors.As
+func As(err error, target interface{}) bool {
+	return stderrors.As(err, target)
+}
@@ -0,0 +1,99 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+/*
+错误码测试
+
+本文件用于测试带错误码的错误，
+包括 HTTP 状态码映射、错误链查找与调用栈记录。
+
+运行命令：
+go test -v -run "^Test.*$"
+
+测试内容：
+1. 错误码与 HTTP 状态码 (Code.HTTPStatus)
+2. 包装与错误链查找 (Wrap, From, CodeOf)
+3. 调用栈记录 (SetStackCapture, Stack)
+*/
+
+func TestCodeHTTPStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    *Error
+		code   Code
+		status int
+	}{
+		{name: "参数校验", err: Validation("参数错误"), code: CodeValidation, status: http.StatusBadRequest},
+		{name: "未认证", err: Unauthorized("请先登录"), code: CodeUnauthorized, status: http.StatusUnauthorized},
+		{name: "无权限", err: Forbidden("无权限访问"), code: CodeForbidden, status: http.StatusForbidden},
+		{name: "不存在", err: NotFound("记录不存在"), code: CodeNotFound, status: http.StatusNotFound},
+		{name: "冲突", err: Conflict("用户名已存在"), code: CodeConflict, status: http.StatusConflict},
+		{name: "内部错误", err: Internal(io.EOF), code: CodeInternal, status: http.StatusInternalServerError},
+		{name: "未分类", err: New(CodeUnknown, "操作失败"), code: CodeUnknown, status: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err.Code() != tt.code || tt.err.HTTPStatus() != tt.status {
+				t.Errorf("Expected %d/%d, got %d/%d", tt.code, tt.status, tt.err.Code(), tt.err.HTTPStatus())
+			}
+		})
+	}
+}
+
+func TestWrapAndFrom(t *testing.T) {
+	if Wrap(nil, CodeInternal, "查询失败") != nil {
+		t.Fatal("Wrap(nil) should return nil")
+	}
+
+	err := fmt.Errorf("更新失败: %w", Wrap(io.EOF, CodeInternal, "查询失败"))
+	if got := err.Error(); got != "更新失败: 查询失败: EOF" {
+		t.Errorf("unexpected message %q", got)
+	}
+	coded, ok := From(err)
+	if !ok || coded.Code() != CodeInternal || coded.Message() != "查询失败" {
+		t.Fatalf("From = %+v, %v", coded, ok)
+	}
+	if !Is(err, io.EOF) {
+		t.Error("wrapped cause should match")
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		code Code
+	}{
+		{name: "空错误", err: nil, code: 0},
+		{name: "普通错误", err: io.EOF, code: CodeUnknown},
+		{name: "包装的错误码", err: fmt.Errorf("上级管理员无效: %w", NotFound("管理员不存在")), code: CodeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CodeOf(tt.err); got != tt.code {
+				t.Errorf("Expected %d, got %d", tt.code, got)
+			}
+		})
+	}
+}
+
+func TestStackCapture(t *testing.T) {
+	if stack := NotFound("记录不存在").Stack(); stack != "" {
+		t.Fatalf("stack should be empty when capture disabled, got %q", stack)
+	}
+
+	SetStackCapture(true)
+	defer SetStackCapture(false)
+	stack := NotFound("记录不存在").Stack()
+	if !strings.Contains(stack, "TestStackCapture") {
+		t.Errorf("stack should start at caller, got %q", stack)
+	}
+	if strings.Contains(stack, "newError") {
+		t.Errorf("stack should skip constructors, got %q", stack)
+	}
+}
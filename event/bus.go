@@ -0,0 +1,341 @@
+package event
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/so68/core/cache"
+	"github.com/so68/core/config"
+)
+
+// ErrBusClosed 事件总线已关闭
+var ErrBusClosed = errors.New("event bus closed")
+
+// Event 事件
+type Event struct {
+	Topic   string      // 事件主题
+	Payload interface{} // 事件数据
+	Time    time.Time   // 发布时间
+	Remote  bool        // 是否来自其他节点（Payload 为 json.RawMessage）
+}
+
+// Handler 事件处理函数
+type Handler func(ctx context.Context, e *Event) error
+
+// subscription 订阅
+type subscription struct {
+	id      uint64
+	pattern string
+	handler Handler
+	sync    bool // 是否在发布方协程中同步执行
+}
+
+// dispatch 待分发事件
+type dispatch struct {
+	ctx   context.Context
+	event *Event
+}
+
+// Broker 跨节点分发事件使用的发布订阅（cache.Cache 已实现）
+type Broker interface {
+	Publish(ctx context.Context, channel string, message interface{}) error
+	Subscribe(ctx context.Context, channels ...string) (*cache.Subscription, error)
+}
+
+// envelope 跨节点分发的事件
+type envelope struct {
+	Node    string          `json:"node"`
+	Topic   string          `json:"topic"`
+	Payload json.RawMessage `json:"payload"`
+	Time    time.Time       `json:"time"`
+}
+
+// Bus 事件总线
+// - 主题支持通配符后缀，例如 "admin.*" 订阅所有 admin 事件，"*" 订阅全部事件
+// - 异步订阅由 worker 分发，处理器错误与 panic 仅记录日志，不影响发布方
+// - 同步订阅在 Publish 中依次执行，错误合并后返回给发布方
+// - 调用 Distribute 后事件同时发布到其他节点，其他节点仅分发给异步订阅
+type Bus struct {
+	config *config.EventConfig
+	logger *slog.Logger
+
+	mutex  sync.RWMutex // 保护订阅列表
+	subs   []*subscription
+	nextID uint64
+
+	closeMutex sync.RWMutex // 保护关闭状态，发布期间持有读锁避免向已关闭通道写入
+	closed     bool
+	queue      chan *dispatch
+	wg         sync.WaitGroup
+
+	node    string              // 节点标识，用于忽略本节点发出的分发事件
+	broker  Broker              // 跨节点发布订阅（未启用时为 nil）
+	channel string              // 跨节点分发频道
+	remote  *cache.Subscription // 跨节点事件订阅
+}
+
+// NewBus 创建事件总线
+func NewBus(cfg *config.EventConfig, logger *slog.Logger) *Bus {
+	b := &Bus{
+		config: cfg,
+		logger: logger,
+		queue:  make(chan *dispatch, cfg.BufferSize),
+	}
+	for i := 0; i < cfg.Workers; i++ {
+		b.wg.Add(1)
+		go b.worker()
+	}
+	return b
+}
+
+// Subscribe 订阅主题（异步执行），返回取消订阅函数
+func (b *Bus) Subscribe(pattern string, handler Handler) (unsubscribe func()) {
+	return b.subscribe(pattern, handler, false)
+}
+
+// SubscribeSync 同步订阅主题（在 Publish 中执行，错误返回给发布方），返回取消订阅函数
+// - 仅处理本节点发布的事件，处理器应尽量轻量，避免阻塞发布方
+func (b *Bus) SubscribeSync(pattern string, handler Handler) (unsubscribe func()) {
+	return b.subscribe(pattern, handler, true)
+}
+
+// subscribe 添加订阅
+func (b *Bus) subscribe(pattern string, handler Handler, sync bool) (unsubscribe func()) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.subs = append(b.subs, &subscription{id: id, pattern: pattern, handler: handler, sync: sync})
+
+	return func() {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+		for i, sub := range b.subs {
+			if sub.id == id {
+				b.subs = append(b.subs[:i], b.subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish 发布事件：先执行同步订阅，再放入异步分发队列（缓冲队列已满时阻塞直到 ctx 结束）
+// - 同步订阅的错误合并后返回，不影响异步分发与跨节点分发
+func (b *Bus) Publish(ctx context.Context, topic string, payload interface{}) error {
+	b.closeMutex.RLock()
+	closed := b.closed
+	b.closeMutex.RUnlock()
+	if closed {
+		return ErrBusClosed
+	}
+
+	e := &Event{Topic: topic, Payload: payload, Time: time.Now()}
+	syncErr := b.invokeSync(ctx, e)
+	if err := b.enqueue(ctx, e); err != nil {
+		return err
+	}
+	b.distribute(ctx, e)
+	return syncErr
+}
+
+// Distribute 通过发布订阅在多个节点间分发事件（需在发布事件前调用，仅可调用一次）
+// - 事件数据需可 JSON 序列化，其他节点收到的 Payload 为 json.RawMessage，强类型订阅会自动反序列化
+func (b *Bus) Distribute(ctx context.Context, broker Broker, channel string) error {
+	node, err := newNodeID()
+	if err != nil {
+		return err
+	}
+	sub, err := broker.Subscribe(ctx, channel)
+	if err != nil {
+		return fmt.Errorf("subscribe event channel %s: %w", channel, err)
+	}
+
+	b.mutex.Lock()
+	b.node, b.broker, b.channel, b.remote = node, broker, channel, sub
+	b.mutex.Unlock()
+
+	go b.receive(sub)
+	return nil
+}
+
+// Close 停止接收事件并等待已发布的事件分发完成（受 ctx 截止时间约束）
+func (b *Bus) Close(ctx context.Context) error {
+	// 先停止接收其他节点的事件
+	b.mutex.RLock()
+	remote := b.remote
+	b.mutex.RUnlock()
+	if remote != nil {
+		_ = remote.Close()
+	}
+
+	b.closeMutex.Lock()
+	if b.closed {
+		b.closeMutex.Unlock()
+		return nil
+	}
+	b.closed = true
+	close(b.queue)
+	b.closeMutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("event bus close timeout: %w", ctx.Err())
+	}
+}
+
+// worker 分发事件
+func (b *Bus) worker() {
+	defer b.wg.Done()
+	for d := range b.queue {
+		for _, sub := range b.match(d.event.Topic, false) {
+			b.invoke(d.ctx, sub, d.event)
+		}
+	}
+}
+
+// enqueue 放入异步分发队列
+func (b *Bus) enqueue(ctx context.Context, e *Event) error {
+	b.closeMutex.RLock()
+	defer b.closeMutex.RUnlock()
+	if b.closed {
+		return ErrBusClosed
+	}
+
+	d := &dispatch{
+		// 事件处理不应随请求结束而取消
+		ctx:   context.WithoutCancel(ctx),
+		event: e,
+	}
+	select {
+	case b.queue <- d:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("publish event %s: %w", e.Topic, ctx.Err())
+	}
+}
+
+// distribute 将本节点发布的事件发送到其他节点（失败仅记录日志）
+func (b *Bus) distribute(ctx context.Context, e *Event) {
+	b.mutex.RLock()
+	node, broker, channel := b.node, b.broker, b.channel
+	b.mutex.RUnlock()
+	if broker == nil {
+		return
+	}
+
+	payload, err := json.Marshal(e.Payload)
+	if err != nil {
+		b.logger.Warn("event distribute failed", slog.String("topic", e.Topic), slog.Any("error", err))
+		return
+	}
+	data, err := json.Marshal(&envelope{Node: node, Topic: e.Topic, Payload: payload, Time: e.Time})
+	if err != nil {
+		b.logger.Warn("event distribute failed", slog.String("topic", e.Topic), slog.Any("error", err))
+		return
+	}
+	if err := broker.Publish(context.WithoutCancel(ctx), channel, string(data)); err != nil {
+		b.logger.Warn("event distribute failed", slog.String("topic", e.Topic), slog.Any("error", err))
+	}
+}
+
+// receive 接收其他节点的事件并放入异步分发队列（忽略本节点发出的事件）
+func (b *Bus) receive(sub *cache.Subscription) {
+	for msg := range sub.Channel() {
+		var env envelope
+		if err := json.Unmarshal([]byte(msg.Payload), &env); err != nil {
+			b.logger.Warn("event receive failed", slog.String("channel", msg.Channel), slog.Any("error", err))
+			continue
+		}
+		if env.Node == b.node {
+			continue
+		}
+		e := &Event{Topic: env.Topic, Payload: env.Payload, Time: env.Time, Remote: true}
+		if err := b.enqueue(context.Background(), e); err != nil {
+			return
+		}
+	}
+}
+
+// invokeSync 依次调用同步订阅（捕获 panic），返回合并后的错误
+func (b *Bus) invokeSync(ctx context.Context, e *Event) error {
+	var errs []error
+	for _, sub := range b.match(e.Topic, true) {
+		if err := callHandler(ctx, sub, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// callHandler 调用处理器，panic 转换为错误
+func callHandler(ctx context.Context, sub *subscription, e *Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("event handler panic on %s: %v", e.Topic, r)
+		}
+	}()
+	return sub.handler(ctx, e)
+}
+
+// match 获取匹配主题的同步或异步订阅
+func (b *Bus) match(topic string, sync bool) []*subscription {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	matched := make([]*subscription, 0)
+	for _, sub := range b.subs {
+		if sub.sync == sync && matchTopic(sub.pattern, topic) {
+			matched = append(matched, sub)
+		}
+	}
+	return matched
+}
+
+// invoke 调用处理器（捕获 panic）
+func (b *Bus) invoke(ctx context.Context, sub *subscription, e *Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.logger.Error("event handler panic", slog.String("topic", e.Topic), slog.Any("panic", r))
+		}
+	}()
+	if err := sub.handler(ctx, e); err != nil {
+		b.logger.Warn("event handler failed", slog.String("topic", e.Topic), slog.Any("error", err))
+	}
+}
+
+// matchTopic 判断主题是否匹配订阅模式
+func matchTopic(pattern, topic string) bool {
+	if pattern == "*" || pattern == topic {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(topic, prefix)
+	}
+	return false
+}
+
+// newNodeID 生成节点标识
+func newNodeID() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate event node id: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,319 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/so68/core/cache"
+	"github.com/so68/core/config"
+)
+
+/*
+事件总线功能测试
+
+本文件用于测试Bus结构体的各种功能特性，
+包括主题匹配、发布订阅、强类型主题、取消订阅、处理器异常隔离、关闭等。
+
+运行命令：
+go test -v -run "^Test.*Bus.*$"
+
+测试内容：
+1. 主题匹配 (matchTopic)
+2. 发布订阅 (Publish, Subscribe)
+3. 强类型主题 (NewTopic, Publish, Subscribe)
+4. 取消订阅 (unsubscribe)
+5. 处理器错误与 panic 隔离
+6. 关闭后发布 (Close, ErrBusClosed)
+7. 同步订阅在发布时执行并返回错误 (SubscribeSync)
+8. 跨节点分发与强类型反序列化 (Distribute)
+*/
+
+// newTestBus 创建测试用事件总线
+func newTestBus(t *testing.T) *Bus {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	bus := NewBus(config.DefaultEventConfig(), logger)
+	t.Cleanup(func() { _ = bus.Close(context.Background()) })
+	return bus
+}
+
+// waitFor 等待条件满足
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("timeout waiting for condition")
+}
+
+func TestBusMatchTopic(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		topic    string
+		expected bool
+	}{
+		{pattern: "admin.created", topic: "admin.created", expected: true},
+		{pattern: "admin.created", topic: "admin.deleted", expected: false},
+		{pattern: "admin.*", topic: "admin.created", expected: true},
+		{pattern: "admin.*", topic: "login.failed", expected: false},
+		{pattern: "*", topic: "login.failed", expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+"_"+tt.topic, func(t *testing.T) {
+			if got := matchTopic(tt.pattern, tt.topic); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestBusPublishSubscribe(t *testing.T) {
+	bus := newTestBus(t)
+
+	var mu sync.Mutex
+	received := make([]string, 0)
+	bus.Subscribe("admin.*", func(ctx context.Context, e *Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, e.Topic)
+		return nil
+	})
+
+	for _, topic := range []string{"admin.created", "login.failed", "admin.deleted"} {
+		if err := bus.Publish(context.Background(), topic, nil); err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == 2
+	})
+}
+
+func TestBusTypedTopic(t *testing.T) {
+	type loginFailed struct{ Username string }
+	topic := NewTopic[*loginFailed]("login.failed")
+	bus := newTestBus(t)
+
+	got := make(chan string, 1)
+	Subscribe(bus, topic, func(ctx context.Context, payload *loginFailed) error {
+		got <- payload.Username
+		return nil
+	})
+
+	if err := Publish(context.Background(), bus, topic, &loginFailed{Username: "admin"}); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	select {
+	case username := <-got:
+		if username != "admin" {
+			t.Errorf("Expected admin, got %s", username)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for event")
+	}
+}
+
+func TestBusUnsubscribe(t *testing.T) {
+	bus := newTestBus(t)
+
+	var mu sync.Mutex
+	count := 0
+	unsubscribe := bus.Subscribe("admin.created", func(ctx context.Context, e *Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		count++
+		return nil
+	})
+	unsubscribe()
+
+	if err := bus.Publish(context.Background(), "admin.created", nil); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if err := bus.Close(context.Background()); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if count != 0 {
+		t.Errorf("Expected 0 deliveries after unsubscribe, got %d", count)
+	}
+}
+
+func TestBusHandlerIsolation(t *testing.T) {
+	bus := newTestBus(t)
+
+	delivered := make(chan struct{}, 1)
+	bus.Subscribe("login.failed", func(ctx context.Context, e *Event) error {
+		panic("boom")
+	})
+	bus.Subscribe("login.failed", func(ctx context.Context, e *Event) error {
+		return errors.New("handler failed")
+	})
+	bus.Subscribe("login.failed", func(ctx context.Context, e *Event) error {
+		delivered <- struct{}{}
+		return nil
+	})
+
+	if err := bus.Publish(context.Background(), "login.failed", nil); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	select {
+	case <-delivered:
+	case <-time.After(2 * time.Second):
+		t.Fatal("healthy handler was not invoked")
+	}
+}
+
+func TestBusClose(t *testing.T) {
+	bus := newTestBus(t)
+
+	var mu sync.Mutex
+	count := 0
+	bus.Subscribe("*", func(ctx context.Context, e *Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		count++
+		return nil
+	})
+
+	for i := 0; i < 10; i++ {
+		if err := bus.Publish(context.Background(), "admin.created", i); err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+
+	// 关闭时应等待已发布事件分发完成
+	if err := bus.Close(context.Background()); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	mu.Lock()
+	if count != 10 {
+		t.Errorf("Expected 10 deliveries, got %d", count)
+	}
+	mu.Unlock()
+
+	if err := bus.Publish(context.Background(), "admin.created", nil); !errors.Is(err, ErrBusClosed) {
+		t.Errorf("Expected ErrBusClosed, got %v", err)
+	}
+}
+
+func TestBusSubscribeSync(t *testing.T) {
+	type adminCreated struct{ Username string }
+	topic := NewTopic[*adminCreated]("admin.created")
+	bus := newTestBus(t)
+
+	var got []string
+	SubscribeSync(bus, topic, func(ctx context.Context, payload *adminCreated) error {
+		got = append(got, payload.Username)
+		return nil
+	})
+	bus.SubscribeSync("admin.*", func(ctx context.Context, e *Event) error {
+		return errors.New("sync failed")
+	})
+	bus.SubscribeSync("admin.*", func(ctx context.Context, e *Event) error {
+		panic("boom")
+	})
+	delivered := make(chan struct{}, 1)
+	bus.Subscribe("admin.created", func(ctx context.Context, e *Event) error {
+		delivered <- struct{}{}
+		return nil
+	})
+
+	// 同步订阅在 Publish 返回前执行，错误与 panic 合并返回
+	err := Publish(context.Background(), bus, topic, &adminCreated{Username: "admin"})
+	if err == nil || !strings.Contains(err.Error(), "sync failed") || !strings.Contains(err.Error(), "panic") {
+		t.Fatalf("Expected joined sync errors, got %v", err)
+	}
+	if len(got) != 1 || got[0] != "admin" {
+		t.Fatalf("Expected sync handler to run before Publish returns, got %v", got)
+	}
+
+	// 同步订阅失败不影响异步分发
+	select {
+	case <-delivered:
+	case <-time.After(2 * time.Second):
+		t.Fatal("async handler was not invoked")
+	}
+}
+
+func TestBusDistribute(t *testing.T) {
+	type loginFailed struct{ Username string }
+	topic := NewTopic[*loginFailed]("login.failed")
+
+	cacheConfig := &config.CacheConfig{Driver: "memory", CleanupInterval: time.Minute}
+	cacheConfig.SetDefaults()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	broker, err := cache.NewMemoryCache(cacheConfig, logger)
+	if err != nil {
+		t.Fatalf("create memory cache failed: %v", err)
+	}
+	defer broker.Close()
+
+	// 两个事件总线模拟两个节点
+	local, remote := newTestBus(t), newTestBus(t)
+	for _, bus := range []*Bus{local, remote} {
+		if err := bus.Distribute(context.Background(), broker, "events"); err != nil {
+			t.Fatalf("Distribute failed: %v", err)
+		}
+	}
+
+	var mu sync.Mutex
+	localCount := 0
+	Subscribe(local, topic, func(ctx context.Context, payload *loginFailed) error {
+		mu.Lock()
+		defer mu.Unlock()
+		localCount++
+		return nil
+	})
+	got := make(chan string, 1)
+	remoteFlag := make(chan bool, 1)
+	remote.Subscribe("login.*", func(ctx context.Context, e *Event) error {
+		remoteFlag <- e.Remote
+		return nil
+	})
+	Subscribe(remote, topic, func(ctx context.Context, payload *loginFailed) error {
+		got <- payload.Username
+		return nil
+	})
+
+	if err := Publish(context.Background(), local, topic, &loginFailed{Username: "admin"}); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	select {
+	case username := <-got:
+		if username != "admin" {
+			t.Errorf("Expected admin, got %s", username)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for remote event")
+	}
+	if !<-remoteFlag {
+		t.Error("Expected event to be marked as remote")
+	}
+
+	// 本节点发出的分发事件被忽略，本地订阅只收到一次
+	if err := local.Close(context.Background()); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if localCount != 1 {
+		t.Errorf("Expected 1 local delivery, got %d", localCount)
+	}
+}
@@ -0,0 +1,14 @@
+package event
+
+// ServerStarted 服务启动事件
+type ServerStarted struct {
+	Name     string `json:"name"`      // 应用名称
+	Addr     string `json:"addr"`      // HTTP 监听地址（未启用 HTTP 服务时为空）
+	GRPCAddr string `json:"grpc_addr"` // gRPC 监听地址（未启用 gRPC 服务时为空）
+}
+
+// 框架内置事件，模块可订阅以扩展启动流程
+var (
+	// TopicServerStarted 服务启动
+	TopicServerStarted = NewTopic[*ServerStarted]("server.started")
+)
@@ -0,0 +1,55 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Topic 强类型主题，约束发布与订阅的数据类型一致
+type Topic[T any] struct {
+	name string
+}
+
+// NewTopic 创建强类型主题
+func NewTopic[T any](name string) Topic[T] {
+	return Topic[T]{name: name}
+}
+
+// Name 获取主题名称
+func (t Topic[T]) Name() string {
+	return t.name
+}
+
+// Publish 发布强类型事件
+func Publish[T any](ctx context.Context, bus *Bus, topic Topic[T], payload T) error {
+	return bus.Publish(ctx, topic.name, payload)
+}
+
+// Subscribe 订阅强类型事件（异步执行）
+func Subscribe[T any](bus *Bus, topic Topic[T], handler func(ctx context.Context, payload T) error) (unsubscribe func()) {
+	return bus.Subscribe(topic.name, typedHandler(handler))
+}
+
+// SubscribeSync 同步订阅强类型事件（在 Publish 中执行，错误返回给发布方）
+func SubscribeSync[T any](bus *Bus, topic Topic[T], handler func(ctx context.Context, payload T) error) (unsubscribe func()) {
+	return bus.SubscribeSync(topic.name, typedHandler(handler))
+}
+
+// typedHandler 将强类型处理器转换为通用处理器，其他节点的事件数据自动反序列化
+func typedHandler[T any](handler func(ctx context.Context, payload T) error) Handler {
+	return func(ctx context.Context, e *Event) error {
+		if payload, ok := e.Payload.(T); ok {
+			return handler(ctx, payload)
+		}
+		raw, ok := e.Payload.(json.RawMessage)
+		if !ok {
+			return fmt.Errorf("unexpected payload type %T for topic %s", e.Payload, e.Topic)
+		}
+		var payload T
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			return fmt.Errorf("decode payload for topic %s: %w", e.Topic, err)
+		}
+		return handler(ctx, payload)
+	}
+}
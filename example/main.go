@@ -1,23 +1,14 @@
 package main
 
 import (
-	"context"
-
-	"github.com/so68/core"
-	"github.com/so68/core/example/internal/admin"
+	"github.com/so68/core/cmd"
+	adminModule "github.com/so68/core/server/module/admin"
 )
 
 func main() {
-	app, err := core.NewApplication("./config.yaml")
-	if err != nil {
-		panic(err)
-	}
-
-	// 初始化 admin 服务器
-	if err := admin.NewAdminServer(app); err != nil {
-		panic(err)
-	}
-
-	// 运行应用
-	app.Run(context.Background())
+	cmd.New("example", "v0.1.0",
+		cmd.WithConfigPath("./config.yaml"),
+		// 注册 admin 模块（serve 时按生命周期初始化，migrate up 时迁移数据表）
+		cmd.WithModules(adminModule.NewAdminApp("/admin")),
+	).Execute()
 }
@@ -0,0 +1,224 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/so68/core/logging"
+	"github.com/so68/core/server/utils"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDMetadata 请求ID元数据键（与 HTTP 的 X-Request-ID 对应）
+const RequestIDMetadata = "x-request-id"
+
+// userIDKey 上下文用户ID键
+type userIDKey struct{}
+
+// UserIDFromContext 获取 JWT 拦截器认证的用户ID，未认证时返回 0
+func UserIDFromContext(ctx context.Context) uint {
+	userID, _ := ctx.Value(userIDKey{}).(uint)
+	return userID
+}
+
+// serverStream 替换上下文的流
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context 流上下文
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// wrapStream 使用新上下文包装流
+func wrapStream(ss grpc.ServerStream, ctx context.Context) grpc.ServerStream {
+	return &serverStream{ServerStream: ss, ctx: ctx}
+}
+
+// RecoveryUnaryInterceptor 一元请求 panic 恢复拦截器，panic 转换为 Internal 错误
+func RecoveryUnaryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ctx, logger, info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// RecoveryStreamInterceptor 流请求 panic 恢复拦截器，panic 转换为 Internal 错误
+func RecoveryStreamInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ss.Context(), logger, info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// recovered 记录 panic 并返回 Internal 错误
+func recovered(ctx context.Context, logger *slog.Logger, method string, r interface{}) error {
+	if ctxLogger := logging.FromContext(ctx); ctxLogger != logging.Default() {
+		logger = ctxLogger
+	}
+	logger.Error("grpc handler panic",
+		slog.String("method", method),
+		slog.Any("panic", r),
+		slog.String("stack", string(debug.Stack())),
+	)
+	return status.Error(codes.Internal, "internal server error")
+}
+
+// LoggerUnaryInterceptor 一元请求日志拦截器
+// - 复用客户端传入的 x-request-id 元数据（否则生成），并写入响应头
+// - 将携带请求ID、链路追踪ID、方法名的日志器写入上下文，业务层通过 logging.FromContext(ctx) 获取
+// - 请求失败时记录错误码与耗时
+func LoggerUnaryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = withRequestLogger(ctx, logger, info.FullMethod)
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logResult(ctx, err, start)
+		return resp, err
+	}
+}
+
+// LoggerStreamInterceptor 流请求日志拦截器
+func LoggerStreamInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := withRequestLogger(ss.Context(), logger, info.FullMethod)
+		start := time.Now()
+		err := handler(srv, wrapStream(ss, ctx))
+		logResult(ctx, err, start)
+		return err
+	}
+}
+
+// withRequestLogger 为上下文写入请求日志器
+func withRequestLogger(ctx context.Context, logger *slog.Logger, method string) context.Context {
+	requestID := firstMetadata(ctx, RequestIDMetadata)
+	if requestID == "" || len(requestID) > 128 {
+		requestID = newRequestID()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadata, requestID))
+
+	attrs := []any{slog.String(logging.KeyRequestID, requestID), slog.String(logging.KeyRoute, method)}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		attrs = append(attrs, slog.String(logging.KeyTraceID, spanContext.TraceID().String()))
+	}
+	return logging.WithContext(logging.WithRequestID(ctx, requestID), logger.With(attrs...))
+}
+
+// logResult 记录失败请求
+func logResult(ctx context.Context, err error, start time.Time) {
+	if err == nil {
+		return
+	}
+	code := status.Code(err)
+	level := slog.LevelWarn
+	switch code {
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		level = slog.LevelError
+	case codes.Canceled, codes.NotFound, codes.InvalidArgument, codes.Unauthenticated, codes.PermissionDenied:
+		level = slog.LevelDebug
+	}
+	logging.FromContext(ctx).Log(ctx, level, "grpc request failed",
+		slog.String("code", code.String()),
+		slog.Duration("duration", time.Since(start)),
+		slog.Any("error", err),
+	)
+}
+
+// JWTUnaryInterceptor 一元请求 JWT 认证拦截器
+// - 令牌从 authorization 元数据读取（Bearer <token>）
+// - 与 HTTP 中间件一致，令牌签发 IP 与对端 IP 不匹配时拒绝（外部身份提供方签发的令牌除外）
+// - skipMethods 为无需认证的完整方法名（例如 /grpc.health.v1.Health/Check）
+func JWTUnaryInterceptor(jwt *utils.JWT, skipMethods ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if slices.Contains(skipMethods, info.FullMethod) {
+			return handler(ctx, req)
+		}
+		ctx, err := authenticate(ctx, jwt)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// JWTStreamInterceptor 流请求 JWT 认证拦截器
+func JWTStreamInterceptor(jwt *utils.JWT, skipMethods ...string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if slices.Contains(skipMethods, info.FullMethod) {
+			return handler(srv, ss)
+		}
+		ctx, err := authenticate(ss.Context(), jwt)
+		if err != nil {
+			return err
+		}
+		return handler(srv, wrapStream(ss, ctx))
+	}
+}
+
+// authenticate 校验令牌并将用户ID写入上下文
+func authenticate(ctx context.Context, jwt *utils.JWT) (context.Context, error) {
+	token, ok := strings.CutPrefix(firstMetadata(ctx, "authorization"), "Bearer ")
+	if !ok || token == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing token")
+	}
+	claims, err := jwt.ParseToken(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if !claims.External && claims.IP != peerIP(ctx) {
+		return nil, status.Error(codes.Unauthenticated, "IP not match")
+	}
+
+	ctx = context.WithValue(ctx, userIDKey{}, claims.UserID)
+	return logging.With(ctx, logging.KeyAdminID, claims.UserID), nil
+}
+
+// firstMetadata 获取请求元数据的第一个值
+func firstMetadata(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// peerIP 获取对端 IP
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// newRequestID 生成请求ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,177 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync"
+
+	"github.com/so68/core/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/reflection"
+)
+
+// Server gRPC 服务
+// - 实现 grpc.ServiceRegistrar，可直接传给生成代码的 RegisterXxxServer
+// - 拦截器通过 Use/UseStream 追加，需在 Start 之前调用
+// - 默认启用 panic 恢复与请求日志拦截器
+type Server struct {
+	config *config.GRPCConfig
+	logger *slog.Logger
+	server *grpc.Server
+
+	mutex    sync.RWMutex
+	unary    []grpc.UnaryServerInterceptor
+	stream   []grpc.StreamServerInterceptor
+	listener net.Listener
+}
+
+// New 创建 gRPC 服务（仅构建，不监听）
+func New(cfg *config.GRPCConfig, logger *slog.Logger) (*Server, error) {
+	s := &Server{
+		config: cfg,
+		logger: logger,
+		unary:  []grpc.UnaryServerInterceptor{RecoveryUnaryInterceptor(logger), LoggerUnaryInterceptor(logger)},
+		stream: []grpc.StreamServerInterceptor{RecoveryStreamInterceptor(logger), LoggerStreamInterceptor(logger)},
+	}
+
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(s.unaryChain),
+		grpc.ChainStreamInterceptor(s.streamChain),
+		grpc.MaxRecvMsgSize(cfg.MaxRecvMsgSize),
+		grpc.MaxSendMsgSize(cfg.MaxSendMsgSize),
+		grpc.ConnectionTimeout(cfg.ConnectionTimeout),
+	}
+	if cfg.TLS {
+		creds, err := credentials.NewServerTLSFromFile(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load grpc tls credentials: %w", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	s.server = grpc.NewServer(opts...)
+	if cfg.Reflection {
+		reflection.Register(s.server)
+	}
+	return s, nil
+}
+
+// Use 追加一元拦截器（按追加顺序执行，位于默认拦截器之后）
+func (s *Server) Use(interceptors ...grpc.UnaryServerInterceptor) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.unary = append(s.unary, interceptors...)
+}
+
+// UseStream 追加流拦截器（按追加顺序执行，位于默认拦截器之后）
+func (s *Server) UseStream(interceptors ...grpc.StreamServerInterceptor) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stream = append(s.stream, interceptors...)
+}
+
+// RegisterService 注册服务（实现 grpc.ServiceRegistrar）
+func (s *Server) RegisterService(desc *grpc.ServiceDesc, impl interface{}) {
+	s.server.RegisterService(desc, impl)
+}
+
+// GRPC 获取底层 grpc.Server
+func (s *Server) GRPC() *grpc.Server {
+	return s.server
+}
+
+// Addr 监听地址
+func (s *Server) Addr() string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if s.listener != nil {
+		return s.listener.Addr().String()
+	}
+	return net.JoinHostPort(s.config.Host, fmt.Sprint(s.config.Port))
+}
+
+// Start 监听并启动服务（阻塞直到关闭）
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", net.JoinHostPort(s.config.Host, fmt.Sprint(s.config.Port)))
+	if err != nil {
+		return fmt.Errorf("failed to listen grpc: %w", err)
+	}
+	return s.Serve(listener)
+}
+
+// Serve 在指定监听器上启动服务（阻塞直到关闭）
+func (s *Server) Serve(listener net.Listener) error {
+	s.mutex.Lock()
+	s.listener = listener
+	s.mutex.Unlock()
+
+	s.logger.Info("gRPC server started successfully", slog.String("addr", listener.Addr().String()), slog.Bool("tls", s.config.TLS))
+	if err := s.server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+// StartAsync 非阻塞启动，返回错误通道
+func (s *Server) StartAsync() <-chan error {
+	ch := make(chan error, 1)
+	go func() {
+		err := s.Start()
+		ch <- err
+		close(ch)
+	}()
+	return ch
+}
+
+// Shutdown 优雅关闭：停止接收新请求并等待执行中的请求完成，ctx 超时后强制关闭
+func (s *Server) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return fmt.Errorf("grpc shutdown timeout: %w", ctx.Err())
+	}
+}
+
+// unaryChain 按当前注册顺序执行一元拦截器
+func (s *Server) unaryChain(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	s.mutex.RLock()
+	interceptors := s.unary
+	s.mutex.RUnlock()
+
+	next := handler
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, h := interceptors[i], next
+		next = func(ctx context.Context, req interface{}) (interface{}, error) {
+			return interceptor(ctx, req, info, h)
+		}
+	}
+	return next(ctx, req)
+}
+
+// streamChain 按当前注册顺序执行流拦截器
+func (s *Server) streamChain(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	s.mutex.RLock()
+	interceptors := s.stream
+	s.mutex.RUnlock()
+
+	next := handler
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, h := interceptors[i], next
+		next = func(srv interface{}, ss grpc.ServerStream) error {
+			return interceptor(srv, ss, info, h)
+		}
+	}
+	return next(srv, ss)
+}
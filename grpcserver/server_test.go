@@ -0,0 +1,228 @@
+package grpcserver
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/so68/core/config"
+	"github.com/so68/core/logging"
+	"github.com/so68/core/server/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+/*
+gRPC 服务功能测试
+
+本文件用于测试Server结构体的各种功能特性，
+包括服务注册、拦截器链、panic 恢复、请求ID、JWT 认证与优雅关闭等。
+
+运行命令：
+go test -v -run "^TestGRPC.*$"
+
+测试内容：
+1. 服务注册与调用 (RegisterService, Serve)
+2. 拦截器执行顺序 (Use)
+3. panic 恢复 (RecoveryUnaryInterceptor)
+4. 请求ID传递 (LoggerUnaryInterceptor)
+5. JWT 认证 (JWTUnaryInterceptor, UserIDFromContext)
+6. 优雅关闭 (Shutdown)
+*/
+
+// newTestServer 创建基于内存连接的测试服务，返回服务与健康检查客户端
+func newTestServer(t *testing.T) (*Server, grpc_health_v1.HealthClient) {
+	t.Helper()
+	cfg := config.DefaultGRPCConfig()
+	cfg.Enabled = true
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	s, err := New(cfg, logger)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	grpc_health_v1.RegisterHealthServer(s, health.NewServer())
+
+	listener := bufconn.Listen(1024 * 1024)
+	go func() { _ = s.Serve(listener) }()
+	t.Cleanup(func() { _ = s.Shutdown(context.Background()) })
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return s, grpc_health_v1.NewHealthClient(conn)
+}
+
+func TestGRPCServe(t *testing.T) {
+	_, client := newTestServer(t)
+
+	resp, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Errorf("Expected SERVING, got %v", resp.Status)
+	}
+}
+
+func TestGRPCInterceptorOrder(t *testing.T) {
+	s, client := newTestServer(t)
+
+	order := make([]string, 0)
+	record := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			order = append(order, name)
+			return handler(ctx, req)
+		}
+	}
+	s.Use(record("first"), record("second"))
+	s.Use(record("third"))
+
+	if _, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	expected := []string{"first", "second", "third"}
+	if len(order) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, order)
+		}
+	}
+}
+
+func TestGRPCRecovery(t *testing.T) {
+	s, client := newTestServer(t)
+	panicking := true
+	s.Use(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if panicking {
+			panic("boom")
+		}
+		return handler(ctx, req)
+	})
+
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("Expected Internal, got %v", err)
+	}
+
+	// panic 后服务仍可用
+	panicking = false
+	if _, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{}); err != nil {
+		t.Errorf("Expected server to keep serving after panic, got %v", err)
+	}
+}
+
+func TestGRPCRequestID(t *testing.T) {
+	s, client := newTestServer(t)
+
+	var logged bool
+	s.Use(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		logged = logging.FromContext(ctx) != logging.Default()
+		return handler(ctx, req)
+	})
+
+	tests := []struct {
+		name      string
+		requestID string
+	}{
+		{name: "reuse client request id", requestID: "req-123"},
+		{name: "generate request id", requestID: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.requestID != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, RequestIDMetadata, tt.requestID)
+			}
+			var header metadata.MD
+			if _, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{}, grpc.Header(&header)); err != nil {
+				t.Fatalf("Check failed: %v", err)
+			}
+			values := header.Get(RequestIDMetadata)
+			if len(values) != 1 || values[0] == "" {
+				t.Fatalf("Expected request id header, got %v", values)
+			}
+			if tt.requestID != "" && values[0] != tt.requestID {
+				t.Errorf("Expected request id %s, got %s", tt.requestID, values[0])
+			}
+			if !logged {
+				t.Error("Expected request logger in context")
+			}
+		})
+	}
+}
+
+func TestGRPCJWT(t *testing.T) {
+	s, client := newTestServer(t)
+	jwt := utils.NewJWT("test-secret", time.Hour)
+
+	var userID uint
+	s.Use(JWTUnaryInterceptor(jwt, "/grpc.health.v1.Health/List"))
+	s.Use(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		userID = UserIDFromContext(ctx)
+		return handler(ctx, req)
+	})
+
+	tests := []struct {
+		name     string
+		token    string
+		expected codes.Code
+		userID   uint
+	}{
+		{name: "missing token", token: "", expected: codes.Unauthenticated},
+		{name: "invalid token", token: "invalid", expected: codes.Unauthenticated},
+		{name: "ip not match", token: jwt.GenerateToken(1, "10.0.0.1"), expected: codes.Unauthenticated},
+		// bufconn 的对端地址为 bufconn
+		{name: "valid token", token: jwt.GenerateToken(7, "bufconn"), expected: codes.OK, userID: 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID = 0
+			ctx := context.Background()
+			if tt.token != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+tt.token)
+			}
+			_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+			if status.Code(err) != tt.expected {
+				t.Fatalf("Expected %v, got %v", tt.expected, err)
+			}
+			if userID != tt.userID {
+				t.Errorf("Expected user id %d, got %d", tt.userID, userID)
+			}
+		})
+	}
+
+	// 跳过认证的方法
+	if _, err := client.List(context.Background(), &grpc_health_v1.HealthListRequest{}); err != nil {
+		t.Errorf("Expected skipped method to succeed, got %v", err)
+	}
+}
+
+func TestGRPCShutdown(t *testing.T) {
+	s, client := newTestServer(t)
+
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{}); err == nil {
+		t.Error("Expected error after shutdown")
+	}
+}
@@ -0,0 +1,266 @@
+package core
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/cache"
+	"github.com/so68/core/config"
+	"github.com/so68/core/database"
+)
+
+// healthSlowThreshold 组件检查耗时超过该阈值视为降级
+const healthSlowThreshold = time.Second
+
+// HealthStatus 健康状态
+type HealthStatus string
+
+const (
+	HealthStatusUp       HealthStatus = "up"       // 正常
+	HealthStatusDegraded HealthStatus = "degraded" // 降级（可用但异常，例如响应缓慢、非关键组件故障）
+	HealthStatusDown     HealthStatus = "down"     // 不可用
+)
+
+// ComponentHealth 组件健康状态
+type ComponentHealth struct {
+	Status   HealthStatus           `json:"status"`             // 状态
+	Critical bool                   `json:"critical"`           // 是否关键组件（关键组件不可用时整体不可用）
+	Latency  string                 `json:"latency"`            // 检查耗时
+	Error    string                 `json:"error,omitempty"`    // 错误信息
+	Metadata map[string]interface{} `json:"metadata,omitempty"` // 附加信息（例如连接池统计）
+}
+
+// HealthReport 健康报告
+type HealthReport struct {
+	Status     HealthStatus                `json:"status"`     // 整体状态
+	Components map[string]*ComponentHealth `json:"components"` // 各组件状态
+	CheckedAt  time.Time                   `json:"checked_at"` // 检查时间
+}
+
+// healthCheck 组件检查项
+type healthCheck struct {
+	name     string
+	critical bool
+	check    func(ctx context.Context) (map[string]interface{}, error)
+}
+
+// Health 聚合健康检查，并发检查各组件并返回结构化报告
+// - 关键组件（数据库、缓存）失败时整体为 down，主库启用延迟连接时仅降级
+// - 非关键组件失败或任一组件响应缓慢时整体为 degraded
+func (a *Application) Health(ctx context.Context) *HealthReport {
+	return a.runHealthChecks(ctx, a.healthChecks())
+}
+
+// Ready 就绪检查，仅检查关键组件（数据库、缓存）
+// - 应用正在关闭时直接返回 down，使负载均衡尽早摘除流量
+func (a *Application) Ready(ctx context.Context) *HealthReport {
+	if a.closing.Load() {
+		return &HealthReport{
+			Status:     HealthStatusDown,
+			Components: map[string]*ComponentHealth{},
+			CheckedAt:  time.Now(),
+		}
+	}
+
+	checks := make([]healthCheck, 0)
+	for _, hc := range a.healthChecks() {
+		if hc.critical {
+			checks = append(checks, hc)
+		}
+	}
+	return a.runHealthChecks(ctx, checks)
+}
+
+// runHealthChecks 并发执行检查项并汇总状态
+func (a *Application) runHealthChecks(ctx context.Context, checks []healthCheck) *HealthReport {
+	report := &HealthReport{
+		Status:     HealthStatusUp,
+		Components: make(map[string]*ComponentHealth, len(checks)),
+		CheckedAt:  time.Now(),
+	}
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for _, hc := range checks {
+		wg.Add(1)
+		go func(hc healthCheck) {
+			defer wg.Done()
+			component := runHealthCheck(ctx, hc)
+			mutex.Lock()
+			report.Components[hc.name] = component
+			mutex.Unlock()
+		}(hc)
+	}
+	wg.Wait()
+
+	for name, component := range report.Components {
+		switch {
+		case component.Status == HealthStatusDown && component.Critical:
+			report.Status = HealthStatusDown
+		case component.Status != HealthStatusUp && report.Status == HealthStatusUp:
+			report.Status = HealthStatusDegraded
+		}
+		if component.Status != HealthStatusUp {
+			a.Logger.Warn("component unhealthy", slog.String("component", name), slog.String("status", string(component.Status)), slog.String("error", component.Error))
+		}
+	}
+	return report
+}
+
+// runHealthCheck 执行单个组件检查
+func runHealthCheck(ctx context.Context, hc healthCheck) *ComponentHealth {
+	start := time.Now()
+	metadata, err := hc.check(ctx)
+	latency := time.Since(start)
+
+	component := &ComponentHealth{
+		Status:   HealthStatusUp,
+		Critical: hc.critical,
+		Latency:  latency.String(),
+		Metadata: metadata,
+	}
+	switch {
+	case err != nil && hc.critical:
+		component.Status = HealthStatusDown
+		component.Error = err.Error()
+	case err != nil:
+		// 非关键组件故障仅降级
+		component.Status = HealthStatusDegraded
+		component.Error = err.Error()
+	case latency > healthSlowThreshold:
+		component.Status = HealthStatusDegraded
+	}
+	return component
+}
+
+// healthChecks 已启用组件的检查项
+func (a *Application) healthChecks() []healthCheck {
+	checks := make([]healthCheck, 0)
+	// 主库启用延迟连接时视为非关键组件，不可用时整体降级（应用继续提供不依赖数据库的服务）
+	if a.DB != nil {
+		checks = append(checks, healthCheck{name: "db", critical: !database.IsLazy(a.DB), check: func(ctx context.Context) (map[string]interface{}, error) {
+			if err := a.DB.HealthCheck(); err != nil {
+				return nil, err
+			}
+			sqlDB, err := a.DB.DB().DB()
+			if err != nil {
+				return nil, nil
+			}
+			return dbPoolStats(sqlDB.Stats()), nil
+		}})
+	}
+	// 命名数据库为非关键组件，故障时整体降级
+	for name, db := range a.databases {
+		checks = append(checks, healthCheck{name: "db:" + name, check: func(ctx context.Context) (map[string]interface{}, error) {
+			if err := db.HealthCheck(); err != nil {
+				return nil, err
+			}
+			sqlDB, err := db.DB().DB()
+			if err != nil {
+				return nil, nil
+			}
+			return dbPoolStats(sqlDB.Stats()), nil
+		}})
+	}
+	if a.Cache != nil {
+		checks = append(checks, healthCheck{name: "cache", critical: true, check: func(ctx context.Context) (map[string]interface{}, error) {
+			if err := a.Cache.HealthCheck(ctx); err != nil {
+				return nil, err
+			}
+			return cacheStats(ctx, a.Cache), nil
+		}})
+	}
+	if a.Queue != nil {
+		checks = append(checks, healthCheck{name: "queue", check: func(ctx context.Context) (map[string]interface{}, error) {
+			stats, err := a.Queue.Stats(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{"ready": stats.Ready, "delayed": stats.Delayed, "dead": stats.Dead}, nil
+		}})
+	}
+	return checks
+}
+
+// dbPoolStats 数据库连接池统计
+func dbPoolStats(stats sql.DBStats) map[string]interface{} {
+	return map[string]interface{}{
+		"max_open_connections": stats.MaxOpenConnections,
+		"open_connections":     stats.OpenConnections,
+		"in_use":               stats.InUse,
+		"idle":                 stats.Idle,
+		"wait_count":           stats.WaitCount,
+		"wait_duration":        stats.WaitDuration.String(),
+	}
+}
+
+// cacheStats 缓存统计（Redis 驱动附加连接池统计）
+func cacheStats(ctx context.Context, c cache.Cache) map[string]interface{} {
+	metadata := make(map[string]interface{})
+	if stats, err := c.Stats(ctx); err == nil {
+		metadata["driver"] = stats.Driver
+		metadata["hits"] = stats.Hits
+		metadata["misses"] = stats.Misses
+		metadata["hit_rate"] = stats.HitRate
+		metadata["keys"] = stats.Keys
+		metadata["memory"] = stats.Memory
+		metadata["max_memory"] = stats.MaxMemory
+		metadata["evictions"] = stats.Evictions
+		metadata["expirations"] = stats.Expirations
+		if stats.Shards > 0 {
+			metadata["shards"] = stats.Shards
+		}
+	}
+	if rc, ok := c.(*cache.RedisCache); ok {
+		pool := rc.Client().PoolStats()
+		metadata["mode"] = rc.Mode()
+		metadata["pool_hits"] = pool.Hits
+		metadata["pool_misses"] = pool.Misses
+		metadata["timeouts"] = pool.Timeouts
+		metadata["total_conns"] = pool.TotalConns
+		metadata["idle_conns"] = pool.IdleConns
+		metadata["stale_conns"] = pool.StaleConns
+	}
+	return metadata
+}
+
+// healthHandler 健康检查接口（down 返回 503，up/degraded 返回 200）
+func (a *Application) healthHandler(c *gin.Context) {
+	writeHealthReport(c, a.Health(c.Request.Context()))
+}
+
+// livenessHandler 存活探针（进程能够响应即视为存活）
+func (a *Application) livenessHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": HealthStatusUp})
+}
+
+// readinessHandler 就绪探针
+func (a *Application) readinessHandler(c *gin.Context) {
+	writeHealthReport(c, a.Ready(c.Request.Context()))
+}
+
+// registerHealthRoutes 注册健康检查接口
+func (a *Application) registerHealthRoutes() {
+	cfg := a.Config.Health
+	if cfg == nil {
+		cfg = config.DefaultHealthConfig()
+	}
+	group := a.Server.NewGroup("")
+	group.GET(cfg.Path, a.healthHandler)
+	group.GET(cfg.LivenessPath, a.livenessHandler)
+	group.GET(cfg.ReadinessPath, a.readinessHandler)
+}
+
+// writeHealthReport 输出健康报告，down 时返回 503
+func writeHealthReport(c *gin.Context, report *HealthReport) {
+	status := http.StatusOK
+	if report.Status == HealthStatusDown {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
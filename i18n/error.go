@@ -0,0 +1,49 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Message 可翻译的错误消息
+// - Error() 返回源语言文本，日志与未翻译时的行为与 fmt.Errorf 一致
+// - 响应时按消息键与参数翻译（见 TranslateError），避免格式化后的文本无法匹配译文
+type Message struct {
+	Key  string        // 消息键（源语言格式串）
+	Args []interface{} // 格式化参数
+}
+
+// Errorf 创建可翻译的错误消息
+func Errorf(key string, args ...interface{}) error {
+	return &Message{Key: key, Args: args}
+}
+
+// Error 源语言文本
+func (m *Message) Error() string {
+	if len(m.Args) > 0 {
+		return fmt.Sprintf(m.Key, m.Args...)
+	}
+	return m.Key
+}
+
+// TranslateError 按请求语言翻译错误信息
+// - 错误信息本身存在译文时直接翻译
+// - 错误链中包含 Message 时替换其源语言文本为译文（保留外层包装的前缀）
+// - 其他错误原样返回
+func (b *Bundle) TranslateError(locale string, err error) string {
+	text := err.Error()
+	if translated := b.Translate(locale, text); translated != text {
+		return translated
+	}
+	var message *Message
+	if errors.As(err, &message) {
+		return strings.Replace(text, message.Error(), b.Translate(locale, message.Key, message.Args...), 1)
+	}
+	return text
+}
+
+// TranslateError 使用全局消息目录翻译错误信息
+func TranslateError(locale string, err error) string {
+	return defaultBundle.TranslateError(locale, err)
+}
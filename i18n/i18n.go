@@ -0,0 +1,171 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// 内置语言
+const (
+	LocaleZhCN = "zh-CN" // 简体中文（源语言）
+	LocaleEnUS = "en-US" // 英文
+)
+
+// Bundle 多语言消息目录
+// - 消息键即源语言（简体中文）文本，未找到译文时原样返回，便于渐进式翻译
+// - 消息支持 fmt 格式化占位符，先翻译格式串再格式化参数
+type Bundle struct {
+	mutex         sync.RWMutex
+	defaultLocale string
+	catalogs      map[string]map[string]string
+}
+
+// NewBundle 创建消息目录
+func NewBundle(defaultLocale string) *Bundle {
+	return &Bundle{
+		defaultLocale: defaultLocale,
+		catalogs:      make(map[string]map[string]string),
+	}
+}
+
+// DefaultLocale 获取默认语言
+func (b *Bundle) DefaultLocale() string {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.defaultLocale
+}
+
+// SetDefaultLocale 设置默认语言
+func (b *Bundle) SetDefaultLocale(locale string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.defaultLocale = locale
+}
+
+// Register 注册（合并）指定语言的消息
+func (b *Bundle) Register(locale string, messages map[string]string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	catalog, ok := b.catalogs[locale]
+	if !ok {
+		catalog = make(map[string]string, len(messages))
+		b.catalogs[locale] = catalog
+	}
+	for key, message := range messages {
+		catalog[key] = message
+	}
+}
+
+// Locales 获取已注册的语言（包含默认语言）
+func (b *Bundle) Locales() []string {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	locales := []string{b.defaultLocale}
+	for locale := range b.catalogs {
+		if locale != b.defaultLocale {
+			locales = append(locales, locale)
+		}
+	}
+	sort.Strings(locales[1:])
+	return locales
+}
+
+// Translate 翻译消息，查找顺序：指定语言 -> 默认语言 -> 消息键本身
+func (b *Bundle) Translate(locale string, key string, args ...interface{}) string {
+	b.mutex.RLock()
+	message, ok := b.catalogs[locale][key]
+	if !ok {
+		message, ok = b.catalogs[b.defaultLocale][key]
+	}
+	b.mutex.RUnlock()
+
+	if !ok {
+		message = key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+// Match 根据 Accept-Language（或单个语言标识）匹配已注册语言，无法匹配时返回默认语言
+// - 按 q 权重排序，先精确匹配（忽略大小写、兼容 zh_CN 写法），再按主语言匹配（en -> en-US）
+func (b *Bundle) Match(acceptLanguage string) string {
+	locales := b.Locales()
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		for _, locale := range locales {
+			if strings.EqualFold(locale, tag) {
+				return locale
+			}
+		}
+		base, _, _ := strings.Cut(tag, "-")
+		for _, locale := range locales {
+			localeBase, _, _ := strings.Cut(locale, "-")
+			if strings.EqualFold(localeBase, base) {
+				return locale
+			}
+		}
+	}
+	return locales[0]
+}
+
+// parseAcceptLanguage 解析 Accept-Language，按权重从高到低返回语言标识
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag    string
+		weight float64
+	}
+
+	items := make([]weighted, 0)
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+		if tag == "" || tag == "*" {
+			continue
+		}
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				weight = parsed
+			}
+		}
+		if weight <= 0 {
+			continue
+		}
+		items = append(items, weighted{tag: tag, weight: weight})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool { return items[i].weight > items[j].weight })
+	tags := make([]string, len(items))
+	for i, item := range items {
+		tags[i] = item.tag
+	}
+	return tags
+}
+
+// defaultBundle 全局消息目录（内置中英文消息）
+var defaultBundle = newDefaultBundle()
+
+// newDefaultBundle 创建内置消息目录
+func newDefaultBundle() *Bundle {
+	bundle := NewBundle(LocaleZhCN)
+	if err := bundle.LoadFS(builtinLocales, "locales"); err != nil {
+		panic(fmt.Sprintf("i18n: load builtin locales failed: %v", err))
+	}
+	return bundle
+}
+
+// Default 获取全局消息目录
+func Default() *Bundle {
+	return defaultBundle
+}
+
+// T 使用全局消息目录翻译消息
+func T(locale string, key string, args ...interface{}) string {
+	return defaultBundle.Translate(locale, key, args...)
+}
@@ -0,0 +1,211 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-playground/validator/v10"
+)
+
+/*
+多语言功能测试
+
+本文件用于测试Bundle结构体的各种功能特性，
+包括消息翻译、语言回退、Accept-Language 匹配、参数校验错误翻译等。
+
+运行命令：
+go test -v -run "^Test.*$"
+
+测试内容：
+1. 消息翻译与回退 (Translate)
+2. Accept-Language 解析与匹配 (Match)
+3. 参数校验错误翻译 (TranslateValidation)
+4. 字段级校验错误 (FieldErrors, FieldName)
+5. 加载消息文件 (LoadFS)
+6. 可翻译的错误消息 (Errorf, TranslateError)
+*/
+
+func TestBundleTranslate(t *testing.T) {
+	bundle := NewBundle(LocaleZhCN)
+	bundle.Register(LocaleEnUS, map[string]string{
+		"无权限访问":     "Access denied",
+		"剩余 %d 次机会": "%d attempts left",
+	})
+
+	tests := []struct {
+		name     string
+		locale   string
+		key      string
+		args     []interface{}
+		expected string
+	}{
+		{name: "源语言", locale: LocaleZhCN, key: "无权限访问", expected: "无权限访问"},
+		{name: "英文", locale: LocaleEnUS, key: "无权限访问", expected: "Access denied"},
+		{name: "格式化", locale: LocaleEnUS, key: "剩余 %d 次机会", args: []interface{}{3}, expected: "3 attempts left"},
+		{name: "未翻译原样返回", locale: LocaleEnUS, key: "未知消息", expected: "未知消息"},
+		{name: "未注册语言回退", locale: "ja-JP", key: "剩余 %d 次机会", args: []interface{}{2}, expected: "剩余 2 次机会"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bundle.Translate(tt.locale, tt.key, tt.args...); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestBundleMatch(t *testing.T) {
+	bundle := NewBundle(LocaleZhCN)
+	bundle.Register(LocaleEnUS, map[string]string{})
+
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{header: "", expected: LocaleZhCN},
+		{header: "en-US", expected: LocaleEnUS},
+		{header: "en", expected: LocaleEnUS},
+		{header: "en-GB,en;q=0.9", expected: LocaleEnUS},
+		{header: "zh_CN", expected: LocaleZhCN},
+		{header: "fr-FR,en;q=0.5,zh;q=0.8", expected: LocaleZhCN},
+		{header: "fr-FR,en;q=0.8,zh;q=0.5", expected: LocaleEnUS},
+		{header: "fr-FR", expected: LocaleZhCN},
+		{header: "en;q=0", expected: LocaleZhCN},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := bundle.Match(tt.header); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTranslateValidation(t *testing.T) {
+	type params struct {
+		Username string `validate:"required"`
+		Age      int    `validate:"min=18"`
+	}
+	err := validator.New().Struct(&params{Age: 1})
+	if err == nil {
+		t.Fatal("Expected validation error")
+	}
+
+	tests := []struct {
+		locale   string
+		expected string
+	}{
+		{locale: LocaleZhCN, expected: "Username为必填字段; Age不能小于18"},
+		{locale: LocaleEnUS, expected: "Username is required; Age must be at least 18"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			if got := TranslateValidation(tt.locale, err); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+
+	// 非校验错误返回通用提示
+	if got := TranslateValidation(LocaleEnUS, errors.New("invalid character")); got != "Invalid request parameters" {
+		t.Errorf("Expected %q, got %q", "Invalid request parameters", got)
+	}
+}
+
+func TestFieldErrors(t *testing.T) {
+	type params struct {
+		Username string `json:"username" validate:"min=3"`
+		Status   int8   `form:"status" validate:"oneof=1 2"`
+		Remark   string `json:"-" validate:"required"`
+	}
+	validate := validator.New()
+	validate.RegisterTagNameFunc(FieldName)
+	err := validate.Struct(&params{Username: "ab", Status: 3})
+	if err == nil {
+		t.Fatal("Expected validation error")
+	}
+
+	tests := []struct {
+		locale   string
+		expected []FieldError
+	}{
+		{locale: LocaleZhCN, expected: []FieldError{
+			{Field: "username", Tag: "min", Param: "3", Message: "username长度不能小于3"},
+			{Field: "status", Tag: "oneof", Param: "1 2", Message: "status必须是[1 2]中的一个"},
+			{Field: "Remark", Tag: "required", Message: "Remark为必填字段"},
+		}},
+		{locale: LocaleEnUS, expected: []FieldError{
+			{Field: "username", Tag: "min", Param: "3", Message: "username length must be at least 3"},
+			{Field: "status", Tag: "oneof", Param: "1 2", Message: "status must be one of [1 2]"},
+			{Field: "Remark", Tag: "required", Message: "Remark is required"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			got := FieldErrors(tt.locale, err)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+
+	// 非校验错误没有字段级错误
+	if got := FieldErrors(LocaleZhCN, errors.New("invalid character")); got != nil {
+		t.Errorf("Expected nil, got %+v", got)
+	}
+}
+
+func TestBundleLoadFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/ja-JP.yaml": {Data: []byte("# 日文\n\"无权限访问\": \"アクセス拒否\"\n")},
+		"locales/en-US.json": {Data: []byte(`{"无权限访问": "Forbidden"}`)},
+		"locales/README.md":  {Data: []byte("ignored")},
+		"broken/en-US.yaml":  {Data: []byte("- not a map")},
+	}
+	bundle := NewBundle(LocaleZhCN)
+	if err := bundle.LoadFS(fsys, "locales"); err != nil {
+		t.Fatalf("LoadFS failed: %v", err)
+	}
+	if got := bundle.Translate("ja-JP", "无权限访问"); got != "アクセス拒否" {
+		t.Errorf("Expected %q, got %q", "アクセス拒否", got)
+	}
+	if got := bundle.Translate(LocaleEnUS, "无权限访问"); got != "Forbidden" {
+		t.Errorf("Expected %q, got %q", "Forbidden", got)
+	}
+	if err := bundle.LoadFS(fsys, "broken"); err == nil {
+		t.Error("Expected parse error")
+	}
+
+	// 内置英文消息
+	if got := T(LocaleEnUS, "服务器内部错误"); got != "Internal server error" {
+		t.Errorf("Expected builtin translation, got %q", got)
+	}
+}
+
+func TestTranslateError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{name: "格式化消息", err: Errorf("账号或密码错误, 请重新输入! 剩余 %d 次机会", 2), expected: "Incorrect username or password, please try again! 2 attempts left"},
+		{name: "包装的消息", err: fmt.Errorf("登录失败: %w", Errorf("账号或密码错误, 请重新输入!")), expected: "登录失败: Incorrect username or password, please try again!"},
+		{name: "普通错误", err: errors.New("无权限访问"), expected: "Access denied"},
+		{name: "未翻译的错误", err: errors.New("未知错误"), expected: "未知错误"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TranslateError(LocaleEnUS, tt.err); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+
+	// 源语言返回格式化后的原文
+	if got := TranslateError(LocaleZhCN, Errorf("剩余 %d 次", 2)); got != "剩余 2 次" {
+		t.Errorf("Expected %q, got %q", "剩余 2 次", got)
+	}
+}
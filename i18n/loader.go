@@ -0,0 +1,55 @@
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// builtinLocales 内置消息文件
+//
+//go:embed locales
+var builtinLocales embed.FS
+
+// LoadFS 从文件系统加载消息文件并注册（合并）
+// - 文件名（不含扩展名）为语言标识，例如 en-US.yaml、ja-JP.json
+// - 文件内容为 "源语言文本: 译文" 的平铺键值，支持 .yaml、.yml 与 .json
+// - 子目录与其他扩展名的文件会被忽略
+func (b *Bundle) LoadFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return fmt.Errorf("读取语言目录失败: %w", err)
+	}
+	for _, entry := range entries {
+		ext := path.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml" && ext != ".json") {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("读取语言文件 %s 失败: %w", entry.Name(), err)
+		}
+		messages := make(map[string]string)
+		// JSON 是 YAML 的子集，统一按 YAML 解析
+		if err := yaml.Unmarshal(data, &messages); err != nil {
+			return fmt.Errorf("解析语言文件 %s 失败: %w", entry.Name(), err)
+		}
+		b.Register(strings.TrimSuffix(entry.Name(), ext), messages)
+	}
+	return nil
+}
+
+// LoadDir 从目录加载消息文件并注册（合并），格式见 LoadFS
+func (b *Bundle) LoadDir(dir string) error {
+	return b.LoadFS(os.DirFS(dir), ".")
+}
+
+// LoadDir 向全局消息目录加载消息文件（可覆盖内置译文或新增语言）
+func LoadDir(dir string) error {
+	return defaultBundle.LoadDir(dir)
+}
@@ -0,0 +1,122 @@
+package i18n
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// validationMessages 校验规则对应的消息模板（第一个参数为字段名，第二个参数为规则参数）
+var validationMessages = map[string]string{
+	"required": "%s为必填字段",
+	"email":    "%s必须是有效的邮箱地址",
+	"url":      "%s必须是有效的URL",
+	"min":      "%s不能小于%s",
+	"max":      "%s不能大于%s",
+	"len":      "%s长度必须为%s",
+	"oneof":    "%s必须是[%s]中的一个",
+	"gte":      "%s必须大于或等于%s",
+	"lte":      "%s必须小于或等于%s",
+	"gt":       "%s必须大于%s",
+	"lt":       "%s必须小于%s",
+	"numeric":  "%s必须是数字",
+	"alphanum": "%s只能包含字母和数字",
+	"ip":       "%s必须是有效的IP地址",
+	"eqfield":  "%s必须等于%s",
+}
+
+// lengthMessages 字符串、切片等按长度校验的规则消息模板
+var lengthMessages = map[string]string{
+	"min": "%s长度不能小于%s",
+	"max": "%s长度不能大于%s",
+}
+
+// FieldError 字段级校验错误
+type FieldError struct {
+	Field   string `json:"field"`           // 字段名（已注册标签名函数时为请求参数名）
+	Tag     string `json:"tag"`             // 校验规则
+	Param   string `json:"param,omitempty"` // 规则参数
+	Message string `json:"message"`         // 已翻译的错误信息
+}
+
+// FieldErrors 翻译参数校验错误为字段级错误列表，非校验错误返回 nil
+func (b *Bundle) FieldErrors(locale string, err error) []FieldError {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil
+	}
+
+	fieldErrors := make([]FieldError, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:   fieldError.Field(),
+			Tag:     fieldError.Tag(),
+			Param:   fieldError.Param(),
+			Message: b.translateFieldError(locale, fieldError),
+		})
+	}
+	return fieldErrors
+}
+
+// translateFieldError 翻译单个字段的校验错误
+func (b *Bundle) translateFieldError(locale string, fieldError validator.FieldError) string {
+	template, ok := validationMessages[fieldError.Tag()]
+	if lengthTemplate, isLength := lengthMessages[fieldError.Tag()]; isLength && hasLength(fieldError.Kind()) {
+		template = lengthTemplate
+	}
+	if !ok {
+		return b.Translate(locale, "%s校验失败(%s)", fieldError.Field(), fieldError.Tag())
+	}
+	if strings.Count(template, "%s") > 1 {
+		return b.Translate(locale, template, fieldError.Field(), fieldError.Param())
+	}
+	return b.Translate(locale, template, fieldError.Field())
+}
+
+// hasLength 字段类型是否按长度校验 min/max
+func hasLength(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// TranslateValidation 翻译参数校验错误，多个字段错误以 "; " 连接
+// 非校验错误（例如 JSON 格式错误）返回 "请求参数格式错误" 的译文
+func (b *Bundle) TranslateValidation(locale string, err error) string {
+	fieldErrors := b.FieldErrors(locale, err)
+	if fieldErrors == nil {
+		return b.Translate(locale, "请求参数格式错误")
+	}
+
+	messages := make([]string, 0, len(fieldErrors))
+	for _, fieldError := range fieldErrors {
+		messages = append(messages, fieldError.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// TranslateValidation 使用全局消息目录翻译参数校验错误
+func TranslateValidation(locale string, err error) string {
+	return defaultBundle.TranslateValidation(locale, err)
+}
+
+// FieldErrors 使用全局消息目录翻译参数校验错误为字段级错误列表
+func FieldErrors(locale string, err error) []FieldError {
+	return defaultBundle.FieldErrors(locale, err)
+}
+
+// FieldName 校验错误使用的字段名：依次取 json、form 标签名（忽略 "-"），均未设置时使用结构体字段名
+// 可通过 validator.Validate.RegisterTagNameFunc 注册，使错误中的字段名与请求参数一致
+func FieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
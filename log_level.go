@@ -0,0 +1,68 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/so68/core/database"
+	"github.com/so68/core/logging"
+)
+
+// LogLevel 当前应用日志级别（debug, info, warn, error；传入自定义日志器时为空）
+func (a *Application) LogLevel() string {
+	if a.logLevel == nil {
+		return ""
+	}
+	return strings.ToLower(a.logLevel.Level().String())
+}
+
+// SetLogLevel 运行时调整应用日志级别（debug, info, warn, error），无需重启
+// - 作用于本地输出，远程输出（syslog、Loki）按各自配置的级别过滤
+// - 传入自定义日志器（WithLogger）时不支持调整
+func (a *Application) SetLogLevel(level string) error {
+	if a.logLevel == nil {
+		return errors.New("custom logger does not support changing level")
+	}
+	if level == "" {
+		return errors.New("log level is empty")
+	}
+	l, err := logging.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	previous := a.LogLevel()
+	a.logLevel.Set(l)
+	a.Logger.Warn("log level changed", slog.String("from", previous), slog.String("to", a.LogLevel()))
+	return nil
+}
+
+// DBLogLevel 当前主库 GORM 日志级别（silent, error, warn, info；未启用数据库时为空）
+func (a *Application) DBLogLevel() string {
+	if a.DB == nil {
+		return ""
+	}
+	return database.LogLevel(a.DB)
+}
+
+// SetDBLogLevel 运行时调整主库与命名数据库的 GORM 日志级别（silent, error, warn, info），无需重启
+func (a *Application) SetDBLogLevel(level string) error {
+	if a.DB == nil {
+		return errors.New("database is not enabled")
+	}
+	if _, err := database.ParseLogLevel(level); err != nil {
+		return err
+	}
+	previous := a.DBLogLevel()
+	if err := database.SetLogLevel(a.DB, level); err != nil {
+		return err
+	}
+	for name, db := range a.databases {
+		if err := database.SetLogLevel(db, level); err != nil {
+			return fmt.Errorf("db %s: %w", name, err)
+		}
+	}
+	a.Logger.Warn("db log level changed", slog.String("from", previous), slog.String("to", a.DBLogLevel()))
+	return nil
+}
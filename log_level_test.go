@@ -0,0 +1,92 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/so68/core/config"
+	"github.com/so68/core/database"
+	"github.com/so68/core/logging"
+)
+
+/*
+运行时日志级别功能测试
+
+本文件用于测试Application运行时调整应用日志与数据库日志级别。
+
+运行命令：
+go test -v -run "^TestApplication.*LogLevel$"
+
+测试内容：
+1. 调整应用日志级别 (SetLogLevel)
+2. 调整主库与命名数据库日志级别 (SetDBLogLevel)
+*/
+
+func TestApplicationSetLogLevel(t *testing.T) {
+	output := &bytes.Buffer{}
+	level := new(slog.LevelVar)
+	level.Set(slog.LevelInfo)
+	app := &Application{
+		Logger:   slog.New(logging.NewLevelHandler(level, slog.NewTextHandler(output, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		logLevel: level,
+	}
+
+	app.Logger.Debug("hidden")
+	if err := app.SetLogLevel("debug"); err != nil {
+		t.Fatalf("SetLogLevel failed: %v", err)
+	}
+	app.Logger.Debug("visible")
+	if app.LogLevel() != "debug" {
+		t.Errorf("Expected level debug, got %q", app.LogLevel())
+	}
+	if strings.Contains(output.String(), "hidden") || !strings.Contains(output.String(), "visible") {
+		t.Errorf("Unexpected output: %q", output.String())
+	}
+
+	if err := app.SetLogLevel("verbose"); err == nil {
+		t.Error("Expected error for invalid level")
+	}
+	if app.LogLevel() != "debug" {
+		t.Errorf("Invalid level should not change level, got %q", app.LogLevel())
+	}
+
+	// 自定义日志器不支持调整
+	custom := &Application{Logger: slog.New(slog.NewTextHandler(output, nil))}
+	if err := custom.SetLogLevel("debug"); err == nil || custom.LogLevel() != "" {
+		t.Errorf("Expected custom logger to be unsupported, got %v", err)
+	}
+}
+
+func TestApplicationSetDBLogLevel(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	open := func() database.Database {
+		db, err := database.NewSQLiteDatabase(&config.DatabaseConfig{Driver: "sqlite", Database: ":memory:", LogLevel: "warn"}, logger)
+		if err != nil {
+			t.Fatalf("NewSQLiteDatabase failed: %v", err)
+		}
+		t.Cleanup(func() { _ = db.Close(context.Background()) })
+		return db
+	}
+	report := open()
+	app := &Application{Logger: logger, DB: open(), databases: map[string]database.Database{"report": report}}
+
+	if app.DBLogLevel() != "warn" {
+		t.Errorf("Expected db level warn, got %q", app.DBLogLevel())
+	}
+	if err := app.SetDBLogLevel("info"); err != nil {
+		t.Fatalf("SetDBLogLevel failed: %v", err)
+	}
+	if app.DBLogLevel() != "info" || database.LogLevel(report) != "info" {
+		t.Errorf("Expected all databases at info, got %q, %q", app.DBLogLevel(), database.LogLevel(report))
+	}
+	if err := app.SetDBLogLevel("debug"); err == nil {
+		t.Error("Expected error for invalid db level")
+	}
+
+	if err := (&Application{Logger: logger}).SetDBLogLevel("info"); err == nil {
+		t.Error("Expected error when database is disabled")
+	}
+}
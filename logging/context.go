@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+)
+
+// 关联字段名称
+const (
+	KeyRequestID = "request_id" // 请求ID
+	KeyTraceID   = "trace_id"   // 链路追踪ID
+	KeyAdminID   = "admin_id"   // 管理员ID
+	KeyTenantID  = "tenant_id"  // 租户ID
+	KeyRoute     = "route"      // 路由模板
+)
+
+// loggerKey 上下文日志器键
+type loggerKey struct{}
+
+// requestIDKey 上下文请求ID键
+type requestIDKey struct{}
+
+// defaultLogger 上下文中未携带日志器时使用的日志器
+var defaultLogger atomic.Pointer[slog.Logger]
+
+// SetDefault 设置默认日志器（通常为应用日志器）
+func SetDefault(logger *slog.Logger) {
+	defaultLogger.Store(logger)
+}
+
+// Default 获取默认日志器，未设置时返回 slog.Default()
+func Default() *slog.Logger {
+	if logger := defaultLogger.Load(); logger != nil {
+		return logger
+	}
+	return slog.Default()
+}
+
+// WithContext 将日志器写入上下文
+func WithContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext 获取上下文中的请求日志器（已携带请求ID、链路追踪ID、管理员ID、路由等关联字段）
+// 非请求上下文返回默认日志器
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := Lookup(ctx); ok {
+		return logger
+	}
+	return Default()
+}
+
+// Lookup 获取上下文中的请求日志器，未携带时返回 false（用于组件在请求日志器与自身日志器之间选择）
+func Lookup(ctx context.Context) (*slog.Logger, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	logger, ok := ctx.Value(loggerKey{}).(*slog.Logger)
+	return logger, ok
+}
+
+// WithRequestID 将请求ID写入上下文（便于向下游服务、任务队列等传递）
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext 获取上下文中的请求ID，未携带时返回空字符串
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// With 为上下文中的日志器追加字段并返回新上下文
+func With(ctx context.Context, args ...any) context.Context {
+	return WithContext(ctx, FromContext(ctx).With(args...))
+}
@@ -0,0 +1,96 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+/*
+请求日志器功能测试
+
+本文件用于测试上下文日志器的各种功能特性，
+包括写入与读取、追加关联字段、默认日志器回退等。
+
+运行命令：
+go test -v -run "^Test.*Context.*$"
+
+测试内容：
+1. 写入与读取 (WithContext, FromContext)
+2. 追加关联字段 (With)
+3. 默认日志器回退 (SetDefault, Default)
+4. 请求日志器查找 (Lookup)
+5. 请求ID传递 (WithRequestID, RequestIDFromContext)
+*/
+
+func TestFromContext(t *testing.T) {
+	buf := &bytes.Buffer{}
+	base := slog.New(slog.NewJSONHandler(buf, nil))
+
+	ctx := WithContext(context.Background(), base.With(KeyRequestID, "req-1"))
+	ctx = With(ctx, KeyAdminID, 7)
+	FromContext(ctx).Info("hello")
+
+	output := buf.String()
+	for _, expected := range []string{`"request_id":"req-1"`, `"admin_id":7`, `"msg":"hello"`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %s, got %s", expected, output)
+		}
+	}
+}
+
+func TestFromContextDefault(t *testing.T) {
+	buf := &bytes.Buffer{}
+	SetDefault(slog.New(slog.NewJSONHandler(buf, nil)))
+	defer defaultLogger.Store(nil)
+
+	FromContext(context.Background()).Info("fallback")
+	if !strings.Contains(buf.String(), `"msg":"fallback"`) {
+		t.Errorf("Expected default logger to be used, got %s", buf.String())
+	}
+}
+
+func TestLookupContext(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(&bytes.Buffer{}, nil))
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected bool
+	}{
+		{name: "nil context", ctx: nil, expected: false},
+		{name: "without logger", ctx: context.Background(), expected: false},
+		{name: "with logger", ctx: WithContext(context.Background(), logger), expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Lookup(tt.ctx)
+			if ok != tt.expected {
+				t.Fatalf("Expected %v, got %v", tt.expected, ok)
+			}
+			if ok && got != logger {
+				t.Error("Expected the logger stored in context")
+			}
+		})
+	}
+}
+
+func TestRequestIDContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected string
+	}{
+		{name: "nil context", ctx: nil, expected: ""},
+		{name: "without request id", ctx: context.Background(), expected: ""},
+		{name: "with request id", ctx: WithRequestID(context.Background(), "req-1"), expected: "req-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RequestIDFromContext(tt.ctx); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
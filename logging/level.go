@@ -0,0 +1,37 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LevelHandler 按可运行时调整的级别过滤日志记录（例如 *slog.LevelVar）
+type LevelHandler struct {
+	level   slog.Leveler
+	handler slog.Handler
+}
+
+// NewLevelHandler 创建级别过滤 Handler
+func NewLevelHandler(level slog.Leveler, handler slog.Handler) *LevelHandler {
+	return &LevelHandler{level: level, handler: handler}
+}
+
+// Enabled 级别不低于当前级别且下游 Handler 启用时启用
+func (h *LevelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() && h.handler.Enabled(ctx, level)
+}
+
+// Handle 交由下游 Handler 处理
+func (h *LevelHandler) Handle(ctx context.Context, record slog.Record) error {
+	return h.handler.Handle(ctx, record)
+}
+
+// WithAttrs 追加属性（共享同一级别）
+func (h *LevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &LevelHandler{level: h.level, handler: h.handler.WithAttrs(attrs)}
+}
+
+// WithGroup 追加分组（共享同一级别）
+func (h *LevelHandler) WithGroup(name string) slog.Handler {
+	return &LevelHandler{level: h.level, handler: h.handler.WithGroup(name)}
+}
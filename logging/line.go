@@ -0,0 +1,61 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// emitFunc 输出一行已格式化的日志
+type emitFunc func(level slog.Level, t time.Time, line string) error
+
+// lineHandler 将日志记录格式化为单行 JSON 后交给远程输出
+// WithAttrs/WithGroup 派生的 Handler 共享缓冲与锁
+type lineHandler struct {
+	inner slog.Handler
+	buf   *bytes.Buffer
+	mutex *sync.Mutex
+	emit  emitFunc
+}
+
+// newLineHandler 创建单行格式化 Handler
+func newLineHandler(level slog.Level, emit emitFunc) *lineHandler {
+	buf := &bytes.Buffer{}
+	return &lineHandler{
+		inner: slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: level}),
+		buf:   buf,
+		mutex: &sync.Mutex{},
+		emit:  emit,
+	}
+}
+
+// Enabled 是否启用该级别
+func (h *lineHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+// Handle 格式化并输出
+func (h *lineHandler) Handle(ctx context.Context, record slog.Record) error {
+	h.mutex.Lock()
+	h.buf.Reset()
+	err := h.inner.Handle(ctx, record)
+	line := strings.TrimSuffix(h.buf.String(), "\n")
+	h.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+	return h.emit(record.Level, record.Time, line)
+}
+
+// WithAttrs 追加属性
+func (h *lineHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &lineHandler{inner: h.inner.WithAttrs(attrs), buf: h.buf, mutex: h.mutex, emit: h.emit}
+}
+
+// WithGroup 追加分组
+func (h *lineHandler) WithGroup(name string) slog.Handler {
+	return &lineHandler{inner: h.inner.WithGroup(name), buf: h.buf, mutex: h.mutex, emit: h.emit}
+}
@@ -0,0 +1,182 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/so68/core/config"
+	"github.com/so68/utils/logger"
+)
+
+// NewLogger 创建应用日志器
+// - 本地输出（stdout/文件轮转）沿用 utils/logger
+// - 按 logSinks 配置追加 syslog、Loki 等远程输出，返回的 io.Closer 用于关闭时刷新远程缓冲
+// - 按 logMask 配置对所有输出统一脱敏
+func NewLogger(cfg *config.AppConfig) (*slog.Logger, io.Closer, error) {
+	return NewLoggerWithLevel(cfg, new(slog.LevelVar))
+}
+
+// NewLoggerWithLevel 创建应用日志器，本地输出级别由 level 控制
+// - level 初始化为 logger.level 配置的级别，之后可通过 level.Set 运行时调整（无需重启）
+// - 远程输出（syslog、Loki）按各自配置的级别过滤，不受 level 影响
+func NewLoggerWithLevel(cfg *config.AppConfig, level *slog.LevelVar) (*slog.Logger, io.Closer, error) {
+	localCfg := logger.DefaultConfig()
+	if cfg.Logger != nil {
+		copied := *cfg.Logger
+		localCfg = &copied
+	}
+	configured, err := ParseLevel(string(localCfg.Level))
+	if err != nil {
+		return nil, nil, err
+	}
+	level.Set(configured)
+
+	// 本地输出以最低级别创建，由 LevelHandler 按 level 过滤
+	localCfg.Level = logger.LevelDebug
+	base, err := logger.NewLogger(localCfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	handler, closer, err := newSinkHandler(cfg, NewLevelHandler(level, base.Handler()))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if cfg.LogMask != nil && cfg.LogMask.Enabled {
+		masker, err := NewMasker(cfg.LogMask)
+		if err != nil {
+			_ = closer.Close()
+			return nil, nil, err
+		}
+		handler = NewMaskHandler(handler, masker)
+	}
+	return slog.New(handler), closer, nil
+}
+
+// newSinkHandler 在本地输出之外追加远程输出
+func newSinkHandler(cfg *config.AppConfig, base slog.Handler) (slog.Handler, io.Closer, error) {
+	if cfg.LogSinks == nil {
+		return base, nopCloser{}, nil
+	}
+
+	handlers := []slog.Handler{base}
+	closers := make(multiCloser, 0)
+
+	if syslogCfg := cfg.LogSinks.Syslog; syslogCfg != nil && syslogCfg.Enabled {
+		tag := syslogCfg.Tag
+		if tag == "" {
+			tag = cfg.Name
+		}
+		handler, err := NewSyslogHandler(syslogCfg, tag)
+		if err != nil {
+			_ = closers.Close()
+			return nil, nil, err
+		}
+		handlers = append(handlers, handler)
+		closers = append(closers, handler)
+	}
+
+	if lokiCfg := cfg.LogSinks.Loki; lokiCfg != nil && lokiCfg.Enabled {
+		handler, err := NewLokiHandler(lokiCfg, cfg.Name)
+		if err != nil {
+			_ = closers.Close()
+			return nil, nil, err
+		}
+		handlers = append(handlers, handler)
+		closers = append(closers, handler)
+	}
+
+	if len(handlers) == 1 {
+		return base, nopCloser{}, nil
+	}
+	return NewMultiHandler(handlers...), closers, nil
+}
+
+// ParseLevel 解析日志级别（debug, info, warn, error），为空时返回 info
+func ParseLevel(level string) (slog.Level, error) {
+	if level == "" {
+		return slog.LevelInfo, nil
+	}
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(strings.ToUpper(level))); err != nil {
+		return slog.LevelInfo, fmt.Errorf("invalid log level %q: %w", level, err)
+	}
+	return l, nil
+}
+
+// MultiHandler 将日志记录分发到多个 Handler
+type MultiHandler struct {
+	handlers []slog.Handler
+}
+
+// NewMultiHandler 创建多路输出 Handler
+func NewMultiHandler(handlers ...slog.Handler) *MultiHandler {
+	return &MultiHandler{handlers: handlers}
+}
+
+// Enabled 任一 Handler 启用即启用
+func (h *MultiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle 分发日志记录，单个输出失败不影响其他输出
+func (h *MultiHandler) Handle(ctx context.Context, record slog.Record) error {
+	var errs []error
+	for _, handler := range h.handlers {
+		if !handler.Enabled(ctx, record.Level) {
+			continue
+		}
+		if err := handler.Handle(ctx, record.Clone()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs 为所有 Handler 追加属性
+func (h *MultiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithAttrs(attrs)
+	}
+	return &MultiHandler{handlers: handlers}
+}
+
+// WithGroup 为所有 Handler 追加分组
+func (h *MultiHandler) WithGroup(name string) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithGroup(name)
+	}
+	return &MultiHandler{handlers: handlers}
+}
+
+// multiCloser 依次关闭多个输出
+type multiCloser []io.Closer
+
+// Close 关闭所有输出
+func (c multiCloser) Close() error {
+	var errs []error
+	for _, closer := range c {
+		if err := closer.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// nopCloser 无需关闭的输出
+type nopCloser struct{}
+
+// Close 无操作
+func (nopCloser) Close() error { return nil }
@@ -0,0 +1,187 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+/*
+日志输出功能测试
+
+本文件用于测试日志多路输出的各种功能特性，
+包括日志级别解析、多路分发、Loki 批量推送等。
+
+运行命令：
+go test -v -run "^Test.*$"
+
+测试内容：
+1. 日志级别解析 (ParseLevel)
+2. 多路分发 (MultiHandler)
+3. Loki 批量推送 (LokiHandler)
+4. 运行时调整级别 (LevelHandler)
+*/
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		level    string
+		expected slog.Level
+		wantErr  bool
+	}{
+		{level: "", expected: slog.LevelInfo},
+		{level: "debug", expected: slog.LevelDebug},
+		{level: "warn", expected: slog.LevelWarn},
+		{level: "ERROR", expected: slog.LevelError},
+		{level: "verbose", expected: slog.LevelInfo, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			level, err := ParseLevel(tt.level)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if level != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, level)
+			}
+		})
+	}
+}
+
+func TestLevelHandler(t *testing.T) {
+	output := &bytes.Buffer{}
+	level := new(slog.LevelVar)
+	level.Set(slog.LevelWarn)
+	logger := slog.New(NewLevelHandler(level, slog.NewTextHandler(output, &slog.HandlerOptions{Level: slog.LevelDebug}))).With("service", "api")
+
+	logger.Info("before")
+	level.Set(slog.LevelDebug)
+	logger.Debug("after")
+	if strings.Contains(output.String(), "before") {
+		t.Errorf("Expected info to be filtered at warn level, got %q", output.String())
+	}
+	if !strings.Contains(output.String(), "after") || !strings.Contains(output.String(), "service=api") {
+		t.Errorf("Expected debug output with attrs after level change, got %q", output.String())
+	}
+
+	// 下游 Handler 的级别仍然生效
+	output.Reset()
+	strict := slog.New(NewLevelHandler(level, slog.NewTextHandler(output, &slog.HandlerOptions{Level: slog.LevelError})))
+	strict.Warn("dropped")
+	if output.Len() != 0 {
+		t.Errorf("Expected downstream level to apply, got %q", output.String())
+	}
+}
+
+func TestNewLoggerWithLevel(t *testing.T) {
+	cfg := config.DefaultAppConfig()
+	cfg.Logger.Level = "warn"
+	level := new(slog.LevelVar)
+	logger, closer, err := NewLoggerWithLevel(cfg, level)
+	if err != nil {
+		t.Fatalf("NewLoggerWithLevel failed: %v", err)
+	}
+	defer closer.Close()
+	if level.Level() != slog.LevelWarn {
+		t.Errorf("Expected level initialized from config, got %v", level.Level())
+	}
+	if logger.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("Expected info disabled at warn level")
+	}
+	level.Set(slog.LevelInfo)
+	if !logger.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("Expected info enabled after level change")
+	}
+
+	cfg.Logger.Level = "verbose"
+	if _, _, err := NewLoggerWithLevel(cfg, new(slog.LevelVar)); err == nil {
+		t.Error("Expected error for invalid level")
+	}
+}
+
+func TestMultiHandler(t *testing.T) {
+	info := &bytes.Buffer{}
+	errorOnly := &bytes.Buffer{}
+	logger := slog.New(NewMultiHandler(
+		slog.NewJSONHandler(info, &slog.HandlerOptions{Level: slog.LevelInfo}),
+		slog.NewJSONHandler(errorOnly, &slog.HandlerOptions{Level: slog.LevelError}),
+	)).With("component", "test")
+
+	logger.Info("hello")
+	logger.Error("failed")
+
+	if got := strings.Count(info.String(), "\n"); got != 2 {
+		t.Errorf("Expected 2 lines in info sink, got %d", got)
+	}
+	if got := strings.Count(errorOnly.String(), "\n"); got != 1 {
+		t.Errorf("Expected 1 line in error sink, got %d", got)
+	}
+	if !strings.Contains(errorOnly.String(), `"component":"test"`) {
+		t.Errorf("Expected attrs to be propagated, got %s", errorOnly.String())
+	}
+}
+
+func TestLokiHandler(t *testing.T) {
+	var mutex sync.Mutex
+	lines := make([]string, 0)
+	labels := make([]map[string]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := &lokiPushRequest{}
+		if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mutex.Lock()
+		for _, stream := range request.Streams {
+			labels = append(labels, stream.Stream)
+			for _, value := range stream.Values {
+				lines = append(lines, value[1])
+			}
+		}
+		mutex.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultLogSinksConfig().Loki
+	cfg.URL = server.URL
+	cfg.Level = "info"
+	cfg.Labels = map[string]string{"env": "test"}
+	cfg.FlushInterval = time.Hour
+	handler, err := NewLokiHandler(cfg, "core")
+	if err != nil {
+		t.Fatalf("NewLokiHandler failed: %v", err)
+	}
+
+	logger := slog.New(handler)
+	logger.Debug("ignored")
+	logger.Info("first", "user_id", 1)
+	logger.Warn("second")
+
+	// 关闭时推送剩余日志
+	if err := handler.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d: %v", len(lines), lines)
+	}
+	for _, stream := range labels {
+		if stream["app"] != "core" || stream["env"] != "test" || stream["level"] == "" {
+			t.Errorf("Unexpected stream labels: %v", stream)
+		}
+	}
+	if !strings.Contains(strings.Join(lines, "\n"), `"user_id":1`) {
+		t.Errorf("Expected structured attrs in lines, got %v", lines)
+	}
+}
@@ -0,0 +1,190 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+// lokiEntry 待推送日志
+type lokiEntry struct {
+	level string
+	time  time.Time
+	line  string
+}
+
+// lokiPushRequest Loki 推送请求体
+type lokiPushRequest struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+// lokiStream Loki 日志流
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// LokiHandler Loki 日志输出
+// 日志写入内存队列后由后台协程批量推送，队列满时丢弃日志，避免阻塞业务
+type LokiHandler struct {
+	*lineHandler
+	config  *config.LokiSinkConfig
+	labels  map[string]string
+	client  *http.Client
+	entries chan lokiEntry
+	dropped atomic.Int64
+	done    chan struct{}
+	wg      sync.WaitGroup
+	once    sync.Once
+}
+
+// NewLokiHandler 创建 Loki 日志输出
+func NewLokiHandler(cfg *config.LokiSinkConfig, appName string) (*LokiHandler, error) {
+	level, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("loki url is required")
+	}
+
+	labels := map[string]string{"app": appName}
+	for key, value := range cfg.Labels {
+		labels[key] = value
+	}
+
+	h := &LokiHandler{
+		config:  cfg,
+		labels:  labels,
+		client:  &http.Client{Timeout: cfg.Timeout},
+		entries: make(chan lokiEntry, cfg.BufferSize),
+		done:    make(chan struct{}),
+	}
+	h.lineHandler = newLineHandler(level, h.enqueue)
+
+	h.wg.Add(1)
+	go h.run()
+	return h, nil
+}
+
+// Dropped 因队列已满丢弃的日志数量
+func (h *LokiHandler) Dropped() int64 {
+	return h.dropped.Load()
+}
+
+// enqueue 写入推送队列
+func (h *LokiHandler) enqueue(level slog.Level, t time.Time, line string) error {
+	select {
+	case h.entries <- lokiEntry{level: level.String(), time: t, line: line}:
+	default:
+		h.dropped.Add(1)
+	}
+	return nil
+}
+
+// run 后台批量推送
+func (h *LokiHandler) run() {
+	defer h.wg.Done()
+
+	ticker := time.NewTicker(h.config.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]lokiEntry, 0, h.config.BatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		// 推送失败无法再写日志（避免递归），直接丢弃该批次
+		_ = h.push(batch)
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case entry := <-h.entries:
+			batch = append(batch, entry)
+			if len(batch) >= h.config.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-h.done:
+			// 推送剩余日志
+			for {
+				select {
+				case entry := <-h.entries:
+					batch = append(batch, entry)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// push 推送一批日志（按级别拆分日志流）
+func (h *LokiHandler) push(batch []lokiEntry) error {
+	streams := make(map[string]*lokiStream)
+	for _, entry := range batch {
+		stream, ok := streams[entry.level]
+		if !ok {
+			labels := make(map[string]string, len(h.labels)+1)
+			for key, value := range h.labels {
+				labels[key] = value
+			}
+			labels["level"] = entry.level
+			stream = &lokiStream{Stream: labels}
+			streams[entry.level] = stream
+		}
+		stream.Values = append(stream.Values, [2]string{strconv.FormatInt(entry.time.UnixNano(), 10), entry.line})
+	}
+
+	request := lokiPushRequest{Streams: make([]lokiStream, 0, len(streams))}
+	for _, stream := range streams {
+		request.Streams = append(request.Streams, *stream)
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), h.config.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.config.Username != "" {
+		req.SetBasicAuth(h.config.Username, h.config.Password)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("loki push failed: %s", resp.Status)
+	}
+	return nil
+}
+
+// Close 停止后台协程并推送剩余日志
+func (h *LokiHandler) Close() error {
+	h.once.Do(func() {
+		close(h.done)
+	})
+	h.wg.Wait()
+	return nil
+}
@@ -0,0 +1,215 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/so68/core/config"
+)
+
+var (
+	// cardPattern 疑似银行卡号（13-19 位数字，允许空格或短横线分隔），命中后再做 Luhn 校验
+	cardPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+	// phonePattern 中国大陆手机号
+	phonePattern = regexp.MustCompile(`\b(1[3-9]\d)\d{4}(\d{4})\b`)
+	// bearerPattern Authorization 头中的 Bearer 令牌
+	bearerPattern = regexp.MustCompile(`(?i)\b(bearer\s+)[A-Za-z0-9\-._~+/]+=*`)
+)
+
+// Masker 日志脱敏器
+//   - 字段名命中时整体替换属性值
+//   - 文本（消息、字符串属性、错误信息）中按 key=value / "key":"value" 形式替换敏感字段的值，
+//     并按规则替换银行卡号、手机号与自定义正则，覆盖 GORM 输出的 SQL 语句
+type Masker struct {
+	fields      []string
+	keyValue    *regexp.Regexp
+	patterns    []*regexp.Regexp
+	replacement string
+	maskCards   bool
+	maskPhones  bool
+}
+
+// NewMasker 创建日志脱敏器
+func NewMasker(cfg *config.LogMaskConfig) (*Masker, error) {
+	m := &Masker{
+		replacement: cfg.Replacement,
+		maskCards:   cfg.MaskCards,
+		maskPhones:  cfg.MaskPhones,
+	}
+	if m.replacement == "" {
+		m.replacement = "******"
+	}
+
+	names := make([]string, 0, len(cfg.Fields))
+	for _, field := range cfg.Fields {
+		normalized := normalizeKey(field)
+		if normalized == "" {
+			continue
+		}
+		m.fields = append(m.fields, normalized)
+		names = append(names, regexp.QuoteMeta(field))
+	}
+	if len(names) > 0 {
+		// 匹配 password=xxx、"token":"xxx"、`password` = 'xxx' 等写法
+		m.keyValue = regexp.MustCompile(`(?i)([\w-]*(?:` + strings.Join(names, "|") + `)["'` + "`" + `]?\s*[:=]\s*)("[^"]*"|'[^']*'|(?:bearer\s+)?[^\s,&;)]+)`)
+	}
+
+	for _, pattern := range cfg.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log mask pattern %q: %w", pattern, err)
+		}
+		m.patterns = append(m.patterns, re)
+	}
+	return m, nil
+}
+
+// IsSensitiveKey 判断字段名是否需要脱敏（忽略大小写与 _/-，按后缀匹配）
+func (m *Masker) IsSensitiveKey(key string) bool {
+	normalized := normalizeKey(key)
+	if normalized == "" {
+		return false
+	}
+	for _, field := range m.fields {
+		if strings.HasSuffix(normalized, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaskString 脱敏文本内容
+func (m *Masker) MaskString(s string) string {
+	if s == "" {
+		return s
+	}
+	if m.keyValue != nil {
+		s = m.keyValue.ReplaceAllStringFunc(s, func(match string) string {
+			sub := m.keyValue.FindStringSubmatch(match)
+			value := sub[2]
+			if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+				return sub[1] + value[:1] + m.replacement + value[len(value)-1:]
+			}
+			return sub[1] + m.replacement
+		})
+	}
+	s = bearerPattern.ReplaceAllString(s, "${1}"+m.replacement)
+	if m.maskCards {
+		s = cardPattern.ReplaceAllStringFunc(s, maskCard)
+	}
+	if m.maskPhones {
+		s = phonePattern.ReplaceAllString(s, "${1}****${2}")
+	}
+	for _, re := range m.patterns {
+		s = re.ReplaceAllString(s, m.replacement)
+	}
+	return s
+}
+
+// MaskAttr 脱敏单个属性（分组递归处理）
+func (m *Masker) MaskAttr(attr slog.Attr) slog.Attr {
+	value := attr.Value.Resolve()
+	if value.Kind() != slog.KindGroup && m.IsSensitiveKey(attr.Key) {
+		return slog.String(attr.Key, m.replacement)
+	}
+
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, m.MaskString(value.String()))
+	case slog.KindGroup:
+		group := value.Group()
+		masked := make([]slog.Attr, len(group))
+		for i, a := range group {
+			masked[i] = m.MaskAttr(a)
+		}
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(masked...)}
+	case slog.KindAny:
+		switch v := value.Any().(type) {
+		case error:
+			return slog.String(attr.Key, m.MaskString(v.Error()))
+		case fmt.Stringer:
+			return slog.String(attr.Key, m.MaskString(v.String()))
+		}
+	}
+	return slog.Attr{Key: attr.Key, Value: value}
+}
+
+// MaskHandler 日志脱敏 Handler，包装最终输出以覆盖所有日志（含 GORM 与访问日志）
+type MaskHandler struct {
+	next   slog.Handler
+	masker *Masker
+}
+
+// NewMaskHandler 创建日志脱敏 Handler
+func NewMaskHandler(next slog.Handler, masker *Masker) *MaskHandler {
+	return &MaskHandler{next: next, masker: masker}
+}
+
+// Enabled 与下游 Handler 一致
+func (h *MaskHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle 脱敏消息与属性后交给下游 Handler
+func (h *MaskHandler) Handle(ctx context.Context, record slog.Record) error {
+	masked := slog.NewRecord(record.Time, record.Level, h.masker.MaskString(record.Message), record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		masked.AddAttrs(h.masker.MaskAttr(attr))
+		return true
+	})
+	return h.next.Handle(ctx, masked)
+}
+
+// WithAttrs 脱敏预置属性
+func (h *MaskHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	masked := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		masked[i] = h.masker.MaskAttr(attr)
+	}
+	return &MaskHandler{next: h.next.WithAttrs(masked), masker: h.masker}
+}
+
+// WithGroup 追加分组
+func (h *MaskHandler) WithGroup(name string) slog.Handler {
+	return &MaskHandler{next: h.next.WithGroup(name), masker: h.masker}
+}
+
+// maskCard 通过 Luhn 校验的卡号仅保留后 4 位
+func maskCard(s string) string {
+	digits := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			digits = append(digits, s[i])
+		}
+	}
+	if !luhnValid(digits) {
+		return s
+	}
+	return strings.Repeat("*", len(digits)-4) + string(digits[len(digits)-4:])
+}
+
+// luhnValid Luhn 校验
+func luhnValid(digits []byte) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// normalizeKey 统一字段名：小写并去除 _ 与 -
+func normalizeKey(key string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(key)))
+}
@@ -0,0 +1,133 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/so68/core/config"
+)
+
+/*
+日志脱敏功能测试
+
+本文件用于测试日志脱敏的各种功能特性，
+包括敏感字段识别、文本脱敏、Handler 包装等。
+
+运行命令：
+go test -v -run "^Test.*Mask.*$"
+
+测试内容：
+1. 敏感字段识别 (IsSensitiveKey)
+2. 文本脱敏 (MaskString)
+3. 脱敏 Handler (MaskHandler)
+4. 非法正则 (NewMasker)
+*/
+
+// newTestMasker 创建测试用脱敏器
+func newTestMasker(t *testing.T) *Masker {
+	t.Helper()
+	masker, err := NewMasker(config.DefaultLogMaskConfig())
+	if err != nil {
+		t.Fatalf("NewMasker failed: %v", err)
+	}
+	return masker
+}
+
+func TestMaskerIsSensitiveKey(t *testing.T) {
+	masker := newTestMasker(t)
+	tests := []struct {
+		key      string
+		expected bool
+	}{
+		{key: "password", expected: true},
+		{key: "NewPassword", expected: true},
+		{key: "access_token", expected: true},
+		{key: "X-Api-Key", expected: true},
+		{key: "mfaCode", expected: true},
+		{key: "token_id", expected: false},
+		{key: "username", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := masker.IsSensitiveKey(tt.key); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestMaskerMaskString(t *testing.T) {
+	masker := newTestMasker(t)
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "query", input: "login?username=admin&password=123456", expected: "login?username=admin&password=******"},
+		{name: "json", input: `{"user":"admin","access_token":"abc.def"}`, expected: `{"user":"admin","access_token":"******"}`},
+		{name: "sql", input: "UPDATE `admin` SET `password`='$2a$10$xyz' WHERE `id` = 1", expected: "UPDATE `admin` SET `password`='******' WHERE `id` = 1"},
+		{name: "bearer", input: "Authorization: Bearer eyJhbGciOi.x.y", expected: "Authorization: ******"},
+		{name: "card", input: "card 4111 1111 1111 1111 paid", expected: "card ************1111 paid"},
+		{name: "not card", input: "order 1234567890123", expected: "order 1234567890123"},
+		{name: "phone", input: "send sms to 13812345678", expected: "send sms to 138****5678"},
+		{name: "plain", input: "admin login succeeded", expected: "admin login succeeded"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := masker.MaskString(tt.input); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestMaskHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewMaskHandler(slog.NewJSONHandler(&buf, nil), newTestMasker(t)))
+
+	logger.With(slog.String("secret", "s3cr3t")).Info("login failed, password=123456",
+		slog.String("username", "admin"),
+		slog.String("phone", "13812345678"),
+		slog.Group("params", slog.String("mfa_code", "654321"), slog.Int("page", 1)),
+		slog.Any("error", errors.New("invalid token=abc")),
+	)
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if record["msg"] != "login failed, password=******" {
+		t.Errorf("Expected masked message, got %v", record["msg"])
+	}
+	if record["secret"] != "******" {
+		t.Errorf("Expected masked secret, got %v", record["secret"])
+	}
+	if record["username"] != "admin" {
+		t.Errorf("Expected username admin, got %v", record["username"])
+	}
+	if record["phone"] != "138****5678" {
+		t.Errorf("Expected masked phone, got %v", record["phone"])
+	}
+	params, _ := record["params"].(map[string]any)
+	if params["mfa_code"] != "******" || params["page"] != float64(1) {
+		t.Errorf("Expected masked group, got %v", params)
+	}
+	if record["error"] != "invalid token=******" {
+		t.Errorf("Expected masked error, got %v", record["error"])
+	}
+	if strings.Contains(buf.String(), "123456") || strings.Contains(buf.String(), "s3cr3t") {
+		t.Errorf("Sensitive data leaked: %s", buf.String())
+	}
+}
+
+func TestNewMaskerInvalidPattern(t *testing.T) {
+	cfg := config.DefaultLogMaskConfig()
+	cfg.Patterns = []string{"("}
+	if _, err := NewMasker(cfg); err == nil {
+		t.Error("Expected error for invalid pattern")
+	}
+}
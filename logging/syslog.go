@@ -0,0 +1,53 @@
+//go:build !windows && !plan9
+
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"log/syslog"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+// SyslogHandler syslog 日志输出
+type SyslogHandler struct {
+	*lineHandler
+	writer *syslog.Writer
+}
+
+// NewSyslogHandler 创建 syslog 日志输出（按日志级别映射 syslog 优先级）
+func NewSyslogHandler(cfg *config.SyslogSinkConfig, tag string) (*SyslogHandler, error) {
+	level, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+	writer, err := syslog.Dial(cfg.Network, cfg.Address, syslog.LOG_INFO|syslog.LOG_LOCAL0, tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+	}
+
+	h := &SyslogHandler{writer: writer}
+	h.lineHandler = newLineHandler(level, h.write)
+	return h, nil
+}
+
+// write 按级别写入 syslog
+func (h *SyslogHandler) write(level slog.Level, t time.Time, line string) error {
+	switch {
+	case level >= slog.LevelError:
+		return h.writer.Err(line)
+	case level >= slog.LevelWarn:
+		return h.writer.Warning(line)
+	case level >= slog.LevelInfo:
+		return h.writer.Info(line)
+	default:
+		return h.writer.Debug(line)
+	}
+}
+
+// Close 关闭 syslog 连接
+func (h *SyslogHandler) Close() error {
+	return h.writer.Close()
+}
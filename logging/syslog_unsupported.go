@@ -0,0 +1,24 @@
+//go:build windows || plan9
+
+package logging
+
+import (
+	"errors"
+
+	"github.com/so68/core/config"
+)
+
+// SyslogHandler syslog 日志输出（当前平台不支持）
+type SyslogHandler struct {
+	*lineHandler
+}
+
+// NewSyslogHandler 当前平台不支持 syslog
+func NewSyslogHandler(cfg *config.SyslogSinkConfig, tag string) (*SyslogHandler, error) {
+	return nil, errors.New("syslog sink is not supported on this platform")
+}
+
+// Close 无操作
+func (h *SyslogHandler) Close() error {
+	return nil
+}
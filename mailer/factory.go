@@ -0,0 +1,32 @@
+package mailer
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/so68/core/config"
+)
+
+// Factory 邮件工厂
+type Factory struct {
+	logger *slog.Logger
+}
+
+// NewFactory 创建邮件工厂
+func NewFactory(logger *slog.Logger) *Factory {
+	return &Factory{
+		logger: logger,
+	}
+}
+
+// CreateMailer 根据配置创建邮件发送器
+func (f *Factory) CreateMailer(cfg *config.MailerConfig) (Mailer, error) {
+	switch cfg.Driver {
+	case "smtp":
+		return NewSMTPMailer(cfg, f.logger)
+	case "log":
+		return NewLogMailer(cfg, f.logger)
+	default:
+		return nil, fmt.Errorf("unsupported mailer driver: %s", cfg.Driver)
+	}
+}
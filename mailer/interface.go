@@ -0,0 +1,23 @@
+package mailer
+
+import (
+	"context"
+)
+
+// Message 邮件消息
+type Message struct {
+	To      []string // 收件人
+	Cc      []string // 抄送
+	Subject string   // 主题
+	Body    string   // 正文
+	HTML    bool     // 正文是否为 HTML
+}
+
+// Mailer 邮件接口
+type Mailer interface {
+	// 发送邮件
+	Send(ctx context.Context, msg *Message) error
+
+	// 连接管理
+	Close() error
+}
@@ -0,0 +1,41 @@
+package mailer
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/so68/core/config"
+)
+
+// LogMailer 仅记录日志的邮件实现（开发环境使用）
+type LogMailer struct {
+	config *config.MailerConfig
+	logger *slog.Logger
+}
+
+// NewLogMailer 创建日志邮件实例
+func NewLogMailer(cfg *config.MailerConfig, logger *slog.Logger) (*LogMailer, error) {
+	return &LogMailer{
+		config: cfg,
+		logger: logger,
+	}, nil
+}
+
+// Send 记录邮件内容
+func (l *LogMailer) Send(ctx context.Context, msg *Message) error {
+	if err := validateMessage(msg); err != nil {
+		return err
+	}
+	l.logger.Info("Mail sent (log driver)",
+		slog.String("to", strings.Join(msg.To, ",")),
+		slog.String("subject", msg.Subject),
+		slog.String("body", msg.Body),
+	)
+	return nil
+}
+
+// Close 关闭连接
+func (l *LogMailer) Close() error {
+	return nil
+}
@@ -0,0 +1,185 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+// SMTPMailer SMTP 邮件实现
+type SMTPMailer struct {
+	config *config.MailerConfig
+	logger *slog.Logger
+}
+
+// NewSMTPMailer 创建 SMTP 邮件实例
+func NewSMTPMailer(cfg *config.MailerConfig, logger *slog.Logger) (*SMTPMailer, error) {
+	if cfg.Host == "" || cfg.Port <= 0 {
+		return nil, fmt.Errorf("invalid smtp address: %s:%d", cfg.Host, cfg.Port)
+	}
+	if cfg.From == "" {
+		return nil, errors.New("smtp from address is required")
+	}
+
+	logger.Info("SMTP mailer initialized",
+		slog.String("host", cfg.Host),
+		slog.Int("port", cfg.Port),
+		slog.Bool("tls", cfg.TLS),
+	)
+
+	return &SMTPMailer{
+		config: cfg,
+		logger: logger,
+	}, nil
+}
+
+// Send 发送邮件
+func (s *SMTPMailer) Send(ctx context.Context, msg *Message) error {
+	if err := validateMessage(msg); err != nil {
+		return err
+	}
+
+	client, err := s.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	// 认证
+	if s.config.Username != "" {
+		if ok, _ := client.Extension("AUTH"); ok {
+			auth := smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+			if err := client.Auth(auth); err != nil {
+				return fmt.Errorf("smtp auth failed: %w", err)
+			}
+		}
+	}
+
+	// 发件人与收件人
+	if err := client.Mail(s.config.From); err != nil {
+		return fmt.Errorf("smtp mail from failed: %w", err)
+	}
+	for _, rcpt := range append(append([]string{}, msg.To...), msg.Cc...) {
+		if err := client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("smtp rcpt %s failed: %w", rcpt, err)
+		}
+	}
+
+	// 写入邮件内容
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp data failed: %w", err)
+	}
+	if _, err := w.Write(buildMessage(s.config, msg)); err != nil {
+		return fmt.Errorf("smtp write failed: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp data close failed: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// dial 建立 SMTP 连接（隐式 TLS 或 STARTTLS）
+func (s *SMTPMailer) dial(ctx context.Context) (*smtp.Client, error) {
+	addr := net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port))
+	dialer := &net.Dialer{Timeout: s.config.Timeout}
+	tlsConfig := &tls.Config{ServerName: s.config.Host}
+
+	var conn net.Conn
+	var err error
+	if s.config.TLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	} else if s.config.Timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(s.config.Timeout))
+	}
+
+	client, err := smtp.NewClient(conn, s.config.Host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create smtp client: %w", err)
+	}
+
+	if !s.config.TLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				client.Close()
+				return nil, fmt.Errorf("smtp starttls failed: %w", err)
+			}
+		}
+	}
+	return client, nil
+}
+
+// Close 关闭连接（每次发送独立建立连接，无需关闭）
+func (s *SMTPMailer) Close() error {
+	return nil
+}
+
+// validateMessage 校验邮件消息
+func validateMessage(msg *Message) error {
+	if msg == nil {
+		return errors.New("mail message is nil")
+	}
+	if len(msg.To) == 0 {
+		return errors.New("mail recipient is required")
+	}
+	for _, addr := range append(append([]string{}, msg.To...), msg.Cc...) {
+		if _, err := mail.ParseAddress(addr); err != nil {
+			return fmt.Errorf("invalid mail address %s: %w", addr, err)
+		}
+	}
+	return nil
+}
+
+// buildMessage 构建 RFC 5322 邮件内容
+func buildMessage(cfg *config.MailerConfig, msg *Message) []byte {
+	from := (&mail.Address{Name: cfg.FromName, Address: cfg.From}).String()
+	contentType := "text/plain"
+	if msg.HTML {
+		contentType = "text/html"
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("From: " + from + "\r\n")
+	buf.WriteString("To: " + strings.Join(msg.To, ", ") + "\r\n")
+	if len(msg.Cc) > 0 {
+		buf.WriteString("Cc: " + strings.Join(msg.Cc, ", ") + "\r\n")
+	}
+	buf.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", msg.Subject) + "\r\n")
+	buf.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: " + contentType + "; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n")
+	buf.WriteString("\r\n")
+
+	// 正文按 76 字符换行
+	encoded := base64.StdEncoding.EncodeToString([]byte(msg.Body))
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+	return buf.Bytes()
+}
@@ -0,0 +1,112 @@
+package mailer
+
+import (
+	"context"
+	"encoding/base64"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/so68/core/config"
+)
+
+/*
+邮件功能测试
+
+本文件用于测试邮件发送相关的功能特性，
+包括邮件工厂、日志驱动、SMTP 消息构建、消息校验等。
+
+运行命令：
+go test -v -run "^Test.*Mail.*$"
+
+测试内容：
+1. 邮件工厂 (CreateMailer)
+2. 日志驱动发送 (LogMailer)
+3. 消息校验 (validateMessage)
+4. SMTP 消息构建 (buildMessage)
+*/
+
+func TestMailerFactory(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	factory := NewFactory(logger)
+
+	tests := []struct {
+		name        string
+		config      *config.MailerConfig
+		expectError bool
+	}{
+		{name: "log_driver", config: &config.MailerConfig{Driver: "log"}, expectError: false},
+		{name: "smtp_driver", config: &config.MailerConfig{Driver: "smtp", Host: "localhost", Port: 25, From: "noreply@example.com"}, expectError: false},
+		{name: "smtp_without_from", config: &config.MailerConfig{Driver: "smtp", Host: "localhost", Port: 25}, expectError: true},
+		{name: "unsupported_driver", config: &config.MailerConfig{Driver: "unknown"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := factory.CreateMailer(tt.config)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("期望出现错误，但没有错误")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("不期望出现错误，但得到: %v", err)
+			}
+			defer m.Close()
+		})
+	}
+}
+
+func TestLogMailer_Send(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	m, _ := NewLogMailer(config.DefaultMailerConfig(), logger)
+
+	if err := m.Send(context.Background(), &Message{To: []string{"admin@example.com"}, Subject: "hello", Body: "world"}); err != nil {
+		t.Errorf("Send failed: %v", err)
+	}
+	if err := m.Send(context.Background(), &Message{Subject: "hello"}); err == nil {
+		t.Error("Expected error for message without recipient")
+	}
+	if err := m.Send(context.Background(), &Message{To: []string{"not-an-address"}}); err == nil {
+		t.Error("Expected error for invalid address")
+	}
+}
+
+func TestMailerBuildMessage(t *testing.T) {
+	cfg := &config.MailerConfig{From: "noreply@example.com", FromName: "System"}
+	msg := &Message{
+		To:      []string{"a@example.com", "b@example.com"},
+		Cc:      []string{"c@example.com"},
+		Subject: "登录提醒",
+		Body:    "您的账号在新设备登录",
+		HTML:    true,
+	}
+
+	data := string(buildMessage(cfg, msg))
+	header, body, ok := strings.Cut(data, "\r\n\r\n")
+	if !ok {
+		t.Fatal("Expected header and body separated by blank line")
+	}
+
+	for _, want := range []string{
+		"To: a@example.com, b@example.com",
+		"Cc: c@example.com",
+		"Content-Type: text/html; charset=UTF-8",
+		"Subject: =?UTF-8?b?",
+		"<noreply@example.com>",
+	} {
+		if !strings.Contains(header, want) {
+			t.Errorf("Expected header to contain %q, got:\n%s", want, header)
+		}
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(strings.TrimSpace(body), "\r\n", ""))
+	if err != nil {
+		t.Fatalf("Failed to decode body: %v", err)
+	}
+	if string(decoded) != msg.Body {
+		t.Errorf("Expected body %q, got %q", msg.Body, string(decoded))
+	}
+}
@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/so68/core/database"
+)
+
+// GORMObserver SQL 执行指标（按数据库、表、操作统计耗时、慢查询数、错误数），name 为数据库名称（主库为 main）
+// 通过 database.SetQueryObserver 设置到数据库，不受数据库日志级别影响
+func (r *Registry) GORMObserver(name string) database.QueryObserver {
+	return &gormObserver{
+		name:     name,
+		duration: r.Histogram("db", "query_duration_seconds", "SQL query latency in seconds.", nil, "db", "table", "operation"),
+		slow:     r.Counter("db", "slow_queries_total", "Total number of slow SQL queries.", "db", "table", "operation"),
+		errors:   r.Counter("db", "query_errors_total", "Total number of failed SQL queries.", "db", "table", "operation"),
+	}
+}
+
+// gormObserver SQL 执行指标
+type gormObserver struct {
+	name     string
+	duration *prometheus.HistogramVec
+	slow     *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+}
+
+// ObserveQuery 记录一次 SQL 执行
+func (o *gormObserver) ObserveQuery(ctx context.Context, info database.QueryInfo) {
+	o.duration.WithLabelValues(o.name, info.Table, info.Operation).Observe(info.Duration.Seconds())
+	if info.Slow {
+		o.slow.WithLabelValues(o.name, info.Table, info.Operation).Inc()
+	}
+	if info.Err != nil {
+		o.errors.WithLabelValues(o.name, info.Table, info.Operation).Inc()
+	}
+}
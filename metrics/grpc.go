@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCUnaryInterceptor gRPC 一元请求指标拦截器（请求数、耗时、处理中请求数）
+func (r *Registry) GRPCUnaryInterceptor() grpc.UnaryServerInterceptor {
+	requests, duration, inflight := r.grpcCollectors()
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		inflight.WithLabelValues().Inc()
+		resp, err := handler(ctx, req)
+		inflight.WithLabelValues().Dec()
+
+		requests.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+		duration.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+		return resp, err
+	}
+}
+
+// GRPCStreamInterceptor gRPC 流请求指标拦截器（耗时为整个流的持续时间）
+func (r *Registry) GRPCStreamInterceptor() grpc.StreamServerInterceptor {
+	requests, duration, inflight := r.grpcCollectors()
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		inflight.WithLabelValues().Inc()
+		err := handler(srv, ss)
+		inflight.WithLabelValues().Dec()
+
+		requests.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+		duration.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// grpcCollectors 创建（或获取已注册的）gRPC 指标，一元与流拦截器共享
+func (r *Registry) grpcCollectors() (*prometheus.CounterVec, *prometheus.HistogramVec, *prometheus.GaugeVec) {
+	requests := r.Counter("grpc", "requests_total", "Total number of gRPC requests.", "method", "code")
+	duration := r.Histogram("grpc", "request_duration_seconds", "gRPC request latency in seconds.", nil, "method")
+	inflight := r.Gauge("grpc", "requests_in_flight", "Number of gRPC requests being served.")
+	return requests, duration, inflight
+}
@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GinMiddleware HTTP 请求指标中间件（请求数、耗时、处理中请求数）
+// 路由标签使用 gin 路由模板（例如 /admin/user/:id），未匹配路由统一记为 unmatched，避免标签基数膨胀
+func (r *Registry) GinMiddleware() gin.HandlerFunc {
+	requests := r.Counter("http", "requests_total", "Total number of HTTP requests.", "method", "route", "status")
+	duration := r.Histogram("http", "request_duration_seconds", "HTTP request latency in seconds.", nil, "method", "route")
+	inflight := r.Gauge("http", "requests_in_flight", "Number of HTTP requests being served.")
+
+	return func(c *gin.Context) {
+		if c.Request.URL.Path == r.config.Path {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		inflight.WithLabelValues().Inc()
+		c.Next()
+		inflight.WithLabelValues().Dec()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		requests.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		duration.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+	}
+}
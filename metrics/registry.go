@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/so68/core/config"
+)
+
+// Registry 应用级指标注册表
+// - 默认注册 Go 运行时与进程指标
+// - 模块通过 Counter/Histogram/Gauge 辅助方法创建指标，统一添加命名空间并注册，重复注册时返回已注册的指标
+type Registry struct {
+	config   *config.MetricsConfig
+	registry *prometheus.Registry
+}
+
+// NewRegistry 创建指标注册表
+func NewRegistry(cfg *config.MetricsConfig) *Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return &Registry{config: cfg, registry: registry}
+}
+
+// Prometheus 获取底层 Prometheus 注册表
+func (r *Registry) Prometheus() *prometheus.Registry {
+	return r.registry
+}
+
+// Register 注册自定义采集器，已注册时返回已存在的采集器
+func (r *Registry) Register(collector prometheus.Collector) (prometheus.Collector, error) {
+	if err := r.registry.Register(collector); err != nil {
+		var registered prometheus.AlreadyRegisteredError
+		if errors.As(err, &registered) {
+			return registered.ExistingCollector, nil
+		}
+		return nil, fmt.Errorf("failed to register metrics collector: %w", err)
+	}
+	return collector, nil
+}
+
+// Counter 创建（或获取已注册的）计数器
+func (r *Registry) Counter(subsystem string, name string, help string, labels ...string) *prometheus.CounterVec {
+	collector := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: r.config.Namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	}, labels)
+	return mustRegister(r, collector)
+}
+
+// Histogram 创建（或获取已注册的）直方图，buckets 为空时使用默认分桶
+func (r *Registry) Histogram(subsystem string, name string, help string, buckets []float64, labels ...string) *prometheus.HistogramVec {
+	if len(buckets) == 0 {
+		buckets = prometheus.DefBuckets
+	}
+	collector := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: r.config.Namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+		Buckets:   buckets,
+	}, labels)
+	return mustRegister(r, collector)
+}
+
+// Gauge 创建（或获取已注册的）仪表盘
+func (r *Registry) Gauge(subsystem string, name string, help string, labels ...string) *prometheus.GaugeVec {
+	collector := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: r.config.Namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	}, labels)
+	return mustRegister(r, collector)
+}
+
+// Handler 指标暴露处理器
+func (r *Registry) Handler() http.Handler {
+	return promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{Registry: r.registry})
+}
+
+// mustRegister 注册指标，已注册时返回已存在的同类型指标，其他错误（例如标签不一致）直接 panic
+func mustRegister[T prometheus.Collector](r *Registry, collector T) T {
+	registered, err := r.Register(collector)
+	if err != nil {
+		panic(err)
+	}
+	existing, ok := registered.(T)
+	if !ok {
+		panic(fmt.Sprintf("metrics collector registered with different type: %T", registered))
+	}
+	return existing
+}
@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/config"
+	"github.com/so68/core/database"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+/*
+指标注册表功能测试
+
+本文件用于测试Registry结构体的各种功能特性，
+包括指标创建、重复注册、指标暴露、HTTP 请求指标中间件等。
+
+运行命令：
+go test -v -run "^Test.*Registry.*$"
+
+测试内容：
+1. 指标创建与重复注册 (Counter, Histogram, Gauge)
+2. 指标暴露 (Handler)
+3. HTTP 请求指标中间件 (GinMiddleware)
+4. gRPC 请求指标拦截器 (GRPCUnaryInterceptor)
+5. SQL 执行指标 (GORMObserver)
+*/
+
+func TestRegistryRegister(t *testing.T) {
+	registry := NewRegistry(config.DefaultMetricsConfig())
+
+	first := registry.Counter("queue", "jobs_total", "Total jobs.", "type")
+	second := registry.Counter("queue", "jobs_total", "Total jobs.", "type")
+	if first != second {
+		t.Error("Expected duplicate registration to return the existing counter")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic when registering with different labels")
+		}
+	}()
+	registry.Counter("queue", "jobs_total", "Total jobs.", "type", "status")
+}
+
+func TestRegistryHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	registry := NewRegistry(config.DefaultMetricsConfig())
+
+	engine := gin.New()
+	engine.Use(registry.GinMiddleware())
+	engine.GET("/users/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+	engine.GET("/metrics", gin.WrapH(registry.Handler()))
+
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/1", nil))
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/not-found", nil))
+
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := recorder.Body.String()
+
+	tests := []string{
+		`app_http_requests_total{method="GET",route="/users/:id",status="200"} 1`,
+		`app_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`app_http_request_duration_seconds_count{method="GET",route="/users/:id"} 1`,
+		`go_goroutines`,
+	}
+	for _, expected := range tests {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Expected metrics to contain %q", expected)
+		}
+	}
+}
+
+func TestRegistryGRPCInterceptor(t *testing.T) {
+	registry := NewRegistry(config.DefaultMetricsConfig())
+	interceptor := registry.GRPCUnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Call"}
+
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+	notFound := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	plain := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, errors.New("boom") }
+	for _, handler := range []grpc.UnaryHandler{ok, ok, notFound, plain} {
+		_, _ = interceptor(context.Background(), nil, info, handler)
+	}
+
+	recorder := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := recorder.Body.String()
+
+	tests := []string{
+		`app_grpc_requests_total{code="OK",method="/test.Service/Call"} 2`,
+		`app_grpc_requests_total{code="NotFound",method="/test.Service/Call"} 1`,
+		`app_grpc_requests_total{code="Unknown",method="/test.Service/Call"} 1`,
+		`app_grpc_request_duration_seconds_count{method="/test.Service/Call"} 4`,
+		`app_grpc_requests_in_flight 0`,
+	}
+	for _, expected := range tests {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Expected metrics to contain %q", expected)
+		}
+	}
+}
+
+func TestRegistryGORMObserver(t *testing.T) {
+	registry := NewRegistry(config.DefaultMetricsConfig())
+	observer := registry.GORMObserver("main")
+
+	ctx := context.Background()
+	observer.ObserveQuery(ctx, database.QueryInfo{Operation: "select", Table: "users", Duration: 20 * time.Millisecond})
+	observer.ObserveQuery(ctx, database.QueryInfo{Operation: "select", Table: "users", Duration: 2 * time.Second, Slow: true})
+	observer.ObserveQuery(ctx, database.QueryInfo{Operation: "insert", Table: "users", Duration: time.Millisecond, Err: errors.New("duplicate")})
+
+	recorder := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := recorder.Body.String()
+	for _, expected := range []string{
+		`app_db_query_duration_seconds_count{db="main",operation="select",table="users"} 2`,
+		`app_db_slow_queries_total{db="main",operation="select",table="users"} 1`,
+		`app_db_query_errors_total{db="main",operation="insert",table="users"} 1`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Expected %s in metrics output", expected)
+		}
+	}
+
+	// 重复创建返回已注册的指标
+	registry.GORMObserver("report").ObserveQuery(ctx, database.QueryInfo{Operation: "delete", Table: "logs"})
+}
@@ -0,0 +1,290 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/so68/core/server"
+	"gorm.io/gorm"
+)
+
+// Module 业务模块
+// 生命周期（按依赖顺序执行）：Init -> Migrate -> Routes -> Start，关闭时按相反顺序 Stop
+type Module interface {
+	// Name 模块名称（唯一）
+	Name() string
+	// Init 初始化模块（创建服务，不应依赖数据表）
+	Init(app *Application) error
+	// Migrate 迁移模块数据表（未配置数据库时跳过）
+	Migrate(db *gorm.DB) error
+	// Routes 注册路由（未启用服务器时跳过）
+	Routes(srv server.Server) error
+	// Start 启动后台任务
+	Start(ctx context.Context) error
+	// Stop 停止后台任务
+	Stop(ctx context.Context) error
+}
+
+// ModuleDependencies 声明模块依赖（可选实现），依赖的模块先于本模块执行各生命周期
+type ModuleDependencies interface {
+	Dependencies() []string
+}
+
+// BaseModule 模块空实现，嵌入后按需覆盖生命周期方法
+type BaseModule struct{}
+
+// Init 初始化模块
+func (BaseModule) Init(app *Application) error { return nil }
+
+// Migrate 迁移模块数据表
+func (BaseModule) Migrate(db *gorm.DB) error { return nil }
+
+// Routes 注册路由
+func (BaseModule) Routes(srv server.Server) error { return nil }
+
+// Start 启动后台任务
+func (BaseModule) Start(ctx context.Context) error { return nil }
+
+// Stop 停止后台任务
+func (BaseModule) Stop(ctx context.Context) error { return nil }
+
+// moduleEntry 已注册模块及其生命周期状态
+type moduleEntry struct {
+	module      Module
+	initialized bool
+	migrated    bool
+	routed      bool
+	started     bool
+}
+
+// RegisterModule 注册模块（模块名称重复时返回错误）
+// 模块在 InitModules（Start 时自动调用）中按依赖顺序初始化
+func (a *Application) RegisterModule(modules ...Module) error {
+	a.modulesMu.Lock()
+	defer a.modulesMu.Unlock()
+
+	for _, m := range modules {
+		if m == nil {
+			return errors.New("module is nil")
+		}
+		name := m.Name()
+		if name == "" {
+			return errors.New("module name is empty")
+		}
+		for _, entry := range a.modules {
+			if entry.module.Name() == name {
+				return fmt.Errorf("module %s already registered", name)
+			}
+		}
+		a.modules = append(a.modules, &moduleEntry{module: m})
+	}
+	return nil
+}
+
+// Module 按名称获取已注册模块，未注册时返回 nil
+func (a *Application) Module(name string) Module {
+	a.modulesMu.Lock()
+	defer a.modulesMu.Unlock()
+
+	for _, entry := range a.modules {
+		if entry.module.Name() == name {
+			return entry.module
+		}
+	}
+	return nil
+}
+
+// Modules 按依赖顺序返回已注册模块
+func (a *Application) Modules() ([]Module, error) {
+	a.modulesMu.Lock()
+	defer a.modulesMu.Unlock()
+
+	entries, err := a.sortModules()
+	if err != nil {
+		return nil, err
+	}
+	modules := make([]Module, len(entries))
+	for i, entry := range entries {
+		modules[i] = entry.module
+	}
+	return modules, nil
+}
+
+// InitModules 按依赖顺序初始化模块、迁移数据表并注册路由（已完成的阶段不会重复执行）
+func (a *Application) InitModules() error {
+	a.modulesMu.Lock()
+	defer a.modulesMu.Unlock()
+
+	entries, err := a.sortModules()
+	if err != nil {
+		return err
+	}
+	if err := a.initModules(entries); err != nil {
+		return err
+	}
+	if err := a.migrateModules(entries); err != nil {
+		return err
+	}
+	if a.Server == nil {
+		return nil
+	}
+	for _, entry := range entries {
+		if entry.routed {
+			continue
+		}
+		if err := entry.module.Routes(a.Server); err != nil {
+			return fmt.Errorf("module %s: routes: %w", entry.module.Name(), err)
+		}
+		entry.routed = true
+	}
+	return nil
+}
+
+// MigrateModules 按依赖顺序初始化模块并迁移数据表（不注册路由，供 migrate 命令使用）
+func (a *Application) MigrateModules() error {
+	a.modulesMu.Lock()
+	defer a.modulesMu.Unlock()
+
+	entries, err := a.sortModules()
+	if err != nil {
+		return err
+	}
+	if err := a.initModules(entries); err != nil {
+		return err
+	}
+	return a.migrateModules(entries)
+}
+
+// initModules 初始化未初始化的模块
+func (a *Application) initModules(entries []*moduleEntry) error {
+	for _, entry := range entries {
+		if entry.initialized {
+			continue
+		}
+		if err := entry.module.Init(a); err != nil {
+			return fmt.Errorf("module %s: init: %w", entry.module.Name(), err)
+		}
+		entry.initialized = true
+	}
+	return nil
+}
+
+// migrateModules 迁移未迁移的模块数据表
+func (a *Application) migrateModules(entries []*moduleEntry) error {
+	if a.DB == nil {
+		return nil
+	}
+	for _, entry := range entries {
+		if entry.migrated {
+			continue
+		}
+		if err := entry.module.Migrate(a.DB.DB()); err != nil {
+			return fmt.Errorf("module %s: migrate: %w", entry.module.Name(), err)
+		}
+		entry.migrated = true
+	}
+	return nil
+}
+
+// startModules 按依赖顺序启动模块
+func (a *Application) startModules(ctx context.Context) error {
+	a.modulesMu.Lock()
+	defer a.modulesMu.Unlock()
+
+	entries, err := a.sortModules()
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.started {
+			continue
+		}
+		if err := entry.module.Start(ctx); err != nil {
+			return fmt.Errorf("module %s: start: %w", entry.module.Name(), err)
+		}
+		entry.started = true
+	}
+	return nil
+}
+
+// stopModules 按依赖的相反顺序停止已启动的模块，返回第一个错误
+func (a *Application) stopModules(ctx context.Context) error {
+	a.modulesMu.Lock()
+	defer a.modulesMu.Unlock()
+
+	entries, err := a.sortModules()
+	if err != nil {
+		// 依赖无法排序时按注册的相反顺序停止
+		entries = a.modules
+	}
+	var firstErr error
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if !entry.started {
+			continue
+		}
+		entry.started = false
+		if err := entry.module.Stop(ctx); err != nil {
+			a.Logger.Error("stop module failed", slog.String("module", entry.module.Name()), slog.Any("error", err))
+			if firstErr == nil {
+				firstErr = fmt.Errorf("module %s: stop: %w", entry.module.Name(), err)
+			}
+		}
+	}
+	return firstErr
+}
+
+// sortModules 按依赖拓扑排序（无依赖关系的模块保持注册顺序），依赖缺失或存在循环时返回错误
+func (a *Application) sortModules() ([]*moduleEntry, error) {
+	byName := make(map[string]*moduleEntry, len(a.modules))
+	for _, entry := range a.modules {
+		byName[entry.module.Name()] = entry
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(a.modules))
+	sorted := make([]*moduleEntry, 0, len(a.modules))
+	var path []string
+
+	var visit func(entry *moduleEntry) error
+	visit = func(entry *moduleEntry) error {
+		name := entry.module.Name()
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("module dependency cycle: %s -> %s", strings.Join(path, " -> "), name)
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		if deps, ok := entry.module.(ModuleDependencies); ok {
+			for _, dep := range deps.Dependencies() {
+				depEntry, ok := byName[dep]
+				if !ok {
+					return fmt.Errorf("module %s depends on unregistered module %s", name, dep)
+				}
+				if err := visit(depEntry); err != nil {
+					return err
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		sorted = append(sorted, entry)
+		return nil
+	}
+
+	for _, entry := range a.modules {
+		if err := visit(entry); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
@@ -0,0 +1,171 @@
+package core
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+/*
+业务模块生命周期功能测试
+
+本文件用于测试Application的模块注册与生命周期管理，
+包括依赖排序、重复注册、缺失依赖、循环依赖以及启动停止顺序。
+
+运行命令：
+go test -v -run "^TestModule.*$"
+
+测试内容：
+1. 重复注册与按名称获取 (RegisterModule, Module)
+2. 依赖排序 (Modules)
+3. 缺失依赖与循环依赖 (Modules)
+4. 启动与停止顺序 (Start, Close)
+*/
+
+// testModule 测试用模块，按调用顺序记录生命周期事件
+type testModule struct {
+	BaseModule
+	name string
+	deps []string
+	log  *[]string
+}
+
+func (m *testModule) Name() string           { return m.name }
+func (m *testModule) Dependencies() []string { return m.deps }
+
+func (m *testModule) Init(app *Application) error {
+	*m.log = append(*m.log, "init:"+m.name)
+	return nil
+}
+
+func (m *testModule) Start(ctx context.Context) error {
+	*m.log = append(*m.log, "start:"+m.name)
+	return nil
+}
+
+func (m *testModule) Stop(ctx context.Context) error {
+	*m.log = append(*m.log, "stop:"+m.name)
+	return nil
+}
+
+// moduleNames 获取模块名称列表
+func moduleNames(modules []Module) string {
+	names := make([]string, len(modules))
+	for i, m := range modules {
+		names[i] = m.Name()
+	}
+	return strings.Join(names, ",")
+}
+
+func TestModuleRegister(t *testing.T) {
+	app := &Application{Logger: slog.Default()}
+	var log []string
+
+	if err := app.RegisterModule(&testModule{name: "a", log: &log}); err != nil {
+		t.Fatalf("RegisterModule failed: %v", err)
+	}
+	if err := app.RegisterModule(&testModule{name: "a", log: &log}); err == nil {
+		t.Error("Expected error for duplicate module")
+	}
+	if err := app.RegisterModule(&testModule{log: &log}); err == nil {
+		t.Error("Expected error for empty module name")
+	}
+	if app.Module("a") == nil {
+		t.Error("Expected module a to be registered")
+	}
+	if app.Module("b") != nil {
+		t.Error("Expected module b to be nil")
+	}
+}
+
+func TestModuleDependencyOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		modules  []*testModule
+		expected string
+		wantErr  string
+	}{
+		{
+			name:     "registration order",
+			modules:  []*testModule{{name: "a"}, {name: "b"}, {name: "c"}},
+			expected: "a,b,c",
+		},
+		{
+			name:     "dependencies first",
+			modules:  []*testModule{{name: "shop", deps: []string{"admin", "payment"}}, {name: "payment", deps: []string{"admin"}}, {name: "admin"}},
+			expected: "admin,payment,shop",
+		},
+		{
+			name:    "missing dependency",
+			modules: []*testModule{{name: "shop", deps: []string{"admin"}}},
+			wantErr: "depends on unregistered module admin",
+		},
+		{
+			name:    "cycle",
+			modules: []*testModule{{name: "a", deps: []string{"b"}}, {name: "b", deps: []string{"c"}}, {name: "c", deps: []string{"a"}}},
+			wantErr: "module dependency cycle: a -> b -> c -> a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &Application{Logger: slog.Default()}
+			var log []string
+			for _, m := range tt.modules {
+				m.log = &log
+				if err := app.RegisterModule(m); err != nil {
+					t.Fatalf("RegisterModule failed: %v", err)
+				}
+			}
+
+			modules, err := app.Modules()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error %q, got %v", tt.wantErr, err)
+				}
+				if err := app.Start(context.Background()); err == nil {
+					t.Error("Expected Start to fail")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Modules failed: %v", err)
+			}
+			if got := moduleNames(modules); got != tt.expected {
+				t.Errorf("Expected order %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestModuleLifecycle(t *testing.T) {
+	app := &Application{Logger: slog.Default()}
+	var log []string
+	if err := app.RegisterModule(
+		&testModule{name: "shop", deps: []string{"admin"}, log: &log},
+		&testModule{name: "admin", log: &log},
+	); err != nil {
+		t.Fatalf("RegisterModule failed: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := app.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	// 重复启动不会再次初始化或启动模块
+	if err := app.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if err := app.Close(ctx); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	// 重复关闭不会再次停止模块
+	if err := app.Close(ctx); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	expected := "init:admin,init:shop,start:admin,start:shop,stop:shop,stop:admin"
+	if got := strings.Join(log, ","); got != expected {
+		t.Errorf("Expected lifecycle %s, got %s", expected, got)
+	}
+}
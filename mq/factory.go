@@ -0,0 +1,34 @@
+package mq
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/so68/core/config"
+)
+
+// Factory 消息队列工厂
+type Factory struct {
+	logger *slog.Logger
+}
+
+// NewFactory 创建消息队列工厂
+func NewFactory(logger *slog.Logger) *Factory {
+	return &Factory{
+		logger: logger,
+	}
+}
+
+// CreateMQ 根据配置创建消息队列
+func (f *Factory) CreateMQ(cfg *config.MQConfig) (MQ, error) {
+	switch cfg.Driver {
+	case "kafka":
+		return NewKafkaMQ(cfg, f.logger)
+	case "nsq":
+		return NewNSQMQ(cfg, f.logger)
+	case "memory":
+		return NewMemoryMQ(cfg, f.logger), nil
+	default:
+		return nil, fmt.Errorf("unsupported mq driver: %s", cfg.Driver)
+	}
+}
@@ -0,0 +1,20 @@
+package mq
+
+import (
+	"context"
+)
+
+// MQ 消息队列接口
+// - 发布方按主题发布消息，订阅方以消费组（config.MQConfig.Group）订阅，同组内每条消息只被一个实例处理
+// - 处理器返回错误时消息在 RetryBackoff 后重新投递，投递次数达到 MaxAttempts 后丢弃并记录日志
+type MQ interface {
+	// 消息发布：payload 为 []byte 时原样发送，其他类型序列化为 JSON；key 为分区键（仅 Kafka 使用）
+	Publish(ctx context.Context, topic string, key string, payload interface{}) error
+
+	// 订阅主题（需在 Start 之前调用，同一主题重复订阅时覆盖）
+	Subscribe(topic string, handler HandlerFunc)
+
+	// 生命周期：Start 加入消费组并开始消费（非阻塞），Stop 等待处理中的消息完成并离开消费组
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
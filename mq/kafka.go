@@ -0,0 +1,337 @@
+package mq
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+// Kafka REST Proxy v2 内容类型
+const (
+	kafkaContentType       = "application/vnd.kafka.v2+json"
+	kafkaBinaryContentType = "application/vnd.kafka.binary.v2+json"
+	kafkaErrorDelay        = time.Second
+)
+
+// KafkaMQ 基于 Kafka 的消息队列（通过 Kafka REST Proxy v2 接入）
+// - 发布使用 binary 格式，key 作为分区键
+// - Start 在 Group 消费组中创建消费者实例并订阅主题，Stop 删除实例以离开消费组
+// - 消息按拉取顺序逐条处理，处理完成（或达到最大投递次数被丢弃）后提交偏移量
+// - 处理失败时在进程内延迟重试，Stop 时未完成的消息不提交偏移量，由消费组重新投递
+type KafkaMQ struct {
+	registry
+	config *config.MQConfig
+	client *http.Client
+	logger *slog.Logger
+
+	mutex    sync.Mutex
+	running  bool
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	instance string // 消费者实例地址（base_uri）
+}
+
+// kafkaRecord 发布或拉取的消息（key/value 为 base64）
+type kafkaRecord struct {
+	Topic     string  `json:"topic,omitempty"`
+	Key       *string `json:"key"`
+	Value     *string `json:"value"`
+	Partition int     `json:"partition,omitempty"`
+	Offset    int64   `json:"offset,omitempty"`
+}
+
+// kafkaError REST Proxy 错误响应
+type kafkaError struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+// NewKafkaMQ 创建 Kafka 消息队列
+func NewKafkaMQ(cfg *config.MQConfig, logger *slog.Logger) (*KafkaMQ, error) {
+	if cfg.Kafka == nil || cfg.Kafka.RestURL == "" {
+		return nil, errors.New("kafka rest url is required")
+	}
+	logger.Info("Kafka mq initialized",
+		slog.String("rest_url", cfg.Kafka.RestURL),
+		slog.String("group", cfg.Group),
+	)
+	return &KafkaMQ{config: cfg, client: &http.Client{Timeout: cfg.Kafka.Timeout + cfg.Kafka.PollTimeout}, logger: logger}, nil
+}
+
+// Publish 发布消息（key 为空时由 Kafka 分配分区）
+func (m *KafkaMQ) Publish(ctx context.Context, topic string, key string, payload interface{}) error {
+	body, err := encodePayload(payload)
+	if err != nil {
+		return err
+	}
+
+	value := base64.StdEncoding.EncodeToString(body)
+	record := kafkaRecord{Value: &value}
+	if key != "" {
+		encodedKey := base64.StdEncoding.EncodeToString([]byte(key))
+		record.Key = &encodedKey
+	}
+	var result struct {
+		Offsets []struct {
+			Partition int     `json:"partition"`
+			Offset    int64   `json:"offset"`
+			Error     *string `json:"error"`
+		} `json:"offsets"`
+	}
+	if err := m.do(ctx, http.MethodPost, m.restURL("/topics/"+url.PathEscape(topic)), kafkaBinaryContentType, map[string]interface{}{"records": []kafkaRecord{record}}, &result); err != nil {
+		return fmt.Errorf("publish message to %s: %w", topic, err)
+	}
+	for _, offset := range result.Offsets {
+		if offset.Error != nil && *offset.Error != "" {
+			return fmt.Errorf("publish message to %s: %s", topic, *offset.Error)
+		}
+	}
+	return nil
+}
+
+// Start 创建消费者实例、订阅已注册的主题并开始拉取（非阻塞，重复调用无效果）
+func (m *KafkaMQ) Start(ctx context.Context) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.running {
+		return nil
+	}
+	topics := m.topics()
+	if len(topics) == 0 {
+		return nil
+	}
+
+	instance, err := m.createInstance(ctx)
+	if err != nil {
+		return err
+	}
+	if err := m.do(ctx, http.MethodPost, instance+"/subscription", kafkaContentType, map[string]interface{}{"topics": topics}, nil); err != nil {
+		_ = m.do(ctx, http.MethodDelete, instance, kafkaContentType, nil, nil)
+		return fmt.Errorf("subscribe kafka topics: %w", err)
+	}
+
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	m.running, m.cancel, m.instance = true, cancel, instance
+	m.wg.Add(1)
+	go m.poll(runCtx, instance)
+	return nil
+}
+
+// Stop 停止拉取，等待处理中的消息完成后删除消费者实例（受 ctx 截止时间约束）
+func (m *KafkaMQ) Stop(ctx context.Context) error {
+	m.mutex.Lock()
+	if !m.running {
+		m.mutex.Unlock()
+		return nil
+	}
+	m.running = false
+	m.cancel()
+	instance := m.instance
+	m.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return fmt.Errorf("kafka mq stop timeout: %w", ctx.Err())
+	}
+
+	// 删除消费者实例，触发消费组重新分配分区
+	if err := m.do(ctx, http.MethodDelete, instance, kafkaContentType, nil, nil); err != nil {
+		return fmt.Errorf("delete kafka consumer instance: %w", err)
+	}
+	return nil
+}
+
+// createInstance 在消费组中创建消费者实例，返回实例地址
+func (m *KafkaMQ) createInstance(ctx context.Context) (string, error) {
+	name, err := kafkaInstanceName()
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		InstanceID string `json:"instance_id"`
+		BaseURI    string `json:"base_uri"`
+	}
+	params := map[string]string{
+		"name":               name,
+		"format":             "binary",
+		"auto.offset.reset":  m.config.Kafka.AutoOffsetReset,
+		"auto.commit.enable": "false",
+	}
+	if err := m.do(ctx, http.MethodPost, m.restURL("/consumers/"+url.PathEscape(m.config.Group)), kafkaContentType, params, &result); err != nil {
+		return "", fmt.Errorf("create kafka consumer instance: %w", err)
+	}
+	if result.BaseURI == "" {
+		return "", errors.New("create kafka consumer instance: empty base_uri")
+	}
+	return strings.TrimRight(result.BaseURI, "/"), nil
+}
+
+// poll 循环拉取并处理消息
+func (m *KafkaMQ) poll(ctx context.Context, instance string) {
+	defer m.wg.Done()
+	for ctx.Err() == nil {
+		var records []kafkaRecord
+		query := "?timeout=" + strconv.FormatInt(m.config.Kafka.PollTimeout.Milliseconds(), 10)
+		if err := m.do(ctx, http.MethodGet, instance+"/records"+query, "", nil, &records); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			m.logger.Warn("kafka fetch records failed", slog.String("group", m.config.Group), slog.Any("error", err))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(kafkaErrorDelay):
+			}
+			continue
+		}
+
+		// 逐条处理，记录每个分区已完成的最大偏移量
+		offsets := make(map[string]kafkaRecord)
+		for _, record := range records {
+			if !m.process(ctx, record) {
+				break
+			}
+			offsets[record.Topic+"/"+strconv.Itoa(record.Partition)] = record
+		}
+		if len(offsets) > 0 {
+			m.commit(instance, offsets)
+		}
+	}
+}
+
+// process 处理单条消息（失败时延迟重试），返回是否可提交偏移量（Stop 时未完成返回 false）
+func (m *KafkaMQ) process(ctx context.Context, record kafkaRecord) bool {
+	msg := &Message{
+		ID:        strconv.Itoa(record.Partition) + "-" + strconv.FormatInt(record.Offset, 10),
+		Topic:     record.Topic,
+		Timestamp: time.Now(),
+	}
+	if record.Key != nil {
+		key, _ := base64.StdEncoding.DecodeString(*record.Key)
+		msg.Key = string(key)
+	}
+	if record.Value != nil {
+		msg.Body, _ = base64.StdEncoding.DecodeString(*record.Value)
+	}
+
+	handler := m.handler(record.Topic)
+	if handler == nil {
+		return true
+	}
+	for {
+		msg.Attempts++
+		err := invoke(context.WithoutCancel(ctx), m.config.MessageTimeout, handler, msg)
+		if err == nil {
+			return true
+		}
+		if msg.Attempts >= m.config.MaxAttempts {
+			m.logger.Error("mq message dropped", slog.String("topic", msg.Topic), slog.String("id", msg.ID), slog.Int("attempts", msg.Attempts), slog.Any("error", err))
+			return true
+		}
+
+		m.logger.Warn("mq message failed, will retry", slog.String("topic", msg.Topic), slog.String("id", msg.ID), slog.Int("attempts", msg.Attempts), slog.Any("error", err))
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(m.config.RetryBackoff):
+		}
+	}
+}
+
+// commit 提交偏移量（REST Proxy 提交时自动加 1，传入已处理消息的偏移量）
+func (m *KafkaMQ) commit(instance string, records map[string]kafkaRecord) {
+	offsets := make([]map[string]interface{}, 0, len(records))
+	for _, record := range records {
+		offsets = append(offsets, map[string]interface{}{"topic": record.Topic, "partition": record.Partition, "offset": record.Offset})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), m.config.Kafka.Timeout)
+	defer cancel()
+	if err := m.do(ctx, http.MethodPost, instance+"/offsets", kafkaContentType, map[string]interface{}{"offsets": offsets}, nil); err != nil {
+		m.logger.Warn("kafka commit offsets failed", slog.String("group", m.config.Group), slog.Any("error", err))
+	}
+}
+
+// restURL 拼接 REST Proxy 地址
+func (m *KafkaMQ) restURL(path string) string {
+	return strings.TrimRight(m.config.Kafka.RestURL, "/") + path
+}
+
+// do 发送请求并解析 JSON 响应（result 为空时忽略响应内容）
+func (m *KafkaMQ) do(ctx context.Context, method string, endpoint string, contentType string, body interface{}, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", kafkaBinaryContentType+", "+kafkaContentType+", application/json")
+	if m.config.Kafka.Username != "" {
+		req.SetBasicAuth(m.config.Kafka.Username, m.config.Kafka.Password)
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var kafkaErr kafkaError
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		if json.Unmarshal(data, &kafkaErr) == nil && kafkaErr.Message != "" {
+			return fmt.Errorf("kafka rest proxy error %d: %s", kafkaErr.ErrorCode, kafkaErr.Message)
+		}
+		return fmt.Errorf("kafka rest proxy status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("decode kafka rest proxy response: %w", err)
+	}
+	return nil
+}
+
+// kafkaInstanceName 生成消费者实例名称（主机名 + 随机串）
+func kafkaInstanceName() (string, error) {
+	buf := make([]byte, 4)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate consumer name: %w", err)
+	}
+	host, _ := os.Hostname()
+	if host == "" {
+		host = "consumer"
+	}
+	return host + "-" + hex.EncodeToString(buf), nil
+}
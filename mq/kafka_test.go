@@ -0,0 +1,217 @@
+package mq
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+/*
+Kafka 消息队列功能测试
+
+本文件使用模拟的 Kafka REST Proxy v2 测试 KafkaMQ，
+包括发布、消费组实例生命周期、拉取消息、失败重试与偏移量提交等。
+
+运行命令：
+go test -v -run "^TestKafka.*$"
+
+测试内容：
+1. 发布消息（binary 格式，key 为分区键）(Publish)
+2. 创建消费者实例并订阅主题 (Start)
+3. 拉取消息、失败重试后提交偏移量 (poll, commit)
+4. 停止时删除消费者实例 (Stop)
+*/
+
+// fakeKafkaRest 模拟 Kafka REST Proxy v2
+type fakeKafkaRest struct {
+	server *httptest.Server
+
+	mutex     sync.Mutex
+	produced  []kafkaRecord
+	consumer  map[string]string
+	topics    []string
+	pending   []kafkaRecord
+	committed []map[string]interface{}
+	deleted   bool
+}
+
+// newFakeKafkaRest 启动模拟 REST Proxy
+func newFakeKafkaRest(t *testing.T) *fakeKafkaRest {
+	t.Helper()
+	f := &fakeKafkaRest{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /topics/{topic}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != kafkaBinaryContentType {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			json.NewEncoder(w).Encode(map[string]interface{}{"error_code": 415, "message": "unsupported content type"})
+			return
+		}
+		var body struct {
+			Records []kafkaRecord `json:"records"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		f.mutex.Lock()
+		for i, record := range body.Records {
+			record.Topic = r.PathValue("topic")
+			record.Offset = int64(len(f.produced) + i)
+			f.produced = append(f.produced, record)
+			f.pending = append(f.pending, record)
+		}
+		offset := len(f.produced) - 1
+		f.mutex.Unlock()
+		json.NewEncoder(w).Encode(map[string]interface{}{"offsets": []map[string]interface{}{{"partition": 0, "offset": offset}}})
+	})
+	mux.HandleFunc("POST /consumers/{group}", func(w http.ResponseWriter, r *http.Request) {
+		var params map[string]string
+		json.NewDecoder(r.Body).Decode(&params)
+		params["group"] = r.PathValue("group")
+		f.mutex.Lock()
+		f.consumer = params
+		f.mutex.Unlock()
+		json.NewEncoder(w).Encode(map[string]string{"instance_id": params["name"], "base_uri": f.server.URL + "/consumers/" + params["group"] + "/instances/" + params["name"]})
+	})
+	mux.HandleFunc("POST /consumers/{group}/instances/{name}/subscription", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Topics []string `json:"topics"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		f.mutex.Lock()
+		f.topics = body.Topics
+		f.mutex.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET /consumers/{group}/instances/{name}/records", func(w http.ResponseWriter, r *http.Request) {
+		f.mutex.Lock()
+		records := f.pending
+		f.pending = nil
+		f.mutex.Unlock()
+		if len(records) == 0 {
+			time.Sleep(10 * time.Millisecond)
+		}
+		w.Header().Set("Content-Type", kafkaBinaryContentType)
+		json.NewEncoder(w).Encode(append([]kafkaRecord{}, records...))
+	})
+	mux.HandleFunc("POST /consumers/{group}/instances/{name}/offsets", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Offsets []map[string]interface{} `json:"offsets"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		f.mutex.Lock()
+		f.committed = append(f.committed, body.Offsets...)
+		f.mutex.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("DELETE /consumers/{group}/instances/{name}", func(w http.ResponseWriter, r *http.Request) {
+		f.mutex.Lock()
+		f.deleted = true
+		f.mutex.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func TestKafkaPublishConsume(t *testing.T) {
+	rest := newFakeKafkaRest(t)
+	cfg := testMQConfig("kafka")
+	cfg.Kafka.RestURL = rest.server.URL
+	cfg.Kafka.PollTimeout = 10 * time.Millisecond
+	m, err := NewKafkaMQ(cfg, testLogger())
+	if err != nil {
+		t.Fatalf("NewKafkaMQ failed: %v", err)
+	}
+
+	var attempts atomic.Int32
+	got := make(chan *Message, 1)
+	m.Subscribe("orders", func(ctx context.Context, msg *Message) error {
+		if attempts.Add(1) < 2 {
+			return errors.New("temporary failure")
+		}
+		got <- msg
+		return nil
+	})
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	rest.mutex.Lock()
+	consumer, topics := rest.consumer, rest.topics
+	rest.mutex.Unlock()
+	if consumer["group"] != "test" || consumer["format"] != "binary" || consumer["auto.commit.enable"] != "false" || consumer["auto.offset.reset"] != "earliest" {
+		t.Errorf("unexpected consumer params: %v", consumer)
+	}
+	if strings.Join(topics, ",") != "orders" {
+		t.Errorf("unexpected subscription: %v", topics)
+	}
+
+	if err := m.Publish(context.Background(), "orders", "user-1", map[string]int{"id": 1}); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	rest.mutex.Lock()
+	produced := rest.produced[0]
+	rest.mutex.Unlock()
+	if key, _ := base64.StdEncoding.DecodeString(*produced.Key); string(key) != "user-1" {
+		t.Errorf("Expected key user-1, got %s", key)
+	}
+
+	select {
+	case msg := <-got:
+		if msg.Key != "user-1" || string(msg.Body) != `{"id":1}` || msg.Attempts != 2 || msg.ID != "0-0" {
+			t.Errorf("unexpected message: %+v", msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for message")
+	}
+	if !waitFor(2*time.Second, func() bool {
+		rest.mutex.Lock()
+		defer rest.mutex.Unlock()
+		return len(rest.committed) == 1
+	}) {
+		t.Fatal("timeout waiting for offset commit")
+	}
+
+	if err := m.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+	rest.mutex.Lock()
+	defer rest.mutex.Unlock()
+	if !rest.deleted {
+		t.Error("Expected consumer instance to be deleted on stop")
+	}
+	if rest.committed[0]["topic"] != "orders" || rest.committed[0]["offset"] != float64(0) {
+		t.Errorf("unexpected committed offsets: %v", rest.committed)
+	}
+}
+
+func TestKafkaPublishError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{"error_code": 40401, "message": "Topic not found."})
+	}))
+	defer server.Close()
+
+	cfg := testMQConfig("kafka")
+	cfg.Kafka.RestURL = server.URL
+	m, err := NewKafkaMQ(cfg, testLogger())
+	if err != nil {
+		t.Fatalf("NewKafkaMQ failed: %v", err)
+	}
+	err = m.Publish(context.Background(), "missing", "", "payload")
+	if err == nil || !strings.Contains(err.Error(), "Topic not found.") {
+		t.Errorf("Expected topic not found error, got %v", err)
+	}
+
+	// 未订阅主题时 Start 不创建消费者实例
+	if err := m.Start(context.Background()); err != nil {
+		t.Errorf("Expected Start without subscriptions to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package mq
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+// memoryBuffer 内存驱动每个主题的消息缓冲大小
+const memoryBuffer = 1024
+
+// MemoryMQ 基于内存的消息队列
+// - 适用于开发与测试环境，消息仅在进程内投递，进程退出时未处理的消息会丢失
+// - 每个主题使用 Concurrency 个 worker 并发处理
+type MemoryMQ struct {
+	registry
+	config *config.MQConfig
+	logger *slog.Logger
+
+	mutex   sync.Mutex
+	queues  map[string]chan *Message
+	running bool
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewMemoryMQ 创建内存消息队列
+func NewMemoryMQ(cfg *config.MQConfig, logger *slog.Logger) *MemoryMQ {
+	logger.Info("Memory mq initialized",
+		slog.String("group", cfg.Group),
+		slog.Int("concurrency", cfg.Concurrency),
+	)
+	return &MemoryMQ{config: cfg, logger: logger, queues: make(map[string]chan *Message)}
+}
+
+// Publish 发布消息（主题缓冲已满时阻塞直到 ctx 结束）
+func (m *MemoryMQ) Publish(ctx context.Context, topic string, key string, payload interface{}) error {
+	body, err := encodePayload(payload)
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Errorf("failed to generate message id: %w", err)
+	}
+
+	msg := &Message{ID: hex.EncodeToString(buf), Topic: topic, Key: key, Body: body, Timestamp: time.Now()}
+	select {
+	case m.queue(topic) <- msg:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("publish message to %s: %w", topic, ctx.Err())
+	}
+}
+
+// Start 为已订阅的主题启动 worker（非阻塞，重复调用无效果）
+func (m *MemoryMQ) Start(ctx context.Context) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.running {
+		return nil
+	}
+
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	m.running, m.cancel = true, cancel
+	for _, topic := range m.topics() {
+		queue := m.queueLocked(topic)
+		for i := 0; i < max(m.config.Concurrency, 1); i++ {
+			m.wg.Add(1)
+			go m.worker(runCtx, topic, queue)
+		}
+	}
+	return nil
+}
+
+// Stop 停止 worker 并等待处理中的消息完成（受 ctx 截止时间约束）
+// - 未处理的消息保留在内存中，再次 Start 后继续处理
+func (m *MemoryMQ) Stop(ctx context.Context) error {
+	m.mutex.Lock()
+	if !m.running {
+		m.mutex.Unlock()
+		return nil
+	}
+	m.running = false
+	m.cancel()
+	m.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("memory mq stop timeout: %w", ctx.Err())
+	}
+}
+
+// queue 获取或创建主题缓冲
+func (m *MemoryMQ) queue(topic string) chan *Message {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.queueLocked(topic)
+}
+
+// queueLocked 获取或创建主题缓冲（调用方需持有锁）
+func (m *MemoryMQ) queueLocked(topic string) chan *Message {
+	queue, ok := m.queues[topic]
+	if !ok {
+		queue = make(chan *Message, memoryBuffer)
+		m.queues[topic] = queue
+	}
+	return queue
+}
+
+// worker 处理主题消息
+func (m *MemoryMQ) worker(ctx context.Context, topic string, queue chan *Message) {
+	defer m.wg.Done()
+	handler := m.handler(topic)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-queue:
+			m.process(ctx, handler, queue, msg)
+		}
+	}
+}
+
+// process 处理单条消息，失败时延迟后重新放入缓冲
+func (m *MemoryMQ) process(ctx context.Context, handler HandlerFunc, queue chan *Message, msg *Message) {
+	msg.Attempts++
+	err := invoke(ctx, m.config.MessageTimeout, handler, msg)
+	if err == nil {
+		return
+	}
+	if msg.Attempts >= m.config.MaxAttempts {
+		m.logger.Error("mq message dropped", slog.String("topic", msg.Topic), slog.String("id", msg.ID), slog.Int("attempts", msg.Attempts), slog.Any("error", err))
+		return
+	}
+
+	m.logger.Warn("mq message failed, will retry", slog.String("topic", msg.Topic), slog.String("id", msg.ID), slog.Int("attempts", msg.Attempts), slog.Any("error", err))
+	time.AfterFunc(m.config.RetryBackoff, func() {
+		select {
+		case queue <- msg:
+		default:
+			m.logger.Error("mq message dropped: buffer full", slog.String("topic", msg.Topic), slog.String("id", msg.ID))
+		}
+	})
+}
@@ -0,0 +1,153 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+/*
+内存消息队列功能测试
+
+本文件用于测试MemoryMQ结构体的各种功能特性，
+包括消息发布订阅、JSON 解析、失败重试、超过最大投递次数丢弃与停止后继续消费等。
+
+运行命令：
+go test -v -run "^TestMemoryMQ.*$"
+
+测试内容：
+1. 消息发布与订阅 (Publish, Subscribe, Start, Bind)
+2. 失败重试与最大投递次数 (MaxAttempts, RetryBackoff)
+3. 停止后保留未处理消息，再次启动继续消费 (Stop, Start)
+*/
+
+// testMQConfig 创建测试用消息队列配置
+func testMQConfig(driver string) *config.MQConfig {
+	cfg := &config.MQConfig{
+		Enabled:      true,
+		Driver:       driver,
+		Group:        "test",
+		Concurrency:  2,
+		MaxAttempts:  3,
+		RetryBackoff: 10 * time.Millisecond,
+	}
+	cfg.SetDefaults()
+	return cfg
+}
+
+// testLogger 测试日志
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// waitFor 轮询等待条件成立
+func waitFor(timeout time.Duration, cond func() bool) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return cond()
+}
+
+func TestMemoryMQPublishSubscribe(t *testing.T) {
+	m := NewMemoryMQ(testMQConfig("memory"), testLogger())
+	defer m.Stop(context.Background())
+
+	type order struct {
+		ID int `json:"id"`
+	}
+	got := make(chan int, 1)
+	m.Subscribe("order.created", func(ctx context.Context, msg *Message) error {
+		var o order
+		if err := msg.Bind(&o); err != nil {
+			return err
+		}
+		got <- o.ID
+		return nil
+	})
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if err := m.Publish(context.Background(), "order.created", "", &order{ID: 7}); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	select {
+	case id := <-got:
+		if id != 7 {
+			t.Errorf("Expected order 7, got %d", id)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for message")
+	}
+}
+
+func TestMemoryMQRetry(t *testing.T) {
+	m := NewMemoryMQ(testMQConfig("memory"), testLogger())
+	defer m.Stop(context.Background())
+
+	var flaky, failing atomic.Int32
+	m.Subscribe("flaky", func(ctx context.Context, msg *Message) error {
+		if flaky.Add(1) < 2 {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+	m.Subscribe("failing", func(ctx context.Context, msg *Message) error {
+		failing.Add(1)
+		panic("boom")
+	})
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	_ = m.Publish(context.Background(), "flaky", "", []byte("payload"))
+	_ = m.Publish(context.Background(), "failing", "", []byte("payload"))
+
+	if !waitFor(2*time.Second, func() bool { return flaky.Load() == 2 && failing.Load() == 3 }) {
+		t.Fatalf("Expected flaky=2 failing=3, got flaky=%d failing=%d", flaky.Load(), failing.Load())
+	}
+	// 超过最大投递次数后不再重试
+	time.Sleep(50 * time.Millisecond)
+	if failing.Load() != 3 {
+		t.Errorf("Expected failing message to be dropped after 3 attempts, got %d", failing.Load())
+	}
+}
+
+func TestMemoryMQStopStart(t *testing.T) {
+	m := NewMemoryMQ(testMQConfig("memory"), testLogger())
+
+	var count atomic.Int32
+	m.Subscribe("audit", func(ctx context.Context, msg *Message) error {
+		count.Add(1)
+		return nil
+	})
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if err := m.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+
+	// 停止期间发布的消息在再次启动后处理
+	_ = m.Publish(context.Background(), "audit", "", "stopped")
+	time.Sleep(30 * time.Millisecond)
+	if count.Load() != 0 {
+		t.Fatalf("Expected no delivery while stopped, got %d", count.Load())
+	}
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer m.Stop(context.Background())
+	if !waitFor(2*time.Second, func() bool { return count.Load() == 1 }) {
+		t.Fatalf("Expected 1 delivery after restart, got %d", count.Load())
+	}
+}
@@ -0,0 +1,90 @@
+package mq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Message 消息
+type Message struct {
+	ID        string    `json:"id"`        // 消息ID（NSQ 为消息ID，Kafka 为 分区-偏移量）
+	Topic     string    `json:"topic"`     // 主题
+	Key       string    `json:"key"`       // 分区键（仅 Kafka）
+	Body      []byte    `json:"body"`      // 消息内容
+	Attempts  int       `json:"attempts"`  // 当前投递次数（从 1 开始）
+	Timestamp time.Time `json:"timestamp"` // 发布时间（Kafka 为拉取时间）
+}
+
+// HandlerFunc 消息处理函数
+type HandlerFunc func(ctx context.Context, msg *Message) error
+
+// Bind 将 JSON 消息内容解析到目标结构体
+func (m *Message) Bind(target interface{}) error {
+	return json.Unmarshal(m.Body, target)
+}
+
+// encodePayload 编码消息内容（[]byte 原样发送，其他类型序列化为 JSON）
+func encodePayload(payload interface{}) ([]byte, error) {
+	if data, ok := payload.([]byte); ok {
+		return data, nil
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize message payload: %w", err)
+	}
+	return data, nil
+}
+
+// invoke 调用处理器（设置超时并将 panic 转换为错误）
+func invoke(ctx context.Context, timeout time.Duration, handler HandlerFunc, msg *Message) (err error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("message handler panic: %v", r)
+		}
+	}()
+	return handler(ctx, msg)
+}
+
+// registry 主题处理器注册表
+type registry struct {
+	mutex    sync.RWMutex
+	handlers map[string]HandlerFunc
+}
+
+// Subscribe 订阅主题（同一主题重复订阅时覆盖）
+func (r *registry) Subscribe(topic string, handler HandlerFunc) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.handlers == nil {
+		r.handlers = make(map[string]HandlerFunc)
+	}
+	r.handlers[topic] = handler
+}
+
+// handler 获取主题处理器
+func (r *registry) handler(topic string) HandlerFunc {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.handlers[topic]
+}
+
+// topics 获取已订阅的主题（按名称排序）
+func (r *registry) topics() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	topics := make([]string, 0, len(r.handlers))
+	for topic := range r.handlers {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
@@ -0,0 +1,469 @@
+package mq
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+// NSQ 协议常量
+const (
+	nsqMagic          = "  V2"
+	nsqFrameResponse  = 0
+	nsqFrameError     = 1
+	nsqFrameMessage   = 2
+	nsqHeartbeat      = "_heartbeat_"
+	nsqCloseWait      = "CLOSE_WAIT"
+	nsqReconnectDelay = 3 * time.Second
+	nsqMaxFrameSize   = 16 << 20
+)
+
+// NSQMQ 基于 NSQ 的消息队列（TCP 协议）
+// - 发布使用到 nsqd 的单连接，出错后下次发布时重连
+// - 消费以 Group 作为 channel 订阅，配置 nsqlookupd 时定期发现新的 nsqd
+// - 处理失败时发送 REQ 由 nsqd 延迟重新投递，投递次数达到 MaxAttempts 后 FIN 丢弃
+type NSQMQ struct {
+	registry
+	config *config.MQConfig
+	client *http.Client
+	logger *slog.Logger
+
+	pubMutex sync.Mutex
+	pubConn  *nsqConn
+
+	mutex   sync.Mutex
+	running bool
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewNSQMQ 创建 NSQ 消息队列
+func NewNSQMQ(cfg *config.MQConfig, logger *slog.Logger) (*NSQMQ, error) {
+	if cfg.NSQ == nil || (cfg.NSQ.Addr == "" && len(cfg.NSQ.LookupdAddrs) == 0) {
+		return nil, errors.New("nsq addr or lookupd addrs is required")
+	}
+	logger.Info("NSQ mq initialized",
+		slog.String("addr", cfg.NSQ.Addr),
+		slog.Any("lookupd_addrs", cfg.NSQ.LookupdAddrs),
+		slog.String("channel", cfg.Group),
+	)
+	return &NSQMQ{config: cfg, client: &http.Client{Timeout: cfg.NSQ.DialTimeout}, logger: logger}, nil
+}
+
+// Publish 发布消息（NSQ 不支持分区键，忽略 key）
+func (m *NSQMQ) Publish(ctx context.Context, topic string, key string, payload interface{}) error {
+	if m.config.NSQ.Addr == "" {
+		return errors.New("nsq addr is required for publishing")
+	}
+	body, err := encodePayload(payload)
+	if err != nil {
+		return err
+	}
+
+	m.pubMutex.Lock()
+	defer m.pubMutex.Unlock()
+	if m.pubConn == nil {
+		conn, err := dialNSQ(ctx, m.config.NSQ.Addr, m.config.NSQ.DialTimeout)
+		if err != nil {
+			return err
+		}
+		m.pubConn = conn
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(m.config.NSQ.DialTimeout)
+	}
+	_ = m.pubConn.conn.SetDeadline(deadline)
+	err = m.pubConn.command("PUB", []string{topic}, body)
+	if err == nil {
+		err = m.pubConn.readResponse()
+	}
+	if err == nil {
+		_ = m.pubConn.conn.SetDeadline(time.Time{})
+		return nil
+	}
+	// 出错后关闭连接，下次发布时重连
+	m.pubConn.Close()
+	m.pubConn = nil
+	return fmt.Errorf("publish message to %s: %w", topic, err)
+}
+
+// Start 为已订阅的主题连接 nsqd 并开始消费（非阻塞，重复调用无效果）
+func (m *NSQMQ) Start(ctx context.Context) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.running {
+		return nil
+	}
+
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	m.running, m.cancel = true, cancel
+	for _, topic := range m.topics() {
+		m.wg.Add(1)
+		go m.discover(runCtx, topic)
+	}
+	return nil
+}
+
+// Stop 发送 CLS 停止接收消息，等待处理中的消息完成后关闭连接（受 ctx 截止时间约束）
+func (m *NSQMQ) Stop(ctx context.Context) error {
+	m.mutex.Lock()
+	if !m.running {
+		m.mutex.Unlock()
+		m.closePublisher()
+		return nil
+	}
+	m.running = false
+	m.cancel()
+	m.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+	defer m.closePublisher()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("nsq mq stop timeout: %w", ctx.Err())
+	}
+}
+
+// closePublisher 关闭发布连接
+func (m *NSQMQ) closePublisher() {
+	m.pubMutex.Lock()
+	defer m.pubMutex.Unlock()
+	if m.pubConn != nil {
+		m.pubConn.Close()
+		m.pubConn = nil
+	}
+}
+
+// discover 发现主题所在的 nsqd 并为每个 nsqd 启动消费（未配置 lookupd 时仅连接 Addr）
+func (m *NSQMQ) discover(ctx context.Context, topic string) {
+	defer m.wg.Done()
+
+	connected := make(map[string]bool)
+	for {
+		for _, addr := range m.nsqdAddrs(ctx, topic) {
+			if connected[addr] {
+				continue
+			}
+			connected[addr] = true
+			m.wg.Add(1)
+			go m.consume(ctx, topic, addr)
+		}
+		if len(m.config.NSQ.LookupdAddrs) == 0 {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(m.config.NSQ.LookupInterval):
+		}
+	}
+}
+
+// nsqdAddrs 获取主题所在的 nsqd TCP 地址
+func (m *NSQMQ) nsqdAddrs(ctx context.Context, topic string) []string {
+	if len(m.config.NSQ.LookupdAddrs) == 0 {
+		return []string{m.config.NSQ.Addr}
+	}
+
+	addrs := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, lookupd := range m.config.NSQ.LookupdAddrs {
+		producers, err := m.lookup(ctx, lookupd, topic)
+		if err != nil {
+			m.logger.Warn("nsq lookup failed", slog.String("lookupd", lookupd), slog.String("topic", topic), slog.Any("error", err))
+			continue
+		}
+		for _, addr := range producers {
+			if !seen[addr] {
+				seen[addr] = true
+				addrs = append(addrs, addr)
+			}
+		}
+	}
+	return addrs
+}
+
+// nsqLookupProducer nsqlookupd 返回的 nsqd 信息
+type nsqLookupProducer struct {
+	BroadcastAddress string `json:"broadcast_address"`
+	TCPPort          int    `json:"tcp_port"`
+}
+
+// nsqLookupResponse nsqlookupd /lookup 响应（兼容旧版本包裹在 data 中的格式）
+type nsqLookupResponse struct {
+	Producers []nsqLookupProducer `json:"producers"`
+	Data      *struct {
+		Producers []nsqLookupProducer `json:"producers"`
+	} `json:"data"`
+}
+
+// lookup 通过 nsqlookupd 查询主题所在的 nsqd
+func (m *NSQMQ) lookup(ctx context.Context, lookupd string, topic string) ([]string, error) {
+	if !strings.Contains(lookupd, "://") {
+		lookupd = "http://" + lookupd
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(lookupd, "/")+"/lookup?topic="+url.QueryEscape(topic), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// 主题尚未创建
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("nsqlookupd status %d", resp.StatusCode)
+	}
+	var result nsqLookupResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode nsqlookupd response: %w", err)
+	}
+	producers := result.Producers
+	if result.Data != nil {
+		producers = result.Data.Producers
+	}
+
+	addrs := make([]string, 0, len(producers))
+	for _, producer := range producers {
+		addrs = append(addrs, net.JoinHostPort(producer.BroadcastAddress, strconv.Itoa(producer.TCPPort)))
+	}
+	return addrs, nil
+}
+
+// consume 持续消费指定 nsqd 上的主题，连接断开后自动重连
+func (m *NSQMQ) consume(ctx context.Context, topic string, addr string) {
+	defer m.wg.Done()
+	for {
+		err := m.consumeConn(ctx, topic, addr)
+		if ctx.Err() != nil {
+			return
+		}
+		m.logger.Warn("nsq consumer disconnected", slog.String("addr", addr), slog.String("topic", topic), slog.Any("error", err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(nsqReconnectDelay):
+		}
+	}
+}
+
+// consumeConn 建立连接并订阅，处理消息直到连接断开或 ctx 结束
+func (m *NSQMQ) consumeConn(ctx context.Context, topic string, addr string) error {
+	conn, err := dialNSQ(ctx, addr, m.config.NSQ.DialTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := conn.command("SUB", []string{topic, m.config.Group}, nil); err != nil {
+		return err
+	}
+	if err := conn.readResponse(); err != nil {
+		return fmt.Errorf("subscribe %s/%s: %w", topic, m.config.Group, err)
+	}
+	maxInFlight := m.config.NSQ.MaxInFlight
+	if maxInFlight <= 0 {
+		maxInFlight = max(m.config.Concurrency, 1)
+	}
+	if err := conn.command("RDY", []string{strconv.Itoa(maxInFlight)}, nil); err != nil {
+		return err
+	}
+
+	// ctx 结束时发送 CLS，nsqd 停止投递并返回 CLOSE_WAIT
+	closed := make(chan struct{})
+	defer close(closed)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.command("CLS", nil, nil)
+			_ = conn.conn.SetReadDeadline(time.Now().Add(m.config.MessageTimeout))
+		case <-closed:
+		}
+	}()
+
+	handler := m.handler(topic)
+	var inflight sync.WaitGroup
+	defer inflight.Wait()
+	for {
+		frameType, data, err := conn.readFrame()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		switch frameType {
+		case nsqFrameResponse:
+			switch string(data) {
+			case nsqHeartbeat:
+				if err := conn.command("NOP", nil, nil); err != nil {
+					return err
+				}
+			case nsqCloseWait:
+				return nil
+			}
+		case nsqFrameError:
+			m.logger.Warn("nsq error frame", slog.String("addr", addr), slog.String("topic", topic), slog.String("error", string(data)))
+		case nsqFrameMessage:
+			msg, err := decodeNSQMessage(topic, data)
+			if err != nil {
+				return err
+			}
+			inflight.Add(1)
+			go func() {
+				defer inflight.Done()
+				m.process(ctx, conn, handler, msg)
+			}()
+		}
+	}
+}
+
+// process 处理单条消息并回复 FIN/REQ
+func (m *NSQMQ) process(ctx context.Context, conn *nsqConn, handler HandlerFunc, msg *Message) {
+	err := invoke(context.WithoutCancel(ctx), m.config.MessageTimeout, handler, msg)
+	if err == nil {
+		if err := conn.command("FIN", []string{msg.ID}, nil); err != nil {
+			m.logger.Warn("nsq finish message failed", slog.String("topic", msg.Topic), slog.String("id", msg.ID), slog.Any("error", err))
+		}
+		return
+	}
+	if msg.Attempts >= m.config.MaxAttempts {
+		m.logger.Error("mq message dropped", slog.String("topic", msg.Topic), slog.String("id", msg.ID), slog.Int("attempts", msg.Attempts), slog.Any("error", err))
+		_ = conn.command("FIN", []string{msg.ID}, nil)
+		return
+	}
+
+	m.logger.Warn("mq message failed, will retry", slog.String("topic", msg.Topic), slog.String("id", msg.ID), slog.Int("attempts", msg.Attempts), slog.Any("error", err))
+	if err := conn.command("REQ", []string{msg.ID, strconv.FormatInt(m.config.RetryBackoff.Milliseconds(), 10)}, nil); err != nil {
+		m.logger.Warn("nsq requeue message failed", slog.String("topic", msg.Topic), slog.String("id", msg.ID), slog.Any("error", err))
+	}
+}
+
+// decodeNSQMessage 解析消息帧：8 字节时间戳(纳秒) + 2 字节投递次数 + 16 字节消息ID + 消息内容
+func decodeNSQMessage(topic string, data []byte) (*Message, error) {
+	if len(data) < 26 {
+		return nil, fmt.Errorf("invalid nsq message frame size %d", len(data))
+	}
+	return &Message{
+		ID:        string(data[10:26]),
+		Topic:     topic,
+		Body:      data[26:],
+		Attempts:  int(binary.BigEndian.Uint16(data[8:10])),
+		Timestamp: time.Unix(0, int64(binary.BigEndian.Uint64(data[:8]))),
+	}, nil
+}
+
+// nsqConn nsqd TCP 连接（写入加锁，读取仅由单个协程进行）
+type nsqConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	mutex  sync.Mutex
+}
+
+// dialNSQ 连接 nsqd 并发送协议版本
+func dialNSQ(ctx context.Context, addr string, timeout time.Duration) (*nsqConn, error) {
+	dialer := &net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("dial nsqd %s: %w", addr, err)
+	}
+	if _, err := conn.Write([]byte(nsqMagic)); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("write nsq magic: %w", err)
+	}
+	return &nsqConn{conn: conn, reader: bufio.NewReader(conn)}, nil
+}
+
+// command 发送命令：NAME param1 param2\n，带消息体时追加 4 字节长度与内容
+func (c *nsqConn) command(name string, params []string, body []byte) error {
+	var buf bytes.Buffer
+	buf.WriteString(name)
+	for _, param := range params {
+		buf.WriteByte(' ')
+		buf.WriteString(param)
+	}
+	buf.WriteByte('\n')
+	if body != nil {
+		_ = binary.Write(&buf, binary.BigEndian, int32(len(body)))
+		buf.Write(body)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	_, err := c.conn.Write(buf.Bytes())
+	return err
+}
+
+// readFrame 读取帧：4 字节长度 + 4 字节帧类型 + 数据
+func (c *nsqConn) readFrame() (int32, []byte, error) {
+	var size int32
+	if err := binary.Read(c.reader, binary.BigEndian, &size); err != nil {
+		return 0, nil, err
+	}
+	if size < 4 || size > nsqMaxFrameSize {
+		return 0, nil, fmt.Errorf("invalid nsq frame size %d", size)
+	}
+	frame := make([]byte, size)
+	if _, err := io.ReadFull(c.reader, frame); err != nil {
+		return 0, nil, err
+	}
+	return int32(binary.BigEndian.Uint32(frame[:4])), frame[4:], nil
+}
+
+// readResponse 读取命令响应（跳过心跳），OK 以外的响应视为错误
+func (c *nsqConn) readResponse() error {
+	for {
+		frameType, data, err := c.readFrame()
+		if err != nil {
+			return err
+		}
+		switch {
+		case frameType == nsqFrameResponse && string(data) == nsqHeartbeat:
+			if err := c.command("NOP", nil, nil); err != nil {
+				return err
+			}
+		case frameType == nsqFrameResponse && string(data) == "OK":
+			return nil
+		case frameType == nsqFrameError:
+			return fmt.Errorf("nsqd error: %s", data)
+		default:
+			return fmt.Errorf("unexpected nsq frame type %d: %s", frameType, data)
+		}
+	}
+}
+
+// Close 关闭连接
+func (c *nsqConn) Close() {
+	_ = c.conn.Close()
+}
@@ -0,0 +1,313 @@
+package mq
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+/*
+NSQ 消息队列功能测试
+
+本文件使用模拟的 nsqd（TCP 协议）与 nsqlookupd（HTTP）测试 NSQMQ，
+包括发布、订阅、心跳、FIN/REQ 回复、通过 nsqlookupd 发现 nsqd 与优雅停止等。
+
+运行命令：
+go test -v -run "^TestNSQ.*$"
+
+测试内容：
+1. 发布消息 (PUB) 与消费确认 (SUB, RDY, FIN)
+2. 心跳回复 (NOP)
+3. 处理失败重新投递与最大投递次数 (REQ)
+4. 通过 nsqlookupd 发现 nsqd (lookup)
+5. 停止时发送 CLS (Stop)
+*/
+
+// fakeNSQD 模拟 nsqd：PUB 的消息投递给已 SUB 且 RDY 的连接，REQ 时投递次数加 1 后重新投递
+type fakeNSQD struct {
+	listener net.Listener
+
+	mutex     sync.Mutex
+	published []string        // 已发布的消息内容
+	finished  []string        // FIN 的消息ID
+	requeued  []string        // REQ 的消息ID
+	commands  []string        // 收到的命令
+	subs      []*fakeNSQDConn // 订阅的连接
+	messages  map[string]*fakeNSQDMessage
+	nextID    int
+}
+
+// fakeNSQDMessage 模拟消息
+type fakeNSQDMessage struct {
+	id       string
+	body     []byte
+	attempts uint16
+}
+
+// fakeNSQDConn 模拟连接
+type fakeNSQDConn struct {
+	conn  net.Conn
+	mutex sync.Mutex
+}
+
+// writeFrame 写入帧
+func (c *fakeNSQDConn) writeFrame(frameType int32, data []byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	_ = binary.Write(c.conn, binary.BigEndian, int32(len(data)+4))
+	_ = binary.Write(c.conn, binary.BigEndian, frameType)
+	_, _ = c.conn.Write(data)
+}
+
+// newFakeNSQD 启动模拟 nsqd
+func newFakeNSQD(t *testing.T) *fakeNSQD {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen failed: %v", err)
+	}
+	d := &fakeNSQD{listener: listener, messages: make(map[string]*fakeNSQDMessage)}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go d.serve(&fakeNSQDConn{conn: conn})
+		}
+	}()
+	return d
+}
+
+// serve 处理连接命令
+func (d *fakeNSQD) serve(c *fakeNSQDConn) {
+	defer c.conn.Close()
+	reader := bufio.NewReader(c.conn)
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(reader, magic); err != nil || string(magic) != nsqMagic {
+		return
+	}
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		params := strings.Fields(line)
+		d.mutex.Lock()
+		d.commands = append(d.commands, params[0])
+		d.mutex.Unlock()
+
+		switch params[0] {
+		case "PUB":
+			var size int32
+			_ = binary.Read(reader, binary.BigEndian, &size)
+			body := make([]byte, size)
+			_, _ = io.ReadFull(reader, body)
+			d.mutex.Lock()
+			d.published = append(d.published, string(body))
+			d.nextID++
+			msg := &fakeNSQDMessage{id: fmt.Sprintf("%016d", d.nextID), body: body}
+			d.messages[msg.id] = msg
+			subs := append([]*fakeNSQDConn{}, d.subs...)
+			d.mutex.Unlock()
+			c.writeFrame(nsqFrameResponse, []byte("OK"))
+			for _, sub := range subs {
+				d.deliver(sub, msg)
+			}
+		case "SUB":
+			d.mutex.Lock()
+			d.subs = append(d.subs, c)
+			d.mutex.Unlock()
+			c.writeFrame(nsqFrameResponse, []byte("OK"))
+		case "RDY":
+			// 连接就绪后发送一次心跳
+			c.writeFrame(nsqFrameResponse, []byte(nsqHeartbeat))
+		case "FIN":
+			d.mutex.Lock()
+			d.finished = append(d.finished, params[1])
+			d.mutex.Unlock()
+		case "REQ":
+			d.mutex.Lock()
+			d.requeued = append(d.requeued, params[1])
+			msg := d.messages[params[1]]
+			d.mutex.Unlock()
+			d.deliver(c, msg)
+		case "CLS":
+			c.writeFrame(nsqFrameResponse, []byte(nsqCloseWait))
+			return
+		}
+	}
+}
+
+// deliver 投递消息（投递次数加 1）
+func (d *fakeNSQD) deliver(c *fakeNSQDConn, msg *fakeNSQDMessage) {
+	d.mutex.Lock()
+	msg.attempts++
+	frame := make([]byte, 26+len(msg.body))
+	binary.BigEndian.PutUint64(frame[:8], uint64(time.Now().UnixNano()))
+	binary.BigEndian.PutUint16(frame[8:10], msg.attempts)
+	copy(frame[10:26], msg.id)
+	copy(frame[26:], msg.body)
+	d.mutex.Unlock()
+	c.writeFrame(nsqFrameMessage, frame)
+}
+
+// snapshot 获取记录的副本
+func (d *fakeNSQD) snapshot() (published, finished, requeued, commands []string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return append([]string{}, d.published...), append([]string{}, d.finished...), append([]string{}, d.requeued...), append([]string{}, d.commands...)
+}
+
+// subscribed 是否已有连接订阅
+func (d *fakeNSQD) subscribed() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return len(d.subs) > 0
+}
+
+func TestNSQPublishConsume(t *testing.T) {
+	nsqd := newFakeNSQD(t)
+	cfg := testMQConfig("nsq")
+	cfg.NSQ.Addr = nsqd.listener.Addr().String()
+	m, err := NewNSQMQ(cfg, testLogger())
+	if err != nil {
+		t.Fatalf("NewNSQMQ failed: %v", err)
+	}
+
+	var mutex sync.Mutex
+	attempts := make([]int, 0)
+	m.Subscribe("orders", func(ctx context.Context, msg *Message) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		attempts = append(attempts, msg.Attempts)
+		if string(msg.Body) == `{"id":2}` && msg.Attempts < 2 {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if !waitFor(2*time.Second, nsqd.subscribed) {
+		t.Fatal("consumer did not subscribe")
+	}
+
+	for i := 1; i <= 2; i++ {
+		if err := m.Publish(context.Background(), "orders", "", map[string]int{"id": i}); err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+	if !waitFor(2*time.Second, func() bool {
+		_, finished, _, _ := nsqd.snapshot()
+		return len(finished) == 2
+	}) {
+		t.Fatal("timeout waiting for messages to finish")
+	}
+
+	published, _, requeued, commands := nsqd.snapshot()
+	if len(published) != 2 || published[0] != `{"id":1}` {
+		t.Errorf("unexpected published messages: %v", published)
+	}
+	if len(requeued) != 1 || requeued[0] != fmt.Sprintf("%016d", 2) {
+		t.Errorf("Expected message 2 to be requeued once, got %v", requeued)
+	}
+	if !strings.Contains(strings.Join(commands, ","), "NOP") {
+		t.Errorf("Expected heartbeat to be answered with NOP, got %v", commands)
+	}
+
+	if err := m.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+	_, _, _, commands = nsqd.snapshot()
+	if !strings.Contains(strings.Join(commands, ","), "CLS") {
+		t.Errorf("Expected CLS on stop, got %v", commands)
+	}
+}
+
+func TestNSQMaxAttempts(t *testing.T) {
+	nsqd := newFakeNSQD(t)
+	cfg := testMQConfig("nsq")
+	cfg.NSQ.Addr = nsqd.listener.Addr().String()
+	m, err := NewNSQMQ(cfg, testLogger())
+	if err != nil {
+		t.Fatalf("NewNSQMQ failed: %v", err)
+	}
+	defer m.Stop(context.Background())
+
+	m.Subscribe("orders", func(ctx context.Context, msg *Message) error {
+		return errors.New("always fails")
+	})
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if !waitFor(2*time.Second, nsqd.subscribed) {
+		t.Fatal("consumer did not subscribe")
+	}
+	if err := m.Publish(context.Background(), "orders", "", []byte("raw")); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	// 投递 3 次后 FIN 丢弃：REQ 2 次，FIN 1 次
+	if !waitFor(2*time.Second, func() bool {
+		_, finished, _, _ := nsqd.snapshot()
+		return len(finished) == 1
+	}) {
+		t.Fatal("timeout waiting for message to be dropped")
+	}
+	_, _, requeued, _ := nsqd.snapshot()
+	if len(requeued) != 2 {
+		t.Errorf("Expected 2 requeues before drop, got %d", len(requeued))
+	}
+}
+
+func TestNSQLookup(t *testing.T) {
+	nsqd := newFakeNSQD(t)
+	host, port, _ := net.SplitHostPort(nsqd.listener.Addr().String())
+	tcpPort, _ := strconv.Atoi(port)
+
+	lookupd := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/lookup" || r.URL.Query().Get("topic") != "orders" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"producers": []map[string]interface{}{{"broadcast_address": host, "tcp_port": tcpPort}},
+		})
+	}))
+	defer lookupd.Close()
+
+	cfg := testMQConfig("nsq")
+	cfg.NSQ.LookupdAddrs = []string{strings.TrimPrefix(lookupd.URL, "http://")}
+	m, err := NewNSQMQ(cfg, testLogger())
+	if err != nil {
+		t.Fatalf("NewNSQMQ failed: %v", err)
+	}
+	defer m.Stop(context.Background())
+
+	m.Subscribe("orders", func(ctx context.Context, msg *Message) error { return nil })
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if !waitFor(2*time.Second, nsqd.subscribed) {
+		t.Fatal("consumer did not subscribe to discovered nsqd")
+	}
+
+	// 未配置 nsqd 地址时不能发布
+	if err := m.Publish(context.Background(), "orders", "", "payload"); err == nil {
+		t.Error("Expected publish to fail without nsqd addr")
+	}
+}
@@ -0,0 +1,90 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// tokenResponse 授权码换取令牌响应
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// exchangeToken 使用授权码换取访问令牌（OAuth2 authorization_code 模式）
+func exchangeToken(ctx context.Context, client *http.Client, tokenURL string, clientID string, clientSecret string, redirectURL string, code string) (*tokenResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURL)
+	form.Set("client_id", clientID)
+	form.Set("client_secret", clientSecret)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("create token request failed: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	token := &tokenResponse{}
+	if err := doJSON(client, req, token); err != nil {
+		if token.Error != "" {
+			return nil, fmt.Errorf("exchange token failed: %s: %s", token.Error, token.ErrorDescription)
+		}
+		return nil, fmt.Errorf("exchange token failed: %w", err)
+	}
+	if token.Error != "" {
+		return nil, fmt.Errorf("exchange token failed: %s: %s", token.Error, token.ErrorDescription)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("exchange token failed: empty access token")
+	}
+	return token, nil
+}
+
+// getJSON 携带访问令牌请求接口并解析 JSON 响应
+func getJSON(ctx context.Context, client *http.Client, apiURL string, accessToken string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return fmt.Errorf("create request failed: %w", err)
+	}
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	return doJSON(client, req, v)
+}
+
+// doJSON 发送请求并解析 JSON 响应（非 2xx 状态码时同样解析响应，便于调用方读取错误信息）
+func doJSON(client *http.Client, req *http.Request, v interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	decodeErr := json.Unmarshal(body, v)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if decodeErr != nil {
+		return fmt.Errorf("decode response failed: %w", decodeErr)
+	}
+	return nil
+}
+
+// authURL 拼接授权地址参数
+func authURL(base string, params url.Values) string {
+	if strings.Contains(base, "?") {
+		return base + "&" + params.Encode()
+	}
+	return base + "?" + params.Encode()
+}
@@ -0,0 +1,121 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/so68/core/config"
+)
+
+// GitHub 默认接口地址
+const (
+	githubAuthURL  = "https://github.com/login/oauth/authorize"
+	githubTokenURL = "https://github.com/login/oauth/access_token"
+	githubEndpoint = "https://api.github.com"
+)
+
+// GitHubProvider GitHub 登录（OAuth2，通过用户接口与邮箱接口获取账号信息）
+type GitHubProvider struct {
+	config   *config.AuthProviderConfig
+	authURL  string
+	tokenURL string
+	endpoint string
+	scopes   []string
+	client   *http.Client
+	logger   *slog.Logger
+}
+
+// githubUser GitHub 用户信息
+type githubUser struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// githubEmail GitHub 邮箱
+type githubEmail struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+// NewGitHubProvider 创建 GitHub 登录
+func NewGitHubProvider(cfg *config.AuthProviderConfig, client *http.Client, logger *slog.Logger) (*GitHubProvider, error) {
+	if cfg.ClientID == "" || cfg.ClientSecret == "" {
+		return nil, errors.New("github client id and client secret are required")
+	}
+	p := &GitHubProvider{
+		config:   cfg,
+		authURL:  cfg.AuthURL,
+		tokenURL: cfg.TokenURL,
+		endpoint: strings.TrimSuffix(cfg.Endpoint, "/"),
+		scopes:   cfg.Scopes,
+		client:   client,
+		logger:   logger,
+	}
+	if p.authURL == "" {
+		p.authURL = githubAuthURL
+	}
+	if p.tokenURL == "" {
+		p.tokenURL = githubTokenURL
+	}
+	if p.endpoint == "" {
+		p.endpoint = githubEndpoint
+	}
+	if len(p.scopes) == 0 {
+		p.scopes = []string{"read:user", "user:email"}
+	}
+	return p, nil
+}
+
+// AuthCodeURL 生成授权地址
+func (p *GitHubProvider) AuthCodeURL(ctx context.Context, state string) (string, error) {
+	params := url.Values{}
+	params.Set("client_id", p.config.ClientID)
+	params.Set("redirect_uri", p.config.RedirectURL)
+	params.Set("scope", strings.Join(p.scopes, " "))
+	params.Set("state", state)
+	return authURL(p.authURL, params), nil
+}
+
+// Exchange 使用授权码换取账号信息（邮箱取已验证的主邮箱）
+func (p *GitHubProvider) Exchange(ctx context.Context, code string) (*User, error) {
+	token, err := exchangeToken(ctx, p.client, p.tokenURL, p.config.ClientID, p.config.ClientSecret, p.config.RedirectURL, code)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &githubUser{}
+	if err := getJSON(ctx, p.client, p.endpoint+"/user", token.AccessToken, user); err != nil {
+		return nil, fmt.Errorf("get github user failed: %w", err)
+	}
+	if user.ID == 0 {
+		return nil, errors.New("get github user failed: empty id")
+	}
+	name := user.Name
+	if name == "" {
+		name = user.Login
+	}
+	result := &User{Subject: strconv.FormatInt(user.ID, 10), Name: name, Avatar: user.AvatarURL}
+
+	var emails []*githubEmail
+	if err := getJSON(ctx, p.client, p.endpoint+"/user/emails", token.AccessToken, &emails); err != nil {
+		// 未授权 user:email 时无法获取邮箱，仅按账号ID登录
+		p.logger.Warn("get github user emails failed", slog.String("error", err.Error()))
+		return result, nil
+	}
+	for _, email := range emails {
+		if email.Primary {
+			result.Email, result.EmailVerified = email.Email, email.Verified
+			break
+		}
+	}
+	return result, nil
+}
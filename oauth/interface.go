@@ -0,0 +1,23 @@
+package oauth
+
+import (
+	"context"
+)
+
+// User 第三方账号信息
+type User struct {
+	Provider      string `json:"provider"`       // 登录方式名称
+	Subject       string `json:"subject"`        // 第三方账号唯一标识
+	Email         string `json:"email"`          // 邮箱
+	EmailVerified bool   `json:"email_verified"` // 邮箱是否已验证（仅已验证的邮箱用于匹配已有管理员）
+	Name          string `json:"name"`           // 名称
+	Avatar        string `json:"avatar"`         // 头像
+}
+
+// Provider 第三方登录方式接口
+type Provider interface {
+	// AuthCodeURL 生成授权地址
+	AuthCodeURL(ctx context.Context, state string) (string, error)
+	// Exchange 使用授权码换取第三方账号信息
+	Exchange(ctx context.Context, code string) (*User, error)
+}
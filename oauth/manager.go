@@ -0,0 +1,188 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/so68/core/cache"
+	"github.com/so68/core/config"
+)
+
+// stateKeyPrefix 登录授权 state 缓存键前缀
+const stateKeyPrefix = "oauth:state:"
+
+// StateCookie 保存与 state 绑定的浏览器标识的 Cookie 名称
+const StateCookie = "oauth_state"
+
+var (
+	ErrProviderNotFound = errors.New("不支持的登录方式")
+	ErrInvalidState     = errors.New("登录授权已过期, 请重新登录")
+	ErrDomainNotAllowed = errors.New("该邮箱域名不允许登录")
+)
+
+// Manager 第三方登录管理
+// - state 保存在缓存中，校验一次后失效
+// - state 与发起授权的浏览器绑定（浏览器标识写入 Cookie，缓存中仅保存摘要），防止诱导他人使用攻击者的授权码登录（登录 CSRF）
+type Manager struct {
+	config *config.AuthConfig
+	cache  cache.Cache
+	logger *slog.Logger
+
+	mutex     sync.RWMutex
+	providers map[string]Provider
+	domains   map[string][]string
+}
+
+// NewManager 根据配置创建第三方登录管理
+func NewManager(cfg *config.AuthConfig, cache cache.Cache, logger *slog.Logger) (*Manager, error) {
+	if cache == nil {
+		return nil, fmt.Errorf("第三方登录需要启用缓存")
+	}
+	m := &Manager{config: cfg, cache: cache, logger: logger, providers: make(map[string]Provider), domains: make(map[string][]string)}
+	client := &http.Client{Timeout: cfg.Timeout}
+	for name, providerConfig := range cfg.Providers {
+		provider, err := NewProvider(providerConfig, client, logger)
+		if err != nil {
+			return nil, fmt.Errorf("创建登录方式 %s 失败: %w", name, err)
+		}
+		m.Register(name, provider)
+		m.domains[name] = providerConfig.AllowedDomains
+	}
+	return m, nil
+}
+
+// NewProvider 根据配置创建第三方登录方式
+func NewProvider(cfg *config.AuthProviderConfig, client *http.Client, logger *slog.Logger) (Provider, error) {
+	switch cfg.Driver {
+	case "google":
+		return NewGoogleProvider(cfg, client, logger)
+	case "github":
+		return NewGitHubProvider(cfg, client, logger)
+	case "wecom":
+		return NewWeComProvider(cfg, client, logger)
+	case "oidc":
+		return NewOIDCProvider(cfg, client, logger)
+	default:
+		return nil, fmt.Errorf("unsupported auth driver: %s", cfg.Driver)
+	}
+}
+
+// Register 注册登录方式（可注册自定义实现，同名覆盖）
+func (m *Manager) Register(name string, provider Provider) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.providers[name] = provider
+}
+
+// Config 第三方登录配置
+func (m *Manager) Config() *config.AuthConfig {
+	return m.config
+}
+
+// Providers 已启用的登录方式名称（按名称排序）
+func (m *Manager) Providers() []string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	names := make([]string, 0, len(m.providers))
+	for name := range m.providers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// AuthCodeURL 生成授权地址与 state，返回授权地址与浏览器标识（须写入 StateCookie，换取账号信息时校验）
+func (m *Manager) AuthCodeURL(ctx context.Context, name string) (string, string, error) {
+	provider, err := m.provider(name)
+	if err != nil {
+		return "", "", err
+	}
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("生成登录授权 state 失败: %w", err)
+	}
+	state, binding := hex.EncodeToString(buf[:16]), hex.EncodeToString(buf[16:])
+	if err := m.cache.Set(ctx, stateKeyPrefix+state, stateValue(name, binding), m.config.StateTTL); err != nil {
+		return "", "", fmt.Errorf("保存登录授权 state 失败: %w", err)
+	}
+	authURL, err := provider.AuthCodeURL(ctx, state)
+	if err != nil {
+		return "", "", fmt.Errorf("生成授权地址失败: %w", err)
+	}
+	return authURL, binding, nil
+}
+
+// Exchange 校验 state 与浏览器标识，并使用授权码换取第三方账号信息
+func (m *Manager) Exchange(ctx context.Context, name string, state string, binding string, code string) (*User, error) {
+	provider, err := m.provider(name)
+	if err != nil {
+		return nil, err
+	}
+	if state == "" || binding == "" || code == "" {
+		return nil, ErrInvalidState
+	}
+	key := stateKeyPrefix + state
+	value, err := m.cache.Get(ctx, key)
+	if err != nil || subtle.ConstantTimeCompare([]byte(value), []byte(stateValue(name, binding))) != 1 {
+		return nil, ErrInvalidState
+	}
+	// 一次性使用，防止重放
+	if err := m.cache.Delete(ctx, key); err != nil {
+		m.logger.Warn("删除登录授权 state 失败", "error", err)
+	}
+
+	user, err := provider.Exchange(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("第三方登录失败: %w", err)
+	}
+	user.Provider = name
+	if !m.allowed(name, user) {
+		return nil, ErrDomainNotAllowed
+	}
+	return user, nil
+}
+
+// provider 获取登录方式
+func (m *Manager) provider(name string) (Provider, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	provider, ok := m.providers[name]
+	if !ok {
+		return nil, ErrProviderNotFound
+	}
+	return provider, nil
+}
+
+// allowed 邮箱域名是否允许登录（限制域名时须为已验证的邮箱）
+func (m *Manager) allowed(name string, user *User) bool {
+	m.mutex.RLock()
+	domains := m.domains[name]
+	m.mutex.RUnlock()
+	if len(domains) == 0 {
+		return true
+	}
+	at := strings.LastIndex(user.Email, "@")
+	if !user.EmailVerified || at < 0 {
+		return false
+	}
+	domain := user.Email[at+1:]
+	return slices.ContainsFunc(domains, func(allowed string) bool {
+		return strings.EqualFold(allowed, domain)
+	})
+}
+
+// stateValue state 缓存值（登录方式与浏览器标识摘要）
+func stateValue(name string, binding string) string {
+	sum := sha256.Sum256([]byte(binding))
+	return name + ":" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,236 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/so68/core/cache"
+	"github.com/so68/core/config"
+)
+
+/*
+第三方登录测试
+
+本文件用于测试 OAuth2/OIDC 第三方登录相关的功能特性，
+包括 OIDC 发现与用户信息、GitHub 邮箱、企业微信成员信息、state 校验与邮箱域名限制等。
+
+运行命令：
+go test -v -run "^Test.*OAuth.*$"
+
+测试内容：
+1. OIDC 发现接口地址与授权码换取用户信息 (OIDCProvider)
+2. GitHub 用户与主邮箱 (GitHubProvider)
+3. 企业微信访问令牌与成员信息 (WeComProvider)
+4. state 一次性校验与邮箱域名限制 (Manager)
+*/
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// oauthServer 模拟第三方登录服务
+func oauthServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			io.WriteString(w, `{"authorization_endpoint":"`+server.URL+`/authorize","token_endpoint":"`+server.URL+`/token","userinfo_endpoint":"`+server.URL+`/userinfo"}`)
+		case "/token":
+			r.ParseForm()
+			if r.PostForm.Get("code") != "good" || r.PostForm.Get("client_secret") != "secret" || r.PostForm.Get("grant_type") != "authorization_code" {
+				w.WriteHeader(http.StatusBadRequest)
+				io.WriteString(w, `{"error":"invalid_grant","error_description":"bad code"}`)
+				return
+			}
+			io.WriteString(w, `{"access_token":"at","token_type":"Bearer"}`)
+		case "/userinfo":
+			if r.Header.Get("Authorization") != "Bearer at" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			io.WriteString(w, `{"sub":"u1","email":"alice@example.com","email_verified":"true","preferred_username":"alice"}`)
+		case "/user":
+			io.WriteString(w, `{"id":42,"login":"octocat","avatar_url":"https://avatars.example.com/42"}`)
+		case "/user/emails":
+			io.WriteString(w, `[{"email":"other@example.com","primary":false,"verified":true},{"email":"octo@example.com","primary":true,"verified":true}]`)
+		case "/cgi-bin/gettoken":
+			if r.URL.Query().Get("corpsecret") != "secret" {
+				io.WriteString(w, `{"errcode":40001,"errmsg":"invalid credential"}`)
+				return
+			}
+			io.WriteString(w, `{"errcode":0,"access_token":"wt","expires_in":7200}`)
+		case "/cgi-bin/auth/getuserinfo":
+			if r.URL.Query().Get("code") != "good" {
+				io.WriteString(w, `{"errcode":40029,"errmsg":"invalid code"}`)
+				return
+			}
+			io.WriteString(w, `{"errcode":0,"userid":"zhangsan"}`)
+		case "/cgi-bin/user/get":
+			io.WriteString(w, `{"errcode":0,"userid":"zhangsan","name":"张三","biz_mail":"zhangsan@corp.example.com"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOIDCProvider(t *testing.T) {
+	server := oauthServer(t)
+	ctx := context.Background()
+	provider, err := NewOIDCProvider(&config.AuthProviderConfig{Issuer: server.URL, ClientID: "client", ClientSecret: "secret", RedirectURL: "https://admin.example.com/callback"}, server.Client(), testLogger())
+	if err != nil {
+		t.Fatalf("NewOIDCProvider() error = %v", err)
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, "s1")
+	if err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+	parsed, _ := url.Parse(authURL)
+	if parsed.Path != "/authorize" || parsed.Query().Get("state") != "s1" || parsed.Query().Get("scope") != "openid email profile" {
+		t.Errorf("AuthCodeURL() = %s", authURL)
+	}
+
+	user, err := provider.Exchange(ctx, "good")
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	if user.Subject != "u1" || user.Email != "alice@example.com" || !user.EmailVerified || user.Name != "alice" {
+		t.Errorf("Exchange() user = %+v", user)
+	}
+	if _, err := provider.Exchange(ctx, "bad"); err == nil || !strings.Contains(err.Error(), "invalid_grant") {
+		t.Errorf("Exchange() bad code error = %v", err)
+	}
+}
+
+func TestGitHubOAuthProvider(t *testing.T) {
+	server := oauthServer(t)
+	provider, err := NewGitHubProvider(&config.AuthProviderConfig{ClientID: "client", ClientSecret: "secret", TokenURL: server.URL + "/token", Endpoint: server.URL}, server.Client(), testLogger())
+	if err != nil {
+		t.Fatalf("NewGitHubProvider() error = %v", err)
+	}
+	user, err := provider.Exchange(context.Background(), "good")
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	if user.Subject != "42" || user.Name != "octocat" || user.Email != "octo@example.com" || !user.EmailVerified {
+		t.Errorf("Exchange() user = %+v", user)
+	}
+}
+
+func TestWeComOAuthProvider(t *testing.T) {
+	server := oauthServer(t)
+	ctx := context.Background()
+	provider, err := NewWeComProvider(&config.AuthProviderConfig{ClientID: "corp", ClientSecret: "secret", AgentID: "1000002", RedirectURL: "https://admin.example.com/callback", Endpoint: server.URL}, server.Client(), testLogger())
+	if err != nil {
+		t.Fatalf("NewWeComProvider() error = %v", err)
+	}
+
+	authURL, _ := provider.AuthCodeURL(ctx, "s1")
+	if query, _ := url.Parse(authURL); query.Query().Get("appid") != "corp" || query.Query().Get("agentid") != "1000002" {
+		t.Errorf("AuthCodeURL() = %s", authURL)
+	}
+	user, err := provider.Exchange(ctx, "good")
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	if user.Subject != "zhangsan" || user.Name != "张三" || user.Email != "zhangsan@corp.example.com" || !user.EmailVerified {
+		t.Errorf("Exchange() user = %+v", user)
+	}
+	if _, err := provider.Exchange(ctx, "bad"); err == nil || !strings.Contains(err.Error(), "40029") {
+		t.Errorf("Exchange() bad code error = %v", err)
+	}
+}
+
+// staticProvider 返回固定账号的登录方式
+type staticProvider struct {
+	user *User
+}
+
+func (p *staticProvider) AuthCodeURL(ctx context.Context, state string) (string, error) {
+	return "https://idp.example.com/authorize?state=" + state, nil
+}
+
+func (p *staticProvider) Exchange(ctx context.Context, code string) (*User, error) {
+	user := *p.user
+	return &user, nil
+}
+
+func TestOAuthManager(t *testing.T) {
+	ctx := context.Background()
+	cacheConfig := &config.CacheConfig{Driver: "memory", CleanupInterval: time.Minute}
+	cacheConfig.SetDefaults()
+	memory, err := cache.NewMemoryCache(cacheConfig, testLogger())
+	if err != nil {
+		t.Fatalf("create memory cache failed: %v", err)
+	}
+	defer memory.Close()
+
+	cfg := config.DefaultAuthConfig()
+	cfg.Providers = map[string]*config.AuthProviderConfig{
+		"corp": {Driver: "oidc", ClientID: "client", ClientSecret: "secret", AuthURL: "https://idp.example.com/authorize", TokenURL: "https://idp.example.com/token", UserInfoURL: "https://idp.example.com/userinfo", AllowedDomains: []string{"Example.com"}},
+	}
+	manager, err := NewManager(cfg, memory, testLogger())
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	manager.Register("corp", &staticProvider{user: &User{Subject: "u1", Email: "alice@example.com", EmailVerified: true}})
+	manager.Register("other", &staticProvider{user: &User{Subject: "u2"}})
+	if names := manager.Providers(); len(names) != 2 || names[0] != "corp" || names[1] != "other" {
+		t.Errorf("Providers() = %v", names)
+	}
+
+	if _, _, err := manager.AuthCodeURL(ctx, "unknown"); !errors.Is(err, ErrProviderNotFound) {
+		t.Errorf("AuthCodeURL() unknown provider error = %v", err)
+	}
+	authURL, binding, err := manager.AuthCodeURL(ctx, "corp")
+	if err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+	state := strings.TrimPrefix(authURL, "https://idp.example.com/authorize?state=")
+	if binding == "" || binding == state {
+		t.Fatalf("AuthCodeURL() binding = %q", binding)
+	}
+
+	// state 须与登录方式一致，且只能使用一次
+	if _, err := manager.Exchange(ctx, "other", state, binding, "code"); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("Exchange() with state of another provider error = %v", err)
+	}
+	// state 须由发起授权的浏览器提交（缺少或其他浏览器标识时拒绝）
+	for _, other := range []string{"", "other"} {
+		if _, err := manager.Exchange(ctx, "corp", state, other, "code"); !errors.Is(err, ErrInvalidState) {
+			t.Errorf("Exchange() with binding %q error = %v", other, err)
+		}
+	}
+	user, err := manager.Exchange(ctx, "corp", state, binding, "code")
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	if user.Provider != "corp" || user.Subject != "u1" {
+		t.Errorf("Exchange() user = %+v", user)
+	}
+	if _, err := manager.Exchange(ctx, "corp", state, binding, "code"); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("Exchange() reused state error = %v", err)
+	}
+
+	// 限制邮箱域名时拒绝未验证或其他域名的邮箱
+	for _, u := range []*User{{Subject: "u3", Email: "bob@other.com", EmailVerified: true}, {Subject: "u4", Email: "carol@example.com"}} {
+		manager.Register("corp", &staticProvider{user: u})
+		authURL, binding, _ := manager.AuthCodeURL(ctx, "corp")
+		state := strings.TrimPrefix(authURL, "https://idp.example.com/authorize?state=")
+		if _, err := manager.Exchange(ctx, "corp", state, binding, "code"); !errors.Is(err, ErrDomainNotAllowed) {
+			t.Errorf("Exchange() %s error = %v, want ErrDomainNotAllowed", u.Email, err)
+		}
+	}
+}
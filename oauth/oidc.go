@@ -0,0 +1,163 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/so68/core/config"
+)
+
+// Google OIDC 默认接口地址
+const (
+	googleAuthURL     = "https://accounts.google.com/o/oauth2/v2/auth"
+	googleTokenURL    = "https://oauth2.googleapis.com/token"
+	googleUserInfoURL = "https://openidconnect.googleapis.com/v1/userinfo"
+)
+
+// OIDCProvider 通用 OIDC 登录（授权码模式，通过用户信息接口获取账号信息）
+type OIDCProvider struct {
+	config *config.AuthProviderConfig
+	scopes []string
+	client *http.Client
+	logger *slog.Logger
+
+	mutex       sync.Mutex
+	authURL     string
+	tokenURL    string
+	userInfoURL string
+}
+
+// oidcDiscovery OIDC 发现文档
+type oidcDiscovery struct {
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+}
+
+// oidcUserInfo OIDC 用户信息
+type oidcUserInfo struct {
+	Subject           string      `json:"sub"`
+	Email             string      `json:"email"`
+	EmailVerified     interface{} `json:"email_verified"` // 部分服务商返回字符串 "true"
+	Name              string      `json:"name"`
+	PreferredUsername string      `json:"preferred_username"`
+	Picture           string      `json:"picture"`
+}
+
+// NewOIDCProvider 创建通用 OIDC 登录（未配置接口地址时首次使用时通过 issuer 发现）
+func NewOIDCProvider(cfg *config.AuthProviderConfig, client *http.Client, logger *slog.Logger) (*OIDCProvider, error) {
+	if cfg.ClientID == "" || cfg.ClientSecret == "" {
+		return nil, errors.New("oidc client id and client secret are required")
+	}
+	if cfg.Issuer == "" && (cfg.AuthURL == "" || cfg.TokenURL == "" || cfg.UserInfoURL == "") {
+		return nil, errors.New("oidc issuer or endpoints are required")
+	}
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+	return &OIDCProvider{
+		config:      cfg,
+		scopes:      scopes,
+		client:      client,
+		logger:      logger,
+		authURL:     cfg.AuthURL,
+		tokenURL:    cfg.TokenURL,
+		userInfoURL: cfg.UserInfoURL,
+	}, nil
+}
+
+// NewGoogleProvider 创建 Google 登录
+func NewGoogleProvider(cfg *config.AuthProviderConfig, client *http.Client, logger *slog.Logger) (*OIDCProvider, error) {
+	google := *cfg
+	if google.AuthURL == "" {
+		google.AuthURL = googleAuthURL
+	}
+	if google.TokenURL == "" {
+		google.TokenURL = googleTokenURL
+	}
+	if google.UserInfoURL == "" {
+		google.UserInfoURL = googleUserInfoURL
+	}
+	return NewOIDCProvider(&google, client, logger)
+}
+
+// AuthCodeURL 生成授权地址
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, state string) (string, error) {
+	if err := p.discover(ctx); err != nil {
+		return "", err
+	}
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", p.config.ClientID)
+	params.Set("redirect_uri", p.config.RedirectURL)
+	params.Set("scope", strings.Join(p.scopes, " "))
+	params.Set("state", state)
+	return authURL(p.authURL, params), nil
+}
+
+// Exchange 使用授权码换取账号信息
+func (p *OIDCProvider) Exchange(ctx context.Context, code string) (*User, error) {
+	if err := p.discover(ctx); err != nil {
+		return nil, err
+	}
+	token, err := exchangeToken(ctx, p.client, p.tokenURL, p.config.ClientID, p.config.ClientSecret, p.config.RedirectURL, code)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &oidcUserInfo{}
+	if err := getJSON(ctx, p.client, p.userInfoURL, token.AccessToken, info); err != nil {
+		return nil, fmt.Errorf("get oidc userinfo failed: %w", err)
+	}
+	if info.Subject == "" {
+		return nil, errors.New("get oidc userinfo failed: empty subject")
+	}
+	name := info.Name
+	if name == "" {
+		name = info.PreferredUsername
+	}
+	verified := false
+	switch v := info.EmailVerified.(type) {
+	case bool:
+		verified = v
+	case string:
+		verified = v == "true"
+	}
+	return &User{Subject: info.Subject, Email: info.Email, EmailVerified: verified, Name: name, Avatar: info.Picture}, nil
+}
+
+// discover 通过 issuer 发现接口地址（成功后缓存，失败时下次重试）
+func (p *OIDCProvider) discover(ctx context.Context) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.authURL != "" && p.tokenURL != "" && p.userInfoURL != "" {
+		return nil
+	}
+
+	document := &oidcDiscovery{}
+	discoveryURL := strings.TrimSuffix(p.config.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := getJSON(ctx, p.client, discoveryURL, "", document); err != nil {
+		return fmt.Errorf("discover oidc endpoints failed: %w", err)
+	}
+	if document.AuthorizationEndpoint == "" || document.TokenEndpoint == "" || document.UserInfoEndpoint == "" {
+		return errors.New("discover oidc endpoints failed: incomplete discovery document")
+	}
+	if p.authURL == "" {
+		p.authURL = document.AuthorizationEndpoint
+	}
+	if p.tokenURL == "" {
+		p.tokenURL = document.TokenEndpoint
+	}
+	if p.userInfoURL == "" {
+		p.userInfoURL = document.UserInfoEndpoint
+	}
+	p.logger.Info("OIDC endpoints discovered", slog.String("issuer", p.config.Issuer))
+	return nil
+}
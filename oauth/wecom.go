@@ -0,0 +1,162 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+// 企业微信默认接口地址
+const (
+	wecomAuthURL  = "https://login.work.weixin.qq.com/wwlogin/sso/login"
+	wecomEndpoint = "https://qyapi.weixin.qq.com"
+)
+
+// WeComProvider 企业微信登录（网页扫码登录，ClientID 为 CorpID，ClientSecret 为应用 Secret）
+type WeComProvider struct {
+	config   *config.AuthProviderConfig
+	authURL  string
+	endpoint string
+	client   *http.Client
+	logger   *slog.Logger
+
+	mutex       sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// wecomResponse 企业微信接口通用响应
+type wecomResponse struct {
+	ErrCode int    `json:"errcode"`
+	ErrMsg  string `json:"errmsg"`
+}
+
+// wecomToken 企业微信应用访问令牌
+type wecomToken struct {
+	wecomResponse
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// wecomUserInfo 企业微信登录身份
+type wecomUserInfo struct {
+	wecomResponse
+	UserID string `json:"userid"`
+	OpenID string `json:"openid"`
+}
+
+// wecomUser 企业微信成员信息
+type wecomUser struct {
+	wecomResponse
+	UserID  string `json:"userid"`
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	BizMail string `json:"biz_mail"`
+	Avatar  string `json:"avatar"`
+}
+
+// NewWeComProvider 创建企业微信登录
+func NewWeComProvider(cfg *config.AuthProviderConfig, client *http.Client, logger *slog.Logger) (*WeComProvider, error) {
+	if cfg.ClientID == "" || cfg.ClientSecret == "" {
+		return nil, errors.New("wecom corp id and secret are required")
+	}
+	if cfg.AgentID == "" {
+		return nil, errors.New("wecom agent id is required")
+	}
+	p := &WeComProvider{
+		config:   cfg,
+		authURL:  cfg.AuthURL,
+		endpoint: strings.TrimSuffix(cfg.Endpoint, "/"),
+		client:   client,
+		logger:   logger,
+	}
+	if p.authURL == "" {
+		p.authURL = wecomAuthURL
+	}
+	if p.endpoint == "" {
+		p.endpoint = wecomEndpoint
+	}
+	return p, nil
+}
+
+// AuthCodeURL 生成扫码登录地址
+func (p *WeComProvider) AuthCodeURL(ctx context.Context, state string) (string, error) {
+	params := url.Values{}
+	params.Set("login_type", "CorpApp")
+	params.Set("appid", p.config.ClientID)
+	params.Set("agentid", p.config.AgentID)
+	params.Set("redirect_uri", p.config.RedirectURL)
+	params.Set("state", state)
+	return authURL(p.authURL, params), nil
+}
+
+// Exchange 使用授权码换取成员信息（仅企业成员可登录，邮箱由企业通讯录维护，视为已验证）
+func (p *WeComProvider) Exchange(ctx context.Context, code string) (*User, error) {
+	accessToken, err := p.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &wecomUserInfo{}
+	query := url.Values{"access_token": {accessToken}, "code": {code}}
+	if err := p.get(ctx, "/cgi-bin/auth/getuserinfo", query, info, &info.wecomResponse); err != nil {
+		return nil, fmt.Errorf("get wecom userinfo failed: %w", err)
+	}
+	if info.UserID == "" {
+		return nil, errors.New("get wecom userinfo failed: not a member of the corp")
+	}
+
+	member := &wecomUser{}
+	query = url.Values{"access_token": {accessToken}, "userid": {info.UserID}}
+	if err := p.get(ctx, "/cgi-bin/user/get", query, member, &member.wecomResponse); err != nil {
+		// 应用无通讯录权限时无法读取成员详情，仅按成员ID登录
+		p.logger.Warn("get wecom user failed", slog.String("userid", info.UserID), slog.String("error", err.Error()))
+		return &User{Subject: info.UserID, Name: info.UserID}, nil
+	}
+	email := member.BizMail
+	if email == "" {
+		email = member.Email
+	}
+	name := member.Name
+	if name == "" {
+		name = info.UserID
+	}
+	return &User{Subject: info.UserID, Email: email, EmailVerified: email != "", Name: name, Avatar: member.Avatar}, nil
+}
+
+// token 获取应用访问令牌（提前 5 分钟刷新）
+func (p *WeComProvider) token(ctx context.Context) (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.accessToken != "" && time.Now().Before(p.expiresAt) {
+		return p.accessToken, nil
+	}
+
+	token := &wecomToken{}
+	query := url.Values{"corpid": {p.config.ClientID}, "corpsecret": {p.config.ClientSecret}}
+	if err := p.get(ctx, "/cgi-bin/gettoken", query, token, &token.wecomResponse); err != nil {
+		return "", fmt.Errorf("get wecom access token failed: %w", err)
+	}
+	p.accessToken = token.AccessToken
+	p.expiresAt = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - 5*time.Minute)
+	return p.accessToken, nil
+}
+
+// get 请求企业微信接口并检查错误码
+func (p *WeComProvider) get(ctx context.Context, path string, query url.Values, v interface{}, result *wecomResponse) error {
+	if err := getJSON(ctx, p.client, p.endpoint+path+"?"+query.Encode(), "", v); err != nil {
+		return err
+	}
+	if result.ErrCode != 0 {
+		return fmt.Errorf("%d: %s", result.ErrCode, result.ErrMsg)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package queue
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/so68/core/config"
+)
+
+// Factory 任务队列工厂
+type Factory struct {
+	logger *slog.Logger
+}
+
+// NewFactory 创建任务队列工厂
+func NewFactory(logger *slog.Logger) *Factory {
+	return &Factory{
+		logger: logger,
+	}
+}
+
+// CreateQueue 根据配置创建任务队列（memory 驱动忽略 client）
+func (f *Factory) CreateQueue(cfg *config.QueueConfig, client redis.UniversalClient) (Queue, error) {
+	switch cfg.Driver {
+	case "redis":
+		return NewRedisQueue(cfg, client, f.logger)
+	case "memory":
+		return NewMemoryQueue(cfg, f.logger), nil
+	default:
+		return nil, fmt.Errorf("unsupported queue driver: %s", cfg.Driver)
+	}
+}
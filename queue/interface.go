@@ -0,0 +1,24 @@
+package queue
+
+import (
+	"context"
+	"time"
+)
+
+// Queue 任务队列接口
+type Queue interface {
+	// 处理器注册
+	Register(jobType string, handler HandlerFunc)
+
+	// 任务投递
+	Enqueue(ctx context.Context, jobType string, payload interface{}) (*Job, error)
+	EnqueueIn(ctx context.Context, delay time.Duration, jobType string, payload interface{}) (*Job, error)
+
+	// 死信与统计
+	DeadJobs(ctx context.Context, start, stop int64) ([]*Job, error)
+	Stats(ctx context.Context) (*Stats, error)
+
+	// 生命周期：Start 启动 worker 池（非阻塞），Stop 等待执行中的任务完成
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
@@ -0,0 +1,75 @@
+package queue
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Job 任务
+type Job struct {
+	ID        string          `json:"id"`         // 任务ID
+	Type      string          `json:"type"`       // 任务类型（对应处理器）
+	Payload   json.RawMessage `json:"payload"`    // 任务数据
+	Attempts  int             `json:"attempts"`   // 已执行次数
+	LastError string          `json:"last_error"` // 最后一次错误
+	CreatedAt time.Time       `json:"created_at"` // 创建时间
+	FailedAt  *time.Time      `json:"failed_at"`  // 进入死信队列时间
+}
+
+// Stats 队列统计
+type Stats struct {
+	Ready   int64 `json:"ready"`   // 待执行任务数
+	Delayed int64 `json:"delayed"` // 延迟/重试等待任务数
+	Dead    int64 `json:"dead"`    // 死信任务数
+}
+
+// HandlerFunc 任务处理函数
+type HandlerFunc func(ctx context.Context, job *Job) error
+
+// Bind 将任务数据解析到目标结构体
+func (j *Job) Bind(target interface{}) error {
+	return json.Unmarshal(j.Payload, target)
+}
+
+// newJob 创建任务
+func newJob(jobType string, payload interface{}) (*Job, error) {
+	if jobType == "" {
+		return nil, fmt.Errorf("job type is required")
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize job payload: %w", err)
+	}
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate job id: %w", err)
+	}
+	return &Job{
+		ID:        hex.EncodeToString(buf),
+		Type:      jobType,
+		Payload:   data,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// backoff 计算第 attempts 次失败后的重试间隔（指数退避）
+func backoff(attempts int, base, max time.Duration) time.Duration {
+	if attempts <= 0 {
+		attempts = 1
+	}
+	delay := base
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if max > 0 && delay >= max {
+			return max
+		}
+	}
+	if max > 0 && delay > max {
+		return max
+	}
+	return delay
+}
@@ -0,0 +1,230 @@
+package queue
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+// delayedJob 延迟任务
+type delayedJob struct {
+	job *Job
+	at  time.Time
+}
+
+// MemoryQueue 基于内存的任务队列
+// - 适用于单实例部署、开发与测试环境
+// - 任务仅保存在进程内存中，进程退出时未执行的任务会丢失
+type MemoryQueue struct {
+	*workerPool
+
+	mutex   sync.Mutex
+	ready   []*Job       // 就绪队列（先进先出）
+	delayed []delayedJob // 延迟队列（按执行时间排序）
+	dead    []*Job       // 死信队列（最新的在前）
+	notify  chan struct{}
+}
+
+// NewMemoryQueue 创建内存任务队列
+func NewMemoryQueue(cfg *config.QueueConfig, logger *slog.Logger) *MemoryQueue {
+	logger.Info("Memory queue initialized",
+		slog.String("name", cfg.Name),
+		slog.Int("concurrency", cfg.Concurrency),
+		slog.Int("max_retries", cfg.MaxRetries),
+	)
+
+	return &MemoryQueue{
+		workerPool: newWorkerPool("memory", cfg, logger),
+		ready:      make([]*Job, 0),
+		delayed:    make([]delayedJob, 0),
+		dead:       make([]*Job, 0),
+		notify:     make(chan struct{}, 1),
+	}
+}
+
+// Enqueue 投递任务（立即执行）
+func (q *MemoryQueue) Enqueue(ctx context.Context, jobType string, payload interface{}) (*Job, error) {
+	job, err := newJob(jobType, payload)
+	if err != nil {
+		return nil, err
+	}
+	q.push(job)
+	return job, nil
+}
+
+// EnqueueIn 投递延迟任务
+func (q *MemoryQueue) EnqueueIn(ctx context.Context, delay time.Duration, jobType string, payload interface{}) (*Job, error) {
+	job, err := newJob(jobType, payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.schedule(ctx, job, time.Now().Add(delay)); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// DeadJobs 获取死信队列中的任务（start/stop 语义与 Redis LRANGE 一致，支持负数下标）
+func (q *MemoryQueue) DeadJobs(ctx context.Context, start, stop int64) ([]*Job, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	n := int64(len(q.dead))
+	if start < 0 {
+		start += n
+	}
+	if stop < 0 {
+		stop += n
+	}
+	if start < 0 {
+		start = 0
+	}
+	if stop >= n {
+		stop = n - 1
+	}
+	if start > stop {
+		return []*Job{}, nil
+	}
+
+	jobs := make([]*Job, 0, stop-start+1)
+	for _, job := range q.dead[start : stop+1] {
+		clone := *job
+		jobs = append(jobs, &clone)
+	}
+	return jobs, nil
+}
+
+// Stats 获取队列统计（待执行、延迟、死信任务数）
+func (q *MemoryQueue) Stats(ctx context.Context) (*Stats, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return &Stats{Ready: int64(len(q.ready)), Delayed: int64(len(q.delayed)), Dead: int64(len(q.dead))}, nil
+}
+
+// Start 启动 worker 池与延迟任务调度（非阻塞）
+func (q *MemoryQueue) Start(ctx context.Context) error {
+	q.start(q.scheduler, q.worker)
+	return nil
+}
+
+// Stop 停止接收新任务并等待执行中的任务完成（受 ctx 截止时间约束）
+// - 未执行的任务保留在内存中，再次 Start 后继续执行
+func (q *MemoryQueue) Stop(ctx context.Context) error {
+	return q.stop(ctx)
+}
+
+// push 推入就绪队列（保存副本，避免调用方修改已投递的任务）
+func (q *MemoryQueue) push(job *Job) {
+	clone := *job
+	q.mutex.Lock()
+	q.ready = append(q.ready, &clone)
+	q.mutex.Unlock()
+	q.wake()
+}
+
+// pop 取出就绪队列头部任务，队列为空时返回 nil
+func (q *MemoryQueue) pop() *Job {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if len(q.ready) == 0 {
+		return nil
+	}
+	job := q.ready[0]
+	q.ready[0] = nil
+	q.ready = q.ready[1:]
+	if len(q.ready) > 0 {
+		// 仍有待执行任务时继续唤醒其他 worker
+		q.wake()
+	}
+	return job
+}
+
+// wake 唤醒一个等待中的 worker
+func (q *MemoryQueue) wake() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// schedule 推入延迟队列
+func (q *MemoryQueue) schedule(ctx context.Context, job *Job, at time.Time) error {
+	clone := *job
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	i := sort.Search(len(q.delayed), func(i int) bool { return q.delayed[i].at.After(at) })
+	q.delayed = append(q.delayed, delayedJob{})
+	copy(q.delayed[i+1:], q.delayed[i:])
+	q.delayed[i] = delayedJob{job: &clone, at: at}
+	return nil
+}
+
+// bury 推入死信队列
+func (q *MemoryQueue) bury(ctx context.Context, job *Job) error {
+	now := time.Now()
+	job.FailedAt = &now
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.dead = append([]*Job{job}, q.dead...)
+	return nil
+}
+
+// moveDue 将到期的延迟任务移动到就绪队列，返回移动的任务数
+func (q *MemoryQueue) moveDue(now time.Time) int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	i := sort.Search(len(q.delayed), func(i int) bool { return q.delayed[i].at.After(now) })
+	for _, due := range q.delayed[:i] {
+		q.ready = append(q.ready, due.job)
+	}
+	q.delayed = q.delayed[i:]
+	return i
+}
+
+// scheduler 定期将到期的延迟任务移动到就绪队列
+func (q *MemoryQueue) scheduler(ctx context.Context) {
+	ticker := time.NewTicker(q.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if q.moveDue(time.Now()) > 0 {
+				q.wake()
+			}
+		}
+	}
+}
+
+// worker 消费就绪队列
+func (q *MemoryQueue) worker(ctx context.Context, id int) {
+	timer := time.NewTimer(q.config.PollInterval)
+	defer timer.Stop()
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		job := q.pop()
+		if job == nil {
+			timer.Reset(q.config.PollInterval)
+			select {
+			case <-ctx.Done():
+				return
+			case <-q.notify:
+			case <-timer.C:
+			}
+			continue
+		}
+
+		// 执行中的任务不受停止信号影响，保证已取出的任务被处理完成
+		q.process(context.WithoutCancel(ctx), q, job)
+	}
+}
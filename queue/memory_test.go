@@ -0,0 +1,253 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+/*
+内存任务队列功能测试
+
+本文件用于测试MemoryQueue结构体的各种功能特性，
+包括任务投递、延迟任务、失败重试、死信队列、统计与优雅停止等。
+
+运行命令：
+go test -v -run "^TestMemoryQueue.*$"
+
+测试内容：
+1. 任务投递与执行 (Enqueue, EnqueueIn, Register, Start)
+2. 失败重试与死信队列 (MaxRetries, DeadJobs)
+3. 死信队列分页 (DeadJobs)
+4. 队列统计 (Stats)
+5. 优雅停止 (Stop)
+*/
+
+// newTestMemoryQueue 创建测试用内存队列
+func newTestMemoryQueue(t *testing.T) *MemoryQueue {
+	cfg := &config.QueueConfig{
+		Driver:       "memory",
+		Concurrency:  2,
+		MaxRetries:   1,
+		RetryBackoff: 10 * time.Millisecond,
+		PollInterval: 20 * time.Millisecond,
+	}
+	cfg.SetDefaults()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	q := NewMemoryQueue(cfg, logger)
+	t.Cleanup(func() { _ = q.Stop(context.Background()) })
+	return q
+}
+
+// waitFor 轮询等待条件成立
+func waitFor(timeout time.Duration, cond func() bool) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return cond()
+}
+
+func TestMemoryQueue_EnqueueAndProcess(t *testing.T) {
+	q := newTestMemoryQueue(t)
+	ctx := context.Background()
+
+	var processed atomic.Int32
+	q.Register("echo", func(ctx context.Context, job *Job) error {
+		var payload map[string]string
+		if err := job.Bind(&payload); err != nil {
+			return err
+		}
+		if payload["hello"] != "world" {
+			return errors.New("unexpected payload")
+		}
+		processed.Add(1)
+		return nil
+	})
+
+	if err := q.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if _, err := q.Enqueue(ctx, "echo", map[string]string{"hello": "world"}); err != nil {
+			t.Fatalf("Enqueue failed: %v", err)
+		}
+	}
+	if _, err := q.EnqueueIn(ctx, 50*time.Millisecond, "echo", map[string]string{"hello": "world"}); err != nil {
+		t.Fatalf("EnqueueIn failed: %v", err)
+	}
+	if _, err := q.Enqueue(ctx, "", nil); err == nil {
+		t.Error("Expected error for empty job type")
+	}
+
+	if !waitFor(3*time.Second, func() bool { return processed.Load() == 6 }) {
+		t.Errorf("Expected 6 processed jobs, got %d", processed.Load())
+	}
+}
+
+func TestMemoryQueue_RetryAndDeadLetter(t *testing.T) {
+	q := newTestMemoryQueue(t)
+	ctx := context.Background()
+
+	var attempts atomic.Int32
+	q.Register("fail", func(ctx context.Context, job *Job) error {
+		attempts.Add(1)
+		return errors.New("always fail")
+	})
+	q.Register("panic", func(ctx context.Context, job *Job) error {
+		panic("boom")
+	})
+
+	if err := q.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	tests := []struct {
+		jobType   string
+		lastError string
+	}{
+		{jobType: "fail", lastError: "always fail"},
+		{jobType: "panic", lastError: "job panic: boom"},
+		{jobType: "unknown", lastError: "no handler registered for job type: unknown"},
+	}
+	for _, tt := range tests {
+		if _, err := q.Enqueue(ctx, tt.jobType, nil); err != nil {
+			t.Fatalf("Enqueue failed: %v", err)
+		}
+	}
+
+	var dead []*Job
+	waitFor(3*time.Second, func() bool {
+		dead, _ = q.DeadJobs(ctx, 0, -1)
+		return len(dead) == len(tests)
+	})
+	if len(dead) != len(tests) {
+		t.Fatalf("Expected %d dead jobs, got %d", len(tests), len(dead))
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("Expected 2 attempts (1 + 1 retry), got %d", attempts.Load())
+	}
+
+	byType := make(map[string]*Job, len(dead))
+	for _, job := range dead {
+		byType[job.Type] = job
+	}
+	for _, tt := range tests {
+		t.Run(tt.jobType, func(t *testing.T) {
+			job, ok := byType[tt.jobType]
+			if !ok {
+				t.Fatalf("Expected dead job of type %s", tt.jobType)
+			}
+			if job.LastError != tt.lastError {
+				t.Errorf("Expected last error '%s', got '%s'", tt.lastError, job.LastError)
+			}
+			if job.FailedAt == nil {
+				t.Error("Expected FailedAt to be set")
+			}
+		})
+	}
+}
+
+func TestMemoryQueue_DeadJobsRange(t *testing.T) {
+	q := newTestMemoryQueue(t)
+	ctx := context.Background()
+
+	// 死信队列最新的在前：3, 2, 1
+	for _, id := range []string{"1", "2", "3"} {
+		_ = q.bury(ctx, &Job{ID: id, Type: "test"})
+	}
+
+	tests := []struct {
+		name     string
+		start    int64
+		stop     int64
+		expected []string
+	}{
+		{name: "all", start: 0, stop: -1, expected: []string{"3", "2", "1"}},
+		{name: "first", start: 0, stop: 0, expected: []string{"3"}},
+		{name: "negative", start: -2, stop: -1, expected: []string{"2", "1"}},
+		{name: "out of range", start: 1, stop: 100, expected: []string{"2", "1"}},
+		{name: "empty", start: 5, stop: 10, expected: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs, err := q.DeadJobs(ctx, tt.start, tt.stop)
+			if err != nil {
+				t.Fatalf("DeadJobs failed: %v", err)
+			}
+			if len(jobs) != len(tt.expected) {
+				t.Fatalf("Expected %d jobs, got %d", len(tt.expected), len(jobs))
+			}
+			for i, job := range jobs {
+				if job.ID != tt.expected[i] {
+					t.Errorf("Expected job %s at %d, got %s", tt.expected[i], i, job.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryQueue_Stats(t *testing.T) {
+	q := newTestMemoryQueue(t)
+	ctx := context.Background()
+
+	// 未启动时任务保留在队列中
+	_, _ = q.Enqueue(ctx, "echo", nil)
+	_, _ = q.Enqueue(ctx, "echo", nil)
+	_, _ = q.EnqueueIn(ctx, time.Hour, "echo", nil)
+	_ = q.bury(ctx, &Job{ID: "dead", Type: "echo"})
+
+	stats, err := q.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if stats.Ready != 2 || stats.Delayed != 1 || stats.Dead != 1 {
+		t.Errorf("Expected ready=2 delayed=1 dead=1, got %+v", stats)
+	}
+}
+
+func TestMemoryQueue_Stop(t *testing.T) {
+	q := newTestMemoryQueue(t)
+	ctx := context.Background()
+
+	started := make(chan struct{})
+	var finished atomic.Bool
+	q.Register("slow", func(ctx context.Context, job *Job) error {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		finished.Store(true)
+		return nil
+	})
+
+	if err := q.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if _, err := q.Enqueue(ctx, "slow", nil); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	<-started
+
+	// 停止时等待执行中的任务完成
+	if err := q.Stop(ctx); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+	if !finished.Load() {
+		t.Error("Expected in-flight job to finish before Stop returns")
+	}
+
+	// 重复停止无副作用
+	if err := q.Stop(ctx); err != nil {
+		t.Errorf("Expected repeated Stop to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/so68/core/config"
+)
+
+// moveDueScript 将到期的延迟任务原子地移动到就绪队列
+var moveDueScript = redis.NewScript(`
+local jobs = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+for _, job in ipairs(jobs) do
+	redis.call('ZREM', KEYS[1], job)
+	redis.call('LPUSH', KEYS[2], job)
+end
+return #jobs
+`)
+
+// RedisQueue 基于 Redis 的任务队列
+// - 就绪队列：list（LPUSH + BRPOP，先进先出）
+// - 延迟队列：zset（score 为执行时间）
+// - 死信队列：list
+type RedisQueue struct {
+	*workerPool
+	client redis.UniversalClient
+}
+
+// NewRedisQueue 创建 Redis 任务队列
+func NewRedisQueue(cfg *config.QueueConfig, client redis.UniversalClient, logger *slog.Logger) (*RedisQueue, error) {
+	if client == nil {
+		return nil, errors.New("redis queue requires a redis client")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	logger.Info("Redis queue initialized",
+		slog.String("name", cfg.Name),
+		slog.Int("concurrency", cfg.Concurrency),
+		slog.Int("max_retries", cfg.MaxRetries),
+	)
+
+	return &RedisQueue{
+		workerPool: newWorkerPool("redis", cfg, logger),
+		client:     client,
+	}, nil
+}
+
+// key 获取队列键（队列名使用哈希标签，保证集群模式下同一队列的键位于同一槽位）
+func (q *RedisQueue) key(kind string) string {
+	return q.config.Prefix + ":{" + q.config.Name + "}:" + kind
+}
+
+// Enqueue 投递任务（立即执行）
+func (q *RedisQueue) Enqueue(ctx context.Context, jobType string, payload interface{}) (*Job, error) {
+	job, err := newJob(jobType, payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.push(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// EnqueueIn 投递延迟任务
+func (q *RedisQueue) EnqueueIn(ctx context.Context, delay time.Duration, jobType string, payload interface{}) (*Job, error) {
+	job, err := newJob(jobType, payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.schedule(ctx, job, time.Now().Add(delay)); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// DeadJobs 获取死信队列中的任务
+func (q *RedisQueue) DeadJobs(ctx context.Context, start, stop int64) ([]*Job, error) {
+	values, err := q.client.LRange(ctx, q.key("dead"), start, stop).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead jobs: %w", err)
+	}
+	jobs := make([]*Job, 0, len(values))
+	for _, value := range values {
+		job := &Job{}
+		if err := json.Unmarshal([]byte(value), job); err != nil {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// Stats 获取队列统计（待执行、延迟、死信任务数）
+func (q *RedisQueue) Stats(ctx context.Context) (*Stats, error) {
+	pipe := q.client.Pipeline()
+	ready := pipe.LLen(ctx, q.key("ready"))
+	delayed := pipe.ZCard(ctx, q.key("delayed"))
+	dead := pipe.LLen(ctx, q.key("dead"))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get queue stats: %w", err)
+	}
+	return &Stats{Ready: ready.Val(), Delayed: delayed.Val(), Dead: dead.Val()}, nil
+}
+
+// Start 启动 worker 池与延迟任务调度（非阻塞）
+func (q *RedisQueue) Start(ctx context.Context) error {
+	q.start(q.scheduler, q.worker)
+	return nil
+}
+
+// Stop 停止接收新任务并等待执行中的任务完成（受 ctx 截止时间约束）
+func (q *RedisQueue) Stop(ctx context.Context) error {
+	return q.stop(ctx)
+}
+
+// push 推入就绪队列
+func (q *RedisQueue) push(ctx context.Context, job *Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to serialize job: %w", err)
+	}
+	if err := q.client.LPush(ctx, q.key("ready"), data).Err(); err != nil {
+		return fmt.Errorf("failed to enqueue job %s: %w", job.ID, err)
+	}
+	return nil
+}
+
+// schedule 推入延迟队列
+func (q *RedisQueue) schedule(ctx context.Context, job *Job, at time.Time) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to serialize job: %w", err)
+	}
+	if err := q.client.ZAdd(ctx, q.key("delayed"), redis.Z{Score: float64(at.UnixMilli()), Member: data}).Err(); err != nil {
+		return fmt.Errorf("failed to schedule job %s: %w", job.ID, err)
+	}
+	return nil
+}
+
+// bury 推入死信队列
+func (q *RedisQueue) bury(ctx context.Context, job *Job) error {
+	now := time.Now()
+	job.FailedAt = &now
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to serialize job: %w", err)
+	}
+	return q.client.LPush(ctx, q.key("dead"), data).Err()
+}
+
+// scheduler 定期将到期的延迟任务移动到就绪队列
+func (q *RedisQueue) scheduler(ctx context.Context) {
+	ticker := time.NewTicker(q.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+			err := moveDueScript.Run(ctx, q.client, []string{q.key("delayed"), q.key("ready")}, now, 100).Err()
+			if err != nil && !errors.Is(err, context.Canceled) {
+				q.logger.Warn("failed to move delayed jobs", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// worker 消费就绪队列
+func (q *RedisQueue) worker(ctx context.Context, id int) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		result, err := q.client.BRPop(ctx, time.Second, q.key("ready")).Result()
+		if err != nil {
+			if errors.Is(err, redis.Nil) || ctx.Err() != nil {
+				continue
+			}
+			q.logger.Warn("failed to pop job", slog.Int("worker", id), slog.Any("error", err))
+			time.Sleep(q.config.PollInterval)
+			continue
+		}
+
+		job := &Job{}
+		if err := json.Unmarshal([]byte(result[1]), job); err != nil {
+			q.logger.Error("failed to deserialize job", slog.String("data", result[1]), slog.Any("error", err))
+			continue
+		}
+
+		// 执行中的任务不受停止信号影响，保证已取出的任务被处理完成
+		q.process(context.WithoutCancel(ctx), q, job)
+	}
+}
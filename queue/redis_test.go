@@ -0,0 +1,152 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/so68/core/config"
+)
+
+/*
+Redis任务队列功能测试
+
+本文件用于测试RedisQueue结构体的各种功能特性，
+包括任务投递、延迟任务、失败重试、死信队列、优雅停止等。
+
+运行命令：
+go test -v -run "^Test.*Queue.*$"
+
+测试内容：
+1. 指数退避计算 (backoff)
+2. 任务投递与执行 (Enqueue, Register, Start)
+3. 延迟任务 (EnqueueIn)
+4. 失败重试与死信队列 (MaxRetries, DeadJobs)
+5. 优雅停止 (Stop)
+*/
+
+func TestQueueBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{attempts: 0, expected: time.Second},
+		{attempts: 1, expected: time.Second},
+		{attempts: 2, expected: 2 * time.Second},
+		{attempts: 3, expected: 4 * time.Second},
+		{attempts: 10, expected: 10 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("attempts_%d", tt.attempts), func(t *testing.T) {
+			if got := backoff(tt.attempts, time.Second, 10*time.Second); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// newTestRedisQueue 创建测试用队列，Redis 不可用时跳过
+func newTestRedisQueue(t *testing.T) *RedisQueue {
+	cfg := &config.QueueConfig{
+		Name:         fmt.Sprintf("test_%d", time.Now().UnixNano()),
+		Concurrency:  2,
+		MaxRetries:   1,
+		RetryBackoff: 10 * time.Millisecond,
+		PollInterval: 20 * time.Millisecond,
+	}
+	cfg.SetDefaults()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379", DB: 1})
+
+	q, err := NewRedisQueue(cfg, client, logger)
+	if err != nil {
+		t.Skipf("Skipping test: failed to connect to Redis: %v", err)
+	}
+	t.Cleanup(func() {
+		ctx := context.Background()
+		client.Del(ctx, q.key("ready"), q.key("delayed"), q.key("dead"))
+		client.Close()
+	})
+	return q
+}
+
+func TestRedisQueue_EnqueueAndProcess(t *testing.T) {
+	q := newTestRedisQueue(t)
+	ctx := context.Background()
+
+	var processed atomic.Int32
+	q.Register("echo", func(ctx context.Context, job *Job) error {
+		var payload map[string]string
+		if err := job.Bind(&payload); err != nil {
+			return err
+		}
+		if payload["hello"] != "world" {
+			return errors.New("unexpected payload")
+		}
+		processed.Add(1)
+		return nil
+	})
+
+	if err := q.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer q.Stop(ctx)
+
+	if _, err := q.Enqueue(ctx, "echo", map[string]string{"hello": "world"}); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	if _, err := q.EnqueueIn(ctx, 50*time.Millisecond, "echo", map[string]string{"hello": "world"}); err != nil {
+		t.Fatalf("EnqueueIn failed: %v", err)
+	}
+
+	deadline := time.Now().Add(3 * time.Second)
+	for processed.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(20 * time.Millisecond)
+	}
+	if processed.Load() != 2 {
+		t.Errorf("Expected 2 processed jobs, got %d", processed.Load())
+	}
+}
+
+func TestRedisQueue_RetryAndDeadLetter(t *testing.T) {
+	q := newTestRedisQueue(t)
+	ctx := context.Background()
+
+	var attempts atomic.Int32
+	q.Register("fail", func(ctx context.Context, job *Job) error {
+		attempts.Add(1)
+		return errors.New("always fail")
+	})
+
+	if err := q.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer q.Stop(ctx)
+
+	if _, err := q.Enqueue(ctx, "fail", nil); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+
+	var dead []*Job
+	deadline := time.Now().Add(3 * time.Second)
+	for len(dead) == 0 && time.Now().Before(deadline) {
+		time.Sleep(20 * time.Millisecond)
+		dead, _ = q.DeadJobs(ctx, 0, -1)
+	}
+	if len(dead) != 1 {
+		t.Fatalf("Expected 1 dead job, got %d", len(dead))
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("Expected 2 attempts (1 + 1 retry), got %d", attempts.Load())
+	}
+	if dead[0].LastError != "always fail" {
+		t.Errorf("Expected last error 'always fail', got '%s'", dead[0].LastError)
+	}
+}
@@ -0,0 +1,165 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+// store 队列存储（由各驱动实现）
+type store interface {
+	// schedule 推入延迟队列
+	schedule(ctx context.Context, job *Job, at time.Time) error
+	// bury 推入死信队列（负责设置 FailedAt）
+	bury(ctx context.Context, job *Job) error
+}
+
+// workerPool 队列通用逻辑：处理器注册、worker 池生命周期、失败重试与死信
+type workerPool struct {
+	driver   string
+	config   *config.QueueConfig
+	logger   *slog.Logger
+	handlers map[string]HandlerFunc
+	mutex    sync.RWMutex
+
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	running bool
+}
+
+// newWorkerPool 创建队列通用逻辑
+func newWorkerPool(driver string, cfg *config.QueueConfig, logger *slog.Logger) *workerPool {
+	return &workerPool{
+		driver:   driver,
+		config:   cfg,
+		logger:   logger,
+		handlers: make(map[string]HandlerFunc),
+	}
+}
+
+// Register 注册任务处理器
+func (w *workerPool) Register(jobType string, handler HandlerFunc) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.handlers[jobType] = handler
+}
+
+// start 启动延迟任务调度与 worker 池（重复调用无副作用）
+func (w *workerPool) start(scheduler func(ctx context.Context), consume func(ctx context.Context, id int)) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.running {
+		return
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.running = true
+
+	// 延迟任务调度
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		scheduler(runCtx)
+	}()
+
+	// worker 池
+	for i := 0; i < w.config.Concurrency; i++ {
+		w.wg.Add(1)
+		go func(id int) {
+			defer w.wg.Done()
+			consume(runCtx, id)
+		}(i)
+	}
+
+	w.logger.Info("Queue started", slog.String("driver", w.driver), slog.String("name", w.config.Name), slog.Int("workers", w.config.Concurrency))
+}
+
+// stop 停止接收新任务并等待执行中的任务完成（受 ctx 截止时间约束）
+func (w *workerPool) stop(ctx context.Context) error {
+	w.mutex.Lock()
+	if !w.running {
+		w.mutex.Unlock()
+		return nil
+	}
+	w.running = false
+	w.cancel()
+	w.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		w.logger.Info("Queue stopped", slog.String("driver", w.driver), slog.String("name", w.config.Name))
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("queue stop timeout: %w", ctx.Err())
+	}
+}
+
+// process 执行任务，失败时按指数退避重试，超过最大重试次数进入死信队列
+func (w *workerPool) process(ctx context.Context, s store, job *Job) {
+	w.mutex.RLock()
+	handler, ok := w.handlers[job.Type]
+	w.mutex.RUnlock()
+
+	job.Attempts++
+	var err error
+	if !ok {
+		err = fmt.Errorf("no handler registered for job type: %s", job.Type)
+	} else {
+		err = w.execute(ctx, handler, job)
+	}
+	if err == nil {
+		return
+	}
+
+	job.LastError = err.Error()
+	if !ok || job.Attempts > w.config.MaxRetries {
+		w.logger.Error("job failed permanently",
+			slog.String("job_id", job.ID),
+			slog.String("type", job.Type),
+			slog.Int("attempts", job.Attempts),
+			slog.Any("error", err),
+		)
+		if buryErr := s.bury(ctx, job); buryErr != nil {
+			w.logger.Error("failed to bury job", slog.String("job_id", job.ID), slog.Any("error", buryErr))
+		}
+		return
+	}
+
+	delay := backoff(job.Attempts, w.config.RetryBackoff, w.config.MaxRetryBackoff)
+	w.logger.Warn("job failed, will retry",
+		slog.String("job_id", job.ID),
+		slog.String("type", job.Type),
+		slog.Int("attempts", job.Attempts),
+		slog.Duration("retry_in", delay),
+		slog.Any("error", err),
+	)
+	if scheduleErr := s.schedule(ctx, job, time.Now().Add(delay)); scheduleErr != nil {
+		w.logger.Error("failed to reschedule job", slog.String("job_id", job.ID), slog.Any("error", scheduleErr))
+	}
+}
+
+// execute 执行任务处理器（捕获 panic 并应用超时）
+func (w *workerPool) execute(ctx context.Context, handler HandlerFunc, job *Job) (err error) {
+	if w.config.JobTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.config.JobTimeout)
+		defer cancel()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panic: %v", r)
+		}
+	}()
+	return handler(ctx, job)
+}
@@ -0,0 +1,195 @@
+package secret
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+const (
+	awsAlgorithm       = "AWS4-HMAC-SHA256"
+	awsService         = "secretsmanager"
+	awsAmzDateFormat   = "20060102T150405Z"
+	awsScopeDateFormat = "20060102"
+)
+
+// AWSProvider AWS Secrets Manager 密钥服务（GetSecretValue 接口，使用 Signature V4 签名）
+type AWSProvider struct {
+	endpoint     *url.URL
+	region       string
+	accessKey    string
+	secretKey    string
+	sessionToken string
+	client       *http.Client
+	now          func() time.Time
+}
+
+// awsSecretValue GetSecretValue 接口响应
+type awsSecretValue struct {
+	SecretString string `json:"SecretString"`
+	SecretBinary []byte `json:"SecretBinary"`
+}
+
+// awsError 接口错误响应
+type awsError struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+// NewAWSProvider 创建 AWS Secrets Manager 密钥服务（未配置的区域与凭证从环境变量读取）
+func NewAWSProvider(cfg *config.AWSSecretConfig, timeout time.Duration) (*AWSProvider, error) {
+	if cfg == nil {
+		cfg = &config.AWSSecretConfig{}
+	}
+	region := firstNonEmpty(cfg.Region, os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION"))
+	if region == "" {
+		return nil, errors.New("aws region is required")
+	}
+	p := &AWSProvider{
+		region:       region,
+		accessKey:    firstNonEmpty(cfg.AccessKeyID, os.Getenv("AWS_ACCESS_KEY_ID")),
+		secretKey:    firstNonEmpty(cfg.SecretAccessKey, os.Getenv("AWS_SECRET_ACCESS_KEY")),
+		sessionToken: firstNonEmpty(cfg.SessionToken, os.Getenv("AWS_SESSION_TOKEN")),
+		client:       &http.Client{Timeout: timeout},
+		now:          time.Now,
+	}
+	if p.accessKey == "" || p.secretKey == "" {
+		return nil, errors.New("aws access key is required")
+	}
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = "https://" + awsService + "." + region + ".amazonaws.com"
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid aws endpoint: %s", endpoint)
+	}
+	p.endpoint = u
+	return p, nil
+}
+
+// Fetch 读取密钥（path 为密钥名称或 ARN，JSON 对象格式的密钥内容可按字段引用）
+func (p *AWSProvider) Fetch(ctx context.Context, path string) (*Secret, error) {
+	payload, err := json.Marshal(map[string]string{"SecretId": path})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint.String(), bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("aws: create request failed: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	p.sign(req, payload)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("aws: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("aws: read response failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e awsError
+		_ = json.Unmarshal(body, &e)
+		if strings.HasSuffix(e.Type, "ResourceNotFoundException") {
+			return nil, fmt.Errorf("%w: aws %s", ErrNotFound, path)
+		}
+		return nil, fmt.Errorf("aws: unexpected status %d: %s %s", resp.StatusCode, e.Type, e.Message)
+	}
+
+	var value awsSecretValue
+	if err := json.Unmarshal(body, &value); err != nil {
+		return nil, fmt.Errorf("aws: decode response failed: %w", err)
+	}
+	raw := value.SecretString
+	if raw == "" && len(value.SecretBinary) > 0 {
+		raw = base64.StdEncoding.EncodeToString(value.SecretBinary)
+	}
+	secret := &Secret{Value: raw}
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(value.SecretString), &fields); err == nil {
+		secret.Data = make(map[string]string, len(fields))
+		for key, field := range fields {
+			secret.Data[key] = stringify(field)
+		}
+	}
+	return secret, nil
+}
+
+// sign 为请求添加 Signature V4 认证头
+func (p *AWSProvider) sign(req *http.Request, payload []byte) {
+	now := p.now().UTC()
+	req.Header.Set("X-Amz-Date", now.Format(awsAmzDateFormat))
+	if p.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", p.sessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "x-amz-") || lower == "content-type" {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	payloadHash := sha256.Sum256(payload)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		"",
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := now.Format(awsScopeDateFormat) + "/" + p.region + "/" + awsService + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{awsAlgorithm, now.Format(awsAmzDateFormat), scope, hex.EncodeToString(requestHash[:])}, "\n")
+	key := hmacSHA256([]byte("AWS4"+p.secretKey), now.Format(awsScopeDateFormat))
+	key = hmacSHA256(key, p.region)
+	key = hmacSHA256(key, awsService)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		awsAlgorithm, p.accessKey, scope, signedHeaders, signature))
+}
+
+// hmacSHA256 计算 HMAC-SHA256
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
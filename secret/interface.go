@@ -0,0 +1,88 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 内置密钥服务的引用前缀
+const (
+	SchemeVault = "vault"  // vault:secret/data/app#jwt_secret
+	SchemeAWS   = "aws-sm" // aws-sm:prod/app#jwt_secret
+)
+
+// ErrNotFound 密钥或字段不存在
+var ErrNotFound = errors.New("secret not found")
+
+// Secret 从密钥服务读取的密钥
+type Secret struct {
+	Value string            // 原始值（密钥内容不是 JSON 对象时使用）
+	Data  map[string]string // 字段值（Vault 数据或 JSON 对象格式的密钥内容）
+	TTL   time.Duration     // 有效期（租约时长，0 表示使用缓存时间）
+}
+
+// Field 获取字段值（key 为空时返回原始值，没有原始值且只有一个字段时返回该字段）
+func (s *Secret) Field(key string) (string, error) {
+	if key == "" {
+		if s.Value != "" {
+			return s.Value, nil
+		}
+		if len(s.Data) == 1 {
+			for _, value := range s.Data {
+				return value, nil
+			}
+		}
+		keys := make([]string, 0, len(s.Data))
+		for k := range s.Data {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return "", fmt.Errorf("secret has multiple fields, specify one of: %s", strings.Join(keys, ", "))
+	}
+	value, ok := s.Data[key]
+	if !ok {
+		return "", fmt.Errorf("%w: field %s", ErrNotFound, key)
+	}
+	return value, nil
+}
+
+// Provider 密钥服务
+type Provider interface {
+	// Fetch 读取密钥（path 为引用中前缀与 # 之间的部分）
+	Fetch(ctx context.Context, path string) (*Secret, error)
+}
+
+// Ref 密钥引用（scheme:path#key）
+type Ref struct {
+	Scheme string // 密钥服务
+	Path   string // 密钥路径
+	Key    string // 字段名（为空时使用整个密钥）
+}
+
+// String 引用字符串
+func (r Ref) String() string {
+	if r.Key == "" {
+		return r.Scheme + ":" + r.Path
+	}
+	return r.Scheme + ":" + r.Path + "#" + r.Key
+}
+
+// ParseRef 解析密钥引用（不含 : 或路径为空时返回 false）
+func ParseRef(value string) (Ref, bool) {
+	scheme, rest, ok := strings.Cut(value, ":")
+	if !ok || scheme == "" {
+		return Ref{}, false
+	}
+	path, key := rest, ""
+	if i := strings.LastIndex(rest, "#"); i >= 0 {
+		path, key = rest[:i], rest[i+1:]
+	}
+	if path == "" {
+		return Ref{}, false
+	}
+	return Ref{Scheme: scheme, Path: path, Key: key}, true
+}
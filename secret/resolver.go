@@ -0,0 +1,366 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+// secretConfigType 外部密钥配置本身不解析（服务地址与凭证需在解析前确定）
+var secretConfigType = reflect.TypeOf(config.SecretConfig{})
+
+// ChangeHandler 密钥续期后值发生变化时的回调（path 为配置路径，例如 jwt.secretKey）
+type ChangeHandler func(path string, value string)
+
+// Resolver 密钥解析器
+// - Resolve 将配置中的密钥引用替换为密钥值，同一密钥只读取一次
+// - 密钥按缓存时间（或 Vault 租约）过期，Start 后定期重新读取，值变化时通知 OnChange 注册的回调
+// - 配置字段只在 Resolve 时写入，续期后的值通过回调或 Get 获取
+type Resolver struct {
+	cacheTTL      time.Duration
+	renewInterval time.Duration
+	logger        *slog.Logger
+	now           func() time.Time
+
+	mu        sync.Mutex
+	providers map[string]Provider
+	cache     map[string]*cacheEntry
+	bindings  map[string]*binding
+	handlers  []ChangeHandler
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// cacheEntry 密钥缓存
+type cacheEntry struct {
+	secret    *Secret
+	expiresAt time.Time // 零值表示不过期
+}
+
+// binding 已解析的配置字段
+type binding struct {
+	ref   Ref
+	value string
+}
+
+// NewResolver 创建密钥解析器（配置了 Vault 地址或 AWS 区域时注册对应的密钥服务，logger 为 nil 时使用默认日志）
+func NewResolver(cfg *config.SecretConfig, logger *slog.Logger) (*Resolver, error) {
+	if cfg == nil {
+		cfg = config.DefaultSecretConfig()
+	}
+	r := &Resolver{
+		cacheTTL:      cfg.CacheTTL,
+		renewInterval: cfg.RenewInterval,
+		logger:        logger,
+		now:           time.Now,
+		providers:     make(map[string]Provider),
+		cache:         make(map[string]*cacheEntry),
+		bindings:      make(map[string]*binding),
+	}
+
+	if vault := cfg.Vault; (vault != nil && vault.Address != "") || os.Getenv("VAULT_ADDR") != "" {
+		p, err := NewVaultProvider(vault, cfg.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		r.providers[SchemeVault] = p
+	}
+	if aws := cfg.AWS; (aws != nil && aws.Region != "") || os.Getenv("AWS_REGION") != "" || os.Getenv("AWS_DEFAULT_REGION") != "" {
+		p, err := NewAWSProvider(aws, cfg.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		r.providers[SchemeAWS] = p
+	}
+	return r, nil
+}
+
+// Register 注册密钥服务（scheme 为引用前缀，同名时覆盖）
+func (r *Resolver) Register(scheme string, provider Provider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.providers[scheme] = provider
+}
+
+// OnChange 注册密钥值变化回调（例如轮换后重建数据库连接）
+func (r *Resolver) OnChange(handler ChangeHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers = append(r.handlers, handler)
+}
+
+// IsRef 是否为已注册密钥服务（或内置密钥服务）的引用
+func (r *Resolver) IsRef(value string) bool {
+	ref, ok := ParseRef(value)
+	if !ok {
+		return false
+	}
+	if ref.Scheme == SchemeVault || ref.Scheme == SchemeAWS {
+		return true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok = r.providers[ref.Scheme]
+	return ok
+}
+
+// Get 读取密钥引用对应的值（缓存未过期时不请求密钥服务）
+func (r *Resolver) Get(ctx context.Context, ref string) (string, error) {
+	parsed, ok := ParseRef(ref)
+	if !ok {
+		return "", fmt.Errorf("invalid secret reference: %s", ref)
+	}
+	return r.lookup(ctx, parsed)
+}
+
+// Resolve 将 target（结构体指针）中所有字符串形式的密钥引用替换为密钥值
+func (r *Resolver) Resolve(ctx context.Context, target interface{}) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return errors.New("secret: resolve target must be a non-nil pointer")
+	}
+	before := r.count()
+	if err := r.walk(ctx, v, ""); err != nil {
+		return err
+	}
+	if resolved := r.count() - before; resolved > 0 {
+		r.log().Info("Secrets resolved", slog.Int("count", resolved))
+	}
+	return nil
+}
+
+// Renew 重新读取即将过期的密钥，值变化时通知回调（读取失败时继续使用旧值）
+func (r *Resolver) Renew(ctx context.Context) error {
+	deadline := r.now().Add(r.renewInterval)
+	r.mu.Lock()
+	var keys []string
+	for key, entry := range r.cache {
+		if !entry.expiresAt.IsZero() && !deadline.Before(entry.expiresAt) {
+			keys = append(keys, key)
+		}
+	}
+	r.mu.Unlock()
+
+	var errs []error
+	for _, key := range keys {
+		scheme, path, _ := strings.Cut(key, ":")
+		secret, err := r.fetch(ctx, scheme, path, true)
+		if err != nil {
+			r.log().Warn("Secret renewal failed", slog.String("ref", key), slog.Any("error", err))
+			errs = append(errs, err)
+			continue
+		}
+		r.notify(key, secret)
+	}
+	return errors.Join(errs...)
+}
+
+// Start 启动后台续期（续期间隔为 0 时不启动）
+func (r *Resolver) Start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.renewInterval <= 0 || r.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+	go func() {
+		defer close(r.done)
+		ticker := time.NewTicker(r.renewInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_ = r.Renew(ctx)
+			}
+		}
+	}()
+}
+
+// Stop 停止后台续期
+func (r *Resolver) Stop() {
+	r.mu.Lock()
+	cancel, done := r.cancel, r.done
+	r.cancel, r.done = nil, nil
+	r.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+// walk 递归遍历并替换密钥引用（路径使用 yaml 标签名称）
+func (r *Resolver) walk(ctx context.Context, v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || v.Type().Elem() == secretConfigType {
+			return nil
+		}
+		return r.walk(ctx, v.Elem(), path)
+	case reflect.Interface:
+		if v.IsNil() || !v.CanSet() {
+			return nil
+		}
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+		if err := r.walk(ctx, elem, path); err != nil {
+			return err
+		}
+		v.Set(elem)
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() || field.Type == secretConfigType {
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			if err := r.walk(ctx, v.Field(i), joinPath(path, name)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := r.walk(ctx, v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		// 映射值不可寻址，复制后解析再写回
+		for _, key := range v.MapKeys() {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(key))
+			if err := r.walk(ctx, elem, joinPath(path, fmt.Sprint(key.Interface()))); err != nil {
+				return err
+			}
+			v.SetMapIndex(key, elem)
+		}
+	case reflect.String:
+		if !v.CanSet() || !r.IsRef(v.String()) {
+			return nil
+		}
+		ref, _ := ParseRef(v.String())
+		value, err := r.lookup(ctx, ref)
+		if err != nil {
+			return fmt.Errorf("resolve secret %s (%s): %w", path, ref, err)
+		}
+		v.SetString(value)
+		r.mu.Lock()
+		r.bindings[path] = &binding{ref: ref, value: value}
+		r.mu.Unlock()
+	}
+	return nil
+}
+
+// lookup 读取引用对应的字段值
+func (r *Resolver) lookup(ctx context.Context, ref Ref) (string, error) {
+	secret, err := r.fetch(ctx, ref.Scheme, ref.Path, false)
+	if err != nil {
+		return "", err
+	}
+	return secret.Field(ref.Key)
+}
+
+// fetch 读取密钥（force 为 false 时优先使用未过期的缓存）
+func (r *Resolver) fetch(ctx context.Context, scheme string, path string, force bool) (*Secret, error) {
+	key := scheme + ":" + path
+	now := r.now()
+	r.mu.Lock()
+	entry := r.cache[key]
+	provider := r.providers[scheme]
+	r.mu.Unlock()
+	if !force && entry != nil && (entry.expiresAt.IsZero() || now.Before(entry.expiresAt)) {
+		return entry.secret, nil
+	}
+	if provider == nil {
+		return nil, fmt.Errorf("secret provider %s is not configured", scheme)
+	}
+
+	secret, err := provider.Fetch(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	ttl := r.cacheTTL
+	if secret.TTL > 0 && (ttl <= 0 || secret.TTL < ttl) {
+		ttl = secret.TTL
+	}
+	entry = &cacheEntry{secret: secret}
+	if ttl > 0 {
+		entry.expiresAt = now.Add(ttl)
+	}
+	r.mu.Lock()
+	r.cache[key] = entry
+	r.mu.Unlock()
+	return secret, nil
+}
+
+// notify 比较续期后的值，通知发生变化的配置字段
+func (r *Resolver) notify(key string, secret *Secret) {
+	type change struct{ path, value string }
+	var changes []change
+	r.mu.Lock()
+	for path, b := range r.bindings {
+		if b.ref.Scheme+":"+b.ref.Path != key {
+			continue
+		}
+		value, err := secret.Field(b.ref.Key)
+		if err != nil || value == b.value {
+			continue
+		}
+		b.value = value
+		changes = append(changes, change{path: path, value: value})
+	}
+	handlers := append([]ChangeHandler(nil), r.handlers...)
+	r.mu.Unlock()
+
+	for _, c := range changes {
+		r.log().Info("Secret rotated", slog.String("path", c.path), slog.String("ref", key))
+		for _, handler := range handlers {
+			handler(c.path, c.value)
+		}
+	}
+}
+
+// count 已解析的配置字段数量
+func (r *Resolver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.bindings)
+}
+
+// log 日志（未指定时使用默认日志，以便使用应用初始化后的日志配置）
+func (r *Resolver) log() *slog.Logger {
+	if r.logger != nil {
+		return r.logger
+	}
+	return slog.Default()
+}
+
+// joinPath 拼接配置路径
+func joinPath(parent string, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
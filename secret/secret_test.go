@@ -0,0 +1,286 @@
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+/*
+外部密钥测试
+
+本文件用于测试配置中密钥引用的解析、Vault 与 AWS Secrets Manager 读取以及缓存续期。
+
+运行命令：
+go test -v -run "^Test(ParseRef|Vault|AWS|Resolver).*$"
+
+测试内容：
+1. 引用解析 scheme:path#key (ParseRef)
+2. Vault KV v2 读取，同一密钥只请求一次，非引用配置保持不变 (VaultProvider, Resolve)
+3. AWS Secrets Manager 签名请求，JSON 字段与原始值引用 (AWSProvider)
+4. 缓存过期后续期，值变化时通知回调 (Renew, OnChange)
+5. 未配置的密钥服务、不存在的字段返回包含配置路径的错误
+*/
+
+// testLogger 丢弃输出的日志
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// newTestResolver 创建不注册内置密钥服务的解析器
+func newTestResolver(t *testing.T) *Resolver {
+	t.Helper()
+	t.Setenv("VAULT_ADDR", "")
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	resolver, err := NewResolver(config.DefaultSecretConfig(), testLogger())
+	if err != nil {
+		t.Fatalf("create resolver failed: %v", err)
+	}
+	return resolver
+}
+
+// fakeProvider 可修改返回值的密钥服务
+type fakeProvider struct {
+	mu    sync.Mutex
+	data  map[string]string
+	ttl   time.Duration
+	calls int
+}
+
+func (p *fakeProvider) Fetch(ctx context.Context, path string) (*Secret, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	data := make(map[string]string, len(p.data))
+	for k, v := range p.data {
+		data[k] = v
+	}
+	return &Secret{Data: data, TTL: p.ttl}, nil
+}
+
+func (p *fakeProvider) set(key, value string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.data[key] = value
+}
+
+func TestParseRef(t *testing.T) {
+	tests := []struct {
+		value string
+		ref   Ref
+		ok    bool
+	}{
+		{"vault:secret/data/app#jwt_secret", Ref{Scheme: "vault", Path: "secret/data/app", Key: "jwt_secret"}, true},
+		{"aws-sm:prod/db", Ref{Scheme: "aws-sm", Path: "prod/db"}, true},
+		{"aws-sm:arn:aws:secretsmanager:us-east-1:123:secret:app#password", Ref{Scheme: "aws-sm", Path: "arn:aws:secretsmanager:us-east-1:123:secret:app", Key: "password"}, true},
+		{"plain-value", Ref{}, false},
+		{"vault:#key", Ref{}, false},
+	}
+	for _, tt := range tests {
+		ref, ok := ParseRef(tt.value)
+		if ok != tt.ok || ref != tt.ref {
+			t.Errorf("ParseRef(%q) = %+v, %v, want %+v, %v", tt.value, ref, ok, tt.ref, tt.ok)
+		}
+		if ok && ref.String() != tt.value {
+			t.Errorf("Ref.String() = %q, want %q", ref.String(), tt.value)
+		}
+	}
+}
+
+func TestVaultResolve(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("X-Vault-Token") != "root-token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		if r.URL.Path != "/v1/secret/data/app" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[]}`))
+			return
+		}
+		w.Write([]byte(`{"lease_duration":0,"data":{"data":{"jwt_secret":"s3cr3t","db_password":"p@ss","port":5432},"metadata":{"version":3}}}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	cfg := config.DefaultSecretConfig()
+	cfg.Vault = &config.VaultSecretConfig{Address: srv.URL, Token: "root-token"}
+	resolver, err := NewResolver(cfg, testLogger())
+	if err != nil {
+		t.Fatalf("create resolver failed: %v", err)
+	}
+
+	app := config.DefaultAppConfig()
+	app.JWT.SecretKey = "vault:secret/data/app#jwt_secret"
+	app.Database.Password = "vault:secret/data/app#db_password"
+	app.Databases = map[string]*config.DatabaseConfig{"report": {Driver: "mysql", Password: "vault:secret/data/app#db_password"}}
+	app.Signature.Clients = map[string]string{"partner": "vault:secret/data/app#jwt_secret"}
+	if err := resolver.Resolve(context.Background(), app); err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+
+	if app.JWT.SecretKey != "s3cr3t" || app.Database.Password != "p@ss" {
+		t.Errorf("unexpected values: jwt=%q db=%q", app.JWT.SecretKey, app.Database.Password)
+	}
+	if app.Databases["report"].Password != "p@ss" || app.Signature.Clients["partner"] != "s3cr3t" {
+		t.Errorf("map values not resolved: %+v %+v", app.Databases["report"], app.Signature.Clients)
+	}
+	if app.Database.Driver != config.DefaultDatabaseConfig().Driver {
+		t.Errorf("non-reference value changed: %q", app.Database.Driver)
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+
+	port, err := resolver.Get(context.Background(), "vault:secret/data/app#port")
+	if err != nil || port != "5432" {
+		t.Errorf("Get port = %q, %v", port, err)
+	}
+	if _, err := resolver.Get(context.Background(), "vault:secret/data/missing#key"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestAWSProvider(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/20240102/us-east-1/secretsmanager/aws4_request, SignedHeaders=content-type;host;x-amz-date;x-amz-security-token;x-amz-target, Signature=") {
+			t.Errorf("unexpected authorization: %s", auth)
+		}
+		if r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" || r.Header.Get("X-Amz-Security-Token") != "session" {
+			t.Errorf("unexpected headers: %v", r.Header)
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		switch body["SecretId"] {
+		case "prod/app":
+			w.Write([]byte(`{"Name":"prod/app","SecretString":"{\"jwt_secret\":\"aws-secret\",\"ttl\":30}"}`))
+		case "prod/token":
+			w.Write([]byte(`{"Name":"prod/token","SecretString":"raw-token"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"ResourceNotFoundException","message":"Secrets Manager can't find the specified secret."}`))
+		}
+	}))
+	defer srv.Close()
+
+	provider, err := NewAWSProvider(&config.AWSSecretConfig{
+		Region:          "us-east-1",
+		Endpoint:        srv.URL,
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "SECRET",
+		SessionToken:    "session",
+	}, time.Second)
+	if err != nil {
+		t.Fatalf("create provider failed: %v", err)
+	}
+	provider.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	resolver := newTestResolver(t)
+	resolver.Register(SchemeAWS, provider)
+	tests := map[string]string{
+		"aws-sm:prod/app#jwt_secret": "aws-secret",
+		"aws-sm:prod/app#ttl":        "30",
+		"aws-sm:prod/token":          "raw-token",
+	}
+	for ref, want := range tests {
+		got, err := resolver.Get(context.Background(), ref)
+		if err != nil || got != want {
+			t.Errorf("Get(%s) = %q, %v, want %q", ref, got, err, want)
+		}
+	}
+	if _, err := resolver.Get(context.Background(), "aws-sm:prod/missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestResolverRenew(t *testing.T) {
+	resolver := newTestResolver(t)
+	resolver.renewInterval = time.Minute
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	resolver.now = func() time.Time { return now }
+
+	provider := &fakeProvider{data: map[string]string{"password": "v1"}, ttl: 10 * time.Minute}
+	resolver.Register("fake", provider)
+	var changes []string
+	resolver.OnChange(func(path, value string) {
+		changes = append(changes, path+"="+value)
+	})
+
+	app := config.DefaultAppConfig()
+	app.Database.Password = "fake:db#password"
+	if err := resolver.Resolve(context.Background(), app); err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+
+	// 未到期时不重新读取
+	provider.set("password", "v2")
+	if err := resolver.Renew(context.Background()); err != nil {
+		t.Fatalf("renew failed: %v", err)
+	}
+	if provider.calls != 1 || len(changes) != 0 {
+		t.Fatalf("expected no renewal, calls=%d changes=%v", provider.calls, changes)
+	}
+
+	// 到期前一个续期间隔内重新读取，值变化时通知
+	now = now.Add(9*time.Minute + time.Second)
+	if err := resolver.Renew(context.Background()); err != nil {
+		t.Fatalf("renew failed: %v", err)
+	}
+	if provider.calls != 2 || len(changes) != 1 || changes[0] != "database.password=v2" {
+		t.Fatalf("unexpected renewal, calls=%d changes=%v", provider.calls, changes)
+	}
+	if value, _ := resolver.Get(context.Background(), "fake:db#password"); value != "v2" {
+		t.Errorf("expected cached v2, got %q", value)
+	}
+	// 配置字段只在 Resolve 时写入
+	if app.Database.Password != "v1" {
+		t.Errorf("config should keep resolved value, got %q", app.Database.Password)
+	}
+}
+
+func TestResolverErrors(t *testing.T) {
+	resolver := newTestResolver(t)
+	resolver.Register("fake", &fakeProvider{data: map[string]string{"a": "1", "b": "2"}})
+
+	app := config.DefaultAppConfig()
+	app.JWT.SecretKey = "vault:secret/data/app#jwt_secret"
+	err := resolver.Resolve(context.Background(), app)
+	if err == nil || !strings.Contains(err.Error(), "jwt.secretKey") || !strings.Contains(err.Error(), "not configured") {
+		t.Errorf("expected provider not configured error, got %v", err)
+	}
+
+	app = config.DefaultAppConfig()
+	app.JWT.SecretKey = "fake:app#missing"
+	if err := resolver.Resolve(context.Background(), app); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	app.JWT.SecretKey = "fake:app"
+	if err := resolver.Resolve(context.Background(), app); err == nil || !strings.Contains(err.Error(), "a, b") {
+		t.Errorf("expected multiple fields error, got %v", err)
+	}
+
+	// 未注册的前缀不是密钥引用
+	app.JWT.SecretKey = "redis:not-a-secret"
+	if err := resolver.Resolve(context.Background(), app); err != nil || app.JWT.SecretKey != "redis:not-a-secret" {
+		t.Errorf("unexpected resolve result: %q, %v", app.JWT.SecretKey, err)
+	}
+	if err := resolver.Resolve(context.Background(), *app); err == nil {
+		t.Error("expected error for non-pointer target")
+	}
+}
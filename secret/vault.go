@@ -0,0 +1,139 @@
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+// VaultProvider HashiCorp Vault 密钥服务（HTTP API，支持 KV v1/v2 与动态密钥）
+type VaultProvider struct {
+	address   *url.URL
+	token     string
+	tokenFile string
+	namespace string
+	client    *http.Client
+}
+
+// vaultResponse Vault 读取接口响应
+type vaultResponse struct {
+	LeaseDuration int                    `json:"lease_duration"`
+	Data          map[string]interface{} `json:"data"`
+	Errors        []string               `json:"errors"`
+}
+
+// NewVaultProvider 创建 Vault 密钥服务（未配置的地址、令牌与命名空间从环境变量读取）
+func NewVaultProvider(cfg *config.VaultSecretConfig, timeout time.Duration) (*VaultProvider, error) {
+	if cfg == nil {
+		cfg = &config.VaultSecretConfig{}
+	}
+	address := firstNonEmpty(cfg.Address, os.Getenv("VAULT_ADDR"))
+	if address == "" {
+		return nil, errors.New("vault address is required")
+	}
+	u, err := url.Parse(address)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid vault address: %s", address)
+	}
+	p := &VaultProvider{
+		address:   u,
+		token:     firstNonEmpty(cfg.Token, os.Getenv("VAULT_TOKEN")),
+		tokenFile: cfg.TokenFile,
+		namespace: firstNonEmpty(cfg.Namespace, os.Getenv("VAULT_NAMESPACE")),
+		client:    &http.Client{Timeout: timeout},
+	}
+	if p.token == "" && p.tokenFile == "" {
+		return nil, errors.New("vault token is required")
+	}
+	return p, nil
+}
+
+// Fetch 读取密钥（KV v2 路径需包含 data，例如 secret/data/app）
+func (p *VaultProvider) Fetch(ctx context.Context, path string) (*Secret, error) {
+	token, err := p.currentToken()
+	if err != nil {
+		return nil, err
+	}
+	endpoint := *p.address
+	endpoint.Path = strings.TrimRight(endpoint.Path, "/") + "/v1/" + strings.TrimLeft(path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("vault: create request failed: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("vault: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	var body vaultResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("vault: decode response failed: %w", err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: vault %s", ErrNotFound, path)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("vault: unexpected status %d: %s", resp.StatusCode, strings.Join(body.Errors, "; "))
+	}
+
+	data := body.Data
+	// KV v2 的字段位于 data.data 中
+	if inner, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = inner
+		}
+	}
+	secret := &Secret{Data: make(map[string]string, len(data)), TTL: time.Duration(body.LeaseDuration) * time.Second}
+	for key, value := range data {
+		secret.Data[key] = stringify(value)
+	}
+	return secret, nil
+}
+
+// currentToken 当前令牌（配置了令牌文件时每次读取，以获取 Vault Agent 续期后的令牌）
+func (p *VaultProvider) currentToken() (string, error) {
+	if p.tokenFile == "" {
+		return p.token, nil
+	}
+	data, err := os.ReadFile(p.tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("vault: read token file failed: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// stringify 将密钥字段转换为字符串（非字符串值使用 JSON 编码）
+func stringify(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// firstNonEmpty 返回第一个非空字符串
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
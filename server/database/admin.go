@@ -1,12 +1,17 @@
 package database
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
 	"database/sql/driver"
+	"encoding/base32"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
+	"github.com/pquerna/otp"
 	"github.com/pquerna/otp/totp"
 	"github.com/so68/core/database"
 	"golang.org/x/crypto/bcrypt"
@@ -28,11 +33,13 @@ const (
 type Admin struct {
 	database.BaseModel
 
-	// 用户名，唯一标识
-	Username string `gorm:"type:varchar(255);uniqueIndex;not null;comment:'用户名'" json:"username"`
-	// 邮箱地址，可选
+	// 所属租户ID（启用多租户时在租户请求中创建，未启用时为空）
+	TenantID string `gorm:"type:varchar(64);index;uniqueIndex:idx_admins_tenant_username,priority:1;not null;default:'';comment:'租户ID'" json:"tenant_id"`
+	// 用户名，租户内唯一
+	Username string `gorm:"type:varchar(255);uniqueIndex:idx_admins_tenant_username,priority:2;not null;comment:'用户名'" json:"username"`
+	// 邮箱地址，可选（全局唯一，用于找回密码与第三方账号关联）
 	Email string `gorm:"type:varchar(255);default:null;uniqueIndex;comment:'邮箱'" json:"email"`
-	// 手机号码，可选
+	// 手机号码，可选（全局唯一）
 	Telephone string `gorm:"type:varchar(255);default:null;uniqueIndex;comment:'手机号'" json:"telephone"`
 	// 密码哈希值，不返回给前端
 	PasswordHash string `gorm:"type:varchar(255);comment:'密码哈希'" json:"-"`
@@ -48,6 +55,8 @@ type Admin struct {
 	LockedUntil time.Time `gorm:"comment:'锁定截止时间'" json:"locked_until"`
 	// 登录失败次数
 	FailedLoginAttempts int8 `gorm:"default:0;comment:'登录失败次数'" json:"-"`
+	// 连续锁定次数（用于计算指数退避的锁定时长，登录成功或手动解锁后清零）
+	LockoutCount int `gorm:"default:0;comment:'连续锁定次数'" json:"-"`
 	// 最后登录时间
 	LastLoginAt time.Time `gorm:"comment:'最后登录时间'" json:"last_login_at"`
 	// 最后登录IP地址
@@ -65,7 +74,13 @@ type Admin struct {
 	// 是否启用MFA双因素认证
 	IsMFAEnabled bool `gorm:"not null;default:false;comment:'是否启用MFA'" json:"is_mfa_enabled"`
 	// MFA密钥哈希或加密值
-	MFASecret string `gorm:"type:varchar(255);comment:'MFA密钥哈希或加密值'" json:"mfa_secret"`
+	MFASecret string `gorm:"type:varchar(255);comment:'MFA密钥哈希或加密值'" json:"-"`
+	// 最近一次通过校验的 MFA 验证码时间步（防止验证码重放）
+	MFALastStep int64 `gorm:"default:0;comment:'MFA最近通过的时间步'" json:"-"`
+	// MFA 连续验证失败次数
+	MFAFailedAttempts int `gorm:"default:0;comment:'MFA验证失败次数'" json:"-"`
+	// MFA 最近一次验证失败时间
+	MFAFailedAt time.Time `gorm:"comment:'MFA最近验证失败时间'" json:"-"`
 	// 客服链接
 	ChatURL string `gorm:"type:varchar(255);comment:'客服链接'" json:"chat_url"`
 	// 数据
@@ -81,6 +96,36 @@ type Admin struct {
 type AdminData struct {
 	// 白名单 - 用 逗号 分隔
 	WhiteList string `json:"white_list" form:"white_list" views:"label:白名单;type:textarea;"`
+	// 关闭新设备登录邮件提醒
+	DisableLoginNotify bool `json:"disable_login_notify" form:"disable_login_notify" views:"label:关闭登录提醒;type:switch;"`
+	// 关闭账户锁定邮件提醒
+	DisableLockoutNotify bool `json:"disable_lockout_notify" form:"disable_lockout_notify" views:"label:关闭锁定提醒;type:switch;"`
+	// 已知登录设备指纹(IP + UA 哈希)，用于识别新设备登录
+	KnownDevices []string `json:"known_devices,omitempty" form:"-"`
+}
+
+// MaxKnownDevices 最多记录的已知登录设备数量
+const MaxKnownDevices = 20
+
+// HasKnownDevice 检查设备指纹是否已知
+func (d *AdminData) HasKnownDevice(fingerprint string) bool {
+	for _, device := range d.KnownDevices {
+		if device == fingerprint {
+			return true
+		}
+	}
+	return false
+}
+
+// AddKnownDevice 记录设备指纹（超出上限时淘汰最早的记录）
+func (d *AdminData) AddKnownDevice(fingerprint string) {
+	if d.HasKnownDevice(fingerprint) {
+		return
+	}
+	d.KnownDevices = append(d.KnownDevices, fingerprint)
+	if len(d.KnownDevices) > MaxKnownDevices {
+		d.KnownDevices = d.KnownDevices[len(d.KnownDevices)-MaxKnownDevices:]
+	}
 }
 
 // Value 实现 driver.Valuer 接口
@@ -104,11 +149,35 @@ func (a *Admin) BeforeCreate(tx *gorm.DB) error {
 		return err
 	}
 
+	// 生成安全码（唯一索引，不能为空字符串）
+	if a.SecurityKey == "" {
+		key := make([]byte, 16)
+		if _, err := rand.Read(key); err != nil {
+			return fmt.Errorf("生成安全码失败: %w", err)
+		}
+		a.SecurityKey = hex.EncodeToString(key)
+	}
+
 	// 生成google 验证器密钥
 	a.GenerateGoogleAuthSecret()
 	return nil
 }
 
+// BeforeSave GORM 钩子：邮箱与手机号为空时不写入，保持 NULL（唯一索引允许多个 NULL，空字符串会冲突）
+// - 没有上级时同样不写入上级管理员ID，避免 0 违反上级外键约束
+func (a *Admin) BeforeSave(tx *gorm.DB) error {
+	if a.Email == "" {
+		tx.Statement.Omit("email")
+	}
+	if a.Telephone == "" {
+		tx.Statement.Omit("telephone")
+	}
+	if a.ParentID == 0 {
+		tx.Statement.Omit("parent_id")
+	}
+	return nil
+}
+
 // CompareHashAndPassword 比较密码哈希值
 func (a *Admin) CompareHashAndPassword(password string) bool {
 	return bcrypt.CompareHashAndPassword([]byte(a.PasswordHash), []byte(password)) == nil
@@ -124,6 +193,25 @@ func (a *Admin) GeneratePasswordHash(password string) error {
 	return nil
 }
 
+// GoogleAuthStep 验证 Google Authenticator 验证码，返回验证码对应的时间步（允许前后1个时间窗口）
+func (a *Admin) GoogleAuthStep(code string) (int64, bool) {
+	if a.MFASecret == "" || len(code) != 6 {
+		return 0, false
+	}
+	now := time.Now()
+	for skew := -1; skew <= 1; skew++ {
+		t := now.Add(time.Duration(skew) * 30 * time.Second)
+		expected, err := totp.GenerateCodeCustom(a.MFASecret, t, totp.ValidateOpts{Period: 30, Digits: otp.DigitsSix})
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return t.Unix() / 30, true
+		}
+	}
+	return 0, false
+}
+
 // VerifyGoogleAuthCode 验证 Google Authenticator 验证码
 func (a *Admin) VerifyGoogleAuthCode(code string) bool {
 	// 使用 Google Authenticator 库验证验证码
@@ -157,23 +245,29 @@ func (a *Admin) GenerateGoogleAuthSecret() error {
 	return nil
 }
 
-// GetGoogleAuthQRCode 获取 Google Authenticator 二维码内容
-func (a *Admin) GetGoogleAuthQRCode() (string, error) {
+// GetGoogleAuthQRCode 获取 Google Authenticator 二维码内容（otpauth:// 格式的密钥配置地址）
+func (a *Admin) GetGoogleAuthQRCode(issuer string) (string, error) {
 	if a.MFASecret == "" {
 		return "", errors.New("MFA密钥未设置")
 	}
 
+	// 密钥以 Base32 保存，生成二维码时需还原为原始字节
+	secret, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(a.MFASecret)
+	if err != nil {
+		return "", fmt.Errorf("MFA密钥无效: %w", err)
+	}
+
 	// 生成二维码内容
 	key, err := totp.Generate(totp.GenerateOpts{
-		Issuer:      "Admin System",
+		Issuer:      issuer,
 		AccountName: a.Username,
-		Secret:      []byte(a.MFASecret),
+		Secret:      secret,
 	})
 	if err != nil {
 		return "", fmt.Errorf("生成二维码失败: %v", err)
 	}
 
-	return key.Secret(), nil
+	return key.URL(), nil
 }
 
 // IsLocked 检查管理员账户是否被锁定
@@ -189,3 +283,8 @@ func (a *Admin) IsLocked() bool {
 
 	return true
 }
+
+// DataScopeColumn 数据权限字段（管理员按自身ID过滤，仅可访问自身及下级管理员）
+func (a *Admin) DataScopeColumn() string {
+	return "id"
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"strings"
+	"time"
+
+	"github.com/so68/core/database"
+)
+
+// AdminAPIKey 管理员 API Key（服务端对接使用，通过请求头 X-API-Key 认证）
+type AdminAPIKey struct {
+	database.BaseModel
+
+	// 所属租户ID（创建时为当前请求的租户，未启用多租户时为空）
+	TenantID string `gorm:"type:varchar(64);index;not null;default:'';comment:'租户ID'" json:"tenant_id"`
+	// 所属管理员ID
+	AdminID uint `gorm:"index;not null;comment:'管理员ID'" json:"admin_id"`
+	// 名称
+	Name string `gorm:"type:varchar(100);not null;comment:'名称'" json:"name"`
+	// Key 前缀，用于展示和识别
+	Prefix string `gorm:"type:varchar(20);index;comment:'Key前缀'" json:"prefix"`
+	// Key 哈希值，不返回给前端
+	KeyHash string `gorm:"type:varchar(64);uniqueIndex;not null;comment:'Key哈希'" json:"-"`
+	// 轮换前的 Key 哈希值，宽限期内仍可使用
+	PreviousKeyHash string `gorm:"type:varchar(64);index;comment:'轮换前Key哈希'" json:"-"`
+	// 轮换前的 Key 失效时间
+	PreviousExpiresAt *time.Time `gorm:"comment:'轮换前Key失效时间'" json:"previous_expires_at"`
+	// 授权范围 - 用 逗号 分隔
+	Scopes string `gorm:"type:varchar(255);not null;comment:'授权范围'" json:"scopes"`
+	// 每分钟请求上限，0 表示不限制
+	RateLimit int `gorm:"default:0;comment:'每分钟请求上限'" json:"rate_limit"`
+	// 过期时间，为空表示永不过期
+	ExpiresAt *time.Time `gorm:"comment:'过期时间'" json:"expires_at"`
+	// 最后轮换时间
+	RotatedAt *time.Time `gorm:"comment:'最后轮换时间'" json:"rotated_at"`
+	// 最后使用时间
+	LastUsedAt *time.Time `gorm:"comment:'最后使用时间'" json:"last_used_at"`
+	// 最后使用IP地址
+	LastUsedIP string `gorm:"type:varchar(255);comment:'最后使用IP'" json:"last_used_ip"`
+	// 吊销时间
+	RevokedAt *time.Time `gorm:"comment:'吊销时间'" json:"revoked_at"`
+
+	// 所属管理员关联
+	Admin *Admin `gorm:"foreignKey:AdminID;references:ID" json:"admin,omitempty"`
+}
+
+// GetScopes 获取授权范围列表
+func (k *AdminAPIKey) GetScopes() []string {
+	scopes := make([]string, 0)
+	for _, scope := range strings.Split(k.Scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// IsActive 检查 Key 是否可用（未吊销且未过期）
+func (k *AdminAPIKey) IsActive() bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	if k.ExpiresAt != nil && k.ExpiresAt.Before(time.Now()) {
+		return false
+	}
+	return true
+}
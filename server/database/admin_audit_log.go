@@ -0,0 +1,33 @@
+package database
+
+import (
+	"github.com/so68/core/database"
+)
+
+// AdminAuditLog 管理员操作审计日志（记录已认证管理员的写操作）
+type AdminAuditLog struct {
+	database.BaseModel
+
+	// 操作管理员ID
+	AdminID uint `gorm:"index;not null;comment:'管理员ID'" json:"admin_id"`
+	// 操作名称（路由注册名称）
+	Name string `gorm:"type:varchar(100);index;comment:'操作名称'" json:"name"`
+	// 请求方法
+	Method string `gorm:"type:varchar(10);comment:'请求方法'" json:"method"`
+	// 请求路径
+	Path string `gorm:"type:varchar(255);comment:'请求路径'" json:"path"`
+	// 请求参数（已脱敏，超出长度截断）
+	Params string `gorm:"type:text;comment:'请求参数'" json:"params"`
+	// 客户端IP
+	IP string `gorm:"type:varchar(255);comment:'客户端IP'" json:"ip"`
+	// 客户端 User-Agent
+	UserAgent string `gorm:"type:varchar(255);comment:'User-Agent'" json:"user_agent"`
+	// 响应状态码
+	Status int `gorm:"comment:'响应状态码'" json:"status"`
+	// 是否成功（HTTP 状态码与业务状态码均成功）
+	Success bool `gorm:"index;comment:'是否成功'" json:"success"`
+	// 失败信息
+	Message string `gorm:"type:varchar(500);comment:'失败信息'" json:"message"`
+	// 耗时(毫秒)
+	Latency int64 `gorm:"comment:'耗时(毫秒)'" json:"latency"`
+}
@@ -0,0 +1,21 @@
+package database
+
+import (
+	"github.com/so68/core/database"
+)
+
+// AdminIdentity 管理员绑定的第三方账号（同一登录方式下第三方账号唯一）
+type AdminIdentity struct {
+	database.BaseModel
+
+	// 管理员ID
+	AdminID uint `gorm:"index;not null;comment:'管理员ID'" json:"admin_id"`
+	// 登录方式名称
+	Provider string `gorm:"type:varchar(50);uniqueIndex:idx_admin_identity_subject,priority:1;not null;comment:'登录方式'" json:"provider"`
+	// 第三方账号唯一标识
+	Subject string `gorm:"type:varchar(255);uniqueIndex:idx_admin_identity_subject,priority:2;not null;comment:'第三方账号标识'" json:"subject"`
+	// 第三方账号邮箱
+	Email string `gorm:"type:varchar(255);comment:'第三方账号邮箱'" json:"email"`
+	// 第三方账号名称
+	Name string `gorm:"type:varchar(255);comment:'第三方账号名称'" json:"name"`
+}
@@ -0,0 +1,29 @@
+package database
+
+import (
+	"github.com/so68/core/database"
+)
+
+// AdminLoginLog 管理员登录日志（记录每次登录尝试）
+type AdminLoginLog struct {
+	database.BaseModel
+
+	// 管理员ID（用户名不存在时为 0）
+	AdminID uint `gorm:"index;comment:'管理员ID'" json:"admin_id"`
+	// 登录用户名
+	Username string `gorm:"type:varchar(255);index;comment:'用户名'" json:"username"`
+	// 登录IP
+	IP string `gorm:"type:varchar(255);index;comment:'登录IP'" json:"ip"`
+	// 登录设备 User-Agent
+	UserAgent string `gorm:"type:varchar(255);comment:'User-Agent'" json:"user_agent"`
+	// 是否登录成功
+	Success bool `gorm:"index;comment:'是否成功'" json:"success"`
+	// 失败原因
+	Reason string `gorm:"type:varchar(255);comment:'失败原因'" json:"reason"`
+	// 是否启用MFA
+	MFAEnabled bool `gorm:"comment:'是否启用MFA'" json:"mfa_enabled"`
+	// MFA是否验证通过
+	MFAPassed bool `gorm:"comment:'MFA是否验证通过'" json:"mfa_passed"`
+	// 是否因本次失败被锁定
+	Locked bool `gorm:"comment:'是否触发锁定'" json:"locked"`
+}
@@ -0,0 +1,30 @@
+package database
+
+import (
+	"github.com/so68/core/database"
+)
+
+// AdminMenu 后台菜单（叶子菜单通过 Permission 关联 AuthHandler 注册的路由权限）
+type AdminMenu struct {
+	database.BaseModel
+
+	// 上级菜单ID，0 表示顶级菜单
+	ParentID uint `gorm:"index;default:0;comment:'上级菜单ID'" json:"parent_id"`
+	// 菜单标识，代码注册的菜单按标识同步
+	Key string `gorm:"type:varchar(100);uniqueIndex;not null;comment:'菜单标识'" json:"key"`
+	// 菜单标题
+	Title string `gorm:"type:varchar(100);not null;comment:'菜单标题'" json:"title"`
+	// 前端路由路径
+	Path string `gorm:"type:varchar(255);comment:'前端路由路径'" json:"path"`
+	// 图标
+	Icon string `gorm:"type:varchar(100);comment:'图标'" json:"icon"`
+	// 排序，越小越靠前
+	Sort int `gorm:"default:0;comment:'排序'" json:"sort"`
+	// 关联的权限名称（AuthHandler 注册路由时的名称），为空时不校验权限
+	Permission string `gorm:"type:varchar(100);comment:'权限名称'" json:"permission"`
+	// 是否隐藏
+	Hidden bool `gorm:"not null;default:false;comment:'是否隐藏'" json:"hidden"`
+
+	// 子菜单
+	Children []*AdminMenu `gorm:"-" json:"children,omitempty"`
+}
@@ -0,0 +1,21 @@
+package database
+
+import (
+	"time"
+
+	"github.com/so68/core/database"
+)
+
+// AdminMFARecoveryCode 管理员 MFA 恢复码（一次性使用，仅保存哈希值）
+type AdminMFARecoveryCode struct {
+	database.BaseModel
+
+	// 管理员ID
+	AdminID uint `gorm:"index;not null;comment:'管理员ID'" json:"admin_id"`
+	// 恢复码哈希值
+	CodeHash string `gorm:"type:varchar(64);index;not null;comment:'恢复码哈希'" json:"-"`
+	// 是否已使用
+	Used bool `gorm:"not null;default:false;comment:'是否已使用'" json:"used"`
+	// 使用时间
+	UsedAt time.Time `gorm:"comment:'使用时间'" json:"used_at"`
+}
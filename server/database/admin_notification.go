@@ -0,0 +1,33 @@
+package database
+
+import (
+	"time"
+
+	"github.com/so68/core/database"
+)
+
+// 通知类型
+const (
+	NotificationTypeSystem = "system" // 系统通知
+	NotificationTypeAction = "action" // 管理员操作通知
+)
+
+// AdminNotification 管理员站内通知（每个接收者一条记录）
+type AdminNotification struct {
+	database.BaseModel
+
+	// 接收管理员ID
+	AdminID uint `gorm:"index:idx_admin_notification_unread,priority:1;not null;comment:'接收管理员ID'" json:"admin_id"`
+	// 发送管理员ID，系统通知为 0
+	SenderID uint `gorm:"default:0;comment:'发送管理员ID'" json:"sender_id"`
+	// 通知类型
+	Type string `gorm:"type:varchar(50);index;comment:'通知类型'" json:"type"`
+	// 标题
+	Title string `gorm:"type:varchar(255);not null;comment:'标题'" json:"title"`
+	// 内容
+	Content string `gorm:"type:text;comment:'内容'" json:"content"`
+	// 跳转链接（前端路由或外部地址）
+	Link string `gorm:"type:varchar(255);comment:'跳转链接'" json:"link"`
+	// 阅读时间，未读为空
+	ReadAt *time.Time `gorm:"index:idx_admin_notification_unread,priority:2;comment:'阅读时间'" json:"read_at"`
+}
@@ -0,0 +1,15 @@
+package database
+
+import (
+	"github.com/so68/core/database"
+)
+
+// AdminPasswordHistory 管理员历史密码（用于禁止重复使用最近的密码）
+type AdminPasswordHistory struct {
+	database.BaseModel
+
+	// 管理员ID
+	AdminID uint `gorm:"index;not null;comment:'管理员ID'" json:"admin_id"`
+	// 密码哈希值
+	PasswordHash string `gorm:"type:varchar(255);not null;comment:'密码哈希'" json:"-"`
+}
@@ -0,0 +1,59 @@
+package database
+
+import (
+	"strings"
+	"time"
+
+	"github.com/so68/core/database"
+)
+
+// AdminToken 管理员机器令牌（长期有效的 API Token）
+type AdminToken struct {
+	database.BaseModel
+
+	// 所属租户ID（创建时为当前请求的租户，未启用多租户时为空）
+	TenantID string `gorm:"type:varchar(64);index;not null;default:'';comment:'租户ID'" json:"tenant_id"`
+	// 所属管理员ID
+	AdminID uint `gorm:"index;not null;comment:'管理员ID'" json:"admin_id"`
+	// 令牌名称
+	Name string `gorm:"type:varchar(100);not null;comment:'令牌名称'" json:"name"`
+	// 令牌前缀，用于展示和识别
+	Prefix string `gorm:"type:varchar(20);index;comment:'令牌前缀'" json:"prefix"`
+	// 令牌哈希值，不返回给前端
+	TokenHash string `gorm:"type:varchar(64);uniqueIndex;not null;comment:'令牌哈希'" json:"-"`
+	// 授权范围 - 用 逗号 分隔
+	Scopes string `gorm:"type:varchar(255);not null;comment:'授权范围'" json:"scopes"`
+	// 过期时间，为空表示永不过期
+	ExpiresAt *time.Time `gorm:"comment:'过期时间'" json:"expires_at"`
+	// 最后使用时间
+	LastUsedAt *time.Time `gorm:"comment:'最后使用时间'" json:"last_used_at"`
+	// 最后使用IP地址
+	LastUsedIP string `gorm:"type:varchar(255);comment:'最后使用IP'" json:"last_used_ip"`
+	// 吊销时间
+	RevokedAt *time.Time `gorm:"comment:'吊销时间'" json:"revoked_at"`
+
+	// 所属管理员关联
+	Admin *Admin `gorm:"foreignKey:AdminID;references:ID" json:"admin,omitempty"`
+}
+
+// GetScopes 获取授权范围列表
+func (t *AdminToken) GetScopes() []string {
+	scopes := make([]string, 0)
+	for _, scope := range strings.Split(t.Scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// IsActive 检查令牌是否可用（未吊销且未过期）
+func (t *AdminToken) IsActive() bool {
+	if t.RevokedAt != nil {
+		return false
+	}
+	if t.ExpiresAt != nil && t.ExpiresAt.Before(time.Now()) {
+		return false
+	}
+	return true
+}
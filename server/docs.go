@@ -0,0 +1,97 @@
+package server
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/server/openapi"
+)
+
+// swaggerTemplate Swagger UI 页面（静态资源从 swagger-ui-dist 加载）
+var swaggerTemplate = template.Must(template.New("swagger").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>{{.Title}}</title>
+  <link rel="stylesheet" href="{{.CDN}}/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="{{.CDN}}/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = function () {
+      window.ui = SwaggerUIBundle({ url: {{.SpecURL}}, dom_id: "#swagger-ui", persistAuthorization: true });
+    };
+  </script>
+</body>
+</html>
+`))
+
+// OpenAPI 根据已描述的路由生成 OpenAPI 文档（未描述的路由不包含在文档中）
+func (s *ginServer) OpenAPI() *openapi.Document {
+	info := openapi.Info{Title: s.cfg.Name, Version: "1.0.0"}
+	if cfg := s.cfg.OpenAPI; cfg != nil {
+		if cfg.Title != "" {
+			info.Title = cfg.Title
+		}
+		if cfg.Version != "" {
+			info.Version = cfg.Version
+		}
+		info.Description = cfg.Description
+	}
+	builder := openapi.NewBuilder(info)
+
+	s.routeMutex.RLock()
+	defer s.routeMutex.RUnlock()
+	for _, route := range s.engine.Routes() {
+		meta, ok := s.routeMetas[route.Method+" "+route.Path]
+		if !ok {
+			continue
+		}
+		operation := openapi.Route{
+			Method:  route.Method,
+			Path:    route.Path,
+			Summary: meta.Name,
+			Tags:    meta.Tags,
+			Secured: meta.Secured || meta.Permission != "",
+		}
+		if doc := meta.Doc; doc != nil {
+			operation.Request = doc.Request
+			operation.Response = doc.Response
+			operation.Page = doc.Page
+			operation.Description = doc.Description
+			if len(doc.Tags) > 0 {
+				operation.Tags = doc.Tags
+			}
+		}
+		builder.Add(operation)
+	}
+	return builder.Document()
+}
+
+// registerOpenAPI 注册 OpenAPI 文档与 Swagger UI 路由（文档在请求时生成，包含之后注册的路由）
+func (s *ginServer) registerOpenAPI() {
+	cfg := s.cfg.OpenAPI
+	s.engine.GET(cfg.Path, func(c *gin.Context) {
+		c.JSON(http.StatusOK, s.OpenAPI())
+	})
+	if !cfg.SwaggerUI {
+		return
+	}
+
+	title := cfg.Title
+	if title == "" {
+		title = s.cfg.Name
+	}
+	var page bytes.Buffer
+	if err := swaggerTemplate.Execute(&page, map[string]string{"Title": title, "CDN": cfg.SwaggerCDN, "SpecURL": cfg.Path}); err != nil {
+		s.logger.Error("Failed to render swagger ui", "error", err)
+		return
+	}
+	s.engine.GET(cfg.SwaggerPath, func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", page.Bytes())
+	})
+}
@@ -2,8 +2,13 @@ package server
 
 import (
 	"context"
+	"html/template"
+	"io/fs"
 
 	"github.com/gin-gonic/gin"
+	"github.com/so68/core/server/middleware"
+	"github.com/so68/core/server/openapi"
+	"github.com/so68/core/server/view"
 )
 
 type RouterMethod string
@@ -18,9 +23,50 @@ const (
 
 // RouterHandler 路由处理器
 type RouterHandler struct {
+	Name    string          // 路由名称（可选，用于路由列表）
 	Path    string          // 路径
 	Method  RouterMethod    // 方法
 	Handler gin.HandlerFunc // 处理器
+	Doc     *RouteDoc       // 接口文档（可选，用于生成 OpenAPI 文档）
+}
+
+// RouteDoc 接口文档
+// - Request 为请求参数 DTO，GET 请求按 form 标签生成查询参数，其余按 json 标签生成请求体
+// - Response 为响应数据 DTO，位于统一响应结构的 data 字段
+type RouteDoc struct {
+	Request     interface{} // 请求参数 DTO（例如 dto.AdminCreateParams{}）
+	Response    interface{} // 响应数据 DTO（例如 &models.Admin{}）
+	Page        bool        // 响应数据为分页结构（Response 为列表元素类型）
+	Description string      // 接口描述
+	Tags        []string    // 分组标签（为空时使用路由描述中的标签）
+}
+
+// Doc 创建接口文档
+func Doc(request interface{}, response interface{}) *RouteDoc {
+	return &RouteDoc{Request: request, Response: response}
+}
+
+// PageDoc 创建分页接口文档（item 为列表元素类型）
+func PageDoc(request interface{}, item interface{}) *RouteDoc {
+	return &RouteDoc{Request: request, Response: item, Page: true}
+}
+
+// RouteMeta 路由描述信息
+type RouteMeta struct {
+	Name       string    // 路由名称
+	Permission string    // 访问所需权限（Casbin 策略名称，无需权限时为空）
+	Secured    bool      // 是否需要认证（Permission 不为空时始终需要）
+	Tags       []string  // 分组标签
+	Doc        *RouteDoc // 接口文档
+}
+
+// RouteInfo 已注册的路由
+type RouteInfo struct {
+	Method     string `json:"method"`     // 请求方法
+	Path       string `json:"path"`       // 完整路径
+	Handler    string `json:"handler"`    // 处理函数名
+	Name       string `json:"name"`       // 路由名称
+	Permission string `json:"permission"` // 访问所需权限
 }
 
 // Server 服务器接口
@@ -31,11 +77,32 @@ type Server interface {
 	StartAsync() <-chan error
 	// 关闭服务器
 	Shutdown(ctx context.Context) error
+	// 注册关闭回调（Shutdown 时调用，用于关闭 WebSocket 等被劫持的长连接）
+	OnShutdown(fn func())
+	// 注册 panic 告警通知（例如发送邮件或 IM 告警）
+	OnPanic(notifier middleware.PanicNotifier)
 
+	// 添加全局中间件（需在创建路由组之前调用）
+	Use(middlewares ...gin.HandlerFunc)
 	// 创建路由组
 	NewGroup(relativePath string) *gin.RouterGroup
 	// 添加中间件
 	Middleware(group *gin.RouterGroup, middlewares ...gin.HandlerFunc) *gin.RouterGroup
 	// 注册路由
 	Register(group *gin.RouterGroup, handlers ...RouterHandler)
+	// 描述路由（名称与所需权限，path 为完整路径）
+	Describe(method string, path string, meta RouteMeta)
+	// 获取已注册的路由（含描述信息）
+	Routes() []RouteInfo
+	// 根据已描述的路由生成 OpenAPI 文档
+	OpenAPI() *openapi.Document
+	// 托管单页应用（history 模式，未匹配的页面路径返回入口文件）
+	SPA(relativePath string, fsys fs.FS)
+
+	// 加载 HTML 模板（embed.FS 或 os.DirFS，加载后 c.HTML 使用该模板引擎渲染）
+	LoadTemplates(fsys fs.FS, opts ...view.Option) error
+	// 添加模板函数（已加载模板时重新加载）
+	TemplateFuncs(funcs template.FuncMap) error
+	// 获取 HTML 模板引擎（未加载模板时为 nil）
+	Views() *view.Engine
 }
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/config"
+	"github.com/so68/core/logging"
+)
+
+// NewAccessLogMiddleware 创建一个基于 slog 的结构化访问日志中间件
+// - 需在请求上下文中间件之后注册，日志携带请求ID、链路追踪ID，认证后的请求携带管理员ID
+// - 状态码 >= 500 记录为 Error，>= 400 或慢请求记录为 Warn，其余按采样率记录为 Info
+// - 排除路径按前缀匹配
+func NewAccessLogMiddleware(cfg *config.AccessLogConfig) gin.HandlerFunc {
+	exclude := cfg.ExcludePaths
+	sampleRate := cfg.SampleRate
+	slowThreshold := cfg.SlowThreshold
+
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		for _, p := range exclude {
+			if p != "" && strings.HasPrefix(path, p) {
+				c.Next()
+				return
+			}
+		}
+
+		start := time.Now()
+		c.Next()
+		latency := time.Since(start)
+
+		status := c.Writer.Status()
+		slow := slowThreshold > 0 && latency > slowThreshold
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400 || slow:
+			level = slog.LevelWarn
+		case sampleRate < 1 && rand.Float64() >= sampleRate:
+			return
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", path),
+			slog.Int("status", status),
+			slog.Duration("latency", latency),
+			slog.Int("bytes", max(c.Writer.Size(), 0)),
+			slog.String("client_ip", c.ClientIP()),
+			slog.String("user_agent", c.Request.UserAgent()),
+		}
+		if query := c.Request.URL.RawQuery; query != "" {
+			attrs = append(attrs, slog.String("query", query))
+		}
+		if slow {
+			attrs = append(attrs, slog.Bool("slow", true))
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+
+		// 使用请求上下文日志器（认证中间件会为其追加管理员ID）
+		ctx := c.Request.Context()
+		logging.FromContext(ctx).LogAttrs(ctx, level, "http request", attrs...)
+	}
+}
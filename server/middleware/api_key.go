@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/server/module/admin/service"
+	"github.com/so68/core/server/utils"
+	"golang.org/x/time/rate"
+)
+
+// APIKeyHeader API Key 请求头
+const APIKeyHeader = "X-API-Key"
+
+// apiKeyLimiter 单个 API Key 的限流器及其对应的每分钟上限
+type apiKeyLimiter struct {
+	limiter   *rate.Limiter
+	rateLimit int
+}
+
+// NewAPIKeyMiddleware 创建一个 API Key 中间件
+// - 仅处理携带 X-API-Key 请求头的请求，其他请求交由机器令牌/JWT 中间件处理
+// - 按 Key 的每分钟上限限流（进程内），上限为 0 时不限制
+// - 校验通过后写入用户ID、租户与授权范围，由 casbin 中间件按范围限制访问
+func NewAPIKeyMiddleware(apiKeyService service.APIKeyService) gin.HandlerFunc {
+	var mutex sync.Mutex
+	limiters := make(map[uint]*apiKeyLimiter)
+
+	// 获取或创建指定 Key 的限流器，上限变更时重新创建
+	getLimiter := func(id uint, rateLimit int) *rate.Limiter {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		entry, ok := limiters[id]
+		if !ok || entry.rateLimit != rateLimit {
+			entry = &apiKeyLimiter{limiter: rate.NewLimiter(rate.Limit(float64(rateLimit)/60), rateLimit), rateLimit: rateLimit}
+			limiters[id] = entry
+		}
+		return entry.limiter
+	}
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(APIKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		apiKey, err := apiKeyService.Authenticate(c.Request.Context(), key, c.ClientIP())
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Key 所属的租户必须与请求解析的租户一致
+		if !bindTenant(c, apiKey.TenantID) {
+			return
+		}
+
+		if apiKey.RateLimit > 0 && !getLimiter(apiKey.ID, apiKey.RateLimit).Allow() {
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limited"})
+			return
+		}
+
+		// 设置用户ID与授权范围
+		utils.SetContextUserID(c, apiKey.AdminID)
+		c.Set(utils.ContextTokenScopesKey, apiKey.GetScopes())
+		c.Next()
+	}
+}
@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/logging"
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/module/admin/service"
+	"github.com/so68/core/server/utils"
+)
+
+const (
+	// auditResponseLimit 解析业务状态码时缓存的响应体最大字节数
+	auditResponseLimit = 4096
+	// auditMaskWindow 参数在截断前额外读取的字节数，先脱敏再截断，避免截断处的敏感值残留
+	auditMaskWindow = 1024
+)
+
+// auditWriter 缓存响应体前缀，用于解析业务状态码
+type auditWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write 写入响应并缓存前缀
+func (w *auditWriter) Write(data []byte) (int, error) {
+	if remain := auditResponseLimit - w.body.Len(); remain > 0 {
+		w.body.Write(data[:min(len(data), remain)])
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// NewAuditMiddleware 创建一个审计日志中间件（记录写操作的名称、参数、操作人、IP、耗时与结果）
+// - 需注册在认证中间件之后，请求参数按 masker 脱敏并截断到 maxParamsSize 字节
+// - 记录失败只输出日志，不影响请求
+func NewAuditMiddleware(name string, auditService service.AuditService, masker *logging.Masker, maxParamsSize int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		params := auditParams(c, maxParamsSize)
+		writer := &auditWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		start := time.Now()
+		c.Next()
+
+		log := &models.AdminAuditLog{
+			AdminID:   utils.GetContextUserID(c),
+			Name:      name,
+			Method:    c.Request.Method,
+			Path:      c.Request.URL.Path,
+			Params:    params,
+			IP:        c.ClientIP(),
+			UserAgent: truncate(c.Request.UserAgent(), 255),
+			Status:    c.Writer.Status(),
+			Latency:   time.Since(start).Milliseconds(),
+		}
+		log.Success, log.Message = auditResult(log.Status, writer.body.Bytes(), c.Errors)
+		if masker != nil {
+			log.Params = masker.MaskString(log.Params)
+		}
+		log.Params = truncate(log.Params, maxParamsSize)
+		log.Message = truncate(log.Message, 500)
+
+		// 请求结束后仍需写入，不继承请求取消
+		ctx := context.WithoutCancel(c.Request.Context())
+		if err := auditService.Record(ctx, log); err != nil {
+			logging.FromContext(ctx).Error("记录审计日志失败", "name", name, "error", err)
+		}
+	}
+}
+
+// auditParams 读取请求参数（查询参数与请求体前缀，读取后恢复请求体，由调用方脱敏后截断）
+func auditParams(c *gin.Context, limit int) string {
+	parts := make([]string, 0, 2)
+	if query := c.Request.URL.RawQuery; query != "" {
+		parts = append(parts, query)
+	}
+	if c.Request.Body != nil && c.Request.Body != http.NoBody {
+		if strings.HasPrefix(c.ContentType(), "multipart/") {
+			parts = append(parts, "[multipart]")
+		} else if limit > 0 {
+			prefix, _ := io.ReadAll(io.LimitReader(c.Request.Body, int64(limit+auditMaskWindow)))
+			c.Request.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(prefix), c.Request.Body), Closer: c.Request.Body}
+			if len(prefix) > 0 {
+				parts = append(parts, string(prefix))
+			}
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// readCloser 组合读取与关闭
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// auditResult 根据 HTTP 状态码与统一响应结构的业务状态码判断操作结果
+func auditResult(status int, body []byte, errs []*gin.Error) (bool, string) {
+	if len(errs) > 0 {
+		return false, errs[len(errs)-1].Error()
+	}
+	resp := &utils.Resp{}
+	parsed := json.Unmarshal(body, resp) == nil
+	if status >= 400 {
+		if parsed && resp.Message != "" {
+			return false, resp.Message
+		}
+		// 中间件中止请求时返回 {"error": "..."}
+		abort := map[string]interface{}{}
+		if json.Unmarshal(body, &abort) == nil {
+			if message, ok := abort["error"].(string); ok {
+				return false, message
+			}
+		}
+		return false, http.StatusText(status)
+	}
+	if parsed && resp.Code != utils.GetRespFormat().SuccessCode {
+		return false, resp.Message
+	}
+	return true, ""
+}
+
+// truncate 按字节截断字符串（不截断多字节字符）
+func truncate(s string, limit int) string {
+	if limit <= 0 || len(s) <= limit {
+		return s
+	}
+	return strings.ToValidUTF8(s[:limit], "")
+}
@@ -4,7 +4,9 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/so68/core/i18n"
 	"github.com/so68/core/server/module/admin/service"
+	"github.com/so68/core/server/utils"
 )
 
 // NewCasbinMiddleware 创建一个 casbin 中间件
@@ -17,7 +19,12 @@ func NewCasbinMiddleware(casbinService service.CasbinService) gin.HandlerFunc {
 		}
 		// 检查角色是否具有继承权限
 		if !casbinService.HasRoleInheritancesEnforce(adminRole, c.Request.URL.Path, c.Request.Method) {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "无权限访问"})
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": i18n.T(utils.GetContextLocale(c), "无权限访问")})
+			return
+		}
+		// 机器令牌仅能访问授权范围内的路由
+		if scopes, ok := utils.GetContextTokenScopes(c); ok && !casbinService.HasScopesEnforce(scopes, c.Request.URL.Path, c.Request.Method) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": i18n.T(utils.GetContextLocale(c), "令牌授权范围不足")})
 			return
 		}
 		c.Next()
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/server/module/admin/service"
+	"github.com/so68/core/server/utils"
+)
+
+// NewDataScopeMiddleware 创建一个数据权限中间件（将当前管理员的数据权限范围写入请求上下文，GormBuilder 自动按范围过滤）
+func NewDataScopeMiddleware(dataScopeService service.DataScopeService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scope, err := dataScopeService.Resolve(c.Request.Context(), utils.GetContextUserID(c))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.Request = c.Request.WithContext(utils.WithDataScope(c.Request.Context(), scope))
+		c.Next()
+	}
+}
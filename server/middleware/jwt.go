@@ -10,6 +10,12 @@ import (
 // NewJWTMiddleware 创建一个 jwt 中间件
 func NewJWTMiddleware(jwt *utils.JWT) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// 已通过机器令牌认证
+		if _, ok := utils.GetContextTokenScopes(c); ok {
+			c.Next()
+			return
+		}
+
 		token := utils.GetRequestToken(c)
 		claims, err := jwt.ParseToken(token)
 		if err != nil {
@@ -17,14 +23,20 @@ func NewJWTMiddleware(jwt *utils.JWT) gin.HandlerFunc {
 			return
 		}
 
-		// 如果IP不匹配，则返回401
-		if claims.IP != c.ClientIP() {
+		// 如果IP不匹配，则返回401（外部身份提供方签发的 Token 未携带 ip 声明时不绑定 IP）
+		if (!claims.External || claims.IP != "") && claims.IP != c.ClientIP() {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "IP not match"})
 			return
 		}
 
-		// 设置用户ID
-		c.Set(utils.ContextUserIDKey, claims.UserID)
+		// Token 中的租户优先，且必须与请求解析的租户一致
+		if !bindTenant(c, claims.TenantID) {
+			return
+		}
+
+		// 设置用户ID与会话ID
+		utils.SetContextUserID(c, claims.UserID)
+		utils.SetContextSessionID(c, claims.SessionID)
 		c.Next()
 	}
 }
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/config"
+	"github.com/so68/core/i18n"
+	"github.com/so68/core/server/utils"
+)
+
+// NewLocaleMiddleware 创建一个语言检测中间件
+// 优先使用查询参数（默认 ?lang=en-US），其次使用 Accept-Language 请求头，均未匹配时使用默认语言
+func NewLocaleMiddleware(cfg *config.AppConfig) gin.HandlerFunc {
+	bundle := i18n.Default()
+	queryParam := "lang"
+	if cfg != nil && cfg.I18n != nil {
+		bundle.SetDefaultLocale(cfg.I18n.DefaultLocale)
+		queryParam = cfg.I18n.QueryParam
+	}
+
+	return func(c *gin.Context) {
+		locale := c.Query(queryParam)
+		if locale == "" {
+			locale = c.GetHeader("Accept-Language")
+		}
+		c.Set(utils.ContextLocaleKey, bundle.Match(locale))
+		c.Next()
+	}
+}
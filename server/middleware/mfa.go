@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/server/module/admin/service"
+	"github.com/so68/core/server/utils"
+)
+
+// MFACodeHeader 敏感操作二次验证的请求头（MFA 验证码或恢复码）
+const MFACodeHeader = "X-MFA-Code"
+
+// NewMFAMiddleware 创建一个敏感操作 MFA 二次验证中间件
+// - 已启用 MFA 的管理员须在请求头 X-MFA-Code 中携带验证码或恢复码
+// - 机器令牌与 API Key 无法二次验证，不允许执行敏感操作（返回 403）
+func NewMFAMiddleware(mfaService service.MFAService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := utils.GetContextTokenScopes(c); ok {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "sensitive operation requires interactive login"})
+			return
+		}
+		if err := mfaService.Verify(c.Request.Context(), utils.GetContextUserID(c), c.GetHeader(MFACodeHeader)); err != nil {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.Next()
+	}
+}
@@ -4,34 +4,117 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/so68/core/config"
 	"golang.org/x/time/rate"
 )
 
-// ipLimiterStore 按客户端 IP 存储限流器
-var ipLimiterStore sync.Map // map[string]*rate.Limiter
+// limiterEntry 单个客户端 IP 的限流器及最近访问时间
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// IPRateLimiter 按客户端 IP 存储限流器，空闲超过 IdleTTL 的限流器由后台清理协程回收
+type IPRateLimiter struct {
+	cfg      *config.AppConfig
+	mutex    sync.Mutex
+	limiters map[string]*limiterEntry
+	stop     chan struct{}
+	once     sync.Once
+}
+
+// NewIPRateLimiter 创建按 IP 限流器并启动后台清理协程（需调用 Close 停止）
+func NewIPRateLimiter(cfg *config.AppConfig) *IPRateLimiter {
+	l := &IPRateLimiter{cfg: cfg, limiters: make(map[string]*limiterEntry), stop: make(chan struct{})}
+	if ttl := l.idleTTL(); ttl > 0 {
+		go l.sweeper(ttl)
+	}
+	return l
+}
+
+// idleTTL 空闲限流器过期时间
+func (l *IPRateLimiter) idleTTL() time.Duration {
+	if l.cfg.RateLimit == nil {
+		return 0
+	}
+	return l.cfg.RateLimit.IdleTTL
+}
+
+// get 获取或创建指定 IP 的限流器，并刷新最近访问时间
+func (l *IPRateLimiter) get(ip string) *rate.Limiter {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
 
-func getOrCreateLimiter(ip string, r rate.Limit, burst int) *rate.Limiter {
-	if v, ok := ipLimiterStore.Load(ip); ok {
-		lim := v.(*rate.Limiter)
-		return lim
+	entry, ok := l.limiters[ip]
+	if !ok {
+		entry = &limiterEntry{limiter: rate.NewLimiter(rate.Limit(l.cfg.RateLimit.Rate), l.cfg.RateLimit.Burst)}
+		l.limiters[ip] = entry
 	}
-	lim := rate.NewLimiter(r, burst)
-	actual, _ := ipLimiterStore.LoadOrStore(ip, lim)
-	return actual.(*rate.Limiter)
+	entry.lastSeen = time.Now()
+	return entry.limiter
 }
 
-// NewIPRateLimitMiddleware 基于 x/time/rate 的按 IP 限流中间件
+// sweeper 按 TTL 的一半周期清理空闲限流器
+func (l *IPRateLimiter) sweeper(ttl time.Duration) {
+	interval := ttl / 2
+	if interval < time.Second {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stop:
+			return
+		case now := <-ticker.C:
+			l.sweep(now.Add(-ttl))
+		}
+	}
+}
+
+// sweep 删除最近访问时间早于 deadline 的限流器，返回删除数量
+func (l *IPRateLimiter) sweep(deadline time.Time) int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	removed := 0
+	for ip, entry := range l.limiters {
+		if entry.lastSeen.Before(deadline) {
+			delete(l.limiters, ip)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Len 当前存储的限流器数量
+func (l *IPRateLimiter) Len() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return len(l.limiters)
+}
+
+// Close 停止后台清理协程（可重复调用）
+func (l *IPRateLimiter) Close() {
+	l.once.Do(func() { close(l.stop) })
+}
+
+// Middleware 基于 x/time/rate 的按 IP 限流中间件
 // - 支持包含/排除路径
 // - 仅当 cfg.RateLimit 非空且 Rate>0 时才应被启用
-func NewIPRateLimitMiddleware(cfg *config.AppConfig) gin.HandlerFunc {
+func (l *IPRateLimiter) Middleware() gin.HandlerFunc {
+	cfg := l.cfg
+	if cfg.RateLimit == nil || cfg.RateLimit.Rate <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+
 	// 基本参数
-	limitPerSec := cfg.RateLimit.Rate
-	burst := cfg.RateLimit.Burst
 	include := cfg.RateLimit.IncludePaths
-	exclude := cfg.RateLimit.ExcludePaths
+	exclude := append([]string{}, cfg.RateLimit.ExcludePaths...)
 
 	// 自动排除静态资源路径（如 /static）
 	if s := strings.TrimSpace(cfg.Static); s != "" {
@@ -62,18 +145,12 @@ func NewIPRateLimitMiddleware(cfg *config.AppConfig) gin.HandlerFunc {
 	}
 
 	return func(c *gin.Context) {
-		if cfg.RateLimit == nil || limitPerSec <= 0 {
-			c.Next()
-			return
-		}
 		if !shouldCheck(c.Request.URL.Path) {
 			c.Next()
 			return
 		}
 
-		ip := c.ClientIP()
-		limiter := getOrCreateLimiter(ip, rate.Limit(limitPerSec), burst)
-		if !limiter.Allow() {
+		if !l.get(c.ClientIP()).Allow() {
 			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limited"})
 			return
 		}
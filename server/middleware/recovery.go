@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/logging"
+	"github.com/so68/core/server/utils"
+)
+
+// PanicEvent panic 事件
+type PanicEvent struct {
+	Error     interface{} // panic 值
+	Stack     string      // 调用栈
+	Method    string      // 请求方法
+	Path      string      // 请求路径
+	ClientIP  string      // 客户端IP
+	RequestID string      // 请求ID
+	UserID    uint        // 用户ID（未认证时为 0）
+	Time      time.Time   // 发生时间
+}
+
+// PanicNotifier panic 告警通知函数（异步调用，不阻塞响应；ctx 不随请求结束取消）
+type PanicNotifier func(ctx context.Context, event *PanicEvent)
+
+// NewRecoveryMiddleware 创建一个 panic 恢复中间件
+// - 通过请求上下文日志器记录 panic 与调用栈（携带请求ID等关联字段）
+// - 返回统一的 Resp 错误响应（HTTP 500），客户端已断开时不再写入响应
+// - 依次异步调用 notifiers（例如发送告警），notifier 自身的 panic 会被捕获
+func NewRecoveryMiddleware(notifiers ...PanicNotifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			// http.ErrAbortHandler 用于主动中止响应，保持原有语义
+			if err, ok := r.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(r)
+			}
+
+			stack := string(debug.Stack())
+			ctx := c.Request.Context()
+			brokenPipe := isBrokenPipe(r)
+			logging.FromContext(ctx).Error("http handler panic",
+				slog.Any("panic", r),
+				slog.String("method", c.Request.Method),
+				slog.String("path", c.Request.URL.Path),
+				slog.Bool("broken_pipe", brokenPipe),
+				slog.String("stack", stack),
+			)
+
+			if brokenPipe {
+				// 连接已断开，无法写入响应
+				_ = c.Error(fmt.Errorf("%v", r))
+				c.Abort()
+			} else {
+				c.Abort()
+				utils.ErrorStatus(c, http.StatusInternalServerError, "服务器内部错误")
+			}
+
+			if len(notifiers) == 0 || brokenPipe {
+				return
+			}
+			event := &PanicEvent{
+				Error:     r,
+				Stack:     stack,
+				Method:    c.Request.Method,
+				Path:      c.Request.URL.Path,
+				ClientIP:  c.ClientIP(),
+				RequestID: utils.GetContextRequestID(c),
+				UserID:    utils.GetContextUserID(c),
+				Time:      time.Now(),
+			}
+			go notifyPanic(context.WithoutCancel(ctx), event, notifiers)
+		}()
+		c.Next()
+	}
+}
+
+// notifyPanic 依次调用告警通知函数
+func notifyPanic(ctx context.Context, event *PanicEvent, notifiers []PanicNotifier) {
+	for _, notifier := range notifiers {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					logging.FromContext(ctx).Error("panic notifier failed", slog.Any("panic", r))
+				}
+			}()
+			notifier(ctx, event)
+		}()
+	}
+}
+
+// isBrokenPipe 判断 panic 是否由客户端断开连接引起
+func isBrokenPipe(r interface{}) bool {
+	err, ok := r.(error)
+	if !ok {
+		return false
+	}
+	if errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		var syscallErr *os.SyscallError
+		if errors.As(opErr.Err, &syscallErr) {
+			message := strings.ToLower(syscallErr.Error())
+			return strings.Contains(message, "broken pipe") || strings.Contains(message, "connection reset by peer")
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/logging"
+	"github.com/so68/core/server/utils"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader 请求ID请求头/响应头
+const RequestIDHeader = "X-Request-ID"
+
+// NewRequestContextMiddleware 创建一个请求上下文中间件
+// - 复用客户端传入的 X-Request-ID（否则生成），并写入响应头
+// - 请求ID同时写入 gin 上下文与请求上下文（logging.RequestIDFromContext 获取）
+// - 将携带请求ID、链路追踪ID、路由的日志器写入请求上下文，业务层通过 logging.FromContext(ctx) 获取
+// - 数据库操作使用 WithContext(ctx) 时，SQL 日志同样携带上述关联字段
+func NewRequestContextMiddleware(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > 128 {
+			requestID = newRequestID()
+		}
+		c.Set(utils.ContextRequestIDKey, requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		ctx := logging.WithRequestID(c.Request.Context(), requestID)
+		attrs := []any{slog.String(logging.KeyRequestID, requestID)}
+		if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+			attrs = append(attrs, slog.String(logging.KeyTraceID, spanContext.TraceID().String()))
+		}
+		if route := c.FullPath(); route != "" {
+			attrs = append(attrs, slog.String(logging.KeyRoute, route))
+		}
+		c.Request = c.Request.WithContext(logging.WithContext(ctx, logger.With(attrs...)))
+		c.Next()
+	}
+}
+
+// newRequestID 生成请求ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
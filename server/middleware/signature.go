@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/server/utils"
+	"github.com/so68/core/signature"
+)
+
+// NewSignatureMiddleware 创建一个请求签名中间件（用于开放接口）
+// - 校验 AppID、时间戳、随机串与请求体摘要的 HMAC-SHA256 签名，同一随机串在时间窗口内只能使用一次
+// - 校验通过后写入接入方 AppID，可通过 utils.GetContextAppID 获取
+func NewSignatureMiddleware(verifier *signature.Verifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		appID, err := verifier.Verify(c.Request.Context(), c.Request)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Set(utils.ContextAppIDKey, appID)
+		c.Next()
+	}
+}
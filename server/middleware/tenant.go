@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/config"
+	"github.com/so68/core/server/utils"
+	"github.com/so68/core/tenant"
+)
+
+// NewTenantMiddleware 创建一个租户解析中间件
+// 按配置的来源顺序从请求头、域名中解析租户ID并写入请求上下文，GormBuilder 与 tenant.Cache 据此隔离数据
+// - 未解析到租户时不设置（需要租户的接口使用 RequireTenant）
+// - 租户ID格式无效时返回 400
+// - 请求头可由客户端任意设置，认证中间件校验登录 Token、机器令牌与 API Key 所属的租户与请求一致
+func NewTenantMiddleware(cfg *config.TenantConfig) gin.HandlerFunc {
+	if cfg == nil {
+		cfg = config.DefaultTenantConfig()
+	}
+	suffix := "." + strings.ToLower(strings.TrimPrefix(cfg.Domain, "."))
+
+	return func(c *gin.Context) {
+		c.Set(utils.ContextTenancyKey, true)
+
+		var id string
+		for _, source := range cfg.Sources {
+			switch source {
+			case "header":
+				id = strings.TrimSpace(c.GetHeader(cfg.Header))
+			case "domain":
+				if cfg.Domain != "" {
+					id = subdomain(c.Request.Host, suffix)
+				}
+			}
+			if id != "" {
+				break
+			}
+		}
+		if id == "" {
+			c.Next()
+			return
+		}
+		if err := tenant.Validate(id); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		utils.SetContextTenantID(c, id)
+		c.Next()
+	}
+}
+
+// RequireTenant 创建一个要求租户的中间件（未解析到租户时返回 400）
+func RequireTenant() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if utils.GetContextTenantID(c) == "" {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "tenant required"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// bindTenant 校验认证凭据所属的租户，并以凭据的租户作为请求的租户（校验失败时返回 403 并返回 false）
+// - 启用多租户时凭据必须属于租户
+// - 请求头或域名解析的租户必须与凭据的租户一致
+func bindTenant(c *gin.Context, tenantID string) bool {
+	if tenantID == "" {
+		if utils.IsContextTenancyEnabled(c) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "tenant required"})
+			return false
+		}
+		return true
+	}
+	if id := utils.GetContextTenantID(c); id != "" && id != tenantID {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "tenant not match"})
+		return false
+	}
+	utils.SetContextTenantID(c, tenantID)
+	return true
+}
+
+// subdomain 获取根域名下的一级子域名（例如 acme.example.com 在 .example.com 下为 acme，多级子域名返回空）
+func subdomain(host string, suffix string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(host)
+	if !strings.HasSuffix(host, suffix) {
+		return ""
+	}
+	sub := strings.TrimSuffix(host, suffix)
+	if strings.Contains(sub, ".") {
+		return ""
+	}
+	return sub
+}
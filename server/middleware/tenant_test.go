@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/config"
+	"github.com/so68/core/server/utils"
+)
+
+/*
+租户中间件测试
+
+本文件用于测试租户解析中间件，以及认证中间件对凭据所属租户的校验。
+
+运行命令：
+go test -v -run "^Test(TenantMiddleware|RequireTenant|JWTMiddleware).*$"
+
+测试内容：
+1. 按配置来源从请求头、域名中解析租户，租户ID格式无效时返回 400 (NewTenantMiddleware)
+2. 未解析到租户时要求租户的接口返回 400 (RequireTenant)
+3. Token 的租户与请求头不一致时返回 403，未携带请求头时使用 Token 的租户 (NewJWTMiddleware)
+4. 启用多租户时拒绝不属于租户的 Token，未启用时不校验 (NewJWTMiddleware)
+*/
+
+// tenantTestRouter 创建测试路由，处理函数返回请求的租户ID
+func tenantTestRouter(middlewares ...gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares...)
+	router.GET("/", func(c *gin.Context) {
+		c.String(http.StatusOK, utils.GetContextTenantID(c))
+	})
+	return router
+}
+
+// tenantTestRequest 发送测试请求
+func tenantTestRequest(router *gin.Engine, host string, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Host = host
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestTenantMiddleware(t *testing.T) {
+	cfg := &config.TenantConfig{Enabled: true, Sources: []string{"header", "domain"}, Header: "X-Tenant-ID", Domain: "example.com"}
+	router := tenantTestRouter(NewTenantMiddleware(cfg))
+
+	tests := []struct {
+		name       string
+		host       string
+		header     map[string]string
+		wantStatus int
+		wantTenant string
+	}{
+		{name: "请求头", host: "api.test", header: map[string]string{"X-Tenant-ID": "acme"}, wantStatus: http.StatusOK, wantTenant: "acme"},
+		{name: "请求头优先于域名", host: "other.example.com", header: map[string]string{"X-Tenant-ID": "acme"}, wantStatus: http.StatusOK, wantTenant: "acme"},
+		{name: "域名", host: "acme.example.com:8080", wantStatus: http.StatusOK, wantTenant: "acme"},
+		{name: "多级子域名不解析", host: "a.acme.example.com", wantStatus: http.StatusOK, wantTenant: ""},
+		{name: "未解析到租户", host: "api.test", wantStatus: http.StatusOK, wantTenant: ""},
+		{name: "租户ID格式无效", host: "api.test", header: map[string]string{"X-Tenant-ID": "acme:*"}, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := tenantTestRequest(router, tt.host, tt.header)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body = %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK && w.Body.String() != tt.wantTenant {
+				t.Errorf("tenant = %q, want %q", w.Body.String(), tt.wantTenant)
+			}
+		})
+	}
+}
+
+func TestRequireTenant(t *testing.T) {
+	router := tenantTestRouter(NewTenantMiddleware(config.DefaultTenantConfig()), RequireTenant())
+
+	if w := tenantTestRequest(router, "api.test", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("status without tenant = %d, want 400", w.Code)
+	}
+	if w := tenantTestRequest(router, "api.test", map[string]string{"X-Tenant-ID": "acme"}); w.Code != http.StatusOK {
+		t.Errorf("status with tenant = %d, want 200", w.Code)
+	}
+}
+
+func TestJWTMiddleware_Tenant(t *testing.T) {
+	jwt := utils.NewJWT("secret", time.Hour)
+	// httptest 请求的客户端地址为 192.0.2.1
+	acmeToken := "Bearer " + jwt.GenerateTenantToken(1, "192.0.2.1", "acme")
+	plainToken := "Bearer " + jwt.GenerateToken(1, "192.0.2.1")
+
+	tenancy := tenantTestRouter(NewTenantMiddleware(config.DefaultTenantConfig()), NewJWTMiddleware(jwt))
+	noTenancy := tenantTestRouter(NewJWTMiddleware(jwt))
+
+	tests := []struct {
+		name       string
+		router     *gin.Engine
+		header     map[string]string
+		wantStatus int
+		wantTenant string
+	}{
+		{name: "租户一致", router: tenancy, header: map[string]string{"Authorization": acmeToken, "X-Tenant-ID": "acme"}, wantStatus: http.StatusOK, wantTenant: "acme"},
+		{name: "租户不一致", router: tenancy, header: map[string]string{"Authorization": acmeToken, "X-Tenant-ID": "other"}, wantStatus: http.StatusForbidden},
+		{name: "未携带请求头时使用Token的租户", router: tenancy, header: map[string]string{"Authorization": acmeToken}, wantStatus: http.StatusOK, wantTenant: "acme"},
+		{name: "启用多租户时Token必须属于租户", router: tenancy, header: map[string]string{"Authorization": plainToken}, wantStatus: http.StatusForbidden},
+		{name: "启用多租户时请求头不能替代Token的租户", router: tenancy, header: map[string]string{"Authorization": plainToken, "X-Tenant-ID": "acme"}, wantStatus: http.StatusForbidden},
+		{name: "未启用多租户", router: noTenancy, header: map[string]string{"Authorization": plainToken}, wantStatus: http.StatusOK, wantTenant: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := tenantTestRequest(tt.router, "api.test", tt.header)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body = %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK && w.Body.String() != tt.wantTenant {
+				t.Errorf("tenant = %q, want %q", w.Body.String(), tt.wantTenant)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/server/module/admin/service"
+	"github.com/so68/core/server/utils"
+)
+
+// NewMachineTokenMiddleware 创建一个机器令牌中间件
+// - 仅处理以机器令牌前缀开头的 Token，其他请求交由 JWT 中间件处理
+// - 校验通过后写入用户ID、租户与授权范围，由 casbin 中间件按范围限制访问
+func NewMachineTokenMiddleware(tokenService service.TokenService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := utils.GetRequestToken(c)
+		if !service.IsMachineToken(token) {
+			c.Next()
+			return
+		}
+
+		adminToken, err := tokenService.Authenticate(c.Request.Context(), token, c.ClientIP())
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		// 令牌所属的租户必须与请求解析的租户一致
+		if !bindTenant(c, adminToken.TenantID) {
+			return
+		}
+
+		// 设置用户ID与授权范围
+		utils.SetContextUserID(c, adminToken.AdminID)
+		c.Set(utils.ContextTokenScopesKey, adminToken.GetScopes())
+		c.Next()
+	}
+}
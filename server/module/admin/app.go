@@ -1,59 +1,263 @@
 package admin
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/so68/core"
+	"github.com/so68/core/captcha"
+	"github.com/so68/core/config"
+	"github.com/so68/core/logging"
+	"github.com/so68/core/oauth"
+	"github.com/so68/core/server"
 	"github.com/so68/core/server/middleware"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/module/admin/handler"
 	"github.com/so68/core/server/module/admin/service"
+	"github.com/so68/core/server/scaffold"
 	"github.com/so68/core/server/utils"
+	"github.com/so68/core/storage"
+	"gorm.io/gorm"
 )
 
-// AdminApp 管理员应用
+// AdminApp 管理员应用模块（实现 core.Module）
 type AdminApp struct {
-	relativePath  string                // 相对路径
-	app           *core.Application     // 应用
-	jwt           *utils.JWT            // JWT实例
-	casbinService service.CasbinService // 权限服务
-	router        *gin.RouterGroup      // 普通路由
-	authRouter    *gin.RouterGroup      // 认证路由
+	relativePath         string                       // 相对路径
+	app                  *core.Application            // 应用
+	jwt                  *utils.JWT                   // JWT实例
+	casbinService        service.CasbinService        // 权限服务
+	tokenService         service.TokenService         // 机器令牌服务
+	apiKeyService        service.APIKeyService        // API Key 服务
+	sessionService       service.SessionService       // 登录会话服务
+	adminService         service.AdminService         // 管理员服务
+	indexService         service.IndexService         // 首页服务
+	notifyService        service.NotifyService        // 安全提醒服务
+	passwordService      service.PasswordService      // 密码策略服务
+	mfaService           service.MFAService           // MFA 双因素认证服务
+	captcha              captcha.Manager              // 登录图形验证码（未启用时为 nil）
+	oauth                *oauth.Manager               // 第三方登录（未启用时为 nil）
+	menuService          service.MenuService          // 菜单服务
+	dataScopeService     service.DataScopeService     // 数据权限服务
+	auditService         service.AuditService         // 操作审计日志服务
+	loginLogService      service.LoginLogService      // 登录日志服务
+	auditMasker          *logging.Masker              // 审计日志参数脱敏器
+	uploadValidator      *storage.UploadValidator     // 上传文件校验
+	uploadService        service.UploadService        // 文件上传服务
+	notificationService  service.NotificationService  // 站内通知服务
+	passwordResetService service.PasswordResetService // 找回密码服务
+	hub                  *server.Hub                  // WebSocket 连接中心
+	router               *gin.RouterGroup             // 普通路由
+	authRouter           *gin.RouterGroup             // 认证路由
+	cleanupStop          chan struct{}                // 停止审计日志清理
+	cleanupDone          chan struct{}                // 审计日志清理已退出
 }
 
-// NewAdminApp 创建一个管理员应用
-func NewAdminApp(app *core.Application, relativePath string) *AdminApp {
-	router := app.Server.NewGroup(relativePath)
+// ModuleName 管理员模块名称（其他模块可通过 Dependencies 声明依赖）
+const ModuleName = "admin"
 
-	// 使用JWT中间件验证Token - 登陆之后的路由
-	jwt := utils.NewJWT(app.Config.JWT.SecretKey, time.Duration(app.Config.JWT.ExpiresIn)*time.Second)
-	// 如果启用单点登录，则使用缓存验证Token
-	if app.Config.JWT.EnableSingle {
-		jwt.WithCache(app.Cache)
+// NewAdminApp 创建一个管理员应用模块，通过 app.RegisterModule 注册后由核心按生命周期初始化
+func NewAdminApp(relativePath string) *AdminApp {
+	return &AdminApp{relativePath: relativePath}
+}
+
+// Name 模块名称
+func (c *AdminApp) Name() string {
+	return ModuleName
+}
+
+// Init 初始化管理员服务
+func (c *AdminApp) Init(app *core.Application) error {
+	if app.DB == nil {
+		return errors.New("admin module requires database")
 	}
-	// 权限服务
-	casbinService := service.NewCasbinService(app.DB.DB(), app.Cache, app.Logger)
+	c.app = app
+	// WebSocket 连接中心
+	c.hub = server.NewHub(app.Logger, app.Config)
+
+	// 注册管理员服务到依赖注入容器
+	if err := app.Provide(c.providers()...); err != nil {
+		return err
+	}
+	err := app.Invoke(func(
+		jwt *utils.JWT,
+		casbinService service.CasbinService,
+		tokenService service.TokenService,
+		apiKeyService service.APIKeyService,
+		sessionService service.SessionService,
+		adminService service.AdminService,
+		indexService service.IndexService,
+		notifyService service.NotifyService,
+		passwordService service.PasswordService,
+		mfaService service.MFAService,
+		captchaManager captcha.Manager,
+		oauthManager *oauth.Manager,
+		menuService service.MenuService,
+		dataScopeService service.DataScopeService,
+		auditService service.AuditService,
+		loginLogService service.LoginLogService,
+		uploadValidator *storage.UploadValidator,
+		uploadService service.UploadService,
+		notificationService service.NotificationService,
+		passwordResetService service.PasswordResetService,
+	) {
+		c.jwt = jwt
+		c.casbinService = casbinService
+		c.tokenService = tokenService
+		c.apiKeyService = apiKeyService
+		c.sessionService = sessionService
+		c.adminService = adminService
+		c.indexService = indexService
+		c.notifyService = notifyService
+		c.passwordService = passwordService
+		c.mfaService = mfaService
+		c.captcha = captchaManager
+		c.oauth = oauthManager
+		c.menuService = menuService
+		c.dataScopeService = dataScopeService
+		c.auditService = auditService
+		c.loginLogService = loginLogService
+		c.uploadValidator = uploadValidator
+		c.uploadService = uploadService
+		c.notificationService = notificationService
+		c.passwordResetService = passwordResetService
+	})
+	if err != nil {
+		return err
+	}
+	c.initAudit()
+	return nil
+}
 
-	// 创建管理员应用
-	adminApp := &AdminApp{app: app, router: router, relativePath: relativePath, jwt: jwt, casbinService: casbinService}
-	adminApp.initAuthRouter().initHandler().initMigrate()
-	return adminApp
+// Migrate 迁移管理员数据表
+func (c *AdminApp) Migrate(db *gorm.DB) error {
+	return InitMigrate(db, c.app.Logger)
+}
+
+// Routes 注册管理员路由与后台菜单
+func (c *AdminApp) Routes(srv server.Server) error {
+	c.router = srv.NewGroup(c.relativePath)
+	c.initAuthRouter().initJWKS().initWebSocket().initHandler().initMenu()
+	return nil
+}
+
+// Start 启动后台任务（定期清理过期审计日志）
+func (c *AdminApp) Start(ctx context.Context) error {
+	c.startAuditCleanup()
+	return nil
+}
+
+// Stop 停止后台任务
+func (c *AdminApp) Stop(ctx context.Context) error {
+	if c.cleanupStop == nil {
+		return nil
+	}
+	close(c.cleanupStop)
+	select {
+	case <-c.cleanupDone:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	c.cleanupStop, c.cleanupDone = nil, nil
+	return nil
 }
 
-// authRouter 使用JWT中间件验证Token - 登陆之后的路由
+// authRouter 使用机器令牌/JWT中间件验证Token - 登陆之后的路由
 func (c *AdminApp) initAuthRouter() *AdminApp {
-	authRouter := c.app.Server.Middleware(c.router.Group(""), middleware.NewJWTMiddleware(c.jwt))
-	c.authRouter = c.app.Server.Middleware(authRouter, middleware.NewCasbinMiddleware(c.casbinService))
+	authRouter := c.app.Server.Middleware(c.router.Group(""), middleware.NewAPIKeyMiddleware(c.apiKeyService), middleware.NewMachineTokenMiddleware(c.tokenService), middleware.NewJWTMiddleware(c.jwt))
+	// 数据权限：代理/商户管理员仅可访问自身及下级的数据
+	c.authRouter = c.app.Server.Middleware(authRouter, middleware.NewCasbinMiddleware(c.casbinService), middleware.NewDataScopeMiddleware(c.dataScopeService))
 	return c
 }
 
+// initAudit 初始化审计日志参数脱敏（沿用日志脱敏字段，未启用日志脱敏时使用默认字段）
+func (c *AdminApp) initAudit() *AdminApp {
+	maskConfig := c.app.Config.LogMask
+	if maskConfig == nil || !maskConfig.Enabled {
+		maskConfig = config.DefaultLogMaskConfig()
+	}
+	masker, err := logging.NewMasker(maskConfig)
+	if err != nil {
+		c.app.Logger.Error("创建审计日志脱敏器失败", "error", err)
+		return c
+	}
+	c.auditMasker = masker
+	return c
+}
+
+// startAuditCleanup 按保留天数定期清理过期审计日志（Stop 时停止）
+func (c *AdminApp) startAuditCleanup() {
+	cfg := c.app.Config.Audit
+	if cfg == nil || !cfg.Enabled || cfg.RetentionDays <= 0 || c.cleanupStop != nil {
+		return
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	c.cleanupStop, c.cleanupDone = stop, done
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(cfg.CleanupInterval)
+		defer ticker.Stop()
+		for {
+			before := time.Now().AddDate(0, 0, -cfg.RetentionDays)
+			if err := c.auditService.Cleanup(context.Background(), before); err != nil {
+				c.app.Logger.Error("清理审计日志失败", "error", err)
+			}
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// initJWKS 使用非对称密钥时发布 JWKS 公钥（GET jwksPath），供其他服务验证本服务签发的 Token
+func (c *AdminApp) initJWKS() *AdminApp {
+	keySet := utils.NewJSONWebKeySet(c.jwt.Keys())
+	if len(keySet.Keys) == 0 || c.app.Config.JWT.JWKSPath == "" {
+		return c
+	}
+	c.app.Server.NewGroup("").GET(c.app.Config.JWT.JWKSPath, utils.JWKSHandler(keySet))
+	return c
+}
+
+// initWebSocket 注册 WebSocket 实时推送路由（GET relativePath/ws，JWT 认证）
+func (c *AdminApp) initWebSocket() *AdminApp {
+	server.NewWebSocketGroup(c.app.Server, c.relativePath+"/ws", c.hub, c.jwt)
+	return c
+}
+
+// Hub 获取 WebSocket 连接中心，用于向管理员推送实时通知
+func (c *AdminApp) Hub() *server.Hub {
+	return c.hub
+}
+
+// Notify 向指定管理员发送站内通知，用于管理员操作提醒与系统告警
+func (c *AdminApp) Notify(ctx context.Context, adminIDs []uint, msg *dto.NotificationMessage) error {
+	return c.notificationService.Send(ctx, adminIDs, msg)
+}
+
+// NotifyAll 向全部已启用的管理员发送站内通知
+func (c *AdminApp) NotifyAll(ctx context.Context, msg *dto.NotificationMessage) error {
+	return c.notificationService.Broadcast(ctx, msg)
+}
+
+// UploadScanner 设置上传文件病毒扫描钩子（如对接 ClamAV），扫描返回错误时拒绝上传
+func (c *AdminApp) UploadScanner(scanner storage.Scanner) {
+	c.uploadValidator.SetScanner(scanner)
+}
+
 // initHandler 初始化路由处理
 func (c *AdminApp) initHandler() *AdminApp {
 	InitRouter(c)
 	return c
 }
 
-// Handler 无验证中间件处理路由
-func (c *AdminApp) Handler(name string, method string, path string, handler gin.HandlerFunc) {
+// Handler 无验证中间件处理路由（doc 为可选的接口文档，用于生成 OpenAPI 文档）
+func (c *AdminApp) Handler(name string, method string, path string, handler gin.HandlerFunc, doc ...*server.RouteDoc) {
 	switch method {
 	case "GET":
 		c.router.GET(path, handler)
@@ -67,25 +271,48 @@ func (c *AdminApp) Handler(name string, method string, path string, handler gin.
 		c.router.PATCH(path, handler)
 	default:
 		c.app.Logger.Error("不支持的方法: " + method)
+		return
 	}
+	c.describe(name, method, path, "", doc)
+}
+
+// AuthHandler 认证处理器（写操作按配置记录审计日志）
+func (c *AdminApp) AuthHandler(name string, method string, path string, handler gin.HandlerFunc, doc ...*server.RouteDoc) {
+	c.authHandler(name, method, path, false, handler, doc)
+}
+
+// SensitiveHandler 敏感操作认证处理器（已启用 MFA 的管理员须在请求头 X-MFA-Code 中携带验证码二次验证）
+func (c *AdminApp) SensitiveHandler(name string, method string, path string, handler gin.HandlerFunc, doc ...*server.RouteDoc) {
+	c.authHandler(name, method, path, true, handler, doc)
 }
 
-// AuthHandler 认证处理器
-func (c *AdminApp) AuthHandler(name string, method string, path string, handler gin.HandlerFunc) {
+// authHandler 注册认证路由
+func (c *AdminApp) authHandler(name string, method string, path string, sensitive bool, handler gin.HandlerFunc, doc []*server.RouteDoc) {
+	handlers := []gin.HandlerFunc{handler}
+	if sensitive {
+		handlers = append([]gin.HandlerFunc{middleware.NewMFAMiddleware(c.mfaService)}, handlers...)
+	}
+	if audit := c.app.Config.Audit; audit != nil && audit.Enabled && method != "GET" {
+		handlers = append([]gin.HandlerFunc{middleware.NewAuditMiddleware(name, c.auditService, c.auditMasker, audit.MaxParamsSize)}, handlers...)
+	}
+
 	switch method {
 	case "GET":
-		c.authRouter.GET(path, handler)
+		c.authRouter.GET(path, handlers...)
 	case "POST":
-		c.authRouter.POST(path, handler)
+		c.authRouter.POST(path, handlers...)
 	case "PUT":
-		c.authRouter.PUT(path, handler)
+		c.authRouter.PUT(path, handlers...)
 	case "DELETE":
-		c.authRouter.DELETE(path, handler)
+		c.authRouter.DELETE(path, handlers...)
 	case "PATCH":
-		c.authRouter.PATCH(path, handler)
+		c.authRouter.PATCH(path, handlers...)
 	default:
 		c.app.Logger.Error("不支持的方法: " + method)
+		return
 	}
+	// 路由所需权限即注册到 Casbin 的策略名称
+	c.describe(name, method, path, name, doc)
 
 	// 添加权限策略
 	c.casbinService.AddPolicy(name, c.relativePath+path, method)
@@ -93,11 +320,75 @@ func (c *AdminApp) AuthHandler(name string, method string, path string, handler
 	c.casbinService.AddRoleInheritance(service.RoleSuperAdmin, name)
 }
 
-// initMigrate 初始化迁移
-func (c *AdminApp) initMigrate() *AdminApp {
-	err := InitMigrate(c.app.DB.DB(), c.app.Logger)
+// ReadOnlyHandler 只读认证处理器（GET 路由，同时授权只读范围的机器令牌与 API Key 访问）
+// - 令牌、会话、API Key、MFA 与运行配置等敏感信息使用 AuthHandler 注册，不对只读范围开放
+func (c *AdminApp) ReadOnlyHandler(name string, path string, handler gin.HandlerFunc, doc ...*server.RouteDoc) {
+	c.authHandler(name, "GET", path, false, handler, doc)
+	c.GrantScope(service.TokenScopeReadOnly, "GET", path)
+}
+
+// describe 描述路由（名称、所需权限与接口文档，文档按模块名称分组）
+func (c *AdminApp) describe(name string, method string, path string, permission string, doc []*server.RouteDoc) {
+	meta := server.RouteMeta{Name: name, Permission: permission, Tags: []string{c.Name()}}
+	if len(doc) > 0 {
+		meta.Doc = doc[0]
+	}
+	c.app.Server.Describe(method, c.relativePath+path, meta)
+}
+
+// GrantScope 授权机器令牌范围访问指定路由（例如为 reports 范围开放报表接口）
+func (c *AdminApp) GrantScope(scope string, method string, path string) {
+	if err := c.casbinService.AddPolicy(service.ScopeSubject(scope), c.relativePath+path, method); err != nil {
+		c.app.Logger.Error("添加令牌授权范围失败", "scope", scope, "error", err)
+	}
+}
+
+// Upload 注册文件上传路由，图片按 upload.images 中名为 profile 的配置处理
+// - POST path                直接上传
+// - POST path/chunk/init     初始化分片上传
+// - POST path/chunk          上传分片
+// - POST path/chunk/complete 完成分片上传
+func (c *AdminApp) Upload(name string, path string, profile string) {
+	uploadHandler := handler.NewUploadHandler(c.uploadService, profile)
+	c.AuthHandler(name, "POST", path, uploadHandler.Upload)
+	c.AuthHandler(name+"分片初始化", "POST", path+"/chunk/init", uploadHandler.ChunkInit)
+	c.AuthHandler(name+"分片", "POST", path+"/chunk", uploadHandler.Chunk)
+	c.AuthHandler(name+"分片完成", "POST", path+"/chunk/complete", uploadHandler.ChunkComplete)
+}
+
+// CRUD 注册基于 GORM 模型生成的通用 CRUD 路由（Go 方法不支持类型参数，因此以函数形式提供）
+// - GET    path/meta   表单元数据（模型声明 views 标签时注册）
+// - GET    path/index  列表
+// - POST   path/create 创建
+// - PUT    path/update 更新
+// - DELETE path/delete 删除
+//
+// 模型未声明 views 标签时，需对返回的处理调用 WithBindFields 设置可写入的字段
+func CRUD[T any](c *AdminApp, name string, path string, hooks *scaffold.Hooks[T]) (*scaffold.Handler[T], error) {
+	handler, err := scaffold.NewHandler(c.app.DB.DB(), c.app.Logger, hooks)
 	if err != nil {
-		c.app.Logger.Error("初始化迁移失败", "error", err)
+		return nil, err
 	}
+	if len(handler.Fields()) > 0 {
+		c.ReadOnlyHandler(name+"表单元数据", path+"/meta", handler.Meta)
+	}
+	c.ReadOnlyHandler(name+"列表", path+"/index", handler.Index)
+	c.AuthHandler("创建"+name, "POST", path+"/create", handler.Create)
+	c.AuthHandler("更新"+name, "PUT", path+"/update", handler.Update)
+	c.AuthHandler("删除"+name, "DELETE", path+"/delete", handler.Delete)
+	return handler, nil
+}
+
+// initMenu 初始化后台菜单
+func (c *AdminApp) initMenu() *AdminApp {
+	InitMenu(c)
 	return c
 }
+
+// Menu 注册后台菜单（按 Key 同步到数据库，已存在的菜单保持不变，便于后台调整标题与排序）
+// - Permission 为 AuthHandler 注册的路由名称，菜单树按当前管理员角色的权限过滤
+func (c *AdminApp) Menu(items ...*dto.MenuItem) {
+	if err := c.menuService.Sync(context.Background(), items); err != nil {
+		c.app.Logger.Error("同步后台菜单失败", "error", err)
+	}
+}
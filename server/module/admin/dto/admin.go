@@ -1 +1,80 @@
 package dto
+
+import "github.com/so68/core/server/utils"
+
+// AdminIndexParams 管理员列表参数
+type AdminIndexParams struct {
+	utils.Page
+	Username  string `form:"username"`                                // 用户名（模糊匹配）
+	Nickname  string `form:"nickname"`                                // 昵称（模糊匹配）
+	Email     string `form:"email"`                                   // 邮箱
+	Telephone string `form:"telephone"`                               // 手机号
+	Status    int8   `form:"status" validate:"omitempty,oneof=1 2 3"` // 状态
+	Type      int8   `form:"type" validate:"omitempty,oneof=1 2 3"`   // 管理员层级
+	Role      string `form:"role"`                                    // 角色
+	ParentID  uint   `form:"parent_id"`                               // 上级管理员ID
+}
+
+// AdminCreateParams 创建管理员参数
+type AdminCreateParams struct {
+	Username  string `json:"username" form:"username" validate:"required,min=3,max=64"`      // 用户名
+	Password  string `json:"password" form:"password" validate:"required,max=64"`            // 密码（复杂度按密码策略校验）
+	Nickname  string `json:"nickname" form:"nickname" validate:"required,max=100"`           // 昵称
+	Email     string `json:"email" form:"email" validate:"omitempty,email,max=255"`          // 邮箱
+	Telephone string `json:"telephone" form:"telephone" validate:"omitempty,numeric,max=20"` // 手机号
+	Avatar    string `json:"avatar" form:"avatar" validate:"max=255"`                        // 头像URL
+	Status    int8   `json:"status" form:"status" validate:"omitempty,oneof=1 2 3"`          // 状态(默认启用)
+	Type      int8   `json:"type" form:"type" validate:"required,oneof=1 2 3"`               // 管理员层级
+	Role      string `json:"role" form:"role" validate:"required"`                           // 角色
+	ParentID  uint   `json:"parent_id" form:"parent_id"`                                     // 上级管理员ID（非超级管理员为空时默认为当前管理员）
+	ChatURL   string `json:"chat_url" form:"chat_url" validate:"omitempty,url,max=255"`      // 客服链接
+}
+
+// AdminUpdateParams 更新管理员参数
+type AdminUpdateParams struct {
+	ID        uint   `json:"id" form:"id" validate:"required"`                               // 管理员ID
+	Password  string `json:"password" form:"password" validate:"omitempty,max=64"`           // 重置密码（为空时不修改）
+	Nickname  string `json:"nickname" form:"nickname" validate:"required,max=100"`           // 昵称
+	Email     string `json:"email" form:"email" validate:"omitempty,email,max=255"`          // 邮箱
+	Telephone string `json:"telephone" form:"telephone" validate:"omitempty,numeric,max=20"` // 手机号
+	Avatar    string `json:"avatar" form:"avatar" validate:"max=255"`                        // 头像URL
+	Status    int8   `json:"status" form:"status" validate:"required,oneof=1 2 3"`           // 状态
+	Type      int8   `json:"type" form:"type" validate:"required,oneof=1 2 3"`               // 管理员层级
+	Role      string `json:"role" form:"role" validate:"required"`                           // 角色
+	ParentID  uint   `json:"parent_id" form:"parent_id"`                                     // 上级管理员ID
+	ChatURL   string `json:"chat_url" form:"chat_url" validate:"omitempty,url,max=255"`      // 客服链接
+}
+
+// AdminProfileParams 更新当前管理员资料参数
+type AdminProfileParams struct {
+	Nickname             string `json:"nickname" form:"nickname" validate:"required,max=100"`           // 昵称
+	Email                string `json:"email" form:"email" validate:"omitempty,email,max=255"`          // 邮箱
+	Telephone            string `json:"telephone" form:"telephone" validate:"omitempty,numeric,max=20"` // 手机号
+	Avatar               string `json:"avatar" form:"avatar" validate:"max=255"`                        // 头像URL
+	ChatURL              string `json:"chat_url" form:"chat_url" validate:"omitempty,url,max=255"`      // 客服链接
+	WhiteList            string `json:"white_list" form:"white_list"`                                   // 白名单（逗号分隔）
+	DisableLoginNotify   bool   `json:"disable_login_notify" form:"disable_login_notify"`               // 关闭新设备登录提醒
+	DisableLockoutNotify bool   `json:"disable_lockout_notify" form:"disable_lockout_notify"`           // 关闭账户锁定提醒
+}
+
+// AdminPasswordParams 修改当前管理员密码参数
+type AdminPasswordParams struct {
+	OldPassword     string `json:"old_password" form:"old_password" validate:"required"`         // 原密码
+	Password        string `json:"password" form:"password" validate:"required,max=64"`          // 新密码
+	ConfirmPassword string `json:"confirm_password" form:"confirm_password" validate:"required"` // 确认新密码
+}
+
+// AdminDeleteParams 删除管理员参数
+type AdminDeleteParams struct {
+	ID uint `json:"id" form:"id" validate:"required"` // 管理员ID
+}
+
+// AdminUnlockParams 解锁管理员参数
+type AdminUnlockParams struct {
+	ID uint `json:"id" form:"id" validate:"required"` // 管理员ID
+}
+
+// AdminRestoreParams 恢复管理员参数
+type AdminRestoreParams struct {
+	ID uint `json:"id" form:"id" validate:"required"` // 管理员ID
+}
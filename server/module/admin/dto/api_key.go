@@ -0,0 +1,28 @@
+package dto
+
+import models "github.com/so68/core/server/database"
+
+// APIKeyCreateParams 创建 API Key 参数
+type APIKeyCreateParams struct {
+	Name      string   `json:"name" form:"name" validate:"required"`     // 名称
+	Scopes    []string `json:"scopes" form:"scopes" validate:"required"` // 授权范围
+	RateLimit int      `json:"rate_limit" form:"rate_limit"`             // 每分钟请求上限，0 表示不限制
+	ExpiresIn int64    `json:"expires_in" form:"expires_in"`             // 有效期(秒)，0 表示永不过期
+}
+
+// APIKeyCreateResult 创建或轮换 API Key 结果
+type APIKeyCreateResult struct {
+	Info *models.AdminAPIKey `json:"info"` // Key 信息
+	Key  string              `json:"key"`  // Key 明文，仅在创建或轮换时返回一次
+}
+
+// APIKeyRotateParams 轮换 API Key 参数
+type APIKeyRotateParams struct {
+	ID          uint  `json:"id" form:"id" validate:"required"` // Key ID
+	GracePeriod int64 `json:"grace_period" form:"grace_period"` // 旧 Key 宽限期(秒)，0 表示立即失效
+}
+
+// APIKeyRevokeParams 吊销 API Key 参数
+type APIKeyRevokeParams struct {
+	ID uint `json:"id" form:"id" validate:"required"` // Key ID
+}
@@ -0,0 +1,14 @@
+package dto
+
+import "github.com/so68/core/server/utils"
+
+// AuditLogIndexParams 审计日志列表参数
+type AuditLogIndexParams struct {
+	utils.Page
+	AdminID uint   `form:"admin_id"` // 操作管理员ID
+	Name    string `form:"name"`     // 操作名称（模糊匹配）
+	Method  string `form:"method"`   // 请求方法
+	Success *bool  `form:"success"`  // 是否成功
+	StartAt int64  `form:"start_at"` // 开始时间(Unix 秒)
+	EndAt   int64  `form:"end_at"`   // 结束时间(Unix 秒)
+}
@@ -4,13 +4,78 @@ import models "github.com/so68/core/server/database"
 
 // LoginParams 登录参数
 type LoginParams struct {
+	CaptchaParams
 	Username string `json:"username" form:"username" validate:"required"` // 用户名
 	Password string `json:"password" form:"password" validate:"required"` // 密码
 	Code     string `json:"code" form:"code"`                             // 验证码
 }
 
+// CaptchaParams 图形验证码参数（登录失败次数达到阈值后必填）
+type CaptchaParams struct {
+	CaptchaID   string `json:"captcha_id" form:"captcha_id"`     // 验证码ID
+	CaptchaCode string `json:"captcha_code" form:"captcha_code"` // 验证码答案（滑动验证码为滑块横坐标）
+}
+
 // LoginResult 登录结果
 type LoginResult struct {
-	Info  *models.Admin `json:"info"`  // 管理员信息
-	Token string        `json:"token"` // 令牌
+	Info         *models.Admin `json:"info"`                    // 管理员信息
+	Token        string        `json:"token"`                   // 令牌
+	RefreshToken string        `json:"refresh_token,omitempty"` // 刷新令牌（未启用时为空）
+}
+
+// ExpiredPasswordParams 修改过期密码参数（密码过期后无法登录，需凭原密码修改）
+type ExpiredPasswordParams struct {
+	CaptchaParams
+	Username        string `json:"username" form:"username" validate:"required"`                 // 用户名
+	OldPassword     string `json:"old_password" form:"old_password" validate:"required"`         // 原密码
+	Code            string `json:"code" form:"code"`                                             // 验证码
+	Password        string `json:"password" form:"password" validate:"required,max=64"`          // 新密码
+	ConfirmPassword string `json:"confirm_password" form:"confirm_password" validate:"required"` // 确认新密码
+}
+
+// RefreshParams 刷新令牌参数
+type RefreshParams struct {
+	RefreshToken string `json:"refresh_token" form:"refresh_token" validate:"required"` // 刷新令牌
+}
+
+// RefreshResult 刷新令牌结果
+type RefreshResult struct {
+	Token        string `json:"token"`         // 新的访问令牌
+	RefreshToken string `json:"refresh_token"` // 新的刷新令牌（旧刷新令牌已失效）
+}
+
+// ForgotPasswordParams 找回密码参数（email 通过邮件发送重置链接，sms 通过短信发送验证码）
+type ForgotPasswordParams struct {
+	CaptchaParams
+	Username string `json:"username" form:"username" validate:"required"`               // 用户名
+	Channel  string `json:"channel" form:"channel" validate:"required,oneof=email sms"` // 找回方式: email, sms
+}
+
+// ResetPasswordParams 重置密码参数
+type ResetPasswordParams struct {
+	Username        string `json:"username" form:"username" validate:"required"`                 // 用户名
+	Channel         string `json:"channel" form:"channel" validate:"required,oneof=email sms"`   // 找回方式: email, sms
+	Token           string `json:"token" form:"token" validate:"required"`                       // 邮件重置令牌或短信验证码
+	Password        string `json:"password" form:"password" validate:"required,max=64"`          // 新密码
+	ConfirmPassword string `json:"confirm_password" form:"confirm_password" validate:"required"` // 确认新密码
+}
+
+// OAuthAuthorizeParams 第三方登录授权参数
+type OAuthAuthorizeParams struct {
+	Provider string `json:"provider" form:"provider" validate:"required"` // 登录方式
+}
+
+// OAuthAuthorizeResult 第三方登录授权结果
+type OAuthAuthorizeResult struct {
+	URL     string `json:"url"` // 授权地址（前端跳转到该地址，授权后回调地址携带 code 与 state）
+	Binding string `json:"-"`   // 与 state 绑定的浏览器标识（由接口写入 Cookie，不返回给前端）
+}
+
+// OAuthLoginParams 第三方登录参数（授权回调后提交授权码与 state）
+type OAuthLoginParams struct {
+	Provider string `json:"provider" form:"provider" validate:"required"`   // 登录方式
+	AuthCode string `json:"auth_code" form:"auth_code" validate:"required"` // 授权码（回调地址中的 code）
+	State    string `json:"state" form:"state" validate:"required"`         // 回调地址中的 state
+	Code     string `json:"code" form:"code"`                               // MFA 验证码（管理员已启用 MFA 时必填）
+	Binding  string `json:"-" form:"-"`                                     // 授权时写入 Cookie 的浏览器标识（由接口从 Cookie 读取）
 }
@@ -0,0 +1,13 @@
+package dto
+
+// LogLevelInfo 运行时日志级别
+type LogLevelInfo struct {
+	Level   string `json:"level"`    // 应用日志级别: debug, info, warn, error（自定义日志器时为空）
+	DBLevel string `json:"db_level"` // 数据库日志级别: silent, error, warn, info（未启用数据库时为空）
+}
+
+// LogLevelUpdateParams 调整日志级别参数（至少指定一项，未指定的保持不变）
+type LogLevelUpdateParams struct {
+	Level   string `json:"level" form:"level"`       // 应用日志级别: debug, info, warn, error
+	DBLevel string `json:"db_level" form:"db_level"` // 数据库日志级别: silent, error, warn, info
+}
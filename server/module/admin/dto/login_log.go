@@ -0,0 +1,19 @@
+package dto
+
+import "github.com/so68/core/server/utils"
+
+// LoginLogIndexParams 登录日志列表参数
+type LoginLogIndexParams struct {
+	utils.Page
+	AdminID  uint   `form:"admin_id"` // 管理员ID
+	Username string `form:"username"` // 用户名
+	IP       string `form:"ip"`       // 登录IP
+	Success  *bool  `form:"success"`  // 是否成功
+	StartAt  int64  `form:"start_at"` // 开始时间(Unix 秒)
+	EndAt    int64  `form:"end_at"`   // 结束时间(Unix 秒)
+}
+
+// LoginLogRecentParams 最近登录参数
+type LoginLogRecentParams struct {
+	Limit int `form:"limit"` // 返回条数（默认 10，最多 50）
+}
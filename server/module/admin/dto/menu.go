@@ -0,0 +1,35 @@
+package dto
+
+// MenuItem 代码注册的菜单（按 Key 同步到数据库，已存在的菜单不会被覆盖）
+type MenuItem struct {
+	Key        string      // 菜单标识(唯一)
+	Title      string      // 菜单标题
+	Path       string      // 前端路由路径
+	Icon       string      // 图标
+	Sort       int         // 排序，越小越靠前
+	Permission string      // 关联的权限名称(AuthHandler 注册路由时的名称)
+	Children   []*MenuItem // 子菜单
+}
+
+// MenuCreateParams 创建菜单参数
+type MenuCreateParams struct {
+	ParentID   uint   `json:"parent_id" form:"parent_id"`             // 上级菜单ID
+	Key        string `json:"key" form:"key" validate:"required"`     // 菜单标识
+	Title      string `json:"title" form:"title" validate:"required"` // 菜单标题
+	Path       string `json:"path" form:"path"`                       // 前端路由路径
+	Icon       string `json:"icon" form:"icon"`                       // 图标
+	Sort       int    `json:"sort" form:"sort"`                       // 排序
+	Permission string `json:"permission" form:"permission"`           // 权限名称
+	Hidden     bool   `json:"hidden" form:"hidden"`                   // 是否隐藏
+}
+
+// MenuUpdateParams 更新菜单参数
+type MenuUpdateParams struct {
+	ID uint `json:"id" form:"id" validate:"required"` // 菜单ID
+	MenuCreateParams
+}
+
+// MenuDeleteParams 删除菜单参数
+type MenuDeleteParams struct {
+	ID uint `json:"id" form:"id" validate:"required"` // 菜单ID
+}
@@ -0,0 +1,29 @@
+package dto
+
+// MFASetupResult 生成 MFA 密钥结果
+type MFASetupResult struct {
+	Secret string `json:"secret"` // 密钥（Base32，可手动输入验证器）
+	URL    string `json:"url"`    // otpauth:// 密钥配置地址（用于生成二维码）
+}
+
+// MFAStatusResult MFA 状态
+type MFAStatusResult struct {
+	Enabled       bool `json:"enabled"`        // 是否已启用
+	RecoveryCodes int  `json:"recovery_codes"` // 剩余可用恢复码数量
+}
+
+// MFACodeParams MFA 验证码参数
+type MFACodeParams struct {
+	Code string `json:"code" form:"code" validate:"required"` // 验证码（启用后也可使用恢复码）
+}
+
+// MFADisableParams 关闭 MFA 参数
+type MFADisableParams struct {
+	Password string `json:"password" form:"password" validate:"required"` // 当前密码
+	Code     string `json:"code" form:"code" validate:"required"`         // 验证码或恢复码
+}
+
+// MFARecoveryCodesResult MFA 恢复码结果
+type MFARecoveryCodesResult struct {
+	Codes []string `json:"codes"` // 恢复码明文，仅在生成时返回一次
+}
@@ -0,0 +1,40 @@
+package dto
+
+import "github.com/so68/core/server/utils"
+
+// NotificationMessage 发送通知内容
+type NotificationMessage struct {
+	Type     string // 通知类型（为空时为 system）
+	Title    string // 标题
+	Content  string // 内容
+	Link     string // 跳转链接
+	SenderID uint   // 发送管理员ID（系统通知为 0）
+}
+
+// NotificationIndexParams 通知列表参数
+type NotificationIndexParams struct {
+	utils.Page
+	Type   string `form:"type"`   // 通知类型
+	Unread bool   `form:"unread"` // 仅未读
+}
+
+// NotificationReadParams 标记通知已读参数
+type NotificationReadParams struct {
+	IDs []uint `json:"ids" form:"ids" validate:"required"` // 通知ID列表
+}
+
+// NotificationUnreadResult 未读通知数
+type NotificationUnreadResult struct {
+	Count int64 `json:"count"` // 未读数量
+}
+
+// NotificationReadResult 标记已读结果
+type NotificationReadResult struct {
+	Count int64 `json:"count"` // 标记数量
+}
+
+// NotificationPush 实时推送的通知消息（WebSocket 消息与 SSE 事件数据）
+type NotificationPush struct {
+	Type string      `json:"type"` // 消息类型：notification 新通知
+	Data interface{} `json:"data"` // 消息数据
+}
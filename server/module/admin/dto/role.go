@@ -0,0 +1,49 @@
+package dto
+
+// RoleInfo 角色信息
+type RoleInfo struct {
+	Name        string   `json:"name"`        // 角色名称
+	BuiltIn     bool     `json:"built_in"`    // 是否为内置角色(不可修改名称或删除)
+	Permissions []string `json:"permissions"` // 直接分配的权限名称
+}
+
+// RoleCreateParams 创建角色参数
+type RoleCreateParams struct {
+	Name        string   `json:"name" form:"name" validate:"required"` // 角色名称
+	Permissions []string `json:"permissions" form:"permissions"`       // 权限名称
+}
+
+// RoleUpdateParams 更新角色参数
+type RoleUpdateParams struct {
+	Name    string `json:"name" form:"name" validate:"required"`         // 角色名称
+	NewName string `json:"new_name" form:"new_name" validate:"required"` // 新角色名称
+}
+
+// RoleDeleteParams 删除角色参数
+type RoleDeleteParams struct {
+	Name string `json:"name" form:"name" validate:"required"` // 角色名称
+}
+
+// RolePermissionsParams 查询角色权限参数
+type RolePermissionsParams struct {
+	Name string `json:"name" form:"name" validate:"required"` // 角色名称
+}
+
+// RoleAssignParams 分配角色权限参数（覆盖原有权限）
+type RoleAssignParams struct {
+	Name        string   `json:"name" form:"name" validate:"required"` // 角色名称
+	Permissions []string `json:"permissions" form:"permissions"`       // 权限名称
+}
+
+// PermissionInfo 权限信息
+type PermissionInfo struct {
+	Name   string `json:"name"`   // 权限名称
+	Path   string `json:"path"`   // 路由路径
+	Method string `json:"method"` // 请求方法
+}
+
+// PolicyData 权限策略导入导出数据
+type PolicyData struct {
+	Policies  [][]string `json:"policies" validate:"required"` // 策略 [主体, 路径, 方法]
+	Groupings [][]string `json:"groupings"`                    // 继承关系 [角色, 继承的角色或权限]
+}
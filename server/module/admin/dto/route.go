@@ -0,0 +1,7 @@
+package dto
+
+// RouteIndexParams 路由列表参数
+type RouteIndexParams struct {
+	Path       string `form:"path"`       // 路径前缀
+	Permission string `form:"permission"` // 所需权限
+}
@@ -0,0 +1,25 @@
+package dto
+
+import "time"
+
+// SessionInfo 登录会话信息
+type SessionInfo struct {
+	ID           string    `json:"id"`             // 会话ID
+	IP           string    `json:"ip"`             // 登录IP
+	UserAgent    string    `json:"user_agent"`     // 登录设备 User-Agent
+	DeviceID     string    `json:"device_id"`      // 设备指纹
+	CreatedAt    time.Time `json:"created_at"`     // 登录时间
+	LastActiveAt time.Time `json:"last_active_at"` // 最近活跃时间(登录或刷新令牌时更新)
+	ExpiresAt    time.Time `json:"expires_at"`     // 过期时间
+	Current      bool      `json:"current"`        // 是否为当前会话
+}
+
+// SessionRevokeParams 吊销登录会话参数
+type SessionRevokeParams struct {
+	ID string `json:"id" form:"id" validate:"required"` // 会话ID
+}
+
+// SessionRevokeAllParams 吊销全部登录会话参数
+type SessionRevokeAllParams struct {
+	IncludeCurrent bool `json:"include_current" form:"include_current"` // 是否同时吊销当前会话
+}
@@ -0,0 +1,21 @@
+package dto
+
+import models "github.com/so68/core/server/database"
+
+// TokenCreateParams 创建机器令牌参数
+type TokenCreateParams struct {
+	Name      string   `json:"name" form:"name" validate:"required"`     // 令牌名称
+	Scopes    []string `json:"scopes" form:"scopes" validate:"required"` // 授权范围
+	ExpiresIn int64    `json:"expires_in" form:"expires_in"`             // 有效期(秒)，0 表示永不过期
+}
+
+// TokenCreateResult 创建机器令牌结果
+type TokenCreateResult struct {
+	Info  *models.AdminToken `json:"info"`  // 令牌信息
+	Token string             `json:"token"` // 令牌明文，仅在创建时返回一次
+}
+
+// TokenRevokeParams 吊销机器令牌参数
+type TokenRevokeParams struct {
+	ID uint `json:"id" form:"id" validate:"required"` // 令牌ID
+}
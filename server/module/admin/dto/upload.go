@@ -0,0 +1,35 @@
+package dto
+
+// UploadResult 上传结果
+type UploadResult struct {
+	URL         string            `json:"url"`                  // 文件访问地址
+	ContentType string            `json:"content_type"`         // 内容类型（按文件内容识别）
+	Size        int64             `json:"size"`                 // 文件大小（图片经过处理时为处理后的大小）
+	Thumbnails  map[string]string `json:"thumbnails,omitempty"` // 缩略图访问地址（按缩略图名称）
+}
+
+// ChunkInitParams 初始化分片上传参数
+type ChunkInitParams struct {
+	Name       string `json:"name" form:"name" validate:"required"` // 文件名
+	Size       int64  `json:"size" form:"size" validate:"required"` // 文件大小
+	Identifier string `json:"identifier" form:"identifier"`         // 文件标识（如文件 MD5），提供时支持断点续传
+}
+
+// ChunkInitResult 初始化分片上传结果
+type ChunkInitResult struct {
+	UploadID  string `json:"upload_id"`  // 上传ID
+	ChunkSize int64  `json:"chunk_size"` // 分片大小（最后一片为剩余部分）
+	Chunks    int    `json:"chunks"`     // 分片数量
+	Uploaded  []int  `json:"uploaded"`   // 已上传的分片序号（断点续传时跳过）
+}
+
+// ChunkParams 上传分片参数（multipart 表单，分片内容字段为 file）
+type ChunkParams struct {
+	UploadID string `form:"upload_id" validate:"required"` // 上传ID
+	Index    int    `form:"index" validate:"min=0"`        // 分片序号（从 0 开始）
+}
+
+// ChunkCompleteParams 完成分片上传参数
+type ChunkCompleteParams struct {
+	UploadID string `json:"upload_id" form:"upload_id" validate:"required"` // 上传ID
+}
@@ -0,0 +1,36 @@
+package events
+
+import (
+	"github.com/so68/core/event"
+	"github.com/so68/core/server/database"
+)
+
+// AdminCreated 管理员创建事件
+type AdminCreated struct {
+	Admin *database.Admin // 新建的管理员
+}
+
+// LoginFailed 管理员登录失败事件
+type LoginFailed struct {
+	Username  string // 登录用户名
+	AdminID   uint   // 管理员ID（用户不存在时为 0）
+	IP        string // 登录IP
+	UserAgent string // 登录设备
+	Locked    bool   // 是否因失败次数过多被锁定
+}
+
+// LoginSucceeded 管理员登录成功事件
+type LoginSucceeded struct {
+	Admin     *database.Admin // 登录的管理员
+	IP        string          // 登录IP
+	UserAgent string          // 登录设备
+}
+
+var (
+	// TopicAdminCreated 管理员创建
+	TopicAdminCreated = event.NewTopic[*AdminCreated]("admin.created")
+	// TopicLoginFailed 管理员登录失败
+	TopicLoginFailed = event.NewTopic[*LoginFailed]("login.failed")
+	// TopicLoginSucceeded 管理员登录成功
+	TopicLoginSucceeded = event.NewTopic[*LoginSucceeded]("login.succeeded")
+)
@@ -1,12 +1,10 @@
 package handler
 
 import (
-	"log/slog"
-
 	"github.com/gin-gonic/gin"
-	"github.com/so68/core/cache"
+	"github.com/so68/core/server/module/admin/dto"
 	"github.com/so68/core/server/module/admin/service"
-	"gorm.io/gorm"
+	"github.com/so68/core/server/utils"
 )
 
 // AdminHandler 管理员处理
@@ -15,36 +13,146 @@ type AdminHandler struct {
 }
 
 // NewAdminHandler 创建一个管理员处理
-func NewAdminHandler(logger *slog.Logger, db *gorm.DB, cache cache.Cache) *AdminHandler {
-	return &AdminHandler{adminService: service.NewAdminService(db, cache, logger)}
+func NewAdminHandler(adminService service.AdminService) *AdminHandler {
+	return &AdminHandler{adminService: adminService}
 }
 
 // Index 管理员列表
 func (h *AdminHandler) Index(c *gin.Context) {
-
+	queryParams := &dto.AdminIndexParams{}
+	if err := c.ShouldBindQuery(queryParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	result, err := h.adminService.List(c.Request.Context(), queryParams)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, result)
 }
 
 // Create 创建管理员
 func (h *AdminHandler) Create(c *gin.Context) {
-
+	bodyParams := &dto.AdminCreateParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	admin, err := h.adminService.Create(c.Request.Context(), utils.GetContextUserID(c), bodyParams)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, admin)
 }
 
 // Update 更新管理员
 func (h *AdminHandler) Update(c *gin.Context) {
-
+	bodyParams := &dto.AdminUpdateParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	admin, err := h.adminService.Update(c.Request.Context(), utils.GetContextUserID(c), bodyParams)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, admin)
 }
 
-// TokenUpdate 更新管理员Token
+// TokenUpdate 更新当前管理员资料
 func (h *AdminHandler) TokenUpdate(c *gin.Context) {
-
+	bodyParams := &dto.AdminProfileParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	admin, err := h.adminService.UpdateProfile(c.Request.Context(), utils.GetContextUserID(c), bodyParams)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, admin)
 }
 
-// TokenPasswordUpdate 更新管理员Token密码
+// TokenPasswordUpdate 修改当前管理员密码（吊销其他登录会话）
 func (h *AdminHandler) TokenPasswordUpdate(c *gin.Context) {
-
+	bodyParams := &dto.AdminPasswordParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	if err := h.adminService.UpdatePassword(c.Request.Context(), utils.GetContextUserID(c), utils.GetContextSessionID(c), bodyParams); err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, nil)
 }
 
 // Delete 删除管理员
 func (h *AdminHandler) Delete(c *gin.Context) {
+	bodyParams := &dto.AdminDeleteParams{}
+	if err := c.ShouldBind(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	if err := h.adminService.Delete(c.Request.Context(), utils.GetContextUserID(c), bodyParams.ID); err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, nil)
+}
+
+// Unlock 解锁管理员
+func (h *AdminHandler) Unlock(c *gin.Context) {
+	bodyParams := &dto.AdminUnlockParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	if err := h.adminService.Unlock(c.Request.Context(), bodyParams.ID); err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, nil)
+}
+
+// Trashed 已删除管理员列表
+func (h *AdminHandler) Trashed(c *gin.Context) {
+	queryParams := &dto.AdminIndexParams{}
+	if err := c.ShouldBindQuery(queryParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	result, err := h.adminService.Trashed(c.Request.Context(), queryParams)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, result)
+}
 
+// Restore 恢复已删除的管理员
+func (h *AdminHandler) Restore(c *gin.Context) {
+	bodyParams := &dto.AdminRestoreParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	if err := h.adminService.Restore(c.Request.Context(), bodyParams.ID); err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, nil)
 }
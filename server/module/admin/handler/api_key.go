@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/module/admin/service"
+	"github.com/so68/core/server/utils"
+)
+
+// APIKeyHandler 管理员 API Key 处理
+type APIKeyHandler struct {
+	apiKeyService service.APIKeyService
+}
+
+// NewAPIKeyHandler 创建一个管理员 API Key 处理
+func NewAPIKeyHandler(apiKeyService service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyService: apiKeyService}
+}
+
+// Index 当前管理员的 API Key 列表
+func (h *APIKeyHandler) Index(c *gin.Context) {
+	keys, err := h.apiKeyService.List(c.Request.Context(), utils.GetContextUserID(c))
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, keys)
+}
+
+// Create 创建 API Key
+func (h *APIKeyHandler) Create(c *gin.Context) {
+	bodyParams := &dto.APIKeyCreateParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	result, err := h.apiKeyService.Create(c.Request.Context(), utils.GetContextUserID(c), bodyParams)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, result)
+}
+
+// Rotate 轮换 API Key
+func (h *APIKeyHandler) Rotate(c *gin.Context) {
+	bodyParams := &dto.APIKeyRotateParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	result, err := h.apiKeyService.Rotate(c.Request.Context(), utils.GetContextUserID(c), bodyParams)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, result)
+}
+
+// Revoke 吊销 API Key
+func (h *APIKeyHandler) Revoke(c *gin.Context) {
+	bodyParams := &dto.APIKeyRevokeParams{}
+	if err := c.ShouldBind(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	if err := h.apiKeyService.Revoke(c.Request.Context(), utils.GetContextUserID(c), bodyParams.ID); err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, nil)
+}
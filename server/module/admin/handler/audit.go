@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/module/admin/service"
+	"github.com/so68/core/server/utils"
+)
+
+// AuditHandler 操作审计日志处理
+type AuditHandler struct {
+	auditService service.AuditService
+}
+
+// NewAuditHandler 创建一个操作审计日志处理
+func NewAuditHandler(auditService service.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+// Index 审计日志列表（代理/商户管理员仅可查看自身及下级的日志）
+func (h *AuditHandler) Index(c *gin.Context) {
+	queryParams := &dto.AuditLogIndexParams{}
+	if err := c.ShouldBindQuery(queryParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	result, err := h.auditService.List(c.Request.Context(), queryParams)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, result)
+}
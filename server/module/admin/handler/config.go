@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/config"
+	coreerrors "github.com/so68/core/errors"
+	"github.com/so68/core/server/utils"
+	"go.yaml.in/yaml/v3"
+)
+
+// ConfigHandler 运行配置处理
+type ConfigHandler struct {
+	cfg *config.AppConfig
+}
+
+// NewConfigHandler 创建一个运行配置处理
+func NewConfigHandler(cfg *config.AppConfig) *ConfigHandler {
+	return &ConfigHandler{cfg: cfg}
+}
+
+// Show 当前生效的配置（密码、密钥等敏感字段已脱敏，字段名与配置文件一致，用于排查问题）
+func (h *ConfigHandler) Show(c *gin.Context) {
+	data, err := yaml.Marshal(h.cfg.Redacted())
+	if err != nil {
+		utils.Fail(c, coreerrors.Internal(err))
+		return
+	}
+	result := map[string]any{}
+	if err := yaml.Unmarshal(data, &result); err != nil {
+		utils.Fail(c, coreerrors.Internal(err))
+		return
+	}
+	utils.Success(c, result)
+}
@@ -1,48 +1,37 @@
 package handler
 
 import (
-	"fmt"
-	"log/slog"
-	"os"
-	"path/filepath"
-	"time"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/so68/core/cache"
+	"github.com/so68/core/oauth"
 	"github.com/so68/core/server/module/admin/dto"
 	"github.com/so68/core/server/module/admin/service"
 	"github.com/so68/core/server/utils"
-	"gorm.io/gorm"
 )
 
 // IndexHandler 首页处理
 type IndexHandler struct {
-	maxHeaderSize int64                // 最大请求头大小
-	staticPath    string               // 静态文件路径
-	indexService  service.IndexService // 首页服务
+	indexService service.IndexService // 首页服务
 }
 
 // NewIndexHandler 创建一个首页处理
-func NewIndexHandler(logger *slog.Logger, db *gorm.DB, cache cache.Cache, jwt *utils.JWT, staticPath string, maxHeaderSize int64) *IndexHandler {
-	return &IndexHandler{
-		maxHeaderSize: maxHeaderSize,
-		staticPath:    staticPath,
-		indexService:  service.NewIndexService(logger, db, cache, jwt),
-	}
+func NewIndexHandler(indexService service.IndexService) *IndexHandler {
+	return &IndexHandler{indexService: indexService}
 }
 
 // Login 管理员登陆
 func (h *IndexHandler) Login(c *gin.Context) {
 	bodyParams := &dto.LoginParams{}
 	if err := c.ShouldBindJSON(bodyParams); err != nil {
-		utils.Error(c, err.Error())
+		utils.BindError(c, err)
 		return
 	}
 
 	// 管理员登陆业务处理
-	result, err := h.indexService.Login(c.Request.Context(), c.ClientIP(), bodyParams)
+	result, err := h.indexService.Login(c.Request.Context(), c.ClientIP(), c.Request.UserAgent(), bodyParams)
 	if err != nil {
-		utils.Error(c, err.Error())
+		utils.Fail(c, err)
 		return
 	}
 
@@ -50,38 +39,92 @@ func (h *IndexHandler) Login(c *gin.Context) {
 	utils.Success(c, result)
 }
 
-// Upload 上传文件
-func (h *IndexHandler) Upload(c *gin.Context) {
-	// 获取上传的文件
-	file, err := c.FormFile("file")
+// Refresh 刷新令牌
+func (h *IndexHandler) Refresh(c *gin.Context) {
+	bodyParams := &dto.RefreshParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	result, err := h.indexService.Refresh(c.Request.Context(), c.ClientIP(), bodyParams)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+
+	utils.Success(c, result)
+}
+
+// ExpiredPassword 修改过期密码
+func (h *IndexHandler) ExpiredPassword(c *gin.Context) {
+	bodyParams := &dto.ExpiredPasswordParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	if err := h.indexService.ExpiredPassword(c.Request.Context(), c.ClientIP(), c.Request.UserAgent(), bodyParams); err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, nil)
+}
+
+// Captcha 获取登录图形验证码
+func (h *IndexHandler) Captcha(c *gin.Context) {
+	result, err := h.indexService.Captcha(c.Request.Context())
 	if err != nil {
-		utils.Error(c, err.Error())
+		utils.Fail(c, err)
 		return
 	}
+	utils.Success(c, result)
+}
 
-	// 验证文件大小 (限制为500MB)
-	if file.Size > h.maxHeaderSize {
-		utils.Error(c, fmt.Sprintf("文件大小超过限制: %d > %d", file.Size, h.maxHeaderSize))
+// OAuthProviders 获取已启用的第三方登录方式
+func (h *IndexHandler) OAuthProviders(c *gin.Context) {
+	utils.Success(c, h.indexService.OAuthProviders())
+}
+
+// OAuthAuthorize 获取第三方登录授权地址
+func (h *IndexHandler) OAuthAuthorize(c *gin.Context) {
+	queryParams := &dto.OAuthAuthorizeParams{}
+	if err := c.ShouldBindQuery(queryParams); err != nil {
+		utils.BindError(c, err)
 		return
 	}
 
-	// 生成安全的文件名
-	filename := fmt.Sprintf("%d_%s", time.Now().UnixNano(), filepath.Base(file.Filename))
+	result, err := h.indexService.OAuthAuthorize(c.Request.Context(), queryParams)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	// 浏览器标识写入 Cookie，授权回调后提交登录时校验，防止登录 CSRF
+	setOAuthStateCookie(c, result.Binding, 0)
+	utils.Success(c, result)
+}
 
-	// 确保上传目录存在
-	uploadDir := filepath.Join(h.staticPath, "uploads")
-	if err := os.MkdirAll(uploadDir, 0755); err != nil {
-		utils.Error(c, err.Error())
+// OAuthLogin 第三方登录
+func (h *IndexHandler) OAuthLogin(c *gin.Context) {
+	bodyParams := &dto.OAuthLoginParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
 		return
 	}
 
-	// 保存文件
-	filepath := filepath.Join(uploadDir, filename)
-	if err := c.SaveUploadedFile(file, filepath); err != nil {
-		utils.Error(c, err.Error())
+	bodyParams.Binding, _ = c.Cookie(oauth.StateCookie)
+	setOAuthStateCookie(c, "", -1)
+
+	result, err := h.indexService.OAuthLogin(c.Request.Context(), c.ClientIP(), c.Request.UserAgent(), bodyParams)
+	if err != nil {
+		utils.Fail(c, err)
 		return
 	}
+	utils.Success(c, result)
+}
 
-	// 返回文件URL
-	utils.Success(c, fmt.Sprintf("/%s", filepath))
+// setOAuthStateCookie 设置（maxAge 小于 0 时删除）第三方登录浏览器标识 Cookie
+func setOAuthStateCookie(c *gin.Context, value string, maxAge int) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauth.StateCookie, value, maxAge, "/", "", c.Request.TLS != nil, true)
 }
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	coreerrors "github.com/so68/core/errors"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/utils"
+)
+
+// LogLevelManager 运行时日志级别管理（由 core.Application 实现）
+type LogLevelManager interface {
+	LogLevel() string
+	SetLogLevel(level string) error
+	DBLogLevel() string
+	SetDBLogLevel(level string) error
+}
+
+// LogLevelHandler 日志级别处理
+type LogLevelHandler struct {
+	manager LogLevelManager
+}
+
+// NewLogLevelHandler 创建一个日志级别处理
+func NewLogLevelHandler(manager LogLevelManager) *LogLevelHandler {
+	return &LogLevelHandler{manager: manager}
+}
+
+// Show 当前日志级别
+func (h *LogLevelHandler) Show(c *gin.Context) {
+	utils.Success(c, h.info())
+}
+
+// Update 运行时调整日志级别（无需重启，重启后恢复为配置文件中的级别）
+func (h *LogLevelHandler) Update(c *gin.Context) {
+	bodyParams := &dto.LogLevelUpdateParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+	if bodyParams.Level == "" && bodyParams.DBLevel == "" {
+		utils.Fail(c, coreerrors.Validation("请指定日志级别"))
+		return
+	}
+
+	if bodyParams.Level != "" {
+		if err := h.manager.SetLogLevel(bodyParams.Level); err != nil {
+			utils.Fail(c, coreerrors.Validation(err.Error()))
+			return
+		}
+	}
+	if bodyParams.DBLevel != "" {
+		if err := h.manager.SetDBLogLevel(bodyParams.DBLevel); err != nil {
+			utils.Fail(c, coreerrors.Validation(err.Error()))
+			return
+		}
+	}
+	utils.Success(c, h.info())
+}
+
+func (h *LogLevelHandler) info() *dto.LogLevelInfo {
+	return &dto.LogLevelInfo{Level: h.manager.LogLevel(), DBLevel: h.manager.DBLogLevel()}
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/module/admin/service"
+	"github.com/so68/core/server/utils"
+)
+
+// LoginLogHandler 登录日志处理
+type LoginLogHandler struct {
+	loginLogService service.LoginLogService
+}
+
+// NewLoginLogHandler 创建一个登录日志处理
+func NewLoginLogHandler(loginLogService service.LoginLogService) *LoginLogHandler {
+	return &LoginLogHandler{loginLogService: loginLogService}
+}
+
+// Index 登录日志列表（代理/商户管理员仅可查看自身及下级的日志）
+func (h *LoginLogHandler) Index(c *gin.Context) {
+	queryParams := &dto.LoginLogIndexParams{}
+	if err := c.ShouldBindQuery(queryParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	result, err := h.loginLogService.List(c.Request.Context(), queryParams)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, result)
+}
+
+// Recent 当前管理员最近的登录记录
+func (h *LoginLogHandler) Recent(c *gin.Context) {
+	queryParams := &dto.LoginLogRecentParams{}
+	if err := c.ShouldBindQuery(queryParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	logs, err := h.loginLogService.Recent(c.Request.Context(), utils.GetContextUserID(c), queryParams.Limit)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, logs)
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/module/admin/service"
+	"github.com/so68/core/server/utils"
+)
+
+// MenuHandler 后台菜单处理
+type MenuHandler struct {
+	casbinService service.CasbinService
+	menuService   service.MenuService
+}
+
+// NewMenuHandler 创建一个后台菜单处理
+func NewMenuHandler(casbinService service.CasbinService, menuService service.MenuService) *MenuHandler {
+	return &MenuHandler{casbinService: casbinService, menuService: menuService}
+}
+
+// Tree 当前管理员可见的菜单树
+func (h *MenuHandler) Tree(c *gin.Context) {
+	role, err := h.casbinService.GetContextRole(c)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+
+	menus, err := h.menuService.Tree(c.Request.Context(), role)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, menus)
+}
+
+// Index 完整菜单树
+func (h *MenuHandler) Index(c *gin.Context) {
+	menus, err := h.menuService.All(c.Request.Context())
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, menus)
+}
+
+// Create 创建菜单
+func (h *MenuHandler) Create(c *gin.Context) {
+	bodyParams := &dto.MenuCreateParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	menu, err := h.menuService.Create(c.Request.Context(), bodyParams)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, menu)
+}
+
+// Update 更新菜单
+func (h *MenuHandler) Update(c *gin.Context) {
+	bodyParams := &dto.MenuUpdateParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	if err := h.menuService.Update(c.Request.Context(), bodyParams); err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, nil)
+}
+
+// Delete 删除菜单及其子菜单
+func (h *MenuHandler) Delete(c *gin.Context) {
+	bodyParams := &dto.MenuDeleteParams{}
+	if err := c.ShouldBind(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	if err := h.menuService.Delete(c.Request.Context(), bodyParams.ID); err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, nil)
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/module/admin/service"
+	"github.com/so68/core/server/utils"
+)
+
+// MFAHandler MFA 双因素认证处理
+type MFAHandler struct {
+	mfaService service.MFAService
+}
+
+// NewMFAHandler 创建一个 MFA 双因素认证处理
+func NewMFAHandler(mfaService service.MFAService) *MFAHandler {
+	return &MFAHandler{mfaService: mfaService}
+}
+
+// Status 当前管理员的 MFA 状态
+func (h *MFAHandler) Status(c *gin.Context) {
+	result, err := h.mfaService.Status(c.Request.Context(), utils.GetContextUserID(c))
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, result)
+}
+
+// Setup 生成 MFA 密钥与二维码地址
+func (h *MFAHandler) Setup(c *gin.Context) {
+	result, err := h.mfaService.Setup(c.Request.Context(), utils.GetContextUserID(c))
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, result)
+}
+
+// Enable 校验首个验证码并启用 MFA
+func (h *MFAHandler) Enable(c *gin.Context) {
+	bodyParams := &dto.MFACodeParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	codes, err := h.mfaService.Enable(c.Request.Context(), utils.GetContextUserID(c), bodyParams.Code)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, &dto.MFARecoveryCodesResult{Codes: codes})
+}
+
+// Disable 关闭 MFA
+func (h *MFAHandler) Disable(c *gin.Context) {
+	bodyParams := &dto.MFADisableParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	if err := h.mfaService.Disable(c.Request.Context(), utils.GetContextUserID(c), bodyParams); err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, nil)
+}
+
+// RecoveryCodes 重新生成恢复码
+func (h *MFAHandler) RecoveryCodes(c *gin.Context) {
+	codes, err := h.mfaService.RegenerateRecoveryCodes(c.Request.Context(), utils.GetContextUserID(c))
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, &dto.MFARecoveryCodesResult{Codes: codes})
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/module/admin/service"
+	"github.com/so68/core/server/utils"
+)
+
+const (
+	notificationStreamHeartbeat = 30 * time.Second // SSE 心跳间隔
+	notificationStreamRetry     = 3 * time.Second  // SSE 客户端重连间隔
+)
+
+// NotificationHandler 站内通知处理
+type NotificationHandler struct {
+	notificationService service.NotificationService
+}
+
+// NewNotificationHandler 创建一个站内通知处理
+func NewNotificationHandler(notificationService service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService}
+}
+
+// Index 当前管理员的通知列表
+func (h *NotificationHandler) Index(c *gin.Context) {
+	queryParams := &dto.NotificationIndexParams{}
+	if err := c.ShouldBindQuery(queryParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	result, err := h.notificationService.List(c.Request.Context(), utils.GetContextUserID(c), queryParams)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, result)
+}
+
+// Unread 当前管理员的未读通知数
+func (h *NotificationHandler) Unread(c *gin.Context) {
+	count, err := h.notificationService.UnreadCount(c.Request.Context(), utils.GetContextUserID(c))
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, &dto.NotificationUnreadResult{Count: count})
+}
+
+// Read 标记指定通知为已读
+func (h *NotificationHandler) Read(c *gin.Context) {
+	bodyParams := &dto.NotificationReadParams{}
+	if err := c.ShouldBind(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	count, err := h.notificationService.MarkRead(c.Request.Context(), utils.GetContextUserID(c), bodyParams.IDs)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, &dto.NotificationReadResult{Count: count})
+}
+
+// ReadAll 标记全部通知为已读
+func (h *NotificationHandler) ReadAll(c *gin.Context) {
+	count, err := h.notificationService.MarkAllRead(c.Request.Context(), utils.GetContextUserID(c))
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, &dto.NotificationReadResult{Count: count})
+}
+
+// Stream 通过 SSE 推送新通知（连接建立时先推送 unread 事件告知未读数，之后每条新通知推送 notification 事件）
+func (h *NotificationHandler) Stream(c *gin.Context) {
+	adminID := utils.GetContextUserID(c)
+	utils.SSE(notificationStreamHeartbeat, notificationStreamRetry, func(ctx context.Context, w *utils.SSEWriter) error {
+		notifications, unsubscribe := h.notificationService.Subscribe(adminID)
+		defer unsubscribe()
+
+		count, err := h.notificationService.UnreadCount(ctx, adminID)
+		if err != nil {
+			return err
+		}
+		if err := w.Send(utils.SSEEvent{Event: "unread", Data: &dto.NotificationUnreadResult{Count: count}}); err != nil {
+			return err
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case notification, ok := <-notifications:
+				if !ok {
+					return nil
+				}
+				if err := w.Send(utils.SSEEvent{ID: strconv.FormatUint(uint64(notification.ID), 10), Event: "notification", Data: notification}); err != nil {
+					return err
+				}
+			}
+		}
+	})(c)
+}
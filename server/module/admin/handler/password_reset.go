@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/module/admin/service"
+	"github.com/so68/core/server/utils"
+)
+
+// PasswordResetHandler 找回密码处理
+type PasswordResetHandler struct {
+	passwordResetService service.PasswordResetService // 找回密码服务
+}
+
+// NewPasswordResetHandler 创建一个找回密码处理
+func NewPasswordResetHandler(passwordResetService service.PasswordResetService) *PasswordResetHandler {
+	return &PasswordResetHandler{passwordResetService: passwordResetService}
+}
+
+// Forgot 申请找回密码（账号不存在时同样返回成功）
+func (h *PasswordResetHandler) Forgot(c *gin.Context) {
+	bodyParams := &dto.ForgotPasswordParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	if err := h.passwordResetService.Forgot(c.Request.Context(), c.ClientIP(), c.Request.UserAgent(), bodyParams); err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, nil)
+}
+
+// Reset 重置密码
+func (h *PasswordResetHandler) Reset(c *gin.Context) {
+	bodyParams := &dto.ResetPasswordParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	if err := h.passwordResetService.Reset(c.Request.Context(), c.ClientIP(), c.Request.UserAgent(), bodyParams); err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, nil)
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/module/admin/service"
+	"github.com/so68/core/server/utils"
+)
+
+// RoleHandler 角色与权限处理
+type RoleHandler struct {
+	casbinService service.CasbinService
+}
+
+// NewRoleHandler 创建一个角色与权限处理
+func NewRoleHandler(casbinService service.CasbinService) *RoleHandler {
+	return &RoleHandler{casbinService: casbinService}
+}
+
+// Index 角色列表
+func (h *RoleHandler) Index(c *gin.Context) {
+	roles, err := h.casbinService.ListRoles()
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, roles)
+}
+
+// Create 创建角色
+func (h *RoleHandler) Create(c *gin.Context) {
+	bodyParams := &dto.RoleCreateParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	if err := h.casbinService.CreateRole(bodyParams.Name, bodyParams.Permissions); err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, nil)
+}
+
+// Update 修改角色名称
+func (h *RoleHandler) Update(c *gin.Context) {
+	bodyParams := &dto.RoleUpdateParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	if err := h.casbinService.UpdateRole(c.Request.Context(), bodyParams.Name, bodyParams.NewName); err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, nil)
+}
+
+// Delete 删除角色
+func (h *RoleHandler) Delete(c *gin.Context) {
+	bodyParams := &dto.RoleDeleteParams{}
+	if err := c.ShouldBind(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	if err := h.casbinService.DeleteRole(c.Request.Context(), bodyParams.Name); err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, nil)
+}
+
+// Permissions 角色的有效权限（含继承）
+func (h *RoleHandler) Permissions(c *gin.Context) {
+	queryParams := &dto.RolePermissionsParams{}
+	if err := c.ShouldBindQuery(queryParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	permissions, err := h.casbinService.GetRolePermissions(queryParams.Name)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, permissions)
+}
+
+// Assign 分配角色权限（覆盖原有权限）
+func (h *RoleHandler) Assign(c *gin.Context) {
+	bodyParams := &dto.RoleAssignParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	if err := h.casbinService.AssignPermissions(bodyParams.Name, bodyParams.Permissions); err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, nil)
+}
+
+// PermissionIndex 全部权限列表
+func (h *RoleHandler) PermissionIndex(c *gin.Context) {
+	permissions, err := h.casbinService.ListPermissions()
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, permissions)
+}
+
+// Export 导出权限策略
+func (h *RoleHandler) Export(c *gin.Context) {
+	data, err := h.casbinService.ExportPolicies()
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, data)
+}
+
+// Import 批量导入权限策略
+func (h *RoleHandler) Import(c *gin.Context) {
+	bodyParams := &dto.PolicyData{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	if err := h.casbinService.ImportPolicies(bodyParams); err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, nil)
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/server"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/utils"
+)
+
+// RouteHandler 路由列表处理
+type RouteHandler struct {
+	srv server.Server
+}
+
+// NewRouteHandler 创建一个路由列表处理
+func NewRouteHandler(srv server.Server) *RouteHandler {
+	return &RouteHandler{srv: srv}
+}
+
+// Index 已注册的路由列表（含名称与所需权限，用于接口文档与权限审计）
+func (h *RouteHandler) Index(c *gin.Context) {
+	queryParams := &dto.RouteIndexParams{}
+	if err := c.ShouldBindQuery(queryParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	routes := make([]server.RouteInfo, 0)
+	for _, route := range h.srv.Routes() {
+		if queryParams.Path != "" && !strings.HasPrefix(route.Path, queryParams.Path) {
+			continue
+		}
+		if queryParams.Permission != "" && route.Permission != queryParams.Permission {
+			continue
+		}
+		routes = append(routes, route)
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path == routes[j].Path {
+			return routes[i].Method < routes[j].Method
+		}
+		return routes[i].Path < routes[j].Path
+	})
+	utils.Success(c, routes)
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/module/admin/service"
+	"github.com/so68/core/server/utils"
+)
+
+// SessionHandler 登录会话处理
+type SessionHandler struct {
+	sessionService service.SessionService
+}
+
+// NewSessionHandler 创建一个登录会话处理
+func NewSessionHandler(sessionService service.SessionService) *SessionHandler {
+	return &SessionHandler{sessionService: sessionService}
+}
+
+// Index 当前管理员的活跃会话列表
+func (h *SessionHandler) Index(c *gin.Context) {
+	sessions, err := h.sessionService.List(c.Request.Context(), utils.GetContextUserID(c), utils.GetContextSessionID(c))
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, sessions)
+}
+
+// Revoke 吊销指定会话
+func (h *SessionHandler) Revoke(c *gin.Context) {
+	bodyParams := &dto.SessionRevokeParams{}
+	if err := c.ShouldBind(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	if err := h.sessionService.Revoke(c.Request.Context(), utils.GetContextUserID(c), bodyParams.ID); err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, nil)
+}
+
+// RevokeAll 吊销全部会话（默认保留当前会话）
+func (h *SessionHandler) RevokeAll(c *gin.Context) {
+	bodyParams := &dto.SessionRevokeAllParams{}
+	if err := c.ShouldBind(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	except := utils.GetContextSessionID(c)
+	if bodyParams.IncludeCurrent {
+		except = ""
+	}
+	count, err := h.sessionService.RevokeAll(c.Request.Context(), utils.GetContextUserID(c), except)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, count)
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/module/admin/service"
+	"github.com/so68/core/server/utils"
+)
+
+// TokenHandler 机器令牌处理
+type TokenHandler struct {
+	tokenService service.TokenService
+}
+
+// NewTokenHandler 创建一个机器令牌处理
+func NewTokenHandler(tokenService service.TokenService) *TokenHandler {
+	return &TokenHandler{tokenService: tokenService}
+}
+
+// Index 当前管理员的机器令牌列表
+func (h *TokenHandler) Index(c *gin.Context) {
+	tokens, err := h.tokenService.List(c.Request.Context(), utils.GetContextUserID(c))
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, tokens)
+}
+
+// Create 签发机器令牌
+func (h *TokenHandler) Create(c *gin.Context) {
+	bodyParams := &dto.TokenCreateParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	result, err := h.tokenService.Create(c.Request.Context(), utils.GetContextUserID(c), bodyParams)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, result)
+}
+
+// Revoke 吊销机器令牌
+func (h *TokenHandler) Revoke(c *gin.Context) {
+	bodyParams := &dto.TokenRevokeParams{}
+	if err := c.ShouldBind(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	if err := h.tokenService.Revoke(c.Request.Context(), utils.GetContextUserID(c), bodyParams.ID); err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, nil)
+}
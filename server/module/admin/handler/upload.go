@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/module/admin/service"
+	"github.com/so68/core/server/utils"
+)
+
+// UploadHandler 文件上传处理
+type UploadHandler struct {
+	uploadService service.UploadService
+	profile       string // 图片处理配置名称
+}
+
+// NewUploadHandler 创建一个文件上传处理
+func NewUploadHandler(uploadService service.UploadService, profile string) *UploadHandler {
+	return &UploadHandler{uploadService: uploadService, profile: profile}
+}
+
+// Upload 上传文件（multipart 表单，文件字段为 file）
+func (h *UploadHandler) Upload(c *gin.Context) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+
+	result, err := h.uploadService.Upload(c.Request.Context(), h.profile, file)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, result)
+}
+
+// ChunkInit 初始化分片上传
+func (h *UploadHandler) ChunkInit(c *gin.Context) {
+	bodyParams := &dto.ChunkInitParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	result, err := h.uploadService.ChunkInit(c.Request.Context(), utils.GetContextUserID(c), bodyParams)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, result)
+}
+
+// Chunk 上传分片（multipart 表单，分片内容字段为 file）
+func (h *UploadHandler) Chunk(c *gin.Context) {
+	bodyParams := &dto.ChunkParams{}
+	if err := c.ShouldBind(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+	file, err := c.FormFile("file")
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+
+	if err := h.uploadService.Chunk(c.Request.Context(), utils.GetContextUserID(c), bodyParams, file); err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, nil)
+}
+
+// ChunkComplete 完成分片上传
+func (h *UploadHandler) ChunkComplete(c *gin.Context) {
+	bodyParams := &dto.ChunkCompleteParams{}
+	if err := c.ShouldBindJSON(bodyParams); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	result, err := h.uploadService.ChunkComplete(c.Request.Context(), utils.GetContextUserID(c), h.profile, bodyParams)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, result)
+}
@@ -17,6 +17,48 @@ func InitMigrate(db *gorm.DB, logger *slog.Logger) error {
 	if err := db.AutoMigrate(&database.Admin{}); err != nil {
 		return fmt.Errorf("迁移管理员表失败: %w", err)
 	}
+	// 用户名改为租户内唯一，删除旧的全局唯一索引
+	if db.Migrator().HasIndex(&database.Admin{}, "idx_admins_username") {
+		if err := db.Migrator().DropIndex(&database.Admin{}, "idx_admins_username"); err != nil {
+			return fmt.Errorf("删除管理员用户名索引失败: %w", err)
+		}
+	}
+	// 迁移管理员机器令牌表
+	if err := db.AutoMigrate(&database.AdminToken{}); err != nil {
+		return fmt.Errorf("迁移管理员机器令牌表失败: %w", err)
+	}
+	// 迁移管理员 API Key 表
+	if err := db.AutoMigrate(&database.AdminAPIKey{}); err != nil {
+		return fmt.Errorf("迁移管理员 API Key 表失败: %w", err)
+	}
+	// 迁移管理员操作审计日志表
+	if err := db.AutoMigrate(&database.AdminAuditLog{}); err != nil {
+		return fmt.Errorf("迁移管理员操作审计日志表失败: %w", err)
+	}
+	// 迁移管理员登录日志表
+	if err := db.AutoMigrate(&database.AdminLoginLog{}); err != nil {
+		return fmt.Errorf("迁移管理员登录日志表失败: %w", err)
+	}
+	// 迁移管理员历史密码表
+	if err := db.AutoMigrate(&database.AdminPasswordHistory{}); err != nil {
+		return fmt.Errorf("迁移管理员历史密码表失败: %w", err)
+	}
+	// 迁移管理员 MFA 恢复码表
+	if err := db.AutoMigrate(&database.AdminMFARecoveryCode{}); err != nil {
+		return fmt.Errorf("迁移管理员 MFA 恢复码表失败: %w", err)
+	}
+	// 迁移后台菜单表
+	if err := db.AutoMigrate(&database.AdminMenu{}); err != nil {
+		return fmt.Errorf("迁移后台菜单表失败: %w", err)
+	}
+	// 迁移管理员站内通知表
+	if err := db.AutoMigrate(&database.AdminNotification{}); err != nil {
+		return fmt.Errorf("迁移管理员站内通知表失败: %w", err)
+	}
+	// 迁移管理员第三方账号表
+	if err := db.AutoMigrate(&database.AdminIdentity{}); err != nil {
+		return fmt.Errorf("迁移管理员第三方账号表失败: %w", err)
+	}
 	// 载入管理员数据
 	if err := db.Model(&database.Admin{}).Count(&nums).Error; err == nil && nums == 0 {
 		// 载入管理员数据
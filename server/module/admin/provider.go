@@ -0,0 +1,154 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/so68/core/cache"
+	"github.com/so68/core/captcha"
+	"github.com/so68/core/config"
+	"github.com/so68/core/event"
+	"github.com/so68/core/oauth"
+	"github.com/so68/core/server/module/admin/service"
+	"github.com/so68/core/server/utils"
+	"github.com/so68/core/sms"
+	"github.com/so68/core/storage"
+	"gorm.io/gorm"
+)
+
+// providers 管理员服务构造函数（注册到依赖注入容器，首次使用时创建，其他模块可通过 core.Resolve 复用）
+func (c *AdminApp) providers() []interface{} {
+	return []interface{}{
+		service.NewCasbinService,
+		service.NewTokenService,
+		service.NewAPIKeyService,
+		service.NewSessionService,
+		service.NewExternalIdentityService,
+		service.NewAuditService,
+		service.NewLoginLogService,
+		service.NewDataScopeService,
+		service.NewMenuService,
+		service.NewAdminService,
+		c.newJWT,
+		c.newPasswordService,
+		c.newNotifyService,
+		c.newMFAService,
+		c.newCaptcha,
+		c.newOAuth,
+		c.newIndexService,
+		c.newPasswordResetService,
+		c.newUploadValidator,
+		c.newUploadService,
+		c.newNotificationService,
+	}
+}
+
+// newJWT 创建 JWT 实例（使用默认密钥或签名密钥加载失败时返回错误）
+func (c *AdminApp) newJWT(sessionService service.SessionService, externalIdentityService service.ExternalIdentityService) (*utils.JWT, error) {
+	cfg := c.app.Config.JWT
+	if cfg.SecretKey == config.DefaultJWTSecretKey {
+		return nil, errors.New("jwt.secretKey must be changed from the default value")
+	}
+	jwt := utils.NewJWT(cfg.SecretKey, time.Duration(cfg.ExpiresIn)*time.Second)
+	// 如果启用单点登录，则使用缓存验证Token
+	if cfg.EnableSingle {
+		jwt.WithCache(c.app.Cache)
+	}
+	// 使用配置的签名密钥（支持 RS256/ES256 与多密钥轮换）
+	keys, err := utils.LoadSigningKeys(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("load jwt signing keys: %w", err)
+	}
+	if len(keys) > 0 {
+		jwt.WithKeys(keys...)
+	}
+	// 信任外部身份提供方签发的 Token（sub 须已绑定管理员）
+	if cfg.JWKSURL != "" {
+		jwt.WithExternal(utils.NewRemoteKeySet(cfg.JWKSURL, cfg.JWKSCacheTTL), cfg.Issuer, cfg.Audience, externalIdentityService.Resolve)
+	}
+	// 校验登录会话（会话吊销后令牌立即失效）
+	jwt.WithSessionValidator(sessionService.Validate)
+	// 启用刷新令牌（基于缓存轮换与吊销）
+	if cfg.RefreshExpiresIn > 0 {
+		jwt.WithRefresh(cfg.RefreshSecretKey, time.Duration(cfg.RefreshExpiresIn)*time.Second, c.app.Cache)
+	}
+	return jwt, nil
+}
+
+// newPasswordService 创建密码策略服务
+func (c *AdminApp) newPasswordService(db *gorm.DB, logger *slog.Logger) service.PasswordService {
+	return service.NewPasswordService(db, c.app.Config.PasswordPolicy, logger)
+}
+
+// newNotifyService 创建安全提醒服务
+func (c *AdminApp) newNotifyService(logger *slog.Logger) service.NotifyService {
+	return service.NewNotifyService(c.app.Config.Name, c.app.Mailer, logger)
+}
+
+// newMFAService 创建 MFA 双因素认证服务
+func (c *AdminApp) newMFAService(db *gorm.DB, logger *slog.Logger) service.MFAService {
+	return service.NewMFAService(db, c.app.Config.Name, logger)
+}
+
+// newCaptcha 创建登录图形验证码（未启用时为 nil）
+func (c *AdminApp) newCaptcha(logger *slog.Logger) captcha.Manager {
+	cfg := c.app.Config.Captcha
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+	manager, err := captcha.NewManager(cfg, c.app.Cache, logger)
+	if err != nil {
+		logger.Error("创建图形验证码失败", "error", err)
+		return nil
+	}
+	return manager
+}
+
+// newOAuth 创建第三方登录（未启用时为 nil）
+func (c *AdminApp) newOAuth(logger *slog.Logger) *oauth.Manager {
+	cfg := c.app.Config.Auth
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+	manager, err := oauth.NewManager(cfg, c.app.Cache, logger)
+	if err != nil {
+		logger.Error("创建第三方登录失败", "error", err)
+		return nil
+	}
+	return manager
+}
+
+// newIndexService 创建首页服务
+func (c *AdminApp) newIndexService(logger *slog.Logger, db *gorm.DB, cache cache.Cache, jwt *utils.JWT, notifyService service.NotifyService, sessionService service.SessionService, loginLogService service.LoginLogService, passwordService service.PasswordService, mfaService service.MFAService, captchaManager captcha.Manager, events *event.Bus, oauthManager *oauth.Manager) service.IndexService {
+	return service.NewIndexService(logger, db, cache, jwt, notifyService, sessionService, loginLogService, passwordService, mfaService, c.app.Config.Security, captchaManager, c.app.Config.Captcha, events, oauthManager)
+}
+
+// newPasswordResetService 创建找回密码服务（短信验证码需要启用短信与缓存）
+func (c *AdminApp) newPasswordResetService(db *gorm.DB, jwt *utils.JWT, passwordService service.PasswordService, sessionService service.SessionService, auditService service.AuditService, captchaManager captcha.Manager, logger *slog.Logger) service.PasswordResetService {
+	var smsCode *sms.CodeManager
+	if c.app.SMS != nil {
+		if manager, err := sms.NewCodeManager(c.app.Config.SMS.Code, c.app.SMS, c.app.Cache, logger); err != nil {
+			logger.Error("创建短信验证码失败", "error", err)
+		} else {
+			smsCode = manager
+		}
+	}
+	return service.NewPasswordResetService(c.app.Config.Name, db, c.app.Cache, jwt, c.app.Mailer, smsCode, passwordService, sessionService, auditService, captchaManager, c.app.Config.PasswordReset, logger)
+}
+
+// newUploadValidator 创建上传文件校验
+func (c *AdminApp) newUploadValidator() *storage.UploadValidator {
+	return storage.NewUploadValidator(c.app.Config.Upload)
+}
+
+// newUploadService 创建文件上传服务
+func (c *AdminApp) newUploadService(validator *storage.UploadValidator, logger *slog.Logger) service.UploadService {
+	return service.NewUploadService(c.app.Storage, validator, logger)
+}
+
+// newNotificationService 创建站内通知服务（新通知实时推送到 WebSocket 连接与 SSE 订阅者）
+func (c *AdminApp) newNotificationService(db *gorm.DB, logger *slog.Logger) service.NotificationService {
+	return service.NewNotificationService(db, c.hub, logger)
+}
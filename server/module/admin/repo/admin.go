@@ -21,6 +21,14 @@ type AdminRepo interface {
 	Update(ctx context.Context, builder *utils.GormBuilder, admin *models.Admin) error
 	// Delete 删除管理员
 	Delete(ctx context.Context, builder *utils.GormBuilder, isScoped bool, id uint) error
+	// Restore 恢复已删除的管理员
+	Restore(ctx context.Context, builder *utils.GormBuilder, id uint) error
+	// CreateInBatches 分批创建管理员
+	CreateInBatches(ctx context.Context, builder *utils.GormBuilder, admins []*models.Admin, batchSize int) error
+	// BatchUpdate 按条件批量更新管理员字段
+	BatchUpdate(ctx context.Context, builder *utils.GormBuilder, values map[string]interface{}) (int64, error)
+	// BatchDelete 按条件批量删除管理员（maxAffected 为删除数量上限）
+	BatchDelete(ctx context.Context, builder *utils.GormBuilder, isScoped bool, maxAffected int64) (int64, error)
 }
 
 // AdminRepoImpl 管理员数据操作实现
@@ -53,8 +61,11 @@ func (r *AdminRepoImpl) FindList(ctx context.Context, builder *utils.GormBuilder
 // FindListWithPage 构建查询分页
 func (r *AdminRepoImpl) FindListWithPage(ctx context.Context, builder *utils.GormBuilder) (*utils.PageResp, error) {
 	var admins []*models.Admin
-	var total int64
-	if err := builder.TotalCount(total).Find(&admins); err != nil {
+	total, err := builder.TotalCount(&models.Admin{})
+	if err != nil {
+		return nil, err
+	}
+	if err := builder.Find(&admins); err != nil {
 		return nil, err
 	}
 	return utils.NewPageResp(total, builder.Page, admins), nil
@@ -74,3 +85,23 @@ func (r *AdminRepoImpl) Update(ctx context.Context, builder *utils.GormBuilder,
 func (r *AdminRepoImpl) Delete(ctx context.Context, builder *utils.GormBuilder, isScoped bool, id uint) error {
 	return builder.WhereEqual("id", id).Delete(isScoped, &models.Admin{})
 }
+
+// Restore 恢复已删除的管理员
+func (r *AdminRepoImpl) Restore(ctx context.Context, builder *utils.GormBuilder, id uint) error {
+	return builder.WhereEqual("id", id).Restore(&models.Admin{})
+}
+
+// CreateInBatches 分批创建管理员
+func (r *AdminRepoImpl) CreateInBatches(ctx context.Context, builder *utils.GormBuilder, admins []*models.Admin, batchSize int) error {
+	return builder.CreateInBatches(admins, batchSize)
+}
+
+// BatchUpdate 按条件批量更新管理员字段
+func (r *AdminRepoImpl) BatchUpdate(ctx context.Context, builder *utils.GormBuilder, values map[string]interface{}) (int64, error) {
+	return builder.BatchUpdate(&models.Admin{}, values)
+}
+
+// BatchDelete 按条件批量删除管理员
+func (r *AdminRepoImpl) BatchDelete(ctx context.Context, builder *utils.GormBuilder, isScoped bool, maxAffected int64) (int64, error) {
+	return builder.BatchDelete(isScoped, &models.Admin{}, maxAffected)
+}
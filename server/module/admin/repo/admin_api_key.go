@@ -0,0 +1,64 @@
+package repo
+
+import (
+	"context"
+
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/utils"
+)
+
+// AdminAPIKeyRepo 管理员 API Key 数据操作
+type AdminAPIKeyRepo interface {
+	// Find 构建查询
+	Find(ctx context.Context, builder *utils.GormBuilder) (*models.AdminAPIKey, error)
+	// FindList 构建查询列表
+	FindList(ctx context.Context, builder *utils.GormBuilder) ([]*models.AdminAPIKey, error)
+	// Create 创建 API Key
+	Create(ctx context.Context, builder *utils.GormBuilder, key *models.AdminAPIKey) error
+	// Update 更新 API Key
+	Update(ctx context.Context, builder *utils.GormBuilder, key *models.AdminAPIKey) error
+	// UpdateColumns 按条件更新 API Key 的指定字段
+	UpdateColumns(ctx context.Context, builder *utils.GormBuilder, values map[string]interface{}) (int64, error)
+}
+
+// AdminAPIKeyRepoImpl 管理员 API Key 数据操作实现
+type AdminAPIKeyRepoImpl struct {
+}
+
+// NewAdminAPIKeyRepo 创建一个管理员 API Key 数据操作
+func NewAdminAPIKeyRepo() AdminAPIKeyRepo {
+	return &AdminAPIKeyRepoImpl{}
+}
+
+// Find 查询单条数据
+func (r *AdminAPIKeyRepoImpl) Find(ctx context.Context, builder *utils.GormBuilder) (*models.AdminAPIKey, error) {
+	var key models.AdminAPIKey
+	if err := builder.First(&key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// FindList 构建查询列表
+func (r *AdminAPIKeyRepoImpl) FindList(ctx context.Context, builder *utils.GormBuilder) ([]*models.AdminAPIKey, error) {
+	var keys []*models.AdminAPIKey
+	if err := builder.Find(&keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Create 创建 API Key
+func (r *AdminAPIKeyRepoImpl) Create(ctx context.Context, builder *utils.GormBuilder, key *models.AdminAPIKey) error {
+	return builder.Create(key)
+}
+
+// Update 更新 API Key
+func (r *AdminAPIKeyRepoImpl) Update(ctx context.Context, builder *utils.GormBuilder, key *models.AdminAPIKey) error {
+	return builder.Update(key)
+}
+
+// UpdateColumns 按条件更新 API Key 的指定字段
+func (r *AdminAPIKeyRepoImpl) UpdateColumns(ctx context.Context, builder *utils.GormBuilder, values map[string]interface{}) (int64, error) {
+	return builder.UpdateColumns(&models.AdminAPIKey{}, values)
+}
@@ -0,0 +1,50 @@
+package repo
+
+import (
+	"context"
+
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/utils"
+)
+
+// AdminAuditLogRepo 管理员操作审计日志数据操作
+type AdminAuditLogRepo interface {
+	// FindListWithPage 构建分页查询列表
+	FindListWithPage(ctx context.Context, builder *utils.GormBuilder) (*utils.PageResp, error)
+	// Create 创建审计日志
+	Create(ctx context.Context, builder *utils.GormBuilder, log *models.AdminAuditLog) error
+	// Delete 删除审计日志（物理删除）
+	Delete(ctx context.Context, builder *utils.GormBuilder) error
+}
+
+// AdminAuditLogRepoImpl 管理员操作审计日志数据操作实现
+type AdminAuditLogRepoImpl struct {
+}
+
+// NewAdminAuditLogRepo 创建一个管理员操作审计日志数据操作
+func NewAdminAuditLogRepo() AdminAuditLogRepo {
+	return &AdminAuditLogRepoImpl{}
+}
+
+// FindListWithPage 构建分页查询列表
+func (r *AdminAuditLogRepoImpl) FindListWithPage(ctx context.Context, builder *utils.GormBuilder) (*utils.PageResp, error) {
+	var logs []*models.AdminAuditLog
+	total, err := builder.TotalCount(&models.AdminAuditLog{})
+	if err != nil {
+		return nil, err
+	}
+	if err := builder.Find(&logs); err != nil {
+		return nil, err
+	}
+	return utils.NewPageResp(total, builder.Page, logs), nil
+}
+
+// Create 创建审计日志
+func (r *AdminAuditLogRepoImpl) Create(ctx context.Context, builder *utils.GormBuilder, log *models.AdminAuditLog) error {
+	return builder.Create(log)
+}
+
+// Delete 删除审计日志（物理删除）
+func (r *AdminAuditLogRepoImpl) Delete(ctx context.Context, builder *utils.GormBuilder) error {
+	return builder.Delete(false, &models.AdminAuditLog{})
+}
@@ -0,0 +1,46 @@
+package repo
+
+import (
+	"context"
+
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/utils"
+)
+
+// AdminIdentityRepo 管理员第三方账号数据操作
+type AdminIdentityRepo interface {
+	// Find 构建查询
+	Find(ctx context.Context, builder *utils.GormBuilder) (*models.AdminIdentity, error)
+	// Create 创建第三方账号绑定
+	Create(ctx context.Context, builder *utils.GormBuilder, identity *models.AdminIdentity) error
+	// Update 更新第三方账号绑定
+	Update(ctx context.Context, builder *utils.GormBuilder, identity *models.AdminIdentity) error
+}
+
+// AdminIdentityRepoImpl 管理员第三方账号数据操作实现
+type AdminIdentityRepoImpl struct {
+}
+
+// NewAdminIdentityRepo 创建一个管理员第三方账号数据操作
+func NewAdminIdentityRepo() AdminIdentityRepo {
+	return &AdminIdentityRepoImpl{}
+}
+
+// Find 构建查询
+func (r *AdminIdentityRepoImpl) Find(ctx context.Context, builder *utils.GormBuilder) (*models.AdminIdentity, error) {
+	var identity models.AdminIdentity
+	if err := builder.First(&identity); err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// Create 创建第三方账号绑定
+func (r *AdminIdentityRepoImpl) Create(ctx context.Context, builder *utils.GormBuilder, identity *models.AdminIdentity) error {
+	return builder.Create(identity)
+}
+
+// Update 更新第三方账号绑定
+func (r *AdminIdentityRepoImpl) Update(ctx context.Context, builder *utils.GormBuilder, identity *models.AdminIdentity) error {
+	return builder.Update(identity)
+}
@@ -0,0 +1,54 @@
+package repo
+
+import (
+	"context"
+
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/utils"
+)
+
+// AdminLoginLogRepo 管理员登录日志数据操作
+type AdminLoginLogRepo interface {
+	// FindList 构建查询列表
+	FindList(ctx context.Context, builder *utils.GormBuilder) ([]*models.AdminLoginLog, error)
+	// FindListWithPage 构建分页查询列表
+	FindListWithPage(ctx context.Context, builder *utils.GormBuilder) (*utils.PageResp, error)
+	// Create 创建登录日志
+	Create(ctx context.Context, builder *utils.GormBuilder, log *models.AdminLoginLog) error
+}
+
+// AdminLoginLogRepoImpl 管理员登录日志数据操作实现
+type AdminLoginLogRepoImpl struct {
+}
+
+// NewAdminLoginLogRepo 创建一个管理员登录日志数据操作
+func NewAdminLoginLogRepo() AdminLoginLogRepo {
+	return &AdminLoginLogRepoImpl{}
+}
+
+// FindList 构建查询列表
+func (r *AdminLoginLogRepoImpl) FindList(ctx context.Context, builder *utils.GormBuilder) ([]*models.AdminLoginLog, error) {
+	var logs []*models.AdminLoginLog
+	if err := builder.Find(&logs); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// FindListWithPage 构建分页查询列表
+func (r *AdminLoginLogRepoImpl) FindListWithPage(ctx context.Context, builder *utils.GormBuilder) (*utils.PageResp, error) {
+	var logs []*models.AdminLoginLog
+	total, err := builder.TotalCount(&models.AdminLoginLog{})
+	if err != nil {
+		return nil, err
+	}
+	if err := builder.Find(&logs); err != nil {
+		return nil, err
+	}
+	return utils.NewPageResp(total, builder.Page, logs), nil
+}
+
+// Create 创建登录日志
+func (r *AdminLoginLogRepoImpl) Create(ctx context.Context, builder *utils.GormBuilder, log *models.AdminLoginLog) error {
+	return builder.Create(log)
+}
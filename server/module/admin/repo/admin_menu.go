@@ -0,0 +1,68 @@
+package repo
+
+import (
+	"context"
+
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/utils"
+)
+
+// AdminMenuRepo 后台菜单数据操作
+type AdminMenuRepo interface {
+	// Find 构建查询
+	Find(ctx context.Context, builder *utils.GormBuilder) (*models.AdminMenu, error)
+	// FindList 构建查询列表
+	FindList(ctx context.Context, builder *utils.GormBuilder) ([]*models.AdminMenu, error)
+	// Create 创建菜单
+	Create(ctx context.Context, builder *utils.GormBuilder, menu *models.AdminMenu) error
+	// Update 更新菜单
+	Update(ctx context.Context, builder *utils.GormBuilder, menu *models.AdminMenu) error
+	// Delete 删除菜单
+	Delete(ctx context.Context, builder *utils.GormBuilder, ids ...uint) error
+}
+
+// AdminMenuRepoImpl 后台菜单数据操作实现
+type AdminMenuRepoImpl struct {
+}
+
+// NewAdminMenuRepo 创建一个后台菜单数据操作
+func NewAdminMenuRepo() AdminMenuRepo {
+	return &AdminMenuRepoImpl{}
+}
+
+// Find 查询单条数据
+func (r *AdminMenuRepoImpl) Find(ctx context.Context, builder *utils.GormBuilder) (*models.AdminMenu, error) {
+	var menu models.AdminMenu
+	if err := builder.First(&menu); err != nil {
+		return nil, err
+	}
+	return &menu, nil
+}
+
+// FindList 构建查询列表
+func (r *AdminMenuRepoImpl) FindList(ctx context.Context, builder *utils.GormBuilder) ([]*models.AdminMenu, error) {
+	var menus []*models.AdminMenu
+	if err := builder.Find(&menus); err != nil {
+		return nil, err
+	}
+	return menus, nil
+}
+
+// Create 创建菜单
+func (r *AdminMenuRepoImpl) Create(ctx context.Context, builder *utils.GormBuilder, menu *models.AdminMenu) error {
+	return builder.Create(menu)
+}
+
+// Update 更新菜单
+func (r *AdminMenuRepoImpl) Update(ctx context.Context, builder *utils.GormBuilder, menu *models.AdminMenu) error {
+	return builder.Update(menu)
+}
+
+// Delete 删除菜单（物理删除，菜单标识可重新使用）
+func (r *AdminMenuRepoImpl) Delete(ctx context.Context, builder *utils.GormBuilder, ids ...uint) error {
+	values := make([]interface{}, len(ids))
+	for i, id := range ids {
+		values[i] = id
+	}
+	return builder.WhereIn("id", values).Delete(false, &models.AdminMenu{})
+}
@@ -0,0 +1,71 @@
+package repo
+
+import (
+	"context"
+
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/utils"
+)
+
+// AdminMFARecoveryCodeRepo 管理员 MFA 恢复码数据操作
+type AdminMFARecoveryCodeRepo interface {
+	// Find 构建查询
+	Find(ctx context.Context, builder *utils.GormBuilder) (*models.AdminMFARecoveryCode, error)
+	// FindList 构建查询列表
+	FindList(ctx context.Context, builder *utils.GormBuilder) ([]*models.AdminMFARecoveryCode, error)
+	// Create 批量创建恢复码
+	Create(ctx context.Context, builder *utils.GormBuilder, codes []*models.AdminMFARecoveryCode) error
+	// Update 更新恢复码
+	Update(ctx context.Context, builder *utils.GormBuilder, code *models.AdminMFARecoveryCode) error
+	// BatchUpdate 按条件批量更新恢复码字段
+	BatchUpdate(ctx context.Context, builder *utils.GormBuilder, values map[string]interface{}) (int64, error)
+	// Delete 删除恢复码（物理删除）
+	Delete(ctx context.Context, builder *utils.GormBuilder) error
+}
+
+// AdminMFARecoveryCodeRepoImpl 管理员 MFA 恢复码数据操作实现
+type AdminMFARecoveryCodeRepoImpl struct {
+}
+
+// NewAdminMFARecoveryCodeRepo 创建一个管理员 MFA 恢复码数据操作
+func NewAdminMFARecoveryCodeRepo() AdminMFARecoveryCodeRepo {
+	return &AdminMFARecoveryCodeRepoImpl{}
+}
+
+// Find 构建查询
+func (r *AdminMFARecoveryCodeRepoImpl) Find(ctx context.Context, builder *utils.GormBuilder) (*models.AdminMFARecoveryCode, error) {
+	var code models.AdminMFARecoveryCode
+	if err := builder.First(&code); err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+// FindList 构建查询列表
+func (r *AdminMFARecoveryCodeRepoImpl) FindList(ctx context.Context, builder *utils.GormBuilder) ([]*models.AdminMFARecoveryCode, error) {
+	var codes []*models.AdminMFARecoveryCode
+	if err := builder.Find(&codes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Create 批量创建恢复码
+func (r *AdminMFARecoveryCodeRepoImpl) Create(ctx context.Context, builder *utils.GormBuilder, codes []*models.AdminMFARecoveryCode) error {
+	return builder.Create(codes)
+}
+
+// Update 更新恢复码
+func (r *AdminMFARecoveryCodeRepoImpl) Update(ctx context.Context, builder *utils.GormBuilder, code *models.AdminMFARecoveryCode) error {
+	return builder.Update(code)
+}
+
+// BatchUpdate 按条件批量更新恢复码字段
+func (r *AdminMFARecoveryCodeRepoImpl) BatchUpdate(ctx context.Context, builder *utils.GormBuilder, values map[string]interface{}) (int64, error) {
+	return builder.BatchUpdate(&models.AdminMFARecoveryCode{}, values)
+}
+
+// Delete 删除恢复码（物理删除）
+func (r *AdminMFARecoveryCodeRepoImpl) Delete(ctx context.Context, builder *utils.GormBuilder) error {
+	return builder.Delete(false, &models.AdminMFARecoveryCode{})
+}
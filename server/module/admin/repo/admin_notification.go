@@ -0,0 +1,57 @@
+package repo
+
+import (
+	"context"
+
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/utils"
+)
+
+// AdminNotificationRepo 管理员站内通知数据操作
+type AdminNotificationRepo interface {
+	// FindListWithPage 构建分页查询列表
+	FindListWithPage(ctx context.Context, builder *utils.GormBuilder) (*utils.PageResp, error)
+	// Count 统计通知数量
+	Count(ctx context.Context, builder *utils.GormBuilder) (int64, error)
+	// CreateInBatches 批量创建通知
+	CreateInBatches(ctx context.Context, builder *utils.GormBuilder, notifications []*models.AdminNotification) error
+	// BatchUpdate 按条件批量更新通知字段
+	BatchUpdate(ctx context.Context, builder *utils.GormBuilder, values map[string]interface{}) (int64, error)
+}
+
+// AdminNotificationRepoImpl 管理员站内通知数据操作实现
+type AdminNotificationRepoImpl struct {
+}
+
+// NewAdminNotificationRepo 创建一个管理员站内通知数据操作
+func NewAdminNotificationRepo() AdminNotificationRepo {
+	return &AdminNotificationRepoImpl{}
+}
+
+// FindListWithPage 构建分页查询列表
+func (r *AdminNotificationRepoImpl) FindListWithPage(ctx context.Context, builder *utils.GormBuilder) (*utils.PageResp, error) {
+	var notifications []*models.AdminNotification
+	total, err := builder.TotalCount(&models.AdminNotification{})
+	if err != nil {
+		return nil, err
+	}
+	if err := builder.Find(&notifications); err != nil {
+		return nil, err
+	}
+	return utils.NewPageResp(total, builder.Page, notifications), nil
+}
+
+// Count 统计通知数量
+func (r *AdminNotificationRepoImpl) Count(ctx context.Context, builder *utils.GormBuilder) (int64, error) {
+	return builder.TotalCount(&models.AdminNotification{})
+}
+
+// CreateInBatches 批量创建通知
+func (r *AdminNotificationRepoImpl) CreateInBatches(ctx context.Context, builder *utils.GormBuilder, notifications []*models.AdminNotification) error {
+	return builder.CreateInBatches(notifications, 100)
+}
+
+// BatchUpdate 按条件批量更新通知字段
+func (r *AdminNotificationRepoImpl) BatchUpdate(ctx context.Context, builder *utils.GormBuilder, values map[string]interface{}) (int64, error) {
+	return builder.BatchUpdate(&models.AdminNotification{}, values)
+}
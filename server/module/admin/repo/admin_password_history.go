@@ -0,0 +1,46 @@
+package repo
+
+import (
+	"context"
+
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/utils"
+)
+
+// AdminPasswordHistoryRepo 管理员历史密码数据操作
+type AdminPasswordHistoryRepo interface {
+	// FindList 构建查询列表
+	FindList(ctx context.Context, builder *utils.GormBuilder) ([]*models.AdminPasswordHistory, error)
+	// Create 创建历史密码
+	Create(ctx context.Context, builder *utils.GormBuilder, history *models.AdminPasswordHistory) error
+	// Delete 删除历史密码（物理删除）
+	Delete(ctx context.Context, builder *utils.GormBuilder) error
+}
+
+// AdminPasswordHistoryRepoImpl 管理员历史密码数据操作实现
+type AdminPasswordHistoryRepoImpl struct {
+}
+
+// NewAdminPasswordHistoryRepo 创建一个管理员历史密码数据操作
+func NewAdminPasswordHistoryRepo() AdminPasswordHistoryRepo {
+	return &AdminPasswordHistoryRepoImpl{}
+}
+
+// FindList 构建查询列表
+func (r *AdminPasswordHistoryRepoImpl) FindList(ctx context.Context, builder *utils.GormBuilder) ([]*models.AdminPasswordHistory, error) {
+	var histories []*models.AdminPasswordHistory
+	if err := builder.Find(&histories); err != nil {
+		return nil, err
+	}
+	return histories, nil
+}
+
+// Create 创建历史密码
+func (r *AdminPasswordHistoryRepoImpl) Create(ctx context.Context, builder *utils.GormBuilder, history *models.AdminPasswordHistory) error {
+	return builder.Create(history)
+}
+
+// Delete 删除历史密码（物理删除）
+func (r *AdminPasswordHistoryRepoImpl) Delete(ctx context.Context, builder *utils.GormBuilder) error {
+	return builder.Delete(false, &models.AdminPasswordHistory{})
+}
@@ -0,0 +1,64 @@
+package repo
+
+import (
+	"context"
+
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/utils"
+)
+
+// AdminTokenRepo 管理员机器令牌数据操作
+type AdminTokenRepo interface {
+	// Find 构建查询
+	Find(ctx context.Context, builder *utils.GormBuilder) (*models.AdminToken, error)
+	// FindList 构建查询列表
+	FindList(ctx context.Context, builder *utils.GormBuilder) ([]*models.AdminToken, error)
+	// Create 创建令牌
+	Create(ctx context.Context, builder *utils.GormBuilder, token *models.AdminToken) error
+	// Update 更新令牌
+	Update(ctx context.Context, builder *utils.GormBuilder, token *models.AdminToken) error
+	// UpdateColumns 按条件更新令牌的指定字段
+	UpdateColumns(ctx context.Context, builder *utils.GormBuilder, values map[string]interface{}) (int64, error)
+}
+
+// AdminTokenRepoImpl 管理员机器令牌数据操作实现
+type AdminTokenRepoImpl struct {
+}
+
+// NewAdminTokenRepo 创建一个管理员机器令牌数据操作
+func NewAdminTokenRepo() AdminTokenRepo {
+	return &AdminTokenRepoImpl{}
+}
+
+// Find 查询单条数据
+func (r *AdminTokenRepoImpl) Find(ctx context.Context, builder *utils.GormBuilder) (*models.AdminToken, error) {
+	var token models.AdminToken
+	if err := builder.First(&token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// FindList 构建查询列表
+func (r *AdminTokenRepoImpl) FindList(ctx context.Context, builder *utils.GormBuilder) ([]*models.AdminToken, error) {
+	var tokens []*models.AdminToken
+	if err := builder.Find(&tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// Create 创建令牌
+func (r *AdminTokenRepoImpl) Create(ctx context.Context, builder *utils.GormBuilder, token *models.AdminToken) error {
+	return builder.Create(token)
+}
+
+// Update 更新令牌
+func (r *AdminTokenRepoImpl) Update(ctx context.Context, builder *utils.GormBuilder, token *models.AdminToken) error {
+	return builder.Update(token)
+}
+
+// UpdateColumns 按条件更新令牌的指定字段
+func (r *AdminTokenRepoImpl) UpdateColumns(ctx context.Context, builder *utils.GormBuilder, values map[string]interface{}) (int64, error) {
+	return builder.UpdateColumns(&models.AdminToken{}, values)
+}
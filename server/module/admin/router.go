@@ -1,22 +1,133 @@
 package admin
 
 import (
+	"github.com/so68/core/captcha"
+	"github.com/so68/core/config"
+	"github.com/so68/core/server"
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/module/admin/dto"
 	"github.com/so68/core/server/module/admin/handler"
 )
 
 // InitRouter 初始化路由
 func InitRouter(app *AdminApp) {
-	indexHandler := handler.NewIndexHandler(app.app.Logger, app.app.DB.DB(), app.app.Cache, app.jwt, app.app.Config.Static, app.app.Config.MaxHeader)
-	adminHandler := handler.NewAdminHandler(app.app.Logger, app.app.DB.DB(), app.app.Cache)
+	indexHandler := handler.NewIndexHandler(app.indexService)
+	adminHandler := handler.NewAdminHandler(app.adminService)
+	tokenHandler := handler.NewTokenHandler(app.tokenService)
+	apiKeyHandler := handler.NewAPIKeyHandler(app.apiKeyService)
+	sessionHandler := handler.NewSessionHandler(app.sessionService)
+	roleHandler := handler.NewRoleHandler(app.casbinService)
+	menuHandler := handler.NewMenuHandler(app.casbinService, app.menuService)
+	auditHandler := handler.NewAuditHandler(app.auditService)
+	loginLogHandler := handler.NewLoginLogHandler(app.loginLogService)
+	mfaHandler := handler.NewMFAHandler(app.mfaService)
+	notificationHandler := handler.NewNotificationHandler(app.notificationService)
+	passwordResetHandler := handler.NewPasswordResetHandler(app.passwordResetService)
+	routeHandler := handler.NewRouteHandler(app.app.Server)
+	configHandler := handler.NewConfigHandler(app.app.Config)
+	logLevelHandler := handler.NewLogLevelHandler(app.app)
 
 	// 通用路由
-	app.Handler("管理员登录", "POST", "/login", indexHandler.Login)
+	app.Handler("管理员登录", "POST", "/login", indexHandler.Login, server.Doc(dto.LoginParams{}, dto.LoginResult{}))
+	app.Handler("刷新令牌", "POST", "/refresh", indexHandler.Refresh, server.Doc(dto.RefreshParams{}, dto.RefreshResult{}))
+	app.Handler("修改过期密码", "POST", "/password/expired", indexHandler.ExpiredPassword, server.Doc(dto.ExpiredPasswordParams{}, nil))
+	app.Handler("获取验证码", "GET", "/captcha", indexHandler.Captcha, server.Doc(nil, captcha.Captcha{}))
+	app.Handler("找回密码", "POST", "/password/forgot", passwordResetHandler.Forgot, server.Doc(dto.ForgotPasswordParams{}, nil))
+	app.Handler("第三方登录方式", "GET", "/oauth/providers", indexHandler.OAuthProviders, server.Doc(nil, []string{}))
+	app.Handler("第三方登录授权", "GET", "/oauth/authorize", indexHandler.OAuthAuthorize, server.Doc(dto.OAuthAuthorizeParams{}, dto.OAuthAuthorizeResult{}))
+	app.Handler("第三方登录", "POST", "/oauth/login", indexHandler.OAuthLogin, server.Doc(dto.OAuthLoginParams{}, dto.LoginResult{}))
+	app.Handler("重置密码", "POST", "/password/reset", passwordResetHandler.Reset, server.Doc(dto.ResetPasswordParams{}, nil))
 
 	// 管理员路由
-	app.AuthHandler("管理员列表", "GET", "/admin/index", adminHandler.Index)
-	app.AuthHandler("创建管理员", "POST", "/admin/create", adminHandler.Create)
-	app.AuthHandler("更新管理员", "PUT", "/admin/update", adminHandler.Update)
-	app.AuthHandler("Token更新管理员", "PUT", "/admin/token/update", adminHandler.TokenUpdate)
-	app.AuthHandler("Token更新管理员密码", "PUT", "/admin/token/password/update", adminHandler.TokenPasswordUpdate)
-	app.AuthHandler("删除管理员", "DELETE", "/admin/delete", adminHandler.Delete)
+	app.ReadOnlyHandler("管理员列表", "/admin/index", adminHandler.Index, server.PageDoc(dto.AdminIndexParams{}, models.Admin{}))
+	app.SensitiveHandler("创建管理员", "POST", "/admin/create", adminHandler.Create, server.Doc(dto.AdminCreateParams{}, models.Admin{}))
+	app.SensitiveHandler("更新管理员", "PUT", "/admin/update", adminHandler.Update, server.Doc(dto.AdminUpdateParams{}, models.Admin{}))
+	app.AuthHandler("Token更新管理员", "PUT", "/admin/token/update", adminHandler.TokenUpdate, server.Doc(dto.AdminProfileParams{}, models.Admin{}))
+	app.SensitiveHandler("Token更新管理员密码", "PUT", "/admin/token/password/update", adminHandler.TokenPasswordUpdate, server.Doc(dto.AdminPasswordParams{}, nil))
+	app.SensitiveHandler("删除管理员", "DELETE", "/admin/delete", adminHandler.Delete, server.Doc(dto.AdminDeleteParams{}, nil))
+	app.AuthHandler("解锁管理员", "PUT", "/admin/unlock", adminHandler.Unlock, server.Doc(dto.AdminUnlockParams{}, nil))
+	app.ReadOnlyHandler("已删除管理员列表", "/admin/trashed", adminHandler.Trashed, server.PageDoc(dto.AdminIndexParams{}, models.Admin{}))
+	app.SensitiveHandler("恢复管理员", "PUT", "/admin/restore", adminHandler.Restore, server.Doc(dto.AdminRestoreParams{}, nil))
+
+	// 角色与权限路由
+	app.ReadOnlyHandler("角色列表", "/role/index", roleHandler.Index, server.Doc(nil, []dto.RoleInfo{}))
+	app.AuthHandler("创建角色", "POST", "/role/create", roleHandler.Create, server.Doc(dto.RoleCreateParams{}, nil))
+	app.AuthHandler("更新角色", "PUT", "/role/update", roleHandler.Update, server.Doc(dto.RoleUpdateParams{}, nil))
+	app.AuthHandler("删除角色", "DELETE", "/role/delete", roleHandler.Delete, server.Doc(dto.RoleDeleteParams{}, nil))
+	app.ReadOnlyHandler("角色权限", "/role/permissions", roleHandler.Permissions, server.Doc(dto.RolePermissionsParams{}, []dto.PermissionInfo{}))
+	app.SensitiveHandler("分配角色权限", "PUT", "/role/permissions/update", roleHandler.Assign, server.Doc(dto.RoleAssignParams{}, nil))
+	app.ReadOnlyHandler("权限列表", "/permission/index", roleHandler.PermissionIndex, server.Doc(nil, []dto.PermissionInfo{}))
+	app.AuthHandler("导出权限策略", "GET", "/policy/export", roleHandler.Export, server.Doc(nil, dto.PolicyData{}))
+	app.SensitiveHandler("导入权限策略", "POST", "/policy/import", roleHandler.Import, server.Doc(dto.PolicyData{}, nil))
+
+	// 机器令牌路由
+	app.AuthHandler("机器令牌列表", "GET", "/token/index", tokenHandler.Index, server.Doc(nil, []models.AdminToken{}))
+	app.SensitiveHandler("签发机器令牌", "POST", "/token/create", tokenHandler.Create, server.Doc(dto.TokenCreateParams{}, dto.TokenCreateResult{}))
+	app.AuthHandler("吊销机器令牌", "DELETE", "/token/revoke", tokenHandler.Revoke, server.Doc(dto.TokenRevokeParams{}, nil))
+	app.AuthHandler("API Key列表", "GET", "/apikey/index", apiKeyHandler.Index, server.Doc(nil, []models.AdminAPIKey{}))
+	app.SensitiveHandler("创建API Key", "POST", "/apikey/create", apiKeyHandler.Create, server.Doc(dto.APIKeyCreateParams{}, dto.APIKeyCreateResult{}))
+	app.SensitiveHandler("轮换API Key", "PUT", "/apikey/rotate", apiKeyHandler.Rotate, server.Doc(dto.APIKeyRotateParams{}, dto.APIKeyCreateResult{}))
+	app.AuthHandler("吊销API Key", "DELETE", "/apikey/revoke", apiKeyHandler.Revoke, server.Doc(dto.APIKeyRevokeParams{}, nil))
+
+	// MFA 双因素认证路由
+	app.AuthHandler("MFA状态", "GET", "/mfa/status", mfaHandler.Status, server.Doc(nil, dto.MFAStatusResult{}))
+	app.AuthHandler("生成MFA密钥", "POST", "/mfa/setup", mfaHandler.Setup, server.Doc(nil, dto.MFASetupResult{}))
+	app.AuthHandler("启用MFA", "POST", "/mfa/enable", mfaHandler.Enable, server.Doc(dto.MFACodeParams{}, dto.MFARecoveryCodesResult{}))
+	app.AuthHandler("关闭MFA", "POST", "/mfa/disable", mfaHandler.Disable, server.Doc(dto.MFADisableParams{}, nil))
+	app.SensitiveHandler("重新生成MFA恢复码", "POST", "/mfa/recovery/codes", mfaHandler.RecoveryCodes, server.Doc(nil, dto.MFARecoveryCodesResult{}))
+
+	// 文件上传路由
+	app.Upload("上传文件", "/upload", config.DefaultImageProfile)
+
+	// 站内通知路由
+	app.ReadOnlyHandler("通知列表", "/notification/index", notificationHandler.Index, server.PageDoc(dto.NotificationIndexParams{}, models.AdminNotification{}))
+	app.ReadOnlyHandler("未读通知数", "/notification/unread", notificationHandler.Unread, server.Doc(nil, dto.NotificationUnreadResult{}))
+	app.AuthHandler("标记通知已读", "PUT", "/notification/read", notificationHandler.Read, server.Doc(dto.NotificationReadParams{}, dto.NotificationReadResult{}))
+	app.AuthHandler("全部通知已读", "PUT", "/notification/read/all", notificationHandler.ReadAll, server.Doc(nil, dto.NotificationReadResult{}))
+	app.AuthHandler("通知推送", "GET", "/notification/stream", notificationHandler.Stream)
+
+	// 登录会话路由
+	app.AuthHandler("登录会话列表", "GET", "/session/index", sessionHandler.Index, server.Doc(nil, []dto.SessionInfo{}))
+	app.AuthHandler("吊销登录会话", "DELETE", "/session/revoke", sessionHandler.Revoke, server.Doc(dto.SessionRevokeParams{}, nil))
+	app.AuthHandler("吊销全部登录会话", "DELETE", "/session/revoke/all", sessionHandler.RevokeAll, server.Doc(dto.SessionRevokeAllParams{}, 0))
+
+	// 菜单路由
+	app.ReadOnlyHandler("我的菜单", "/menu/tree", menuHandler.Tree, server.Doc(nil, []models.AdminMenu{}))
+	app.ReadOnlyHandler("菜单列表", "/menu/index", menuHandler.Index, server.Doc(nil, []models.AdminMenu{}))
+	app.AuthHandler("创建菜单", "POST", "/menu/create", menuHandler.Create, server.Doc(dto.MenuCreateParams{}, models.AdminMenu{}))
+	app.AuthHandler("更新菜单", "PUT", "/menu/update", menuHandler.Update, server.Doc(dto.MenuUpdateParams{}, nil))
+	app.AuthHandler("删除菜单", "DELETE", "/menu/delete", menuHandler.Delete, server.Doc(dto.MenuDeleteParams{}, nil))
+
+	// 操作审计日志路由
+	app.ReadOnlyHandler("审计日志列表", "/audit/index", auditHandler.Index, server.PageDoc(dto.AuditLogIndexParams{}, models.AdminAuditLog{}))
+
+	// 登录日志路由
+	app.ReadOnlyHandler("登录日志列表", "/login/log/index", loginLogHandler.Index, server.PageDoc(dto.LoginLogIndexParams{}, models.AdminLoginLog{}))
+	app.ReadOnlyHandler("最近登录记录", "/login/log/recent", loginLogHandler.Recent, server.Doc(dto.LoginLogRecentParams{}, []models.AdminLoginLog{}))
+
+	// 路由列表（文档与权限审计）
+	app.AuthHandler("路由列表", "GET", "/route/index", routeHandler.Index, server.Doc(dto.RouteIndexParams{}, []server.RouteInfo{}))
+
+	// 运行配置（敏感字段已脱敏）
+	app.AuthHandler("运行配置", "GET", "/config", configHandler.Show, server.Doc(nil, map[string]any{}))
+
+	// 日志级别（运行时调整，重启后恢复为配置文件中的级别）
+	app.AuthHandler("日志级别", "GET", "/log/level", logLevelHandler.Show, server.Doc(nil, dto.LogLevelInfo{}))
+	app.AuthHandler("调整日志级别", "PUT", "/log/level/update", logLevelHandler.Update, server.Doc(dto.LogLevelUpdateParams{}, dto.LogLevelInfo{}))
+}
+
+// InitMenu 初始化后台菜单（Permission 对应 AuthHandler 注册的路由名称）
+func InitMenu(app *AdminApp) {
+	app.Menu(&dto.MenuItem{Key: "system", Title: "系统管理", Icon: "setting", Sort: 100, Children: []*dto.MenuItem{
+		{Key: "system.admin", Title: "管理员", Path: "/system/admin", Sort: 1, Permission: "管理员列表"},
+		{Key: "system.role", Title: "角色权限", Path: "/system/role", Sort: 2, Permission: "角色列表"},
+		{Key: "system.token", Title: "机器令牌", Path: "/system/token", Sort: 3, Permission: "机器令牌列表"},
+		{Key: "system.session", Title: "登录会话", Path: "/system/session", Sort: 4, Permission: "登录会话列表"},
+		{Key: "system.menu", Title: "菜单管理", Path: "/system/menu", Sort: 5, Permission: "菜单列表"},
+		{Key: "system.audit", Title: "操作日志", Path: "/system/audit", Sort: 6, Permission: "审计日志列表"},
+		{Key: "system.login_log", Title: "登录日志", Path: "/system/login-log", Sort: 7, Permission: "登录日志列表"},
+		{Key: "system.api_key", Title: "API Key", Path: "/system/api-key", Sort: 8, Permission: "API Key列表"},
+		{Key: "system.route", Title: "路由列表", Path: "/system/route", Sort: 9, Permission: "路由列表"},
+		{Key: "system.config", Title: "运行配置", Path: "/system/config", Sort: 10, Permission: "运行配置"},
+	}})
 }
@@ -1,31 +1,602 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"slices"
+	"time"
 
 	"github.com/so68/core/cache"
+	coreerrors "github.com/so68/core/errors"
+	"github.com/so68/core/event"
+	"github.com/so68/core/logging"
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/module/admin/events"
 	"github.com/so68/core/server/module/admin/repo"
+	"github.com/so68/core/server/utils"
+	"github.com/so68/core/tenant"
 	"gorm.io/gorm"
 )
 
 // AdminService 管理员服务
+// - 查询均按上下文中的数据权限范围过滤，代理/商户管理员只能管理自身及下级
+// - 层级约束：超级管理员没有上级，其他管理员的层级不能高于上级（数值不小于上级）
 type AdminService interface {
+	// List 分页查询管理员
+	// @param ctx 上下文
+	// @param params 查询参数
+	// @return *utils.PageResp 分页结果
+	// @return error 错误
+	List(ctx context.Context, params *dto.AdminIndexParams) (*utils.PageResp, error)
+	// Create 创建管理员
+	// @param ctx 上下文
+	// @param operatorID 操作管理员ID
+	// @param params 创建参数
+	// @return *models.Admin 管理员
+	// @return error 错误
+	Create(ctx context.Context, operatorID uint, params *dto.AdminCreateParams) (*models.Admin, error)
+	// Update 更新管理员（不能修改自身的层级、角色、状态与上级）
+	// @param ctx 上下文
+	// @param operatorID 操作管理员ID
+	// @param params 更新参数
+	// @return *models.Admin 管理员
+	// @return error 错误
+	Update(ctx context.Context, operatorID uint, params *dto.AdminUpdateParams) (*models.Admin, error)
+	// UpdateProfile 更新当前管理员资料
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @param params 资料参数
+	// @return *models.Admin 管理员
+	// @return error 错误
+	UpdateProfile(ctx context.Context, adminID uint, params *dto.AdminProfileParams) (*models.Admin, error)
+	// UpdatePassword 修改当前管理员密码（吊销当前会话之外的全部登录会话）
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @param sessionID 当前会话ID
+	// @param params 密码参数
+	// @return error 错误
+	UpdatePassword(ctx context.Context, adminID uint, sessionID string, params *dto.AdminPasswordParams) error
+	// Delete 删除管理员（不能删除自身与存在下级的管理员）
+	// @param ctx 上下文
+	// @param operatorID 操作管理员ID
+	// @param id 管理员ID
+	// @return error 错误
+	Delete(ctx context.Context, operatorID uint, id uint) error
+	// Unlock 解锁管理员（清除锁定状态、登录失败次数与连续锁定次数）
+	// @param ctx 上下文
+	// @param id 管理员ID
+	// @return error 错误
+	Unlock(ctx context.Context, id uint) error
+	// Trashed 分页查询已删除的管理员
+	// @param ctx 上下文
+	// @param params 查询参数
+	// @return *utils.PageResp 分页结果
+	// @return error 错误
+	Trashed(ctx context.Context, params *dto.AdminIndexParams) (*utils.PageResp, error)
+	// Restore 恢复已删除的管理员（上级管理员已删除时需先恢复上级）
+	// @param ctx 上下文
+	// @param id 管理员ID
+	// @return error 错误
+	Restore(ctx context.Context, id uint) error
 }
 
 // AdminServiceImpl 管理员服务实现
 type AdminServiceImpl struct {
-	db        *gorm.DB
-	cache     cache.Cache
-	logger    *slog.Logger
-	adminRepo repo.AdminRepo
+	db              *gorm.DB
+	cache           cache.Cache
+	logger          *slog.Logger
+	adminRepo       repo.AdminRepo
+	casbinService   CasbinService
+	sessionService  SessionService
+	passwordService PasswordService
+	events          *event.Bus
 }
 
-// NewAdminService 创建一个管理员服务
-func NewAdminService(db *gorm.DB, cache cache.Cache, logger *slog.Logger) AdminService {
+// NewAdminService 创建一个管理员服务（events 为空时不发布管理员事件）
+func NewAdminService(db *gorm.DB, cache cache.Cache, logger *slog.Logger, casbinService CasbinService, sessionService SessionService, passwordService PasswordService, events *event.Bus) AdminService {
 	return &AdminServiceImpl{
-		db:        db,
-		cache:     cache,
-		logger:    logger,
-		adminRepo: repo.NewAdminRepo(),
+		db:              db,
+		cache:           cache,
+		logger:          logger,
+		adminRepo:       repo.NewAdminRepo(),
+		casbinService:   casbinService,
+		sessionService:  sessionService,
+		passwordService: passwordService,
+		events:          events,
+	}
+}
+
+// List 分页查询管理员
+func (s *AdminServiceImpl) List(ctx context.Context, params *dto.AdminIndexParams) (*utils.PageResp, error) {
+	if params.Sort == "" {
+		params.Sort, params.Order = "id", "DESC"
+	}
+	builder := utils.NewGormBuilderWithPage(ctx, s.db.Model(&models.Admin{}), &params.Page,
+		"id", "username", "nickname", "status", "type", "amount", "last_login_at", "created_at", "updated_at")
+	s.applyFilters(builder, params)
+
+	result, err := s.adminRepo.FindListWithPage(ctx, builder)
+	if err != nil {
+		return nil, fmt.Errorf("查询管理员失败: %w", err)
+	}
+	return result, nil
+}
+
+// Trashed 分页查询已删除的管理员
+func (s *AdminServiceImpl) Trashed(ctx context.Context, params *dto.AdminIndexParams) (*utils.PageResp, error) {
+	if params.Sort == "" {
+		params.Sort, params.Order = "deleted_at", "DESC"
+	}
+	builder := utils.NewGormBuilderWithPage(ctx, s.db.Model(&models.Admin{}), &params.Page,
+		"deleted_at", "id", "username", "nickname", "status", "type", "created_at", "updated_at").OnlyTrashed()
+	s.applyFilters(builder, params)
+
+	result, err := s.adminRepo.FindListWithPage(ctx, builder)
+	if err != nil {
+		return nil, fmt.Errorf("查询已删除管理员失败: %w", err)
+	}
+	return result, nil
+}
+
+// applyFilters 添加列表查询条件
+func (s *AdminServiceImpl) applyFilters(builder *utils.GormBuilder, params *dto.AdminIndexParams) {
+	if params.Username != "" {
+		builder.WhereLike("username", "%"+params.Username+"%")
+	}
+	if params.Nickname != "" {
+		builder.WhereLike("nickname", "%"+params.Nickname+"%")
+	}
+	if params.Email != "" {
+		builder.WhereEqual("email", params.Email)
+	}
+	if params.Telephone != "" {
+		builder.WhereEqual("telephone", params.Telephone)
+	}
+	if params.Status > 0 {
+		builder.WhereEqual("status", params.Status)
+	}
+	if params.Type > 0 {
+		builder.WhereEqual("type", params.Type)
+	}
+	if params.Role != "" {
+		builder.WhereEqual("role", params.Role)
+	}
+	if params.ParentID > 0 {
+		builder.WhereEqual("parent_id", params.ParentID)
+	}
+}
+
+// Create 创建管理员
+func (s *AdminServiceImpl) Create(ctx context.Context, operatorID uint, params *dto.AdminCreateParams) (*models.Admin, error) {
+	operator, err := s.find(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 非超级管理员创建的管理员默认挂在自身之下
+	parentID := params.ParentID
+	if parentID == 0 && operator.Type != models.AdminTypeSuper {
+		parentID = operator.ID
+	}
+	if err := s.checkHierarchy(ctx, operator, 0, parentID, params.Type); err != nil {
+		return nil, err
+	}
+	if err := s.checkRole(operator, params.Role, params.Type); err != nil {
+		return nil, err
+	}
+	if err := s.checkUnique(ctx, 0, params.Username, params.Email, params.Telephone); err != nil {
+		return nil, err
+	}
+	if err := s.passwordService.Validate(ctx, nil, params.Password); err != nil {
+		return nil, err
+	}
+
+	status := params.Status
+	if status == 0 {
+		status = models.AdminStatusEnabled
+	}
+	admin := &models.Admin{
+		Username:          params.Username,
+		PasswordHash:      params.Password, // 创建钩子中哈希
+		Nickname:          params.Nickname,
+		Email:             params.Email,
+		Telephone:         params.Telephone,
+		Avatar:            params.Avatar,
+		Status:            status,
+		Type:              params.Type,
+		Role:              params.Role,
+		ParentID:          parentID,
+		ChatURL:           params.ChatURL,
+		PasswordChangedAt: time.Now(),
+	}
+	if err := s.adminRepo.Create(ctx, utils.NewGormBuilder(ctx, s.db), admin); err != nil {
+		return nil, fmt.Errorf("创建管理员失败: %w", err)
+	}
+	s.recordPassword(ctx, admin)
+	publishEvent(ctx, s.events, events.TopicAdminCreated, &events.AdminCreated{Admin: admin})
+	return admin, nil
+}
+
+// Update 更新管理员
+func (s *AdminServiceImpl) Update(ctx context.Context, operatorID uint, params *dto.AdminUpdateParams) (*models.Admin, error) {
+	operator, err := s.find(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	admin, err := s.find(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 防止管理员提升或锁定自身
+	if admin.ID == operator.ID && (params.Type != admin.Type || params.Role != admin.Role || params.Status != admin.Status || params.ParentID != admin.ParentID) {
+		return nil, errors.New("不能修改自身的层级、角色、状态或上级")
+	}
+	if err := s.checkHierarchy(ctx, operator, admin.ID, params.ParentID, params.Type); err != nil {
+		return nil, err
+	}
+	if params.Type != admin.Type {
+		if err := s.checkChildrenType(ctx, admin.ID, params.Type); err != nil {
+			return nil, err
+		}
+	}
+	if params.Role != admin.Role || params.Type != admin.Type {
+		if err := s.checkRole(operator, params.Role, params.Type); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkUnique(ctx, admin.ID, admin.Username, params.Email, params.Telephone); err != nil {
+		return nil, err
+	}
+
+	revoke := params.Status == models.AdminStatusDisabled && admin.Status != models.AdminStatusDisabled
+	if params.Password != "" {
+		if err := s.passwordService.Validate(ctx, admin, params.Password); err != nil {
+			return nil, err
+		}
+		if err := admin.GeneratePasswordHash(params.Password); err != nil {
+			return nil, err
+		}
+		admin.PasswordChangedAt = time.Now()
+		revoke = true
+	}
+	clear := s.clearedColumns(admin, params.Email, params.Telephone)
+	if admin.ParentID != 0 && params.ParentID == 0 {
+		clear = append(clear, "parent_id")
+	}
+	admin.Nickname = params.Nickname
+	admin.Email = params.Email
+	admin.Telephone = params.Telephone
+	admin.Avatar = params.Avatar
+	admin.Status = params.Status
+	admin.Type = params.Type
+	admin.Role = params.Role
+	admin.ParentID = params.ParentID
+	admin.ChatURL = params.ChatURL
+	if err := s.save(ctx, admin, clear); err != nil {
+		return nil, err
+	}
+
+	if params.Password != "" {
+		s.recordPassword(ctx, admin)
+	}
+	// 禁用或重置密码后吊销其全部登录会话
+	if revoke {
+		s.revokeSessions(ctx, admin.ID, "")
+	}
+	return admin, nil
+}
+
+// UpdateProfile 更新当前管理员资料
+func (s *AdminServiceImpl) UpdateProfile(ctx context.Context, adminID uint, params *dto.AdminProfileParams) (*models.Admin, error) {
+	admin, err := s.find(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkUnique(ctx, admin.ID, admin.Username, params.Email, params.Telephone); err != nil {
+		return nil, err
+	}
+
+	clear := s.clearedColumns(admin, params.Email, params.Telephone)
+	admin.Nickname = params.Nickname
+	admin.Email = params.Email
+	admin.Telephone = params.Telephone
+	admin.Avatar = params.Avatar
+	admin.ChatURL = params.ChatURL
+	admin.Data.WhiteList = params.WhiteList
+	admin.Data.DisableLoginNotify = params.DisableLoginNotify
+	admin.Data.DisableLockoutNotify = params.DisableLockoutNotify
+	if err := s.save(ctx, admin, clear); err != nil {
+		return nil, err
+	}
+	return admin, nil
+}
+
+// UpdatePassword 修改当前管理员密码
+func (s *AdminServiceImpl) UpdatePassword(ctx context.Context, adminID uint, sessionID string, params *dto.AdminPasswordParams) error {
+	if params.Password != params.ConfirmPassword {
+		return errors.New("两次输入的密码不一致")
+	}
+	admin, err := s.find(ctx, adminID)
+	if err != nil {
+		return err
+	}
+	if !admin.CompareHashAndPassword(params.OldPassword) {
+		return errors.New("原密码错误")
+	}
+	if params.Password == params.OldPassword {
+		return errors.New("新密码不能与原密码相同")
+	}
+	if err := s.passwordService.Validate(ctx, admin, params.Password); err != nil {
+		return err
+	}
+
+	if err := admin.GeneratePasswordHash(params.Password); err != nil {
+		return err
+	}
+	admin.PasswordChangedAt = time.Now()
+	if err := s.save(ctx, admin, nil); err != nil {
+		return err
+	}
+	s.recordPassword(ctx, admin)
+	s.revokeSessions(ctx, admin.ID, sessionID)
+	return nil
+}
+
+// Delete 删除管理员
+func (s *AdminServiceImpl) Delete(ctx context.Context, operatorID uint, id uint) error {
+	if id == operatorID {
+		return errors.New("不能删除自身")
+	}
+	admin, err := s.find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	children, err := utils.NewGormBuilderFind(ctx, s.db, "parent_id", admin.ID).WithoutDataScope().TotalCount(&models.Admin{})
+	if err != nil {
+		return fmt.Errorf("查询下级管理员失败: %w", err)
+	}
+	if children > 0 {
+		return errors.New("存在下级管理员, 无法删除")
+	}
+
+	if err := s.adminRepo.Delete(ctx, utils.NewGormBuilder(ctx, s.db), true, admin.ID); err != nil {
+		return fmt.Errorf("删除管理员失败: %w", err)
+	}
+	s.revokeSessions(ctx, admin.ID, "")
+	return nil
+}
+
+// Unlock 解锁管理员
+func (s *AdminServiceImpl) Unlock(ctx context.Context, id uint) error {
+	admin, err := s.find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if admin.Status == models.AdminStatusLocked {
+		admin.Status = models.AdminStatusEnabled
+	}
+	admin.LockedUntil = time.Time{}
+	admin.FailedLoginAttempts = 0
+	admin.LockoutCount = 0
+	return s.save(ctx, admin, nil)
+}
+
+// Restore 恢复已删除的管理员
+func (s *AdminServiceImpl) Restore(ctx context.Context, id uint) error {
+	admin, err := s.adminRepo.Find(ctx, utils.NewGormBuilderFind(ctx, s.db, "id", id).OnlyTrashed())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return coreerrors.NotFound("管理员不存在")
+	}
+	if err != nil {
+		return fmt.Errorf("查询管理员失败: %w", err)
+	}
+
+	if admin.ParentID > 0 {
+		exists, err := s.exists(ctx, admin.ParentID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return errors.New("上级管理员已删除, 请先恢复上级管理员")
+		}
+	}
+
+	if err := s.adminRepo.Restore(ctx, utils.NewGormBuilder(ctx, s.db), admin.ID); err != nil {
+		return fmt.Errorf("恢复管理员失败: %w", err)
+	}
+	return nil
+}
+
+// exists 管理员是否存在（不含已删除，不限数据权限范围）
+func (s *AdminServiceImpl) exists(ctx context.Context, id uint) (bool, error) {
+	count, err := utils.NewGormBuilderFind(ctx, s.db, "id", id).WithoutDataScope().TotalCount(&models.Admin{})
+	if err != nil {
+		return false, fmt.Errorf("查询上级管理员失败: %w", err)
+	}
+	return count > 0, nil
+}
+
+// find 按数据权限范围查询管理员
+func (s *AdminServiceImpl) find(ctx context.Context, id uint) (*models.Admin, error) {
+	admin, err := s.adminRepo.Find(ctx, utils.NewGormBuilderFind(ctx, s.db, "id", id))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, coreerrors.NotFound("管理员不存在")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("查询管理员失败: %w", err)
+	}
+	return admin, nil
+}
+
+// checkHierarchy 校验上级管理员与层级约束
+// - 超级管理员只能由超级管理员创建，且没有上级
+// - 上级必须在操作人的数据权限范围内，层级不能高于上级，且不能是自身或自身的下级
+func (s *AdminServiceImpl) checkHierarchy(ctx context.Context, operator *models.Admin, id uint, parentID uint, adminType int8) error {
+	if adminType == models.AdminTypeSuper {
+		if operator.Type != models.AdminTypeSuper {
+			return errors.New("无权设置超级管理员")
+		}
+		if parentID != 0 {
+			return errors.New("超级管理员不能设置上级")
+		}
+		return nil
+	}
+	if parentID == 0 {
+		if operator.Type != models.AdminTypeSuper {
+			return errors.New("上级管理员不能为空")
+		}
+		return nil
+	}
+
+	if id != 0 && parentID == id {
+		return errors.New("上级管理员不能是自身")
+	}
+	parent, err := s.find(ctx, parentID)
+	if err != nil {
+		return fmt.Errorf("上级管理员无效: %w", err)
+	}
+	if adminType < parent.Type {
+		return errors.New("管理员层级不能高于上级")
+	}
+	if id != 0 {
+		subordinates, err := subordinateIDs(ctx, s.db, id)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(subordinates, parentID) {
+			return errors.New("上级管理员不能是自身的下级")
+		}
+	}
+	return nil
+}
+
+// checkChildrenType 校验修改层级后不高于直属下级
+func (s *AdminServiceImpl) checkChildrenType(ctx context.Context, id uint, adminType int8) error {
+	count, err := utils.NewGormBuilderFind(ctx, s.db, "parent_id", id).WithoutDataScope().WhereLessThan("type", adminType).TotalCount(&models.Admin{})
+	if err != nil {
+		return fmt.Errorf("查询下级管理员失败: %w", err)
+	}
+	if count > 0 {
+		return errors.New("管理员层级不能低于其下级")
+	}
+	return nil
+}
+
+// checkRole 校验角色存在，超级管理员角色仅能分配给超级管理员
+// - 非超级管理员只能分配权限不超过自身角色的角色，防止通过下级提升权限
+func (s *AdminServiceImpl) checkRole(operator *models.Admin, role string, adminType int8) error {
+	exists, err := hasRole(role)
+	if err != nil {
+		return fmt.Errorf("查询角色失败: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("角色 %s 不存在", role)
+	}
+	if role == RoleSuperAdmin && adminType != models.AdminTypeSuper {
+		return errors.New("超级管理员角色仅能分配给超级管理员")
+	}
+	if operator.Type == models.AdminTypeSuper {
+		return nil
+	}
+	covered, err := coversRole(operator.Role, role)
+	if err != nil {
+		return fmt.Errorf("查询角色权限失败: %w", err)
+	}
+	if !covered {
+		return fmt.Errorf("无权分配角色 %s: 权限超出自身角色", role)
+	}
+	return nil
+}
+
+// checkUnique 校验用户名、邮箱与手机号唯一（包含已删除的管理员，忽略数据权限范围；唯一索引不区分租户，因此同样忽略租户）
+func (s *AdminServiceImpl) checkUnique(ctx context.Context, id uint, username string, email string, telephone string) error {
+	fields := []struct {
+		column  string
+		value   string
+		message string
+	}{
+		{"username", username, "用户名已存在"},
+		{"email", email, "邮箱已存在"},
+		{"telephone", telephone, "手机号已存在"},
+	}
+	for _, field := range fields {
+		if field.value == "" {
+			continue
+		}
+		// 用户名在租户内唯一，邮箱与手机号全局唯一
+		var builder *utils.GormBuilder
+		if field.column == "username" {
+			builder = usernameBuilder(ctx, s.db, field.value).WithoutDataScope().WithTrashed()
+		} else {
+			builder = utils.NewGormBuilderFind(ctx, s.db, field.column, field.value).WithoutDataScope().WithoutTenant().WithTrashed()
+		}
+		if id != 0 {
+			builder = builder.WhereNotEqual("id", id)
+		}
+		count, err := builder.TotalCount(&models.Admin{})
+		if err != nil {
+			return fmt.Errorf("查询管理员失败: %w", err)
+		}
+		if count > 0 {
+			return coreerrors.Conflict(field.message)
+		}
+	}
+	return nil
+}
+
+// usernameBuilder 按用户名查询管理员（用户名在租户内唯一，请求未指定租户时仅匹配不属于租户的管理员）
+func usernameBuilder(ctx context.Context, db *gorm.DB, username string) *utils.GormBuilder {
+	builder := utils.NewGormBuilderFind(ctx, db, "username", username)
+	if _, ok := tenant.FromContext(ctx); !ok {
+		builder.WhereEqual("tenant_id", "")
+	}
+	return builder
+}
+
+// clearedColumns 获取被清空的可选唯一字段（保存时写入 NULL）
+func (s *AdminServiceImpl) clearedColumns(admin *models.Admin, email string, telephone string) []string {
+	columns := make([]string, 0)
+	if admin.Email != "" && email == "" {
+		columns = append(columns, "email")
+	}
+	if admin.Telephone != "" && telephone == "" {
+		columns = append(columns, "telephone")
+	}
+	return columns
+}
+
+// save 保存管理员，并将被清空的可选字段置为 NULL
+func (s *AdminServiceImpl) save(ctx context.Context, admin *models.Admin, clear []string) error {
+	if err := s.adminRepo.Update(ctx, utils.NewGormBuilder(ctx, s.db), admin); err != nil {
+		return fmt.Errorf("更新管理员失败: %w", err)
+	}
+	for _, column := range clear {
+		if err := s.db.WithContext(ctx).Model(&models.Admin{}).Where("id = ?", admin.ID).UpdateColumn(column, nil).Error; err != nil {
+			return fmt.Errorf("更新管理员失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// recordPassword 记录历史密码（失败仅记录日志）
+func (s *AdminServiceImpl) recordPassword(ctx context.Context, admin *models.Admin) {
+	if err := s.passwordService.Record(ctx, admin); err != nil {
+		logging.FromContext(ctx).Warn("记录历史密码失败", "admin_id", admin.ID, "error", err)
+	}
+}
+
+// revokeSessions 吊销管理员的登录会话（未启用缓存时跳过，失败仅记录日志）
+func (s *AdminServiceImpl) revokeSessions(ctx context.Context, adminID uint, exceptSessionID string) {
+	if s.cache == nil {
+		return
+	}
+	if _, err := s.sessionService.RevokeAll(ctx, adminID, exceptSessionID); err != nil {
+		logging.FromContext(ctx).Warn("吊销登录会话失败", "admin_id", adminID, "error", err)
 	}
 }
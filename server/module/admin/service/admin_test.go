@@ -0,0 +1,565 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/so68/core/config"
+	coredb "github.com/so68/core/database"
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/utils"
+	"github.com/so68/core/tenant"
+	"gorm.io/gorm"
+)
+
+/*
+管理员服务测试
+
+本文件用于测试管理员的增删改查逻辑，
+包括唯一性校验、层级约束、角色校验、密码修改与数据权限范围过滤。
+
+运行命令：
+go test -v -run "^TestAdminService.*$"
+
+测试内容：
+1. 创建管理员 (Create)
+2. 更新管理员 (Update)
+3. 修改密码 (UpdatePassword)
+4. 删除管理员 (Delete)
+5. 解锁管理员 (Unlock)
+6. 分页查询与数据权限范围 (List)
+7. 已删除管理员列表与恢复 (Trashed, Restore)
+8. 用户名在租户内唯一，邮箱全局唯一 (checkUnique)
+
+说明：Casbin 执行器为全局单例，绑定首次创建时的数据库，因此各测试共用同一个 SQLite 文件库
+（Casbin 适配器保存策略时需要多个连接，不能使用单连接的内存库），并在每个测试开始时清空管理员表。
+*/
+
+// adminTestDB 测试共用数据库
+var adminTestDB *gorm.DB
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "admin-service-test")
+	if err != nil {
+		panic(err)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	database, err := coredb.NewSQLiteDatabase(&config.DatabaseConfig{Driver: "sqlite", Database: filepath.Join(dir, "admin.db"), LogLevel: "silent"}, logger)
+	if err != nil {
+		panic(err)
+	}
+	if err := database.DB().AutoMigrate(&models.Admin{}, &models.AdminPasswordHistory{}, &models.AdminMFARecoveryCode{}, &models.AdminNotification{}, &models.AdminAuditLog{}, &models.AdminIdentity{}, &models.AdminLoginLog{}, &models.AdminAPIKey{}); err != nil {
+		panic(err)
+	}
+	adminTestDB = database.DB()
+
+	code := m.Run()
+	_ = database.Close(context.Background())
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// newAdminTestService 创建管理员测试服务并清空管理员表
+func newAdminTestService(t *testing.T) (*AdminServiceImpl, *gorm.DB) {
+	t.Helper()
+	if err := adminTestDB.Session(&gorm.Session{AllowGlobalUpdate: true}).Unscoped().Delete(&models.Admin{}).Error; err != nil {
+		t.Fatalf("clear admins failed: %v", err)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewAdminService(adminTestDB, nil, logger, NewCasbinService(adminTestDB, nil, logger), NewSessionService(nil, logger), NewPasswordService(adminTestDB, nil, logger), nil).(*AdminServiceImpl), adminTestDB
+}
+
+// createTestAdmin 直接写入一个管理员
+func createTestAdmin(t *testing.T, db *gorm.DB, username string, adminType int8, role string, parentID uint) *models.Admin {
+	t.Helper()
+	admin := &models.Admin{
+		Username:     username,
+		PasswordHash: "password123",
+		Nickname:     username,
+		Status:       models.AdminStatusEnabled,
+		Type:         adminType,
+		Role:         role,
+		ParentID:     parentID,
+	}
+	if err := db.Create(admin).Error; err != nil {
+		t.Fatalf("create admin %s failed: %v", username, err)
+	}
+	return admin
+}
+
+// assertError 校验错误信息
+func assertError(t *testing.T, err error, wantErr string) {
+	t.Helper()
+	if wantErr == "" {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	if err == nil || !strings.Contains(err.Error(), wantErr) {
+		t.Fatalf("expected error containing %q, got %v", wantErr, err)
+	}
+}
+
+func TestAdminService_Create(t *testing.T) {
+	tests := []struct {
+		name       string
+		operator   string
+		params     dto.AdminCreateParams
+		wantErr    string
+		wantParent string
+	}{
+		{
+			name:       "超级管理员创建商户管理员",
+			operator:   "root",
+			params:     dto.AdminCreateParams{Username: "merchant2", Password: "password123", Nickname: "m2", Type: models.AdminTypeMerchant, Role: RoleMerchant, Email: "m2@example.com"},
+			wantParent: "",
+		},
+		{
+			name:       "商户管理员创建代理默认挂在自身之下",
+			operator:   "merchant",
+			params:     dto.AdminCreateParams{Username: "agent2", Password: "password123", Nickname: "a2", Type: models.AdminTypeAgent, Role: RoleAgent},
+			wantParent: "merchant",
+		},
+		{
+			name:     "商户管理员不能创建超级管理员",
+			operator: "merchant",
+			params:   dto.AdminCreateParams{Username: "super2", Password: "password123", Nickname: "s2", Type: models.AdminTypeSuper, Role: RoleSuperAdmin},
+			wantErr:  "无权设置超级管理员",
+		},
+		{
+			name:     "超级管理员创建无上级的商户管理员",
+			operator: "root",
+			params:   dto.AdminCreateParams{Username: "merchant3", Password: "password123", Nickname: "m3", Type: models.AdminTypeMerchant, Role: RoleMerchant, ParentID: 0},
+			wantErr:  "",
+		},
+		{
+			name:     "代理之下不能创建商户",
+			operator: "agent",
+			params:   dto.AdminCreateParams{Username: "merchant4", Password: "password123", Nickname: "m4", Type: models.AdminTypeMerchant, Role: RoleMerchant},
+			wantErr:  "管理员层级不能高于上级",
+		},
+		{
+			name:     "用户名重复",
+			operator: "root",
+			params:   dto.AdminCreateParams{Username: "merchant", Password: "password123", Nickname: "dup", Type: models.AdminTypeMerchant, Role: RoleMerchant},
+			wantErr:  "用户名已存在",
+		},
+		{
+			name:     "邮箱重复",
+			operator: "root",
+			params:   dto.AdminCreateParams{Username: "merchant5", Password: "password123", Nickname: "dup", Type: models.AdminTypeMerchant, Role: RoleMerchant, Email: "merchant@example.com"},
+			wantErr:  "邮箱已存在",
+		},
+		{
+			name:     "角色不存在",
+			operator: "root",
+			params:   dto.AdminCreateParams{Username: "merchant6", Password: "password123", Nickname: "m6", Type: models.AdminTypeMerchant, Role: "不存在的角色"},
+			wantErr:  "不存在",
+		},
+		{
+			name:     "超级管理员角色仅能分配给超级管理员",
+			operator: "root",
+			params:   dto.AdminCreateParams{Username: "merchant7", Password: "password123", Nickname: "m7", Type: models.AdminTypeMerchant, Role: RoleSuperAdmin},
+			wantErr:  "超级管理员角色仅能分配给超级管理员",
+		},
+		{
+			name:     "代理不能分配权限超出自身角色的角色",
+			operator: "agent",
+			params:   dto.AdminCreateParams{Username: "agent3", Password: "password123", Nickname: "a3", Type: models.AdminTypeAgent, Role: RoleMerchant},
+			wantErr:  "权限超出自身角色",
+		},
+	}
+
+	// 商户管理员角色额外拥有一条代理管理员没有的权限
+	newAdminTestService(t)
+	if _, err := enforcer.AddPolicy(RoleMerchant, "/admin/test/escalation", "POST"); err != nil {
+		t.Fatalf("add policy failed: %v", err)
+	}
+	t.Cleanup(func() { _, _ = enforcer.RemovePolicy(RoleMerchant, "/admin/test/escalation", "POST") })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, db := newAdminTestService(t)
+			admins := map[string]*models.Admin{}
+			admins["root"] = createTestAdmin(t, db, "root", models.AdminTypeSuper, RoleSuperAdmin, 0)
+			admins["merchant"] = createTestAdmin(t, db, "merchant", models.AdminTypeMerchant, RoleMerchant, admins["root"].ID)
+			if err := db.Model(admins["merchant"]).Update("email", "merchant@example.com").Error; err != nil {
+				t.Fatalf("update email failed: %v", err)
+			}
+			admins["agent"] = createTestAdmin(t, db, "agent", models.AdminTypeAgent, RoleAgent, admins["merchant"].ID)
+
+			params := tt.params
+			admin, err := s.Create(context.Background(), admins[tt.operator].ID, &params)
+			assertError(t, err, tt.wantErr)
+			if tt.wantErr != "" {
+				return
+			}
+
+			var wantParentID uint
+			if tt.wantParent != "" {
+				wantParentID = admins[tt.wantParent].ID
+			}
+			if admin.ParentID != wantParentID {
+				t.Errorf("ParentID = %d, want %d", admin.ParentID, wantParentID)
+			}
+			if admin.Status != models.AdminStatusEnabled {
+				t.Errorf("Status = %d, want enabled", admin.Status)
+			}
+			if !admin.CompareHashAndPassword(params.Password) {
+				t.Error("password should be hashed and verifiable")
+			}
+			if admin.PasswordChangedAt.IsZero() {
+				t.Error("PasswordChangedAt should be set")
+			}
+		})
+	}
+}
+
+func TestAdminService_Update(t *testing.T) {
+	tests := []struct {
+		name     string
+		operator string
+		target   string
+		modify   func(params *dto.AdminUpdateParams, admins map[string]*models.Admin)
+		wantErr  string
+	}{
+		{
+			name:     "修改资料并禁用",
+			operator: "root",
+			target:   "agent",
+			modify: func(params *dto.AdminUpdateParams, _ map[string]*models.Admin) {
+				params.Nickname = "new-agent"
+				params.Status = models.AdminStatusDisabled
+			},
+		},
+		{
+			name:     "不能修改自身角色",
+			operator: "merchant",
+			target:   "merchant",
+			modify: func(params *dto.AdminUpdateParams, _ map[string]*models.Admin) {
+				params.Role = RoleAgent
+			},
+			wantErr: "不能修改自身",
+		},
+		{
+			name:     "上级不能是自身的下级",
+			operator: "root",
+			target:   "merchant",
+			modify: func(params *dto.AdminUpdateParams, admins map[string]*models.Admin) {
+				params.ParentID = admins["agent"].ID
+				params.Type = models.AdminTypeAgent
+			},
+			wantErr: "上级管理员不能是自身的下级",
+		},
+		{
+			name:     "上级不能是自身",
+			operator: "root",
+			target:   "agent",
+			modify: func(params *dto.AdminUpdateParams, admins map[string]*models.Admin) {
+				params.ParentID = admins["agent"].ID
+			},
+			wantErr: "上级管理员不能是自身",
+		},
+		{
+			name:     "层级不能低于下级",
+			operator: "root",
+			target:   "merchant",
+			modify: func(params *dto.AdminUpdateParams, _ map[string]*models.Admin) {
+				params.Type = models.AdminTypeAgent
+				params.Role = RoleAgent
+			},
+			wantErr: "管理员层级不能低于其下级",
+		},
+		{
+			name:     "移至顶级",
+			operator: "root",
+			target:   "other",
+			modify: func(params *dto.AdminUpdateParams, _ map[string]*models.Admin) {
+				params.ParentID = 0
+			},
+		},
+		{
+			name:     "超出数据权限范围",
+			operator: "agent",
+			target:   "other",
+			modify:   func(*dto.AdminUpdateParams, map[string]*models.Admin) {},
+			wantErr:  "管理员不存在",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, db := newAdminTestService(t)
+			admins := map[string]*models.Admin{}
+			admins["root"] = createTestAdmin(t, db, "root", models.AdminTypeSuper, RoleSuperAdmin, 0)
+			admins["merchant"] = createTestAdmin(t, db, "merchant", models.AdminTypeMerchant, RoleMerchant, admins["root"].ID)
+			admins["agent"] = createTestAdmin(t, db, "agent", models.AdminTypeAgent, RoleAgent, admins["merchant"].ID)
+			admins["other"] = createTestAdmin(t, db, "other", models.AdminTypeMerchant, RoleMerchant, admins["root"].ID)
+			admins["sub"] = createTestAdmin(t, db, "sub", models.AdminTypeMerchant, RoleMerchant, admins["merchant"].ID)
+
+			target := admins[tt.target]
+			params := &dto.AdminUpdateParams{
+				ID:       target.ID,
+				Nickname: target.Nickname,
+				Status:   target.Status,
+				Type:     target.Type,
+				Role:     target.Role,
+				ParentID: target.ParentID,
+			}
+			tt.modify(params, admins)
+
+			ctx := context.Background()
+			if admins[tt.operator].Type != models.AdminTypeSuper {
+				ctx = utils.WithDataScope(ctx, &utils.DataScope{AdminIDs: []uint{admins[tt.operator].ID}})
+			}
+			admin, err := s.Update(ctx, admins[tt.operator].ID, params)
+			assertError(t, err, tt.wantErr)
+			if tt.wantErr != "" {
+				return
+			}
+			var saved models.Admin
+			if err := db.First(&saved, admin.ID).Error; err != nil {
+				t.Fatalf("find admin failed: %v", err)
+			}
+			if saved.Nickname != params.Nickname || saved.Status != params.Status || saved.ParentID != params.ParentID {
+				t.Errorf("admin not updated: %+v", saved)
+			}
+		})
+	}
+}
+
+func TestAdminService_UpdatePassword(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  dto.AdminPasswordParams
+		wantErr string
+	}{
+		{name: "修改成功", params: dto.AdminPasswordParams{OldPassword: "password123", Password: "newpassword", ConfirmPassword: "newpassword"}},
+		{name: "两次密码不一致", params: dto.AdminPasswordParams{OldPassword: "password123", Password: "newpassword", ConfirmPassword: "other"}, wantErr: "两次输入的密码不一致"},
+		{name: "原密码错误", params: dto.AdminPasswordParams{OldPassword: "wrong", Password: "newpassword", ConfirmPassword: "newpassword"}, wantErr: "原密码错误"},
+		{name: "新旧密码相同", params: dto.AdminPasswordParams{OldPassword: "password123", Password: "password123", ConfirmPassword: "password123"}, wantErr: "新密码不能与原密码相同"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, db := newAdminTestService(t)
+			admin := createTestAdmin(t, db, "root", models.AdminTypeSuper, RoleSuperAdmin, 0)
+
+			err := s.UpdatePassword(context.Background(), admin.ID, "session", &tt.params)
+			assertError(t, err, tt.wantErr)
+
+			var saved models.Admin
+			if err := db.First(&saved, admin.ID).Error; err != nil {
+				t.Fatalf("find admin failed: %v", err)
+			}
+			want := "password123"
+			if tt.wantErr == "" {
+				want = tt.params.Password
+			}
+			if !saved.CompareHashAndPassword(want) {
+				t.Errorf("saved password should be %q", want)
+			}
+		})
+	}
+}
+
+func TestAdminService_Delete(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		wantErr string
+	}{
+		{name: "删除无下级的管理员", target: "agent"},
+		{name: "不能删除自身", target: "root", wantErr: "不能删除自身"},
+		{name: "存在下级时不能删除", target: "merchant", wantErr: "存在下级管理员"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, db := newAdminTestService(t)
+			admins := map[string]*models.Admin{}
+			admins["root"] = createTestAdmin(t, db, "root", models.AdminTypeSuper, RoleSuperAdmin, 0)
+			admins["merchant"] = createTestAdmin(t, db, "merchant", models.AdminTypeMerchant, RoleMerchant, admins["root"].ID)
+			admins["agent"] = createTestAdmin(t, db, "agent", models.AdminTypeAgent, RoleAgent, admins["merchant"].ID)
+
+			err := s.Delete(context.Background(), admins["root"].ID, admins[tt.target].ID)
+			assertError(t, err, tt.wantErr)
+			if tt.wantErr != "" {
+				return
+			}
+
+			var count int64
+			db.Model(&models.Admin{}).Where("id = ?", admins[tt.target].ID).Count(&count)
+			if count != 0 {
+				t.Error("admin should be deleted")
+			}
+			db.Unscoped().Model(&models.Admin{}).Where("id = ?", admins[tt.target].ID).Count(&count)
+			if count != 1 {
+				t.Error("admin should be soft deleted")
+			}
+		})
+	}
+}
+
+func TestAdminService_Unlock(t *testing.T) {
+	s, db := newAdminTestService(t)
+	admin := createTestAdmin(t, db, "root", models.AdminTypeSuper, RoleSuperAdmin, 0)
+	admin.Status = models.AdminStatusLocked
+	admin.LockedUntil = time.Now().Add(time.Hour)
+	admin.FailedLoginAttempts = 3
+	admin.LockoutCount = 2
+	if err := db.Save(admin).Error; err != nil {
+		t.Fatalf("lock admin failed: %v", err)
+	}
+
+	if err := s.Unlock(context.Background(), admin.ID); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+	var saved models.Admin
+	if err := db.First(&saved, admin.ID).Error; err != nil {
+		t.Fatalf("find admin failed: %v", err)
+	}
+	if saved.IsLocked() || saved.Status != models.AdminStatusEnabled || saved.FailedLoginAttempts != 0 || saved.LockoutCount != 0 {
+		t.Errorf("admin not unlocked: status=%d failed=%d lockouts=%d", saved.Status, saved.FailedLoginAttempts, saved.LockoutCount)
+	}
+}
+
+func TestAdminService_UniqueInTenant(t *testing.T) {
+	s, db := newAdminTestService(t)
+	admin := createTestAdmin(t, db, "root", models.AdminTypeSuper, RoleSuperAdmin, 0)
+	if err := db.Model(admin).Updates(map[string]interface{}{"tenant_id": "acme", "email": "root@example.com"}).Error; err != nil {
+		t.Fatalf("update admin failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		tenantID string
+		email    string
+		wantErr  string
+	}{
+		{name: "同一租户内用户名重复", tenantID: "acme", wantErr: "用户名已存在"},
+		{name: "其他租户可使用相同用户名", tenantID: "beta"},
+		{name: "不属于租户的管理员可使用相同用户名", tenantID: ""},
+		{name: "邮箱全局唯一", tenantID: "beta", email: "root@example.com", wantErr: "邮箱已存在"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.tenantID != "" {
+				ctx = tenant.WithID(ctx, tt.tenantID)
+			}
+			assertError(t, s.checkUnique(ctx, 0, "root", tt.email, ""), tt.wantErr)
+		})
+	}
+
+	// 不同租户的同名管理员可同时存在
+	if err := db.Create(&models.Admin{TenantID: "beta", Username: "root", PasswordHash: "password123", Nickname: "root", Type: models.AdminTypeSuper, Role: RoleSuperAdmin}).Error; err != nil {
+		t.Fatalf("create admin in other tenant failed: %v", err)
+	}
+}
+
+func TestAdminService_Restore(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		wantErr string
+	}{
+		{name: "恢复已删除的管理员", target: "merchant"},
+		{name: "上级已删除时不能恢复", target: "agent", wantErr: "请先恢复上级管理员"},
+		{name: "未删除的管理员不能恢复", target: "root", wantErr: "管理员不存在"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, db := newAdminTestService(t)
+			admins := map[string]*models.Admin{}
+			admins["root"] = createTestAdmin(t, db, "root", models.AdminTypeSuper, RoleSuperAdmin, 0)
+			admins["merchant"] = createTestAdmin(t, db, "merchant", models.AdminTypeMerchant, RoleMerchant, admins["root"].ID)
+			admins["agent"] = createTestAdmin(t, db, "agent", models.AdminTypeAgent, RoleAgent, admins["merchant"].ID)
+			if err := db.Delete(&models.Admin{}, []uint{admins["merchant"].ID, admins["agent"].ID}).Error; err != nil {
+				t.Fatalf("delete admins failed: %v", err)
+			}
+
+			trashed, err := s.Trashed(context.Background(), &dto.AdminIndexParams{Page: utils.Page{Page: 1, Size: 10}})
+			if err != nil {
+				t.Fatalf("Trashed failed: %v", err)
+			}
+			if trashed.Total != 2 {
+				t.Fatalf("Trashed total = %d, want 2", trashed.Total)
+			}
+
+			err = s.Restore(context.Background(), admins[tt.target].ID)
+			assertError(t, err, tt.wantErr)
+			if tt.wantErr != "" {
+				return
+			}
+			var count int64
+			db.Model(&models.Admin{}).Where("id = ?", admins[tt.target].ID).Count(&count)
+			if count != 1 {
+				t.Error("admin should be restored")
+			}
+		})
+	}
+}
+
+func TestAdminService_List(t *testing.T) {
+	s, db := newAdminTestService(t)
+	root := createTestAdmin(t, db, "root", models.AdminTypeSuper, RoleSuperAdmin, 0)
+	merchant := createTestAdmin(t, db, "merchant", models.AdminTypeMerchant, RoleMerchant, root.ID)
+	agent := createTestAdmin(t, db, "agent", models.AdminTypeAgent, RoleAgent, merchant.ID)
+	createTestAdmin(t, db, "other", models.AdminTypeMerchant, RoleMerchant, root.ID)
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		params dto.AdminIndexParams
+		want   []string
+		total  int64
+	}{
+		{name: "全部按ID倒序", ctx: context.Background(), want: []string{"other", "agent", "merchant", "root"}, total: 4},
+		{name: "按用户名模糊匹配", ctx: context.Background(), params: dto.AdminIndexParams{Username: "er"}, want: []string{"other", "merchant"}, total: 2},
+		{name: "按层级过滤", ctx: context.Background(), params: dto.AdminIndexParams{Type: models.AdminTypeMerchant}, want: []string{"other", "merchant"}, total: 2},
+		{name: "按上级过滤", ctx: context.Background(), params: dto.AdminIndexParams{ParentID: merchant.ID}, want: []string{"agent"}, total: 1},
+		{name: "自定义排序", ctx: context.Background(), params: dto.AdminIndexParams{Page: utils.Page{Sort: "username", Order: "ASC"}}, want: []string{"agent", "merchant", "other", "root"}, total: 4},
+		{name: "排序字段不在白名单", ctx: context.Background(), params: dto.AdminIndexParams{Page: utils.Page{Sort: "password_hash", Order: "DESC"}}, want: []string{"other", "agent", "merchant", "root"}, total: 4},
+		{name: "分页", ctx: context.Background(), params: dto.AdminIndexParams{Page: utils.Page{Page: 2, Size: 3}}, want: []string{"root"}, total: 4},
+		{
+			name:  "数据权限范围",
+			ctx:   utils.WithDataScope(context.Background(), &utils.DataScope{AdminIDs: []uint{merchant.ID, agent.ID}}),
+			want:  []string{"agent", "merchant"},
+			total: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params
+			result, err := s.List(tt.ctx, &params)
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			items, ok := result.Items.([]*models.Admin)
+			if !ok {
+				t.Fatalf("unexpected items type %T", result.Items)
+			}
+			got := make([]string, 0, len(items))
+			for _, item := range items {
+				got = append(got, item.Username)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("List = %v, want %v", got, tt.want)
+			}
+			if result.Total != tt.total {
+				t.Errorf("List total = %d, want %d", result.Total, tt.total)
+			}
+		})
+	}
+}
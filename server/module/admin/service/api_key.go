@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/so68/core/logging"
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/module/admin/repo"
+	"github.com/so68/core/server/utils"
+	"gorm.io/gorm"
+)
+
+// APIKeyPrefix API Key 前缀，用于和机器令牌区分
+const APIKeyPrefix = "ak_"
+
+// APIKeyService 管理员 API Key 服务
+type APIKeyService interface {
+	// Create 创建 API Key
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @param params 创建参数
+	// @return *dto.APIKeyCreateResult 创建结果(包含 Key 明文)
+	// @return error 错误
+	Create(ctx context.Context, adminID uint, params *dto.APIKeyCreateParams) (*dto.APIKeyCreateResult, error)
+	// List 获取管理员的 API Key 列表
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @return []*models.AdminAPIKey Key 列表
+	// @return error 错误
+	List(ctx context.Context, adminID uint) ([]*models.AdminAPIKey, error)
+	// Rotate 轮换 API Key，旧 Key 在宽限期内仍可使用
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @param params 轮换参数
+	// @return *dto.APIKeyCreateResult 轮换结果(包含新 Key 明文)
+	// @return error 错误
+	Rotate(ctx context.Context, adminID uint, params *dto.APIKeyRotateParams) (*dto.APIKeyCreateResult, error)
+	// Revoke 吊销 API Key
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @param id Key ID
+	// @return error 错误
+	Revoke(ctx context.Context, adminID uint, id uint) error
+	// Authenticate 校验 API Key
+	// @param ctx 上下文
+	// @param key Key 明文
+	// @param ip 客户端IP
+	// @return *models.AdminAPIKey Key 信息
+	// @return error 错误
+	Authenticate(ctx context.Context, key string, ip string) (*models.AdminAPIKey, error)
+}
+
+// APIKeyServiceImpl 管理员 API Key 服务实现
+type APIKeyServiceImpl struct {
+	db         *gorm.DB
+	logger     *slog.Logger
+	adminRepo  repo.AdminRepo
+	apiKeyRepo repo.AdminAPIKeyRepo
+}
+
+// NewAPIKeyService 创建一个管理员 API Key 服务
+func NewAPIKeyService(db *gorm.DB, logger *slog.Logger) APIKeyService {
+	return &APIKeyServiceImpl{
+		db:         db,
+		logger:     logger,
+		adminRepo:  repo.NewAdminRepo(),
+		apiKeyRepo: repo.NewAdminAPIKeyRepo(),
+	}
+}
+
+// Create 创建 API Key
+func (s *APIKeyServiceImpl) Create(ctx context.Context, adminID uint, params *dto.APIKeyCreateParams) (*dto.APIKeyCreateResult, error) {
+	if params.Name == "" {
+		return nil, errors.New("API Key 名称不能为空")
+	}
+	if len(params.Scopes) == 0 {
+		return nil, errors.New("授权范围不能为空")
+	}
+	for _, scope := range params.Scopes {
+		if !slices.Contains(TokenScopes, scope) {
+			return nil, fmt.Errorf("不支持的授权范围: %s", scope)
+		}
+	}
+	if params.RateLimit < 0 {
+		return nil, errors.New("请求频率上限不能小于0")
+	}
+
+	// 生成 Key 明文
+	plain, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	key := &models.AdminAPIKey{
+		AdminID:   adminID,
+		Name:      params.Name,
+		Prefix:    plain[:len(APIKeyPrefix)+8],
+		KeyHash:   hashMachineToken(plain),
+		Scopes:    strings.Join(params.Scopes, ","),
+		RateLimit: params.RateLimit,
+	}
+	if params.ExpiresIn > 0 {
+		expiresAt := time.Now().Add(time.Duration(params.ExpiresIn) * time.Second)
+		key.ExpiresAt = &expiresAt
+	}
+
+	if err := s.apiKeyRepo.Create(ctx, utils.NewGormBuilder(ctx, s.db), key); err != nil {
+		return nil, fmt.Errorf("创建 API Key 失败: %w", err)
+	}
+	return &dto.APIKeyCreateResult{Info: key, Key: plain}, nil
+}
+
+// List 获取管理员的 API Key 列表
+func (s *APIKeyServiceImpl) List(ctx context.Context, adminID uint) ([]*models.AdminAPIKey, error) {
+	keys, err := s.apiKeyRepo.FindList(ctx, utils.NewGormBuilderFind(ctx, s.db, "admin_id", adminID))
+	if err != nil {
+		return nil, fmt.Errorf("查询 API Key 失败: %w", err)
+	}
+	return keys, nil
+}
+
+// Rotate 轮换 API Key
+func (s *APIKeyServiceImpl) Rotate(ctx context.Context, adminID uint, params *dto.APIKeyRotateParams) (*dto.APIKeyCreateResult, error) {
+	if params.GracePeriod < 0 {
+		return nil, errors.New("宽限期不能小于0")
+	}
+
+	key, err := s.apiKeyRepo.Find(ctx, utils.NewGormBuilderFind(ctx, s.db, "id", params.ID).WhereEqual("admin_id", adminID))
+	if err != nil {
+		return nil, fmt.Errorf("查询 API Key 失败: %w", err)
+	}
+	if !key.IsActive() {
+		return nil, errors.New("API Key 已吊销或已过期")
+	}
+
+	plain, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// 旧 Key 在宽限期内仍可使用，宽限期为 0 时立即失效
+	now := time.Now()
+	key.PreviousKeyHash = ""
+	key.PreviousExpiresAt = nil
+	if params.GracePeriod > 0 {
+		previousExpiresAt := now.Add(time.Duration(params.GracePeriod) * time.Second)
+		key.PreviousKeyHash = key.KeyHash
+		key.PreviousExpiresAt = &previousExpiresAt
+	}
+	key.Prefix = plain[:len(APIKeyPrefix)+8]
+	key.KeyHash = hashMachineToken(plain)
+	key.RotatedAt = &now
+
+	if err := s.apiKeyRepo.Update(ctx, utils.NewGormBuilder(ctx, s.db), key); err != nil {
+		return nil, fmt.Errorf("轮换 API Key 失败: %w", err)
+	}
+	return &dto.APIKeyCreateResult{Info: key, Key: plain}, nil
+}
+
+// Revoke 吊销 API Key
+func (s *APIKeyServiceImpl) Revoke(ctx context.Context, adminID uint, id uint) error {
+	key, err := s.apiKeyRepo.Find(ctx, utils.NewGormBuilderFind(ctx, s.db, "id", id).WhereEqual("admin_id", adminID))
+	if err != nil {
+		return fmt.Errorf("查询 API Key 失败: %w", err)
+	}
+	if key.RevokedAt != nil {
+		return nil
+	}
+
+	now := time.Now()
+	key.RevokedAt = &now
+	if err := s.apiKeyRepo.Update(ctx, utils.NewGormBuilder(ctx, s.db), key); err != nil {
+		return fmt.Errorf("吊销 API Key 失败: %w", err)
+	}
+	return nil
+}
+
+// Authenticate 校验 API Key
+func (s *APIKeyServiceImpl) Authenticate(ctx context.Context, plain string, ip string) (*models.AdminAPIKey, error) {
+	if !strings.HasPrefix(plain, APIKeyPrefix) {
+		return nil, errors.New("无效的 API Key")
+	}
+
+	// 优先匹配当前 Key，其次匹配宽限期内的旧 Key
+	hash := hashMachineToken(plain)
+	key, err := s.apiKeyRepo.Find(ctx, utils.NewGormBuilderFind(ctx, s.db, "key_hash", hash))
+	if err != nil {
+		key, err = s.apiKeyRepo.Find(ctx, utils.NewGormBuilderFind(ctx, s.db, "previous_key_hash", hash))
+		if err != nil || key.PreviousExpiresAt == nil || !key.PreviousExpiresAt.After(time.Now()) {
+			return nil, errors.New("无效的 API Key")
+		}
+	}
+	if !key.IsActive() {
+		return nil, errors.New("API Key 已吊销或已过期")
+	}
+
+	// Key 所属管理员必须可用
+	admin, err := s.adminRepo.Find(ctx, utils.NewGormBuilderFind(ctx, s.db, "id", key.AdminID))
+	if err != nil {
+		return nil, errors.New("API Key 所属管理员不存在")
+	}
+	if admin.Status == models.AdminStatusDisabled || admin.IsLocked() {
+		return nil, errors.New("API Key 所属管理员已禁用或锁定")
+	}
+
+	// 记录最后使用信息（仅写入使用字段且要求未吊销，避免以读取时的旧数据覆盖并发的轮换或吊销）
+	now := time.Now()
+	key.LastUsedAt = &now
+	key.LastUsedIP = ip
+	builder := utils.NewGormBuilderFind(ctx, s.db, "id", key.ID).WhereIsNull("revoked_at")
+	if _, err := s.apiKeyRepo.UpdateColumns(ctx, builder, map[string]interface{}{"last_used_at": now, "last_used_ip": ip}); err != nil {
+		logging.FromContext(ctx).Warn("更新 API Key 使用信息失败", "error", err)
+	}
+	key.Admin = admin
+	return key, nil
+}
+
+// generateAPIKey 生成 API Key 明文
+func generateAPIKey() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("生成 API Key 失败: %w", err)
+	}
+	return APIKeyPrefix + hex.EncodeToString(buf), nil
+}
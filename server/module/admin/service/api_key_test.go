@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/module/admin/dto"
+	"gorm.io/gorm"
+)
+
+/*
+管理员 API Key 测试
+
+本文件用于测试服务端对接使用的 API Key 创建、校验、轮换与吊销。
+
+运行命令：
+go test -v -run "^TestAPIKeyService.*$"
+
+测试内容：
+1. 参数校验：名称、授权范围、请求频率上限 (Create)
+2. Key 明文仅返回一次，数据库只保存哈希值
+3. 校验 Key 并记录最后使用信息 (Authenticate)
+4. 轮换后旧 Key 在宽限期内可用，宽限期为 0 时立即失效 (Rotate)
+5. 吊销后 Key 不可用，管理员禁用后 Key 不可用 (Revoke)
+*/
+
+// newAPIKeyTestService 创建 API Key 测试服务（API Key 关联管理员外键，需先于管理员清理）
+func newAPIKeyTestService(t *testing.T) (APIKeyService, *gorm.DB) {
+	t.Helper()
+	clear := func() {
+		if err := adminTestDB.Session(&gorm.Session{AllowGlobalUpdate: true}).Unscoped().Delete(&models.AdminAPIKey{}).Error; err != nil {
+			t.Fatalf("clear api keys failed: %v", err)
+		}
+	}
+	clear()
+	t.Cleanup(clear)
+	_, db := newAdminTestService(t)
+	return NewAPIKeyService(db, slog.New(slog.NewTextHandler(io.Discard, nil))), db
+}
+
+func TestAPIKeyService_Create(t *testing.T) {
+	svc, db := newAPIKeyTestService(t)
+	ctx := context.Background()
+	admin := createTestAdmin(t, db, "apikey_create", models.AdminTypeSuper, "admin", 0)
+
+	_, err := svc.Create(ctx, admin.ID, &dto.APIKeyCreateParams{Scopes: []string{TokenScopeReadOnly}})
+	assertError(t, err, "API Key 名称不能为空")
+	_, err = svc.Create(ctx, admin.ID, &dto.APIKeyCreateParams{Name: "erp"})
+	assertError(t, err, "授权范围不能为空")
+	_, err = svc.Create(ctx, admin.ID, &dto.APIKeyCreateParams{Name: "erp", Scopes: []string{"write"}})
+	assertError(t, err, "不支持的授权范围")
+	_, err = svc.Create(ctx, admin.ID, &dto.APIKeyCreateParams{Name: "erp", Scopes: []string{TokenScopeReadOnly}, RateLimit: -1})
+	assertError(t, err, "请求频率上限不能小于0")
+
+	result, err := svc.Create(ctx, admin.ID, &dto.APIKeyCreateParams{Name: "erp", Scopes: []string{TokenScopeReadOnly, TokenScopeReports}, RateLimit: 60, ExpiresIn: 3600})
+	assertError(t, err, "")
+	if len(result.Key) != len(APIKeyPrefix)+64 || result.Key[:len(APIKeyPrefix)] != APIKeyPrefix {
+		t.Fatalf("unexpected key format: %s", result.Key)
+	}
+	if result.Info.KeyHash == result.Key || result.Info.KeyHash != hashMachineToken(result.Key) {
+		t.Fatalf("expected key to be stored as hash")
+	}
+	if result.Info.ExpiresAt == nil || result.Info.RateLimit != 60 {
+		t.Fatalf("unexpected key info: %+v", result.Info)
+	}
+
+	keys, err := svc.List(ctx, admin.ID)
+	assertError(t, err, "")
+	if len(keys) != 1 || len(keys[0].GetScopes()) != 2 {
+		t.Fatalf("expected 1 key with 2 scopes, got %+v", keys)
+	}
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	svc, db := newAPIKeyTestService(t)
+	ctx := context.Background()
+	admin := createTestAdmin(t, db, "apikey_auth", models.AdminTypeSuper, "admin", 0)
+
+	result, err := svc.Create(ctx, admin.ID, &dto.APIKeyCreateParams{Name: "erp", Scopes: []string{TokenScopeReadOnly}})
+	assertError(t, err, "")
+
+	_, err = svc.Authenticate(ctx, "mt_"+result.Key[len(APIKeyPrefix):], "127.0.0.1")
+	assertError(t, err, "无效的 API Key")
+	_, err = svc.Authenticate(ctx, APIKeyPrefix+"unknown", "127.0.0.1")
+	assertError(t, err, "无效的 API Key")
+
+	key, err := svc.Authenticate(ctx, result.Key, "10.0.0.1")
+	assertError(t, err, "")
+	if key.AdminID != admin.ID || key.Admin == nil {
+		t.Fatalf("unexpected key: %+v", key)
+	}
+	stored := &models.AdminAPIKey{}
+	if err := db.First(stored, key.ID).Error; err != nil {
+		t.Fatalf("find key failed: %v", err)
+	}
+	if stored.LastUsedAt == nil || stored.LastUsedIP != "10.0.0.1" {
+		t.Fatalf("expected last used info to be recorded, got %+v", stored)
+	}
+
+	// 管理员禁用后 Key 不可用
+	if err := db.Model(admin).Update("status", models.AdminStatusDisabled).Error; err != nil {
+		t.Fatalf("disable admin failed: %v", err)
+	}
+	_, err = svc.Authenticate(ctx, result.Key, "10.0.0.1")
+	assertError(t, err, "API Key 所属管理员已禁用或锁定")
+}
+
+func TestAPIKeyService_Rotate(t *testing.T) {
+	svc, db := newAPIKeyTestService(t)
+	ctx := context.Background()
+	admin := createTestAdmin(t, db, "apikey_rotate", models.AdminTypeSuper, "admin", 0)
+	other := createTestAdmin(t, db, "apikey_rotate_other", models.AdminTypeSuper, "admin", 0)
+
+	created, err := svc.Create(ctx, admin.ID, &dto.APIKeyCreateParams{Name: "erp", Scopes: []string{TokenScopeReadOnly}})
+	assertError(t, err, "")
+
+	// 不能轮换其他管理员的 Key
+	_, err = svc.Rotate(ctx, other.ID, &dto.APIKeyRotateParams{ID: created.Info.ID})
+	assertError(t, err, "查询 API Key 失败")
+
+	// 宽限期内旧 Key 与新 Key 均可使用
+	rotated, err := svc.Rotate(ctx, admin.ID, &dto.APIKeyRotateParams{ID: created.Info.ID, GracePeriod: 3600})
+	assertError(t, err, "")
+	if rotated.Key == created.Key || rotated.Info.RotatedAt == nil {
+		t.Fatalf("expected a new key, got %+v", rotated.Info)
+	}
+	_, err = svc.Authenticate(ctx, created.Key, "127.0.0.1")
+	assertError(t, err, "")
+	_, err = svc.Authenticate(ctx, rotated.Key, "127.0.0.1")
+	assertError(t, err, "")
+
+	// 宽限期结束后旧 Key 失效
+	if err := db.Model(&models.AdminAPIKey{}).Where("id = ?", created.Info.ID).Update("previous_expires_at", time.Now().Add(-time.Second)).Error; err != nil {
+		t.Fatalf("expire previous key failed: %v", err)
+	}
+	_, err = svc.Authenticate(ctx, created.Key, "127.0.0.1")
+	assertError(t, err, "无效的 API Key")
+
+	// 宽限期为 0 时旧 Key 立即失效
+	again, err := svc.Rotate(ctx, admin.ID, &dto.APIKeyRotateParams{ID: created.Info.ID})
+	assertError(t, err, "")
+	_, err = svc.Authenticate(ctx, rotated.Key, "127.0.0.1")
+	assertError(t, err, "无效的 API Key")
+	_, err = svc.Authenticate(ctx, again.Key, "127.0.0.1")
+	assertError(t, err, "")
+}
+
+func TestAPIKeyService_Revoke(t *testing.T) {
+	svc, db := newAPIKeyTestService(t)
+	ctx := context.Background()
+	admin := createTestAdmin(t, db, "apikey_revoke", models.AdminTypeSuper, "admin", 0)
+
+	created, err := svc.Create(ctx, admin.ID, &dto.APIKeyCreateParams{Name: "erp", Scopes: []string{TokenScopeReports}})
+	assertError(t, err, "")
+
+	assertError(t, svc.Revoke(ctx, admin.ID, created.Info.ID), "")
+	// 重复吊销不报错
+	assertError(t, svc.Revoke(ctx, admin.ID, created.Info.ID), "")
+
+	_, err = svc.Authenticate(ctx, created.Key, "127.0.0.1")
+	assertError(t, err, "API Key 已吊销或已过期")
+	_, err = svc.Rotate(ctx, admin.ID, &dto.APIKeyRotateParams{ID: created.Info.ID})
+	assertError(t, err, "API Key 已吊销或已过期")
+}
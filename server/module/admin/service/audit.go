@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/module/admin/repo"
+	"github.com/so68/core/server/utils"
+	"gorm.io/gorm"
+)
+
+// AuditService 管理员操作审计日志服务
+type AuditService interface {
+	// Record 记录审计日志
+	// @param ctx 上下文
+	// @param log 审计日志
+	// @return error 错误
+	Record(ctx context.Context, log *models.AdminAuditLog) error
+	// List 分页查询审计日志（按数据权限范围过滤）
+	// @param ctx 上下文
+	// @param params 查询参数
+	// @return *utils.PageResp 分页结果
+	// @return error 错误
+	List(ctx context.Context, params *dto.AuditLogIndexParams) (*utils.PageResp, error)
+	// Cleanup 清理指定时间之前的审计日志
+	// @param ctx 上下文
+	// @param before 截止时间
+	// @return error 错误
+	Cleanup(ctx context.Context, before time.Time) error
+}
+
+// AuditServiceImpl 管理员操作审计日志服务实现
+type AuditServiceImpl struct {
+	db           *gorm.DB
+	logger       *slog.Logger
+	auditLogRepo repo.AdminAuditLogRepo
+}
+
+// NewAuditService 创建一个管理员操作审计日志服务
+func NewAuditService(db *gorm.DB, logger *slog.Logger) AuditService {
+	return &AuditServiceImpl{db: db, logger: logger, auditLogRepo: repo.NewAdminAuditLogRepo()}
+}
+
+// Record 记录审计日志
+func (s *AuditServiceImpl) Record(ctx context.Context, log *models.AdminAuditLog) error {
+	if err := s.auditLogRepo.Create(ctx, utils.NewGormBuilder(ctx, s.db), log); err != nil {
+		return fmt.Errorf("记录审计日志失败: %w", err)
+	}
+	return nil
+}
+
+// List 分页查询审计日志
+func (s *AuditServiceImpl) List(ctx context.Context, params *dto.AuditLogIndexParams) (*utils.PageResp, error) {
+	if params.Sort == "" {
+		params.Sort, params.Order = "id", "DESC"
+	}
+	builder := utils.NewGormBuilderWithPage(ctx, s.db.Model(&models.AdminAuditLog{}), &params.Page, "id", "admin_id", "name", "status", "latency", "created_at")
+	if params.AdminID > 0 {
+		builder.WhereEqual("admin_id", params.AdminID)
+	}
+	if params.Name != "" {
+		builder.WhereLike("name", "%"+params.Name+"%")
+	}
+	if params.Method != "" {
+		builder.WhereEqual("method", params.Method)
+	}
+	if params.Success != nil {
+		builder.WhereEqual("success", *params.Success)
+	}
+	if params.StartAt > 0 {
+		builder.WhereGreaterThanEqual("created_at", time.Unix(params.StartAt, 0))
+	}
+	if params.EndAt > 0 {
+		builder.WhereLessThan("created_at", time.Unix(params.EndAt, 0))
+	}
+
+	result, err := s.auditLogRepo.FindListWithPage(ctx, builder)
+	if err != nil {
+		return nil, fmt.Errorf("查询审计日志失败: %w", err)
+	}
+	return result, nil
+}
+
+// Cleanup 清理指定时间之前的审计日志
+func (s *AuditServiceImpl) Cleanup(ctx context.Context, before time.Time) error {
+	builder := utils.NewGormBuilder(ctx, s.db).WithoutDataScope().WhereLessThan("created_at", before)
+	if err := s.auditLogRepo.Delete(ctx, builder); err != nil {
+		return fmt.Errorf("清理审计日志失败: %w", err)
+	}
+	return nil
+}
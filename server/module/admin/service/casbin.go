@@ -1,15 +1,20 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/casbin/casbin/v2"
 	"github.com/casbin/casbin/v2/model"
 	gormadapter "github.com/casbin/gorm-adapter/v3"
 	"github.com/gin-gonic/gin"
 	"github.com/so68/core/cache"
+	"github.com/so68/core/server/module/admin/dto"
 	"github.com/so68/core/server/module/admin/repo"
 	"github.com/so68/core/server/utils"
 	"gorm.io/gorm"
@@ -23,6 +28,9 @@ const (
 	RoleAgent      = "代理管理员"
 )
 
+// BuiltInRoles 内置角色（启动时自动创建，不可修改名称或删除）
+var BuiltInRoles = []string{RoleSuperAdmin, RoleMerchant, RoleAgent}
+
 // CasbinService 权限服务
 type CasbinService interface {
 	// GetContextRole 获取上下文角色
@@ -47,6 +55,55 @@ type CasbinService interface {
 	// @param method 方法
 	// @return bool 是否具有继承权限
 	HasRoleInheritancesEnforce(role string, path string, method string) bool
+	// HasScopesEnforce 检查机器令牌授权范围是否允许访问
+	// @param scopes 授权范围
+	// @param path 路径
+	// @param method 方法
+	// @return bool 任一授权范围允许即返回 true
+	HasScopesEnforce(scopes []string, path string, method string) bool
+
+	// ListRoles 获取角色列表
+	// @return []*dto.RoleInfo 角色列表
+	// @return error 错误
+	ListRoles() ([]*dto.RoleInfo, error)
+	// CreateRole 创建角色
+	// @param name 角色名称
+	// @param permissions 权限名称
+	// @return error 错误
+	CreateRole(name string, permissions []string) error
+	// UpdateRole 修改角色名称（同步更新使用该角色的管理员）
+	// @param ctx 上下文
+	// @param name 角色名称
+	// @param newName 新角色名称
+	// @return error 错误
+	UpdateRole(ctx context.Context, name string, newName string) error
+	// DeleteRole 删除角色（仍有管理员使用时不可删除）
+	// @param ctx 上下文
+	// @param name 角色名称
+	// @return error 错误
+	DeleteRole(ctx context.Context, name string) error
+	// AssignPermissions 分配角色权限（覆盖原有权限）
+	// @param role 角色
+	// @param permissions 权限名称
+	// @return error 错误
+	AssignPermissions(role string, permissions []string) error
+	// ListPermissions 获取全部权限
+	// @return []*dto.PermissionInfo 权限列表
+	// @return error 错误
+	ListPermissions() ([]*dto.PermissionInfo, error)
+	// GetRolePermissions 获取角色的有效权限（含继承）
+	// @param role 角色
+	// @return []*dto.PermissionInfo 权限列表
+	// @return error 错误
+	GetRolePermissions(role string) ([]*dto.PermissionInfo, error)
+	// ExportPolicies 导出全部策略与继承关系
+	// @return *dto.PolicyData 策略数据
+	// @return error 错误
+	ExportPolicies() (*dto.PolicyData, error)
+	// ImportPolicies 批量导入策略与继承关系（与现有策略合并，已存在的忽略）
+	// @param data 策略数据
+	// @return error 错误
+	ImportPolicies(data *dto.PolicyData) error
 }
 
 // CasbinServiceImpl 权限服务实现
@@ -85,8 +142,7 @@ func NewCasbinService(db *gorm.DB, cache cache.Cache, logger *slog.Logger) Casbi
 		}
 
 		// 检查超级管理员策略是否已存在，不存在则添加
-		adminRoles := []string{RoleSuperAdmin, RoleMerchant, RoleAgent}
-		for _, role := range adminRoles {
+		for _, role := range BuiltInRoles {
 			exists, _ := enforcer.HasPolicy(role, "role", "read")
 			if !exists {
 				_, err = enforcer.AddPolicy(role, "role", "read")
@@ -163,3 +219,313 @@ func (s *CasbinServiceImpl) HasRoleInheritancesEnforce(role string, path string,
 	}
 	return ok
 }
+
+// HasScopesEnforce 检查机器令牌授权范围是否允许访问
+func (s *CasbinServiceImpl) HasScopesEnforce(scopes []string, path string, method string) bool {
+	for _, scope := range scopes {
+		ok, err := enforcer.Enforce(ScopeSubject(scope), path, method)
+		if err != nil {
+			s.logger.Warn("检查令牌授权范围失败", "scope", scope, "error", err)
+			continue
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// roleMarker 角色标记策略（拥有该策略的主体视为角色）
+func roleMarker(role string) []string {
+	return []string{role, "role", "read"}
+}
+
+// isPermissionPolicy 是否为权限策略（排除角色标记与机器令牌授权范围）
+func isPermissionPolicy(policy []string) bool {
+	return len(policy) == 3 && policy[1] != "role" && !strings.HasPrefix(policy[0], ScopeSubject(""))
+}
+
+// hasRole 角色是否存在
+func hasRole(role string) (bool, error) {
+	return enforcer.HasPolicy(roleMarker(role))
+}
+
+// coversRole 角色 role 的有效权限（含继承）是否包含角色 target 的全部有效权限
+func coversRole(role string, target string) (bool, error) {
+	if role == target {
+		return true, nil
+	}
+	granted, err := enforcer.GetImplicitPermissionsForUser(role)
+	if err != nil {
+		return false, err
+	}
+	required, err := enforcer.GetImplicitPermissionsForUser(target)
+	if err != nil {
+		return false, err
+	}
+	for _, policy := range required {
+		if !isPermissionPolicy(policy) {
+			continue
+		}
+		if !slices.ContainsFunc(granted, func(p []string) bool { return p[1] == policy[1] && p[2] == policy[2] }) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// checkPermissions 校验权限名称是否均已注册
+func checkPermissions(permissions []string) error {
+	for _, permission := range permissions {
+		policies, err := enforcer.GetFilteredPolicy(0, permission)
+		if err != nil {
+			return err
+		}
+		if !slices.ContainsFunc(policies, isPermissionPolicy) {
+			return fmt.Errorf("权限不存在: %s", permission)
+		}
+	}
+	return nil
+}
+
+// ListRoles 获取角色列表
+func (s *CasbinServiceImpl) ListRoles() ([]*dto.RoleInfo, error) {
+	markers, err := enforcer.GetFilteredPolicy(1, "role", "read")
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]*dto.RoleInfo, 0, len(markers))
+	for _, marker := range markers {
+		groupings, err := enforcer.GetFilteredGroupingPolicy(0, marker[0])
+		if err != nil {
+			return nil, err
+		}
+		permissions := make([]string, 0, len(groupings))
+		for _, grouping := range groupings {
+			permissions = append(permissions, grouping[1])
+		}
+		roles = append(roles, &dto.RoleInfo{Name: marker[0], BuiltIn: slices.Contains(BuiltInRoles, marker[0]), Permissions: permissions})
+	}
+	return roles, nil
+}
+
+// CreateRole 创建角色
+func (s *CasbinServiceImpl) CreateRole(name string, permissions []string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("角色名称不能为空")
+	}
+	exists, err := hasRole(name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("角色已存在: %s", name)
+	}
+	if err := checkPermissions(permissions); err != nil {
+		return err
+	}
+
+	if _, err := enforcer.AddPolicy(roleMarker(name)); err != nil {
+		return fmt.Errorf("创建角色失败: %w", err)
+	}
+	return s.AssignPermissions(name, permissions)
+}
+
+// UpdateRole 修改角色名称（同步更新使用该角色的管理员）
+func (s *CasbinServiceImpl) UpdateRole(ctx context.Context, name string, newName string) error {
+	newName = strings.TrimSpace(newName)
+	if slices.Contains(BuiltInRoles, name) {
+		return fmt.Errorf("内置角色不可修改: %s", name)
+	}
+	if newName == "" || newName == name {
+		return errors.New("新角色名称无效")
+	}
+	exists, err := hasRole(name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("角色不存在: %s", name)
+	}
+	exists, err = hasRole(newName)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("角色已存在: %s", newName)
+	}
+
+	// 更新角色标记与继承关系（角色作为子角色或父角色）
+	if _, err := enforcer.UpdatePolicy(roleMarker(name), roleMarker(newName)); err != nil {
+		return fmt.Errorf("更新角色失败: %w", err)
+	}
+	for index := range 2 {
+		groupings, err := enforcer.GetFilteredGroupingPolicy(index, name)
+		if err != nil {
+			return err
+		}
+		for _, grouping := range groupings {
+			renamed := slices.Clone(grouping)
+			renamed[index] = newName
+			if _, err := enforcer.UpdateGroupingPolicy(grouping, renamed); err != nil {
+				return fmt.Errorf("更新角色继承关系失败: %w", err)
+			}
+		}
+	}
+	if err := enforcer.SavePolicy(); err != nil {
+		return err
+	}
+
+	// 同步更新管理员角色（角色全局生效，不受数据权限范围与租户限制）
+	admins, err := s.adminRepo.FindList(ctx, utils.NewGormBuilderFind(ctx, s.db, "role", name).WithoutDataScope().WithoutTenant())
+	if err != nil {
+		return fmt.Errorf("查询角色管理员失败: %w", err)
+	}
+	for _, admin := range admins {
+		admin.Role = newName
+		if err := s.adminRepo.Update(ctx, utils.NewGormBuilder(ctx, s.db).WithoutDataScope().WithoutTenant(), admin); err != nil {
+			return fmt.Errorf("更新管理员角色失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// DeleteRole 删除角色（仍有管理员使用时不可删除）
+func (s *CasbinServiceImpl) DeleteRole(ctx context.Context, name string) error {
+	if slices.Contains(BuiltInRoles, name) {
+		return fmt.Errorf("内置角色不可删除: %s", name)
+	}
+	exists, err := hasRole(name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("角色不存在: %s", name)
+	}
+	// 按全部租户、全部数据范围检查，避免遗漏当前操作者不可见的管理员
+	admins, err := s.adminRepo.FindList(ctx, utils.NewGormBuilderFind(ctx, s.db, "role", name).WithoutDataScope().WithoutTenant())
+	if err != nil {
+		return fmt.Errorf("查询角色管理员失败: %w", err)
+	}
+	if len(admins) > 0 {
+		return fmt.Errorf("角色仍有 %d 个管理员使用, 不可删除", len(admins))
+	}
+
+	// 删除角色标记与相关继承关系
+	if _, err := enforcer.RemovePolicy(roleMarker(name)); err != nil {
+		return fmt.Errorf("删除角色失败: %w", err)
+	}
+	for index := range 2 {
+		if _, err := enforcer.RemoveFilteredGroupingPolicy(index, name); err != nil {
+			return fmt.Errorf("删除角色继承关系失败: %w", err)
+		}
+	}
+	return enforcer.SavePolicy()
+}
+
+// AssignPermissions 分配角色权限（覆盖原有权限）
+func (s *CasbinServiceImpl) AssignPermissions(role string, permissions []string) error {
+	if role == RoleSuperAdmin {
+		return errors.New("超级管理员自动拥有全部权限, 无需分配")
+	}
+	exists, err := hasRole(role)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("角色不存在: %s", role)
+	}
+	if err := checkPermissions(permissions); err != nil {
+		return err
+	}
+
+	if _, err := enforcer.RemoveFilteredGroupingPolicy(0, role); err != nil {
+		return fmt.Errorf("清除角色权限失败: %w", err)
+	}
+	rules := make([][]string, 0, len(permissions))
+	for _, permission := range slices.Compact(slices.Sorted(slices.Values(permissions))) {
+		rules = append(rules, []string{role, permission})
+	}
+	if len(rules) > 0 {
+		if _, err := enforcer.AddGroupingPolicies(rules); err != nil {
+			return fmt.Errorf("分配角色权限失败: %w", err)
+		}
+	}
+	return enforcer.SavePolicy()
+}
+
+// ListPermissions 获取全部权限
+func (s *CasbinServiceImpl) ListPermissions() ([]*dto.PermissionInfo, error) {
+	policies, err := enforcer.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+	return toPermissions(policies), nil
+}
+
+// GetRolePermissions 获取角色的有效权限（含继承）
+func (s *CasbinServiceImpl) GetRolePermissions(role string) ([]*dto.PermissionInfo, error) {
+	exists, err := hasRole(role)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("角色不存在: %s", role)
+	}
+	policies, err := enforcer.GetImplicitPermissionsForUser(role)
+	if err != nil {
+		return nil, err
+	}
+	return toPermissions(policies), nil
+}
+
+// toPermissions 将策略转换为权限列表
+func toPermissions(policies [][]string) []*dto.PermissionInfo {
+	permissions := make([]*dto.PermissionInfo, 0, len(policies))
+	for _, policy := range policies {
+		if isPermissionPolicy(policy) {
+			permissions = append(permissions, &dto.PermissionInfo{Name: policy[0], Path: policy[1], Method: policy[2]})
+		}
+	}
+	return permissions
+}
+
+// ExportPolicies 导出全部策略与继承关系
+func (s *CasbinServiceImpl) ExportPolicies() (*dto.PolicyData, error) {
+	policies, err := enforcer.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+	groupings, err := enforcer.GetGroupingPolicy()
+	if err != nil {
+		return nil, err
+	}
+	return &dto.PolicyData{Policies: policies, Groupings: groupings}, nil
+}
+
+// ImportPolicies 批量导入策略与继承关系（与现有策略合并，已存在的忽略）
+func (s *CasbinServiceImpl) ImportPolicies(data *dto.PolicyData) error {
+	for _, policy := range data.Policies {
+		if len(policy) != 3 || slices.Contains(policy, "") {
+			return fmt.Errorf("无效的策略: %v", policy)
+		}
+	}
+	for _, grouping := range data.Groupings {
+		if len(grouping) != 2 || slices.Contains(grouping, "") {
+			return fmt.Errorf("无效的继承关系: %v", grouping)
+		}
+	}
+
+	if len(data.Policies) > 0 {
+		if _, err := enforcer.AddPoliciesEx(data.Policies); err != nil {
+			return fmt.Errorf("导入策略失败: %w", err)
+		}
+	}
+	if len(data.Groupings) > 0 {
+		if _, err := enforcer.AddGroupingPoliciesEx(data.Groupings); err != nil {
+			return fmt.Errorf("导入继承关系失败: %w", err)
+		}
+	}
+	return enforcer.SavePolicy()
+}
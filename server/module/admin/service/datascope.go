@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/utils"
+	"gorm.io/gorm"
+)
+
+// DataScopeService 数据权限服务
+type DataScopeService interface {
+	// Resolve 获取管理员的数据权限范围（超级管理员不限制，其他管理员为自身及全部下级）
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @return *utils.DataScope 数据权限范围
+	// @return error 错误
+	Resolve(ctx context.Context, adminID uint) (*utils.DataScope, error)
+}
+
+// DataScopeServiceImpl 数据权限服务实现
+type DataScopeServiceImpl struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+// NewDataScopeService 创建一个数据权限服务
+func NewDataScopeService(db *gorm.DB, logger *slog.Logger) DataScopeService {
+	return &DataScopeServiceImpl{db: db, logger: logger}
+}
+
+// Resolve 获取管理员的数据权限范围
+func (s *DataScopeServiceImpl) Resolve(ctx context.Context, adminID uint) (*utils.DataScope, error) {
+	admin := &models.Admin{}
+	if err := utils.NewGormBuilderFind(ctx, s.db, "id", adminID).WithoutDataScope().Select("id", "type").First(admin); err != nil {
+		return nil, fmt.Errorf("查询管理员失败: %w", err)
+	}
+	if admin.Type == models.AdminTypeSuper {
+		return &utils.DataScope{All: true}, nil
+	}
+
+	ids, err := subordinateIDs(ctx, s.db, admin.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &utils.DataScope{AdminIDs: append([]uint{admin.ID}, ids...)}, nil
+}
+
+// subordinateIDs 按 ParentID 逐层查询全部下级管理员ID（不含自身）
+func subordinateIDs(ctx context.Context, db *gorm.DB, adminID uint) ([]uint, error) {
+	ids := make([]uint, 0)
+	parents := []uint{adminID}
+	for len(parents) > 0 {
+		values := make([]interface{}, 0, len(parents))
+		for _, id := range parents {
+			values = append(values, id)
+		}
+		var children []*models.Admin
+		if err := utils.NewGormBuilder(ctx, db).WithoutDataScope().Select("id").WhereIn("parent_id", values).Find(&children); err != nil {
+			return nil, fmt.Errorf("查询下级管理员失败: %w", err)
+		}
+		parents = parents[:0]
+		for _, child := range children {
+			id := child.ID
+			// 防止层级数据异常形成环
+			if id != adminID && !slices.Contains(ids, id) {
+				ids = append(ids, id)
+				parents = append(parents, id)
+			}
+		}
+	}
+	return ids, nil
+}
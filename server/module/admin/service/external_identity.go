@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/so68/core/cache"
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/module/admin/repo"
+	"github.com/so68/core/server/utils"
+	"gorm.io/gorm"
+)
+
+// ExternalIdentityProvider 外部身份提供方签发的 Token 在第三方账号绑定中使用的登录方式名称
+// 外部账号(sub)须先绑定管理员（admin_identities 表 provider=jwks, subject=sub）才能访问
+const ExternalIdentityProvider = "jwks"
+
+// ExternalIdentityService 外部身份映射服务（外部身份提供方签发的 Token）
+type ExternalIdentityService interface {
+	// Resolve 将外部 Token 的 sub 映射为已绑定的管理员，并为 Token 建立登录会话，用于 utils.JWT 外部身份映射
+	// - 会话按 Token 首次使用时创建，吊销后同一 Token 不再重新建立会话
+	// @param ctx 上下文
+	// @param claims 令牌声明（写入 UserID 与 SessionID）
+	// @return error 错误
+	Resolve(ctx context.Context, claims *utils.Claims) error
+}
+
+// ExternalIdentityServiceImpl 外部身份映射服务实现
+type ExternalIdentityServiceImpl struct {
+	db             *gorm.DB
+	cache          cache.Cache
+	logger         *slog.Logger
+	sessionService SessionService
+	adminRepo      repo.AdminRepo
+	identityRepo   repo.AdminIdentityRepo
+}
+
+// NewExternalIdentityService 创建一个外部身份映射服务
+func NewExternalIdentityService(db *gorm.DB, cache cache.Cache, logger *slog.Logger, sessionService SessionService) ExternalIdentityService {
+	return &ExternalIdentityServiceImpl{
+		db:             db,
+		cache:          cache,
+		logger:         logger,
+		sessionService: sessionService,
+		adminRepo:      repo.NewAdminRepo(),
+		identityRepo:   repo.NewAdminIdentityRepo(),
+	}
+}
+
+// externalSessionKey 外部 Token 会话已建立标记的缓存Key
+func externalSessionKey(sessionID string) string {
+	return "admin:session:external:" + sessionID
+}
+
+// externalSessionID 由外部 Token 的签发者、sub、jti 与签发时间派生会话ID
+func externalSessionID(claims *utils.Claims) string {
+	var issuedAt int64
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Unix()
+	}
+	sum := sha256.Sum256(fmt.Appendf(nil, "%s|%s|%s|%d|%d", claims.Issuer, claims.Subject, claims.ID, issuedAt, claims.ExpiresAt.Unix()))
+	return "ext-" + hex.EncodeToString(sum[:16])
+}
+
+// Resolve 映射外部身份并建立会话
+func (s *ExternalIdentityServiceImpl) Resolve(ctx context.Context, claims *utils.Claims) error {
+	if claims.Subject == "" || claims.ExpiresAt == nil {
+		return errors.New("external token missing sub or exp")
+	}
+	identity, err := s.identityRepo.Find(ctx, utils.NewGormBuilderFind(ctx, s.db, "provider", ExternalIdentityProvider).WhereEqual("subject", claims.Subject))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("external identity not bound")
+	}
+	if err != nil {
+		return fmt.Errorf("查询第三方账号失败: %w", err)
+	}
+	admin, err := s.adminRepo.Find(ctx, utils.NewGormBuilderFind(ctx, s.db, "id", identity.AdminID))
+	if err != nil {
+		return errors.New("external identity not bound")
+	}
+	if admin.Status == models.AdminStatusDisabled {
+		return errors.New("管理员已禁用")
+	}
+	if admin.IsLocked() {
+		return errors.New("管理员已锁定")
+	}
+
+	claims.UserID = admin.ID
+	claims.SessionID = externalSessionID(claims)
+	if s.cache == nil {
+		return nil
+	}
+
+	// 首次使用时建立会话，之后由会话校验判断是否已吊销
+	key := externalSessionKey(claims.SessionID)
+	seen, err := s.cache.Exists(ctx, key)
+	if err != nil {
+		return fmt.Errorf("查询会话失败: %w", err)
+	}
+	if seen {
+		return nil
+	}
+	ttl := time.Until(claims.ExpiresAt.Time)
+	if err := s.sessionService.Create(ctx, admin.ID, &utils.TokenPair{SessionID: claims.SessionID, ExpiresAt: claims.ExpiresAt.Time}, "", claims.Issuer); err != nil {
+		return err
+	}
+	if err := s.cache.Set(ctx, key, admin.ID, ttl); err != nil {
+		return fmt.Errorf("保存会话失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/so68/core/cache"
+	"github.com/so68/core/config"
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/utils"
+)
+
+/*
+外部身份映射测试
+
+本文件用于测试外部身份提供方签发的 Token 映射为本地管理员与会话。
+
+运行命令：
+go test -v -run "^TestExternalIdentityService.*$"
+
+测试内容：
+1. 未绑定的外部账号不能访问 (Resolve)
+2. 已绑定时映射为管理员并建立会话，不信任 Token 中的 user_id
+3. 会话吊销后同一 Token 不再有效 (Resolve, SessionService.Validate)
+4. 禁用的管理员不能访问
+*/
+
+func TestExternalIdentityService_Resolve(t *testing.T) {
+	_, db := newAdminTestService(t)
+	if err := db.Where("1 = 1").Delete(&models.AdminIdentity{}).Error; err != nil {
+		t.Fatalf("clear identities failed: %v", err)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	cacheConfig := &config.CacheConfig{Driver: "memory", CleanupInterval: time.Minute}
+	cacheConfig.SetDefaults()
+	memory, err := cache.NewMemoryCache(cacheConfig, logger)
+	if err != nil {
+		t.Fatalf("create memory cache failed: %v", err)
+	}
+	defer memory.Close()
+
+	sessionService := NewSessionService(memory, logger)
+	s := NewExternalIdentityService(db, memory, logger, sessionService)
+	newClaims := func(subject string) *utils.Claims {
+		return &utils.Claims{External: true, RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "https://idp.example.com",
+			Subject:   subject,
+			ID:        "token-1",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		}}
+	}
+
+	// 未绑定（即使 sub 为已存在的管理员ID）
+	admin := createTestAdmin(t, db, "alice", models.AdminTypeAgent, "agent", 0)
+	if err := s.Resolve(ctx, newClaims(strconv.FormatUint(uint64(admin.ID), 10))); err == nil {
+		t.Fatal("expected unbound identity rejected")
+	}
+
+	// 已绑定
+	if err := db.Create(&models.AdminIdentity{AdminID: admin.ID, Provider: ExternalIdentityProvider, Subject: "alice@idp"}).Error; err != nil {
+		t.Fatalf("bind identity failed: %v", err)
+	}
+	claims := newClaims("alice@idp")
+	if err := s.Resolve(ctx, claims); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if claims.UserID != admin.ID || claims.SessionID == "" {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+	if err := sessionService.Validate(ctx, claims); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	sessions, err := sessionService.List(ctx, admin.ID, "")
+	if err != nil || len(sessions) != 1 {
+		t.Fatalf("expected one session, got %v %v", sessions, err)
+	}
+
+	// 吊销会话后同一 Token 不再重新建立会话
+	if err := sessionService.Revoke(ctx, admin.ID, claims.SessionID); err != nil {
+		t.Fatalf("Revoke failed: %v", err)
+	}
+	claims = newClaims("alice@idp")
+	if err := s.Resolve(ctx, claims); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if err := sessionService.Validate(ctx, claims); err == nil {
+		t.Fatal("expected revoked session rejected")
+	}
+
+	// 禁用的管理员
+	if err := db.Model(admin).Update("status", models.AdminStatusDisabled).Error; err != nil {
+		t.Fatalf("disable admin failed: %v", err)
+	}
+	if err := s.Resolve(ctx, newClaims("alice@idp")); err == nil {
+		t.Fatal("expected disabled admin rejected")
+	}
+}
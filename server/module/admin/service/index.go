@@ -2,16 +2,28 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
 	"time"
 
 	"github.com/so68/core/cache"
+	"github.com/so68/core/captcha"
+	"github.com/so68/core/config"
+	coredb "github.com/so68/core/database"
+	"github.com/so68/core/event"
+	"github.com/so68/core/i18n"
+	"github.com/so68/core/logging"
+	"github.com/so68/core/oauth"
 	"github.com/so68/core/server/database"
 	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/module/admin/events"
 	"github.com/so68/core/server/module/admin/repo"
 	"github.com/so68/core/server/utils"
+	"github.com/so68/core/tenant"
 	"gorm.io/gorm"
 )
 
@@ -20,68 +32,532 @@ type IndexService interface {
 	// Login 管理员登陆
 	// @param ctx 上下文
 	// @param loginIP 登录IP
+	// @param userAgent 登录设备
 	// @param bodyParams 登录参数
 	// @return *dto.LoginResult 登录结果
 	// @return error 错误
-	Login(ctx context.Context, loginIP string, bodyParams *dto.LoginParams) (*dto.LoginResult, error)
+	Login(ctx context.Context, loginIP string, userAgent string, bodyParams *dto.LoginParams) (*dto.LoginResult, error)
+
+	// Refresh 使用刷新令牌换取新的访问令牌与刷新令牌
+	// @param ctx 上下文
+	// @param clientIP 客户端IP
+	// @param bodyParams 刷新参数
+	// @return *dto.RefreshResult 刷新结果
+	// @return error 错误
+	Refresh(ctx context.Context, clientIP string, bodyParams *dto.RefreshParams) (*dto.RefreshResult, error)
+
+	// ExpiredPassword 修改过期密码（校验方式与登录一致，失败同样计入登录失败次数）
+	// @param ctx 上下文
+	// @param loginIP 登录IP
+	// @param userAgent 登录设备
+	// @param bodyParams 修改参数
+	// @return error 错误
+	ExpiredPassword(ctx context.Context, loginIP string, userAgent string, bodyParams *dto.ExpiredPasswordParams) error
+
+	// Captcha 生成登录图形验证码
+	// @param ctx 上下文
+	// @return *captcha.Captcha 验证码
+	// @return error 错误
+	Captcha(ctx context.Context) (*captcha.Captcha, error)
+
+	// OAuthProviders 已启用的第三方登录方式
+	// @return []string 登录方式名称
+	OAuthProviders() []string
+
+	// OAuthAuthorize 生成第三方登录授权地址
+	// @param ctx 上下文
+	// @param params 授权参数
+	// @return *dto.OAuthAuthorizeResult 授权结果
+	// @return error 错误
+	OAuthAuthorize(ctx context.Context, params *dto.OAuthAuthorizeParams) (*dto.OAuthAuthorizeResult, error)
+
+	// OAuthLogin 第三方登录（按绑定关系或已验证邮箱匹配管理员，按配置自动创建管理员）
+	// @param ctx 上下文
+	// @param loginIP 登录IP
+	// @param userAgent 登录设备
+	// @param bodyParams 登录参数
+	// @return *dto.LoginResult 登录结果
+	// @return error 错误
+	OAuthLogin(ctx context.Context, loginIP string, userAgent string, bodyParams *dto.OAuthLoginParams) (*dto.LoginResult, error)
 }
 
 // IndexServiceImpl 首页服务实现
 type IndexServiceImpl struct {
-	jwt       *utils.JWT
-	db        *gorm.DB
-	cache     cache.Cache
-	logger    *slog.Logger
-	adminRepo repo.AdminRepo
+	jwt             *utils.JWT
+	db              *gorm.DB
+	cache           cache.Cache
+	logger          *slog.Logger
+	adminRepo       repo.AdminRepo
+	notifyService   NotifyService
+	sessionService  SessionService
+	loginLogService LoginLogService
+	passwordService PasswordService
+	mfaService      MFAService
+	security        *config.SecurityConfig
+	captcha         captcha.Manager
+	captchaConfig   *config.CaptchaConfig
+	events          *event.Bus
+	oauth           *oauth.Manager
+	identityRepo    repo.AdminIdentityRepo
 }
 
 // NewIndexService 创建一个首页服务
-func NewIndexService(logger *slog.Logger, db *gorm.DB, cache cache.Cache, jwt *utils.JWT) IndexService {
+// - captchaManager 为 nil 时不启用登录图形验证码
+// - oauthManager 为 nil 时不启用第三方登录
+func NewIndexService(logger *slog.Logger, db *gorm.DB, cache cache.Cache, jwt *utils.JWT, notifyService NotifyService, sessionService SessionService, loginLogService LoginLogService, passwordService PasswordService, mfaService MFAService, security *config.SecurityConfig, captchaManager captcha.Manager, captchaConfig *config.CaptchaConfig, events *event.Bus, oauthManager *oauth.Manager) IndexService {
+	if security == nil {
+		security = config.DefaultSecurityConfig()
+	}
 	return &IndexServiceImpl{
-		jwt:       jwt,
-		db:        db,
-		cache:     cache,
-		logger:    logger,
-		adminRepo: repo.NewAdminRepo(),
+		jwt:             jwt,
+		db:              db,
+		cache:           cache,
+		logger:          logger,
+		adminRepo:       repo.NewAdminRepo(),
+		notifyService:   notifyService,
+		sessionService:  sessionService,
+		loginLogService: loginLogService,
+		passwordService: passwordService,
+		mfaService:      mfaService,
+		security:        security,
+		captcha:         captchaManager,
+		captchaConfig:   captchaConfig,
+		events:          events,
+		oauth:           oauthManager,
+		identityRepo:    repo.NewAdminIdentityRepo(),
 	}
 }
 
 // Login 管理员登陆
-func (s *IndexServiceImpl) Login(ctx context.Context, loginIP string, bodyParams *dto.LoginParams) (*dto.LoginResult, error) {
+func (s *IndexServiceImpl) Login(ctx context.Context, loginIP string, userAgent string, bodyParams *dto.LoginParams) (*dto.LoginResult, error) {
+	// 记录每次登录尝试
+	loginLog := &database.AdminLoginLog{Username: bodyParams.Username, IP: loginIP, UserAgent: userAgent}
+	defer func() { s.loginLogService.Record(ctx, loginLog) }()
+
+	admin, err := s.authenticate(ctx, loginIP, userAgent, bodyParams.Username, bodyParams.Password, bodyParams.Code, &bodyParams.CaptchaParams, loginLog)
+	if err != nil {
+		return nil, err
+	}
+
+	// 密码过期后须先修改密码
+	if s.passwordService.Expired(admin) {
+		loginLog.Reason = "密码已过期"
+		return nil, errors.New("密码已过期, 请修改密码后重新登录")
+	}
+
+	return s.completeLogin(ctx, admin, loginIP, userAgent, loginLog)
+}
+
+// completeLogin 认证通过后更新登录信息、签发令牌并记录登录会话
+func (s *IndexServiceImpl) completeLogin(ctx context.Context, admin *database.Admin, loginIP string, userAgent string, loginLog *database.AdminLoginLog) (*dto.LoginResult, error) {
+	// 新设备/新IP登录提醒（首次登录仅记录设备，不提醒）
+	fingerprint := DeviceFingerprint(loginIP, userAgent)
+	if !admin.Data.HasKnownDevice(fingerprint) {
+		if len(admin.Data.KnownDevices) > 0 {
+			s.notifyService.NotifyNewDeviceLogin(admin, loginIP, userAgent)
+		}
+		admin.Data.AddKnownDevice(fingerprint)
+	}
+
+	// 更新管理员登录信息
+	if admin.Status == database.AdminStatusLocked {
+		admin.Status = database.AdminStatusEnabled
+	}
+	admin.LastLoginAt = time.Now()
+	admin.LastLoginIP = loginIP
+	admin.FailedLoginAttempts = 0
+	admin.LockoutCount = 0
+	if err := s.adminRepo.Update(ctx, utils.NewGormBuilder(ctx, s.db), admin); err != nil {
+		logging.FromContext(ctx).Warn("更新管理员登录信息失败", "admin_id", admin.ID, "error", err)
+	}
+
+	publishEvent(ctx, s.events, events.TopicLoginSucceeded, &events.LoginSucceeded{Admin: admin, IP: loginIP, UserAgent: userAgent})
+
+	// 签发令牌（绑定请求的租户）并记录登录会话
+	tenantID, _ := tenant.FromContext(ctx)
+	pair, err := s.jwt.GenerateTenantTokenPair(admin.ID, loginIP, tenantID)
+	if err != nil {
+		loginLog.Reason = "生成令牌失败"
+		return nil, fmt.Errorf("生成令牌失败: %w", err)
+	}
+	if err := s.sessionService.Create(ctx, admin.ID, pair, loginIP, userAgent); err != nil {
+		loginLog.Reason = "记录登录会话失败"
+		return nil, fmt.Errorf("记录登录会话失败: %w", err)
+	}
+	loginLog.Success = true
+
+	// 返回登陆成功数据
+	return &dto.LoginResult{Info: admin, Token: pair.AccessToken, RefreshToken: pair.RefreshToken}, nil
+}
+
+// ExpiredPassword 修改过期密码
+func (s *IndexServiceImpl) ExpiredPassword(ctx context.Context, loginIP string, userAgent string, bodyParams *dto.ExpiredPasswordParams) error {
+	if bodyParams.Password != bodyParams.ConfirmPassword {
+		return errors.New("两次输入的密码不一致")
+	}
+
+	// 与登录一样记录本次尝试
+	loginLog := &database.AdminLoginLog{Username: bodyParams.Username, IP: loginIP, UserAgent: userAgent}
+	defer func() { s.loginLogService.Record(ctx, loginLog) }()
+
+	admin, err := s.authenticate(ctx, loginIP, userAgent, bodyParams.Username, bodyParams.OldPassword, bodyParams.Code, &bodyParams.CaptchaParams, loginLog)
+	if err != nil {
+		return err
+	}
+	if !s.passwordService.Expired(admin) {
+		loginLog.Reason = "密码未过期"
+		return errors.New("密码未过期, 请登录后修改密码")
+	}
+	if err := s.passwordService.Validate(ctx, admin, bodyParams.Password); err != nil {
+		loginLog.Reason = "新密码不符合密码策略"
+		return err
+	}
+
+	if err := admin.GeneratePasswordHash(bodyParams.Password); err != nil {
+		return err
+	}
+	if admin.Status == database.AdminStatusLocked {
+		admin.Status = database.AdminStatusEnabled
+	}
+	admin.PasswordChangedAt = time.Now()
+	admin.FailedLoginAttempts = 0
+	admin.LockoutCount = 0
+	if err := s.adminRepo.Update(ctx, utils.NewGormBuilder(ctx, s.db), admin); err != nil {
+		loginLog.Reason = "修改密码失败"
+		return fmt.Errorf("修改密码失败: %w", err)
+	}
+	if err := s.passwordService.Record(ctx, admin); err != nil {
+		logging.FromContext(ctx).Warn("记录历史密码失败", "admin_id", admin.ID, "error", err)
+	}
+	loginLog.Success, loginLog.Reason = true, "修改过期密码"
+	return nil
+}
+
+// Refresh 使用刷新令牌换取新的访问令牌与刷新令牌
+func (s *IndexServiceImpl) Refresh(ctx context.Context, clientIP string, bodyParams *dto.RefreshParams) (*dto.RefreshResult, error) {
+	claims, err := s.jwt.ParseRefreshToken(bodyParams.RefreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("刷新令牌无效: %w", err)
+	}
+
+	// 管理员被禁用或锁定后不再续签
+	admin, err := s.adminRepo.Find(ctx, utils.NewGormBuilderFind(ctx, s.db, "id", claims.UserID))
+	if err != nil {
+		return nil, fmt.Errorf("查询管理员失败: %w", err)
+	}
+	if admin.Status == database.AdminStatusDisabled || admin.IsLocked() {
+		if err := s.jwt.RevokeUserRefreshTokens(admin.ID); err != nil {
+			logging.FromContext(ctx).Warn("吊销刷新令牌失败", "admin_id", admin.ID, "error", err)
+		}
+		return nil, errors.New("管理员已禁用或锁定, 请重新登录")
+	}
+
+	pair, err := s.jwt.RefreshToken(bodyParams.RefreshToken, clientIP)
+	if err != nil {
+		return nil, fmt.Errorf("刷新令牌失败: %w", err)
+	}
+	if err := s.sessionService.Touch(ctx, admin.ID, pair, clientIP); err != nil {
+		logging.FromContext(ctx).Warn("更新登录会话失败", "admin_id", admin.ID, "error", err)
+	}
+	return &dto.RefreshResult{Token: pair.AccessToken, RefreshToken: pair.RefreshToken}, nil
+}
+
+// Captcha 生成登录图形验证码
+func (s *IndexServiceImpl) Captcha(ctx context.Context) (*captcha.Captcha, error) {
+	if !s.captchaEnabled() {
+		return nil, errors.New("未启用图形验证码")
+	}
+	return s.captcha.Generate(ctx)
+}
+
+// OAuthProviders 已启用的第三方登录方式
+func (s *IndexServiceImpl) OAuthProviders() []string {
+	if s.oauth == nil {
+		return []string{}
+	}
+	return s.oauth.Providers()
+}
+
+// OAuthAuthorize 生成第三方登录授权地址
+func (s *IndexServiceImpl) OAuthAuthorize(ctx context.Context, params *dto.OAuthAuthorizeParams) (*dto.OAuthAuthorizeResult, error) {
+	if s.oauth == nil {
+		return nil, errors.New("未启用第三方登录")
+	}
+	authURL, binding, err := s.oauth.AuthCodeURL(ctx, params.Provider)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.OAuthAuthorizeResult{URL: authURL, Binding: binding}, nil
+}
+
+// OAuthLogin 第三方登录
+func (s *IndexServiceImpl) OAuthLogin(ctx context.Context, loginIP string, userAgent string, bodyParams *dto.OAuthLoginParams) (*dto.LoginResult, error) {
+	if s.oauth == nil {
+		return nil, errors.New("未启用第三方登录")
+	}
+	loginLog := &database.AdminLoginLog{Username: bodyParams.Provider, IP: loginIP, UserAgent: userAgent}
+	defer func() { s.loginLogService.Record(ctx, loginLog) }()
+
+	user, err := s.oauth.Exchange(ctx, bodyParams.Provider, bodyParams.State, bodyParams.Binding, bodyParams.AuthCode)
+	if err != nil {
+		loginLog.Reason = "第三方登录失败"
+		return nil, err
+	}
+	loginLog.Username = user.Provider + ":" + user.Subject
+
+	admin, err := s.oauthAdmin(ctx, user)
+	if err != nil {
+		loginLog.Reason = "第三方账号未绑定管理员"
+		return nil, err
+	}
+	loginLog.AdminID, loginLog.Username, loginLog.MFAEnabled = admin.ID, admin.Username, admin.IsMFAEnabled
+
+	if admin.Status == database.AdminStatusDisabled {
+		loginLog.Reason = "管理员已禁用"
+		return nil, errors.New("管理员已禁用")
+	}
+	if admin.IsLocked() {
+		loginLog.Reason = "管理员已锁定"
+		return nil, i18n.Errorf("管理员已锁定,请联系管理员解锁! 锁定截止时间: %s", admin.LockedUntil.Format(time.DateTime))
+	}
+	if admin.IsMFAEnabled {
+		if !s.mfaService.Check(ctx, admin, bodyParams.Code) {
+			loginLog.Reason = "MFA 验证失败"
+			return nil, errors.New("-Google Authenticator 验证失败, 请重新输入")
+		}
+		loginLog.MFAPassed = true
+	}
+
+	return s.completeLogin(ctx, admin, loginIP, userAgent, loginLog)
+}
+
+// oauthAdmin 查找第三方账号对应的管理员
+// - 已绑定时按绑定关系查找
+// - 未绑定且登录方式开启 linkByEmail 时，按已验证的邮箱匹配管理员并绑定（超级管理员不自动绑定，防止通过第三方邮箱接管账号）
+// - 均未匹配且启用自动创建时，以默认角色与层级创建管理员并绑定（不能为超级管理员，数据范围仅限自身）
+func (s *IndexServiceImpl) oauthAdmin(ctx context.Context, user *oauth.User) (*database.Admin, error) {
+	identity, err := s.identityRepo.Find(ctx, utils.NewGormBuilderFind(ctx, s.db, "provider", user.Provider).WhereEqual("subject", user.Subject))
+	if err == nil {
+		admin, err := s.adminRepo.Find(ctx, utils.NewGormBuilderFind(ctx, s.db, "id", identity.AdminID))
+		if err != nil {
+			return nil, fmt.Errorf("查询管理员失败: %w", err)
+		}
+		if identity.Email != user.Email || identity.Name != user.Name {
+			identity.Email, identity.Name = user.Email, user.Name
+			if err := s.identityRepo.Update(ctx, utils.NewGormBuilder(ctx, s.db), identity); err != nil {
+				logging.FromContext(ctx).Warn("更新第三方账号信息失败", "admin_id", admin.ID, "error", err)
+			}
+		}
+		return admin, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("查询第三方账号失败: %w", err)
+	}
+
+	identity = &database.AdminIdentity{Provider: user.Provider, Subject: user.Subject, Email: user.Email, Name: user.Name}
+	cfg := s.oauth.Config()
+	if provider := cfg.Providers[user.Provider]; provider != nil && provider.LinkByEmail && user.Email != "" && user.EmailVerified {
+		if admin, err := s.adminRepo.Find(ctx, utils.NewGormBuilderFind(ctx, s.db, "email", user.Email)); err == nil {
+			if admin.Type == database.AdminTypeSuper {
+				return nil, errors.New("超级管理员不能通过邮箱自动绑定第三方账号")
+			}
+			identity.AdminID = admin.ID
+			if err := s.identityRepo.Create(ctx, utils.NewGormBuilder(ctx, s.db), identity); err != nil {
+				return nil, fmt.Errorf("绑定第三方账号失败: %w", err)
+			}
+			return admin, nil
+		}
+	}
+
+	if !cfg.AutoProvision {
+		return nil, errors.New("该第三方账号未绑定管理员")
+	}
+	if cfg.DefaultRole == "" || cfg.DefaultRole == RoleSuperAdmin {
+		return nil, errors.New("自动创建管理员的默认角色无效")
+	}
+	adminType := cfg.DefaultType
+	if adminType == 0 {
+		adminType = database.AdminTypeAgent
+	}
+	if adminType != database.AdminTypeMerchant && adminType != database.AdminTypeAgent {
+		return nil, errors.New("自动创建管理员的默认层级无效")
+	}
+	password := make([]byte, 24)
+	if _, err := rand.Read(password); err != nil {
+		return nil, fmt.Errorf("生成管理员密码失败: %w", err)
+	}
+	admin := &database.Admin{
+		Username:          user.Provider + "_" + user.Subject,
+		Nickname:          user.Name,
+		Avatar:            user.Avatar,
+		PasswordHash:      hex.EncodeToString(password), // 仅可通过第三方登录，需要密码登录时由上级重置密码
+		PasswordChangedAt: time.Now(),
+		Status:            database.AdminStatusEnabled,
+		Type:              adminType,
+		Role:              cfg.DefaultRole,
+	}
+	if admin.Nickname == "" {
+		admin.Nickname = admin.Username
+	}
+	if user.EmailVerified {
+		admin.Email = user.Email
+	}
+	err = coredb.Transaction(ctx, s.db, func(tx *gorm.DB) error {
+		txCtx := tx.Statement.Context
+		if err := s.adminRepo.Create(txCtx, utils.NewGormBuilder(txCtx, s.db), admin); err != nil {
+			return fmt.Errorf("创建管理员失败: %w", err)
+		}
+		identity.AdminID = admin.ID
+		if err := s.identityRepo.Create(txCtx, utils.NewGormBuilder(txCtx, s.db), identity); err != nil {
+			return fmt.Errorf("绑定第三方账号失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	publishEvent(ctx, s.events, events.TopicAdminCreated, &events.AdminCreated{Admin: admin})
+	return admin, nil
+}
+
+// authenticate 校验图形验证码后校验管理员凭据，并按IP与账号统计失败次数（失败次数达到阈值后要求图形验证码）
+func (s *IndexServiceImpl) authenticate(ctx context.Context, loginIP string, userAgent string, username string, password string, code string, captchaParams *dto.CaptchaParams, loginLog *database.AdminLoginLog) (*database.Admin, error) {
+	if s.captchaRequired(ctx, loginIP, username) && !s.captcha.Verify(ctx, captchaParams.CaptchaID, captchaParams.CaptchaCode) {
+		loginLog.Reason = "验证码错误"
+		return nil, errors.New("请输入正确的图形验证码")
+	}
+
+	admin, err := s.checkCredentials(ctx, loginIP, userAgent, username, password, code, loginLog)
+	if err != nil {
+		s.recordCaptchaFailure(ctx, loginIP, username)
+		return nil, err
+	}
+	s.clearCaptchaFailure(ctx, username)
+	return admin, nil
+}
+
+// checkCredentials 校验管理员账号、密码与 MFA 验证码（密码错误累计失败次数，达到上限后锁定，再次锁定时长按倍数递增）
+func (s *IndexServiceImpl) checkCredentials(ctx context.Context, loginIP string, userAgent string, username string, password string, code string, loginLog *database.AdminLoginLog) (*database.Admin, error) {
 	// 查询管理员
-	admin, err := s.adminRepo.Find(ctx, utils.NewGormBuilderFind(ctx, s.db, "username", bodyParams.Username))
+	admin, err := s.adminRepo.Find(ctx, usernameBuilder(ctx, s.db, username))
 	if err != nil {
+		loginLog.Reason = "管理员不存在"
+		publishEvent(ctx, s.events, events.TopicLoginFailed, &events.LoginFailed{Username: username, IP: loginIP, UserAgent: userAgent})
 		return nil, fmt.Errorf("查询管理员失败: %w", err)
 	}
+	loginLog.AdminID = admin.ID
+	loginLog.MFAEnabled = admin.IsMFAEnabled
 
 	// 检查管理员是否锁定
 	if admin.IsLocked() {
-		return nil, fmt.Errorf("管理员已锁定,请联系管理员解锁! 锁定截止时间: %s", admin.LockedUntil.Format(time.DateTime))
+		loginLog.Reason = "管理员已锁定"
+		return nil, i18n.Errorf("管理员已锁定,请联系管理员解锁! 锁定截止时间: %s", admin.LockedUntil.Format(time.DateTime))
 	}
 
 	// 检查管理员密码是否正确
-	if !admin.CompareHashAndPassword(bodyParams.Password) {
-		updateAdmin := &database.Admin{}
-		updateAdmin.FailedLoginAttempts += 1
+	if !admin.CompareHashAndPassword(password) {
+		admin.FailedLoginAttempts += 1
 
-		// 检查管理员是否多次登录失败, 锁定 5 分钟
-		if admin.FailedLoginAttempts >= 5 {
-			updateAdmin.Status = database.AdminStatusLocked
-			updateAdmin.LockedUntil = time.Now().Add(time.Minute * 5)
+		// 检查管理员是否多次登录失败
+		locked := s.security.MaxLoginAttempts > 0 && int(admin.FailedLoginAttempts) >= s.security.MaxLoginAttempts
+		if locked {
+			admin.LockoutCount += 1
+			admin.Status = database.AdminStatusLocked
+			admin.LockedUntil = time.Now().Add(s.security.LockoutDurationFor(admin.LockoutCount))
+			admin.FailedLoginAttempts = 0
+		}
+		if err := s.adminRepo.Update(ctx, utils.NewGormBuilder(ctx, s.db), admin); err != nil {
+			logging.FromContext(ctx).Warn("更新管理员登录失败次数失败", "admin_id", admin.ID, "error", err)
 		}
-		s.adminRepo.Update(ctx, utils.NewGormBuilderFind(ctx, s.db, "id", admin.ID), updateAdmin)
-		return nil, fmt.Errorf("账号或密码错误, 请重新输入! 剩余 %d 次机会", 5-admin.FailedLoginAttempts)
+		loginLog.Reason, loginLog.Locked = "密码错误", locked
+		publishEvent(ctx, s.events, events.TopicLoginFailed, &events.LoginFailed{Username: admin.Username, AdminID: admin.ID, IP: loginIP, UserAgent: userAgent, Locked: locked})
+		if locked {
+			s.notifyService.NotifyLockout(admin, loginIP)
+			return nil, i18n.Errorf("管理员已锁定,请联系管理员解锁! 锁定截止时间: %s", admin.LockedUntil.Format(time.DateTime))
+		}
+		if s.security.MaxLoginAttempts <= 0 {
+			return nil, i18n.Errorf("账号或密码错误, 请重新输入!")
+		}
+		return nil, i18n.Errorf("账号或密码错误, 请重新输入! 剩余 %d 次机会", s.security.MaxLoginAttempts-int(admin.FailedLoginAttempts))
 	}
 
-	// 是否开启Google Authenticator 验证
-	if admin.IsMFAEnabled && !admin.VerifyGoogleAuthCode(bodyParams.Code) {
-		return nil, errors.New("-Google Authenticator 验证失败, 请重新输入")
+	// 是否开启Google Authenticator 验证（验证器不可用时可使用恢复码）
+	if admin.IsMFAEnabled {
+		if !s.mfaService.Check(ctx, admin, code) {
+			loginLog.Reason = "MFA 验证失败"
+			return nil, errors.New("-Google Authenticator 验证失败, 请重新输入")
+		}
+		loginLog.MFAPassed = true
 	}
 
-	// 更新管理员登录信息
-	updateAdmin := &database.Admin{LastLoginAt: time.Now(), LastLoginIP: loginIP, FailedLoginAttempts: 0}
-	s.adminRepo.Update(ctx, utils.NewGormBuilderFind(ctx, s.db, "id", admin.ID), updateAdmin)
+	return admin, nil
+}
 
-	// 返回登陆成功数据
-	return &dto.LoginResult{Info: admin, Token: s.jwt.GenerateToken(admin.ID, loginIP)}, nil
+// captchaEnabled 是否启用登录图形验证码
+func (s *IndexServiceImpl) captchaEnabled() bool {
+	return s.captcha != nil && s.cache != nil && s.captchaConfig != nil && s.captchaConfig.Enabled
+}
+
+// captchaRequired 本次登录是否需要图形验证码（IP或账号在统计窗口内失败次数达到阈值）
+func (s *IndexServiceImpl) captchaRequired(ctx context.Context, loginIP string, username string) bool {
+	if !s.captchaEnabled() {
+		return false
+	}
+	if s.captchaConfig.FailedThreshold <= 0 {
+		return true
+	}
+	for _, key := range []string{captchaFailureKey("ip", loginIP), captchaFailureKey("user", username)} {
+		value, err := s.cache.Get(ctx, key)
+		if err != nil || value == "" {
+			continue
+		}
+		if count, _ := strconv.Atoi(value); count >= s.captchaConfig.FailedThreshold {
+			return true
+		}
+	}
+	return false
+}
+
+// recordCaptchaFailure 累计IP与账号的登录失败次数（首次失败时设置统计窗口）
+func (s *IndexServiceImpl) recordCaptchaFailure(ctx context.Context, loginIP string, username string) {
+	if !s.captchaEnabled() || s.captchaConfig.FailedThreshold <= 0 {
+		return
+	}
+	for _, key := range []string{captchaFailureKey("ip", loginIP), captchaFailureKey("user", username)} {
+		count, err := s.cache.Increment(ctx, key, 1)
+		if err != nil {
+			logging.FromContext(ctx).Warn("记录登录失败次数失败", "key", key, "error", err)
+			continue
+		}
+		if count == 1 {
+			if err := s.cache.Expire(ctx, key, s.captchaConfig.FailureWindow); err != nil {
+				logging.FromContext(ctx).Warn("设置登录失败次数有效期失败", "key", key, "error", err)
+			}
+		}
+	}
+}
+
+// clearCaptchaFailure 登录成功后清除账号的登录失败次数（IP失败次数保留至统计窗口结束）
+func (s *IndexServiceImpl) clearCaptchaFailure(ctx context.Context, username string) {
+	if !s.captchaEnabled() || s.captchaConfig.FailedThreshold <= 0 {
+		return
+	}
+	if err := s.cache.Delete(ctx, captchaFailureKey("user", username)); err != nil {
+		logging.FromContext(ctx).Warn("清除登录失败次数失败", "username", username, "error", err)
+	}
+}
+
+// captchaFailureKey 登录失败次数缓存键
+func captchaFailureKey(kind string, value string) string {
+	return "captcha:login:" + kind + ":" + value
+}
+
+// publishEvent 发布事件（未启用事件总线时忽略，发布失败仅记录日志）
+func publishEvent[T any](ctx context.Context, bus *event.Bus, topic event.Topic[T], payload T) {
+	if bus == nil {
+		return
+	}
+	if err := event.Publish(ctx, bus, topic, payload); err != nil {
+		logging.FromContext(ctx).Warn("发布事件失败", "topic", topic.Name(), "error", err)
+	}
 }
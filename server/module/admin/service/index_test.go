@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/so68/core/cache"
+	"github.com/so68/core/captcha"
+	"github.com/so68/core/config"
+	"github.com/so68/core/oauth"
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/utils"
+	"github.com/so68/core/tenant"
+	"gorm.io/gorm"
+)
+
+/*
+登录测试
+
+本文件用于测试登录失败次数达到阈值后要求图形验证码，以及第三方登录的账号匹配。
+
+运行命令：
+go test -v -run "^TestIndexService.*$"
+
+测试内容：
+1. 未达到阈值时无需验证码
+2. 账号失败次数达到阈值后要求验证码 (authenticate)
+3. 登录成功后清除账号失败次数，IP失败次数保留
+4. 第三方登录在开启 linkByEmail 时按已验证邮箱绑定管理员（超级管理员除外），绑定后按第三方账号登录，state 须由发起授权的浏览器提交 (OAuthLogin)
+5. 未绑定时按配置自动创建管理员（不能为超级管理员），禁用的管理员不能登录
+6. 租户请求中只能登录该租户的管理员，未指定租户时只能登录不属于租户的同名管理员，签发的 Token 绑定请求的租户 (Login)
+*/
+
+func TestIndexService_Captcha(t *testing.T) {
+	_, db := newAdminTestService(t)
+	createTestAdmin(t, db, "root", models.AdminTypeSuper, RoleSuperAdmin, 0)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	cacheConfig := &config.CacheConfig{Driver: "memory", CleanupInterval: time.Minute}
+	cacheConfig.SetDefaults()
+	memory, err := cache.NewMemoryCache(cacheConfig, logger)
+	if err != nil {
+		t.Fatalf("create memory cache failed: %v", err)
+	}
+	defer memory.Close()
+
+	captchaConfig := config.DefaultCaptchaConfig()
+	captchaConfig.Enabled, captchaConfig.FailedThreshold = true, 2
+	manager, err := captcha.NewManager(captchaConfig, memory, logger)
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	s := NewIndexService(logger, db, memory, nil, nil, NewSessionService(memory, logger), NewLoginLogService(db, logger), NewPasswordService(db, nil, logger), NewMFAService(db, "core", logger), &config.SecurityConfig{}, manager, captchaConfig, nil, nil).(*IndexServiceImpl)
+
+	login := func(ip string, password string, params *dto.CaptchaParams) (*models.AdminLoginLog, error) {
+		loginLog := &models.AdminLoginLog{}
+		_, err := s.authenticate(ctx, ip, "test", "root", password, "", params, loginLog)
+		return loginLog, err
+	}
+	solve := func() *dto.CaptchaParams {
+		c, err := s.Captcha(ctx)
+		if err != nil {
+			t.Fatalf("Captcha failed: %v", err)
+		}
+		answer, _ := memory.Get(ctx, "captcha:"+c.ID)
+		return &dto.CaptchaParams{CaptchaID: c.ID, CaptchaCode: answer}
+	}
+
+	// 未达到阈值时密码错误仅提示密码错误
+	for range 2 {
+		if _, err := login("10.0.0.1", "wrong", &dto.CaptchaParams{}); err == nil || !strings.Contains(err.Error(), "账号或密码错误") {
+			t.Fatalf("expected password error, got %v", err)
+		}
+	}
+
+	// 达到阈值后，即使密码正确也必须提供验证码（其他IP同样要求，因为账号失败次数已达到阈值）
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2"} {
+		loginLog, err := login(ip, "password123", &dto.CaptchaParams{})
+		if err == nil || loginLog.Reason != "验证码错误" {
+			t.Fatalf("expected captcha error from %s, got %v", ip, err)
+		}
+	}
+	if _, err := login("10.0.0.1", "password123", &dto.CaptchaParams{CaptchaID: "unknown", CaptchaCode: "1234"}); err == nil {
+		t.Fatal("expected captcha error with unknown captcha")
+	}
+	if _, err := login("10.0.0.1", "password123", solve()); err != nil {
+		t.Fatalf("expected login with captcha to succeed, got %v", err)
+	}
+
+	// 登录成功后清除账号失败次数，但原IP失败次数仍在统计窗口内
+	if _, err := login("10.0.0.2", "password123", &dto.CaptchaParams{}); err != nil {
+		t.Fatalf("expected login from new IP without captcha, got %v", err)
+	}
+	if _, err := login("10.0.0.1", "password123", &dto.CaptchaParams{}); err == nil {
+		t.Fatal("expected captcha required for failed IP")
+	}
+}
+
+// staticOAuthProvider 返回固定账号的第三方登录方式
+type staticOAuthProvider struct {
+	user *oauth.User
+}
+
+func (p *staticOAuthProvider) AuthCodeURL(ctx context.Context, state string) (string, error) {
+	return "https://idp.example.com/authorize?state=" + state, nil
+}
+
+func (p *staticOAuthProvider) Exchange(ctx context.Context, code string) (*oauth.User, error) {
+	user := *p.user
+	return &user, nil
+}
+
+func TestIndexService_OAuthLogin(t *testing.T) {
+	_, db := newAdminTestService(t)
+	if err := db.Session(&gorm.Session{AllowGlobalUpdate: true}).Unscoped().Delete(&models.AdminIdentity{}).Error; err != nil {
+		t.Fatalf("clear identities failed: %v", err)
+	}
+	root := createTestAdmin(t, db, "root", models.AdminTypeSuper, RoleSuperAdmin, 0)
+	if err := db.Model(root).Update("email", "root@example.com").Error; err != nil {
+		t.Fatalf("update admin email failed: %v", err)
+	}
+	merchant := createTestAdmin(t, db, "merchant", models.AdminTypeMerchant, RoleMerchant, root.ID)
+	if err := db.Model(merchant).Update("email", "merchant@example.com").Error; err != nil {
+		t.Fatalf("update admin email failed: %v", err)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	cacheConfig := &config.CacheConfig{Driver: "memory", CleanupInterval: time.Minute}
+	cacheConfig.SetDefaults()
+	memory, err := cache.NewMemoryCache(cacheConfig, logger)
+	if err != nil {
+		t.Fatalf("create memory cache failed: %v", err)
+	}
+	defer memory.Close()
+
+	authConfig := config.DefaultAuthConfig()
+	manager, err := oauth.NewManager(authConfig, memory, logger)
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	provider := &staticOAuthProvider{}
+	manager.Register("corp", provider)
+	s := NewIndexService(logger, db, memory, utils.NewJWT("secret", time.Hour), NewNotifyService("core", nil, logger), NewSessionService(memory, logger), NewLoginLogService(db, logger), NewPasswordService(db, nil, logger), NewMFAService(db, "core", logger), &config.SecurityConfig{}, nil, nil, nil, manager)
+
+	if providers := s.OAuthProviders(); len(providers) != 1 || providers[0] != "corp" {
+		t.Fatalf("OAuthProviders() = %v", providers)
+	}
+	login := func(user *oauth.User) (*dto.LoginResult, error) {
+		provider.user = user
+		authorize, err := s.OAuthAuthorize(ctx, &dto.OAuthAuthorizeParams{Provider: "corp"})
+		if err != nil {
+			t.Fatalf("OAuthAuthorize failed: %v", err)
+		}
+		state := strings.TrimPrefix(authorize.URL, "https://idp.example.com/authorize?state=")
+		return s.OAuthLogin(ctx, "10.0.0.1", "test", &dto.OAuthLoginParams{Provider: "corp", AuthCode: "code", State: state, Binding: authorize.Binding})
+	}
+
+	// 未携带发起授权时的浏览器标识时拒绝登录
+	provider.user = &oauth.User{Subject: "u1", Email: "root@example.com", EmailVerified: true}
+	authorize, err := s.OAuthAuthorize(ctx, &dto.OAuthAuthorizeParams{Provider: "corp"})
+	if err != nil {
+		t.Fatalf("OAuthAuthorize failed: %v", err)
+	}
+	state := strings.TrimPrefix(authorize.URL, "https://idp.example.com/authorize?state=")
+	if _, err := s.OAuthLogin(ctx, "10.0.0.1", "test", &dto.OAuthLoginParams{Provider: "corp", AuthCode: "code", State: state}); !errors.Is(err, oauth.ErrInvalidState) {
+		t.Fatalf("expected invalid state without binding, got %v", err)
+	}
+
+	// 登录方式未开启 linkByEmail 时不按邮箱匹配管理员
+	if _, err := login(&oauth.User{Subject: "u1", Email: "merchant@example.com", EmailVerified: true}); err == nil || !strings.Contains(err.Error(), "未绑定管理员") {
+		t.Fatalf("expected unbound error without linkByEmail, got %v", err)
+	}
+	authConfig.Providers["corp"] = &config.AuthProviderConfig{LinkByEmail: true}
+	// 未验证的邮箱不匹配管理员
+	if _, err := login(&oauth.User{Subject: "u1", Email: "merchant@example.com"}); err == nil || !strings.Contains(err.Error(), "未绑定管理员") {
+		t.Fatalf("expected unbound error for unverified email, got %v", err)
+	}
+	// 超级管理员不按邮箱自动绑定
+	if _, err := login(&oauth.User{Subject: "u0", Email: "root@example.com", EmailVerified: true}); err == nil || !strings.Contains(err.Error(), "超级管理员不能通过邮箱自动绑定") {
+		t.Fatalf("expected super admin not linked by email, got %v", err)
+	}
+	// 已验证的邮箱匹配管理员并绑定，之后邮箱变更仍按第三方账号登录
+	result, err := login(&oauth.User{Subject: "u1", Email: "merchant@example.com", EmailVerified: true, Name: "Merchant"})
+	if err != nil {
+		t.Fatalf("OAuthLogin failed: %v", err)
+	}
+	if result.Info.ID != merchant.ID || result.Token == "" {
+		t.Fatalf("unexpected login result: %+v", result.Info)
+	}
+	if result, err = login(&oauth.User{Subject: "u1", Email: "changed@example.com", EmailVerified: true}); err != nil || result.Info.ID != merchant.ID {
+		t.Fatalf("expected login by identity, got %v", err)
+	}
+	identity := &models.AdminIdentity{}
+	if err := db.Where("provider = ? AND subject = ?", "corp", "u1").First(identity).Error; err != nil || identity.AdminID != merchant.ID || identity.Email != "changed@example.com" {
+		t.Fatalf("unexpected identity: %+v, err = %v", identity, err)
+	}
+
+	// 自动创建管理员
+	authConfig.AutoProvision, authConfig.DefaultRole = true, RoleSuperAdmin
+	if _, err := login(&oauth.User{Subject: "u2", Name: "Alice"}); err == nil || !strings.Contains(err.Error(), "默认角色无效") {
+		t.Fatalf("expected super admin role rejected, got %v", err)
+	}
+	authConfig.DefaultRole = "viewer"
+	result, err = login(&oauth.User{Subject: "u2", Email: "alice@example.com", EmailVerified: true, Name: "Alice"})
+	if err != nil {
+		t.Fatalf("OAuthLogin with auto provision failed: %v", err)
+	}
+	if result.Info.Username != "corp_u2" || result.Info.Role != "viewer" || result.Info.Email != "alice@example.com" || result.Info.Nickname != "Alice" {
+		t.Fatalf("unexpected provisioned admin: %+v", result.Info)
+	}
+	// 自动创建的管理员默认为代理管理员，数据范围仅限自身
+	if result.Info.Type != models.AdminTypeAgent {
+		t.Fatalf("provisioned admin type = %d, want agent", result.Info.Type)
+	}
+	scope, err := NewDataScopeService(db, logger).Resolve(ctx, result.Info.ID)
+	if err != nil || scope.All {
+		t.Fatalf("provisioned admin should not see all data: %+v, err = %v", scope, err)
+	}
+	authConfig.DefaultType = models.AdminTypeSuper
+	if _, err := login(&oauth.User{Subject: "u3", Name: "Bob"}); err == nil || !strings.Contains(err.Error(), "默认层级无效") {
+		t.Fatalf("expected super admin type rejected, got %v", err)
+	}
+
+	// 禁用的管理员不能登录
+	if err := db.Model(&models.Admin{}).Where("id = ?", result.Info.ID).Update("status", models.AdminStatusDisabled).Error; err != nil {
+		t.Fatalf("disable admin failed: %v", err)
+	}
+	if _, err := login(&oauth.User{Subject: "u2"}); err == nil || !strings.Contains(err.Error(), "管理员已禁用") {
+		t.Fatalf("expected disabled error, got %v", err)
+	}
+}
+
+func TestIndexService_TenantLogin(t *testing.T) {
+	_, db := newAdminTestService(t)
+	admin := createTestAdmin(t, db, "root", models.AdminTypeSuper, RoleSuperAdmin, 0)
+	if err := db.Model(admin).Update("tenant_id", "acme").Error; err != nil {
+		t.Fatalf("update admin tenant failed: %v", err)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	cacheConfig := &config.CacheConfig{Driver: "memory", CleanupInterval: time.Minute}
+	cacheConfig.SetDefaults()
+	memory, err := cache.NewMemoryCache(cacheConfig, logger)
+	if err != nil {
+		t.Fatalf("create memory cache failed: %v", err)
+	}
+	defer memory.Close()
+
+	jwt := utils.NewJWT("secret", time.Hour)
+	s := NewIndexService(logger, db, memory, jwt, NewNotifyService("core", nil, logger), NewSessionService(memory, logger), NewLoginLogService(db, logger), NewPasswordService(db, nil, logger), NewMFAService(db, "core", logger), &config.SecurityConfig{}, nil, nil, nil, nil)
+	params := &dto.LoginParams{Username: "root", Password: "password123"}
+	platform := createTestAdmin(t, db, "root", models.AdminTypeSuper, RoleSuperAdmin, 0)
+
+	// 未指定租户时仅匹配不属于租户的同名管理员
+	result, err := s.Login(context.Background(), "10.0.0.1", "test", params)
+	if err != nil || result.Info.ID != platform.ID {
+		t.Fatalf("expected login as platform admin, got %v", err)
+	}
+
+	// 其他租户的请求中查询不到该管理员
+	if _, err := s.Login(tenant.WithID(context.Background(), "other"), "10.0.0.1", "test", params); err == nil {
+		t.Fatalf("expected login rejected in other tenant, got %v", err)
+	}
+
+	result, err = s.Login(tenant.WithID(context.Background(), "acme"), "10.0.0.1", "test", params)
+	if err != nil || result.Info.ID != admin.ID {
+		t.Fatalf("Login failed: %v", err)
+	}
+	claims, err := jwt.ParseToken(result.Token)
+	if err != nil {
+		t.Fatalf("ParseToken failed: %v", err)
+	}
+	if claims.TenantID != "acme" {
+		t.Errorf("token tenant = %q, want acme", claims.TenantID)
+	}
+}
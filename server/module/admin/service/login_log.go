@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/so68/core/logging"
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/module/admin/repo"
+	"github.com/so68/core/server/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	loginLogRecentDefault = 10 // 最近登录默认条数
+	loginLogRecentMax     = 50 // 最近登录最大条数
+)
+
+// LoginLogService 管理员登录日志服务
+type LoginLogService interface {
+	// Record 记录登录尝试（记录失败仅输出日志，不影响登录）
+	// @param ctx 上下文
+	// @param log 登录日志
+	Record(ctx context.Context, log *models.AdminLoginLog)
+	// List 分页查询登录日志（按数据权限范围过滤）
+	// @param ctx 上下文
+	// @param params 查询参数
+	// @return *utils.PageResp 分页结果
+	// @return error 错误
+	List(ctx context.Context, params *dto.LoginLogIndexParams) (*utils.PageResp, error)
+	// Recent 获取管理员最近的登录记录
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @param limit 条数
+	// @return []*models.AdminLoginLog 登录记录
+	// @return error 错误
+	Recent(ctx context.Context, adminID uint, limit int) ([]*models.AdminLoginLog, error)
+}
+
+// LoginLogServiceImpl 管理员登录日志服务实现
+type LoginLogServiceImpl struct {
+	db           *gorm.DB
+	logger       *slog.Logger
+	loginLogRepo repo.AdminLoginLogRepo
+}
+
+// NewLoginLogService 创建一个管理员登录日志服务
+func NewLoginLogService(db *gorm.DB, logger *slog.Logger) LoginLogService {
+	return &LoginLogServiceImpl{db: db, logger: logger, loginLogRepo: repo.NewAdminLoginLogRepo()}
+}
+
+// Record 记录登录尝试
+func (s *LoginLogServiceImpl) Record(ctx context.Context, log *models.AdminLoginLog) {
+	if len(log.UserAgent) > 255 {
+		log.UserAgent = strings.ToValidUTF8(log.UserAgent[:255], "")
+	}
+	if err := s.loginLogRepo.Create(ctx, utils.NewGormBuilder(ctx, s.db), log); err != nil {
+		logging.FromContext(ctx).Warn("记录登录日志失败", "username", log.Username, "error", err)
+	}
+}
+
+// List 分页查询登录日志
+func (s *LoginLogServiceImpl) List(ctx context.Context, params *dto.LoginLogIndexParams) (*utils.PageResp, error) {
+	if params.Sort == "" {
+		params.Sort, params.Order = "id", "DESC"
+	}
+	builder := utils.NewGormBuilderWithPage(ctx, s.db.Model(&models.AdminLoginLog{}), &params.Page, "id", "admin_id", "username", "ip", "created_at")
+	if params.AdminID > 0 {
+		builder.WhereEqual("admin_id", params.AdminID)
+	}
+	if params.Username != "" {
+		builder.WhereEqual("username", params.Username)
+	}
+	if params.IP != "" {
+		builder.WhereEqual("ip", params.IP)
+	}
+	if params.Success != nil {
+		builder.WhereEqual("success", *params.Success)
+	}
+	if params.StartAt > 0 {
+		builder.WhereGreaterThanEqual("created_at", time.Unix(params.StartAt, 0))
+	}
+	if params.EndAt > 0 {
+		builder.WhereLessThan("created_at", time.Unix(params.EndAt, 0))
+	}
+
+	result, err := s.loginLogRepo.FindListWithPage(ctx, builder)
+	if err != nil {
+		return nil, fmt.Errorf("查询登录日志失败: %w", err)
+	}
+	return result, nil
+}
+
+// Recent 获取管理员最近的登录记录
+func (s *LoginLogServiceImpl) Recent(ctx context.Context, adminID uint, limit int) ([]*models.AdminLoginLog, error) {
+	if limit <= 0 {
+		limit = loginLogRecentDefault
+	}
+	limit = min(limit, loginLogRecentMax)
+
+	db := s.db.Model(&models.AdminLoginLog{}).Order("id DESC").Limit(limit)
+	logs, err := s.loginLogRepo.FindList(ctx, utils.NewGormBuilderFind(ctx, db, "admin_id", adminID))
+	if err != nil {
+		return nil, fmt.Errorf("查询最近登录记录失败: %w", err)
+	}
+	return logs, nil
+}
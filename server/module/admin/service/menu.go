@@ -0,0 +1,252 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	coreerrors "github.com/so68/core/errors"
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/module/admin/repo"
+	"github.com/so68/core/server/utils"
+	"gorm.io/gorm"
+)
+
+// MenuService 后台菜单服务
+type MenuService interface {
+	// Tree 获取角色可见的菜单树（按角色权限过滤，隐藏菜单与无可见子菜单的分组不返回）
+	// @param ctx 上下文
+	// @param role 角色
+	// @return []*models.AdminMenu 菜单树
+	// @return error 错误
+	Tree(ctx context.Context, role string) ([]*models.AdminMenu, error)
+	// All 获取完整菜单树（用于菜单管理）
+	// @param ctx 上下文
+	// @return []*models.AdminMenu 菜单树
+	// @return error 错误
+	All(ctx context.Context) ([]*models.AdminMenu, error)
+	// Create 创建菜单
+	// @param ctx 上下文
+	// @param params 创建参数
+	// @return *models.AdminMenu 菜单
+	// @return error 错误
+	Create(ctx context.Context, params *dto.MenuCreateParams) (*models.AdminMenu, error)
+	// Update 更新菜单
+	// @param ctx 上下文
+	// @param params 更新参数
+	// @return error 错误
+	Update(ctx context.Context, params *dto.MenuUpdateParams) error
+	// Delete 删除菜单及其子菜单
+	// @param ctx 上下文
+	// @param id 菜单ID
+	// @return error 错误
+	Delete(ctx context.Context, id uint) error
+	// Sync 同步代码注册的菜单（按 Key 创建缺失的菜单，已存在的菜单保持不变）
+	// @param ctx 上下文
+	// @param items 菜单
+	// @return error 错误
+	Sync(ctx context.Context, items []*dto.MenuItem) error
+}
+
+// MenuServiceImpl 后台菜单服务实现
+type MenuServiceImpl struct {
+	db            *gorm.DB
+	logger        *slog.Logger
+	casbinService CasbinService
+	menuRepo      repo.AdminMenuRepo
+}
+
+// NewMenuService 创建一个后台菜单服务
+func NewMenuService(db *gorm.DB, casbinService CasbinService, logger *slog.Logger) MenuService {
+	return &MenuServiceImpl{db: db, logger: logger, casbinService: casbinService, menuRepo: repo.NewAdminMenuRepo()}
+}
+
+// Tree 获取角色可见的菜单树
+func (s *MenuServiceImpl) Tree(ctx context.Context, role string) ([]*models.AdminMenu, error) {
+	menus, err := s.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := s.casbinService.GetRolePermissions(role)
+	if err != nil {
+		return nil, err
+	}
+	allowed := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		allowed[permission.Name] = true
+	}
+
+	return buildMenuTree(menus, 0, func(menu *models.AdminMenu, children []*models.AdminMenu) bool {
+		if menu.Hidden {
+			return false
+		}
+		if menu.Permission != "" {
+			return allowed[menu.Permission]
+		}
+		// 分组菜单仅在存在可见子菜单时显示
+		return len(children) > 0 || menu.Path != ""
+	}), nil
+}
+
+// All 获取完整菜单树
+func (s *MenuServiceImpl) All(ctx context.Context) ([]*models.AdminMenu, error) {
+	menus, err := s.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return buildMenuTree(menus, 0, func(*models.AdminMenu, []*models.AdminMenu) bool { return true }), nil
+}
+
+// Create 创建菜单
+func (s *MenuServiceImpl) Create(ctx context.Context, params *dto.MenuCreateParams) (*models.AdminMenu, error) {
+	if err := s.checkParent(ctx, 0, params.ParentID); err != nil {
+		return nil, err
+	}
+	menu := &models.AdminMenu{}
+	applyMenuParams(menu, params)
+	if err := s.menuRepo.Create(ctx, utils.NewGormBuilder(ctx, s.db), menu); err != nil {
+		return nil, fmt.Errorf("创建菜单失败: %w", err)
+	}
+	return menu, nil
+}
+
+// Update 更新菜单
+func (s *MenuServiceImpl) Update(ctx context.Context, params *dto.MenuUpdateParams) error {
+	menu, err := s.menuRepo.Find(ctx, utils.NewGormBuilderFind(ctx, s.db, "id", params.ID))
+	if err != nil {
+		return fmt.Errorf("查询菜单失败: %w", err)
+	}
+	if err := s.checkParent(ctx, menu.ID, params.ParentID); err != nil {
+		return err
+	}
+	applyMenuParams(menu, &params.MenuCreateParams)
+	if err := s.menuRepo.Update(ctx, utils.NewGormBuilder(ctx, s.db), menu); err != nil {
+		return fmt.Errorf("更新菜单失败: %w", err)
+	}
+	return nil
+}
+
+// Delete 删除菜单及其子菜单
+func (s *MenuServiceImpl) Delete(ctx context.Context, id uint) error {
+	menus, err := s.list(ctx)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(menus, func(menu *models.AdminMenu) bool { return menu.ID == id }) {
+		return coreerrors.NotFound("菜单不存在")
+	}
+
+	ids := append([]uint{id}, descendantIDs(menus, id)...)
+	if err := s.menuRepo.Delete(ctx, utils.NewGormBuilder(ctx, s.db), ids...); err != nil {
+		return fmt.Errorf("删除菜单失败: %w", err)
+	}
+	return nil
+}
+
+// Sync 同步代码注册的菜单
+func (s *MenuServiceImpl) Sync(ctx context.Context, items []*dto.MenuItem) error {
+	menus, err := s.list(ctx)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]*models.AdminMenu, len(menus))
+	for _, menu := range menus {
+		existing[menu.Key] = menu
+	}
+	return s.sync(ctx, existing, 0, items)
+}
+
+// sync 递归创建缺失的菜单
+func (s *MenuServiceImpl) sync(ctx context.Context, existing map[string]*models.AdminMenu, parentID uint, items []*dto.MenuItem) error {
+	for _, item := range items {
+		menu, ok := existing[item.Key]
+		if !ok {
+			menu = &models.AdminMenu{ParentID: parentID, Key: item.Key, Title: item.Title, Path: item.Path, Icon: item.Icon, Sort: item.Sort, Permission: item.Permission}
+			if err := s.menuRepo.Create(ctx, utils.NewGormBuilder(ctx, s.db), menu); err != nil {
+				return fmt.Errorf("同步菜单 %s 失败: %w", item.Key, err)
+			}
+			existing[item.Key] = menu
+		}
+		if err := s.sync(ctx, existing, menu.ID, item.Children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// list 查询全部菜单（按排序与ID升序）
+func (s *MenuServiceImpl) list(ctx context.Context) ([]*models.AdminMenu, error) {
+	menus, err := s.menuRepo.FindList(ctx, utils.NewGormBuilder(ctx, s.db))
+	if err != nil {
+		return nil, fmt.Errorf("查询菜单失败: %w", err)
+	}
+	slices.SortFunc(menus, func(a, b *models.AdminMenu) int {
+		return cmp.Or(cmp.Compare(a.Sort, b.Sort), cmp.Compare(a.ID, b.ID))
+	})
+	return menus, nil
+}
+
+// checkParent 校验上级菜单存在且不会形成循环
+func (s *MenuServiceImpl) checkParent(ctx context.Context, id uint, parentID uint) error {
+	if parentID == 0 {
+		return nil
+	}
+	if parentID == id {
+		return errors.New("上级菜单不能是自身")
+	}
+	menus, err := s.list(ctx)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(menus, func(menu *models.AdminMenu) bool { return menu.ID == parentID }) {
+		return errors.New("上级菜单不存在")
+	}
+	if id != 0 && slices.Contains(descendantIDs(menus, id), parentID) {
+		return errors.New("上级菜单不能是自身的子菜单")
+	}
+	return nil
+}
+
+// applyMenuParams 将参数写入菜单
+func applyMenuParams(menu *models.AdminMenu, params *dto.MenuCreateParams) {
+	menu.ParentID = params.ParentID
+	menu.Key = params.Key
+	menu.Title = params.Title
+	menu.Path = params.Path
+	menu.Icon = params.Icon
+	menu.Sort = params.Sort
+	menu.Permission = params.Permission
+	menu.Hidden = params.Hidden
+}
+
+// buildMenuTree 构建菜单树，visible 在子菜单构建完成后判断菜单是否保留
+func buildMenuTree(menus []*models.AdminMenu, parentID uint, visible func(menu *models.AdminMenu, children []*models.AdminMenu) bool) []*models.AdminMenu {
+	tree := make([]*models.AdminMenu, 0)
+	for _, menu := range menus {
+		if menu.ParentID != parentID {
+			continue
+		}
+		node := *menu
+		node.Children = buildMenuTree(menus, menu.ID, visible)
+		if visible(&node, node.Children) {
+			tree = append(tree, &node)
+		}
+	}
+	return tree
+}
+
+// descendantIDs 获取菜单的全部子孙菜单ID
+func descendantIDs(menus []*models.AdminMenu, id uint) []uint {
+	ids := make([]uint, 0)
+	for _, menu := range menus {
+		if menu.ParentID == id && menu.ID != id {
+			ids = append(ids, menu.ID)
+			ids = append(ids, descendantIDs(menus, menu.ID)...)
+		}
+	}
+	return ids
+}
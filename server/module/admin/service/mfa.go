@@ -0,0 +1,375 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	coredb "github.com/so68/core/database"
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/module/admin/repo"
+	"github.com/so68/core/server/utils"
+	"gorm.io/gorm"
+)
+
+// MFARecoveryCodeCount 每次生成的恢复码数量
+const MFARecoveryCodeCount = 10
+
+const (
+	mfaMaxFailedAttempts = 5                // MFA 连续验证失败次数上限
+	mfaFailedWindow      = 15 * time.Minute // 达到上限后暂停验证的时长（同时为失败计数的统计窗口）
+)
+
+// MFAService 管理员 MFA 双因素认证服务
+// - 启用流程：Setup 生成密钥并返回二维码地址，Enable 校验首个验证码后启用并返回恢复码
+// - 恢复码一次性使用，仅保存 SHA-256 哈希值，可在验证码不可用时代替验证码
+// - 同一时间步的验证码只能使用一次，连续失败达到上限后暂停验证
+type MFAService interface {
+	// Status 获取 MFA 状态
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @return *dto.MFAStatusResult MFA 状态
+	// @return error 错误
+	Status(ctx context.Context, adminID uint) (*dto.MFAStatusResult, error)
+	// Setup 生成新的 MFA 密钥（已启用时需先关闭）
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @return *dto.MFASetupResult 密钥与二维码地址
+	// @return error 错误
+	Setup(ctx context.Context, adminID uint) (*dto.MFASetupResult, error)
+	// Enable 校验验证码并启用 MFA
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @param code 验证码
+	// @return []string 恢复码明文
+	// @return error 错误
+	Enable(ctx context.Context, adminID uint, code string) ([]string, error)
+	// Disable 校验密码与验证码后关闭 MFA，并清除恢复码
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @param params 关闭参数
+	// @return error 错误
+	Disable(ctx context.Context, adminID uint, params *dto.MFADisableParams) error
+	// RegenerateRecoveryCodes 重新生成恢复码（原有恢复码全部失效）
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @return []string 恢复码明文
+	// @return error 错误
+	RegenerateRecoveryCodes(ctx context.Context, adminID uint) ([]string, error)
+	// Check 校验验证码或恢复码（恢复码校验通过后即失效，验证码不可重复使用，连续失败过多时直接返回 false）
+	// @param ctx 上下文
+	// @param admin 管理员
+	// @param code 验证码或恢复码
+	// @return bool 是否通过
+	Check(ctx context.Context, admin *models.Admin, code string) bool
+	// Verify 敏感操作二次验证（未启用 MFA 的管理员直接通过）
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @param code 验证码或恢复码
+	// @return error 错误
+	Verify(ctx context.Context, adminID uint, code string) error
+}
+
+// MFAServiceImpl 管理员 MFA 双因素认证服务实现
+type MFAServiceImpl struct {
+	db               *gorm.DB
+	issuer           string
+	logger           *slog.Logger
+	adminRepo        repo.AdminRepo
+	recoveryCodeRepo repo.AdminMFARecoveryCodeRepo
+}
+
+// NewMFAService 创建一个管理员 MFA 双因素认证服务
+// - issuer 为验证器中显示的发行方名称（通常为应用名称）
+func NewMFAService(db *gorm.DB, issuer string, logger *slog.Logger) MFAService {
+	return &MFAServiceImpl{
+		db:               db,
+		issuer:           issuer,
+		logger:           logger,
+		adminRepo:        repo.NewAdminRepo(),
+		recoveryCodeRepo: repo.NewAdminMFARecoveryCodeRepo(),
+	}
+}
+
+// Status 获取 MFA 状态
+func (s *MFAServiceImpl) Status(ctx context.Context, adminID uint) (*dto.MFAStatusResult, error) {
+	admin, err := s.find(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	builder := utils.NewGormBuilder(ctx, s.db).WithoutDataScope().WhereEqual("admin_id", admin.ID).WhereEqual("used", false)
+	codes, err := s.recoveryCodeRepo.FindList(ctx, builder)
+	if err != nil {
+		return nil, fmt.Errorf("查询恢复码失败: %w", err)
+	}
+	return &dto.MFAStatusResult{Enabled: admin.IsMFAEnabled, RecoveryCodes: len(codes)}, nil
+}
+
+// Setup 生成新的 MFA 密钥
+func (s *MFAServiceImpl) Setup(ctx context.Context, adminID uint) (*dto.MFASetupResult, error) {
+	admin, err := s.find(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if admin.IsMFAEnabled {
+		return nil, errors.New("MFA已启用, 请先关闭后再重新绑定")
+	}
+
+	if err := admin.GenerateGoogleAuthSecret(); err != nil {
+		return nil, err
+	}
+	admin.MFALastStep = 0
+	url, err := admin.GetGoogleAuthQRCode(s.issuer)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.adminRepo.Update(ctx, utils.NewGormBuilder(ctx, s.db), admin); err != nil {
+		return nil, fmt.Errorf("保存MFA密钥失败: %w", err)
+	}
+	return &dto.MFASetupResult{Secret: admin.MFASecret, URL: url}, nil
+}
+
+// Enable 校验验证码并启用 MFA
+func (s *MFAServiceImpl) Enable(ctx context.Context, adminID uint, code string) ([]string, error) {
+	admin, err := s.find(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if admin.IsMFAEnabled {
+		return nil, errors.New("MFA已启用")
+	}
+	if admin.MFASecret == "" {
+		return nil, errors.New("请先生成MFA密钥")
+	}
+	step, ok := admin.GoogleAuthStep(code)
+	if !ok {
+		return nil, errors.New("MFA验证码错误")
+	}
+	admin.MFALastStep = step
+
+	var codes []string
+	err = coredb.Transaction(ctx, s.db, func(tx *gorm.DB) error {
+		txCtx := tx.Statement.Context
+		admin.IsMFAEnabled = true
+		if err := s.adminRepo.Update(txCtx, utils.NewGormBuilder(txCtx, s.db), admin); err != nil {
+			return fmt.Errorf("启用MFA失败: %w", err)
+		}
+		codes, err = s.replaceRecoveryCodes(txCtx, admin.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Disable 关闭 MFA
+func (s *MFAServiceImpl) Disable(ctx context.Context, adminID uint, params *dto.MFADisableParams) error {
+	admin, err := s.find(ctx, adminID)
+	if err != nil {
+		return err
+	}
+	if !admin.IsMFAEnabled {
+		return errors.New("MFA未启用")
+	}
+	if !admin.CompareHashAndPassword(params.Password) {
+		return errors.New("密码错误")
+	}
+	if !s.Check(ctx, admin, params.Code) {
+		return errors.New("MFA验证码错误")
+	}
+
+	return coredb.Transaction(ctx, s.db, func(tx *gorm.DB) error {
+		txCtx := tx.Statement.Context
+		admin.IsMFAEnabled = false
+		if err := admin.GenerateGoogleAuthSecret(); err != nil {
+			return err
+		}
+		if err := s.adminRepo.Update(txCtx, utils.NewGormBuilder(txCtx, s.db), admin); err != nil {
+			return fmt.Errorf("关闭MFA失败: %w", err)
+		}
+		builder := utils.NewGormBuilder(txCtx, s.db).WithoutDataScope().WhereEqual("admin_id", admin.ID)
+		if err := s.recoveryCodeRepo.Delete(txCtx, builder); err != nil {
+			return fmt.Errorf("清除恢复码失败: %w", err)
+		}
+		return nil
+	})
+}
+
+// RegenerateRecoveryCodes 重新生成恢复码
+func (s *MFAServiceImpl) RegenerateRecoveryCodes(ctx context.Context, adminID uint) ([]string, error) {
+	admin, err := s.find(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if !admin.IsMFAEnabled {
+		return nil, errors.New("MFA未启用")
+	}
+
+	var codes []string
+	err = coredb.Transaction(ctx, s.db, func(tx *gorm.DB) error {
+		codes, err = s.replaceRecoveryCodes(tx.Statement.Context, admin.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Check 校验验证码或恢复码
+func (s *MFAServiceImpl) Check(ctx context.Context, admin *models.Admin, code string) bool {
+	if code == "" || s.throttled(admin) {
+		return false
+	}
+	if s.checkCode(ctx, admin, code) || s.useRecoveryCode(ctx, admin, code) {
+		s.resetFailures(ctx, admin)
+		return true
+	}
+	s.recordFailure(ctx, admin)
+	return false
+}
+
+// checkCode 校验验证码，并以条件更新记录时间步（同一时间步及更早的验证码不能再次使用）
+func (s *MFAServiceImpl) checkCode(ctx context.Context, admin *models.Admin, code string) bool {
+	step, ok := admin.GoogleAuthStep(code)
+	if !ok || step <= admin.MFALastStep {
+		return false
+	}
+	builder := utils.NewGormBuilderFind(ctx, s.db, "id", admin.ID).WhereLessThan("mfa_last_step", step)
+	affected, err := s.adminRepo.BatchUpdate(ctx, builder, map[string]interface{}{"mfa_last_step": step})
+	if err != nil {
+		s.logger.Warn("记录MFA验证码时间步失败", "admin_id", admin.ID, "error", err)
+		return false
+	}
+	if affected != 1 {
+		return false
+	}
+	admin.MFALastStep = step
+	return true
+}
+
+// useRecoveryCode 使用恢复码（以条件更新标记已使用，并发请求中只有一个成功）
+func (s *MFAServiceImpl) useRecoveryCode(ctx context.Context, admin *models.Admin, code string) bool {
+	builder := utils.NewGormBuilder(ctx, s.db).WithoutDataScope().
+		WhereEqual("admin_id", admin.ID).
+		WhereEqual("code_hash", hashRecoveryCode(code)).
+		WhereEqual("used", false)
+	affected, err := s.recoveryCodeRepo.BatchUpdate(ctx, builder, map[string]interface{}{"used": true, "used_at": time.Now()})
+	if err != nil {
+		s.logger.Warn("标记恢复码已使用失败", "admin_id", admin.ID, "error", err)
+		return false
+	}
+	return affected == 1
+}
+
+// throttled 连续验证失败是否已达到上限（窗口期内暂停验证）
+func (s *MFAServiceImpl) throttled(admin *models.Admin) bool {
+	return admin.MFAFailedAttempts >= mfaMaxFailedAttempts && time.Since(admin.MFAFailedAt) < mfaFailedWindow
+}
+
+// recordFailure 记录验证失败（超过统计窗口后重新计数）
+func (s *MFAServiceImpl) recordFailure(ctx context.Context, admin *models.Admin) {
+	now := time.Now()
+	attempts := interface{}(gorm.Expr("mfa_failed_attempts + 1"))
+	if time.Since(admin.MFAFailedAt) >= mfaFailedWindow {
+		attempts = 1
+		admin.MFAFailedAttempts = 0
+	}
+	builder := utils.NewGormBuilderFind(ctx, s.db, "id", admin.ID)
+	if _, err := s.adminRepo.BatchUpdate(ctx, builder, map[string]interface{}{"mfa_failed_attempts": attempts, "mfa_failed_at": now}); err != nil {
+		s.logger.Warn("记录MFA验证失败次数失败", "admin_id", admin.ID, "error", err)
+		return
+	}
+	admin.MFAFailedAttempts++
+	admin.MFAFailedAt = now
+}
+
+// resetFailures 验证通过后清除失败次数
+func (s *MFAServiceImpl) resetFailures(ctx context.Context, admin *models.Admin) {
+	if admin.MFAFailedAttempts == 0 {
+		return
+	}
+	builder := utils.NewGormBuilderFind(ctx, s.db, "id", admin.ID)
+	if _, err := s.adminRepo.BatchUpdate(ctx, builder, map[string]interface{}{"mfa_failed_attempts": 0}); err != nil {
+		s.logger.Warn("清除MFA验证失败次数失败", "admin_id", admin.ID, "error", err)
+		return
+	}
+	admin.MFAFailedAttempts = 0
+}
+
+// Verify 敏感操作二次验证
+func (s *MFAServiceImpl) Verify(ctx context.Context, adminID uint, code string) error {
+	admin, err := s.find(ctx, adminID)
+	if err != nil {
+		return err
+	}
+	if !admin.IsMFAEnabled {
+		return nil
+	}
+	if code == "" {
+		return errors.New("敏感操作需要MFA验证码")
+	}
+	if s.throttled(admin) {
+		return errors.New("MFA验证失败次数过多, 请稍后再试")
+	}
+	if !s.Check(ctx, admin, code) {
+		return errors.New("MFA验证码错误")
+	}
+	return nil
+}
+
+// find 查询管理员
+func (s *MFAServiceImpl) find(ctx context.Context, adminID uint) (*models.Admin, error) {
+	admin, err := s.adminRepo.Find(ctx, utils.NewGormBuilderFind(ctx, s.db, "id", adminID))
+	if err != nil {
+		return nil, fmt.Errorf("查询管理员失败: %w", err)
+	}
+	return admin, nil
+}
+
+// replaceRecoveryCodes 删除原有恢复码并生成新的恢复码
+func (s *MFAServiceImpl) replaceRecoveryCodes(ctx context.Context, adminID uint) ([]string, error) {
+	builder := utils.NewGormBuilder(ctx, s.db).WithoutDataScope().WhereEqual("admin_id", adminID)
+	if err := s.recoveryCodeRepo.Delete(ctx, builder); err != nil {
+		return nil, fmt.Errorf("清除恢复码失败: %w", err)
+	}
+
+	codes := make([]string, 0, MFARecoveryCodeCount)
+	records := make([]*models.AdminMFARecoveryCode, 0, MFARecoveryCodeCount)
+	for range MFARecoveryCodeCount {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		records = append(records, &models.AdminMFARecoveryCode{AdminID: adminID, CodeHash: hashRecoveryCode(code)})
+	}
+	if err := s.recoveryCodeRepo.Create(ctx, utils.NewGormBuilder(ctx, s.db), records); err != nil {
+		return nil, fmt.Errorf("生成恢复码失败: %w", err)
+	}
+	return codes, nil
+}
+
+// generateRecoveryCode 生成恢复码（格式 xxxxx-xxxxx）
+func generateRecoveryCode() (string, error) {
+	buf := make([]byte, 5)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("生成恢复码失败: %w", err)
+	}
+	code := hex.EncodeToString(buf)
+	return code[:5] + "-" + code[5:], nil
+}
+
+// hashRecoveryCode 计算恢复码哈希（忽略大小写与首尾空白）
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(code))))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/module/admin/dto"
+)
+
+/*
+MFA 双因素认证测试
+
+本文件用于测试管理员 MFA 的启用、恢复码与敏感操作二次验证。
+
+运行命令：
+go test -v -run "^TestMFAService.*$"
+
+测试内容：
+1. 生成密钥与启用 (Setup, Enable)
+2. 恢复码一次性使用 (Check)
+3. 敏感操作二次验证 (Verify)
+4. 关闭 MFA (Disable)
+5. 验证码不能重放，恢复码并发使用只有一次成功 (Check)
+6. 连续失败达到上限后暂停验证 (Verify)
+*/
+
+func TestMFAService(t *testing.T) {
+	_, db := newAdminTestService(t)
+	s := NewMFAService(db, "core", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	admin := createTestAdmin(t, db, "root", models.AdminTypeSuper, RoleSuperAdmin, 0)
+	ctx := context.Background()
+
+	// 未启用 MFA 时敏感操作直接通过
+	if err := s.Verify(ctx, admin.ID, ""); err != nil {
+		t.Fatalf("Verify without MFA failed: %v", err)
+	}
+
+	setup, err := s.Setup(ctx, admin.ID)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	if !strings.HasPrefix(setup.URL, "otpauth://totp/") || !strings.Contains(setup.URL, "secret="+setup.Secret) {
+		t.Fatalf("unexpected setup url: %s", setup.URL)
+	}
+
+	if _, err := s.Enable(ctx, admin.ID, "000000"); err == nil {
+		t.Fatal("Enable with wrong code should fail")
+	}
+	code, err := totp.GenerateCode(setup.Secret, time.Now())
+	if err != nil {
+		t.Fatalf("GenerateCode failed: %v", err)
+	}
+	codes, err := s.Enable(ctx, admin.ID, code)
+	if err != nil {
+		t.Fatalf("Enable failed: %v", err)
+	}
+	if len(codes) != MFARecoveryCodeCount {
+		t.Fatalf("recovery codes = %d, want %d", len(codes), MFARecoveryCodeCount)
+	}
+	if _, err := s.Setup(ctx, admin.ID); err == nil {
+		t.Fatal("Setup after enabled should fail")
+	}
+
+	next, err := totp.GenerateCode(setup.Secret, time.Now().Add(30*time.Second))
+	if err != nil {
+		t.Fatalf("GenerateCode failed: %v", err)
+	}
+	tests := []struct {
+		name    string
+		code    string
+		wantErr string
+	}{
+		{name: "缺少验证码", code: "", wantErr: "敏感操作需要MFA验证码"},
+		{name: "验证码错误", code: "000000", wantErr: "MFA验证码错误"},
+		{name: "启用时使用过的验证码不能重放", code: code, wantErr: "MFA验证码错误"},
+		{name: "验证器验证码", code: next},
+		{name: "验证器验证码不能重复使用", code: next, wantErr: "MFA验证码错误"},
+		{name: "恢复码（忽略大小写）", code: strings.ToUpper(codes[0])},
+		{name: "恢复码不能重复使用", code: codes[0], wantErr: "MFA验证码错误"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertError(t, s.Verify(ctx, admin.ID, tt.code), tt.wantErr)
+		})
+	}
+
+	status, err := s.Status(ctx, admin.ID)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if !status.Enabled || status.RecoveryCodes != MFARecoveryCodeCount-1 {
+		t.Errorf("unexpected status: %+v", status)
+	}
+
+	if err := s.Disable(ctx, admin.ID, &dto.MFADisableParams{Password: "wrong", Code: codes[1]}); err == nil {
+		t.Fatal("Disable with wrong password should fail")
+	}
+	if err := s.Disable(ctx, admin.ID, &dto.MFADisableParams{Password: "password123", Code: codes[1]}); err != nil {
+		t.Fatalf("Disable failed: %v", err)
+	}
+	status, err = s.Status(ctx, admin.ID)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if status.Enabled || status.RecoveryCodes != 0 {
+		t.Errorf("unexpected status after disable: %+v", status)
+	}
+}
+
+func TestMFAService_RecoveryCodeConcurrent(t *testing.T) {
+	_, db := newAdminTestService(t)
+	s := NewMFAService(db, "core", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	admin := createTestAdmin(t, db, "root", models.AdminTypeSuper, RoleSuperAdmin, 0)
+	ctx := context.Background()
+
+	setup, err := s.Setup(ctx, admin.ID)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	code, _ := totp.GenerateCode(setup.Secret, time.Now())
+	codes, err := s.Enable(ctx, admin.ID, code)
+	if err != nil {
+		t.Fatalf("Enable failed: %v", err)
+	}
+
+	// 多个请求同时使用同一个恢复码
+	var wg sync.WaitGroup
+	var passed atomic.Int32
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			loaded := &models.Admin{}
+			if err := db.First(loaded, admin.ID).Error; err != nil {
+				return
+			}
+			if s.Check(ctx, loaded, codes[0]) {
+				passed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if passed.Load() != 1 {
+		t.Fatalf("recovery code accepted %d times, want 1", passed.Load())
+	}
+}
+
+func TestMFAService_Throttle(t *testing.T) {
+	_, db := newAdminTestService(t)
+	s := NewMFAService(db, "core", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	admin := createTestAdmin(t, db, "root", models.AdminTypeSuper, RoleSuperAdmin, 0)
+	ctx := context.Background()
+
+	setup, err := s.Setup(ctx, admin.ID)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	code, _ := totp.GenerateCode(setup.Secret, time.Now())
+	codes, err := s.Enable(ctx, admin.ID, code)
+	if err != nil {
+		t.Fatalf("Enable failed: %v", err)
+	}
+
+	for range mfaMaxFailedAttempts {
+		assertError(t, s.Verify(ctx, admin.ID, "000000"), "MFA验证码错误")
+	}
+	// 达到上限后正确的恢复码也不再校验，且不会被消耗
+	assertError(t, s.Verify(ctx, admin.ID, codes[0]), "MFA验证失败次数过多, 请稍后再试")
+
+	// 超过暂停时长后恢复验证，通过后清除失败次数
+	if err := db.Model(&models.Admin{}).Where("id = ?", admin.ID).Update("mfa_failed_at", time.Now().Add(-mfaFailedWindow)).Error; err != nil {
+		t.Fatalf("update failed at: %v", err)
+	}
+	assertError(t, s.Verify(ctx, admin.ID, codes[0]), "")
+	stored := &models.Admin{}
+	db.First(stored, admin.ID)
+	if stored.MFAFailedAttempts != 0 {
+		t.Errorf("expected failed attempts reset, got %d", stored.MFAFailedAttempts)
+	}
+}
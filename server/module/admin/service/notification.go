@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/module/admin/repo"
+	"github.com/so68/core/server/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	notificationPushType         = "notification" // 实时推送的新通知消息类型
+	notificationSubscriberBuffer = 16             // 单个订阅者的推送缓冲区大小
+)
+
+// NotificationPusher 通知实时推送（*server.Hub 实现该接口）
+type NotificationPusher interface {
+	SendJSONToUser(userID uint, v interface{}) (int, error)
+}
+
+// NotificationService 站内通知服务
+// - 通知按接收者逐条保存，发送后实时推送给在线的 WebSocket 连接与 SSE 订阅者
+// - 推送失败不影响发送，客户端重连后可通过列表与未读数重新同步
+type NotificationService interface {
+	// Send 向指定管理员发送通知
+	// @param ctx 上下文
+	// @param adminIDs 接收管理员ID列表
+	// @param msg 通知内容
+	// @return error 错误
+	Send(ctx context.Context, adminIDs []uint, msg *dto.NotificationMessage) error
+	// Broadcast 向全部已启用的管理员发送通知
+	// @param ctx 上下文
+	// @param msg 通知内容
+	// @return error 错误
+	Broadcast(ctx context.Context, msg *dto.NotificationMessage) error
+	// List 分页查询管理员的通知
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @param params 查询参数
+	// @return *utils.PageResp 分页结果
+	// @return error 错误
+	List(ctx context.Context, adminID uint, params *dto.NotificationIndexParams) (*utils.PageResp, error)
+	// UnreadCount 管理员的未读通知数
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @return int64 未读数量
+	// @return error 错误
+	UnreadCount(ctx context.Context, adminID uint) (int64, error)
+	// MarkRead 标记指定通知为已读（仅可标记自己的通知）
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @param ids 通知ID列表
+	// @return int64 标记数量
+	// @return error 错误
+	MarkRead(ctx context.Context, adminID uint, ids []uint) (int64, error)
+	// MarkAllRead 标记全部通知为已读
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @return int64 标记数量
+	// @return error 错误
+	MarkAllRead(ctx context.Context, adminID uint) (int64, error)
+	// Subscribe 订阅管理员的新通知（用于 SSE 推送），取消订阅后通道关闭
+	// @param adminID 管理员ID
+	// @return <-chan *models.AdminNotification 新通知通道
+	// @return func() 取消订阅
+	Subscribe(adminID uint) (<-chan *models.AdminNotification, func())
+}
+
+// NotificationServiceImpl 站内通知服务实现
+type NotificationServiceImpl struct {
+	db               *gorm.DB
+	pusher           NotificationPusher
+	logger           *slog.Logger
+	notificationRepo repo.AdminNotificationRepo
+
+	mutex       sync.RWMutex
+	subscribers map[uint]map[chan *models.AdminNotification]struct{}
+}
+
+// NewNotificationService 创建一个站内通知服务（pusher 为空时不推送 WebSocket 消息）
+func NewNotificationService(db *gorm.DB, pusher NotificationPusher, logger *slog.Logger) NotificationService {
+	return &NotificationServiceImpl{
+		db:               db,
+		pusher:           pusher,
+		logger:           logger,
+		notificationRepo: repo.NewAdminNotificationRepo(),
+		subscribers:      make(map[uint]map[chan *models.AdminNotification]struct{}),
+	}
+}
+
+// Send 向指定管理员发送通知
+func (s *NotificationServiceImpl) Send(ctx context.Context, adminIDs []uint, msg *dto.NotificationMessage) error {
+	if msg.Title == "" {
+		return errors.New("通知标题不能为空")
+	}
+	adminIDs = slices.Compact(slices.Sorted(slices.Values(adminIDs)))
+	if len(adminIDs) == 0 {
+		return nil
+	}
+
+	notificationType := msg.Type
+	if notificationType == "" {
+		notificationType = models.NotificationTypeSystem
+	}
+	notifications := make([]*models.AdminNotification, 0, len(adminIDs))
+	for _, adminID := range adminIDs {
+		if adminID == 0 {
+			continue
+		}
+		notifications = append(notifications, &models.AdminNotification{
+			AdminID:  adminID,
+			SenderID: msg.SenderID,
+			Type:     notificationType,
+			Title:    msg.Title,
+			Content:  msg.Content,
+			Link:     msg.Link,
+		})
+	}
+	if len(notifications) == 0 {
+		return nil
+	}
+	if err := s.notificationRepo.CreateInBatches(ctx, utils.NewGormBuilder(ctx, s.db), notifications); err != nil {
+		return fmt.Errorf("发送通知失败: %w", err)
+	}
+
+	for _, notification := range notifications {
+		s.push(notification)
+	}
+	return nil
+}
+
+// Broadcast 向全部已启用的管理员发送通知
+func (s *NotificationServiceImpl) Broadcast(ctx context.Context, msg *dto.NotificationMessage) error {
+	var adminIDs []uint
+	if err := s.db.WithContext(ctx).Model(&models.Admin{}).Where("status = ?", models.AdminStatusEnabled).Pluck("id", &adminIDs).Error; err != nil {
+		return fmt.Errorf("查询管理员失败: %w", err)
+	}
+	return s.Send(ctx, adminIDs, msg)
+}
+
+// List 分页查询管理员的通知
+func (s *NotificationServiceImpl) List(ctx context.Context, adminID uint, params *dto.NotificationIndexParams) (*utils.PageResp, error) {
+	if params.Sort == "" {
+		params.Sort, params.Order = "id", "DESC"
+	}
+	builder := utils.NewGormBuilderWithPage(ctx, s.db.Model(&models.AdminNotification{}), &params.Page, "id", "created_at").WhereEqual("admin_id", adminID)
+	if params.Type != "" {
+		builder.WhereEqual("type", params.Type)
+	}
+	if params.Unread {
+		builder.WhereIsNull("read_at")
+	}
+
+	result, err := s.notificationRepo.FindListWithPage(ctx, builder)
+	if err != nil {
+		return nil, fmt.Errorf("查询通知失败: %w", err)
+	}
+	return result, nil
+}
+
+// UnreadCount 管理员的未读通知数
+func (s *NotificationServiceImpl) UnreadCount(ctx context.Context, adminID uint) (int64, error) {
+	builder := utils.NewGormBuilderFind(ctx, s.db, "admin_id", adminID).WhereIsNull("read_at")
+	count, err := s.notificationRepo.Count(ctx, builder)
+	if err != nil {
+		return 0, fmt.Errorf("查询未读通知数失败: %w", err)
+	}
+	return count, nil
+}
+
+// MarkRead 标记指定通知为已读
+func (s *NotificationServiceImpl) MarkRead(ctx context.Context, adminID uint, ids []uint) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	values := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, id)
+	}
+	builder := utils.NewGormBuilderFind(ctx, s.db, "admin_id", adminID).WhereIn("id", values).WhereIsNull("read_at")
+	return s.markRead(ctx, builder)
+}
+
+// MarkAllRead 标记全部通知为已读
+func (s *NotificationServiceImpl) MarkAllRead(ctx context.Context, adminID uint) (int64, error) {
+	builder := utils.NewGormBuilderFind(ctx, s.db, "admin_id", adminID).WhereIsNull("read_at")
+	return s.markRead(ctx, builder)
+}
+
+// markRead 将符合条件的未读通知标记为已读
+func (s *NotificationServiceImpl) markRead(ctx context.Context, builder *utils.GormBuilder) (int64, error) {
+	count, err := s.notificationRepo.BatchUpdate(ctx, builder, map[string]interface{}{"read_at": time.Now()})
+	if err != nil {
+		return 0, fmt.Errorf("标记通知已读失败: %w", err)
+	}
+	return count, nil
+}
+
+// Subscribe 订阅管理员的新通知
+func (s *NotificationServiceImpl) Subscribe(adminID uint) (<-chan *models.AdminNotification, func()) {
+	ch := make(chan *models.AdminNotification, notificationSubscriberBuffer)
+	s.mutex.Lock()
+	if s.subscribers[adminID] == nil {
+		s.subscribers[adminID] = make(map[chan *models.AdminNotification]struct{})
+	}
+	s.subscribers[adminID][ch] = struct{}{}
+	s.mutex.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.mutex.Lock()
+			defer s.mutex.Unlock()
+			delete(s.subscribers[adminID], ch)
+			if len(s.subscribers[adminID]) == 0 {
+				delete(s.subscribers, adminID)
+			}
+			close(ch)
+		})
+	}
+}
+
+// push 推送新通知给在线的 WebSocket 连接与 SSE 订阅者（缓冲区已满的订阅者跳过本条通知）
+func (s *NotificationServiceImpl) push(notification *models.AdminNotification) {
+	if s.pusher != nil {
+		if _, err := s.pusher.SendJSONToUser(notification.AdminID, &dto.NotificationPush{Type: notificationPushType, Data: notification}); err != nil {
+			s.logger.Warn("推送通知失败", "admin_id", notification.AdminID, "error", err)
+		}
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	for ch := range s.subscribers[notification.AdminID] {
+		select {
+		case ch <- notification:
+		default:
+			s.logger.Warn("通知订阅者缓冲区已满，跳过推送", "admin_id", notification.AdminID, "notification_id", notification.ID)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/module/admin/dto"
+	"gorm.io/gorm"
+)
+
+/*
+站内通知测试
+
+本文件用于测试管理员站内通知的发送、实时推送、未读数与标记已读。
+
+运行命令：
+go test -v -run "^TestNotificationService.*$"
+
+测试内容：
+1. 发送与广播通知 (Send, Broadcast)
+2. WebSocket 与 SSE 订阅推送 (Subscribe)
+3. 列表、未读数与标记已读 (List, UnreadCount, MarkRead, MarkAllRead)
+*/
+
+// recordPusher 记录推送的消息
+type recordPusher struct {
+	mu    sync.Mutex
+	users []uint
+}
+
+func (p *recordPusher) SendJSONToUser(userID uint, v interface{}) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.users = append(p.users, userID)
+	return 1, nil
+}
+
+func TestNotificationService(t *testing.T) {
+	_, db := newAdminTestService(t)
+	if err := db.Session(&gorm.Session{AllowGlobalUpdate: true}).Unscoped().Delete(&models.AdminNotification{}).Error; err != nil {
+		t.Fatalf("clear notifications failed: %v", err)
+	}
+	pusher := &recordPusher{}
+	s := NewNotificationService(db, pusher, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	root := createTestAdmin(t, db, "root", models.AdminTypeSuper, RoleSuperAdmin, 0)
+	agent := createTestAdmin(t, db, "agent", models.AdminTypeAgent, "agent", root.ID)
+	ctx := context.Background()
+
+	notifications, unsubscribe := s.Subscribe(agent.ID)
+	defer unsubscribe()
+
+	if err := s.Send(ctx, []uint{agent.ID}, &dto.NotificationMessage{}); err == nil {
+		t.Fatal("Send without title should fail")
+	}
+	if err := s.Send(ctx, []uint{agent.ID, agent.ID, 0}, &dto.NotificationMessage{Title: "审批提醒", Type: models.NotificationTypeAction, SenderID: root.ID}); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	select {
+	case notification := <-notifications:
+		if notification.ID == 0 || notification.Title != "审批提醒" || notification.SenderID != root.ID {
+			t.Fatalf("unexpected notification: %+v", notification)
+		}
+	default:
+		t.Fatal("subscriber should receive notification")
+	}
+
+	if err := s.Broadcast(ctx, &dto.NotificationMessage{Title: "系统维护"}); err != nil {
+		t.Fatalf("Broadcast failed: %v", err)
+	}
+	if len(pusher.users) != 3 {
+		t.Fatalf("pushed users = %v, want 3 messages", pusher.users)
+	}
+	if notification := <-notifications; notification.Title != "系统维护" {
+		t.Fatalf("unexpected broadcast notification: %+v", notification)
+	}
+
+	count, err := s.UnreadCount(ctx, agent.ID)
+	if err != nil || count != 2 {
+		t.Fatalf("UnreadCount = %d, %v, want 2", count, err)
+	}
+	result, err := s.List(ctx, agent.ID, &dto.NotificationIndexParams{Type: models.NotificationTypeSystem})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	items := result.Items.([]*models.AdminNotification)
+	if result.Total != 1 || items[0].Title != "系统维护" {
+		t.Fatalf("List system notifications = %+v", items)
+	}
+
+	// 不能标记他人的通知
+	if marked, err := s.MarkRead(ctx, root.ID, []uint{items[0].ID}); err != nil || marked != 0 {
+		t.Fatalf("MarkRead other admin = %d, %v, want 0", marked, err)
+	}
+	if marked, err := s.MarkRead(ctx, agent.ID, []uint{items[0].ID}); err != nil || marked != 1 {
+		t.Fatalf("MarkRead = %d, %v, want 1", marked, err)
+	}
+	if result, _ := s.List(ctx, agent.ID, &dto.NotificationIndexParams{Unread: true}); result.Total != 1 {
+		t.Fatalf("unread list total = %d, want 1", result.Total)
+	}
+	if marked, err := s.MarkAllRead(ctx, agent.ID); err != nil || marked != 1 {
+		t.Fatalf("MarkAllRead = %d, %v, want 1", marked, err)
+	}
+	if count, _ := s.UnreadCount(ctx, agent.ID); count != 0 {
+		t.Fatalf("UnreadCount after MarkAllRead = %d, want 0", count)
+	}
+	if count, _ := s.UnreadCount(ctx, root.ID); count != 1 {
+		t.Fatalf("root UnreadCount = %d, want 1", count)
+	}
+
+	unsubscribe()
+	if _, ok := <-notifications; ok {
+		t.Fatal("channel should be closed after unsubscribe")
+	}
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/so68/core/mailer"
+	"github.com/so68/core/server/database"
+)
+
+// NotifyService 安全提醒服务
+type NotifyService interface {
+	// NotifyNewDeviceLogin 新设备/新IP登录提醒
+	// @param admin 管理员
+	// @param ip 登录IP
+	// @param userAgent 登录设备
+	NotifyNewDeviceLogin(admin *database.Admin, ip string, userAgent string)
+	// NotifyLockout 账户锁定提醒
+	// @param admin 管理员
+	// @param ip 触发锁定的IP
+	NotifyLockout(admin *database.Admin, ip string)
+}
+
+// NotifyServiceImpl 安全提醒服务实现
+type NotifyServiceImpl struct {
+	appName string
+	mailer  mailer.Mailer
+	logger  *slog.Logger
+}
+
+// NewNotifyService 创建一个安全提醒服务（mailer 为空时不发送任何提醒）
+func NewNotifyService(appName string, m mailer.Mailer, logger *slog.Logger) NotifyService {
+	return &NotifyServiceImpl{appName: appName, mailer: m, logger: logger}
+}
+
+// DeviceFingerprint 计算登录设备指纹
+func DeviceFingerprint(ip string, userAgent string) string {
+	sum := sha256.Sum256([]byte(ip + "|" + userAgent))
+	return hex.EncodeToString(sum[:8])
+}
+
+// NotifyNewDeviceLogin 新设备/新IP登录提醒
+func (s *NotifyServiceImpl) NotifyNewDeviceLogin(admin *database.Admin, ip string, userAgent string) {
+	if admin.Data.DisableLoginNotify {
+		return
+	}
+	s.send(admin, fmt.Sprintf("[%s] 新设备登录提醒", s.appName), fmt.Sprintf(
+		"您好 %s：\n\n您的账号 %s 于 %s 在新的设备或IP登录。\n\n登录IP：%s\n登录设备：%s\n\n如非本人操作，请立即修改密码并联系管理员。",
+		admin.Nickname, admin.Username, time.Now().Format(time.DateTime), ip, userAgent,
+	))
+}
+
+// NotifyLockout 账户锁定提醒
+func (s *NotifyServiceImpl) NotifyLockout(admin *database.Admin, ip string) {
+	if admin.Data.DisableLockoutNotify {
+		return
+	}
+	s.send(admin, fmt.Sprintf("[%s] 账户锁定提醒", s.appName), fmt.Sprintf(
+		"您好 %s：\n\n您的账号 %s 因多次登录失败已被锁定，锁定截止时间：%s。\n\n最后一次失败登录IP：%s\n\n如非本人操作，请尽快修改密码。",
+		admin.Nickname, admin.Username, admin.LockedUntil.Format(time.DateTime), ip,
+	))
+}
+
+// send 异步发送邮件，避免阻塞登录流程
+func (s *NotifyServiceImpl) send(admin *database.Admin, subject string, body string) {
+	if s.mailer == nil || admin.Email == "" {
+		return
+	}
+	msg := &mailer.Message{To: []string{admin.Email}, Subject: subject, Body: body}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := s.mailer.Send(ctx, msg); err != nil {
+			s.logger.Warn("发送安全提醒邮件失败", "admin_id", admin.ID, "subject", subject, "error", err)
+		}
+	}()
+}
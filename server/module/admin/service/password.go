@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/so68/core/config"
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/module/admin/repo"
+	"github.com/so68/core/server/utils"
+	"gorm.io/gorm"
+)
+
+// PasswordService 管理员密码策略服务
+// - 未配置密码策略时不做任何限制
+type PasswordService interface {
+	// Validate 校验新密码是否符合密码策略（复杂度与最近使用过的密码）
+	// @param ctx 上下文
+	// @param admin 管理员（创建管理员时为 nil，仅校验复杂度）
+	// @param password 新密码
+	// @return error 错误
+	Validate(ctx context.Context, admin *models.Admin, password string) error
+	// Record 记录管理员当前密码到历史密码，并清理超出保留数量的记录
+	// @param ctx 上下文
+	// @param admin 管理员
+	// @return error 错误
+	Record(ctx context.Context, admin *models.Admin) error
+	// Expired 管理员密码是否已过期
+	// @param admin 管理员
+	// @return bool 是否过期
+	Expired(admin *models.Admin) bool
+}
+
+// PasswordServiceImpl 管理员密码策略服务实现
+type PasswordServiceImpl struct {
+	db          *gorm.DB
+	policy      *config.PasswordPolicyConfig
+	logger      *slog.Logger
+	historyRepo repo.AdminPasswordHistoryRepo
+}
+
+// NewPasswordService 创建一个管理员密码策略服务
+func NewPasswordService(db *gorm.DB, policy *config.PasswordPolicyConfig, logger *slog.Logger) PasswordService {
+	return &PasswordServiceImpl{db: db, policy: policy, logger: logger, historyRepo: repo.NewAdminPasswordHistoryRepo()}
+}
+
+// Validate 校验新密码是否符合密码策略
+func (s *PasswordServiceImpl) Validate(ctx context.Context, admin *models.Admin, password string) error {
+	if s.policy == nil {
+		return nil
+	}
+	if err := s.checkComplexity(password); err != nil {
+		return err
+	}
+	if admin == nil || s.policy.HistoryCount <= 0 {
+		return nil
+	}
+
+	// 当前密码始终视为已使用（兼容启用策略前没有历史记录的管理员）
+	if admin.CompareHashAndPassword(password) {
+		return fmt.Errorf("不能使用最近 %d 次使用过的密码", s.policy.HistoryCount)
+	}
+	histories, err := s.recent(ctx, admin.ID)
+	if err != nil {
+		return fmt.Errorf("查询历史密码失败: %w", err)
+	}
+	for _, history := range histories {
+		if (&models.Admin{PasswordHash: history.PasswordHash}).CompareHashAndPassword(password) {
+			return fmt.Errorf("不能使用最近 %d 次使用过的密码", s.policy.HistoryCount)
+		}
+	}
+	return nil
+}
+
+// Record 记录管理员当前密码到历史密码
+func (s *PasswordServiceImpl) Record(ctx context.Context, admin *models.Admin) error {
+	if s.policy == nil || s.policy.HistoryCount <= 0 {
+		return nil
+	}
+
+	history := &models.AdminPasswordHistory{AdminID: admin.ID, PasswordHash: admin.PasswordHash}
+	if err := s.historyRepo.Create(ctx, utils.NewGormBuilder(ctx, s.db), history); err != nil {
+		return fmt.Errorf("记录历史密码失败: %w", err)
+	}
+
+	// 仅保留最近 HistoryCount 条
+	histories, err := s.recent(ctx, admin.ID)
+	if err != nil {
+		return fmt.Errorf("查询历史密码失败: %w", err)
+	}
+	if len(histories) < s.policy.HistoryCount {
+		return nil
+	}
+	builder := utils.NewGormBuilder(ctx, s.db).WithoutDataScope().
+		WhereEqual("admin_id", admin.ID).
+		WhereLessThan("id", histories[len(histories)-1].ID)
+	if err := s.historyRepo.Delete(ctx, builder); err != nil {
+		return fmt.Errorf("清理历史密码失败: %w", err)
+	}
+	return nil
+}
+
+// Expired 管理员密码是否已过期（未记录修改时间时按创建时间计算）
+func (s *PasswordServiceImpl) Expired(admin *models.Admin) bool {
+	if s.policy == nil || s.policy.ExpireDays <= 0 {
+		return false
+	}
+	changedAt := admin.PasswordChangedAt
+	if changedAt.IsZero() {
+		changedAt = admin.CreatedAt
+	}
+	return time.Since(changedAt) > time.Duration(s.policy.ExpireDays)*24*time.Hour
+}
+
+// recent 查询管理员最近 HistoryCount 条历史密码（按时间倒序）
+func (s *PasswordServiceImpl) recent(ctx context.Context, adminID uint) ([]*models.AdminPasswordHistory, error) {
+	page := &utils.Page{Page: 1, Size: int64(s.policy.HistoryCount), Sort: "id", Order: "DESC"}
+	builder := utils.NewGormBuilderWithPage(ctx, s.db, page).WithoutDataScope().WhereEqual("admin_id", adminID)
+	return s.historyRepo.FindList(ctx, builder)
+}
+
+// checkComplexity 校验密码长度与字符类型
+func (s *PasswordServiceImpl) checkComplexity(password string) error {
+	if utf8.RuneCountInString(password) < s.policy.MinLength {
+		return fmt.Errorf("密码长度不能少于 %d 位", s.policy.MinLength)
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			symbol = true
+		}
+	}
+	switch {
+	case s.policy.RequireUpper && !upper:
+		return errors.New("密码必须包含大写字母")
+	case s.policy.RequireLower && !lower:
+		return errors.New("密码必须包含小写字母")
+	case s.policy.RequireDigit && !digit:
+		return errors.New("密码必须包含数字")
+	case s.policy.RequireSymbol && !symbol:
+		return errors.New("密码必须包含特殊字符")
+	}
+	return nil
+}
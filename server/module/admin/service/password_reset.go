@@ -0,0 +1,396 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/so68/core/cache"
+	"github.com/so68/core/captcha"
+	"github.com/so68/core/config"
+	"github.com/so68/core/logging"
+	"github.com/so68/core/mailer"
+	"github.com/so68/core/server/database"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/module/admin/repo"
+	"github.com/so68/core/server/utils"
+	"github.com/so68/core/sms"
+	"gorm.io/gorm"
+)
+
+const (
+	PasswordResetChannelEmail = "email" // 通过邮件发送重置链接
+	PasswordResetChannelSMS   = "sms"   // 通过短信发送验证码
+
+	passwordResetSMSScene       = "password_reset"                 // 找回密码短信验证码用途
+	passwordResetTokenKeyPrefix = "admin:password:reset:token:"    // 邮件重置令牌摘要缓存键前缀
+	passwordResetIntervalPrefix = "admin:password:reset:interval:" // 账号申请间隔缓存键前缀
+	passwordResetDailyPrefix    = "admin:password:reset:daily:"    // 账号每日申请次数缓存键前缀
+	passwordResetIPPrefix       = "admin:password:reset:ip:"       // IP每小时申请次数缓存键前缀
+	passwordResetAttemptPrefix  = "admin:password:reset:attempt:"  // 账号与IP每小时重置尝试次数缓存键前缀
+)
+
+var (
+	errPasswordResetTooFrequent = errors.New("找回密码申请过于频繁, 请稍后再试")
+	errPasswordResetInvalid     = errors.New("重置验证码无效或已过期")
+	errPasswordResetAttempts    = errors.New("重置密码尝试次数过多, 请稍后再试")
+)
+
+// PasswordResetService 管理员找回密码服务
+// - 申请时无论账号是否存在都返回成功，重置时账号不存在与验证码错误返回相同错误，避免通过接口探测账号
+// - 重置密码按账号与IP限制尝试次数，避免暴力猜测短信验证码
+// - 邮件重置令牌与短信验证码均为一次性，重置成功后吊销管理员的全部会话与刷新令牌
+type PasswordResetService interface {
+	// Forgot 申请找回密码，通过邮件发送重置链接或通过短信发送验证码
+	// @param ctx 上下文
+	// @param clientIP 客户端IP
+	// @param userAgent 客户端设备
+	// @param bodyParams 找回密码参数
+	// @return error 错误
+	Forgot(ctx context.Context, clientIP string, userAgent string, bodyParams *dto.ForgotPasswordParams) error
+	// Reset 凭重置令牌或短信验证码重置密码
+	// @param ctx 上下文
+	// @param clientIP 客户端IP
+	// @param userAgent 客户端设备
+	// @param bodyParams 重置密码参数
+	// @return error 错误
+	Reset(ctx context.Context, clientIP string, userAgent string, bodyParams *dto.ResetPasswordParams) error
+}
+
+// PasswordResetServiceImpl 管理员找回密码服务实现
+type PasswordResetServiceImpl struct {
+	appName         string
+	db              *gorm.DB
+	cache           cache.Cache
+	jwt             *utils.JWT
+	logger          *slog.Logger
+	adminRepo       repo.AdminRepo
+	mailer          mailer.Mailer
+	smsCode         *sms.CodeManager
+	passwordService PasswordService
+	sessionService  SessionService
+	auditService    AuditService
+	captcha         captcha.Manager
+	config          *config.PasswordResetConfig
+}
+
+// NewPasswordResetService 创建一个管理员找回密码服务
+// - mailer 为 nil 时不支持邮件找回，smsCode 为 nil 时不支持短信找回
+// - captchaManager 不为 nil 时申请找回密码须通过图形验证码
+func NewPasswordResetService(appName string, db *gorm.DB, cache cache.Cache, jwt *utils.JWT, m mailer.Mailer, smsCode *sms.CodeManager, passwordService PasswordService, sessionService SessionService, auditService AuditService, captchaManager captcha.Manager, cfg *config.PasswordResetConfig, logger *slog.Logger) PasswordResetService {
+	return &PasswordResetServiceImpl{
+		appName:         appName,
+		db:              db,
+		cache:           cache,
+		jwt:             jwt,
+		logger:          logger,
+		adminRepo:       repo.NewAdminRepo(),
+		mailer:          m,
+		smsCode:         smsCode,
+		passwordService: passwordService,
+		sessionService:  sessionService,
+		auditService:    auditService,
+		captcha:         captchaManager,
+		config:          cfg,
+	}
+}
+
+// Forgot 申请找回密码
+func (s *PasswordResetServiceImpl) Forgot(ctx context.Context, clientIP string, userAgent string, bodyParams *dto.ForgotPasswordParams) error {
+	if err := s.checkChannel(bodyParams.Channel); err != nil {
+		return err
+	}
+	if s.captcha != nil && !s.captcha.Verify(ctx, bodyParams.CaptchaID, bodyParams.CaptchaCode) {
+		return errors.New("请输入正确的图形验证码")
+	}
+
+	log := s.auditLog("找回密码", clientIP, userAgent, bodyParams.Username, bodyParams.Channel)
+	defer s.recordAudit(ctx, log)
+
+	// 按IP与用户名限流（在查询账号之前，不存在的账号同样计数）
+	if err := s.throttle(ctx, clientIP, bodyParams.Username); err != nil {
+		log.Message = err.Error()
+		return err
+	}
+
+	admin, err := s.adminRepo.Find(ctx, usernameBuilder(ctx, s.db, bodyParams.Username))
+	if err != nil {
+		log.Message = "管理员不存在"
+		return nil
+	}
+	log.AdminID = admin.ID
+	if admin.Status == database.AdminStatusDisabled {
+		log.Message = "管理员已禁用"
+		return nil
+	}
+
+	switch bodyParams.Channel {
+	case PasswordResetChannelEmail:
+		err = s.sendEmail(ctx, admin)
+	case PasswordResetChannelSMS:
+		err = s.sendSMS(ctx, admin)
+	}
+	if err != nil {
+		// 发送失败不返回给客户端，避免暴露账号是否绑定邮箱或手机号
+		log.Message = err.Error()
+		logging.FromContext(ctx).Warn("发送找回密码验证失败", "admin_id", admin.ID, "channel", bodyParams.Channel, "error", err)
+		return nil
+	}
+	log.Success = true
+	return nil
+}
+
+// Reset 凭重置令牌或短信验证码重置密码
+func (s *PasswordResetServiceImpl) Reset(ctx context.Context, clientIP string, userAgent string, bodyParams *dto.ResetPasswordParams) error {
+	if err := s.checkChannel(bodyParams.Channel); err != nil {
+		return err
+	}
+	if bodyParams.Password != bodyParams.ConfirmPassword {
+		return errors.New("两次输入的密码不一致")
+	}
+
+	// 先校验密码复杂度（与账号无关），避免复杂度不通过时消耗一次性验证码
+	if err := s.passwordService.Validate(ctx, nil, bodyParams.Password); err != nil {
+		return err
+	}
+
+	log := s.auditLog("重置密码", clientIP, userAgent, bodyParams.Username, bodyParams.Channel)
+	defer s.recordAudit(ctx, log)
+
+	// 按账号与IP限制尝试次数（在查询账号之前，不存在的账号同样计数）
+	if err := s.throttleAttempt(ctx, clientIP, bodyParams.Username); err != nil {
+		log.Message = err.Error()
+		return err
+	}
+
+	admin, err := s.adminRepo.Find(ctx, usernameBuilder(ctx, s.db, bodyParams.Username))
+	if err != nil || admin.Status == database.AdminStatusDisabled {
+		log.Message = "管理员不存在或已禁用"
+		return errPasswordResetInvalid
+	}
+	log.AdminID = admin.ID
+
+	if !s.verify(ctx, admin, bodyParams.Channel, bodyParams.Token) {
+		log.Message = errPasswordResetInvalid.Error()
+		return errPasswordResetInvalid
+	}
+	// 历史密码与账号相关，须在验证码通过后校验，避免未持有验证码时探测账号
+	if err := s.passwordService.Validate(ctx, admin, bodyParams.Password); err != nil {
+		log.Message = err.Error()
+		return err
+	}
+
+	if err := admin.GeneratePasswordHash(bodyParams.Password); err != nil {
+		return err
+	}
+	if admin.Status == database.AdminStatusLocked {
+		admin.Status = database.AdminStatusEnabled
+	}
+	admin.PasswordChangedAt = time.Now()
+	admin.FailedLoginAttempts = 0
+	admin.LockoutCount = 0
+	if err := s.adminRepo.Update(ctx, utils.NewGormBuilder(ctx, s.db), admin); err != nil {
+		log.Message = "重置密码失败"
+		return fmt.Errorf("重置密码失败: %w", err)
+	}
+	if err := s.passwordService.Record(ctx, admin); err != nil {
+		logging.FromContext(ctx).Warn("记录历史密码失败", "admin_id", admin.ID, "error", err)
+	}
+
+	// 密码已重置，已登录的设备须重新登录
+	if _, err := s.sessionService.RevokeAll(ctx, admin.ID, ""); err != nil {
+		logging.FromContext(ctx).Warn("吊销登录会话失败", "admin_id", admin.ID, "error", err)
+	}
+	if s.jwt != nil {
+		if err := s.jwt.RevokeUserRefreshTokens(admin.ID); err != nil {
+			logging.FromContext(ctx).Warn("吊销刷新令牌失败", "admin_id", admin.ID, "error", err)
+		}
+	}
+	log.Success = true
+	return nil
+}
+
+// checkChannel 检查是否启用找回密码及找回方式
+func (s *PasswordResetServiceImpl) checkChannel(channel string) error {
+	if s.config == nil || !s.config.Enabled || s.cache == nil {
+		return errors.New("未启用找回密码")
+	}
+	switch channel {
+	case PasswordResetChannelEmail:
+		if s.mailer == nil {
+			return errors.New("未启用邮件找回密码")
+		}
+	case PasswordResetChannelSMS:
+		if s.smsCode == nil {
+			return errors.New("未启用短信找回密码")
+		}
+	default:
+		return fmt.Errorf("不支持的找回方式: %s", channel)
+	}
+	return nil
+}
+
+// throttle 找回密码申请限流（同一IP每小时上限、同一账号申请间隔与每日上限）
+func (s *PasswordResetServiceImpl) throttle(ctx context.Context, clientIP string, username string) error {
+	if s.config.IPLimit > 0 {
+		if exceeded, err := s.exceeded(ctx, passwordResetIPPrefix+clientIP, s.config.IPLimit, time.Hour); err != nil {
+			return err
+		} else if exceeded {
+			return errPasswordResetTooFrequent
+		}
+	}
+
+	intervalKey := passwordResetIntervalPrefix + username
+	if exists, err := s.cache.Exists(ctx, intervalKey); err != nil {
+		return fmt.Errorf("检查找回密码申请间隔失败: %w", err)
+	} else if exists {
+		return errPasswordResetTooFrequent
+	}
+	if s.config.DailyLimit > 0 {
+		dailyKey := passwordResetDailyPrefix + username + ":" + time.Now().Format("20060102")
+		if exceeded, err := s.exceeded(ctx, dailyKey, s.config.DailyLimit, 24*time.Hour); err != nil {
+			return err
+		} else if exceeded {
+			return errors.New("今日找回密码申请次数已达上限")
+		}
+	}
+	if s.config.Interval > 0 {
+		if err := s.cache.Set(ctx, intervalKey, 1, s.config.Interval); err != nil {
+			s.logger.Warn("保存找回密码申请间隔失败", "error", err)
+		}
+	}
+	return nil
+}
+
+// throttleAttempt 重置密码尝试限流（同一账号、同一IP每小时上限）
+func (s *PasswordResetServiceImpl) throttleAttempt(ctx context.Context, clientIP string, username string) error {
+	if s.config.AttemptLimit <= 0 {
+		return nil
+	}
+	for _, key := range []string{passwordResetAttemptPrefix + "ip:" + clientIP, passwordResetAttemptPrefix + "user:" + username} {
+		if exceeded, err := s.exceeded(ctx, key, s.config.AttemptLimit, time.Hour); err != nil {
+			return err
+		} else if exceeded {
+			return errPasswordResetAttempts
+		}
+	}
+	return nil
+}
+
+// exceeded 累计次数并判断是否超过上限（首次计数时设置统计窗口）
+func (s *PasswordResetServiceImpl) exceeded(ctx context.Context, key string, limit int, window time.Duration) (bool, error) {
+	count, err := s.cache.Increment(ctx, key, 1)
+	if err != nil {
+		return false, fmt.Errorf("统计找回密码申请次数失败: %w", err)
+	}
+	if count == 1 {
+		if err := s.cache.Expire(ctx, key, window); err != nil {
+			s.logger.Warn("设置找回密码申请次数过期时间失败", "error", err)
+		}
+	}
+	return count > int64(limit), nil
+}
+
+// sendEmail 生成重置令牌并发送重置邮件（缓存中仅保存令牌摘要，重新申请后旧令牌失效）
+func (s *PasswordResetServiceImpl) sendEmail(ctx context.Context, admin *database.Admin) error {
+	if admin.Email == "" {
+		return errors.New("管理员未绑定邮箱")
+	}
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Errorf("生成重置令牌失败: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+	key := passwordResetTokenKey(admin.ID)
+	if err := s.cache.Set(ctx, key, passwordResetTokenHash(token), s.config.TokenTTL); err != nil {
+		return fmt.Errorf("保存重置令牌失败: %w", err)
+	}
+
+	reset := "重置令牌：" + token
+	if s.config.ResetURL != "" {
+		reset = "重置链接：" + strings.ReplaceAll(s.config.ResetURL, "${token}", url.QueryEscape(token))
+	}
+	msg := &mailer.Message{
+		To:      []string{admin.Email},
+		Subject: fmt.Sprintf("[%s] 找回密码", s.appName),
+		Body: fmt.Sprintf(
+			"您好 %s：\n\n您的账号 %s 正在申请找回密码，%s\n\n有效期至 %s，仅可使用一次。\n\n如非本人操作，请忽略本邮件。",
+			admin.Nickname, admin.Username, reset, time.Now().Add(s.config.TokenTTL).Format(time.DateTime),
+		),
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		_ = s.cache.Delete(ctx, key)
+		return fmt.Errorf("发送找回密码邮件失败: %w", err)
+	}
+	return nil
+}
+
+// sendSMS 发送找回密码短信验证码
+func (s *PasswordResetServiceImpl) sendSMS(ctx context.Context, admin *database.Admin) error {
+	if admin.Telephone == "" {
+		return errors.New("管理员未绑定手机号")
+	}
+	return s.smsCode.Send(ctx, passwordResetSMSScene, admin.Telephone)
+}
+
+// verify 校验重置令牌或短信验证码（通过后失效）
+func (s *PasswordResetServiceImpl) verify(ctx context.Context, admin *database.Admin, channel string, token string) bool {
+	if channel == PasswordResetChannelSMS {
+		return admin.Telephone != "" && s.smsCode.Verify(ctx, passwordResetSMSScene, admin.Telephone, token)
+	}
+
+	key := passwordResetTokenKey(admin.ID)
+	hash, err := s.cache.Get(ctx, key)
+	if err != nil || hash == "" {
+		return false
+	}
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(passwordResetTokenHash(strings.TrimSpace(token)))) != 1 {
+		return false
+	}
+	if err := s.cache.Delete(ctx, key); err != nil {
+		s.logger.Warn("删除重置令牌失败", "admin_id", admin.ID, "error", err)
+	}
+	return true
+}
+
+// auditLog 创建找回密码审计日志
+func (s *PasswordResetServiceImpl) auditLog(name string, clientIP string, userAgent string, username string, channel string) *database.AdminAuditLog {
+	return &database.AdminAuditLog{
+		Name:      name,
+		Method:    http.MethodPost,
+		Params:    url.Values{"username": {username}, "channel": {channel}}.Encode(),
+		IP:        clientIP,
+		UserAgent: userAgent,
+		Status:    http.StatusOK,
+	}
+}
+
+// recordAudit 记录审计日志（失败只输出日志）
+func (s *PasswordResetServiceImpl) recordAudit(ctx context.Context, log *database.AdminAuditLog) {
+	if s.auditService == nil {
+		return
+	}
+	if err := s.auditService.Record(context.WithoutCancel(ctx), log); err != nil {
+		logging.FromContext(ctx).Error("记录审计日志失败", "name", log.Name, "error", err)
+	}
+}
+
+// passwordResetTokenKey 邮件重置令牌缓存键
+func passwordResetTokenKey(adminID uint) string {
+	return fmt.Sprintf("%s%d", passwordResetTokenKeyPrefix, adminID)
+}
+
+// passwordResetTokenHash 计算重置令牌摘要
+func passwordResetTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/so68/core/cache"
+	"github.com/so68/core/config"
+	"github.com/so68/core/mailer"
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/sms"
+	"gorm.io/gorm"
+)
+
+/*
+找回密码测试
+
+本文件用于测试管理员通过邮件或短信找回密码。
+
+运行命令：
+go test -v -run "^TestPasswordResetService.*$"
+
+测试内容：
+1. 未启用或未配置找回方式时拒绝申请
+2. 账号不存在时同样返回成功且不发送 (Forgot)
+3. 申请间隔与IP限流
+4. 邮件重置令牌一次性使用，重置后解锁账户 (Reset)
+5. 短信验证码重置密码与审计日志
+6. 重置时不区分账号是否存在，验证码通过后才校验历史密码，按账号与IP限制尝试次数 (Reset)
+*/
+
+// recordMailer 记录发送的邮件
+type recordMailer struct {
+	mu       sync.Mutex
+	messages []*mailer.Message
+}
+
+func (m *recordMailer) Send(ctx context.Context, msg *mailer.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+func (m *recordMailer) Close() error {
+	return nil
+}
+
+// recordSMSSender 记录发送的短信
+type recordSMSSender struct {
+	mu       sync.Mutex
+	messages []*sms.Message
+}
+
+func (r *recordSMSSender) Send(ctx context.Context, msg *sms.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, msg)
+	return nil
+}
+
+func TestPasswordResetService(t *testing.T) {
+	_, db := newAdminTestService(t)
+	if err := db.Session(&gorm.Session{AllowGlobalUpdate: true}).Unscoped().Delete(&models.AdminAuditLog{}).Error; err != nil {
+		t.Fatalf("clear audit logs failed: %v", err)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	cacheConfig := &config.CacheConfig{Driver: "memory", CleanupInterval: time.Minute}
+	cacheConfig.SetDefaults()
+	memory, err := cache.NewMemoryCache(cacheConfig, logger)
+	if err != nil {
+		t.Fatalf("create memory cache failed: %v", err)
+	}
+	defer memory.Close()
+
+	sender := &recordSMSSender{}
+	smsCode, err := sms.NewCodeManager(config.DefaultSMSCodeConfig(), sender, memory, logger)
+	if err != nil {
+		t.Fatalf("NewCodeManager failed: %v", err)
+	}
+	mail := &recordMailer{}
+	resetConfig := config.DefaultPasswordResetConfig()
+	resetConfig.ResetURL = "https://admin.example.com/reset?token=${token}"
+	resetConfig.IPLimit = 5
+	newService := func(cfg *config.PasswordResetConfig, m mailer.Mailer) PasswordResetService {
+		return NewPasswordResetService("core", db, memory, nil, m, smsCode, NewPasswordService(db, nil, logger), NewSessionService(memory, logger), NewAuditService(db, logger), nil, cfg, logger)
+	}
+
+	// 未启用或未配置邮件时拒绝申请
+	forgot := &dto.ForgotPasswordParams{Username: "root", Channel: PasswordResetChannelEmail}
+	assertError(t, newService(config.DefaultPasswordResetConfig(), mail).Forgot(ctx, "10.0.0.1", "test", forgot), "未启用找回密码")
+	resetConfig.Enabled = true
+	assertError(t, newService(resetConfig, nil).Forgot(ctx, "10.0.0.1", "test", forgot), "未启用邮件找回密码")
+	s := newService(resetConfig, mail)
+
+	root := createTestAdmin(t, db, "root", models.AdminTypeSuper, RoleSuperAdmin, 0)
+	if err := db.Model(root).Updates(map[string]interface{}{"email": "root@example.com", "telephone": "+8613800000000", "status": models.AdminStatusLocked, "locked_until": time.Now().Add(time.Hour)}).Error; err != nil {
+		t.Fatalf("update admin failed: %v", err)
+	}
+
+	// 账号不存在时同样返回成功，且不发送邮件
+	if err := s.Forgot(ctx, "10.0.0.1", "test", &dto.ForgotPasswordParams{Username: "nobody", Channel: PasswordResetChannelEmail}); err != nil {
+		t.Fatalf("Forgot unknown user failed: %v", err)
+	}
+	if len(mail.messages) != 0 {
+		t.Fatalf("unknown user should not receive mail, got %d", len(mail.messages))
+	}
+
+	// 发送重置邮件，申请间隔内不能再次申请
+	if err := s.Forgot(ctx, "10.0.0.1", "test", forgot); err != nil {
+		t.Fatalf("Forgot failed: %v", err)
+	}
+	if len(mail.messages) != 1 || mail.messages[0].To[0] != "root@example.com" {
+		t.Fatalf("unexpected mails: %+v", mail.messages)
+	}
+	match := regexp.MustCompile(`token=([0-9a-f]{64})`).FindStringSubmatch(mail.messages[0].Body)
+	if match == nil {
+		t.Fatalf("reset link not found in mail: %s", mail.messages[0].Body)
+	}
+	token := match[1]
+	if err := s.Forgot(ctx, "10.0.0.1", "test", forgot); !errors.Is(err, errPasswordResetTooFrequent) {
+		t.Fatalf("Forgot within interval error = %v, want too frequent", err)
+	}
+
+	// 重置密码
+	reset := &dto.ResetPasswordParams{Username: "root", Channel: PasswordResetChannelEmail, Token: "wrong", Password: "newpass123", ConfirmPassword: "newpass123"}
+	assertError(t, s.Reset(ctx, "10.0.0.1", "test", reset), "重置验证码无效或已过期")
+	reset.Token, reset.ConfirmPassword = token, "other"
+	assertError(t, s.Reset(ctx, "10.0.0.1", "test", reset), "两次输入的密码不一致")
+	reset.ConfirmPassword = "newpass123"
+	if err := s.Reset(ctx, "10.0.0.1", "test", reset); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	assertError(t, s.Reset(ctx, "10.0.0.1", "test", reset), "重置验证码无效或已过期")
+
+	admin := &models.Admin{}
+	if err := db.First(admin, root.ID).Error; err != nil {
+		t.Fatalf("find admin failed: %v", err)
+	}
+	if !admin.CompareHashAndPassword("newpass123") || admin.Status != models.AdminStatusEnabled {
+		t.Fatalf("password should be reset and admin unlocked, status = %d", admin.Status)
+	}
+
+	// 短信验证码重置密码
+	if err := s.Forgot(ctx, "10.0.0.2", "test", &dto.ForgotPasswordParams{Username: "root", Channel: PasswordResetChannelSMS}); !errors.Is(err, errPasswordResetTooFrequent) {
+		t.Fatalf("Forgot within interval error = %v, want too frequent", err)
+	}
+	if err := memory.Delete(ctx, passwordResetIntervalPrefix+"root"); err != nil {
+		t.Fatalf("clear interval failed: %v", err)
+	}
+	if err := s.Forgot(ctx, "10.0.0.2", "test", &dto.ForgotPasswordParams{Username: "root", Channel: PasswordResetChannelSMS}); err != nil {
+		t.Fatalf("Forgot by sms failed: %v", err)
+	}
+	if len(sender.messages) != 1 {
+		t.Fatalf("sms messages = %d, want 1", len(sender.messages))
+	}
+	reset = &dto.ResetPasswordParams{Username: "root", Channel: PasswordResetChannelSMS, Token: sender.messages[0].Params["code"], Password: "smspass123", ConfirmPassword: "smspass123"}
+	if err := s.Reset(ctx, "10.0.0.2", "test", reset); err != nil {
+		t.Fatalf("Reset by sms failed: %v", err)
+	}
+
+	// IP每小时申请上限（不存在的账号同样计数）
+	for _, username := range []string{"a", "b"} {
+		if err := s.Forgot(ctx, "10.0.0.1", "test", &dto.ForgotPasswordParams{Username: username, Channel: PasswordResetChannelEmail}); err != nil {
+			t.Fatalf("Forgot %s failed: %v", username, err)
+		}
+	}
+	if err := s.Forgot(ctx, "10.0.0.1", "test", &dto.ForgotPasswordParams{Username: "c", Channel: PasswordResetChannelEmail}); !errors.Is(err, errPasswordResetTooFrequent) {
+		t.Fatalf("Forgot over ip limit error = %v, want too frequent", err)
+	}
+
+	var logs []*models.AdminAuditLog
+	if err := db.Where("admin_id = ? AND success = ?", root.ID, true).Order("id").Find(&logs).Error; err != nil {
+		t.Fatalf("find audit logs failed: %v", err)
+	}
+	if len(logs) != 4 || logs[0].Name != "找回密码" || logs[1].Name != "重置密码" || logs[1].IP != "10.0.0.1" {
+		t.Fatalf("unexpected audit logs: %d", len(logs))
+	}
+}
+
+func TestPasswordResetService_Reset(t *testing.T) {
+	_, db := newAdminTestService(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	cacheConfig := &config.CacheConfig{Driver: "memory", CleanupInterval: time.Minute}
+	cacheConfig.SetDefaults()
+	memory, err := cache.NewMemoryCache(cacheConfig, logger)
+	if err != nil {
+		t.Fatalf("create memory cache failed: %v", err)
+	}
+	defer memory.Close()
+
+	resetConfig := config.DefaultPasswordResetConfig()
+	resetConfig.Enabled = true
+	resetConfig.AttemptLimit = 3
+	policy := &config.PasswordPolicyConfig{MinLength: 8, HistoryCount: 3}
+	s := NewPasswordResetService("core", db, memory, nil, &recordMailer{}, nil, NewPasswordService(db, policy, logger), NewSessionService(memory, logger), nil, nil, resetConfig, logger)
+	root := createTestAdmin(t, db, "root", models.AdminTypeSuper, RoleSuperAdmin, 0)
+
+	// 密码复杂度与账号无关，账号存在与否返回相同错误
+	for _, username := range []string{"root", "nobody"} {
+		reset := &dto.ResetPasswordParams{Username: username, Channel: PasswordResetChannelEmail, Token: "wrong", Password: "short", ConfirmPassword: "short"}
+		assertError(t, s.Reset(ctx, "10.0.0.1", "test", reset), "密码长度不能少于 8 位")
+	}
+
+	// 验证码错误时不校验历史密码，账号不存在同样返回验证码无效
+	for _, username := range []string{"root", "nobody"} {
+		reset := &dto.ResetPasswordParams{Username: username, Channel: PasswordResetChannelEmail, Token: "wrong", Password: "password123", ConfirmPassword: "password123"}
+		assertError(t, s.Reset(ctx, "10.0.0.1", "test", reset), "重置验证码无效或已过期")
+	}
+
+	// 验证码通过后校验历史密码
+	token := "0123456789abcdef"
+	if err := memory.Set(ctx, passwordResetTokenKey(root.ID), passwordResetTokenHash(token), time.Minute); err != nil {
+		t.Fatalf("set token failed: %v", err)
+	}
+	reset := &dto.ResetPasswordParams{Username: "root", Channel: PasswordResetChannelEmail, Token: token, Password: "password123", ConfirmPassword: "password123"}
+	assertError(t, s.Reset(ctx, "10.0.0.2", "test", reset), "不能使用最近 3 次使用过的密码")
+	// 历史密码校验失败时验证码已失效
+	assertError(t, s.Reset(ctx, "10.0.0.1", "test", reset), "重置验证码无效或已过期")
+
+	// 同一账号超过尝试上限后，更换IP也不能继续尝试
+	assertError(t, s.Reset(ctx, "10.0.0.3", "test", reset), "重置密码尝试次数过多, 请稍后再试")
+
+	// 同一IP超过尝试上限后，其他账号也不能继续尝试
+	reset.Username = "other"
+	assertError(t, s.Reset(ctx, "10.0.0.1", "test", reset), "重置密码尝试次数过多, 请稍后再试")
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/so68/core/config"
+	models "github.com/so68/core/server/database"
+)
+
+/*
+密码策略测试
+
+本文件用于测试管理员密码策略，
+包括复杂度校验、禁止重复使用最近的密码与密码过期。
+
+运行命令：
+go test -v -run "^TestPasswordService.*$"
+
+测试内容：
+1. 密码复杂度 (Validate)
+2. 历史密码记录与重复使用 (Record, Validate)
+3. 密码过期 (Expired)
+*/
+
+func TestPasswordService_Complexity(t *testing.T) {
+	policy := &config.PasswordPolicyConfig{MinLength: 8, RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSymbol: true}
+	s := NewPasswordService(adminTestDB, policy, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	tests := []struct {
+		name     string
+		password string
+		wantErr  string
+	}{
+		{name: "符合策略", password: "Abcdef1!"},
+		{name: "长度不足", password: "Ab1!", wantErr: "密码长度不能少于 8 位"},
+		{name: "缺少大写字母", password: "abcdef1!", wantErr: "密码必须包含大写字母"},
+		{name: "缺少小写字母", password: "ABCDEF1!", wantErr: "密码必须包含小写字母"},
+		{name: "缺少数字", password: "Abcdefg!", wantErr: "密码必须包含数字"},
+		{name: "缺少特殊字符", password: "Abcdefg1", wantErr: "密码必须包含特殊字符"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertError(t, s.Validate(context.Background(), nil, tt.password), tt.wantErr)
+		})
+	}
+}
+
+func TestPasswordService_History(t *testing.T) {
+	_, db := newAdminTestService(t)
+	policy := &config.PasswordPolicyConfig{MinLength: 8, HistoryCount: 2}
+	s := NewPasswordService(db, policy, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	admin := createTestAdmin(t, db, "root", models.AdminTypeSuper, RoleSuperAdmin, 0)
+	ctx := context.Background()
+
+	// 依次使用 password123 -> password456 -> password789
+	if err := s.Record(ctx, admin); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	for _, password := range []string{"password456", "password789"} {
+		if err := s.Validate(ctx, admin, password); err != nil {
+			t.Fatalf("Validate %s failed: %v", password, err)
+		}
+		if err := admin.GeneratePasswordHash(password); err != nil {
+			t.Fatalf("GeneratePasswordHash failed: %v", err)
+		}
+		if err := s.Record(ctx, admin); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+
+	var count int64
+	db.Model(&models.AdminPasswordHistory{}).Where("admin_id = ?", admin.ID).Count(&count)
+	if count != 2 {
+		t.Errorf("history count = %d, want 2", count)
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		wantErr  string
+	}{
+		{name: "当前密码", password: "password789", wantErr: "不能使用最近 2 次使用过的密码"},
+		{name: "上一次密码", password: "password456", wantErr: "不能使用最近 2 次使用过的密码"},
+		{name: "超出保留数量的旧密码", password: "password123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertError(t, s.Validate(ctx, admin, tt.password), tt.wantErr)
+		})
+	}
+}
+
+func TestPasswordService_Expired(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		policy *config.PasswordPolicyConfig
+		admin  *models.Admin
+		want   bool
+	}{
+		{name: "未配置策略", policy: nil, admin: &models.Admin{PasswordChangedAt: now.AddDate(-1, 0, 0)}, want: false},
+		{name: "永不过期", policy: &config.PasswordPolicyConfig{}, admin: &models.Admin{PasswordChangedAt: now.AddDate(-1, 0, 0)}, want: false},
+		{name: "未过期", policy: &config.PasswordPolicyConfig{ExpireDays: 90}, admin: &models.Admin{PasswordChangedAt: now.AddDate(0, 0, -30)}, want: false},
+		{name: "已过期", policy: &config.PasswordPolicyConfig{ExpireDays: 90}, admin: &models.Admin{PasswordChangedAt: now.AddDate(0, 0, -91)}, want: true},
+		{
+			name:   "未记录修改时间按创建时间计算",
+			policy: &config.PasswordPolicyConfig{ExpireDays: 90},
+			admin:  func() *models.Admin { a := &models.Admin{}; a.CreatedAt = now.AddDate(0, 0, -91); return a }(),
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewPasswordService(adminTestDB, tt.policy, logger)
+			if got := s.Expired(tt.admin); got != tt.want {
+				t.Errorf("Expired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/so68/core/cache"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/utils"
+)
+
+// SessionService 登录会话服务（会话保存在缓存中，吊销后对应的访问令牌与刷新令牌立即失效）
+type SessionService interface {
+	// Create 记录登录会话
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @param pair 登录签发的令牌
+	// @param ip 登录IP
+	// @param userAgent 登录设备
+	// @return error 错误
+	Create(ctx context.Context, adminID uint, pair *utils.TokenPair, ip string, userAgent string) error
+	// Touch 刷新令牌后更新会话
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @param pair 刷新签发的令牌
+	// @param ip 客户端IP
+	// @return error 错误
+	Touch(ctx context.Context, adminID uint, pair *utils.TokenPair, ip string) error
+	// List 获取管理员的活跃会话
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @param currentSessionID 当前会话ID
+	// @return []*dto.SessionInfo 会话列表(按登录时间倒序)
+	// @return error 错误
+	List(ctx context.Context, adminID uint, currentSessionID string) ([]*dto.SessionInfo, error)
+	// Revoke 吊销指定会话
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @param sessionID 会话ID
+	// @return error 错误
+	Revoke(ctx context.Context, adminID uint, sessionID string) error
+	// RevokeAll 吊销管理员的全部会话
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @param exceptSessionID 保留的会话ID(为空时吊销全部)
+	// @return int 吊销数量
+	// @return error 错误
+	RevokeAll(ctx context.Context, adminID uint, exceptSessionID string) (int, error)
+	// Validate 校验会话是否有效，用于 utils.JWT 会话校验
+	// @param ctx 上下文
+	// @param claims 令牌声明
+	// @return error 错误
+	Validate(ctx context.Context, claims *utils.Claims) error
+}
+
+// SessionServiceImpl 登录会话服务实现
+type SessionServiceImpl struct {
+	cache  cache.Cache
+	logger *slog.Logger
+}
+
+// NewSessionService 创建一个登录会话服务
+func NewSessionService(cache cache.Cache, logger *slog.Logger) SessionService {
+	return &SessionServiceImpl{cache: cache, logger: logger}
+}
+
+// sessionKey 会话缓存Key
+func sessionKey(adminID uint, sessionID string) string {
+	return fmt.Sprintf("admin:session:%d:%s", adminID, sessionID)
+}
+
+// Create 记录登录会话
+func (s *SessionServiceImpl) Create(ctx context.Context, adminID uint, pair *utils.TokenPair, ip string, userAgent string) error {
+	if s.cache == nil {
+		return nil
+	}
+	now := time.Now()
+	session := &dto.SessionInfo{
+		ID:           pair.SessionID,
+		IP:           ip,
+		UserAgent:    userAgent,
+		DeviceID:     DeviceFingerprint(ip, userAgent),
+		CreatedAt:    now,
+		LastActiveAt: now,
+		ExpiresAt:    pair.ExpiresAt,
+	}
+	return s.save(ctx, adminID, session)
+}
+
+// Touch 刷新令牌后更新会话
+func (s *SessionServiceImpl) Touch(ctx context.Context, adminID uint, pair *utils.TokenPair, ip string) error {
+	if s.cache == nil {
+		return nil
+	}
+	session, err := s.find(ctx, adminID, pair.SessionID)
+	if err != nil {
+		return err
+	}
+	session.IP = ip
+	session.LastActiveAt = time.Now()
+	session.ExpiresAt = pair.ExpiresAt
+	return s.save(ctx, adminID, session)
+}
+
+// List 获取管理员的活跃会话
+func (s *SessionServiceImpl) List(ctx context.Context, adminID uint, currentSessionID string) ([]*dto.SessionInfo, error) {
+	if s.cache == nil {
+		return nil, errors.New("会话管理需要启用缓存")
+	}
+	keys, err := s.cache.Keys(ctx, sessionKey(adminID, "*"))
+	if err != nil {
+		return nil, fmt.Errorf("查询会话失败: %w", err)
+	}
+	if len(keys) == 0 {
+		return []*dto.SessionInfo{}, nil
+	}
+	values, err := s.cache.MGet(ctx, keys...)
+	if err != nil {
+		return nil, fmt.Errorf("查询会话失败: %w", err)
+	}
+
+	sessions := make([]*dto.SessionInfo, 0, len(values))
+	for _, value := range values {
+		// 查询期间过期的会话返回 nil
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+		session := &dto.SessionInfo{}
+		if err := json.Unmarshal([]byte(raw), session); err != nil {
+			s.logger.Warn("解析会话失败", "admin_id", adminID, "error", err)
+			continue
+		}
+		session.Current = session.ID == currentSessionID
+		sessions = append(sessions, session)
+	}
+	slices.SortFunc(sessions, func(a, b *dto.SessionInfo) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return sessions, nil
+}
+
+// Revoke 吊销指定会话
+func (s *SessionServiceImpl) Revoke(ctx context.Context, adminID uint, sessionID string) error {
+	if s.cache == nil {
+		return errors.New("会话管理需要启用缓存")
+	}
+	exists, err := s.cache.Exists(ctx, sessionKey(adminID, sessionID))
+	if err != nil {
+		return fmt.Errorf("查询会话失败: %w", err)
+	}
+	if !exists {
+		return errors.New("会话不存在或已过期")
+	}
+	if err := s.cache.Delete(ctx, sessionKey(adminID, sessionID)); err != nil {
+		return fmt.Errorf("吊销会话失败: %w", err)
+	}
+	return nil
+}
+
+// RevokeAll 吊销管理员的全部会话
+func (s *SessionServiceImpl) RevokeAll(ctx context.Context, adminID uint, exceptSessionID string) (int, error) {
+	if s.cache == nil {
+		return 0, errors.New("会话管理需要启用缓存")
+	}
+	keys, err := s.cache.Keys(ctx, sessionKey(adminID, "*"))
+	if err != nil {
+		return 0, fmt.Errorf("查询会话失败: %w", err)
+	}
+	if exceptSessionID != "" {
+		except := sessionKey(adminID, exceptSessionID)
+		keys = slices.DeleteFunc(keys, func(key string) bool {
+			return key == except
+		})
+	}
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	if err := s.cache.MDelete(ctx, keys...); err != nil {
+		return 0, fmt.Errorf("吊销会话失败: %w", err)
+	}
+	return len(keys), nil
+}
+
+// Validate 校验会话是否有效（未启用缓存时跳过；令牌不含会话ID或缓存异常时拒绝）
+func (s *SessionServiceImpl) Validate(ctx context.Context, claims *utils.Claims) error {
+	if s.cache == nil {
+		return nil
+	}
+	if claims.SessionID == "" {
+		return errors.New("session required")
+	}
+	exists, err := s.cache.Exists(ctx, sessionKey(claims.UserID, claims.SessionID))
+	if err != nil {
+		return fmt.Errorf("查询会话失败: %w", err)
+	}
+	if !exists {
+		return errors.New("session revoked or expired")
+	}
+	return nil
+}
+
+// find 查询会话
+func (s *SessionServiceImpl) find(ctx context.Context, adminID uint, sessionID string) (*dto.SessionInfo, error) {
+	raw, err := s.cache.Get(ctx, sessionKey(adminID, sessionID))
+	if err != nil {
+		return nil, fmt.Errorf("查询会话失败: %w", err)
+	}
+	session := &dto.SessionInfo{}
+	if err := json.Unmarshal([]byte(raw), session); err != nil {
+		return nil, fmt.Errorf("解析会话失败: %w", err)
+	}
+	return session, nil
+}
+
+// save 保存会话，过期时间与会话令牌一致
+func (s *SessionServiceImpl) save(ctx context.Context, adminID uint, session *dto.SessionInfo) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	if err := s.cache.Set(ctx, sessionKey(adminID, session.ID), string(data), time.Until(session.ExpiresAt)); err != nil {
+		return fmt.Errorf("保存会话失败: %w", err)
+	}
+	return nil
+}
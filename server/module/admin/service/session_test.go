@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/so68/core/cache"
+	"github.com/so68/core/config"
+	"github.com/so68/core/server/utils"
+)
+
+/*
+登录会话测试
+
+本文件用于测试登录会话的校验。
+
+运行命令：
+go test -v -run "^TestSessionService.*$"
+
+测试内容：
+1. 会话存在时通过，吊销后拒绝 (Validate, Revoke)
+2. 令牌不含会话ID时拒绝
+3. 缓存异常时返回错误
+4. 未启用缓存时跳过校验
+*/
+
+// sessionTestFailingCache 查询总是失败的缓存
+type sessionTestFailingCache struct {
+	cache.Cache
+}
+
+func (c *sessionTestFailingCache) Exists(ctx context.Context, key string) (bool, error) {
+	return false, errors.New("connection refused")
+}
+
+func TestSessionService_Validate(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+	cacheConfig := &config.CacheConfig{Driver: "memory", CleanupInterval: time.Minute}
+	cacheConfig.SetDefaults()
+	memory, err := cache.NewMemoryCache(cacheConfig, logger)
+	if err != nil {
+		t.Fatalf("create memory cache failed: %v", err)
+	}
+	defer memory.Close()
+
+	s := NewSessionService(memory, logger)
+	pair := &utils.TokenPair{SessionID: "session-1", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := s.Create(ctx, 1, pair, "127.0.0.1", "test"); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := s.Validate(ctx, &utils.Claims{UserID: 1, SessionID: "session-1"}); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if err := s.Validate(ctx, &utils.Claims{UserID: 2, SessionID: "session-1"}); err == nil {
+		t.Fatal("expected session of other admin rejected")
+	}
+	if err := s.Validate(ctx, &utils.Claims{UserID: 1}); err == nil || err.Error() != "session required" {
+		t.Fatalf("expected session required, got %v", err)
+	}
+
+	if err := s.Revoke(ctx, 1, "session-1"); err != nil {
+		t.Fatalf("Revoke failed: %v", err)
+	}
+	if err := s.Validate(ctx, &utils.Claims{UserID: 1, SessionID: "session-1"}); err == nil {
+		t.Fatal("expected revoked session rejected")
+	}
+
+	// 缓存异常时拒绝
+	failing := NewSessionService(&sessionTestFailingCache{Cache: memory}, logger)
+	if err := failing.Validate(ctx, &utils.Claims{UserID: 1, SessionID: "session-1"}); err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Fatalf("expected cache error, got %v", err)
+	}
+
+	// 未启用缓存时跳过
+	if err := NewSessionService(nil, logger).Validate(ctx, &utils.Claims{UserID: 1}); err != nil {
+		t.Fatalf("expected validation skipped without cache, got %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/so68/core/cache"
+	"github.com/so68/core/logging"
+	models "github.com/so68/core/server/database"
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/server/module/admin/repo"
+	"github.com/so68/core/server/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	// TokenPrefix 机器令牌前缀，用于和 JWT 区分
+	TokenPrefix = "mt_"
+
+	TokenScopeReadOnly = "read-only" // 只读范围：仅允许 ReadOnlyHandler 注册的只读路由
+	TokenScopeReports  = "reports"   // 报表范围：仅允许显式授权的报表路由
+)
+
+// TokenScopes 支持的授权范围
+var TokenScopes = []string{TokenScopeReadOnly, TokenScopeReports}
+
+// ScopeSubject 获取授权范围对应的 casbin 主体
+func ScopeSubject(scope string) string {
+	return "scope:" + scope
+}
+
+// IsMachineToken 判断是否为机器令牌
+func IsMachineToken(token string) bool {
+	return strings.HasPrefix(token, TokenPrefix)
+}
+
+// TokenService 机器令牌服务
+type TokenService interface {
+	// Create 创建机器令牌
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @param params 创建参数
+	// @return *dto.TokenCreateResult 创建结果(包含令牌明文)
+	// @return error 错误
+	Create(ctx context.Context, adminID uint, params *dto.TokenCreateParams) (*dto.TokenCreateResult, error)
+	// List 获取管理员的机器令牌列表
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @return []*models.AdminToken 令牌列表
+	// @return error 错误
+	List(ctx context.Context, adminID uint) ([]*models.AdminToken, error)
+	// Revoke 吊销机器令牌
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @param id 令牌ID
+	// @return error 错误
+	Revoke(ctx context.Context, adminID uint, id uint) error
+	// Authenticate 校验机器令牌
+	// @param ctx 上下文
+	// @param token 令牌明文
+	// @param ip 客户端IP
+	// @return *models.AdminToken 令牌信息
+	// @return error 错误
+	Authenticate(ctx context.Context, token string, ip string) (*models.AdminToken, error)
+}
+
+// TokenServiceImpl 机器令牌服务实现
+type TokenServiceImpl struct {
+	db        *gorm.DB
+	cache     cache.Cache
+	logger    *slog.Logger
+	adminRepo repo.AdminRepo
+	tokenRepo repo.AdminTokenRepo
+}
+
+// NewTokenService 创建一个机器令牌服务
+func NewTokenService(db *gorm.DB, cache cache.Cache, logger *slog.Logger) TokenService {
+	return &TokenServiceImpl{
+		db:        db,
+		cache:     cache,
+		logger:    logger,
+		adminRepo: repo.NewAdminRepo(),
+		tokenRepo: repo.NewAdminTokenRepo(),
+	}
+}
+
+// Create 创建机器令牌
+func (s *TokenServiceImpl) Create(ctx context.Context, adminID uint, params *dto.TokenCreateParams) (*dto.TokenCreateResult, error) {
+	if params.Name == "" {
+		return nil, errors.New("令牌名称不能为空")
+	}
+	if len(params.Scopes) == 0 {
+		return nil, errors.New("授权范围不能为空")
+	}
+	for _, scope := range params.Scopes {
+		if !slices.Contains(TokenScopes, scope) {
+			return nil, fmt.Errorf("不支持的授权范围: %s", scope)
+		}
+	}
+
+	// 生成令牌明文
+	plain, err := generateMachineToken()
+	if err != nil {
+		return nil, err
+	}
+
+	token := &models.AdminToken{
+		AdminID:   adminID,
+		Name:      params.Name,
+		Prefix:    plain[:len(TokenPrefix)+8],
+		TokenHash: hashMachineToken(plain),
+		Scopes:    strings.Join(params.Scopes, ","),
+	}
+	if params.ExpiresIn > 0 {
+		expiresAt := time.Now().Add(time.Duration(params.ExpiresIn) * time.Second)
+		token.ExpiresAt = &expiresAt
+	}
+
+	if err := s.tokenRepo.Create(ctx, utils.NewGormBuilder(ctx, s.db), token); err != nil {
+		return nil, fmt.Errorf("创建令牌失败: %w", err)
+	}
+	return &dto.TokenCreateResult{Info: token, Token: plain}, nil
+}
+
+// List 获取管理员的机器令牌列表
+func (s *TokenServiceImpl) List(ctx context.Context, adminID uint) ([]*models.AdminToken, error) {
+	tokens, err := s.tokenRepo.FindList(ctx, utils.NewGormBuilderFind(ctx, s.db, "admin_id", adminID))
+	if err != nil {
+		return nil, fmt.Errorf("查询令牌失败: %w", err)
+	}
+	return tokens, nil
+}
+
+// Revoke 吊销机器令牌
+func (s *TokenServiceImpl) Revoke(ctx context.Context, adminID uint, id uint) error {
+	token, err := s.tokenRepo.Find(ctx, utils.NewGormBuilderFind(ctx, s.db, "id", id).WhereEqual("admin_id", adminID))
+	if err != nil {
+		return fmt.Errorf("查询令牌失败: %w", err)
+	}
+	if token.RevokedAt != nil {
+		return nil
+	}
+
+	now := time.Now()
+	token.RevokedAt = &now
+	if err := s.tokenRepo.Update(ctx, utils.NewGormBuilder(ctx, s.db), token); err != nil {
+		return fmt.Errorf("吊销令牌失败: %w", err)
+	}
+	return nil
+}
+
+// Authenticate 校验机器令牌
+func (s *TokenServiceImpl) Authenticate(ctx context.Context, plain string, ip string) (*models.AdminToken, error) {
+	if !IsMachineToken(plain) {
+		return nil, errors.New("无效的令牌")
+	}
+
+	token, err := s.tokenRepo.Find(ctx, utils.NewGormBuilderFind(ctx, s.db, "token_hash", hashMachineToken(plain)))
+	if err != nil {
+		return nil, errors.New("无效的令牌")
+	}
+	if !token.IsActive() {
+		return nil, errors.New("令牌已吊销或已过期")
+	}
+
+	// 令牌所属管理员必须可用
+	admin, err := s.adminRepo.Find(ctx, utils.NewGormBuilderFind(ctx, s.db, "id", token.AdminID))
+	if err != nil {
+		return nil, errors.New("令牌所属管理员不存在")
+	}
+	if admin.Status == models.AdminStatusDisabled || admin.IsLocked() {
+		return nil, errors.New("令牌所属管理员已禁用或锁定")
+	}
+
+	// 记录最后使用信息（仅写入使用字段且要求未吊销，避免以读取时的旧数据覆盖并发的吊销）
+	now := time.Now()
+	token.LastUsedAt = &now
+	token.LastUsedIP = ip
+	builder := utils.NewGormBuilderFind(ctx, s.db, "id", token.ID).WhereIsNull("revoked_at")
+	if _, err := s.tokenRepo.UpdateColumns(ctx, builder, map[string]interface{}{"last_used_at": now, "last_used_ip": ip}); err != nil {
+		logging.FromContext(ctx).Warn("更新令牌使用信息失败", "error", err)
+	}
+	token.Admin = admin
+	return token, nil
+}
+
+// generateMachineToken 生成机器令牌明文
+func generateMachineToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("生成令牌失败: %w", err)
+	}
+	return TokenPrefix + hex.EncodeToString(buf), nil
+}
+
+// hashMachineToken 计算机器令牌哈希值
+func hashMachineToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
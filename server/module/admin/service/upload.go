@@ -0,0 +1,228 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/so68/core/server/module/admin/dto"
+	"github.com/so68/core/storage"
+)
+
+// UploadService 文件上传服务
+// - 小文件直接上传，大文件先初始化分片上传，按序号上传分片（可断点续传），全部上传后合并
+// - 文件保存前校验扩展名、按内容识别的类型、图片尺寸，并执行病毒扫描钩子
+// - 图片按上传端点的图片处理配置去除元数据、转换格式并生成缩略图
+type UploadService interface {
+	// Upload 上传文件
+	// @param ctx 上下文
+	// @param profile 图片处理配置名称
+	// @param file 上传的文件
+	// @return *dto.UploadResult 上传结果
+	// @return error 错误
+	Upload(ctx context.Context, profile string, file *multipart.FileHeader) (*dto.UploadResult, error)
+	// ChunkInit 初始化分片上传（相同文件标识的未完成上传会被恢复）
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @param params 初始化参数
+	// @return *dto.ChunkInitResult 上传ID、分片信息与已上传的分片
+	// @return error 错误
+	ChunkInit(ctx context.Context, adminID uint, params *dto.ChunkInitParams) (*dto.ChunkInitResult, error)
+	// Chunk 上传分片
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @param params 分片参数
+	// @param file 分片内容
+	// @return error 错误
+	Chunk(ctx context.Context, adminID uint, params *dto.ChunkParams, file *multipart.FileHeader) error
+	// ChunkComplete 合并分片并保存文件
+	// @param ctx 上下文
+	// @param adminID 管理员ID
+	// @param profile 图片处理配置名称
+	// @param params 完成参数
+	// @return *dto.UploadResult 上传结果
+	// @return error 错误
+	ChunkComplete(ctx context.Context, adminID uint, profile string, params *dto.ChunkCompleteParams) (*dto.UploadResult, error)
+}
+
+// UploadServiceImpl 文件上传服务实现
+type UploadServiceImpl struct {
+	storage   storage.Storage
+	validator *storage.UploadValidator
+	chunks    *storage.ChunkStore
+	logger    *slog.Logger
+}
+
+// NewUploadService 创建文件上传服务（分片目录不可用时仅支持直接上传）
+func NewUploadService(fileStorage storage.Storage, validator *storage.UploadValidator, logger *slog.Logger) UploadService {
+	chunks, err := storage.NewChunkStore(validator.Config(), logger)
+	if err != nil {
+		logger.Error("创建分片上传目录失败", "error", err)
+	}
+	return &UploadServiceImpl{storage: fileStorage, validator: validator, chunks: chunks, logger: logger}
+}
+
+// Upload 上传文件
+func (s *UploadServiceImpl) Upload(ctx context.Context, profile string, file *multipart.FileHeader) (*dto.UploadResult, error) {
+	if s.storage == nil {
+		return nil, errors.New("未配置文件存储")
+	}
+	if err := s.validator.CheckName(file.Filename, file.Size); err != nil {
+		return nil, err
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	contentType, err := s.validator.Validate(ctx, file.Filename, src, file.Size)
+	if err != nil {
+		return nil, err
+	}
+	return s.put(ctx, profile, file.Filename, src, file.Size, contentType)
+}
+
+// ChunkInit 初始化分片上传
+func (s *UploadServiceImpl) ChunkInit(ctx context.Context, adminID uint, params *dto.ChunkInitParams) (*dto.ChunkInitResult, error) {
+	if s.storage == nil || s.chunks == nil {
+		return nil, errors.New("未配置文件存储")
+	}
+	if err := s.validator.CheckName(params.Name, params.Size); err != nil {
+		return nil, err
+	}
+
+	upload, uploaded, err := s.chunks.Init(s.owner(adminID), filepath.Base(params.Name), params.Size, params.Identifier)
+	if err != nil {
+		return nil, fmt.Errorf("初始化分片上传失败: %w", err)
+	}
+	return &dto.ChunkInitResult{UploadID: upload.ID, ChunkSize: upload.ChunkSize, Chunks: upload.Chunks, Uploaded: uploaded}, nil
+}
+
+// Chunk 上传分片
+func (s *UploadServiceImpl) Chunk(ctx context.Context, adminID uint, params *dto.ChunkParams, file *multipart.FileHeader) error {
+	if s.chunks == nil {
+		return errors.New("未配置文件存储")
+	}
+	upload, err := s.chunks.Get(s.owner(adminID), params.UploadID)
+	if err != nil {
+		return err
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	return s.chunks.Save(upload, params.Index, src, file.Size)
+}
+
+// ChunkComplete 合并分片并保存文件（校验未通过时删除已上传的分片）
+func (s *UploadServiceImpl) ChunkComplete(ctx context.Context, adminID uint, profile string, params *dto.ChunkCompleteParams) (*dto.UploadResult, error) {
+	if s.storage == nil || s.chunks == nil {
+		return nil, errors.New("未配置文件存储")
+	}
+	upload, err := s.chunks.Get(s.owner(adminID), params.UploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := s.chunks.Merge(upload)
+	if err != nil {
+		return nil, err
+	}
+	defer merged.Close()
+
+	contentType, err := s.validator.Validate(ctx, upload.Name, merged, upload.Size)
+	if err != nil {
+		s.removeChunks(upload)
+		return nil, err
+	}
+	// 保存失败时保留分片，客户端可重试
+	result, err := s.put(ctx, profile, upload.Name, merged, upload.Size, contentType)
+	if err != nil {
+		return nil, err
+	}
+	s.removeChunks(upload)
+	return result, nil
+}
+
+// put 保存到文件存储（图片按配置处理后保存处理结果与缩略图）
+func (s *UploadServiceImpl) put(ctx context.Context, profile string, name string, file io.Reader, size int64, contentType string) (*dto.UploadResult, error) {
+	// 生成安全的文件名（按日期分目录）
+	now := time.Now()
+	key := fmt.Sprintf("%s/%d_%s", now.Format("2006/01/02"), now.UnixNano(), filepath.Base(name))
+
+	imageConfig := s.validator.Config().Images[profile]
+	if imageConfig == nil || !strings.HasPrefix(contentType, "image/") {
+		if err := s.storage.Put(ctx, key, file, size, contentType); err != nil {
+			return nil, fmt.Errorf("保存文件失败: %w", err)
+		}
+		return &dto.UploadResult{URL: s.storage.URL(key), ContentType: contentType, Size: size}, nil
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	main, thumbnails, err := storage.ProcessImage(data, contentType, imageConfig)
+	if err != nil {
+		return nil, err
+	}
+	base := strings.TrimSuffix(key, path.Ext(key))
+	if main != nil {
+		key, data, contentType = base+main.Ext, main.Data, main.ContentType
+	}
+
+	saved := make([]string, 0, len(thumbnails)+1)
+	result := &dto.UploadResult{ContentType: contentType, Size: int64(len(data)), Thumbnails: make(map[string]string, len(thumbnails))}
+	variants := append([]*storage.ImageVariant{{Data: data, ContentType: contentType}}, thumbnails...)
+	for _, variant := range variants {
+		variantKey := key
+		if variant.Name != "" {
+			variantKey = base + "_" + variant.Name + variant.Ext
+		}
+		if err := s.storage.Put(ctx, variantKey, bytes.NewReader(variant.Data), int64(len(variant.Data)), variant.ContentType); err != nil {
+			s.removeFiles(ctx, saved)
+			return nil, fmt.Errorf("保存文件失败: %w", err)
+		}
+		saved = append(saved, variantKey)
+		if variant.Name == "" {
+			result.URL = s.storage.URL(variantKey)
+		} else {
+			result.Thumbnails[variant.Name] = s.storage.URL(variantKey)
+		}
+	}
+	return result, nil
+}
+
+// removeFiles 删除已保存的文件（保存图片变体失败时回滚）
+func (s *UploadServiceImpl) removeFiles(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := s.storage.Delete(ctx, key); err != nil {
+			s.logger.Warn("删除上传文件失败", "key", key, "error", err)
+		}
+	}
+}
+
+// removeChunks 删除分片上传
+func (s *UploadServiceImpl) removeChunks(upload *storage.ChunkUpload) {
+	if err := s.chunks.Remove(upload); err != nil {
+		s.logger.Warn("删除分片上传失败", "upload_id", upload.ID, "error", err)
+	}
+}
+
+// owner 分片上传所属管理员
+func (s *UploadServiceImpl) owner(adminID uint) string {
+	return strconv.FormatUint(uint64(adminID), 10)
+}
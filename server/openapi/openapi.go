@@ -0,0 +1,229 @@
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Version OpenAPI 规范版本
+const Version = "3.0.3"
+
+// BearerAuth 需要认证的接口使用的安全方案名称
+const BearerAuth = "bearerAuth"
+
+// Document OpenAPI 文档
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components *Components          `json:"components,omitempty"`
+}
+
+// Info 文档信息
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem 路径下的接口（键为小写的请求方法）
+type PathItem map[string]*Operation
+
+// Operation 接口
+type Operation struct {
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	OperationID string                `json:"operationId,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+// Parameter 路径或查询参数
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"` // path 或 query
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody 请求体
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response 响应
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType 内容类型
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components 可复用组件
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme 安全方案
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// Route 用于生成文档的路由
+type Route struct {
+	Method      string      // 请求方法
+	Path        string      // 完整路径（Gin 格式，例如 /admin/user/:id）
+	Summary     string      // 摘要（路由名称）
+	Description string      // 描述
+	Tags        []string    // 分组标签
+	Secured     bool        // 是否需要认证
+	Request     interface{} // 请求参数 DTO（GET、HEAD 请求生成查询参数，其余生成 JSON 请求体）
+	Response    interface{} // 响应数据 DTO（位于统一响应结构的 data 字段）
+	Page        bool        // 响应数据为分页结构（Response 为列表元素类型）
+}
+
+// Builder 文档构建器
+type Builder struct {
+	doc     *Document
+	schemas *schemaRegistry
+}
+
+// NewBuilder 创建一个文档构建器
+func NewBuilder(info Info) *Builder {
+	schemas := newSchemaRegistry()
+	return &Builder{
+		doc: &Document{
+			OpenAPI: Version,
+			Info:    info,
+			Paths:   make(map[string]*PathItem),
+			Components: &Components{
+				Schemas: schemas.schemas,
+				SecuritySchemes: map[string]*SecurityScheme{
+					BearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+				},
+			},
+		},
+		schemas: schemas,
+	}
+}
+
+// Add 添加路由
+func (b *Builder) Add(routes ...Route) *Builder {
+	for _, route := range routes {
+		b.add(route)
+	}
+	return b
+}
+
+// Document 获取文档
+func (b *Builder) Document() *Document {
+	return b.doc
+}
+
+// add 添加单个路由
+func (b *Builder) add(route Route) {
+	method := strings.ToLower(route.Method)
+	path, pathParams := convertPath(route.Path)
+	op := &Operation{
+		Summary:     route.Summary,
+		Description: route.Description,
+		OperationID: operationID(route.Method, path),
+		Tags:        route.Tags,
+		Parameters:  pathParams,
+		Responses: map[string]*Response{
+			"200": {
+				Description: "OK",
+				Content:     map[string]*MediaType{"application/json": {Schema: b.envelope(route)}},
+			},
+		},
+	}
+	if route.Secured {
+		op.Security = []map[string][]string{{BearerAuth: {}}}
+	}
+	if route.Request != nil {
+		if route.Method == http.MethodGet || route.Method == http.MethodHead {
+			op.Parameters = append(op.Parameters, b.schemas.queryParameters(typeOf(route.Request))...)
+		} else {
+			op.RequestBody = &RequestBody{
+				Required: true,
+				Content:  map[string]*MediaType{"application/json": {Schema: b.schemas.schema(typeOf(route.Request))}},
+			}
+		}
+	}
+
+	item, ok := b.doc.Paths[path]
+	if !ok {
+		item = &PathItem{}
+		b.doc.Paths[path] = item
+	}
+	(*item)[method] = op
+}
+
+// envelope 统一响应结构（code、message、data）
+func (b *Builder) envelope(route Route) *Schema {
+	data := &Schema{}
+	if route.Response != nil {
+		data = b.schemas.schema(typeOf(route.Response))
+	}
+	if route.Page {
+		data = &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"total":       {Type: "integer", Format: "int64", Description: "总记录数"},
+				"pages":       {Type: "integer", Format: "int64", Description: "总页数"},
+				"current":     {Type: "integer", Format: "int64", Description: "当前页码"},
+				"size":        {Type: "integer", Format: "int64", Description: "每页数量"},
+				"items":       {Type: "array", Items: data, Description: "数据列表"},
+				"next_cursor": {Type: "string", Description: "下一页游标（没有更多数据时为空）"},
+			},
+			Required: []string{"total", "pages", "current", "size", "items"},
+		}
+	}
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"code":    {Type: "integer", Description: "业务状态码"},
+			"message": {Type: "string", Description: "提示信息"},
+			"data":    data,
+		},
+		Required: []string{"code", "message"},
+	}
+}
+
+// ginParamPattern Gin 路径参数（:id 与 *path）
+var ginParamPattern = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// convertPath 将 Gin 路径转换为 OpenAPI 路径并生成路径参数
+func convertPath(path string) (string, []*Parameter) {
+	var params []*Parameter
+	converted := ginParamPattern.ReplaceAllStringFunc(path, func(match string) string {
+		name := match[1:]
+		params = append(params, &Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		return "{" + name + "}"
+	})
+	return converted, params
+}
+
+// operationID 根据方法与路径生成接口标识（例如 get_admin_role_index）
+func operationID(method string, path string) string {
+	parts := []string{strings.ToLower(method)}
+	for _, segment := range strings.Split(path, "/") {
+		segment = strings.Trim(segment, "{}")
+		if segment != "" {
+			parts = append(parts, strings.NewReplacer("-", "_", ".", "_").Replace(segment))
+		}
+	}
+	return strings.Join(parts, "_")
+}
@@ -0,0 +1,191 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+/*
+OpenAPI 文档生成测试
+
+本文件用于测试根据路由与 DTO 类型生成 OpenAPI 3 文档，
+包括结构体组件、校验约束、查询参数、路径参数、分页响应与认证方案。
+
+运行命令：
+go test -v -run "^TestBuilder.*$"
+
+测试内容：
+1. 请求体与响应组件（json 标签、validate 约束、嵌入字段、递归类型）
+2. 查询参数（form 标签）与路径参数
+3. 分页响应与统一响应结构
+4. 认证方案与接口标识
+*/
+
+// testPage 测试用分页参数
+type testPage struct {
+	Page int64 `form:"page"`
+	Size int64 `form:"size" validate:"omitempty,max=100"`
+
+	cursor string
+}
+
+// testIndexParams 测试用列表参数
+type testIndexParams struct {
+	testPage
+	Status int8   `form:"status" validate:"omitempty,oneof=1 2"`
+	Name   string `form:"name" validate:"required"`
+	Secret string `form:"-"`
+}
+
+// testCreateParams 测试用创建参数
+type testCreateParams struct {
+	Username string   `json:"username" validate:"required,min=3,max=64"`
+	Email    string   `json:"email" validate:"omitempty,email"`
+	Role     string   `json:"role" validate:"oneof=admin user"`
+	Tags     []string `json:"tags" validate:"max=5,dive,max=10"`
+	Ignored  string   `json:"-"`
+}
+
+// testUser 测试用模型（含递归引用）
+type testUser struct {
+	ID        uint              `json:"id"`
+	Name      string            `gorm:"type:varchar(100);comment:'名称'" json:"name"`
+	CreatedAt time.Time         `json:"created_at"`
+	Parent    *testUser         `json:"parent"`
+	Labels    map[string]string `json:"labels"`
+	Extra     interface{}       `json:"extra"`
+}
+
+// encode 序列化后反序列化为通用结构，便于断言
+func encode(t *testing.T, v interface{}) map[string]interface{} {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	return result
+}
+
+func TestBuilderRequestBodyAndComponents(t *testing.T) {
+	doc := NewBuilder(Info{Title: "test", Version: "1.0.0"}).Add(Route{
+		Method:   "POST",
+		Path:     "/admin/user/create",
+		Summary:  "创建用户",
+		Tags:     []string{"admin"},
+		Secured:  true,
+		Request:  testCreateParams{},
+		Response: &testUser{},
+	}).Document()
+
+	op := (*doc.Paths["/admin/user/create"])["post"]
+	if op == nil || op.Summary != "创建用户" || op.OperationID != "post_admin_user_create" {
+		t.Fatalf("Unexpected operation: %+v", op)
+	}
+	if len(op.Security) != 1 || op.Security[0][BearerAuth] == nil {
+		t.Errorf("Expected bearer security, got %v", op.Security)
+	}
+	if ref := op.RequestBody.Content["application/json"].Schema.Ref; ref != "#/components/schemas/openapi.testCreateParams" {
+		t.Errorf("Unexpected request ref: %s", ref)
+	}
+
+	params := doc.Components.Schemas["openapi.testCreateParams"]
+	if !reflect.DeepEqual(params.Required, []string{"username"}) {
+		t.Errorf("Unexpected required fields: %v", params.Required)
+	}
+	if _, ok := params.Properties["Ignored"]; ok {
+		t.Error("Expected json:\"-\" field to be skipped")
+	}
+	username := params.Properties["username"]
+	if *username.MinLength != 3 || *username.MaxLength != 64 {
+		t.Errorf("Unexpected username bounds: %d-%d", *username.MinLength, *username.MaxLength)
+	}
+	if params.Properties["email"].Format != "email" {
+		t.Errorf("Expected email format, got %q", params.Properties["email"].Format)
+	}
+	if !reflect.DeepEqual(params.Properties["role"].Enum, []interface{}{"admin", "user"}) {
+		t.Errorf("Unexpected role enum: %v", params.Properties["role"].Enum)
+	}
+	if tags := params.Properties["tags"]; *tags.MaxItems != 5 || tags.Items.MaxLength != nil {
+		t.Errorf("Expected max items 5 without element bounds, got %+v", tags)
+	}
+
+	user := encode(t, doc.Components.Schemas["openapi.testUser"])
+	properties := user["properties"].(map[string]interface{})
+	expected := map[string]map[string]interface{}{
+		"id":         {"type": "integer", "format": "int64"},
+		"name":       {"type": "string", "description": "名称"},
+		"created_at": {"type": "string", "format": "date-time"},
+		"parent":     {"$ref": "#/components/schemas/openapi.testUser"},
+		"labels":     {"type": "object", "additionalProperties": map[string]interface{}{"type": "string"}},
+		"extra":      {},
+	}
+	for name, want := range expected {
+		if !reflect.DeepEqual(properties[name], map[string]interface{}(want)) {
+			t.Errorf("Unexpected property %s: %v", name, properties[name])
+		}
+	}
+
+	data := op.Responses["200"].Content["application/json"].Schema.Properties["data"]
+	if data.Ref != "#/components/schemas/openapi.testUser" {
+		t.Errorf("Unexpected response data: %+v", data)
+	}
+}
+
+func TestBuilderQueryAndPathParameters(t *testing.T) {
+	doc := NewBuilder(Info{Title: "test", Version: "1.0.0"}).Add(
+		Route{Method: "GET", Path: "/user/index", Request: &testIndexParams{}, Response: testUser{}, Page: true},
+		Route{Method: "GET", Path: "/user/:id/files/*path"},
+	).Document()
+
+	index := (*doc.Paths["/user/index"])["get"]
+	if index.RequestBody != nil || index.Security != nil {
+		t.Errorf("Expected no request body and security, got %+v", index)
+	}
+	var names []string
+	for _, param := range index.Parameters {
+		names = append(names, param.Name)
+		if param.In != "query" {
+			t.Errorf("Expected query parameter, got %s", param.In)
+		}
+	}
+	if !reflect.DeepEqual(names, []string{"page", "size", "status", "name"}) {
+		t.Errorf("Unexpected query parameters: %v", names)
+	}
+	if size := index.Parameters[1].Schema; *size.Maximum != 100 {
+		t.Errorf("Unexpected size maximum: %v", *size.Maximum)
+	}
+	if status := index.Parameters[2].Schema; !reflect.DeepEqual(status.Enum, []interface{}{int64(1), int64(2)}) {
+		t.Errorf("Unexpected status enum: %v", status.Enum)
+	}
+	if !index.Parameters[3].Required {
+		t.Error("Expected name to be required")
+	}
+
+	data := index.Responses["200"].Content["application/json"].Schema.Properties["data"]
+	if items := data.Properties["items"]; items.Type != "array" || items.Items.Ref != "#/components/schemas/openapi.testUser" {
+		t.Errorf("Unexpected page items: %+v", items)
+	}
+
+	files, ok := doc.Paths["/user/{id}/files/{path}"]
+	if !ok {
+		t.Fatalf("Expected converted path, got %v", doc.Paths)
+	}
+	op := (*files)["get"]
+	if len(op.Parameters) != 2 || op.Parameters[0].Name != "id" || op.Parameters[1].Name != "path" || !op.Parameters[0].Required {
+		t.Errorf("Unexpected path parameters: %+v", op.Parameters)
+	}
+	if op.OperationID != "get_user_id_files_path" {
+		t.Errorf("Unexpected operation id: %s", op.OperationID)
+	}
+
+	result := encode(t, doc)
+	if result["openapi"] != Version {
+		t.Errorf("Unexpected openapi version: %v", result["openapi"])
+	}
+}
@@ -0,0 +1,282 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema JSON Schema（OpenAPI 3.0 子集）
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// schemaRegistry 结构体组件注册表（命名结构体生成 $ref 引用，支持递归类型）
+type schemaRegistry struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+// newSchemaRegistry 创建组件注册表
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// typeOf 获取 DTO 的类型（支持直接传入 reflect.Type）
+func typeOf(v interface{}) reflect.Type {
+	if t, ok := v.(reflect.Type); ok {
+		return t
+	}
+	return reflect.TypeOf(v)
+}
+
+// schema 生成类型对应的 Schema
+func (r *schemaRegistry) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		// 自定义 JSON 序列化的类型无法推断结构
+		return &Schema{}
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: r.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + r.register(t)}
+	default:
+		// interface{} 等任意类型
+		return &Schema{}
+	}
+}
+
+// componentNamePattern 组件名称允许的字符
+var componentNamePattern = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// register 注册命名结构体组件并返回组件名称（包名.类型名）
+func (r *schemaRegistry) register(t reflect.Type) string {
+	if name, ok := r.names[t]; ok {
+		return name
+	}
+	base := t.Name()
+	if pkg := t.PkgPath(); pkg != "" {
+		base = pkg[strings.LastIndex(pkg, "/")+1:] + "." + base
+	}
+	base = strings.Trim(componentNamePattern.ReplaceAllString(base, "_"), "_")
+	name := base
+	for i := 2; ; i++ {
+		if _, exists := r.schemas[name]; !exists {
+			break
+		}
+		name = base + strconv.Itoa(i)
+	}
+	// 先占位再生成属性，递归引用自身时直接返回名称
+	r.names[t] = name
+	r.schemas[name] = &Schema{}
+	*r.schemas[name] = *r.structSchema(t)
+	return name
+}
+
+// structSchema 生成结构体的对象 Schema（匿名嵌入字段展开到外层）
+func (r *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	r.walkFields(t, "json", func(name string, field reflect.StructField) {
+		property := r.schema(field.Type)
+		if property.Ref == "" {
+			applyField(property, field)
+		}
+		s.Properties[name] = property
+		if isRequired(field) {
+			s.Required = append(s.Required, name)
+		}
+	})
+	return s
+}
+
+// queryParameters 根据 form 标签生成查询参数
+func (r *schemaRegistry) queryParameters(t reflect.Type) []*Parameter {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var params []*Parameter
+	r.walkFields(t, "form", func(name string, field reflect.StructField) {
+		schema := r.schema(field.Type)
+		applyField(schema, field)
+		param := &Parameter{Name: name, In: "query", Required: isRequired(field), Schema: schema}
+		param.Description, schema.Description = schema.Description, ""
+		params = append(params, param)
+	})
+	return params
+}
+
+// walkFields 遍历结构体导出字段（名称取自 tag 标签，未设置时使用字段名，"-" 忽略）
+func (r *schemaRegistry) walkFields(t reflect.Type, tag string, fn func(name string, field reflect.StructField)) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct && embedded != timeType {
+				r.walkFields(embedded, tag, fn)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fn(name, field)
+	}
+}
+
+// gormCommentPattern gorm 标签中的字段注释
+var gormCommentPattern = regexp.MustCompile(`comment:'?([^;']*)`)
+
+// applyField 根据字段标签补充描述与校验约束（validate 标签）
+func applyField(s *Schema, field reflect.StructField) {
+	if match := gormCommentPattern.FindStringSubmatch(field.Tag.Get("gorm")); match != nil {
+		s.Description = match[1]
+	}
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		if key == "dive" {
+			// dive 之后的规则校验的是列表元素
+			return
+		}
+		switch key {
+		case "email":
+			s.Format = "email"
+		case "url":
+			s.Format = "uri"
+		case "oneof":
+			for _, option := range strings.Fields(value) {
+				s.Enum = append(s.Enum, enumValue(s.Type, option))
+			}
+		case "min", "max", "len":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			applyBound(s, key, n)
+		}
+	}
+}
+
+// applyBound 按类型设置长度、数量或数值范围
+func applyBound(s *Schema, key string, n float64) {
+	size := int(n)
+	switch s.Type {
+	case "string":
+		if key != "max" {
+			s.MinLength = &size
+		}
+		if key != "min" {
+			s.MaxLength = &size
+		}
+	case "array":
+		if key != "max" {
+			s.MinItems = &size
+		}
+		if key != "min" {
+			s.MaxItems = &size
+		}
+	case "integer", "number":
+		if key != "max" {
+			s.Minimum = &n
+		}
+		if key != "min" {
+			s.Maximum = &n
+		}
+	}
+}
+
+// enumValue 按类型转换枚举值
+func enumValue(schemaType string, option string) interface{} {
+	switch schemaType {
+	case "integer":
+		if n, err := strconv.ParseInt(option, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(option, 64); err == nil {
+			return n
+		}
+	}
+	return option
+}
+
+// isRequired 字段是否必填（validate 或 binding 标签包含 required）
+func isRequired(field reflect.StructField) bool {
+	for _, tag := range []string{"validate", "binding"} {
+		for _, rule := range strings.Split(field.Tag.Get(tag), ",") {
+			if rule == "required" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,370 @@
+package scaffold
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	coredb "github.com/so68/core/database"
+	"github.com/so68/core/server/utils"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Hook 记录钩子，返回错误时中止请求并回滚事务
+// - ctx 携带当前事务，使用 utils.NewGormBuilder(ctx, db) 即可在同一事务内读写
+type Hook[T any] func(ctx context.Context, c *gin.Context, model *T) error
+
+// QueryHook 列表查询钩子，可追加查询条件（如按状态过滤、按当前管理员过滤）
+type QueryHook func(ctx context.Context, c *gin.Context, builder *utils.GormBuilder) error
+
+// Hooks 通用 CRUD 钩子（均可为空）
+type Hooks[T any] struct {
+	Query        QueryHook // 列表查询前
+	BeforeCreate Hook[T]   // 创建前（校验、填充字段）
+	AfterCreate  Hook[T]   // 创建后（同一事务内）
+	BeforeUpdate Hook[T]   // 更新前（model 为合并请求参数后的记录）
+	AfterUpdate  Hook[T]   // 更新后（同一事务内）
+	BeforeDelete Hook[T]   // 删除前（model 为待删除的记录）
+	AfterDelete  Hook[T]   // 删除后（同一事务内）
+}
+
+// Handler 基于 GORM 模型生成的通用 CRUD 处理
+// - 请求参数绑定到模型，按模型的 validate 标签校验
+// - 模型声明 views 标签时，Meta 返回表单元数据，创建/更新仅写入 views 中非只读的字段，search 字段可作为列表查询参数
+// - 模型未声明 views 标签时，仅写入 WithBindFields 设置的字段（未设置时拒绝创建/更新）
+// - views 中类型为 password 的字段以 bcrypt 哈希保存，更新时为空则保持原值
+// - 记录按主键 id 查询、更新与软删除，并遵循上下文中的数据权限范围
+// - 模型含数据权限字段（默认 admin_id）时，创建记录归属当前管理员，更新时不可修改
+type Handler[T any] struct {
+	db     *gorm.DB
+	logger *slog.Logger
+	repo   Repo[T]
+	hooks  *Hooks[T]
+	fields []*ViewField // views 表单字段（未声明时为空）
+	binds  []string     // 未声明 views 时允许写入的字段（JSON 名称）
+	owner  *schema.Field
+	filter utils.Filter // 列表允许过滤的字段
+	sorts  []string     // 允许排序的字段
+}
+
+// idParams 主键参数
+type idParams struct {
+	ID uint `json:"id" form:"id"` // 主键ID
+}
+
+// NewHandler 创建一个通用 CRUD 处理
+func NewHandler[T any](db *gorm.DB, logger *slog.Logger, hooks *Hooks[T]) (*Handler[T], error) {
+	modelSchema, err := schema.Parse(new(T), &sync.Map{}, db.NamingStrategy)
+	if err != nil {
+		return nil, fmt.Errorf("scaffold: parse model %T failed: %w", *new(T), err)
+	}
+	if modelSchema.LookUpField("id") == nil {
+		return nil, fmt.Errorf("scaffold: model %s has no id column", modelSchema.Name)
+	}
+	if hooks == nil {
+		hooks = &Hooks[T]{}
+	}
+	fields := ParseViews(new(T))
+	for _, field := range fields {
+		if field.Column != "" {
+			continue
+		}
+		if schemaField := modelSchema.LookUpField(field.Name); schemaField != nil {
+			field.Column = schemaField.DBName
+		}
+	}
+	// 主键排在第一位，排序字段不合法时按主键排序
+	sorts := append([]string{"id"}, slices.DeleteFunc(slices.Clone(modelSchema.DBNames), func(name string) bool { return name == "id" })...)
+	owner := modelSchema.LookUpField(utils.DataScopeColumn(modelSchema.ModelType))
+	return &Handler[T]{db: db, logger: logger, repo: NewRepo[T](), hooks: hooks, fields: fields, owner: owner, sorts: sorts}, nil
+}
+
+// Fields 获取 views 表单元数据（模型未声明 views 标签时为空）
+func (h *Handler[T]) Fields() []*ViewField {
+	return h.fields
+}
+
+// Meta 表单元数据
+func (h *Handler[T]) Meta(c *gin.Context) {
+	utils.Success(c, h.fields)
+}
+
+// WithRepo 使用自定义数据操作（例如在通用实现之上追加预加载）
+func (h *Handler[T]) WithRepo(repo Repo[T]) *Handler[T] {
+	h.repo = repo
+	return h
+}
+
+// WithBindFields 设置未声明 views 标签的模型允许通过请求参数写入的字段（JSON 名称）
+func (h *Handler[T]) WithBindFields(fields ...string) *Handler[T] {
+	h.binds = fields
+	return h
+}
+
+// WithFilter 设置列表允许通过查询参数过滤的字段（格式见 utils.Filter）
+func (h *Handler[T]) WithFilter(filter utils.Filter) *Handler[T] {
+	h.filter = filter
+	return h
+}
+
+// Index 分页列表
+func (h *Handler[T]) Index(c *gin.Context) {
+	page := utils.NewDefaultPage()
+	if err := c.ShouldBindQuery(page); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	builder := utils.NewGormBuilderWithPage(ctx, h.db.Model(new(T)), page, h.sorts...)
+	if err := h.filter.BindValues(c.Request.URL.Query(), builder); err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	h.search(c, builder)
+	if h.hooks.Query != nil {
+		if err := h.hooks.Query(ctx, c, builder); err != nil {
+			utils.Fail(c, err)
+			return
+		}
+	}
+	result, err := h.repo.FindListWithPage(ctx, builder)
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, result)
+}
+
+// Create 创建记录（忽略请求中的主键）
+func (h *Handler[T]) Create(c *gin.Context) {
+	model := new(T)
+	if err := h.bind(c, model, true); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+	h.setID(model, 0)
+	if err := h.setOwner(c, model); err != nil {
+		utils.Fail(c, err)
+		return
+	}
+
+	err := coredb.Transaction(c.Request.Context(), h.db, func(tx *gorm.DB) error {
+		txCtx := tx.Statement.Context
+		if err := h.call(h.hooks.BeforeCreate, txCtx, c, model); err != nil {
+			return err
+		}
+		if err := h.repo.Create(txCtx, utils.NewGormBuilder(txCtx, h.db), model); err != nil {
+			return fmt.Errorf("创建失败: %w", err)
+		}
+		return h.call(h.hooks.AfterCreate, txCtx, c, model)
+	})
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, model)
+}
+
+// Update 更新记录（请求体需包含 id，未传的字段保持原值）
+func (h *Handler[T]) Update(c *gin.Context) {
+	params := &idParams{}
+	if err := c.ShouldBindBodyWith(params, binding.JSON); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+	if params.ID == 0 {
+		utils.Error(c, "id 不能为空")
+		return
+	}
+
+	var model *T
+	err := coredb.Transaction(c.Request.Context(), h.db, func(tx *gorm.DB) error {
+		txCtx := tx.Statement.Context
+		var err error
+		if model, err = h.find(txCtx, params.ID); err != nil {
+			return err
+		}
+		owner := h.ownerValue(txCtx, model)
+		if err := h.bind(c, model, false); err != nil {
+			return errors.New("参数格式错误: " + err.Error())
+		}
+		h.setID(model, params.ID)
+		if h.owner != nil {
+			if err := h.owner.Set(txCtx, reflect.ValueOf(model), owner); err != nil {
+				return err
+			}
+		}
+
+		if err := h.call(h.hooks.BeforeUpdate, txCtx, c, model); err != nil {
+			return err
+		}
+		if err := h.repo.Update(txCtx, utils.NewGormBuilder(txCtx, h.db), model); err != nil {
+			return fmt.Errorf("更新失败: %w", err)
+		}
+		return h.call(h.hooks.AfterUpdate, txCtx, c, model)
+	})
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, model)
+}
+
+// Delete 删除记录（软删除）
+func (h *Handler[T]) Delete(c *gin.Context) {
+	params := &idParams{}
+	if err := c.ShouldBind(params); err != nil {
+		utils.BindError(c, err)
+		return
+	}
+	if params.ID == 0 {
+		utils.Error(c, "id 不能为空")
+		return
+	}
+
+	err := coredb.Transaction(c.Request.Context(), h.db, func(tx *gorm.DB) error {
+		txCtx := tx.Statement.Context
+		model, err := h.find(txCtx, params.ID)
+		if err != nil {
+			return err
+		}
+		if err := h.call(h.hooks.BeforeDelete, txCtx, c, model); err != nil {
+			return err
+		}
+		if err := h.repo.Delete(txCtx, utils.NewGormBuilder(txCtx, h.db), true, params.ID); err != nil {
+			return fmt.Errorf("删除失败: %w", err)
+		}
+		return h.call(h.hooks.AfterDelete, txCtx, c, model)
+	})
+	if err != nil {
+		utils.Fail(c, err)
+		return
+	}
+	utils.Success(c, nil)
+}
+
+// find 按主键查询记录（遵循数据权限范围）
+func (h *Handler[T]) find(ctx context.Context, id uint) (*T, error) {
+	model, err := h.repo.Find(ctx, utils.NewGormBuilderFind(ctx, h.db, "id", id))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("记录不存在")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("查询失败: %w", err)
+	}
+	return model, nil
+}
+
+// bind 绑定请求参数到模型（声明 views 标签时仅绑定 views 中非只读的字段，否则仅绑定 WithBindFields 设置的字段）
+func (h *Handler[T]) bind(c *gin.Context, model *T, create bool) error {
+	if len(h.fields) == 0 && len(h.binds) == 0 {
+		return errors.New("模型未声明 views 标签，需通过 WithBindFields 设置可写入的字段")
+	}
+
+	payload := make(map[string]interface{})
+	if err := c.ShouldBindBodyWith(&payload, binding.JSON); err != nil {
+		return err
+	}
+	filtered := make(map[string]interface{})
+	for _, name := range h.binds {
+		if value, exists := payload[name]; exists {
+			filtered[name] = value
+		}
+	}
+	for _, field := range h.fields {
+		if field.Readonly {
+			continue
+		}
+		value, exists := payload[field.JSON]
+		if !exists || value == nil || value == "" {
+			if create && field.Required {
+				return fmt.Errorf("%s 不能为空", field.Label)
+			}
+			// 密码为空时保持原值
+			if !exists || field.Type == ViewTypePassword {
+				continue
+			}
+		}
+		if field.Type == ViewTypePassword {
+			password, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("%s 格式错误", field.Label)
+			}
+			hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+			if err != nil {
+				return err
+			}
+			value = string(hash)
+		}
+		filtered[field.JSON] = value
+	}
+
+	data, err := json.Marshal(filtered)
+	if err != nil {
+		return err
+	}
+	return binding.JSON.BindBody(data, model)
+}
+
+// search 按 views 中的 search 字段追加查询条件（文本字段模糊匹配，其他字段精确匹配）
+func (h *Handler[T]) search(c *gin.Context, builder *utils.GormBuilder) {
+	for _, field := range h.fields {
+		if !field.Search || field.Column == "" {
+			continue
+		}
+		value := strings.TrimSpace(c.Query(field.JSON))
+		if value == "" {
+			continue
+		}
+		switch field.Type {
+		case ViewTypeInput, ViewTypeTextarea:
+			builder.WhereLike(field.Column, "%"+value+"%")
+		default:
+			builder.WhereEqual(field.Column, value)
+		}
+	}
+}
+
+// call 调用钩子（未设置时忽略）
+func (h *Handler[T]) call(hook Hook[T], ctx context.Context, c *gin.Context, model *T) error {
+	if hook == nil {
+		return nil
+	}
+	return hook(ctx, c, model)
+}
+
+// setOwner 将记录归属到当前管理员（模型不含数据权限字段或未登录时忽略）
+func (h *Handler[T]) setOwner(c *gin.Context, model *T) error {
+	userID := utils.GetContextUserID(c)
+	if h.owner == nil || userID == 0 {
+		return nil
+	}
+	return h.owner.Set(c.Request.Context(), reflect.ValueOf(model), userID)
+}
+
+// ownerValue 获取记录的数据归属字段值
+func (h *Handler[T]) ownerValue(ctx context.Context, model *T) interface{} {
+	if h.owner == nil {
+		return nil
+	}
+	value, _ := h.owner.ValueOf(ctx, reflect.ValueOf(model))
+	return value
+}
+
+// setID 设置模型主键（防止请求参数改写主键）
+func (h *Handler[T]) setID(model *T, id uint) {
+	if field := reflect.ValueOf(model).Elem().FieldByName("ID"); field.IsValid() && field.CanSet() && field.Kind() == reflect.Uint {
+		field.SetUint(uint64(id))
+	}
+}
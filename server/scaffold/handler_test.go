@@ -0,0 +1,344 @@
+package scaffold
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/so68/core/config"
+	coredb "github.com/so68/core/database"
+	"github.com/so68/core/server/utils"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+/*
+通用 CRUD 处理测试
+
+本文件用于测试基于 GORM 模型生成的通用 CRUD 处理与钩子。
+
+运行命令：
+go test -v -run "^TestHandler.*$"
+
+测试内容：
+1. 创建、列表、更新、删除 (Create, Index, Update, Delete)
+2. 参数校验、主键与数据归属字段保护、查询参数过滤
+3. 钩子调用与事务回滚 (Hooks)
+4. views 表单元数据、字段白名单、密码哈希与搜索字段 (Meta, Fields)
+5. 未声明 views 标签且未设置可写字段时拒绝写入 (WithBindFields)
+*/
+
+// handlerTestAdminID 测试请求的当前管理员ID
+const handlerTestAdminID = 7
+
+// handlerTestArticle 测试模型
+type handlerTestArticle struct {
+	coredb.BaseModel
+	Title   string `gorm:"type:varchar(100);not null" json:"title" validate:"required"`
+	Status  int8   `gorm:"not null;default:1" json:"status"`
+	AdminID uint   `gorm:"index" json:"admin_id"`
+}
+
+// handlerTestResp 测试响应
+type handlerTestResp struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// newHandlerTestRouter 创建测试路由与数据库
+func newHandlerTestRouter(t *testing.T, hooks *Hooks[handlerTestArticle]) (*gin.Engine, *gorm.DB) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.SetTagName("validate")
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	database, err := coredb.NewSQLiteDatabase(&config.DatabaseConfig{Driver: "sqlite", Database: filepath.Join(t.TempDir(), "scaffold.db"), LogLevel: "silent"}, logger)
+	if err != nil {
+		t.Fatalf("create database failed: %v", err)
+	}
+	t.Cleanup(func() { _ = database.Close(context.Background()) })
+	if err := database.DB().AutoMigrate(&handlerTestArticle{}); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+
+	handler, err := NewHandler(database.DB(), logger, hooks)
+	if err != nil {
+		t.Fatalf("NewHandler failed: %v", err)
+	}
+	handler.WithBindFields("title", "status").WithFilter(utils.NewFilter("title"))
+	router := gin.New()
+	router.Use(func(c *gin.Context) { utils.SetContextUserID(c, handlerTestAdminID) })
+	router.GET("/article/index", handler.Index)
+	router.POST("/article/create", handler.Create)
+	router.PUT("/article/update", handler.Update)
+	router.DELETE("/article/delete", handler.Delete)
+	return router, database.DB()
+}
+
+// doHandlerTestRequest 发送测试请求
+func doHandlerTestRequest(t *testing.T, router *gin.Engine, method string, path string, body string) *handlerTestResp {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("%s %s status = %d", method, path, w.Code)
+	}
+	resp := &handlerTestResp{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("decode response failed: %v", err)
+	}
+	return resp
+}
+
+func TestHandler_CRUD(t *testing.T) {
+	var created, deleted []string
+	router, db := newHandlerTestRouter(t, &Hooks[handlerTestArticle]{
+		Query: func(ctx context.Context, c *gin.Context, builder *utils.GormBuilder) error {
+			if status := c.Query("status"); status != "" {
+				builder.WhereEqual("status", status)
+			}
+			return nil
+		},
+		AfterCreate: func(ctx context.Context, c *gin.Context, model *handlerTestArticle) error {
+			created = append(created, model.Title)
+			return nil
+		},
+		BeforeUpdate: func(ctx context.Context, c *gin.Context, model *handlerTestArticle) error {
+			if model.Title == "forbidden" {
+				return errors.New("标题不可用")
+			}
+			return nil
+		},
+		BeforeDelete: func(ctx context.Context, c *gin.Context, model *handlerTestArticle) error {
+			deleted = append(deleted, model.Title)
+			return nil
+		},
+	})
+
+	// 创建（忽略请求中的主键与未允许的字段，记录归属当前管理员）
+	resp := doHandlerTestRequest(t, router, "POST", "/article/create", `{"id":100,"title":"first","status":1,"admin_id":99,"created_at":"2000-01-01T00:00:00Z"}`)
+	if resp.Code != 0 {
+		t.Fatalf("create failed: %s", resp.Message)
+	}
+	article := &handlerTestArticle{}
+	_ = json.Unmarshal(resp.Data, article)
+	if article.ID == 0 || article.ID == 100 {
+		t.Fatalf("expected generated id, got %d", article.ID)
+	}
+	if article.AdminID != handlerTestAdminID || article.CreatedAt.Year() == 2000 {
+		t.Fatalf("unexpected owner or timestamps: %+v", article)
+	}
+	doHandlerTestRequest(t, router, "POST", "/article/create", `{"title":"second","status":2}`)
+	if resp := doHandlerTestRequest(t, router, "POST", "/article/create", `{"status":1}`); resp.Code == 0 {
+		t.Fatal("expected validation error for missing title")
+	}
+	if len(created) != 2 {
+		t.Fatalf("expected AfterCreate called twice, got %v", created)
+	}
+
+	// 列表（查询钩子追加条件）
+	resp = doHandlerTestRequest(t, router, "GET", "/article/index?status=2&sort=unknown", "")
+	page := &struct {
+		Items []*handlerTestArticle `json:"items"`
+	}{}
+	_ = json.Unmarshal(resp.Data, page)
+	if resp.Code != 0 || len(page.Items) != 1 || page.Items[0].Title != "second" {
+		t.Fatalf("unexpected index result: %s %s", resp.Message, resp.Data)
+	}
+
+	// 列表（查询参数过滤）
+	resp = doHandlerTestRequest(t, router, "GET", "/article/index?title__like=fir&status__gt=9", "")
+	_ = json.Unmarshal(resp.Data, page)
+	if resp.Code != 0 || len(page.Items) != 1 || page.Items[0].Title != "first" {
+		t.Fatalf("unexpected filtered result: %s %s", resp.Message, resp.Data)
+	}
+	if resp := doHandlerTestRequest(t, router, "GET", "/article/index?title__regex=x", ""); resp.Code == 0 {
+		t.Fatal("expected unsupported filter error")
+	}
+
+	// 更新（未传的字段保持原值，钩子返回错误时不更新）
+	resp = doHandlerTestRequest(t, router, "PUT", "/article/update", `{"id":`+jsonID(article.ID)+`,"title":"updated","admin_id":99}`)
+	if resp.Code != 0 {
+		t.Fatalf("update failed: %s", resp.Message)
+	}
+	if resp := doHandlerTestRequest(t, router, "PUT", "/article/update", `{"id":`+jsonID(article.ID)+`,"title":"forbidden"}`); resp.Code == 0 {
+		t.Fatal("expected BeforeUpdate error")
+	}
+	if resp := doHandlerTestRequest(t, router, "PUT", "/article/update", `{"id":9999,"title":"missing"}`); resp.Message != "记录不存在" {
+		t.Fatalf("expected not found, got %q", resp.Message)
+	}
+	stored := &handlerTestArticle{}
+	if err := db.First(stored, article.ID).Error; err != nil {
+		t.Fatalf("find article failed: %v", err)
+	}
+	if stored.Title != "updated" || stored.Status != 1 || stored.AdminID != handlerTestAdminID {
+		t.Fatalf("unexpected stored article: %+v", stored)
+	}
+
+	// 删除（软删除）
+	if resp := doHandlerTestRequest(t, router, "DELETE", "/article/delete?id="+jsonID(article.ID), ""); resp.Code != 0 {
+		t.Fatalf("delete failed: %s", resp.Message)
+	}
+	if err := db.First(&handlerTestArticle{}, article.ID).Error; !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected article deleted, got %v", err)
+	}
+	if len(deleted) != 1 || deleted[0] != "updated" {
+		t.Fatalf("unexpected BeforeDelete calls: %v", deleted)
+	}
+}
+
+func TestHandler_HookRollback(t *testing.T) {
+	router, db := newHandlerTestRouter(t, &Hooks[handlerTestArticle]{
+		AfterCreate: func(ctx context.Context, c *gin.Context, model *handlerTestArticle) error {
+			return errors.New("同步失败")
+		},
+	})
+
+	if resp := doHandlerTestRequest(t, router, "POST", "/article/create", `{"title":"rollback"}`); resp.Code == 0 {
+		t.Fatal("expected AfterCreate error")
+	}
+	var count int64
+	db.Model(&handlerTestArticle{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("expected create rolled back, got %d records", count)
+	}
+}
+
+func TestHandler_BindFieldsRequired(t *testing.T) {
+	_, db := newHandlerTestRouter(t, nil)
+	handler, err := NewHandler[handlerTestArticle](db, slog.New(slog.NewTextHandler(io.Discard, nil)), nil)
+	if err != nil {
+		t.Fatalf("NewHandler failed: %v", err)
+	}
+	router := gin.New()
+	router.POST("/article/create", handler.Create)
+
+	if resp := doHandlerTestRequest(t, router, "POST", "/article/create", `{"title":"first"}`); resp.Code == 0 {
+		t.Fatal("expected error for model without views or bind fields")
+	}
+	var count int64
+	db.Model(&handlerTestArticle{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("expected no records, got %d", count)
+	}
+}
+
+// handlerTestAccount views 测试模型
+type handlerTestAccount struct {
+	coredb.BaseModel
+	Name     string `gorm:"type:varchar(50);not null" json:"name" views:"label:名称;required;search"`
+	Password string `gorm:"type:varchar(100)" json:"password" views:"label:密码;type:password;hidden"`
+	Level    int    `json:"level" views:"label:等级;type:number;search"`
+	Balance  int    `json:"balance" views:"label:余额;readonly"`
+	Role     string `json:"role"`
+}
+
+func TestHandler_Views(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	database, err := coredb.NewSQLiteDatabase(&config.DatabaseConfig{Driver: "sqlite", Database: filepath.Join(t.TempDir(), "views.db"), LogLevel: "silent"}, logger)
+	if err != nil {
+		t.Fatalf("create database failed: %v", err)
+	}
+	t.Cleanup(func() { _ = database.Close(context.Background()) })
+	db := database.DB()
+	if err := db.AutoMigrate(&handlerTestAccount{}); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+
+	handler, err := NewHandler[handlerTestAccount](db, logger, &Hooks[handlerTestAccount]{
+		// 列表查询条件参与总数统计
+		Query: func(ctx context.Context, c *gin.Context, builder *utils.GormBuilder) error {
+			builder.WhereNotEqual("name", "hidden")
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewHandler failed: %v", err)
+	}
+	if len(handler.Fields()) != 4 || handler.Fields()[2].Column != "level" {
+		t.Fatalf("unexpected fields: %+v", handler.Fields())
+	}
+	router := gin.New()
+	router.GET("/account/meta", handler.Meta)
+	router.GET("/account/index", handler.Index)
+	router.POST("/account/create", handler.Create)
+	router.PUT("/account/update", handler.Update)
+
+	if resp := doHandlerTestRequest(t, router, "GET", "/account/meta", ""); resp.Code != 0 || !strings.Contains(string(resp.Data), `"type":"password"`) {
+		t.Fatalf("unexpected meta: %s %s", resp.Message, resp.Data)
+	}
+
+	// 创建（必填校验，只读与未声明 views 的字段不写入，密码哈希保存）
+	if resp := doHandlerTestRequest(t, router, "POST", "/account/create", `{"password":"secret"}`); resp.Code == 0 {
+		t.Fatal("expected required error")
+	}
+	resp := doHandlerTestRequest(t, router, "POST", "/account/create", `{"name":"alice","password":"secret","level":2,"balance":100,"role":"super"}`)
+	if resp.Code != 0 {
+		t.Fatalf("create failed: %s", resp.Message)
+	}
+	account := &handlerTestAccount{}
+	_ = json.Unmarshal(resp.Data, account)
+	stored := &handlerTestAccount{}
+	if err := db.First(stored, account.ID).Error; err != nil {
+		t.Fatalf("find account failed: %v", err)
+	}
+	if stored.Balance != 0 || stored.Role != "" || stored.Level != 2 {
+		t.Fatalf("unexpected stored account: %+v", stored)
+	}
+	if stored.Password == "secret" || bcrypt.CompareHashAndPassword([]byte(stored.Password), []byte("secret")) != nil {
+		t.Fatalf("expected hashed password, got %q", stored.Password)
+	}
+	hash := stored.Password
+
+	// 更新（密码为空时保持原值）
+	if resp := doHandlerTestRequest(t, router, "PUT", "/account/update", `{"id":`+jsonID(account.ID)+`,"name":"alice2","password":""}`); resp.Code != 0 {
+		t.Fatalf("update failed: %s", resp.Message)
+	}
+	stored = &handlerTestAccount{}
+	db.First(stored, account.ID)
+	if stored.Name != "alice2" || stored.Password != hash || stored.Level != 2 {
+		t.Fatalf("unexpected updated account: %+v", stored)
+	}
+
+	// 列表（search 字段过滤，总数与列表使用相同条件）
+	doHandlerTestRequest(t, router, "POST", "/account/create", `{"name":"bob","level":3}`)
+	doHandlerTestRequest(t, router, "POST", "/account/create", `{"name":"hidden","level":3}`)
+	page := &struct {
+		Total int64                 `json:"total"`
+		Items []*handlerTestAccount `json:"items"`
+	}{}
+	resp = doHandlerTestRequest(t, router, "GET", "/account/index?level=3", "")
+	_ = json.Unmarshal(resp.Data, page)
+	if resp.Code != 0 || page.Total != 1 || len(page.Items) != 1 || page.Items[0].Name != "bob" {
+		t.Fatalf("unexpected index result: %s %s", resp.Message, resp.Data)
+	}
+	resp = doHandlerTestRequest(t, router, "GET", "/account/index?name=lic", "")
+	_ = json.Unmarshal(resp.Data, page)
+	if resp.Code != 0 || page.Total != 1 || page.Items[0].Name != "alice2" {
+		t.Fatalf("unexpected search result: %s %s", resp.Message, resp.Data)
+	}
+}
+
+// jsonID 主键转字符串
+func jsonID(id uint) string {
+	data, _ := json.Marshal(id)
+	return string(data)
+}
@@ -0,0 +1,78 @@
+package scaffold
+
+import (
+	"context"
+
+	"github.com/so68/core/server/utils"
+)
+
+// Repo 通用数据操作（T 为 GORM 模型结构体），新模块无需再逐个编写 XxxRepo
+type Repo[T any] interface {
+	// Find 构建查询
+	Find(ctx context.Context, builder *utils.GormBuilder) (*T, error)
+	// FindList 构建查询列表
+	FindList(ctx context.Context, builder *utils.GormBuilder) ([]*T, error)
+	// FindListWithPage 查询分页列表
+	FindListWithPage(ctx context.Context, builder *utils.GormBuilder) (*utils.PageResp, error)
+	// Create 创建记录
+	Create(ctx context.Context, builder *utils.GormBuilder, model *T) error
+	// Update 更新记录
+	Update(ctx context.Context, builder *utils.GormBuilder, model *T) error
+	// Delete 删除记录
+	Delete(ctx context.Context, builder *utils.GormBuilder, isScoped bool, id uint) error
+}
+
+// RepoImpl 通用数据操作实现
+type RepoImpl[T any] struct {
+}
+
+// NewRepo 创建一个通用数据操作
+func NewRepo[T any]() Repo[T] {
+	return &RepoImpl[T]{}
+}
+
+// Find 查询单条数据
+func (r *RepoImpl[T]) Find(ctx context.Context, builder *utils.GormBuilder) (*T, error) {
+	var model T
+	if err := builder.First(&model); err != nil {
+		return nil, err
+	}
+	return &model, nil
+}
+
+// FindList 构建查询列表
+func (r *RepoImpl[T]) FindList(ctx context.Context, builder *utils.GormBuilder) ([]*T, error) {
+	var list []*T
+	if err := builder.Find(&list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// FindListWithPage 构建查询分页
+func (r *RepoImpl[T]) FindListWithPage(ctx context.Context, builder *utils.GormBuilder) (*utils.PageResp, error) {
+	var list []*T
+	total, err := builder.TotalCount(new(T))
+	if err != nil {
+		return nil, err
+	}
+	if err := builder.Find(&list); err != nil {
+		return nil, err
+	}
+	return utils.NewPageResp(total, builder.Page, list), nil
+}
+
+// Create 创建记录
+func (r *RepoImpl[T]) Create(ctx context.Context, builder *utils.GormBuilder, model *T) error {
+	return builder.Create(model)
+}
+
+// Update 更新记录
+func (r *RepoImpl[T]) Update(ctx context.Context, builder *utils.GormBuilder, model *T) error {
+	return builder.Update(model)
+}
+
+// Delete 删除记录
+func (r *RepoImpl[T]) Delete(ctx context.Context, builder *utils.GormBuilder, isScoped bool, id uint) error {
+	return builder.WhereEqual("id", id).Delete(isScoped, new(T))
+}
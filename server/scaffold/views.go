@@ -0,0 +1,169 @@
+package scaffold
+
+import (
+	"reflect"
+	"strings"
+)
+
+type ViewType string
+
+const (
+	ViewTypeInput    ViewType = "input"    // 单行文本
+	ViewTypeTextarea ViewType = "textarea" // 多行文本
+	ViewTypeNumber   ViewType = "number"   // 数字
+	ViewTypePassword ViewType = "password" // 密码
+	ViewTypeSelect   ViewType = "select"   // 下拉选择
+	ViewTypeSwitch   ViewType = "switch"   // 开关
+	ViewTypeDate     ViewType = "date"     // 日期时间
+	ViewTypeImage    ViewType = "image"    // 图片
+	ViewTypeObject   ViewType = "object"   // 嵌套对象
+)
+
+// ViewOption 下拉选项
+type ViewOption struct {
+	Label string `json:"label"` // 显示名称
+	Value string `json:"value"` // 选项值
+}
+
+// ViewField 表单字段元数据（由 views 标签解析）
+//
+// 标签格式：views:"label:名称;type:select;options:1=启用,2=禁用;required;search;hidden;readonly"
+type ViewField struct {
+	Name        string        `json:"name"`                  // 结构体字段名
+	JSON        string        `json:"json"`                  // JSON 字段名
+	Column      string        `json:"-"`                     // 数据库列名
+	Label       string        `json:"label"`                 // 显示名称
+	Type        ViewType      `json:"type"`                  // 表单类型
+	Placeholder string        `json:"placeholder,omitempty"` // 占位提示
+	Default     string        `json:"default,omitempty"`     // 默认值
+	Options     []*ViewOption `json:"options,omitempty"`     // 下拉选项
+	Required    bool          `json:"required"`              // 是否必填
+	Search      bool          `json:"search"`                // 是否可搜索
+	Hidden      bool          `json:"hidden"`                // 是否在列表中隐藏
+	Readonly    bool          `json:"readonly"`              // 是否只读（不可创建/更新）
+	Children    []*ViewField  `json:"children,omitempty"`    // 嵌套字段
+}
+
+// ParseViews 解析模型的 views 标签
+// - 匿名嵌入结构体的字段会被展开
+// - 结构体类型字段若包含 views 标签，则作为嵌套对象返回
+func ParseViews(model interface{}) []*ViewField {
+	t := reflect.TypeOf(model)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	return parseStruct(t)
+}
+
+// parseStruct 解析结构体字段
+func parseStruct(t reflect.Type) []*ViewField {
+	fields := make([]*ViewField, 0)
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		// 未导出的匿名嵌入结构体的导出字段仍可访问
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+
+		// 展开匿名嵌入结构体
+		ft := sf.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if sf.Anonymous && ft.Kind() == reflect.Struct {
+			fields = append(fields, parseStruct(ft)...)
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag, hasTag := sf.Tag.Lookup("views")
+		var children []*ViewField
+		if ft.Kind() == reflect.Struct {
+			children = parseStruct(ft)
+		}
+		if !hasTag && len(children) == 0 {
+			continue
+		}
+
+		field := parseTag(tag)
+		field.Name = sf.Name
+		field.JSON = jsonName(sf)
+		field.Column = columnName(sf)
+		field.Children = children
+		if field.Label == "" {
+			field.Label = sf.Name
+		}
+		if len(children) > 0 {
+			field.Type = ViewTypeObject
+		}
+		if field.JSON == "-" {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// parseTag 解析 views 标签内容
+func parseTag(tag string) *ViewField {
+	field := &ViewField{Type: ViewTypeInput}
+	for _, part := range strings.Split(tag, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(part, ":")
+		switch strings.TrimSpace(key) {
+		case "label":
+			field.Label = value
+		case "type":
+			field.Type = ViewType(value)
+		case "placeholder":
+			field.Placeholder = value
+		case "default":
+			field.Default = value
+		case "options":
+			for _, option := range strings.Split(value, ",") {
+				v, l, ok := strings.Cut(option, "=")
+				if !ok {
+					l = v
+				}
+				field.Options = append(field.Options, &ViewOption{Label: l, Value: v})
+			}
+		case "required":
+			field.Required = true
+		case "search":
+			field.Search = true
+		case "hidden":
+			field.Hidden = true
+		case "readonly":
+			field.Readonly = true
+		}
+	}
+	return field
+}
+
+// jsonName 获取字段的 JSON 名称
+func jsonName(sf reflect.StructField) string {
+	if tag, ok := sf.Tag.Lookup("json"); ok {
+		if name, _, _ := strings.Cut(tag, ","); name != "" {
+			return name
+		}
+	}
+	return sf.Name
+}
+
+// columnName 获取字段的数据库列名（优先 gorm column 标签）
+func columnName(sf reflect.StructField) string {
+	for _, part := range strings.Split(sf.Tag.Get("gorm"), ";") {
+		if key, value, ok := strings.Cut(part, ":"); ok && strings.EqualFold(strings.TrimSpace(key), "column") {
+			return value
+		}
+	}
+	return ""
+}
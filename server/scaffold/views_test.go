@@ -0,0 +1,88 @@
+package scaffold
+
+import (
+	"testing"
+)
+
+/*
+views 标签解析测试
+
+本文件用于测试模型 views 标签的解析。
+
+运行命令：
+go test -v -run "^TestParseViews.*$"
+
+测试内容：
+1. 标签字段、下拉选项与列名解析 (ParseViews)
+2. 匿名嵌入展开、嵌套对象与忽略字段
+*/
+
+// viewsTestProfile 测试嵌套对象
+type viewsTestProfile struct {
+	Nickname string `json:"nickname" views:"label:昵称"`
+}
+
+// viewsTestBase 测试匿名嵌入结构体
+type viewsTestBase struct {
+	Remark string `json:"remark" views:"label:备注;type:textarea"`
+}
+
+// viewsTestModel 测试模型
+type viewsTestModel struct {
+	viewsTestBase
+	Name     string           `json:"name" views:"label:名称;required;search;placeholder:请输入名称"`
+	Status   int8             `json:"status" gorm:"column:state" views:"label:状态;type:select;options:1=启用,2=禁用;default:1"`
+	Password string           `json:"password" views:"label:密码;type:password;hidden"`
+	Profile  viewsTestProfile `json:"profile"`
+	Ignored  string           `json:"-" views:"label:忽略"`
+	Untagged string           `json:"untagged"`
+	private  string           `views:"label:私有"`
+}
+
+func TestParseViews(t *testing.T) {
+	fields := ParseViews(&viewsTestModel{})
+	if len(fields) != 5 {
+		t.Fatalf("expected 5 fields, got %d", len(fields))
+	}
+
+	byName := make(map[string]*ViewField, len(fields))
+	for _, field := range fields {
+		byName[field.Name] = field
+	}
+
+	if remark := byName["Remark"]; remark == nil || remark.Type != ViewTypeTextarea || remark.JSON != "remark" {
+		t.Fatalf("expected embedded remark field, got %+v", remark)
+	}
+	name := byName["Name"]
+	if name == nil || name.Type != ViewTypeInput || !name.Required || !name.Search || name.Placeholder != "请输入名称" {
+		t.Fatalf("unexpected name field: %+v", name)
+	}
+	status := byName["Status"]
+	if status == nil || status.Type != ViewTypeSelect || status.Column != "state" || status.Default != "1" || len(status.Options) != 2 {
+		t.Fatalf("unexpected status field: %+v", status)
+	}
+	if status.Options[1].Value != "2" || status.Options[1].Label != "禁用" {
+		t.Fatalf("unexpected status option: %+v", status.Options[1])
+	}
+	if password := byName["Password"]; password == nil || password.Type != ViewTypePassword || !password.Hidden {
+		t.Fatalf("unexpected password field: %+v", password)
+	}
+	profile := byName["Profile"]
+	if profile == nil || profile.Type != ViewTypeObject || profile.Label != "Profile" || len(profile.Children) != 1 || profile.Children[0].JSON != "nickname" {
+		t.Fatalf("unexpected profile field: %+v", profile)
+	}
+	for _, name := range []string{"Ignored", "Untagged", "private"} {
+		if byName[name] != nil {
+			t.Errorf("expected %s skipped", name)
+		}
+	}
+}
+
+func TestParseViews_NotStruct(t *testing.T) {
+	if fields := ParseViews("text"); fields != nil {
+		t.Fatalf("expected nil for non-struct, got %v", fields)
+	}
+	if fields := ParseViews(nil); fields != nil {
+		t.Fatalf("expected nil for nil, got %v", fields)
+	}
+}
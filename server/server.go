@@ -4,12 +4,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"html/template"
 	"log/slog"
 	"net/http"
+	"path"
+	"strings"
+	"sync"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 	"github.com/so68/core/config"
+	coreerrors "github.com/so68/core/errors"
+	"github.com/so68/core/i18n"
 	"github.com/so68/core/server/middleware"
+	"github.com/so68/core/server/utils"
+	"github.com/so68/core/server/view"
+	"github.com/so68/core/telemetry"
 )
 
 // ginServer 实现 Server 接口的最小功能版
@@ -18,6 +29,20 @@ type ginServer struct {
 	logger     *slog.Logger
 	engine     *gin.Engine
 	httpServer *http.Server
+	onShutdown []func()
+
+	redirectServer *http.Server              // HTTP 跳转 HTTPS 服务（未启用时为 nil）
+	rateLimiter    *middleware.IPRateLimiter // 按 IP 限流器（未启用时为 nil）
+
+	notifierMutex  sync.RWMutex
+	panicNotifiers []middleware.PanicNotifier // panic 告警通知
+
+	routeMutex sync.RWMutex
+	routeMetas map[string]RouteMeta // 路由描述（键为 "方法 路径"）
+
+	templateMutex sync.RWMutex
+	templateFuncs template.FuncMap // 模板函数（加载模板前注册）
+	views         *view.Engine     // HTML 模板引擎（未加载模板时为 nil）
 }
 
 // NewServer 创建一个最小可用的 Gin 服务实例
@@ -26,31 +51,69 @@ func NewServer(logger *slog.Logger, cfg *config.AppConfig) Server {
 		gin.SetMode(gin.ReleaseMode)
 	}
 	engine := gin.New()
+	// 调试模式下带错误码的错误记录调用栈
+	coreerrors.SetStackCapture(cfg.Debug)
+	// 统一响应格式
+	utils.SetRespFormat(utils.NewRespFormat(cfg.Response))
+	// 参数校验使用 validate 标签，错误中的字段名与请求参数名一致
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.SetTagName("validate")
+		v.RegisterTagNameFunc(i18n.FieldName)
+	}
+	s := &ginServer{logger: logger, engine: engine, cfg: cfg, routeMetas: make(map[string]RouteMeta), templateFuncs: template.FuncMap{}}
+
+	// 链路追踪中间件（按配置启用）
+	if cfg.Telemetry != nil && cfg.Telemetry.Enabled {
+		serviceName := cfg.Name
+		if cfg.Telemetry.ServiceName != "" {
+			serviceName = cfg.Telemetry.ServiceName
+		}
+		engine.Use(telemetry.GinMiddleware(serviceName))
+	}
+
+	// 请求上下文中间件（请求ID与请求日志器，需在链路追踪之后以获取 trace_id）
+	engine.Use(middleware.NewRequestContextMiddleware(logger))
 
-	// 使用gin.Logger()中间件来记录请求日志 - 只在Debug模式下记录请求日志
-	if cfg.Debug {
-		engine.Use(gin.Logger())
+	// 结构化访问日志（需在请求上下文之后以携带关联字段，调试模式下始终启用）
+	if cfg.AccessLog != nil && (cfg.AccessLog.Enabled || cfg.Debug) {
+		engine.Use(middleware.NewAccessLogMiddleware(cfg.AccessLog))
 	}
 
-	// 使用gin.Recovery()中间件来恢复panic
-	engine.Use(gin.Recovery())
+	// panic 恢复（位于访问日志与链路追踪之内，使其记录到 500 响应）
+	engine.Use(middleware.NewRecoveryMiddleware(s.notifyPanic))
 
 	// 基于 x/time/rate 的按 IP 限流（按配置启用）
 	if cfg.RateLimit != nil && cfg.RateLimit.Rate > 0 {
-		engine.Use(middleware.NewIPRateLimitMiddleware(cfg))
+		s.rateLimiter = middleware.NewIPRateLimiter(cfg)
+		engine.Use(s.rateLimiter.Middleware())
 	}
 
 	// CORS 中间件
 	engine.Use(middleware.NewCORSMiddleware(cfg))
 
-	// 测试路由
-	engine.GET("/", func(c *gin.Context) {
-		c.JSON(http.StatusOK, "Welcome to the "+cfg.Name)
-	})
+	// 语言检测中间件
+	engine.Use(middleware.NewLocaleMiddleware(cfg))
+
+	// 租户解析中间件（按配置启用）
+	if cfg.Tenant != nil && cfg.Tenant.Enabled {
+		engine.Use(middleware.NewTenantMiddleware(cfg.Tenant))
+	}
+
+	// 测试路由（前端托管在根路径时由前端处理）
+	if cfg.UI == nil || !cfg.UI.Enabled || strings.TrimRight(cfg.UI.Path, "/") != "" {
+		engine.GET("/", func(c *gin.Context) {
+			c.JSON(http.StatusOK, "Welcome to the "+cfg.Name)
+		})
+	}
+
+	// OpenAPI 文档与 Swagger UI（按配置启用）
+	if cfg.OpenAPI != nil && cfg.OpenAPI.Enabled {
+		s.registerOpenAPI()
+	}
 
 	// 静态文件路由
 	engine.Static(cfg.Static, cfg.Static)
-	return &ginServer{logger: logger, engine: engine, cfg: cfg}
+	return s
 }
 
 // buildHTTPServer 构建 http.Server（不启动）
@@ -65,17 +128,33 @@ func (s *ginServer) buildHTTPServer() *http.Server {
 		IdleTimeout:    s.cfg.ParseDuration(s.cfg.IdleTimeout),  // Keep-Alive 连接的空闲超时时间
 		MaxHeaderBytes: int(s.cfg.MaxHeader),                    // 最大请求头大小(bytes)
 	}
+	for _, fn := range s.onShutdown {
+		server.RegisterOnShutdown(fn)
+	}
+	if s.tlsEnabled() {
+		s.configureTLS(server)
+	}
 	s.httpServer = server
 	return server
 }
 
-// Start 启动 HTTP 服务（阻塞直到关闭）
+// Start 启动 HTTP 服务（阻塞直到关闭），启用 HTTPS 时使用 ListenAndServeTLS
 func (s *ginServer) Start() error {
 	if s.httpServer == nil {
 		s.buildHTTPServer()
 	}
-	s.logger.Info("Server started successfully", slog.String("addr", fmt.Sprintf("%s:%d", s.cfg.Host, s.cfg.Port)))
-	if err := s.httpServer.ListenAndServe(); err != nil {
+
+	var err error
+	if s.tlsEnabled() {
+		s.startRedirect()
+		s.logger.Info("Server started successfully", slog.String("addr", fmt.Sprintf("%s:%d", s.cfg.Host, s.cfg.Port)), slog.Bool("tls", true), slog.Bool("auto_cert", s.cfg.TLS.AutoCert))
+		// 自动证书由 TLSConfig.GetCertificate 提供，证书文件参数为空
+		err = s.httpServer.ListenAndServeTLS(s.cfg.TLS.CertFile, s.cfg.TLS.KeyFile)
+	} else {
+		s.logger.Info("Server started successfully", slog.String("addr", fmt.Sprintf("%s:%d", s.cfg.Host, s.cfg.Port)))
+		err = s.httpServer.ListenAndServe()
+	}
+	if err != nil {
 		if errors.Is(err, http.ErrServerClosed) {
 			return nil
 		}
@@ -84,6 +163,11 @@ func (s *ginServer) Start() error {
 	return nil
 }
 
+// tlsEnabled 是否启用 HTTPS
+func (s *ginServer) tlsEnabled() bool {
+	return s.cfg.TLS != nil && s.cfg.TLS.Enabled
+}
+
 // StartAsync 非阻塞启动，返回错误通道
 func (s *ginServer) StartAsync() <-chan error {
 	ch := make(chan error, 1)
@@ -97,12 +181,57 @@ func (s *ginServer) StartAsync() <-chan error {
 
 // Shutdown 优雅关闭 HTTP 服务
 func (s *ginServer) Shutdown(ctx context.Context) error {
+	if s.rateLimiter != nil {
+		s.rateLimiter.Close()
+	}
 	if s.httpServer == nil {
 		return nil
 	}
+	if s.redirectServer != nil {
+		if err := s.redirectServer.Shutdown(ctx); err != nil {
+			s.logger.Warn("failed to shutdown HTTP redirect server", slog.Any("error", err))
+		}
+	}
 	return s.httpServer.Shutdown(ctx)
 }
 
+// OnShutdown 注册关闭回调（http.Server.Shutdown 不会关闭被劫持的连接）
+func (s *ginServer) OnShutdown(fn func()) {
+	s.onShutdown = append(s.onShutdown, fn)
+	if s.httpServer != nil {
+		s.httpServer.RegisterOnShutdown(fn)
+	}
+}
+
+// OnPanic 注册 panic 告警通知
+func (s *ginServer) OnPanic(notifier middleware.PanicNotifier) {
+	s.notifierMutex.Lock()
+	defer s.notifierMutex.Unlock()
+	s.panicNotifiers = append(s.panicNotifiers, notifier)
+}
+
+// notifyPanic 依次调用已注册的 panic 告警通知（单个通知 panic 不影响其他通知）
+func (s *ginServer) notifyPanic(ctx context.Context, event *middleware.PanicEvent) {
+	s.notifierMutex.RLock()
+	notifiers := s.panicNotifiers
+	s.notifierMutex.RUnlock()
+	for _, notifier := range notifiers {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					s.logger.Error("panic notifier failed", slog.Any("panic", r))
+				}
+			}()
+			notifier(ctx, event)
+		}()
+	}
+}
+
+// Use 添加全局中间件
+func (s *ginServer) Use(middlewares ...gin.HandlerFunc) {
+	s.engine.Use(middlewares...)
+}
+
 // NewGroup 创建一个路由组
 func (s *ginServer) NewGroup(relativePath string) *gin.RouterGroup {
 	return s.engine.Group(relativePath)
@@ -129,5 +258,47 @@ func (s *ginServer) Register(group *gin.RouterGroup, handlers ...RouterHandler)
 		case RouterMethodPatch:
 			group.PATCH(handler.Path, handler.Handler)
 		}
+		if handler.Name != "" || handler.Doc != nil {
+			s.Describe(string(handler.Method), joinPaths(group.BasePath(), handler.Path), RouteMeta{Name: handler.Name, Doc: handler.Doc})
+		}
+	}
+}
+
+// Describe 描述路由
+func (s *ginServer) Describe(method string, path string, meta RouteMeta) {
+	s.routeMutex.Lock()
+	defer s.routeMutex.Unlock()
+	s.routeMetas[method+" "+path] = meta
+}
+
+// Routes 获取已注册的路由
+func (s *ginServer) Routes() []RouteInfo {
+	s.routeMutex.RLock()
+	defer s.routeMutex.RUnlock()
+
+	routes := s.engine.Routes()
+	infos := make([]RouteInfo, 0, len(routes))
+	for _, route := range routes {
+		meta := s.routeMetas[route.Method+" "+route.Path]
+		infos = append(infos, RouteInfo{
+			Method:     route.Method,
+			Path:       route.Path,
+			Handler:    route.Handler,
+			Name:       meta.Name,
+			Permission: meta.Permission,
+		})
+	}
+	return infos
+}
+
+// joinPaths 拼接路由组路径与相对路径
+func joinPaths(basePath string, relativePath string) string {
+	if relativePath == "" {
+		return basePath
+	}
+	joined := path.Join(basePath, relativePath)
+	if strings.HasSuffix(relativePath, "/") && !strings.HasSuffix(joined, "/") {
+		joined += "/"
 	}
+	return joined
 }
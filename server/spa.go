@@ -0,0 +1,106 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/config"
+)
+
+// SPA 托管单页应用（fsys 根目录为构建产物，例如 fs.Sub(dist, "dist")）
+// - 存在的文件直接返回，静态资源按配置缓存，入口文件不缓存
+// - 浏览器访问不存在的页面路径（无扩展名且接受 text/html）时返回入口文件，由前端路由处理（history 模式）
+// - relativePath 为 "/" 时作为未匹配路由的兜底，不影响已注册的接口
+func (s *ginServer) SPA(relativePath string, fsys fs.FS) {
+	cfg := s.cfg.UI
+	if cfg == nil {
+		cfg = config.DefaultUIConfig()
+	}
+	handler := newSPAHandler(fsys, cfg.Index, cfg.MaxAge)
+
+	prefix := strings.TrimRight(relativePath, "/")
+	if prefix == "" {
+		s.engine.NoRoute(func(c *gin.Context) {
+			if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+				c.Status(http.StatusNotFound)
+				return
+			}
+			handler(c, c.Request.URL.Path)
+		})
+		return
+	}
+	serve := func(c *gin.Context) {
+		handler(c, c.Param("filepath"))
+	}
+	s.engine.GET(prefix+"/*filepath", serve)
+	s.engine.HEAD(prefix+"/*filepath", serve)
+}
+
+// newSPAHandler 创建单页应用文件处理
+func newSPAHandler(fsys fs.FS, index string, maxAge time.Duration) func(c *gin.Context, name string) {
+	cacheControl := fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds()))
+	return func(c *gin.Context, name string) {
+		name = strings.TrimPrefix(path.Clean("/"+name), "/")
+		if name == "" {
+			name = index
+		}
+		info, err := fs.Stat(fsys, name)
+		if err != nil || info.IsDir() {
+			if path.Ext(name) != "" || !acceptsHTML(c.Request) {
+				c.Status(http.StatusNotFound)
+				return
+			}
+			name = index
+		}
+
+		if name == index {
+			c.Header("Cache-Control", "no-cache")
+		} else {
+			c.Header("Cache-Control", cacheControl)
+		}
+		if err := serveFS(c.Writer, c.Request, fsys, name); err != nil {
+			c.Status(http.StatusNotFound)
+		}
+	}
+}
+
+// acceptsHTML 请求是否为浏览器页面访问
+func acceptsHTML(r *http.Request) bool {
+	accept := r.Header.Get("Accept")
+	return accept == "" || strings.Contains(accept, "text/html")
+}
+
+// serveFS 输出文件（支持 Range 与 If-Modified-Since，不会像 http.FileServer 一样将 index.html 重定向到目录）
+func serveFS(w http.ResponseWriter, r *http.Request, fsys fs.FS, name string) error {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return errors.New("is a directory")
+	}
+
+	content, ok := file.(io.ReadSeeker)
+	if !ok {
+		data, err := io.ReadAll(file)
+		if err != nil {
+			return err
+		}
+		content = bytes.NewReader(data)
+	}
+	http.ServeContent(w, r, name, info.ModTime(), content)
+	return nil
+}
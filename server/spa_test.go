@@ -0,0 +1,97 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/config"
+)
+
+/*
+单页应用托管测试
+
+本文件用于测试内嵌前端的托管，
+包括静态文件、history 模式兜底、缓存头以及根路径托管时与接口路由共存。
+
+运行命令：
+go test -v -run "^TestSPA.*$"
+
+测试内容：
+1. 子路径托管 (SPA)
+2. 根路径托管 (SPA, NoRoute)
+*/
+
+// newSPATestServer 创建托管测试前端的服务
+func newSPATestServer(path string) *ginServer {
+	cfg := config.DefaultAppConfig()
+	cfg.UI.Enabled = true
+	cfg.UI.Path = path
+	cfg.UI.MaxAge = time.Hour
+	s := NewServer(slog.New(slog.NewTextHandler(io.Discard, nil)), cfg).(*ginServer)
+	s.SPA(path, fstest.MapFS{
+		"index.html":    {Data: []byte("<html>console</html>")},
+		"assets/app.js": {Data: []byte("console.log(1)")},
+	})
+	s.NewGroup("/api").GET("/ping", func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+	return s
+}
+
+// doSPARequest 发起测试请求
+func doSPARequest(s *ginServer, method string, target string, accept string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, target, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	s.engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestSPA(t *testing.T) {
+	tests := []struct {
+		name         string
+		root         string
+		target       string
+		method       string
+		accept       string
+		wantStatus   int
+		wantBody     string
+		wantCacheHdr string
+	}{
+		{name: "入口文件", root: "/console", target: "/console/", accept: "text/html", wantStatus: 200, wantBody: "<html>console</html>", wantCacheHdr: "no-cache"},
+		{name: "静态资源", root: "/console", target: "/console/assets/app.js", wantStatus: 200, wantBody: "console.log(1)", wantCacheHdr: "public, max-age=3600"},
+		{name: "前端路由兜底", root: "/console", target: "/console/system/admin", accept: "text/html,application/xhtml+xml", wantStatus: 200, wantBody: "<html>console</html>", wantCacheHdr: "no-cache"},
+		{name: "入口文件不重定向", root: "/console", target: "/console/index.html", accept: "text/html", wantStatus: 200, wantBody: "<html>console</html>"},
+		{name: "缺失资源", root: "/console", target: "/console/assets/missing.js", accept: "text/html", wantStatus: 404},
+		{name: "接口请求不兜底", root: "/console", target: "/console/system/admin", accept: "application/json", wantStatus: 404},
+		{name: "路径穿越", root: "/console", target: "/console/../../etc/passwd", accept: "text/html", wantStatus: 200, wantBody: "<html>console</html>"},
+		{name: "根路径入口", root: "/", target: "/", accept: "text/html", wantStatus: 200, wantBody: "<html>console</html>"},
+		{name: "根路径前端路由", root: "/", target: "/system/admin", accept: "text/html", wantStatus: 200, wantBody: "<html>console</html>"},
+		{name: "根路径接口优先", root: "/", target: "/api/ping", accept: "text/html", wantStatus: 200, wantBody: "pong"},
+		{name: "根路径非 GET 请求", root: "/", target: "/system/admin", method: "POST", accept: "text/html", wantStatus: 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			w := doSPARequest(newSPATestServer(tt.root), method, tt.target, tt.accept)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("Expected body %q, got %q", tt.wantBody, w.Body.String())
+			}
+			if tt.wantCacheHdr != "" && w.Header().Get("Cache-Control") != tt.wantCacheHdr {
+				t.Errorf("Expected Cache-Control %q, got %q", tt.wantCacheHdr, w.Header().Get("Cache-Control"))
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"html/template"
+	"io/fs"
+
+	"github.com/so68/core/server/view"
+)
+
+// LoadTemplates 加载 HTML 模板（按 template 配置设置默认布局与热加载），加载后 c.HTML 使用该模板引擎渲染
+func (s *ginServer) LoadTemplates(fsys fs.FS, opts ...view.Option) error {
+	s.templateMutex.Lock()
+	defer s.templateMutex.Unlock()
+
+	options := []view.Option{view.WithFuncs(s.templateFuncs)}
+	if cfg := s.cfg.Template; cfg != nil {
+		options = append(options, view.WithLayout(cfg.Layout), view.WithReload(cfg.Reload))
+	}
+	views, err := view.New(fsys, append(options, opts...)...)
+	if err != nil {
+		return err
+	}
+	s.views = views
+	s.engine.HTMLRender = views
+	return nil
+}
+
+// TemplateFuncs 添加模板函数（已加载模板时重新加载）
+func (s *ginServer) TemplateFuncs(funcs template.FuncMap) error {
+	s.templateMutex.Lock()
+	defer s.templateMutex.Unlock()
+
+	for name, fn := range funcs {
+		s.templateFuncs[name] = fn
+	}
+	if s.views == nil {
+		return nil
+	}
+	return s.views.Funcs(funcs)
+}
+
+// Views 获取 HTML 模板引擎（未加载模板时为 nil）
+func (s *ginServer) Views() *view.Engine {
+	s.templateMutex.RLock()
+	defer s.templateMutex.RUnlock()
+	return s.views
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"html/template"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/config"
+)
+
+/*
+服务端 HTML 模板测试
+
+本文件用于测试服务加载 HTML 模板后通过 c.HTML 渲染页面，
+包括按配置使用默认布局，以及加载后注册模板函数时重新加载。
+
+运行命令：
+go test -v -run "^TestTemplate.*$"
+
+测试内容：
+1. 加载模板与默认布局 (LoadTemplates, c.HTML)
+2. 加载后注册模板函数 (TemplateFuncs)
+*/
+
+func TestTemplateLoadAndRender(t *testing.T) {
+	cfg := config.DefaultAppConfig()
+	cfg.Template.Layout = "layouts/base.html"
+	s := NewServer(slog.New(slog.NewTextHandler(io.Discard, nil)), cfg).(*ginServer)
+	if s.Views() != nil {
+		t.Fatal("Expected no views before loading templates")
+	}
+
+	fsys := fstest.MapFS{
+		"layouts/base.html": {Data: []byte(`<body>{{template "content" .}}</body>`)},
+		"hello.html":        {Data: []byte(`{{define "content"}}{{greet .Name}}{{end}}`)},
+	}
+	if err := s.TemplateFuncs(template.FuncMap{"greet": func(name string) string { return "hello " + name }}); err != nil {
+		t.Fatalf("TemplateFuncs failed: %v", err)
+	}
+	if err := s.LoadTemplates(fsys); err != nil {
+		t.Fatalf("LoadTemplates failed: %v", err)
+	}
+	s.NewGroup("").GET("/hello", func(c *gin.Context) {
+		c.HTML(http.StatusOK, "hello.html", gin.H{"Name": "admin"})
+	})
+
+	get := func() string {
+		w := httptest.NewRecorder()
+		s.engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/hello", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d", w.Code)
+		}
+		return w.Body.String()
+	}
+	if got := get(); got != "<body>hello admin</body>" {
+		t.Errorf("Unexpected output: %s", got)
+	}
+
+	if err := s.TemplateFuncs(template.FuncMap{"greet": func(name string) string { return "hi " + name }}); err != nil {
+		t.Fatalf("TemplateFuncs failed: %v", err)
+	}
+	if got := get(); got != "<body>hi admin</body>" {
+		t.Errorf("Expected output with new funcs, got %s", got)
+	}
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/so68/core/config"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// newCertManager 创建 Let's Encrypt 自动证书管理器
+func newCertManager(cfg *config.TLSConfig) *autocert.Manager {
+	return &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(cfg.Domains...),
+		Cache:      autocert.DirCache(cfg.CacheDir),
+		Email:      cfg.Email,
+	}
+}
+
+// newRedirectHandler 创建 HTTP 跳转 HTTPS 处理器（保留路径与查询参数，非 443 端口时附加端口）
+func newRedirectHandler(httpsPort int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if httpsPort != 443 {
+			host = net.JoinHostPort(host, strconv.Itoa(httpsPort))
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	})
+}
+
+// configureTLS 为 HTTPS 服务配置证书，并按需构建 HTTP 跳转服务
+func (s *ginServer) configureTLS(server *http.Server) {
+	tlsCfg := s.cfg.TLS
+	redirect := newRedirectHandler(s.cfg.Port)
+
+	if tlsCfg.AutoCert {
+		manager := newCertManager(tlsCfg)
+		server.TLSConfig = manager.TLSConfig()
+		// HTTP 服务同时处理 ACME HTTP-01 验证，其余请求跳转到 HTTPS
+		redirect = manager.HTTPHandler(redirect)
+	} else {
+		server.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	if tlsCfg.RedirectHTTP {
+		s.redirectServer = &http.Server{
+			Addr:              fmt.Sprintf("%s:%d", s.cfg.Host, tlsCfg.HTTPPort),
+			Handler:           redirect,
+			ReadHeaderTimeout: s.cfg.ParseDuration(s.cfg.ReadTimeout),
+			IdleTimeout:       s.cfg.ParseDuration(s.cfg.IdleTimeout),
+		}
+	}
+}
+
+// startRedirect 后台启动 HTTP 跳转服务
+func (s *ginServer) startRedirect() {
+	if s.redirectServer == nil {
+		return
+	}
+	go func() {
+		s.logger.Info("HTTP redirect server started", slog.String("addr", s.redirectServer.Addr))
+		if err := s.redirectServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("HTTP redirect server failed", slog.Any("error", err))
+		}
+	}()
+}
@@ -2,12 +2,24 @@ package utils
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/so68/core/database"
+	"github.com/so68/core/tenant"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"gorm.io/plugin/dbresolver"
 )
 
+// ErrTooManyAffected 批量操作影响的记录数超过上限（操作已回滚）
+var ErrTooManyAffected = errors.New("影响记录数超过上限")
+
 type GormBuilderWhereOperator string
 
 const (
@@ -31,8 +43,19 @@ type GormBuilderWhere struct {
 	Operator GormBuilderWhereOperator
 	Field    string
 	Value    interface{}
+	Or       bool                // 与前面的条件以 OR 连接（默认 AND）
+	Group    []*GormBuilderWhere // 分组子条件（非空时忽略 Operator/Field/Value，整体加括号）
 }
 
+// trashedMode 软删除记录查询方式
+type trashedMode int8
+
+const (
+	trashedModeNone trashedMode = iota // 不含已删除记录（默认）
+	trashedModeWith                    // 包含已删除记录
+	trashedModeOnly                    // 仅查询已删除记录
+)
+
 // GormBuilderJoin GORM 构建器连接
 type GormBuilderJoin struct {
 	Query string        // 查询
@@ -50,18 +73,36 @@ type GormBuilder struct {
 	joins    []*GormBuilderJoin  // 连接
 	wheres   []*GormBuilderWhere // 条件
 	groups   []string            // 分组
+	primary  bool                // 强制使用主库
+	trashed  trashedMode         // 软删除记录查询方式
+	timeout  time.Duration       // 单次操作超时（0 表示不限制）
+
+	dataScope *DataScope // 数据权限范围（来自上下文）
+	tenantID  string     // 租户ID（来自上下文）
 }
 
 // NewGormBuilder 创建 GORM 构建器
+// - 所有操作（包括预加载与事务内的语句）使用 ctx 执行，请求取消或超时后中止查询
+// - 上下文中存在事务时自动加入该事务
+// - 上下文中存在数据权限范围时，查询、更新、删除自动按范围过滤
+// - 上下文中存在租户时，查询、更新、删除自动按租户字段过滤，创建与保存时为数据设置当前租户
 func NewGormBuilder(ctx context.Context, db *gorm.DB) *GormBuilder {
+	if tx, ok := database.TxFromContext(ctx); ok {
+		db = tx
+	}
+	dataScope, _ := DataScopeFromContext(ctx)
+	tenantID, _ := tenant.FromContext(ctx)
 	return &GormBuilder{
-		db:       db,
-		Page:     &Page{},
-		selects:  make([]string, 0),
-		preloads: make([]string, 0),
-		joins:    make([]*GormBuilderJoin, 0),
-		wheres:   make([]*GormBuilderWhere, 0),
-		groups:   make([]string, 0),
+		ctx:       ctx,
+		dataScope: dataScope,
+		tenantID:  tenantID,
+		db:        db,
+		Page:      &Page{},
+		selects:   make([]string, 0),
+		preloads:  make([]string, 0),
+		joins:     make([]*GormBuilderJoin, 0),
+		wheres:    make([]*GormBuilderWhere, 0),
+		groups:    make([]string, 0),
 	}
 }
 
@@ -70,36 +111,78 @@ func NewGormBuilderFind(ctx context.Context, db *gorm.DB, field string, value in
 	return NewGormBuilder(ctx, db).WhereEqual(field, value)
 }
 
-// NewGormBuilderWithPage 创建分页查询构建器
-func NewGormBuilderWithPage(ctx context.Context, db *gorm.DB, page *Page) *GormBuilder {
-	return NewGormBuilder(ctx, db).WithPage(page)
+// NewGormBuilderWithPage 创建分页查询构建器（sorts 为允许排序的字段，见 WithPage）
+func NewGormBuilderWithPage(ctx context.Context, db *gorm.DB, page *Page, sorts ...string) *GormBuilder {
+	return NewGormBuilder(ctx, db).WithPage(page, sorts...)
 }
 
-// TotalCount 获取总记录数
-func (b *GormBuilder) TotalCount(n int64) *GormBuilder {
-	b.build().Count(&n)
-	return b
+// TotalCount 获取总记录数（忽略分页与排序，model 为空时使用 Model 设置的模型）
+func (b *GormBuilder) TotalCount(model interface{}) (int64, error) {
+	b, cancel := b.begin()
+	defer cancel()
+	db := b.build(model)
+	if model != nil {
+		db = db.Model(model)
+	}
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
 }
 
-// Find 查询数据
+// Find 查询数据（分页参数包含游标时按游标分页）
 func (b *GormBuilder) Find(data interface{}) error {
+	b, cancel := b.begin()
+	defer cancel()
+	db := b.build(data)
+	if b.Page.Cursor != "" {
+		return b.findWithCursor(db, data)
+	}
+
 	// 分页
 	if b.Page.Size > 0 {
-		b.db.Offset(int(b.Page.GetOffset())).Limit(int(b.Page.GetLimit()))
+		db = db.Offset(int(b.Page.GetOffset())).Limit(int(b.Page.GetLimit()))
+	}
+	// 字段排序（排序字段来自请求参数，仅接受合法的字段名）
+	if orderBy, ok := b.Page.orderBy(); ok {
+		db = db.Order(orderBy)
 	}
-	// 字段排序
-	if b.Page.Sort != "" {
-		b.db.Order(b.Page.GetSort())
+	// 排序字段为模型字段时追加主键排序，保证排序稳定，并返回下一页游标（客户端可从任意一页切换为游标分页）
+	fields, err := b.lookupCursorFields(db, data)
+	if err == nil && fields.sort != fields.primary {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: fields.qualifiedPrimary(b.Page.Sort)}, Desc: fields.desc})
 	}
-	if err := b.build().Find(data).Error; err != nil {
+	if err := db.Find(data).Error; err != nil {
 		return err
 	}
+	if fields != nil && b.Page.Size > 0 {
+		b.Page.nextCursor = b.nextPageCursor(fields, data)
+	}
+	return nil
+}
+
+// findWithCursor 游标分页查询
+func (b *GormBuilder) findWithCursor(db *gorm.DB, data interface{}) error {
+	fields, err := b.lookupCursorFields(db, data)
+	if err != nil {
+		return err
+	}
+	if db, err = b.applyCursor(db, fields); err != nil {
+		return err
+	}
+	if err := db.Find(data).Error; err != nil {
+		return err
+	}
+	b.Page.nextCursor = b.nextPageCursor(fields, data)
 	return nil
 }
 
 // First 查询单条数据
 func (b *GormBuilder) First(data interface{}) error {
-	if err := b.build().First(data).Error; err != nil {
+	b, cancel := b.begin()
+	defer cancel()
+	if err := b.build(data).First(data).Error; err != nil {
 		return err
 	}
 	return nil
@@ -107,28 +190,188 @@ func (b *GormBuilder) First(data interface{}) error {
 
 // Create 创建数据
 func (b *GormBuilder) Create(data interface{}) error {
-	return b.db.WithContext(b.ctx).Create(data).Error
+	b, cancel := b.begin()
+	defer cancel()
+	db := b.session()
+	if _, err := b.assignTenant(db, data); err != nil {
+		return err
+	}
+	return db.Create(data).Error
 }
 
-// Update 更新数据
+// Update 更新数据（记录不存在或不在数据权限范围、当前租户内时返回 gorm.ErrRecordNotFound）
 func (b *GormBuilder) Update(data interface{}) error {
-	return b.build().WithContext(b.ctx).Save(data).Error
+	b, cancel := b.begin()
+	defer cancel()
+	db := b.build(data)
+	scoped, err := b.assignTenant(db, data)
+	if err != nil {
+		return err
+	}
+	// 按数据权限范围或租户隔离时更新全部字段但不回退为插入（避免条件不匹配时以插入覆盖范围外的记录）
+	if (scoped || (b.dataScope != nil && !b.dataScope.All)) && len(b.selects) == 0 {
+		db = db.Select("*")
+	}
+	result := db.Save(data)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
 
 // Delete 删除数据
 func (b *GormBuilder) Delete(isScoped bool, model interface{}) error {
+	b, cancel := b.begin()
+	defer cancel()
 	if isScoped {
-		return b.build().WithContext(b.ctx).Delete(model).Error
+		return b.build(model).Delete(model).Error
+	}
+	return b.build(model).Unscoped().Delete(model).Error
+}
+
+// CreateInBatches 分批创建数据（data 为切片，batchSize 为每批条数）
+func (b *GormBuilder) CreateInBatches(data interface{}, batchSize int) error {
+	b, cancel := b.begin()
+	defer cancel()
+	db := b.session()
+	if _, err := b.assignTenant(db, data); err != nil {
+		return err
 	}
-	return b.build().WithContext(b.ctx).Unscoped().Delete(model).Error
+	return db.CreateInBatches(data, batchSize).Error
+}
+
+// BatchUpdate 按条件批量更新字段，返回影响的记录数
+// - values 为列名与值，会执行模型的更新钩子并自动更新 updated_at
+// - 没有任何条件时返回 gorm.ErrMissingWhereClause，避免误更新全表
+func (b *GormBuilder) BatchUpdate(model interface{}, values map[string]interface{}) (int64, error) {
+	b, cancel := b.begin()
+	defer cancel()
+	result := b.build(model).Model(model).Updates(values)
+	return result.RowsAffected, result.Error
+}
+
+// UpdateColumns 按条件更新指定字段，返回影响的记录数
+// - 仅写入 values 中的列，不执行模型的更新钩子，也不更新 updated_at（适用于记录使用时间等并发写入的字段）
+// - 没有任何条件时返回 gorm.ErrMissingWhereClause，避免误更新全表
+func (b *GormBuilder) UpdateColumns(model interface{}, values map[string]interface{}) (int64, error) {
+	b, cancel := b.begin()
+	defer cancel()
+	result := b.build(model).Model(model).UpdateColumns(values)
+	return result.RowsAffected, result.Error
+}
+
+// BatchDelete 按条件批量删除，返回影响的记录数
+// - maxAffected 大于 0 时，删除的记录数超过上限则回滚并返回 ErrTooManyAffected
+// - 没有任何条件时返回 gorm.ErrMissingWhereClause，避免误删全表
+func (b *GormBuilder) BatchDelete(isScoped bool, model interface{}, maxAffected int64) (int64, error) {
+	b, cancel := b.begin()
+	defer cancel()
+	var affected int64
+	err := b.session().Transaction(func(tx *gorm.DB) error {
+		clone := b.Clone()
+		clone.db = tx
+		db := clone.build(model)
+		if !isScoped {
+			db = db.Unscoped()
+		}
+		result := db.Delete(model)
+		if result.Error != nil {
+			return result.Error
+		}
+		affected = result.RowsAffected
+		if maxAffected > 0 && affected > maxAffected {
+			return fmt.Errorf("%w: %d > %d", ErrTooManyAffected, affected, maxAffected)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return affected, nil
+}
+
+// Restore 恢复软删除的记录（仅恢复符合条件的已删除记录，没有可恢复的记录时返回 gorm.ErrRecordNotFound）
+func (b *GormBuilder) Restore(model interface{}) error {
+	b, cancel := b.begin()
+	defer cancel()
+	clone := b.Clone().OnlyTrashed()
+	db := clone.build(model).Model(model)
+	column, ok := softDeleteColumn(db, model)
+	if !ok {
+		return fmt.Errorf("模型 %T 不支持软删除", model)
+	}
+	result := db.UpdateColumn(column.Name, nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Clone 复制构建器（条件、字段、分页互不影响），便于在同一组条件上分别执行多次查询
+func (b *GormBuilder) Clone() *GormBuilder {
+	clone := *b
+	page := *b.Page
+	clone.Page = &page
+	clone.selects = slices.Clone(b.selects)
+	clone.preloads = slices.Clone(b.preloads)
+	clone.joins = slices.Clone(b.joins)
+	clone.wheres = slices.Clone(b.wheres)
+	clone.groups = slices.Clone(b.groups)
+	return &clone
 }
 
 // WithPage 设置分页参数
-func (b *GormBuilder) WithPage(page *Page) *GormBuilder {
+// - sorts 为允许排序的字段白名单，排序字段不在白名单中时使用白名单第一个字段；为空时仅校验字段名格式
+// - 排序方向统一为 ASC 或 DESC（非 DESC 均按 ASC）
+func (b *GormBuilder) WithPage(page *Page, sorts ...string) *GormBuilder {
+	page.normalize(sorts)
 	b.Page = page
 	return b
 }
 
+// UsePrimary 强制查询走主库（配置读写分离时默认读从库，写后立即读等场景需避免复制延迟）
+func (b *GormBuilder) UsePrimary() *GormBuilder {
+	b.primary = true
+	return b
+}
+
+// Timeout 设置单次操作超时（超时后中止查询并返回 context.DeadlineExceeded，与上下文的取消同时生效）
+// - 数据库配置了 queryTimeout 时每条语句另有默认超时，以较早的截止时间为准
+func (b *GormBuilder) Timeout(timeout time.Duration) *GormBuilder {
+	b.timeout = timeout
+	return b
+}
+
+// WithTrashed 查询结果包含软删除的记录
+func (b *GormBuilder) WithTrashed() *GormBuilder {
+	b.trashed = trashedModeWith
+	return b
+}
+
+// OnlyTrashed 仅查询软删除的记录
+func (b *GormBuilder) OnlyTrashed() *GormBuilder {
+	b.trashed = trashedModeOnly
+	return b
+}
+
+// WithoutDataScope 忽略数据权限范围（系统内部查询使用）
+func (b *GormBuilder) WithoutDataScope() *GormBuilder {
+	b.dataScope = nil
+	return b
+}
+
+// WithoutTenant 忽略租户隔离（跨租户的系统内部查询使用）
+func (b *GormBuilder) WithoutTenant() *GormBuilder {
+	b.tenantID = ""
+	return b
+}
+
 // Select 添加选择字段
 func (b *GormBuilder) Select(fields ...string) *GormBuilder {
 	b.selects = append(b.selects, fields...)
@@ -226,48 +469,273 @@ func (b *GormBuilder) WhereIsNotNull(field string) *GormBuilder {
 	return b.Where(GormBuilderWhereOperatorIsNotNull, field, nil)
 }
 
-// Build 构建 GORM 查询
-func (b *GormBuilder) build() *gorm.DB {
+// WhereOr 添加与前面条件以 OR 连接的条件
+// - 例如 WhereEqual("a", 1).WhereOr(GormBuilderWhereOperatorEqual, "b", 2) 生成 a = 1 OR b = 2
+func (b *GormBuilder) WhereOr(operator GormBuilderWhereOperator, field string, value interface{}) *GormBuilder {
+	b.wheres = append(b.wheres, &GormBuilderWhere{
+		Operator: operator,
+		Field:    field,
+		Value:    value,
+		Or:       true,
+	})
+	return b
+}
+
+// WhereGroup 添加以 AND 连接的分组条件（仅收集 fn 中添加的条件）
+// - 例如 WhereGroup(func(g *GormBuilder) { g.WhereEqual("a", 1).WhereOr(GormBuilderWhereOperatorEqual, "b", 2) }).WhereEqual("c", 3)
+// 生成 (a = 1 OR b = 2) AND c = 3
+func (b *GormBuilder) WhereGroup(fn func(g *GormBuilder)) *GormBuilder {
+	return b.whereGroup(false, fn)
+}
+
+// WhereOrGroup 添加以 OR 连接的分组条件
+func (b *GormBuilder) WhereOrGroup(fn func(g *GormBuilder)) *GormBuilder {
+	return b.whereGroup(true, fn)
+}
+
+// whereGroup 添加分组条件（分组为空时忽略）
+func (b *GormBuilder) whereGroup(or bool, fn func(g *GormBuilder)) *GormBuilder {
+	group := &GormBuilder{Page: &Page{}, wheres: make([]*GormBuilderWhere, 0)}
+	fn(group)
+	if len(group.wheres) > 0 {
+		b.wheres = append(b.wheres, &GormBuilderWhere{Group: group.wheres, Or: or})
+	}
+	return b
+}
+
+// Build 构建 GORM 查询（model 用于确定数据权限字段，为空时使用 Model 设置的模型）
+func (b *GormBuilder) build(model interface{}) *gorm.DB {
+	db := b.session()
+
+	// 强制主库
+	if b.primary {
+		db = db.Clauses(dbresolver.Write)
+	}
+
 	// 构建选择字段
 	if len(b.selects) > 0 {
-		b.db = b.db.Select(strings.Join(b.selects, ","))
+		db = db.Select(strings.Join(b.selects, ","))
 	}
 
 	// 构建预加载
 	if len(b.preloads) > 0 {
 		for _, preload := range b.preloads {
-			b.db = b.db.Preload(preload)
+			db = db.Preload(preload)
 		}
 	}
 
 	// 构建连接
 	if len(b.joins) > 0 {
 		for _, join := range b.joins {
-			b.db = b.db.Joins(join.Query, join.Args...)
+			db = db.Joins(join.Query, join.Args...)
 		}
 	}
 
-	// 构建条件
-	for _, where := range b.wheres {
-		switch where.Operator {
-		case GormBuilderWhereOperatorBetween:
-			// 范围类型需要两个值 安全的类型断言
-			if values, ok := where.Value.([]interface{}); ok && len(values) == 2 {
-				b.db = b.db.Where(fmt.Sprintf("%s BETWEEN ? AND ?", where.Field), values[0], values[1])
-			}
-		case GormBuilderWhereOperatorIsNull, GormBuilderWhereOperatorIsNotNull:
-			// IS NULL 和 IS NOT NULL 不需要值
-			b.db = b.db.Where(fmt.Sprintf("%s %s", where.Field, where.Operator))
-		default:
-			// 其他操作符使用单个值
-			b.db = b.db.Where(fmt.Sprintf("%s %s ?", where.Field, where.Operator), where.Value)
-		}
+	// 构建条件（包含 OR 条件时整体加括号，避免与数据权限等后续条件的优先级混淆）
+	if hasOrWhere(b.wheres) {
+		db = db.Where(b.applyWheres(b.db.Session(&gorm.Session{NewDB: true}), b.wheres))
+	} else {
+		db = b.applyWheres(db, b.wheres)
 	}
 
+	// 软删除记录
+	db = b.applyTrashed(db, model)
+
+	// 数据权限范围
+	db = b.applyDataScope(db, model)
+
+	// 租户隔离
+	db = b.applyTenant(db, model)
+
 	// 构建分组
 	if len(b.groups) > 0 {
-		b.db = b.db.Group(strings.Join(b.groups, ","))
+		db = db.Group(strings.Join(b.groups, ","))
+	}
+
+	return db
+}
+
+// begin 开始一次操作（设置了超时时返回使用超时上下文的构建器副本，操作结束后调用 cancel 释放）
+func (b *GormBuilder) begin() (*GormBuilder, context.CancelFunc) {
+	if b.timeout <= 0 {
+		return b, func() {}
+	}
+	ctx := b.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	op := *b
+	var cancel context.CancelFunc
+	op.ctx, cancel = context.WithTimeout(ctx, b.timeout)
+	return &op, cancel
+}
+
+// session 基于构建器的数据库实例创建新会话（后续链式调用不会修改 b.db，构建器可重复执行查询）
+func (b *GormBuilder) session() *gorm.DB {
+	if b.ctx == nil {
+		return b.db.Session(&gorm.Session{})
+	}
+	return b.db.Session(&gorm.Session{Context: b.ctx})
+}
+
+// applyWheres 按顺序添加条件（分组条件作为带括号的子条件）
+func (b *GormBuilder) applyWheres(db *gorm.DB, wheres []*GormBuilderWhere) *gorm.DB {
+	for _, where := range wheres {
+		var query interface{}
+		var args []interface{}
+		if len(where.Group) > 0 {
+			query = b.applyWheres(b.db.Session(&gorm.Session{NewDB: true}), where.Group)
+		} else if query, args = where.expression(); query == nil {
+			continue
+		}
+		if where.Or {
+			db = db.Or(query, args...)
+		} else {
+			db = db.Where(query, args...)
+		}
+	}
+	return db
+}
+
+// expression 条件语句与参数（条件无效时返回 nil）
+func (w *GormBuilderWhere) expression() (interface{}, []interface{}) {
+	switch w.Operator {
+	case GormBuilderWhereOperatorBetween:
+		// 范围类型需要两个值 安全的类型断言
+		if values, ok := w.Value.([]interface{}); ok && len(values) == 2 {
+			return fmt.Sprintf("%s BETWEEN ? AND ?", w.Field), values
+		}
+		return nil, nil
+	case GormBuilderWhereOperatorIsNull, GormBuilderWhereOperatorIsNotNull:
+		// IS NULL 和 IS NOT NULL 不需要值
+		return fmt.Sprintf("%s %s", w.Field, w.Operator), nil
+	default:
+		// 其他操作符使用单个值
+		return fmt.Sprintf("%s %s ?", w.Field, w.Operator), []interface{}{w.Value}
+	}
+}
+
+// hasOrWhere 顶层条件中是否包含 OR 条件
+func hasOrWhere(wheres []*GormBuilderWhere) bool {
+	for _, where := range wheres {
+		if where.Or {
+			return true
+		}
+	}
+	return false
+}
+
+// applyTrashed 按软删除记录查询方式过滤（模型不支持软删除时仅查询已删除记录不返回任何数据）
+func (b *GormBuilder) applyTrashed(db *gorm.DB, model interface{}) *gorm.DB {
+	switch b.trashed {
+	case trashedModeWith:
+		return db.Unscoped()
+	case trashedModeOnly:
+		column, ok := softDeleteColumn(db, model)
+		if !ok {
+			return db.Where("1 = 0")
+		}
+		return db.Unscoped().Where(clause.Expr{SQL: "? IS NOT NULL", Vars: []interface{}{column}})
+	}
+	return db
+}
+
+// softDeleteColumn 模型的软删除字段（带表名）
+func softDeleteColumn(db *gorm.DB, model interface{}) (clause.Column, bool) {
+	if model == nil {
+		model = db.Statement.Model
+	}
+	if model == nil {
+		return clause.Column{}, false
+	}
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return clause.Column{}, false
+	}
+	for _, field := range stmt.Schema.Fields {
+		if field.DBName != "" && field.FieldType == reflect.TypeOf(gorm.DeletedAt{}) {
+			return clause.Column{Table: stmt.Schema.Table, Name: field.DBName}, true
+		}
+	}
+	return clause.Column{}, false
+}
+
+// applyDataScope 按数据权限范围过滤（模型不含数据权限字段时不限制）
+func (b *GormBuilder) applyDataScope(db *gorm.DB, model interface{}) *gorm.DB {
+	if b.dataScope == nil || b.dataScope.All {
+		return db
+	}
+	if model == nil {
+		model = db.Statement.Model
+	}
+	if model == nil {
+		return db
+	}
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return db
 	}
+	column := DataScopeColumn(stmt.Schema.ModelType)
+	if stmt.Schema.LookUpField(column) == nil {
+		return db
+	}
+
+	values := make([]interface{}, 0, len(b.dataScope.AdminIDs))
+	for _, id := range b.dataScope.AdminIDs {
+		values = append(values, id)
+	}
+	return db.Where(clause.IN{Column: clause.Column{Table: stmt.Schema.Table, Name: column}, Values: values})
+}
+
+// tenantField 模型的租户字段（未设置租户或模型不含租户字段时返回 nil）
+func (b *GormBuilder) tenantField(db *gorm.DB, model interface{}) (*gorm.Statement, *schema.Field) {
+	if b.tenantID == "" {
+		return nil, nil
+	}
+	if model == nil {
+		model = db.Statement.Model
+	}
+	if model == nil {
+		return nil, nil
+	}
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return nil, nil
+	}
+	return stmt, stmt.Schema.LookUpField(tenant.Column(stmt.Schema.ModelType))
+}
 
-	return b.db
+// applyTenant 按租户过滤（模型不含租户字段时不限制）
+func (b *GormBuilder) applyTenant(db *gorm.DB, model interface{}) *gorm.DB {
+	stmt, field := b.tenantField(db, model)
+	if field == nil {
+		return db
+	}
+	return db.Where(clause.Eq{Column: clause.Column{Table: stmt.Schema.Table, Name: field.DBName}, Value: b.tenantID})
+}
+
+// assignTenant 为待写入的数据（结构体或切片）设置当前租户，返回是否按租户隔离
+func (b *GormBuilder) assignTenant(db *gorm.DB, data interface{}) (bool, error) {
+	_, field := b.tenantField(db, data)
+	if field == nil {
+		return false, nil
+	}
+	ctx := b.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	value := reflect.Indirect(reflect.ValueOf(data))
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := field.Set(ctx, reflect.Indirect(value.Index(i)), b.tenantID); err != nil {
+				return false, fmt.Errorf("设置租户失败: %w", err)
+			}
+		}
+	case reflect.Struct:
+		if err := field.Set(ctx, value, b.tenantID); err != nil {
+			return false, fmt.Errorf("设置租户失败: %w", err)
+		}
+	}
+	return true, nil
 }
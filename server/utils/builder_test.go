@@ -0,0 +1,545 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/so68/core/config"
+	coredb "github.com/so68/core/database"
+	"github.com/so68/core/tenant"
+	"gorm.io/gorm"
+)
+
+/*
+GORM 构建器测试
+
+本文件用于测试构建器重复执行查询与复制。
+
+运行命令：
+go test -v -run "^TestGormBuilder.*$"
+
+测试内容：
+1. 先统计总数再查询列表时条件不重复叠加 (TotalCount, Find)
+2. 复制构建器后互不影响 (Clone)
+3. OR 条件与分组条件 (WhereOr, WhereGroup, WhereOrGroup)
+4. 游标分页 (Page.Cursor, PageResp.NextCursor)
+5. 软删除记录查询与恢复 (WithTrashed, OnlyTrashed, Restore)
+6. 批量创建、更新与删除 (CreateInBatches, BatchUpdate, BatchDelete)
+7. 上下文取消与操作超时 (Timeout)
+8. 租户隔离 (tenant.WithID, WithoutTenant)
+9. 数据权限范围外的记录不能更新，且更新不会回退为插入覆盖 (WithDataScope, Update)
+*/
+
+// builderTestItem 测试模型
+type builderTestItem struct {
+	coredb.BaseModel
+	Name   string `gorm:"type:varchar(50)"`
+	Status int8
+}
+
+// newBuilderTestDB 创建测试数据库并写入测试数据
+func newBuilderTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	database, err := coredb.NewSQLiteDatabase(&config.DatabaseConfig{Driver: "sqlite", Database: filepath.Join(t.TempDir(), "builder.db"), LogLevel: "silent"}, logger)
+	if err != nil {
+		t.Fatalf("create database failed: %v", err)
+	}
+	t.Cleanup(func() { _ = database.Close(context.Background()) })
+	if err := database.DB().AutoMigrate(&builderTestItem{}); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	for i, name := range []string{"a", "b", "c", "d", "e"} {
+		if err := database.DB().Create(&builderTestItem{Name: name, Status: int8(i%2 + 1)}).Error; err != nil {
+			t.Fatalf("create item failed: %v", err)
+		}
+	}
+	return database.DB()
+}
+
+func TestGormBuilder_TotalCountThenFind(t *testing.T) {
+	db := newBuilderTestDB(t)
+	ctx := context.Background()
+
+	builder := NewGormBuilderWithPage(ctx, db.Model(&builderTestItem{}), &Page{Page: 1, Size: 2, Sort: "id", Order: "ASC"}).WhereEqual("status", 1)
+	total, err := builder.TotalCount(nil)
+	if err != nil {
+		t.Fatalf("TotalCount failed: %v", err)
+	}
+	if total != 3 {
+		t.Fatalf("TotalCount = %d, want 3", total)
+	}
+
+	var items []*builderTestItem
+	if err := builder.Find(&items); err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if len(items) != 2 || items[0].Name != "a" || items[1].Name != "c" {
+		t.Fatalf("unexpected items %+v", items)
+	}
+
+	// 再次执行结果一致（条件未叠加到共享的数据库实例）
+	if total, err := builder.TotalCount(&builderTestItem{}); err != nil || total != 3 {
+		t.Fatalf("second TotalCount = %d, %v", total, err)
+	}
+	if stmt := db.Statement; len(stmt.Clauses) != 0 {
+		t.Fatalf("shared db statement mutated: %v", stmt.Clauses)
+	}
+}
+
+func TestGormBuilder_Clone(t *testing.T) {
+	db := newBuilderTestDB(t)
+	ctx := context.Background()
+
+	base := NewGormBuilderWithPage(ctx, db, &Page{Page: 1, Size: 10, Sort: "id", Order: "DESC"}).WhereEqual("status", 2)
+	clone := base.Clone().WhereEqual("name", "b")
+	clone.Page.Size = 1
+
+	var all, one []*builderTestItem
+	if err := base.Find(&all); err != nil {
+		t.Fatalf("base Find failed: %v", err)
+	}
+	if err := clone.Find(&one); err != nil {
+		t.Fatalf("clone Find failed: %v", err)
+	}
+	if len(all) != 2 || base.Page.Size != 10 {
+		t.Fatalf("base builder affected by clone: %d items, size %d", len(all), base.Page.Size)
+	}
+	if len(one) != 1 || one[0].Name != "b" {
+		t.Fatalf("unexpected clone items %+v", one)
+	}
+}
+
+func TestGormBuilder_WhereOrAndGroup(t *testing.T) {
+	db := newBuilderTestDB(t)
+	ctx := context.Background()
+
+	// 测试数据: a(1) b(2) c(1) d(2) e(1)
+	tests := []struct {
+		name  string
+		db    *gorm.DB
+		build func(b *GormBuilder)
+		want  string
+	}{
+		{
+			name: "OR 条件",
+			db:   db,
+			build: func(b *GormBuilder) {
+				b.WhereEqual("name", "a").WhereOr(GormBuilderWhereOperatorEqual, "name", "d")
+			},
+			want: "a,d",
+		},
+		{
+			name: "分组条件 (a OR b) AND status",
+			db:   db,
+			build: func(b *GormBuilder) {
+				b.WhereGroup(func(g *GormBuilder) {
+					g.WhereEqual("name", "a").WhereOr(GormBuilderWhereOperatorEqual, "name", "b")
+				}).WhereEqual("status", 2)
+			},
+			want: "b",
+		},
+		{
+			name: "OR 分组条件",
+			db:   db,
+			build: func(b *GormBuilder) {
+				b.WhereEqual("name", "a").WhereOrGroup(func(g *GormBuilder) {
+					g.WhereEqual("status", 2).WhereGreaterThan("name", "c")
+				})
+			},
+			want: "a,d",
+		},
+		{
+			name: "OR 条件不影响已有条件",
+			db:   db.Where("status = ?", 2),
+			build: func(b *GormBuilder) {
+				b.WhereEqual("name", "b").WhereOr(GormBuilderWhereOperatorEqual, "name", "c")
+			},
+			want: "b",
+		},
+		{
+			name: "空分组忽略",
+			db:   db,
+			build: func(b *GormBuilder) {
+				b.WhereGroup(func(g *GormBuilder) {}).WhereEqual("name", "e")
+			},
+			want: "e",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewGormBuilderWithPage(ctx, tt.db, &Page{Sort: "id", Order: "ASC"})
+			tt.build(builder)
+			var items []*builderTestItem
+			if err := builder.Find(&items); err != nil {
+				t.Fatalf("Find failed: %v", err)
+			}
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			if got := strings.Join(names, ","); got != tt.want {
+				t.Errorf("Find = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGormBuilder_CursorPagination(t *testing.T) {
+	db := newBuilderTestDB(t)
+	ctx := context.Background()
+
+	// 测试数据: a(1) b(2) c(1) d(2) e(1)，按 status 倒序，相同 status 按主键倒序
+	tests := []struct {
+		name  string
+		sort  string
+		order string
+		want  []string
+	}{
+		{name: "按主键倒序", sort: "id", order: "DESC", want: []string{"e,d", "c,b", "a"}},
+		{name: "按非唯一字段倒序", sort: "status", order: "DESC", want: []string{"d,b", "e,c", "a"}},
+		{name: "按非唯一字段正序", sort: "status", order: "ASC", want: []string{"a,c", "e,b", "d"}},
+		{name: "按时间倒序", sort: "created_at", order: "DESC", want: []string{"e,d", "c,b", "a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := &Page{Page: 1, Size: 2, Sort: tt.sort, Order: tt.order}
+			got := make([]string, 0)
+			for range 5 {
+				var items []*builderTestItem
+				if err := NewGormBuilderWithPage(ctx, db, page).Find(&items); err != nil {
+					t.Fatalf("Find failed: %v", err)
+				}
+				names := make([]string, 0, len(items))
+				for _, item := range items {
+					names = append(names, item.Name)
+				}
+				got = append(got, strings.Join(names, ","))
+
+				resp := NewPageResp(5, page, items)
+				if resp.NextCursor == "" {
+					break
+				}
+				page = &Page{Size: 2, Sort: tt.sort, Order: tt.order, Cursor: resp.NextCursor}
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("pages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("游标无效", func(t *testing.T) {
+		var items []*builderTestItem
+		for _, page := range []*Page{
+			{Size: 2, Sort: "id", Order: "DESC", Cursor: "not-a-cursor"},
+			{Size: 2, Sort: "name", Order: "DESC", Cursor: (&pageCursor{Sort: "id", Order: "DESC", Value: []byte("1"), ID: []byte("1")}).mustEncode(t)},
+			{Size: 2, Sort: "unknown", Order: "DESC", Cursor: (&pageCursor{Sort: "unknown", Order: "DESC", Value: []byte("1"), ID: []byte("1")}).mustEncode(t)},
+		} {
+			if err := NewGormBuilderWithPage(ctx, db, page).Find(&items); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("Find(%+v) error = %v, want ErrInvalidCursor", page, err)
+			}
+		}
+	})
+}
+
+// mustEncode 编码游标
+func (c *pageCursor) mustEncode(t *testing.T) string {
+	t.Helper()
+	encoded, err := c.encode()
+	if err != nil {
+		t.Fatalf("encode cursor failed: %v", err)
+	}
+	return encoded
+}
+
+func TestGormBuilder_Trashed(t *testing.T) {
+	db := newBuilderTestDB(t)
+	ctx := context.Background()
+	if err := db.Where("name IN ?", []string{"b", "d"}).Delete(&builderTestItem{}).Error; err != nil {
+		t.Fatalf("delete items failed: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		apply func(b *GormBuilder) *GormBuilder
+		want  int64
+	}{
+		{name: "默认不含已删除记录", apply: func(b *GormBuilder) *GormBuilder { return b }, want: 3},
+		{name: "包含已删除记录", apply: (*GormBuilder).WithTrashed, want: 5},
+		{name: "仅已删除记录", apply: (*GormBuilder).OnlyTrashed, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			total, err := tt.apply(NewGormBuilder(ctx, db)).TotalCount(&builderTestItem{})
+			if err != nil {
+				t.Fatalf("TotalCount failed: %v", err)
+			}
+			if total != tt.want {
+				t.Fatalf("TotalCount = %d, want %d", total, tt.want)
+			}
+		})
+	}
+
+	// 恢复已删除记录，未删除或不存在的记录返回 gorm.ErrRecordNotFound
+	if err := NewGormBuilderFind(ctx, db, "name", "b").Restore(&builderTestItem{}); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if err := NewGormBuilderFind(ctx, db, "name", "a").Restore(&builderTestItem{}); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("Restore not deleted item err = %v, want ErrRecordNotFound", err)
+	}
+	var items []*builderTestItem
+	if err := NewGormBuilder(ctx, db).OnlyTrashed().Find(&items); err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if len(items) != 1 || items[0].Name != "d" {
+		t.Fatalf("unexpected trashed items %+v", items)
+	}
+}
+
+func TestGormBuilder_Batch(t *testing.T) {
+	db := newBuilderTestDB(t)
+	ctx := context.Background()
+
+	items := []*builderTestItem{{Name: "f", Status: 1}, {Name: "g", Status: 1}, {Name: "h", Status: 2}}
+	if err := NewGormBuilder(ctx, db).CreateInBatches(items, 2); err != nil {
+		t.Fatalf("CreateInBatches failed: %v", err)
+	}
+	if items[2].ID == 0 {
+		t.Fatal("CreateInBatches should set primary keys")
+	}
+
+	affected, err := NewGormBuilderFind(ctx, db, "status", 1).BatchUpdate(&builderTestItem{}, map[string]interface{}{"status": 3})
+	if err != nil || affected != 5 {
+		t.Fatalf("BatchUpdate = %d, %v, want 5", affected, err)
+	}
+	if _, err := NewGormBuilder(ctx, db).BatchUpdate(&builderTestItem{}, map[string]interface{}{"status": 1}); !errors.Is(err, gorm.ErrMissingWhereClause) {
+		t.Fatalf("BatchUpdate without conditions err = %v, want ErrMissingWhereClause", err)
+	}
+
+	tests := []struct {
+		name        string
+		maxAffected int64
+		wantErr     error
+		wantLeft    int64
+	}{
+		{name: "超过上限时回滚", maxAffected: 4, wantErr: ErrTooManyAffected, wantLeft: 8},
+		{name: "未超过上限时删除", maxAffected: 5, wantLeft: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGormBuilderFind(ctx, db, "status", 3).BatchDelete(true, &builderTestItem{}, tt.maxAffected)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("BatchDelete err = %v, want %v", err, tt.wantErr)
+			}
+			left, err := NewGormBuilder(ctx, db).TotalCount(&builderTestItem{})
+			if err != nil || left != tt.wantLeft {
+				t.Fatalf("left = %d, %v, want %d", left, err, tt.wantLeft)
+			}
+		})
+	}
+}
+
+func TestGormBuilder_ContextCanceled(t *testing.T) {
+	db := newBuilderTestDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	page := &Page{Page: 1, Size: 2}
+	ops := map[string]func(b *GormBuilder) error{
+		"TotalCount":      func(b *GormBuilder) error { _, err := b.TotalCount(&builderTestItem{}); return err },
+		"Find":            func(b *GormBuilder) error { return b.Find(&[]builderTestItem{}) },
+		"FindPage":        func(b *GormBuilder) error { return b.WithPage(page).Find(&[]builderTestItem{}) },
+		"First":           func(b *GormBuilder) error { return b.First(&builderTestItem{}) },
+		"Create":          func(b *GormBuilder) error { return b.Create(&builderTestItem{Name: "x"}) },
+		"CreateInBatches": func(b *GormBuilder) error { return b.CreateInBatches(&[]builderTestItem{{Name: "x"}}, 10) },
+		"Update": func(b *GormBuilder) error {
+			return b.Update(&builderTestItem{BaseModel: coredb.BaseModel{ID: 1}, Name: "x"})
+		},
+		"BatchUpdate": func(b *GormBuilder) error {
+			_, err := b.WhereEqual("status", 1).BatchUpdate(&builderTestItem{}, map[string]interface{}{"name": "x"})
+			return err
+		},
+		"Delete": func(b *GormBuilder) error { return b.WhereEqual("id", 1).Delete(true, &builderTestItem{}) },
+		"BatchDelete": func(b *GormBuilder) error {
+			_, err := b.WhereEqual("status", 1).BatchDelete(true, &builderTestItem{}, 0)
+			return err
+		},
+		"Restore": func(b *GormBuilder) error { return b.WhereEqual("id", 1).Restore(&builderTestItem{}) },
+	}
+	for name, op := range ops {
+		t.Run(name, func(t *testing.T) {
+			if err := op(NewGormBuilder(ctx, db)); !errors.Is(err, context.Canceled) {
+				t.Errorf("Expected context.Canceled, got %v", err)
+			}
+		})
+	}
+}
+
+func TestGormBuilder_Timeout(t *testing.T) {
+	db := newBuilderTestDB(t)
+
+	// 记录执行查询时的上下文截止时间
+	var deadlines []bool
+	if err := db.Callback().Query().Before("gorm:query").Register("test:deadline", func(tx *gorm.DB) {
+		_, ok := tx.Statement.Context.Deadline()
+		deadlines = append(deadlines, ok)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := NewGormBuilder(context.Background(), db).Timeout(time.Minute)
+	var items []builderTestItem
+	if err := builder.Find(&items); err != nil || len(items) != 5 {
+		t.Fatalf("Find failed: %d, %v", len(items), err)
+	}
+	if err := NewGormBuilder(context.Background(), db).First(&builderTestItem{}); err != nil {
+		t.Fatalf("First failed: %v", err)
+	}
+	if len(deadlines) != 2 || !deadlines[0] || deadlines[1] {
+		t.Errorf("Expected deadline only with Timeout, got %v", deadlines)
+	}
+
+	// 超时后中止查询，构建器可继续使用
+	if err := builder.Timeout(time.Nanosecond).Find(&items); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if _, err := builder.Timeout(0).TotalCount(&builderTestItem{}); err != nil {
+		t.Errorf("Expected builder to be reusable after timeout, got %v", err)
+	}
+}
+
+// builderTenantItem 租户隔离测试模型
+type builderTenantItem struct {
+	coredb.BaseModel
+	TenantID string `gorm:"type:varchar(64);index"`
+	Name     string `gorm:"type:varchar(50)"`
+}
+
+func TestGormBuilder_Tenant(t *testing.T) {
+	db := newBuilderTestDB(t)
+	if err := db.AutoMigrate(&builderTenantItem{}); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	ctxA := tenant.WithID(context.Background(), "a")
+	ctxB := tenant.WithID(context.Background(), "b")
+
+	// 创建时设置当前租户（忽略传入的租户）
+	itemA := &builderTenantItem{Name: "a1", TenantID: "b"}
+	if err := NewGormBuilder(ctxA, db).Create(itemA); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if itemA.TenantID != "a" {
+		t.Errorf("TenantID = %q, want a", itemA.TenantID)
+	}
+	batch := []*builderTenantItem{{Name: "b1"}, {Name: "b2"}}
+	if err := NewGormBuilder(ctxB, db).CreateInBatches(batch, 10); err != nil {
+		t.Fatalf("CreateInBatches failed: %v", err)
+	}
+	if batch[0].TenantID != "b" || batch[1].TenantID != "b" {
+		t.Errorf("Expected batch tenant b, got %q, %q", batch[0].TenantID, batch[1].TenantID)
+	}
+
+	// 查询仅返回当前租户的数据
+	var items []*builderTenantItem
+	if err := NewGormBuilder(ctxA, db).Find(&items); err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if len(items) != 1 || items[0].Name != "a1" {
+		t.Errorf("Expected only tenant a items, got %d", len(items))
+	}
+	total, err := NewGormBuilder(ctxB, db.Model(&builderTenantItem{})).TotalCount(nil)
+	if err != nil || total != 2 {
+		t.Errorf("TotalCount = %d, %v, want 2", total, err)
+	}
+	if err := NewGormBuilderFind(ctxB, db, "id", itemA.ID).First(&builderTenantItem{}); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected ErrRecordNotFound for other tenant, got %v", err)
+	}
+
+	// 更新与删除其他租户的记录不生效，且更新不会回退为插入覆盖
+	foreign := &builderTenantItem{BaseModel: coredb.BaseModel{ID: itemA.ID}, Name: "hijacked"}
+	if err := NewGormBuilder(ctxB, db).Update(foreign); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("Update of other tenant error = %v, want ErrRecordNotFound", err)
+	}
+	if err := NewGormBuilder(ctxB, db).Delete(true, &builderTenantItem{BaseModel: coredb.BaseModel{ID: itemA.ID}}); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	var stored builderTenantItem
+	if err := NewGormBuilderFind(ctxA, db, "id", itemA.ID).First(&stored); err != nil {
+		t.Fatalf("First failed: %v", err)
+	}
+	if stored.Name != "a1" || stored.TenantID != "a" {
+		t.Errorf("Expected tenant a item untouched, got %+v", stored)
+	}
+
+	// 更新当前租户的记录
+	stored.Name = "a1-updated"
+	if err := NewGormBuilder(ctxA, db).Update(&stored); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if err := NewGormBuilderFind(ctxA, db, "id", itemA.ID).First(&stored); err != nil || stored.Name != "a1-updated" {
+		t.Errorf("Expected updated name, got %q, %v", stored.Name, err)
+	}
+
+	// 忽略租户隔离与不含租户字段的模型
+	total, err = NewGormBuilder(ctxA, db.Model(&builderTenantItem{})).WithoutTenant().TotalCount(nil)
+	if err != nil || total != 3 {
+		t.Errorf("TotalCount without tenant = %d, %v, want 3", total, err)
+	}
+	total, err = NewGormBuilder(ctxA, db.Model(&builderTestItem{})).TotalCount(nil)
+	if err != nil || total != 5 {
+		t.Errorf("TotalCount for model without tenant column = %d, %v, want 5", total, err)
+	}
+}
+
+// builderScopeItem 数据权限测试模型
+type builderScopeItem struct {
+	coredb.BaseModel
+	AdminID uint
+	Name    string `gorm:"type:varchar(50)"`
+}
+
+func TestGormBuilder_DataScopeUpdate(t *testing.T) {
+	db := newBuilderTestDB(t)
+	if err := db.AutoMigrate(&builderScopeItem{}); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	own := &builderScopeItem{AdminID: 1, Name: "own"}
+	other := &builderScopeItem{AdminID: 2, Name: "other"}
+	for _, item := range []*builderScopeItem{own, other} {
+		if err := db.Create(item).Error; err != nil {
+			t.Fatalf("create item failed: %v", err)
+		}
+	}
+	ctx := WithDataScope(context.Background(), &DataScope{AdminIDs: []uint{1}})
+
+	// 范围外的记录：返回记录不存在，且不会以插入覆盖
+	foreign := &builderScopeItem{BaseModel: coredb.BaseModel{ID: other.ID}, AdminID: 1, Name: "overwritten"}
+	if err := NewGormBuilder(ctx, db).Update(foreign); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("Update out of scope error = %v, want ErrRecordNotFound", err)
+	}
+	var stored builderScopeItem
+	if err := db.First(&stored, other.ID).Error; err != nil {
+		t.Fatalf("First failed: %v", err)
+	}
+	if stored.Name != "other" || stored.AdminID != 2 {
+		t.Errorf("Expected out of scope item untouched, got %+v", stored)
+	}
+
+	// 范围内的记录正常更新
+	own.Name = "own-updated"
+	if err := NewGormBuilder(ctx, db).Update(own); err != nil {
+		t.Fatalf("Update in scope failed: %v", err)
+	}
+	var updated builderScopeItem
+	if err := db.First(&updated, own.ID).Error; err != nil || updated.Name != "own-updated" {
+		t.Errorf("Expected updated name, got %q, %v", updated.Name, err)
+	}
+}
@@ -1,12 +1,84 @@
 package utils
 
-import "github.com/gin-gonic/gin"
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/i18n"
+	"github.com/so68/core/logging"
+	"github.com/so68/core/tenant"
+)
 
 const (
-	ContextUserIDKey = "user_id" // 用户ID
+	ContextUserIDKey      = "user_id"      // 用户ID
+	ContextSessionIDKey   = "session_id"   // 会话ID
+	ContextTokenScopesKey = "token_scopes" // 机器令牌授权范围
+	ContextAppIDKey       = "app_id"       // 开放接口接入方 AppID
+	ContextLocaleKey      = "locale"       // 请求语言
+	ContextRequestIDKey   = "request_id"   // 请求ID
+	ContextTenancyKey     = "tenancy"      // 已启用多租户（租户解析中间件写入）
 )
 
 // GetContextUserID 获取用户ID
 func GetContextUserID(c *gin.Context) uint {
 	return c.GetUint(ContextUserIDKey)
 }
+
+// SetContextUserID 设置用户ID，并为请求日志器追加管理员ID
+func SetContextUserID(c *gin.Context, userID uint) {
+	c.Set(ContextUserIDKey, userID)
+	c.Request = c.Request.WithContext(logging.With(c.Request.Context(), logging.KeyAdminID, userID))
+}
+
+// GetContextSessionID 获取当前登录会话ID（机器令牌请求为空）
+func GetContextSessionID(c *gin.Context) string {
+	return c.GetString(ContextSessionIDKey)
+}
+
+// SetContextSessionID 设置当前登录会话ID
+func SetContextSessionID(c *gin.Context, sessionID string) {
+	c.Set(ContextSessionIDKey, sessionID)
+}
+
+// GetContextRequestID 获取请求ID
+func GetContextRequestID(c *gin.Context) string {
+	return c.GetString(ContextRequestIDKey)
+}
+
+// GetContextTokenScopes 获取机器令牌授权范围，非机器令牌请求返回 false
+func GetContextTokenScopes(c *gin.Context) ([]string, bool) {
+	value, exists := c.Get(ContextTokenScopesKey)
+	if !exists {
+		return nil, false
+	}
+	scopes, ok := value.([]string)
+	return scopes, ok
+}
+
+// GetContextAppID 获取签名请求的接入方 AppID（未经签名校验的请求为空）
+func GetContextAppID(c *gin.Context) string {
+	return c.GetString(ContextAppIDKey)
+}
+
+// IsContextTenancyEnabled 是否已启用多租户（启用时认证凭据必须属于租户）
+func IsContextTenancyEnabled(c *gin.Context) bool {
+	return c.GetBool(ContextTenancyKey)
+}
+
+// GetContextTenantID 获取请求的租户ID（未启用多租户或未解析到租户时为空）
+func GetContextTenantID(c *gin.Context) string {
+	id, _ := tenant.FromContext(c.Request.Context())
+	return id
+}
+
+// SetContextTenantID 设置请求的租户ID（写入请求上下文供 GormBuilder 与缓存使用），并为请求日志器追加租户ID
+func SetContextTenantID(c *gin.Context, tenantID string) {
+	ctx := tenant.WithID(c.Request.Context(), tenantID)
+	c.Request = c.Request.WithContext(logging.With(ctx, logging.KeyTenantID, tenantID))
+}
+
+// GetContextLocale 获取请求语言，未设置时返回默认语言
+func GetContextLocale(c *gin.Context) string {
+	if locale := c.GetString(ContextLocaleKey); locale != "" {
+		return locale
+	}
+	return i18n.Default().DefaultLocale()
+}
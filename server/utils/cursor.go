@@ -0,0 +1,146 @@
+package utils
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// ErrInvalidCursor 分页游标无效（格式错误或与当前排序不一致）
+var ErrInvalidCursor = errors.New("分页游标无效")
+
+// pageCursor 游标内容（上一页最后一条记录的排序字段值与主键，对客户端不透明）
+type pageCursor struct {
+	Sort  string          `json:"s"` // 排序字段
+	Order string          `json:"o"` // 排序方向
+	Value json.RawMessage `json:"v"` // 排序字段值
+	ID    json.RawMessage `json:"i"` // 主键值
+}
+
+// encode 编码游标
+func (c *pageCursor) encode() (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodePageCursor 解码游标
+func decodePageCursor(value string) (*pageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	cursor := &pageCursor{}
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+// cursorFields 游标分页使用的排序字段与主键字段
+type cursorFields struct {
+	sort    *schema.Field // 排序字段
+	primary *schema.Field // 主键字段（排序字段值相同时按主键区分先后）
+	desc    bool          // 是否倒序
+}
+
+// lookupCursorFields 根据分页参数查找排序字段与主键字段（排序字段须为模型字段）
+func (b *GormBuilder) lookupCursorFields(db *gorm.DB, data interface{}) (*cursorFields, error) {
+	if !sortPattern.MatchString(b.Page.Sort) {
+		return nil, fmt.Errorf("%w: 排序字段不合法", ErrInvalidCursor)
+	}
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(data); err != nil {
+		return nil, err
+	}
+	column := b.Page.Sort
+	if i := strings.LastIndex(column, "."); i >= 0 {
+		column = column[i+1:]
+	}
+	fields := &cursorFields{
+		sort:    stmt.Schema.LookUpField(column),
+		primary: stmt.Schema.PrioritizedPrimaryField,
+		desc:    strings.EqualFold(b.Page.Order, "DESC"),
+	}
+	if fields.sort == nil || fields.primary == nil {
+		return nil, fmt.Errorf("%w: 不支持按 %s 游标分页", ErrInvalidCursor, b.Page.Sort)
+	}
+	return fields, nil
+}
+
+// qualifiedPrimary 主键列名（排序字段带表名时主键同样带表名，避免连接查询时字段歧义）
+func (f *cursorFields) qualifiedPrimary(sort string) string {
+	if i := strings.LastIndex(sort, "."); i >= 0 {
+		return sort[:i] + "." + f.primary.DBName
+	}
+	return f.primary.DBName
+}
+
+// applyCursor 按游标添加条件与排序：(sort < v) OR (sort = v AND id < last_id)，正序时为 >
+func (b *GormBuilder) applyCursor(db *gorm.DB, fields *cursorFields) (*gorm.DB, error) {
+	cursor, err := decodePageCursor(b.Page.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	if cursor.Sort != b.Page.Sort || cursor.Order != strings.ToUpper(b.Page.Order) {
+		return nil, fmt.Errorf("%w: 排序方式已改变", ErrInvalidCursor)
+	}
+	value := reflect.New(fields.sort.FieldType)
+	id := reflect.New(fields.primary.FieldType)
+	if json.Unmarshal(cursor.Value, value.Interface()) != nil || json.Unmarshal(cursor.ID, id.Interface()) != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	operator := ">"
+	if fields.desc {
+		operator = "<"
+	}
+	primary := fields.qualifiedPrimary(b.Page.Sort)
+	if fields.sort == fields.primary {
+		db = db.Where(fmt.Sprintf("%s %s ?", b.Page.Sort, operator), id.Elem().Interface())
+	} else {
+		db = db.Where(fmt.Sprintf("(%s %s ? OR (%s = ? AND %s %s ?))", b.Page.Sort, operator, b.Page.Sort, primary, operator),
+			value.Elem().Interface(), value.Elem().Interface(), id.Elem().Interface())
+	}
+
+	db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: b.Page.Sort}, Desc: fields.desc})
+	if fields.sort != fields.primary {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: primary}, Desc: fields.desc})
+	}
+	return db.Limit(int(b.Page.GetLimit())), nil
+}
+
+// nextPageCursor 根据本页最后一条记录生成下一页游标（本页未满时没有下一页）
+func (b *GormBuilder) nextPageCursor(fields *cursorFields, data interface{}) string {
+	list := reflect.Indirect(reflect.ValueOf(data))
+	if list.Kind() != reflect.Slice || list.Len() == 0 || int64(list.Len()) < b.Page.GetLimit() {
+		return ""
+	}
+	last := reflect.Indirect(list.Index(list.Len() - 1))
+	value, _ := fields.sort.ValueOf(context.Background(), last)
+	id, _ := fields.primary.ValueOf(context.Background(), last)
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	idJSON, err := json.Marshal(id)
+	if err != nil {
+		return ""
+	}
+	cursor := &pageCursor{Sort: b.Page.Sort, Order: strings.ToUpper(b.Page.Order), Value: valueJSON, ID: idJSON}
+	encoded, err := cursor.encode()
+	if err != nil {
+		return ""
+	}
+	return encoded
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"context"
+	"reflect"
+)
+
+// DataScope 数据权限范围（行级）
+// - All 为 true 时不限制（超级管理员）
+// - 否则仅可访问 AdminIDs（自身及全部下级管理员）所属的数据
+type DataScope struct {
+	All      bool   // 不限制
+	AdminIDs []uint // 可访问的管理员ID
+}
+
+// DataScopeModel 自定义数据权限字段的模型（未实现时使用 admin_id 字段，模型不含该字段时不限制）
+type DataScopeModel interface {
+	// DataScopeColumn 数据归属的管理员ID字段
+	DataScopeColumn() string
+}
+
+// DefaultDataScopeColumn 默认数据权限字段
+const DefaultDataScopeColumn = "admin_id"
+
+type dataScopeKey struct{}
+
+// WithDataScope 将数据权限范围写入上下文
+func WithDataScope(ctx context.Context, scope *DataScope) context.Context {
+	return context.WithValue(ctx, dataScopeKey{}, scope)
+}
+
+// DataScopeFromContext 获取上下文中的数据权限范围
+func DataScopeFromContext(ctx context.Context) (*DataScope, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	scope, ok := ctx.Value(dataScopeKey{}).(*DataScope)
+	return scope, ok && scope != nil
+}
+
+// DataScopeColumn 获取模型的数据权限字段
+func DataScopeColumn(modelType reflect.Type) string {
+	if model, ok := reflect.New(modelType).Interface().(DataScopeModel); ok {
+		return model.DataScopeColumn()
+	}
+	return DefaultDataScopeColumn
+}
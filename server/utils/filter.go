@@ -0,0 +1,130 @@
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// filterSeparator 查询参数中字段与操作符的分隔符（如 status__eq=1）
+const filterSeparator = "__"
+
+// filterOperators 查询参数操作符与构建器条件操作符的对应关系
+var filterOperators = map[string]GormBuilderWhereOperator{
+	"eq":      GormBuilderWhereOperatorEqual,
+	"ne":      GormBuilderWhereOperatorNotEqual,
+	"gt":      GormBuilderWhereOperatorGreaterThan,
+	"gte":     GormBuilderWhereOperatorGreaterThanEqual,
+	"lt":      GormBuilderWhereOperatorLessThan,
+	"lte":     GormBuilderWhereOperatorLessThanEqual,
+	"like":    GormBuilderWhereOperatorLike,
+	"in":      GormBuilderWhereOperatorIn,
+	"nin":     GormBuilderWhereOperatorNotIn,
+	"between": GormBuilderWhereOperatorBetween,
+	"null":    GormBuilderWhereOperatorIsNull,
+}
+
+// Filter 查询参数过滤白名单（参数字段名 -> 数据库列名）
+//
+// 参数格式：字段__操作符=值，省略操作符时为 eq，例如：
+//   - status=1、status__ne=2
+//   - created_at__gte=2024-01-01&created_at__lt=2024-02-01
+//   - username__like=adm（两侧自动添加 %）
+//   - id__in=1,2,3、id__nin=4,5
+//   - amount__between=10,100
+//   - deleted_at__null=true（false 为 IS NOT NULL）
+//
+// 不在白名单中的参数（如分页参数）会被忽略。
+type Filter map[string]string
+
+// NewFilter 创建查询参数过滤白名单（数据库列名与参数字段名相同）
+func NewFilter(fields ...string) Filter {
+	filter := make(Filter, len(fields))
+	for _, field := range fields {
+		filter[field] = field
+	}
+	return filter
+}
+
+// Column 添加参数字段与数据库列名不同的过滤字段（如 admin_name -> admins.username）
+func (f Filter) Column(field string, column string) Filter {
+	f[field] = column
+	return f
+}
+
+// Bind 将请求查询参数绑定为构建器条件
+func (f Filter) Bind(c *gin.Context, builder *GormBuilder) error {
+	return f.BindValues(c.Request.URL.Query(), builder)
+}
+
+// BindValues 将查询参数绑定为构建器条件（白名单字段使用了不支持的操作符或值格式错误时返回错误）
+func (f Filter) BindValues(values url.Values, builder *GormBuilder) error {
+	for key, items := range values {
+		field, op, found := strings.Cut(key, filterSeparator)
+		if !found {
+			op = "eq"
+		}
+		column, ok := f[field]
+		if !ok {
+			continue
+		}
+		operator, ok := filterOperators[op]
+		if !ok {
+			return fmt.Errorf("不支持的过滤条件: %s", key)
+		}
+		for _, value := range items {
+			if value = strings.TrimSpace(value); value == "" {
+				continue
+			}
+			if err := f.where(builder, operator, column, value); err != nil {
+				return fmt.Errorf("过滤条件 %s 格式错误: %w", key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// where 按操作符添加条件
+func (f Filter) where(builder *GormBuilder, operator GormBuilderWhereOperator, column string, value string) error {
+	switch operator {
+	case GormBuilderWhereOperatorLike:
+		builder.WhereLike(column, "%"+value+"%")
+	case GormBuilderWhereOperatorIn, GormBuilderWhereOperatorNotIn:
+		values := splitFilterValues(value)
+		if len(values) == 0 {
+			return fmt.Errorf("需要至少一个值")
+		}
+		builder.Where(operator, column, values)
+	case GormBuilderWhereOperatorBetween:
+		values := splitFilterValues(value)
+		if len(values) != 2 {
+			return fmt.Errorf("需要两个以逗号分隔的值")
+		}
+		builder.WhereBetween(column, values[0], values[1])
+	case GormBuilderWhereOperatorIsNull:
+		switch value {
+		case "true", "1":
+			builder.WhereIsNull(column)
+		case "false", "0":
+			builder.WhereIsNotNull(column)
+		default:
+			return fmt.Errorf("需要 true 或 false")
+		}
+	default:
+		builder.Where(operator, column, value)
+	}
+	return nil
+}
+
+// splitFilterValues 拆分逗号分隔的值（忽略空值）
+func splitFilterValues(value string) []interface{} {
+	values := make([]interface{}, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
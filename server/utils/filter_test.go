@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+/*
+查询参数过滤测试
+
+本文件用于测试将查询参数按白名单绑定为构建器条件。
+
+运行命令：
+go test -v -run "^TestFilter.*$"
+
+测试内容：
+1. 操作符解析（eq, ne, gte, like, in, between, null 等）
+2. 白名单过滤与列名映射
+3. 不支持的操作符与格式错误
+*/
+
+func TestFilter_BindValues(t *testing.T) {
+	filter := NewFilter("status", "created_at", "username", "id", "amount", "deleted_at").Column("admin_name", "admins.username")
+
+	tests := []struct {
+		name        string
+		query       string
+		want        []*GormBuilderWhere
+		expectError bool
+	}{
+		{name: "省略操作符", query: "status=1", want: []*GormBuilderWhere{{Operator: GormBuilderWhereOperatorEqual, Field: "status", Value: "1"}}},
+		{name: "不等于", query: "status__ne=2", want: []*GormBuilderWhere{{Operator: GormBuilderWhereOperatorNotEqual, Field: "status", Value: "2"}}},
+		{name: "大于等于", query: "created_at__gte=2024-01-01", want: []*GormBuilderWhere{{Operator: GormBuilderWhereOperatorGreaterThanEqual, Field: "created_at", Value: "2024-01-01"}}},
+		{name: "模糊匹配", query: "username__like=adm", want: []*GormBuilderWhere{{Operator: GormBuilderWhereOperatorLike, Field: "username", Value: "%adm%"}}},
+		{name: "列表", query: "id__in=1,2,,3", want: []*GormBuilderWhere{{Operator: GormBuilderWhereOperatorIn, Field: "id", Value: []interface{}{"1", "2", "3"}}}},
+		{name: "范围", query: "amount__between=10,100", want: []*GormBuilderWhere{{Operator: GormBuilderWhereOperatorBetween, Field: "amount", Value: []interface{}{"10", "100"}}}},
+		{name: "为空", query: "deleted_at__null=true", want: []*GormBuilderWhere{{Operator: GormBuilderWhereOperatorIsNull, Field: "deleted_at"}}},
+		{name: "不为空", query: "deleted_at__null=false", want: []*GormBuilderWhere{{Operator: GormBuilderWhereOperatorIsNotNull, Field: "deleted_at"}}},
+		{name: "列名映射", query: "admin_name=root", want: []*GormBuilderWhere{{Operator: GormBuilderWhereOperatorEqual, Field: "admins.username", Value: "root"}}},
+		{name: "忽略白名单外与空值参数", query: "page=1&size=10&password=x&status=", want: []*GormBuilderWhere{}},
+		{name: "不支持的操作符", query: "status__regex=1", expectError: true},
+		{name: "范围缺少值", query: "amount__between=10", expectError: true},
+		{name: "列表缺少值", query: "id__in=,", expectError: true},
+		{name: "为空值无效", query: "deleted_at__null=yes", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("parse query failed: %v", err)
+			}
+			builder := NewGormBuilder(context.Background(), nil)
+			err = filter.BindValues(values, builder)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("expected error, got wheres %v", builder.wheres)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BindValues failed: %v", err)
+			}
+			if !reflect.DeepEqual(builder.wheres, tt.want) {
+				t.Errorf("wheres = %+v, want %+v", builder.wheres, tt.want)
+			}
+		})
+	}
+}
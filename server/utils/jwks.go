@@ -0,0 +1,231 @@
+package utils
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JSONWebKey JWK 公钥（RFC 7517，仅支持 RSA 与 EC）
+type JSONWebKey struct {
+	Kty string `json:"kty"`           // 密钥类型: RSA, EC
+	Kid string `json:"kid"`           // 密钥ID
+	Use string `json:"use,omitempty"` // 用途: sig
+	Alg string `json:"alg,omitempty"` // 签名算法
+	N   string `json:"n,omitempty"`   // RSA 模数
+	E   string `json:"e,omitempty"`   // RSA 指数
+	Crv string `json:"crv,omitempty"` // EC 曲线
+	X   string `json:"x,omitempty"`   // EC X 坐标
+	Y   string `json:"y,omitempty"`   // EC Y 坐标
+}
+
+// JSONWebKeySet JWKS 公钥集
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// curves JWK 曲线名称与椭圆曲线的对应关系
+var curves = map[string]elliptic.Curve{
+	"P-256": elliptic.P256(),
+	"P-384": elliptic.P384(),
+	"P-521": elliptic.P521(),
+}
+
+// NewJSONWebKeySet 将签名密钥转换为 JWKS（忽略 HS256 密钥）
+func NewJSONWebKeySet(keys []*SigningKey) *JSONWebKeySet {
+	set := &JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, key := range keys {
+		jwk := JSONWebKey{Kid: key.ID, Use: "sig", Alg: key.Method.Alg()}
+		switch public := key.Public().(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case *ecdsa.PublicKey:
+			point, err := public.ECDH()
+			if err != nil {
+				continue
+			}
+			// 未压缩格式: 0x04 || X || Y
+			raw := point.Bytes()[1:]
+			size := len(raw) / 2
+			jwk.Kty = "EC"
+			jwk.Crv = public.Curve.Params().Name
+			jwk.X = base64.RawURLEncoding.EncodeToString(raw[:size])
+			jwk.Y = base64.RawURLEncoding.EncodeToString(raw[size:])
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+// SigningKey 将 JWK 转换为仅用于验证的签名密钥
+func (k JSONWebKey) SigningKey() (*SigningKey, error) {
+	key := &SigningKey{ID: k.Kid}
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("decode JWK %s modulus: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("decode JWK %s exponent: %w", k.Kid, err)
+		}
+		key.verifyKey = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		key.Method = jwt.SigningMethodRS256
+	case "EC":
+		curve, ok := curves[k.Crv]
+		if !ok {
+			return nil, fmt.Errorf("unsupported JWK %s curve: %s", k.Kid, k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("decode JWK %s x: %w", k.Kid, err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("decode JWK %s y: %w", k.Kid, err)
+		}
+		public := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		// 通过 ECDH 转换校验点是否在曲线上
+		if _, err := public.ECDH(); err != nil {
+			return nil, fmt.Errorf("invalid JWK %s point: %w", k.Kid, err)
+		}
+		key.verifyKey = public
+		key.Method = map[string]jwt.SigningMethod{"P-256": jwt.SigningMethodES256, "P-384": jwt.SigningMethodES384, "P-521": jwt.SigningMethodES512}[k.Crv]
+	default:
+		return nil, fmt.Errorf("unsupported JWK %s key type: %s", k.Kid, k.Kty)
+	}
+
+	if k.Alg != "" {
+		method := jwt.GetSigningMethod(k.Alg)
+		if method == nil {
+			return nil, fmt.Errorf("unsupported JWK %s algorithm: %s", k.Kid, k.Alg)
+		}
+		key.Method = method
+	}
+	return key, nil
+}
+
+// JWKSHandler 发布 JWKS 公钥集（GET /.well-known/jwks.json）
+func JWKSHandler(set *JSONWebKeySet) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(http.StatusOK, set)
+	}
+}
+
+// RemoteKeySet 外部身份提供方的 JWKS 公钥集
+// - 首次使用时拉取，超过 ttl 后重新拉取
+// - 遇到未知 kid 时立即刷新（两次刷新间隔至少 minRefreshInterval，防止伪造 kid 造成请求风暴）
+type RemoteKeySet struct {
+	url    string
+	ttl    time.Duration
+	client *http.Client
+
+	mutex     sync.RWMutex
+	keys      map[string]*SigningKey
+	fetchedAt time.Time
+
+	fetchMutex  sync.Mutex
+	attemptedAt time.Time // 最近一次拉取时间（含失败）
+}
+
+// minRefreshInterval 两次刷新 JWKS 的最小间隔
+const minRefreshInterval = 10 * time.Second
+
+// NewRemoteKeySet 创建外部 JWKS 公钥集
+func NewRemoteKeySet(url string, ttl time.Duration) *RemoteKeySet {
+	return &RemoteKeySet{url: url, ttl: ttl, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Key 按 kid 获取验证密钥
+func (r *RemoteKeySet) Key(ctx context.Context, kid string) (*SigningKey, error) {
+	r.mutex.RLock()
+	key, ok := r.keys[kid]
+	expired := time.Since(r.fetchedAt) > r.ttl
+	r.mutex.RUnlock()
+	if ok && !expired {
+		return key, nil
+	}
+
+	if err := r.refresh(ctx); err != nil {
+		// 拉取失败时继续使用已缓存的公钥
+		if ok {
+			return key, nil
+		}
+		return nil, err
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if key, ok := r.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown key id: %s", kid)
+}
+
+// refresh 拉取并替换公钥集（并发请求只拉取一次）
+func (r *RemoteKeySet) refresh(ctx context.Context) error {
+	r.fetchMutex.Lock()
+	defer r.fetchMutex.Unlock()
+
+	if time.Since(r.attemptedAt) < minRefreshInterval {
+		return nil
+	}
+	r.attemptedAt = time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	set := &JSONWebKeySet{}
+	if err := json.NewDecoder(resp.Body).Decode(set); err != nil {
+		return fmt.Errorf("decode JWKS: %w", err)
+	}
+	keys := make(map[string]*SigningKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		// 跳过不支持的密钥，避免单个密钥导致整个公钥集不可用
+		key, err := jwk.SigningKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	if len(keys) == 0 {
+		return errors.New("JWKS contains no usable keys")
+	}
+
+	r.mutex.Lock()
+	r.keys = keys
+	r.fetchedAt = time.Now()
+	r.mutex.Unlock()
+	return nil
+}
@@ -2,8 +2,11 @@ package utils
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -15,18 +18,46 @@ import (
 
 // Claims 自定义JWT声明
 type Claims struct {
-	SessionID string `json:"session_id"` // 会话ID(UUID)
-	IP        string `json:"ip"`         // 客户端IP
-	UserID    uint   `json:"user_id"`    // 用户ID(管理员ID或用户ID)
+	SessionID string `json:"session_id"`          // 会话ID(UUID)
+	IP        string `json:"ip"`                  // 客户端IP
+	UserID    uint   `json:"user_id"`             // 用户ID(管理员ID或用户ID)
+	TenantID  string `json:"tenant_id,omitempty"` // 租户ID（未启用多租户时为空）
+	External  bool   `json:"-"`                   // 是否由外部身份提供方签发
 	jwt.RegisteredClaims
 }
 
+// SessionValidator 会话校验函数，返回错误时 Token 视为无效（例如会话已被吊销）
+type SessionValidator func(ctx context.Context, claims *Claims) error
+
+// ExternalResolver 外部身份映射函数，将外部 Token 的身份映射为本地用户并写入 UserID 与 SessionID，返回错误时 Token 视为无效
+type ExternalResolver func(ctx context.Context, claims *Claims) error
+
+// TokenPair 访问令牌与刷新令牌（同一会话）
+type TokenPair struct {
+	SessionID    string    // 会话ID
+	AccessToken  string    // 访问令牌
+	RefreshToken string    // 刷新令牌（未启用时为空）
+	ExpiresAt    time.Time // 会话过期时间（启用刷新令牌时为刷新令牌过期时间）
+}
+
 // JWT JWT配置
 type JWT struct {
 	secretKey string        // 密钥
 	expiresIn time.Duration // 过期时间
 	cacheKey  string        // 缓存Key
 	cache     cache.Cache   // 缓存
+	keys      []*SigningKey // 签名密钥（第一个用于签发，全部用于验证；为空时使用 secretKey 以 HS256 签发）
+
+	external *RemoteKeySet    // 外部身份提供方公钥集
+	issuer   string           // 外部 Token 签发者(iss)
+	audience string           // 外部 Token 受众(aud)
+	resolver ExternalResolver // 外部身份映射
+
+	refreshSecretKey string        // 刷新令牌密钥
+	refreshExpiresIn time.Duration // 刷新令牌过期时间（为 0 时不签发刷新令牌）
+	refreshStore     cache.Cache   // 刷新令牌存储（用于轮换与吊销）
+
+	sessionValidator SessionValidator // 会话校验（可选）
 }
 
 // NewJWT 创建一个JWT实例
@@ -44,24 +75,98 @@ func (j *JWT) WithCache(c cache.Cache) *JWT {
 	return j
 }
 
+// WithKeys 使用指定密钥签发与验证 Token（可选）
+// - 第一个密钥用于签发，并写入 Token 头部 kid
+// - 所有密钥均可用于验证，轮换时将旧密钥后移即可，已签发的 Token 仍然有效
+// - 配置密钥后不再接受不含 kid 的 Token（防止以 secretKey 按 HS256 伪造）
+func (j *JWT) WithKeys(keys ...*SigningKey) *JWT {
+	j.keys = keys
+	return j
+}
+
+// Keys 获取签名密钥
+func (j *JWT) Keys() []*SigningKey {
+	return j.keys
+}
+
+// WithExternal 信任外部身份提供方签发的 Token（可选）
+// - 本地密钥中不存在的 kid 通过 keySet 获取公钥验证
+// - issuer/audience 非空时校验 iss/aud
+// - Token 中的 user_id 与 session_id 不被信任，由 resolver 映射为本地用户与会话，未设置 resolver 时拒绝外部 Token
+// - 外部 Token 不在本地签发缓存中，吊销通过会话校验生效；携带 ip 声明时同样校验 IP
+func (j *JWT) WithExternal(keySet *RemoteKeySet, issuer string, audience string, resolver ExternalResolver) *JWT {
+	j.external = keySet
+	j.issuer = issuer
+	j.audience = audience
+	j.resolver = resolver
+	return j
+}
+
+// WithRefresh 启用刷新令牌（可选）
+// - secretKey 为空时由访问令牌密钥派生，保证访问令牌无法当作刷新令牌使用
+// - store 用于保存刷新令牌，每次刷新后旧令牌立即失效；为空时无法轮换与吊销
+func (j *JWT) WithRefresh(secretKey string, expiresIn time.Duration, store cache.Cache) *JWT {
+	if secretKey == "" {
+		secretKey = j.secretKey + ":refresh"
+	}
+	j.refreshSecretKey = secretKey
+	j.refreshExpiresIn = expiresIn
+	j.refreshStore = store
+	return j
+}
+
+// WithSessionValidator 解析访问令牌与刷新令牌时校验会话（可选，外部 Token 不校验）
+func (j *JWT) WithSessionValidator(validator SessionValidator) *JWT {
+	j.sessionValidator = validator
+	return j
+}
+
+// randomID 生成随机ID（会话ID/刷新令牌ID）
+func randomID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
 // tokenKey 生成在缓存中保存 Token 的 Key
 func (j *JWT) tokenKey(token string) string {
 	return fmt.Sprintf("%s:%s", j.cacheKey, token)
 }
 
-// GenerateToken 生成JWT Token
+// GenerateToken 生成JWT Token（新会话）
 func (j *JWT) GenerateToken(userID uint, ip string) string {
+	return j.generateToken(userID, ip, randomID(), "")
+}
+
+// GenerateTenantToken 生成属于指定租户的JWT Token（新会话）
+func (j *JWT) GenerateTenantToken(userID uint, ip string, tenantID string) string {
+	return j.generateToken(userID, ip, randomID(), tenantID)
+}
+
+// generateToken 生成指定会话的访问令牌
+func (j *JWT) generateToken(userID uint, ip string, sessionID string, tenantID string) string {
 	claims := &Claims{
-		UserID: userID,
-		IP:     ip,
+		SessionID: sessionID,
+		UserID:    userID,
+		IP:        ip,
+		TenantID:  tenantID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.expiresIn)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := token.SignedString([]byte(j.secretKey))
+	var token *jwt.Token
+	var signKey interface{}
+	if len(j.keys) > 0 {
+		token = jwt.NewWithClaims(j.keys[0].Method, claims)
+		token.Header["kid"] = j.keys[0].ID
+		signKey = j.keys[0].signKey
+	} else {
+		token = jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		signKey = []byte(j.secretKey)
+	}
+	signed, err := token.SignedString(signKey)
 	if err != nil {
 		return ""
 	}
@@ -76,9 +181,7 @@ func (j *JWT) GenerateToken(userID uint, ip string) string {
 
 // ParseToken 解析JWT Token
 func (j *JWT) ParseToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(j.secretKey), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, j.verifyKey)
 	if err != nil {
 		return nil, err
 	}
@@ -88,15 +191,90 @@ func (j *JWT) ParseToken(tokenString string) (*Claims, error) {
 		return nil, errors.New("invalid token")
 	}
 
-	// 如果启用了缓存，校验 Token 是否仍然有效（未被注销）
-	if j.cache != nil {
+	claims := token.Claims.(*Claims)
+	if claims.External {
+		if err := j.checkExternal(claims); err != nil {
+			return nil, err
+		}
+	} else if j.cache != nil {
+		// 如果启用了缓存，校验 Token 是否仍然有效（未被注销）
 		exists, err := j.cache.Exists(context.Background(), j.tokenKey(tokenString))
 		if err == nil && !exists {
 			return nil, errors.New("token revoked or expired")
 		}
 	}
 
-	return token.Claims.(*Claims), nil
+	if err := j.validateSession(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// validateSession 调用会话校验函数
+func (j *JWT) validateSession(claims *Claims) error {
+	if j.sessionValidator == nil {
+		return nil
+	}
+	return j.sessionValidator(context.Background(), claims)
+}
+
+// checkExternal 校验外部 Token 的签发者与受众，并映射为本地用户与会话
+func (j *JWT) checkExternal(claims *Claims) error {
+	if j.issuer != "" && claims.Issuer != j.issuer {
+		return errors.New("invalid token issuer")
+	}
+	if j.audience != "" && !slices.Contains(claims.Audience, j.audience) {
+		return errors.New("invalid token audience")
+	}
+	if j.resolver == nil {
+		return errors.New("external identity not mapped")
+	}
+	claims.UserID, claims.SessionID = 0, ""
+	if err := j.resolver(context.Background(), claims); err != nil {
+		return err
+	}
+	if claims.UserID == 0 {
+		return errors.New("external identity not mapped")
+	}
+	return nil
+}
+
+// verifyKey 按 Token 头部 kid 选择验证密钥，并校验签名算法与密钥一致
+func (j *JWT) verifyKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		if len(j.keys) > 0 {
+			return nil, errors.New("missing key id")
+		}
+		if token.Method != jwt.SigningMethodHS256 {
+			return nil, fmt.Errorf("unexpected signing method: %s", token.Method.Alg())
+		}
+		return []byte(j.secretKey), nil
+	}
+
+	for _, key := range j.keys {
+		if key.ID != kid {
+			continue
+		}
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %s", token.Method.Alg())
+		}
+		return key.verifyKey, nil
+	}
+
+	// 本地不存在的 kid 交由外部身份提供方验证
+	if j.external != nil {
+		key, err := j.external.Key(context.Background(), kid)
+		if err != nil {
+			return nil, err
+		}
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %s", token.Method.Alg())
+		}
+		token.Claims.(*Claims).External = true
+		return key.verifyKey, nil
+	}
+	return nil, fmt.Errorf("unknown key id: %s", kid)
 }
 
 // RevokeToken 主动使 Token 失效（从缓存中删除）
@@ -107,6 +285,160 @@ func (j *JWT) RevokeToken(tokenString string) error {
 	return j.cache.Delete(context.Background(), j.tokenKey(tokenString))
 }
 
+// refreshKey 生成在缓存中保存刷新令牌的 Key（按用户分组，便于吊销用户全部刷新令牌）
+func refreshKey(userID uint, id string) string {
+	return fmt.Sprintf("jwt:refresh:%d:%s", userID, id)
+}
+
+// GenerateTokenPair 为新会话生成访问令牌与刷新令牌（未启用刷新令牌时仅生成访问令牌）
+func (j *JWT) GenerateTokenPair(userID uint, ip string) (*TokenPair, error) {
+	return j.generateTokenPair(userID, ip, randomID(), "")
+}
+
+// GenerateTenantTokenPair 为新会话生成属于指定租户的访问令牌与刷新令牌（刷新后租户保持不变）
+func (j *JWT) GenerateTenantTokenPair(userID uint, ip string, tenantID string) (*TokenPair, error) {
+	return j.generateTokenPair(userID, ip, randomID(), tenantID)
+}
+
+// generateTokenPair 生成指定会话的访问令牌与刷新令牌
+func (j *JWT) generateTokenPair(userID uint, ip string, sessionID string, tenantID string) (*TokenPair, error) {
+	pair := &TokenPair{SessionID: sessionID, AccessToken: j.generateToken(userID, ip, sessionID, tenantID), ExpiresAt: time.Now().Add(j.expiresIn)}
+	if pair.AccessToken == "" {
+		return nil, errors.New("failed to generate token")
+	}
+	if j.refreshExpiresIn > 0 {
+		pair.RefreshToken = j.generateRefreshToken(userID, ip, sessionID, tenantID)
+		if pair.RefreshToken == "" {
+			return nil, errors.New("failed to generate refresh token")
+		}
+		pair.ExpiresAt = time.Now().Add(j.refreshExpiresIn)
+	}
+	return pair, nil
+}
+
+// generateRefreshToken 生成指定会话的刷新令牌
+func (j *JWT) generateRefreshToken(userID uint, ip string, sessionID string, tenantID string) string {
+	claims := &Claims{
+		SessionID: sessionID,
+		UserID:    userID,
+		IP:        ip,
+		TenantID:  tenantID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        randomID(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.refreshExpiresIn)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(j.refreshSecretKey))
+	if err != nil {
+		return ""
+	}
+
+	// 将刷新令牌存入缓存用于后续轮换/吊销
+	if j.refreshStore != nil {
+		if err := j.refreshStore.Set(context.Background(), refreshKey(userID, claims.ID), ip, j.refreshExpiresIn); err != nil {
+			return ""
+		}
+	}
+
+	return signed
+}
+
+// ParseRefreshToken 解析刷新令牌
+func (j *JWT) ParseRefreshToken(tokenString string) (*Claims, error) {
+	if j.refreshExpiresIn <= 0 {
+		return nil, errors.New("refresh token disabled")
+	}
+
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		// 刷新令牌固定以 HS256 签发
+		if token.Method != jwt.SigningMethodHS256 {
+			return nil, fmt.Errorf("unexpected signing method: %s", token.Method.Alg())
+		}
+		return []byte(j.refreshSecretKey), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("invalid refresh token")
+	}
+
+	claims := token.Claims.(*Claims)
+	if j.refreshStore != nil {
+		exists, err := j.refreshStore.Exists(context.Background(), refreshKey(claims.UserID, claims.ID))
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, errors.New("refresh token revoked or expired")
+		}
+	}
+
+	if err := j.validateSession(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// RefreshToken 使用刷新令牌换取同一会话新的访问令牌与刷新令牌（旧刷新令牌仅可使用一次）
+func (j *JWT) RefreshToken(refreshToken string, ip string) (*TokenPair, error) {
+	claims, err := j.ParseRefreshToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if claims.IP != ip {
+		return nil, errors.New("IP not match")
+	}
+
+	// 标记旧刷新令牌已使用（并发刷新时仅第一个请求成功），随后吊销
+	if j.refreshStore != nil {
+		ctx := context.Background()
+		key := refreshKey(claims.UserID, claims.ID)
+		used, err := j.refreshStore.Increment(ctx, key+":used", 1)
+		if err != nil {
+			return nil, err
+		}
+		_ = j.refreshStore.Expire(ctx, key+":used", j.refreshExpiresIn)
+		if used > 1 {
+			return nil, errors.New("refresh token already used")
+		}
+		if err := j.refreshStore.Delete(ctx, key); err != nil {
+			return nil, err
+		}
+	}
+
+	// 旧版本签发的刷新令牌不含会话ID，此时开启新会话
+	sessionID := claims.SessionID
+	if sessionID == "" {
+		sessionID = randomID()
+	}
+	return j.generateTokenPair(claims.UserID, ip, sessionID, claims.TenantID)
+}
+
+// RevokeRefreshToken 主动使刷新令牌失效（从缓存中删除）
+func (j *JWT) RevokeRefreshToken(tokenString string) error {
+	if j.refreshStore == nil {
+		return nil
+	}
+	claims, err := j.ParseRefreshToken(tokenString)
+	if err != nil {
+		return err
+	}
+	return j.refreshStore.Delete(context.Background(), refreshKey(claims.UserID, claims.ID))
+}
+
+// RevokeUserRefreshTokens 吊销用户的全部刷新令牌（例如修改密码或禁用账号后）
+func (j *JWT) RevokeUserRefreshTokens(userID uint) error {
+	if j.refreshStore == nil {
+		return nil
+	}
+	_, err := j.refreshStore.DeleteByPattern(context.Background(), fmt.Sprintf("jwt:refresh:%d:*", userID))
+	return err
+}
+
 // GetRequestToken 获取请求头中的Token
 func GetRequestToken(c *gin.Context) string {
 	// 优先级：Query > Header
@@ -0,0 +1,197 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+/*
+JWT 签发与验证测试
+
+本文件用于测试 JWT 的签名密钥选择与签名算法校验。
+
+运行命令：
+go test -v -run "^TestJWT.*$"
+
+测试内容：
+1. 未配置密钥时以 secretKey 按 HS256 签发与验证 (GenerateToken, ParseToken)
+2. 配置密钥后拒绝不含 kid 的 Token (verifyKey)
+3. 拒绝 kid 对应密钥与签名算法不一致的 Token (verifyKey)
+4. 外部 Token 的身份由映射函数决定，不信任 Token 中的 user_id (WithExternal, checkExternal)
+5. 刷新令牌拒绝 HS256 以外的签名算法 (ParseRefreshToken)
+*/
+
+// newTestRSAKey 生成测试 RS256 密钥
+func newTestRSAKey(t *testing.T, id string) *SigningKey {
+	t.Helper()
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate RSA key failed: %v", err)
+	}
+	privatePEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(private)})
+	key, err := NewRSAKey(id, privatePEM, nil)
+	if err != nil {
+		t.Fatalf("NewRSAKey failed: %v", err)
+	}
+	return key
+}
+
+// signTestToken 使用指定算法与密钥签名 Token
+func signTestToken(t *testing.T, method jwt.SigningMethod, kid string, key interface{}) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, &Claims{
+		UserID: 1,
+		IP:     "127.0.0.1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("sign token failed: %v", err)
+	}
+	return signed
+}
+
+func TestJWT_SecretKey(t *testing.T) {
+	j := NewJWT("test-secret", time.Hour)
+	claims, err := j.ParseToken(j.GenerateToken(1, "127.0.0.1"))
+	if err != nil {
+		t.Fatalf("ParseToken failed: %v", err)
+	}
+	if claims.UserID != 1 || claims.IP != "127.0.0.1" {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+
+	if _, err := j.ParseToken(signTestToken(t, jwt.SigningMethodHS256, "", []byte("other-secret"))); err == nil {
+		t.Fatal("expected invalid signature error")
+	}
+}
+
+func TestJWT_RejectHS256WithKeys(t *testing.T) {
+	key := newTestRSAKey(t, "rsa-1")
+	j := NewJWT("test-secret", time.Hour).WithKeys(key)
+
+	// 本地签发的 Token 带 kid
+	if _, err := j.ParseToken(j.GenerateToken(1, "127.0.0.1")); err != nil {
+		t.Fatalf("ParseToken failed: %v", err)
+	}
+
+	// 以 secretKey 签名且不含 kid 的 Token 不再被接受
+	if _, err := j.ParseToken(signTestToken(t, jwt.SigningMethodHS256, "", []byte("test-secret"))); err == nil {
+		t.Fatal("expected token without kid rejected")
+	}
+}
+
+func TestJWT_RejectAlgorithmMismatch(t *testing.T) {
+	key := newTestRSAKey(t, "rsa-1")
+	j := NewJWT("test-secret", time.Hour).WithKeys(key, NewHMACKey("hmac-1", "hmac-secret"))
+
+	// 以 HS256 冒用 RS256 密钥的 kid
+	if _, err := j.ParseToken(signTestToken(t, jwt.SigningMethodHS256, "rsa-1", []byte("test-secret"))); err == nil {
+		t.Fatal("expected algorithm mismatch rejected")
+	}
+	// 未知 kid
+	if _, err := j.ParseToken(signTestToken(t, jwt.SigningMethodHS256, "unknown", []byte("test-secret"))); err == nil {
+		t.Fatal("expected unknown kid rejected")
+	}
+	// 已配置的 HS256 密钥仍可验证
+	if _, err := j.ParseToken(signTestToken(t, jwt.SigningMethodHS256, "hmac-1", []byte("hmac-secret"))); err != nil {
+		t.Fatalf("ParseToken failed: %v", err)
+	}
+}
+
+func TestJWT_RefreshSigningMethod(t *testing.T) {
+	j := NewJWT("test-secret", time.Hour).WithRefresh("refresh-secret", time.Hour, nil)
+	pair, err := j.GenerateTokenPair(1, "127.0.0.1")
+	if err != nil {
+		t.Fatalf("GenerateTokenPair failed: %v", err)
+	}
+	if _, err := j.ParseRefreshToken(pair.RefreshToken); err != nil {
+		t.Fatalf("ParseRefreshToken failed: %v", err)
+	}
+
+	// 以相同密钥但其他 HMAC 算法签名的刷新令牌
+	if _, err := j.ParseRefreshToken(signTestToken(t, jwt.SigningMethodHS384, "", []byte("refresh-secret"))); err == nil {
+		t.Fatal("expected signing method mismatch rejected")
+	}
+}
+
+func TestJWT_External(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	external := newTestRSAKey(t, "idp-1")
+	router := gin.New()
+	router.GET("/jwks", JWKSHandler(NewJSONWebKeySet([]*SigningKey{external})))
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	// 外部 Token 声明 user_id 为 1、会话为 forged
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, &Claims{
+		UserID:    1,
+		SessionID: "forged",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "https://idp.example.com",
+			Subject:   "alice",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+	token.Header["kid"] = "idp-1"
+	signed, err := token.SignedString(external.signKey)
+	if err != nil {
+		t.Fatalf("sign token failed: %v", err)
+	}
+
+	// 未设置映射函数时拒绝外部 Token
+	j := NewJWT("test-secret", time.Hour).WithExternal(NewRemoteKeySet(server.URL+"/jwks", time.Hour), "https://idp.example.com", "", nil)
+	if _, err := j.ParseToken(signed); err == nil {
+		t.Fatal("expected external token rejected without resolver")
+	}
+
+	// 映射函数看到的声明已清除 user_id 与会话
+	var validated *Claims
+	j = NewJWT("test-secret", time.Hour).WithExternal(NewRemoteKeySet(server.URL+"/jwks", time.Hour), "https://idp.example.com", "", func(ctx context.Context, claims *Claims) error {
+		if claims.UserID != 0 || claims.SessionID != "" {
+			return errors.New("unexpected trusted claims")
+		}
+		if claims.Subject != "alice" {
+			return errors.New("not bound")
+		}
+		claims.UserID, claims.SessionID = 42, "ext-session"
+		return nil
+	}).WithSessionValidator(func(ctx context.Context, claims *Claims) error {
+		validated = claims
+		return nil
+	})
+	claims, err := j.ParseToken(signed)
+	if err != nil {
+		t.Fatalf("ParseToken failed: %v", err)
+	}
+	if !claims.External || claims.UserID != 42 || claims.SessionID != "ext-session" {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+	if validated != claims {
+		t.Fatal("expected session validator called for external token")
+	}
+
+	// 签发者不一致
+	j = NewJWT("test-secret", time.Hour).WithExternal(NewRemoteKeySet(server.URL+"/jwks", time.Hour), "https://other.example.com", "", func(ctx context.Context, claims *Claims) error {
+		claims.UserID = 42
+		return nil
+	})
+	if _, err := j.ParseToken(signed); err == nil {
+		t.Fatal("expected invalid issuer")
+	}
+}
@@ -0,0 +1,142 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/so68/core/config"
+)
+
+// SigningKey JWT 签名密钥（通过 kid 区分，仅含公钥时只用于验证）
+type SigningKey struct {
+	ID        string            // 密钥ID(kid)
+	Method    jwt.SigningMethod // 签名算法
+	signKey   interface{}       // 签名密钥（仅验证时为 nil）
+	verifyKey interface{}       // 验证密钥
+}
+
+// NewHMACKey 创建 HS256 签名密钥
+func NewHMACKey(id string, secret string) *SigningKey {
+	return &SigningKey{ID: id, Method: jwt.SigningMethodHS256, signKey: []byte(secret), verifyKey: []byte(secret)}
+}
+
+// NewRSAKey 创建 RS256 签名密钥，privatePEM 为空时仅用于验证
+func NewRSAKey(id string, privatePEM []byte, publicPEM []byte) (*SigningKey, error) {
+	key := &SigningKey{ID: id, Method: jwt.SigningMethodRS256}
+	if len(privatePEM) > 0 {
+		private, err := jwt.ParseRSAPrivateKeyFromPEM(privatePEM)
+		if err != nil {
+			return nil, fmt.Errorf("parse RSA private key %s: %w", id, err)
+		}
+		key.signKey, key.verifyKey = private, &private.PublicKey
+	}
+	if len(publicPEM) > 0 {
+		public, err := jwt.ParseRSAPublicKeyFromPEM(publicPEM)
+		if err != nil {
+			return nil, fmt.Errorf("parse RSA public key %s: %w", id, err)
+		}
+		key.verifyKey = public
+	}
+	if key.verifyKey == nil {
+		return nil, fmt.Errorf("RSA key %s has neither private nor public key", id)
+	}
+	return key, nil
+}
+
+// NewECDSAKey 创建 ES256 签名密钥（P-256 曲线），privatePEM 为空时仅用于验证
+func NewECDSAKey(id string, privatePEM []byte, publicPEM []byte) (*SigningKey, error) {
+	key := &SigningKey{ID: id, Method: jwt.SigningMethodES256}
+	if len(privatePEM) > 0 {
+		private, err := jwt.ParseECPrivateKeyFromPEM(privatePEM)
+		if err != nil {
+			return nil, fmt.Errorf("parse ECDSA private key %s: %w", id, err)
+		}
+		key.signKey, key.verifyKey = private, &private.PublicKey
+	}
+	if len(publicPEM) > 0 {
+		public, err := jwt.ParseECPublicKeyFromPEM(publicPEM)
+		if err != nil {
+			return nil, fmt.Errorf("parse ECDSA public key %s: %w", id, err)
+		}
+		key.verifyKey = public
+	}
+	public, ok := key.verifyKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("ECDSA key %s has neither private nor public key", id)
+	}
+	if public.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("ECDSA key %s must use the P-256 curve", id)
+	}
+	return key, nil
+}
+
+// CanSign 是否可用于签发
+func (k *SigningKey) CanSign() bool {
+	return k.signKey != nil
+}
+
+// Public 返回公钥（HS256 密钥返回 nil）
+func (k *SigningKey) Public() crypto.PublicKey {
+	switch key := k.verifyKey.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		return key
+	}
+	return nil
+}
+
+// LoadSigningKeys 按配置加载签名密钥（密钥内容优先于文件）
+func LoadSigningKeys(cfg *config.JWTConfig) ([]*SigningKey, error) {
+	keys := make([]*SigningKey, 0, len(cfg.Keys))
+	for _, item := range cfg.Keys {
+		var key *SigningKey
+		switch cfg.Algorithm {
+		case "", "HS256":
+			key = NewHMACKey(item.ID, item.Secret)
+		case "RS256", "ES256":
+			privatePEM, err := readPEM(item.PrivateKey, item.PrivateKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			publicPEM, err := readPEM(item.PublicKey, item.PublicKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			if cfg.Algorithm == "RS256" {
+				key, err = NewRSAKey(item.ID, privatePEM, publicPEM)
+			} else {
+				key, err = NewECDSAKey(item.ID, privatePEM, publicPEM)
+			}
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unsupported JWT algorithm: %s", cfg.Algorithm)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) > 0 && !keys[0].CanSign() {
+		return nil, errors.New("the first JWT key must have a private key")
+	}
+	return keys, nil
+}
+
+// readPEM 读取 PEM 内容（内容为空时读取文件）
+func readPEM(content string, file string) ([]byte, error) {
+	if content != "" {
+		return []byte(content), nil
+	}
+	if file == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read key file: %w", err)
+	}
+	return data, nil
+}
@@ -1,11 +1,28 @@
 package utils
 
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm/clause"
+)
+
+// sortPattern 合法的排序字段（字段名或 表名.字段名）
+var sortPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
 // Page 分页请求参数
+// - 默认按页码分页（OFFSET）
+// - 传入 Cursor 时按游标分页（忽略页码），适用于数据量大的表，翻页期间新增数据也不会导致重复或遗漏
 type Page struct {
-	Page  int64  `form:"page" binding:"omitempty"`  // 页码，从1开始
-	Size  int64  `form:"size" binding:"omitempty"`  // 每页数量
-	Sort  string `form:"sort" binding:"omitempty"`  // 排序字段
-	Order string `form:"order" binding:"omitempty"` // 排序方向
+	Page   int64  `form:"page" binding:"omitempty"`                                               // 页码，从1开始
+	Size   int64  `form:"size" binding:"omitempty"`                                               // 每页数量
+	Sort   string `form:"sort" binding:"omitempty"`                                               // 排序字段
+	Order  string `form:"order" binding:"omitempty" validate:"omitempty,oneof=asc desc ASC DESC"` // 排序方向
+	Cursor string `form:"cursor" binding:"omitempty"`                                             // 游标（上一次查询返回的 next_cursor）
+
+	nextCursor string // 下一页游标（查询后生成）
 }
 
 // NewDefaultPage 创建默认分页参数
@@ -37,18 +54,60 @@ func (p *Page) GetLimit() int64 {
 	return p.Size
 }
 
-// 获取排序字段
+// 获取排序字段（字段名不合法时返回空字符串）
 func (p *Page) GetSort() string {
-	return p.Sort + " " + p.Order
+	if !sortPattern.MatchString(p.Sort) {
+		return ""
+	}
+	if strings.EqualFold(p.Order, "DESC") {
+		return p.Sort + " DESC"
+	}
+	return p.Sort + " ASC"
+}
+
+// normalize 按白名单校验排序字段，并统一排序方向
+func (p *Page) normalize(sorts []string) {
+	if len(sorts) > 0 && !slices.Contains(sorts, p.Sort) {
+		p.Sort = sorts[0]
+	}
+	if strings.EqualFold(p.Order, "DESC") {
+		p.Order = "DESC"
+	} else {
+		p.Order = "ASC"
+	}
+}
+
+// orderBy 构建排序子句（字段名不合法时不排序，排序方向默认升序）
+func (p *Page) orderBy() (clause.OrderByColumn, bool) {
+	if !sortPattern.MatchString(p.Sort) {
+		return clause.OrderByColumn{}, false
+	}
+	return clause.OrderByColumn{Column: clause.Column{Name: p.Sort}, Desc: strings.EqualFold(p.Order, "DESC")}, true
 }
 
 // PageResp 分页响应结构
 type PageResp struct {
-	Total   int64       `json:"total"`   // 总记录数
-	Pages   int64       `json:"pages"`   // 总页数
-	Current int64       `json:"current"` // 当前页码
-	Size    int64       `form:"size"`    // 每页数量
-	Items   interface{} `json:"items"`   // 数据列表
+	Total      int64       `json:"total"`                 // 总记录数
+	Pages      int64       `json:"pages"`                 // 总页数
+	Current    int64       `json:"current"`               // 当前页码
+	Size       int64       `json:"size"`                  // 每页数量
+	Items      interface{} `json:"items"`                 // 数据列表
+	NextCursor string      `json:"next_cursor,omitempty"` // 下一页游标（没有更多数据时为空）
+}
+
+// fields 按响应格式输出分页字段
+func (p *PageResp) fields(format *RespFormat) gin.H {
+	fields := gin.H{
+		"total":   p.Total,
+		"pages":   p.Pages,
+		"current": p.Current,
+		"size":    p.Size,
+		"items":   p.Items,
+	}
+	if p.NextCursor != "" {
+		fields[format.key("next_cursor")] = p.NextCursor
+	}
+	return fields
 }
 
 // NewPageResp 创建分页响应结构
@@ -64,10 +123,11 @@ func NewPageResp(total int64, page *Page, items interface{}) *PageResp {
 		pages++
 	}
 	return &PageResp{
-		Total:   total,
-		Pages:   pages,
-		Current: page.Page,
-		Size:    page.Size,
-		Items:   items,
+		Total:      total,
+		Pages:      pages,
+		Current:    page.Page,
+		Size:       page.Size,
+		Items:      items,
+		NextCursor: page.nextCursor,
 	}
 }
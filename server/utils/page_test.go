@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"context"
+	"testing"
+)
+
+/*
+分页参数测试
+
+本文件用于测试分页排序字段白名单与排序方向校验。
+
+运行命令：
+go test -v -run "^TestPage.*$"
+
+测试内容：
+1. 排序字段白名单 (WithPage)
+2. 排序方向统一为 ASC/DESC
+3. 排序语句 (GetSort)
+*/
+
+func TestPage_WithPageSorts(t *testing.T) {
+	tests := []struct {
+		name      string
+		page      Page
+		sorts     []string
+		wantSort  string
+		wantOrder string
+		wantSQL   string
+	}{
+		{name: "白名单内字段", page: Page{Sort: "username", Order: "desc"}, sorts: []string{"id", "username"}, wantSort: "username", wantOrder: "DESC", wantSQL: "username DESC"},
+		{name: "白名单外字段", page: Page{Sort: "password_hash", Order: "ASC"}, sorts: []string{"id", "username"}, wantSort: "id", wantOrder: "ASC", wantSQL: "id ASC"},
+		{name: "注入语句", page: Page{Sort: "id; DROP TABLE admins", Order: "DESC"}, sorts: []string{"id"}, wantSort: "id", wantOrder: "DESC", wantSQL: "id DESC"},
+		{name: "非法排序方向", page: Page{Sort: "id", Order: "DESC, (SELECT 1)"}, sorts: []string{"id"}, wantSort: "id", wantOrder: "ASC", wantSQL: "id ASC"},
+		{name: "未设置白名单", page: Page{Sort: "id; DROP TABLE admins", Order: "desc"}, wantSort: "id; DROP TABLE admins", wantOrder: "DESC", wantSQL: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := tt.page
+			NewGormBuilderWithPage(context.Background(), nil, &page, tt.sorts...)
+			if page.Sort != tt.wantSort || page.Order != tt.wantOrder {
+				t.Errorf("page = %s %s, want %s %s", page.Sort, page.Order, tt.wantSort, tt.wantOrder)
+			}
+			if got := page.GetSort(); got != tt.wantSQL {
+				t.Errorf("GetSort() = %q, want %q", got, tt.wantSQL)
+			}
+		})
+	}
+}
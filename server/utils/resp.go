@@ -1,9 +1,17 @@
 package utils
 
 import (
+	"log/slog"
 	"net/http"
+	"strings"
+	"sync/atomic"
 
 	"github.com/gin-gonic/gin"
+	"github.com/so68/core/config"
+	coreerrors "github.com/so68/core/errors"
+	"github.com/so68/core/i18n"
+	"github.com/so68/core/logging"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Resp 统一响应结构
@@ -13,19 +21,172 @@ type Resp struct {
 	Data    interface{} `json:"data,omitempty"` // 响应数据
 }
 
+// RespFormat 统一响应格式（不同前端约定的外层结构不同时通过配置调整）
+type RespFormat struct {
+	SuccessCode int  // 成功时的业务状态码
+	ErrorCode   int  // 失败时的业务状态码（带错误码的错误使用其错误码）
+	TraceID     bool // 响应中包含链路追踪ID（未启用链路追踪时为请求ID）
+	CamelCase   bool // 响应外层与分页字段使用驼峰命名（traceId、nextCursor），data 中的业务字段不受影响
+}
+
+// respFormat 当前响应格式
+var respFormat atomic.Pointer[RespFormat]
+
+func init() {
+	respFormat.Store(&RespFormat{SuccessCode: 0, ErrorCode: -1})
+}
+
+// NewRespFormat 根据配置创建响应格式
+func NewRespFormat(cfg *config.ResponseConfig) *RespFormat {
+	if cfg == nil {
+		cfg = config.DefaultResponseConfig()
+	}
+	return &RespFormat{
+		SuccessCode: cfg.SuccessCode,
+		ErrorCode:   cfg.ErrorCode,
+		TraceID:     cfg.TraceID,
+		CamelCase:   cfg.FieldCase == config.ResponseFieldCaseCamel,
+	}
+}
+
+// SetRespFormat 设置响应格式（服务启动时按配置设置）
+func SetRespFormat(format *RespFormat) {
+	respFormat.Store(format)
+}
+
+// GetRespFormat 获取当前响应格式
+func GetRespFormat() *RespFormat {
+	return respFormat.Load()
+}
+
+// key 按命名方式转换字段名（snake_case -> camelCase）
+func (f *RespFormat) key(name string) string {
+	if !f.CamelCase || !strings.Contains(name, "_") {
+		return name
+	}
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// JSON 按统一响应格式输出响应（分页数据按命名方式输出分页字段）
+func JSON(c *gin.Context, status int, resp Resp) {
+	format := GetRespFormat()
+	body := gin.H{"code": resp.Code, "message": resp.Message}
+	switch data := resp.Data.(type) {
+	case nil:
+	case *PageResp:
+		if data != nil {
+			body["data"] = data.fields(format)
+		}
+	default:
+		body["data"] = data
+	}
+	if format.TraceID {
+		if traceID := traceIDFromContext(c); traceID != "" {
+			body[format.key("trace_id")] = traceID
+		}
+	}
+	c.JSON(status, body)
+}
+
+// traceIDFromContext 获取链路追踪ID，未启用链路追踪时使用请求ID
+func traceIDFromContext(c *gin.Context) string {
+	if spanContext := trace.SpanContextFromContext(c.Request.Context()); spanContext.HasTraceID() {
+		return spanContext.TraceID().String()
+	}
+	return GetContextRequestID(c)
+}
+
 // Success 成功响应
 func Success(c *gin.Context, data interface{}) {
-	c.JSON(http.StatusOK, Resp{
-		Code:    0,
-		Message: "ok",
+	JSON(c, http.StatusOK, Resp{
+		Code:    GetRespFormat().SuccessCode,
+		Message: i18n.T(GetContextLocale(c), "ok"),
 		Data:    data,
 	})
 }
 
-// Error 错误响应
+// SuccessPage 分页成功响应（data 为分页字段与数据列表）
+func SuccessPage(c *gin.Context, total int64, page *Page, items interface{}) {
+	Success(c, NewPageResp(total, page, items))
+}
+
+// Error 错误响应（按请求语言翻译提示信息）
 func Error(c *gin.Context, message string) {
-	c.JSON(http.StatusOK, Resp{
-		Code:    -1,
+	ErrorStatus(c, http.StatusOK, message)
+}
+
+// ErrorStatus 指定 HTTP 状态码的错误响应（按请求语言翻译提示信息）
+func ErrorStatus(c *gin.Context, status int, message string) {
+	JSON(c, status, Resp{
+		Code:    GetRespFormat().ErrorCode,
+		Message: i18n.T(GetContextLocale(c), message),
+	})
+}
+
+// Errorf 格式化错误响应（先翻译格式串再格式化参数）
+func Errorf(c *gin.Context, format string, args ...interface{}) {
+	JSON(c, http.StatusOK, Resp{
+		Code:    GetRespFormat().ErrorCode,
+		Message: i18n.T(GetContextLocale(c), format, args...),
+	})
+}
+
+// Fail 错误响应
+// - 带错误码的错误（core/errors，可被 fmt.Errorf 包装）按错误码返回业务状态码与 HTTP 状态码
+// - 内部错误只返回通用提示并记录日志
+// - 提示信息按请求语言翻译，i18n.Errorf 创建的错误按消息键与参数翻译（见 i18n.TranslateError）
+func Fail(c *gin.Context, err error) {
+	locale := GetContextLocale(c)
+	coded, ok := coreerrors.From(err)
+	if !ok {
+		JSON(c, http.StatusOK, Resp{
+			Code:    GetRespFormat().ErrorCode,
+			Message: i18n.TranslateError(locale, err),
+		})
+		return
+	}
+
+	// 内部错误记录日志，调试模式下其他错误同时输出调用栈
+	attrs := []any{slog.Int("code", int(coded.Code())), slog.String("error", err.Error())}
+	stack := coded.Stack()
+	if stack != "" {
+		attrs = append(attrs, slog.String("stack", stack))
+	}
+	if coded.Code() == coreerrors.CodeInternal {
+		logging.FromContext(c.Request.Context()).Error("request failed", attrs...)
+	} else if stack != "" {
+		logging.FromContext(c.Request.Context()).Debug("request failed", attrs...)
+	}
+	message := i18n.TranslateError(locale, err)
+	if coded.Code() == coreerrors.CodeInternal {
+		message = i18n.T(locale, coded.Message())
+	}
+	code := int(coded.Code())
+	if coded.Code() == coreerrors.CodeUnknown {
+		code = GetRespFormat().ErrorCode
+	}
+	JSON(c, coded.HTTPStatus(), Resp{
+		Code:    code,
 		Message: message,
 	})
 }
+
+// BindError 参数绑定/校验错误响应（翻译校验错误）
+// - 校验错误时 data 为字段级错误列表 []i18n.FieldError，便于前端在对应字段下提示
+func BindError(c *gin.Context, err error) {
+	locale := GetContextLocale(c)
+	resp := Resp{
+		Code:    GetRespFormat().ErrorCode,
+		Message: i18n.TranslateValidation(locale, err),
+	}
+	if fieldErrors := i18n.FieldErrors(locale, err); len(fieldErrors) > 0 {
+		resp.Data = fieldErrors
+	}
+	JSON(c, http.StatusOK, resp)
+}
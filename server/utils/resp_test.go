@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	coreerrors "github.com/so68/core/errors"
+)
+
+/*
+统一响应测试
+
+本文件用于测试错误响应的业务状态码与 HTTP 状态码。
+
+运行命令：
+go test -v -run "^(TestFail|TestRespFormat).*$"
+
+测试内容：
+1. 带错误码的错误按错误码响应 (Fail)
+2. 普通错误保持原有响应
+3. 内部错误不返回原始错误信息
+4. 响应格式：业务状态码、trace_id 与字段命名 (SetRespFormat, SuccessPage)
+*/
+
+func TestFail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantCode    int
+		wantMessage string
+	}{
+		{name: "普通错误", err: errors.New("操作失败"), wantStatus: http.StatusOK, wantCode: -1, wantMessage: "操作失败"},
+		{name: "不存在", err: coreerrors.NotFound("管理员不存在"), wantStatus: http.StatusNotFound, wantCode: int(coreerrors.CodeNotFound), wantMessage: "管理员不存在"},
+		{name: "包装的错误码", err: fmt.Errorf("上级管理员无效: %w", coreerrors.NotFound("管理员不存在")), wantStatus: http.StatusNotFound, wantCode: int(coreerrors.CodeNotFound), wantMessage: "上级管理员无效: 管理员不存在"},
+		{name: "冲突", err: coreerrors.Conflict("用户名已存在"), wantStatus: http.StatusConflict, wantCode: int(coreerrors.CodeConflict), wantMessage: "用户名已存在"},
+		{name: "内部错误", err: coreerrors.Internal(io.ErrUnexpectedEOF), wantStatus: http.StatusInternalServerError, wantCode: int(coreerrors.CodeInternal), wantMessage: "服务器内部错误"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/", nil)
+			Fail(c, tt.err)
+
+			resp := &Resp{}
+			if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+				t.Fatalf("decode response failed: %v", err)
+			}
+			if w.Code != tt.wantStatus || resp.Code != tt.wantCode || resp.Message != tt.wantMessage {
+				t.Errorf("got %d %d %q, want %d %d %q", w.Code, resp.Code, resp.Message, tt.wantStatus, tt.wantCode, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestRespFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	defer SetRespFormat(GetRespFormat())
+
+	page := &Page{Page: 1, Size: 2}
+	page.nextCursor = "abc"
+	tests := []struct {
+		name   string
+		format *RespFormat
+		write  func(c *gin.Context)
+		want   string
+	}{
+		{
+			name:   "默认格式",
+			format: NewRespFormat(nil),
+			write:  func(c *gin.Context) { Success(c, nil) },
+			want:   `{"code":0,"message":"ok"}`,
+		},
+		{
+			name:   "自定义业务状态码",
+			format: &RespFormat{SuccessCode: 200, ErrorCode: 500},
+			write:  func(c *gin.Context) { Error(c, "操作失败") },
+			want:   `{"code":500,"message":"操作失败"}`,
+		},
+		{
+			name:   "包含请求ID",
+			format: &RespFormat{ErrorCode: -1, TraceID: true},
+			write:  func(c *gin.Context) { Success(c, nil) },
+			want:   `{"code":0,"message":"ok","trace_id":"req-1"}`,
+		},
+		{
+			name:   "分页下划线命名",
+			format: &RespFormat{ErrorCode: -1},
+			write:  func(c *gin.Context) { SuccessPage(c, 3, page, []int{1, 2}) },
+			want:   `{"code":0,"data":{"current":1,"items":[1,2],"next_cursor":"abc","pages":2,"size":2,"total":3},"message":"ok"}`,
+		},
+		{
+			name:   "分页驼峰命名",
+			format: &RespFormat{ErrorCode: -1, TraceID: true, CamelCase: true},
+			write:  func(c *gin.Context) { SuccessPage(c, 3, page, []int{1, 2}) },
+			want:   `{"code":0,"data":{"current":1,"items":[1,2],"nextCursor":"abc","pages":2,"size":2,"total":3},"message":"ok","traceId":"req-1"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetRespFormat(tt.format)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/", nil)
+			c.Set(ContextRequestIDKey, "req-1")
+			tt.write(c)
+			if got := w.Body.String(); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
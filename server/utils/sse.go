@@ -0,0 +1,178 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/logging"
+)
+
+// SSEEvent 服务端推送事件
+type SSEEvent struct {
+	ID    string        // 事件ID（客户端重连时通过 Last-Event-ID 回传）
+	Event string        // 事件类型（为空时客户端触发 message 事件）
+	Data  interface{}   // 事件数据（string/[]byte 原样发送，其他类型序列化为 JSON）
+	Retry time.Duration // 客户端重连间隔（为 0 时不发送）
+}
+
+// SSEStreamFunc 事件流处理函数，返回时结束事件流（客户端断开时 ctx 被取消）
+type SSEStreamFunc func(ctx context.Context, w *SSEWriter) error
+
+// SSEWriter SSE 写入器（并发安全）
+type SSEWriter struct {
+	c     *gin.Context
+	mutex sync.Mutex
+}
+
+// NewSSEWriter 创建 SSE 写入器并写入事件流响应头
+func NewSSEWriter(c *gin.Context) *SSEWriter {
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no") // 禁用 Nginx 缓冲
+	c.Writer.WriteHeader(http.StatusOK)
+	c.Writer.Flush()
+	return &SSEWriter{c: c}
+}
+
+// LastEventID 客户端重连时携带的最后事件ID（优先 Last-Event-ID 请求头，其次 lastEventId 查询参数）
+func (w *SSEWriter) LastEventID() string {
+	if id := w.c.GetHeader("Last-Event-ID"); id != "" {
+		return id
+	}
+	return w.c.Query("lastEventId")
+}
+
+// Send 发送事件
+func (w *SSEWriter) Send(event SSEEvent) error {
+	var buf strings.Builder
+	if event.ID != "" {
+		fmt.Fprintf(&buf, "id: %s\n", sanitizeSSEField(event.ID))
+	}
+	if event.Event != "" {
+		fmt.Fprintf(&buf, "event: %s\n", sanitizeSSEField(event.Event))
+	}
+	if event.Retry > 0 {
+		fmt.Fprintf(&buf, "retry: %d\n", event.Retry.Milliseconds())
+	}
+
+	data, err := encodeSSEData(event.Data)
+	if err != nil {
+		return err
+	}
+	// 多行数据逐行写入 data 字段
+	for _, line := range strings.Split(data, "\n") {
+		fmt.Fprintf(&buf, "data: %s\n", strings.TrimSuffix(line, "\r"))
+	}
+	buf.WriteString("\n")
+	return w.write(buf.String())
+}
+
+// Data 发送仅包含数据的事件
+func (w *SSEWriter) Data(data interface{}) error {
+	return w.Send(SSEEvent{Data: data})
+}
+
+// Retry 设置客户端重连间隔
+func (w *SSEWriter) Retry(retry time.Duration) error {
+	return w.write(fmt.Sprintf("retry: %d\n\n", retry.Milliseconds()))
+}
+
+// Comment 发送注释（客户端忽略，用作心跳保持连接）
+func (w *SSEWriter) Comment(text string) error {
+	return w.write(": " + sanitizeSSEField(text) + "\n\n")
+}
+
+// write 写入并立即刷新
+func (w *SSEWriter) write(s string) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if err := w.c.Request.Context().Err(); err != nil {
+		return err
+	}
+	if _, err := w.c.Writer.WriteString(s); err != nil {
+		return err
+	}
+	w.c.Writer.Flush()
+	return nil
+}
+
+// SSE 创建 SSE 路由处理器
+// - heartbeat > 0 时定期发送注释心跳，防止代理因空闲断开连接
+// - retry > 0 时在连接建立后告知客户端重连间隔
+// - stream 返回非 context 取消错误时发送 error 事件
+func SSE(heartbeat time.Duration, retry time.Duration, stream SSEStreamFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+
+		w := NewSSEWriter(c)
+		if retry > 0 {
+			if err := w.Retry(retry); err != nil {
+				return
+			}
+		}
+
+		var wg sync.WaitGroup
+		if heartbeat > 0 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ticker := time.NewTicker(heartbeat)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						if err := w.Comment("ping"); err != nil {
+							cancel()
+							return
+						}
+					}
+				}
+			}()
+		}
+
+		err := stream(ctx, w)
+		cancel()
+		wg.Wait()
+
+		if err != nil && !errors.Is(err, context.Canceled) {
+			logging.FromContext(c.Request.Context()).Warn("sse stream failed", slog.Any("error", err))
+			_ = w.Send(SSEEvent{Event: "error", Data: err.Error()})
+		}
+	}
+}
+
+// encodeSSEData 编码事件数据
+func encodeSSEData(data interface{}) (string, error) {
+	switch v := data.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode sse data: %w", err)
+		}
+		return string(encoded), nil
+	}
+}
+
+// sanitizeSSEField 移除单行字段中的换行符，防止注入额外字段
+func sanitizeSSEField(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
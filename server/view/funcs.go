@@ -0,0 +1,43 @@
+package view
+
+import (
+	"encoding/json"
+	"html/template"
+	"time"
+)
+
+// DefaultFuncs 默认模板函数
+// - safeHTML、safeURL：输出不转义的 HTML、URL（仅用于可信内容）
+// - json：序列化为 JSON（用于内联脚本数据）
+// - formatTime：按格式输出时间，零值输出空字符串（默认 2006-01-02 15:04:05）
+// - default：值为空时使用默认值
+// - add：整数相加（用于序号）
+func DefaultFuncs() template.FuncMap {
+	return template.FuncMap{
+		"safeHTML": func(s string) template.HTML { return template.HTML(s) },
+		"safeURL":  func(s string) template.URL { return template.URL(s) },
+		"json": func(v interface{}) (template.JS, error) {
+			data, err := json.Marshal(v)
+			return template.JS(data), err
+		},
+		"formatTime": func(t time.Time, layout ...string) string {
+			if t.IsZero() {
+				return ""
+			}
+			if len(layout) > 0 {
+				return t.Format(layout[0])
+			}
+			return t.Format(time.DateTime)
+		},
+		"default": func(fallback interface{}, value interface{}) interface{} {
+			if value == nil {
+				return fallback
+			}
+			if s, ok := value.(string); ok && s == "" {
+				return fallback
+			}
+			return value
+		},
+		"add": func(a int, b int) int { return a + b },
+	}
+}
@@ -0,0 +1,268 @@
+package view
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+)
+
+// ContentBlock 页面正文模板名称，页面定义该模板时套用布局渲染
+const ContentBlock = "content"
+
+// Option 模板引擎可选项
+type Option func(*Engine)
+
+// WithFuncs 添加模板函数（与默认函数同名时覆盖）
+func WithFuncs(funcs template.FuncMap) Option {
+	return func(e *Engine) {
+		for name, fn := range funcs {
+			e.funcs[name] = fn
+		}
+	}
+}
+
+// WithLayout 指定默认布局（例如 layouts/base.html，为空时不使用布局）
+func WithLayout(layout string) Option {
+	return func(e *Engine) { e.layout = layout }
+}
+
+// WithPatterns 指定模板文件的匹配规则（fs.Glob 语法，默认加载全部 .html 文件）
+func WithPatterns(patterns ...string) Option {
+	return func(e *Engine) { e.patterns = patterns }
+}
+
+// WithShared 指定公共模板目录（布局与片段，每个页面均可引用，默认 layouts、partials）
+func WithShared(dirs ...string) Option {
+	return func(e *Engine) { e.shared = dirs }
+}
+
+// WithReload 每次渲染前重新加载模板（开发时修改模板无需重启）
+func WithReload(reload bool) Option {
+	return func(e *Engine) { e.reload = reload }
+}
+
+// Engine HTML 模板引擎
+// - 模板名称为相对于文件系统根目录的路径（例如 emails/preview.html）
+// - 公共目录中的模板（布局、片段）解析到每个页面中
+// - 页面定义 {{define "content"}} 时套用布局渲染，布局中通过 {{template "content" .}} 输出正文；否则直接渲染页面
+type Engine struct {
+	fsys     fs.FS
+	patterns []string
+	shared   []string
+	layout   string
+	reload   bool
+
+	mu        sync.RWMutex
+	funcs     template.FuncMap
+	templates map[string]*template.Template
+}
+
+// New 创建模板引擎并加载模板（fsys 可以是 embed.FS 或 os.DirFS）
+func New(fsys fs.FS, opts ...Option) (*Engine, error) {
+	e := &Engine{
+		fsys:   fsys,
+		shared: []string{"layouts", "partials"},
+		funcs:  DefaultFuncs(),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if err := e.Load(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Funcs 添加模板函数并重新加载模板
+func (e *Engine) Funcs(funcs template.FuncMap) error {
+	e.mu.Lock()
+	for name, fn := range funcs {
+		e.funcs[name] = fn
+	}
+	e.mu.Unlock()
+	return e.Load()
+}
+
+// Load 加载模板
+func (e *Engine) Load() error {
+	files, err := e.files()
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// 公共模板
+	base := template.New("").Funcs(e.funcs)
+	var pages []string
+	for _, file := range files {
+		if !e.isShared(file) {
+			pages = append(pages, file)
+			continue
+		}
+		if err := e.parse(base, file); err != nil {
+			return err
+		}
+	}
+
+	// 每个页面独立解析，避免不同页面的同名 content 模板互相覆盖
+	templates := make(map[string]*template.Template, len(pages))
+	for _, page := range pages {
+		t, err := base.Clone()
+		if err != nil {
+			return fmt.Errorf("clone templates: %w", err)
+		}
+		if err := e.parse(t, page); err != nil {
+			return err
+		}
+		templates[page] = t
+	}
+	e.templates = templates
+	return nil
+}
+
+// Names 已加载的页面模板名称
+func (e *Engine) Names() []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	names := make([]string, 0, len(e.templates))
+	for name := range e.templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Render 渲染页面（使用默认布局）
+func (e *Engine) Render(w io.Writer, name string, data interface{}) error {
+	return e.RenderLayout(w, e.layout, name, data)
+}
+
+// RenderLayout 使用指定布局渲染页面（layout 为空时不使用布局）
+func (e *Engine) RenderLayout(w io.Writer, layout string, name string, data interface{}) error {
+	if e.reload {
+		if err := e.Load(); err != nil {
+			return err
+		}
+	}
+
+	e.mu.RLock()
+	t, ok := e.templates[name]
+	e.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("template %s not found", name)
+	}
+
+	entry := name
+	if layout != "" && t.Lookup(ContentBlock) != nil {
+		if t.Lookup(layout) == nil {
+			return fmt.Errorf("layout %s not found", layout)
+		}
+		entry = layout
+	}
+	// 先渲染到缓冲区，出错时不输出不完整的页面
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, entry, data); err != nil {
+		return err
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// Instance 实现 gin 的 HTMLRender，c.HTML 使用默认布局渲染
+func (e *Engine) Instance(name string, data interface{}) render.Render {
+	return &htmlRender{engine: e, layout: e.layout, name: name, data: data}
+}
+
+// HTML 使用默认布局输出页面
+func (e *Engine) HTML(c *gin.Context, status int, name string, data interface{}) {
+	c.Render(status, e.Instance(name, data))
+}
+
+// HTMLLayout 使用指定布局输出页面（layout 为空时不使用布局）
+func (e *Engine) HTMLLayout(c *gin.Context, status int, layout string, name string, data interface{}) {
+	c.Render(status, &htmlRender{engine: e, layout: layout, name: name, data: data})
+}
+
+// files 获取模板文件列表
+func (e *Engine) files() ([]string, error) {
+	var files []string
+	if len(e.patterns) > 0 {
+		for _, pattern := range e.patterns {
+			matches, err := fs.Glob(e.fsys, pattern)
+			if err != nil {
+				return nil, fmt.Errorf("glob templates %s: %w", pattern, err)
+			}
+			files = append(files, matches...)
+		}
+	} else {
+		err := fs.WalkDir(e.fsys, ".", func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && path.Ext(name) == ".html" {
+				files = append(files, name)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("walk templates: %w", err)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// isShared 是否为公共模板
+func (e *Engine) isShared(file string) bool {
+	for _, dir := range e.shared {
+		if strings.HasPrefix(file, strings.TrimSuffix(dir, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// parse 以文件路径为名称解析模板
+func (e *Engine) parse(t *template.Template, file string) error {
+	data, err := fs.ReadFile(e.fsys, file)
+	if err != nil {
+		return fmt.Errorf("read template %s: %w", file, err)
+	}
+	if _, err := t.New(file).Parse(string(data)); err != nil {
+		return fmt.Errorf("parse template %s: %w", file, err)
+	}
+	return nil
+}
+
+// htmlRender gin 渲染器
+type htmlRender struct {
+	engine *Engine
+	layout string
+	name   string
+	data   interface{}
+}
+
+// Render 输出页面
+func (r *htmlRender) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	return r.engine.RenderLayout(w, r.layout, r.name, r.data)
+}
+
+// WriteContentType 设置内容类型
+func (r *htmlRender) WriteContentType(w http.ResponseWriter) {
+	if header := w.Header(); header.Get("Content-Type") == "" {
+		header.Set("Content-Type", "text/html; charset=utf-8")
+	}
+}
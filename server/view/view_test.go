@@ -0,0 +1,179 @@
+package view
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+HTML 模板引擎测试
+
+本文件用于测试基于 html/template 的模板引擎，
+包括公共模板（布局、片段）、页面独立解析、布局选择、模板函数、热加载与 gin 渲染。
+
+运行命令：
+go test -v -run "^TestEngine.*$"
+
+测试内容：
+1. 布局与片段 (Render, RenderLayout)
+2. 模板函数 (DefaultFuncs, WithFuncs, Funcs)
+3. 匹配规则与热加载 (WithPatterns, WithReload)
+4. 错误处理（模板不存在、布局不存在、解析失败）
+5. gin 渲染 (Instance, HTML, HTMLLayout)
+*/
+
+// newTestFS 创建测试模板
+func newTestFS() fstest.MapFS {
+	return fstest.MapFS{
+		"layouts/base.html":   {Data: []byte(`<main>{{template "partials/nav.html" .}}{{template "content" .}}</main>`)},
+		"layouts/plain.html":  {Data: []byte(`<div>{{template "content" .}}</div>`)},
+		"partials/nav.html":   {Data: []byte(`<nav>{{.Title}}</nav>`)},
+		"index.html":          {Data: []byte(`{{define "content"}}<h1>{{.Title}}</h1>{{end}}`)},
+		"users/list.html":     {Data: []byte(`{{define "content"}}{{range $i, $u := .Users}}{{add $i 1}}.{{$u}} {{end}}{{end}}`)},
+		"emails/preview.html": {Data: []byte(`<html>{{.Title | upper}} {{formatTime .At}} {{default "guest" .Name}}</html>`)},
+		"notes.txt":           {Data: []byte(`not a template`)},
+	}
+}
+
+// renderString 渲染并返回结果
+func renderString(t *testing.T, e *Engine, layout string, name string, data interface{}) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := e.RenderLayout(&buf, layout, name, data); err != nil {
+		t.Fatalf("Render %s failed: %v", name, err)
+	}
+	return buf.String()
+}
+
+func TestEngineRender(t *testing.T) {
+	upper := WithFuncs(template.FuncMap{"upper": strings.ToUpper})
+	e, err := New(newTestFS(), WithLayout("layouts/base.html"), upper)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if names := e.Names(); strings.Join(names, ",") != "emails/preview.html,index.html,users/list.html" {
+		t.Errorf("Unexpected pages: %v", names)
+	}
+
+	data := map[string]interface{}{"Title": "<Home>"}
+	var buf bytes.Buffer
+	if err := e.Render(&buf, "index.html", data); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if got := buf.String(); got != "<main><nav>&lt;Home&gt;</nav><h1>&lt;Home&gt;</h1></main>" {
+		t.Errorf("Unexpected default layout output: %s", got)
+	}
+	if got := renderString(t, e, "layouts/plain.html", "index.html", data); got != "<div><h1>&lt;Home&gt;</h1></div>" {
+		t.Errorf("Unexpected plain layout output: %s", got)
+	}
+	if got := renderString(t, e, "", "index.html", data); got != "" {
+		t.Errorf("Expected empty output without layout, got %s", got)
+	}
+	if got := renderString(t, e, "layouts/plain.html", "users/list.html", map[string]interface{}{"Users": []string{"a", "b"}}); got != "<div>1.a 2.b </div>" {
+		t.Errorf("Unexpected list output: %s", got)
+	}
+
+	// 未定义 content 的页面直接渲染，不套用布局
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	if got := renderString(t, e, "layouts/base.html", "emails/preview.html", map[string]interface{}{"Title": "hi", "At": at, "Name": ""}); got != "<html>HI 2024-01-02 03:04:05 guest</html>" {
+		t.Errorf("Unexpected standalone output: %s", got)
+	}
+}
+
+func TestEngineFuncsAndReload(t *testing.T) {
+	fsys := newTestFS()
+	fsys["emails/preview.html"] = &fstest.MapFile{Data: []byte(`{{greet .}}`)}
+
+	if _, err := New(fsys, WithPatterns("emails/*.html")); err == nil || !strings.Contains(err.Error(), `function "greet" not defined`) {
+		t.Fatalf("Expected undefined function error, got %v", err)
+	}
+
+	e, err := New(fsys, WithPatterns("emails/*.html"), WithFuncs(template.FuncMap{"greet": func(s string) string { return "hello " + s }}), WithReload(true))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if names := e.Names(); len(names) != 1 || names[0] != "emails/preview.html" {
+		t.Errorf("Unexpected pages with patterns: %v", names)
+	}
+	if got := renderString(t, e, "", "emails/preview.html", "bob"); got != "hello bob" {
+		t.Errorf("Unexpected output: %s", got)
+	}
+
+	if err := e.Funcs(template.FuncMap{"greet": func(s string) string { return "hi " + s }}); err != nil {
+		t.Fatalf("Funcs failed: %v", err)
+	}
+	fsys["emails/preview.html"] = &fstest.MapFile{Data: []byte(`{{greet .}}!`)}
+	if got := renderString(t, e, "", "emails/preview.html", "bob"); got != "hi bob!" {
+		t.Errorf("Expected reloaded output, got %s", got)
+	}
+}
+
+func TestEngineErrors(t *testing.T) {
+	fsys := newTestFS()
+	fsys["broken.html"] = &fstest.MapFile{Data: []byte(`{{if}}`)}
+	upper := WithFuncs(template.FuncMap{"upper": strings.ToUpper})
+	if _, err := New(fsys, upper); err == nil || !strings.Contains(err.Error(), "parse template broken.html") {
+		t.Errorf("Expected parse error, got %v", err)
+	}
+
+	e, err := New(newTestFS(), upper)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := e.RenderLayout(&buf, "", "missing.html", nil); err == nil || !strings.Contains(err.Error(), "template missing.html not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+	if err := e.RenderLayout(&buf, "layouts/missing.html", "index.html", nil); err == nil || !strings.Contains(err.Error(), "layout layouts/missing.html not found") {
+		t.Errorf("Expected layout not found error, got %v", err)
+	}
+	if err := e.RenderLayout(&buf, "layouts/base.html", "index.html", (*struct{ Title string })(nil)); err == nil {
+		t.Error("Expected execute error")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected no partial output, got %q", buf.String())
+	}
+}
+
+func TestEngineGin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	e, err := New(newTestFS(), WithLayout("layouts/base.html"), WithFuncs(template.FuncMap{"upper": strings.ToUpper}))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	engine := gin.New()
+	engine.HTMLRender = e
+	engine.GET("/default", func(c *gin.Context) { c.HTML(http.StatusOK, "index.html", gin.H{"Title": "a"}) })
+	engine.GET("/plain", func(c *gin.Context) {
+		e.HTMLLayout(c, http.StatusCreated, "layouts/plain.html", "index.html", gin.H{"Title": "b"})
+	})
+	engine.GET("/helper", func(c *gin.Context) { e.HTML(c, http.StatusOK, "index.html", gin.H{"Title": "c"}) })
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{path: "/default", status: http.StatusOK, body: "<main><nav>a</nav><h1>a</h1></main>"},
+		{path: "/plain", status: http.StatusCreated, body: "<div><h1>b</h1></div>"},
+		{path: "/helper", status: http.StatusOK, body: "<main><nav>c</nav><h1>c</h1></main>"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if w.Code != tt.status || w.Body.String() != tt.body {
+			t.Errorf("%s: expected %d %q, got %d %q", tt.path, tt.status, tt.body, w.Code, w.Body.String())
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "text/html; charset=utf-8" {
+			t.Errorf("%s: unexpected content type %q", tt.path, ct)
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/so68/core/config"
+	"github.com/so68/core/server/middleware"
+	"github.com/so68/core/server/utils"
+)
+
+const (
+	wsWriteWait      = 10 * time.Second    // 写消息超时
+	wsPongWait       = 60 * time.Second    // 等待 pong 超时
+	wsPingPeriod     = wsPongWait * 9 / 10 // 发送 ping 间隔（需小于 pongWait）
+	wsMaxMessageSize = 64 * 1024           // 客户端消息最大长度
+	wsSendBuffer     = 256                 // 单连接发送缓冲区大小
+)
+
+// MessageHandler 客户端消息处理函数
+type MessageHandler func(client *Client, message []byte)
+
+// Hub WebSocket 连接中心
+// - 维护所有连接及用户ID到连接的映射（同一用户可有多个连接）
+// - 发送缓冲区满的慢连接会被直接断开，避免阻塞广播
+type Hub struct {
+	logger   *slog.Logger
+	upgrader websocket.Upgrader
+	handler  MessageHandler
+
+	mutex   sync.RWMutex
+	clients map[*Client]struct{}
+	users   map[uint]map[*Client]struct{}
+	closed  bool
+}
+
+// Client WebSocket 连接
+type Client struct {
+	hub    *Hub
+	conn   *websocket.Conn
+	userID uint
+	send   chan []byte
+}
+
+// NewHub 创建 WebSocket 连接中心（跨域校验复用 cors.allowOrigins 配置）
+func NewHub(logger *slog.Logger, cfg *config.AppConfig) *Hub {
+	var allowOrigins []string
+	if cfg != nil && cfg.Cors != nil {
+		allowOrigins = cfg.Cors.AllowOrigins
+	}
+	return &Hub{
+		logger: logger,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin:     checkOrigin(allowOrigins),
+		},
+		clients: make(map[*Client]struct{}),
+		users:   make(map[uint]map[*Client]struct{}),
+	}
+}
+
+// NewWebSocketGroup 创建 WebSocket 路由组，连接需通过 JWT 认证（浏览器可使用 ?token= 传递令牌）
+// - GET relativePath 升级为 WebSocket 连接并注册到 hub
+// - 服务器关闭时自动关闭 hub 中的所有连接
+func NewWebSocketGroup(srv Server, relativePath string, hub *Hub, jwt *utils.JWT) *gin.RouterGroup {
+	group := srv.Middleware(srv.NewGroup(relativePath), middleware.NewJWTMiddleware(jwt))
+	group.GET("", hub.Handler)
+	srv.OnShutdown(hub.Close)
+	return group
+}
+
+// checkOrigin 根据允许的来源校验握手请求，未携带 Origin 的非浏览器请求直接放行
+func checkOrigin(allowOrigins []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" || len(allowOrigins) == 0 {
+			return true
+		}
+		return slices.Contains(allowOrigins, "*") || slices.Contains(allowOrigins, origin)
+	}
+}
+
+// OnMessage 设置客户端消息处理函数（需在建立连接前调用）
+func (h *Hub) OnMessage(handler MessageHandler) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.handler = handler
+}
+
+// Handler 升级 WebSocket 连接（用户ID取自认证中间件设置的上下文）
+func (h *Hub) Handler(c *gin.Context) {
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrade 失败时已写入错误响应
+		h.logger.Warn("websocket upgrade failed", slog.Any("error", err))
+		return
+	}
+
+	client := &Client{hub: h, conn: conn, userID: utils.GetContextUserID(c), send: make(chan []byte, wsSendBuffer)}
+	if !h.Register(client) {
+		_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, "server closing"), time.Now().Add(wsWriteWait))
+		_ = conn.Close()
+		return
+	}
+
+	go client.writePump()
+	go client.readPump()
+}
+
+// Register 注册连接，hub 已关闭时返回 false
+func (h *Hub) Register(client *Client) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.closed {
+		return false
+	}
+
+	h.clients[client] = struct{}{}
+	if h.users[client.userID] == nil {
+		h.users[client.userID] = make(map[*Client]struct{})
+	}
+	h.users[client.userID][client] = struct{}{}
+	h.logger.Debug("websocket client registered", slog.Uint64("user_id", uint64(client.userID)), slog.Int("clients", len(h.clients)))
+	return true
+}
+
+// Unregister 注销连接并关闭其发送通道（重复调用无副作用）
+func (h *Hub) Unregister(client *Client) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.remove(client)
+}
+
+// remove 移除连接（调用方需持有写锁）
+func (h *Hub) remove(client *Client) {
+	if _, ok := h.clients[client]; !ok {
+		return
+	}
+	delete(h.clients, client)
+	if conns := h.users[client.userID]; conns != nil {
+		delete(conns, client)
+		if len(conns) == 0 {
+			delete(h.users, client.userID)
+		}
+	}
+	close(client.send)
+	h.logger.Debug("websocket client unregistered", slog.Uint64("user_id", uint64(client.userID)), slog.Int("clients", len(h.clients)))
+}
+
+// Broadcast 向所有连接广播消息
+func (h *Hub) Broadcast(message []byte) {
+	h.mutex.RLock()
+	slow := make([]*Client, 0)
+	for client := range h.clients {
+		if !client.trySend(message) {
+			slow = append(slow, client)
+		}
+	}
+	h.mutex.RUnlock()
+	h.drop(slow)
+}
+
+// SendToUser 向指定用户的所有连接发送消息，返回成功投递的连接数
+func (h *Hub) SendToUser(userID uint, message []byte) int {
+	h.mutex.RLock()
+	sent := 0
+	slow := make([]*Client, 0)
+	for client := range h.users[userID] {
+		if client.trySend(message) {
+			sent++
+		} else {
+			slow = append(slow, client)
+		}
+	}
+	h.mutex.RUnlock()
+	h.drop(slow)
+	return sent
+}
+
+// BroadcastJSON 以 JSON 格式广播消息
+func (h *Hub) BroadcastJSON(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	h.Broadcast(data)
+	return nil
+}
+
+// SendJSONToUser 以 JSON 格式向指定用户发送消息，返回成功投递的连接数
+func (h *Hub) SendJSONToUser(userID uint, v interface{}) (int, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0, err
+	}
+	return h.SendToUser(userID, data), nil
+}
+
+// Online 判断用户是否在线
+func (h *Hub) Online(userID uint) bool {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	return len(h.users[userID]) > 0
+}
+
+// Count 当前连接数
+func (h *Hub) Count() int {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	return len(h.clients)
+}
+
+// Close 关闭所有连接，之后的连接请求将被拒绝
+func (h *Hub) Close() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.closed = true
+	for client := range h.clients {
+		h.remove(client)
+	}
+}
+
+// drop 断开发送缓冲区已满的慢连接
+func (h *Hub) drop(clients []*Client) {
+	for _, client := range clients {
+		h.logger.Warn("websocket client too slow, disconnecting", slog.Uint64("user_id", uint64(client.userID)))
+		h.Unregister(client)
+	}
+}
+
+// UserID 连接所属用户ID
+func (c *Client) UserID() uint {
+	return c.userID
+}
+
+// Send 向当前连接发送消息，发送缓冲区已满时返回 false
+func (c *Client) Send(message []byte) bool {
+	c.hub.mutex.RLock()
+	defer c.hub.mutex.RUnlock()
+	if _, ok := c.hub.clients[c]; !ok {
+		return false
+	}
+	return c.trySend(message)
+}
+
+// trySend 非阻塞写入发送通道（调用方需持有 hub 读锁，保证通道未关闭）
+func (c *Client) trySend(message []byte) bool {
+	select {
+	case c.send <- message:
+		return true
+	default:
+		return false
+	}
+}
+
+// readPump 读取客户端消息，连接断开时注销
+func (c *Client) readPump() {
+	defer func() {
+		c.hub.Unregister(c)
+		_ = c.conn.Close()
+	}()
+
+	c.conn.SetReadLimit(wsMaxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		_, message, err := c.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				c.hub.logger.Debug("websocket read failed", slog.Uint64("user_id", uint64(c.userID)), slog.Any("error", err))
+			}
+			return
+		}
+
+		c.hub.mutex.RLock()
+		handler := c.hub.handler
+		c.hub.mutex.RUnlock()
+		if handler != nil {
+			handler(c, message)
+		}
+	}
+}
+
+// writePump 将发送通道中的消息写入连接，并定期发送 ping 保活
+func (c *Client) writePump() {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer func() {
+		ticker.Stop()
+		_ = c.conn.Close()
+	}()
+
+	for {
+		select {
+		case message, ok := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if !ok {
+				// 发送通道已关闭（注销或 hub 关闭）
+				_ = c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, ""))
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
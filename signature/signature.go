@@ -0,0 +1,113 @@
+package signature
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 签名请求头
+const (
+	HeaderAppID     = "X-App-Id"    // 接入方 AppID
+	HeaderTimestamp = "X-Timestamp" // 请求时间戳（Unix 秒）
+	HeaderNonce     = "X-Nonce"     // 随机串，同一 AppID 在时间窗口内不可重复
+	HeaderSignature = "X-Signature" // 签名（HMAC-SHA256，十六进制小写）
+)
+
+// StringToSign 生成待签名字符串
+// 格式为以换行分隔的：请求方法、请求路径（含查询参数）、时间戳、随机串、请求体 SHA256（十六进制小写）
+func StringToSign(method, uri, timestamp, nonce string, body []byte) string {
+	digest := sha256.Sum256(body)
+	return strings.Join([]string{strings.ToUpper(method), uri, timestamp, nonce, hex.EncodeToString(digest[:])}, "\n")
+}
+
+// Sign 使用密钥计算签名
+func Sign(secret, method, uri, timestamp, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(StringToSign(method, uri, timestamp, nonce, body)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignRequest 为请求生成时间戳、随机串并写入签名请求头（读取请求体后会还原，可正常发送）
+func SignRequest(req *http.Request, appID, secret string) error {
+	body, err := readBody(req)
+	if err != nil {
+		return err
+	}
+	nonce, err := newNonce()
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(HeaderAppID, appID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderNonce, nonce)
+	req.Header.Set(HeaderSignature, Sign(secret, req.Method, req.URL.RequestURI(), timestamp, nonce, body))
+	return nil
+}
+
+// Transport 自动为请求签名的 http.RoundTripper，用于接入方客户端
+//
+//	client := &http.Client{Transport: &signature.Transport{AppID: "partner", Secret: "secret"}}
+type Transport struct {
+	AppID  string            // 接入方 AppID
+	Secret string            // 签名密钥
+	Base   http.RoundTripper // 底层传输，为空时使用 http.DefaultTransport
+}
+
+// RoundTrip 签名后发送请求（不修改原请求）
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	signed := req.Clone(req.Context())
+	if err := SignRequest(signed, t.AppID, t.Secret); err != nil {
+		return nil, err
+	}
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(signed)
+}
+
+// readBody 读取请求体并还原，优先使用 GetBody 以免消费原请求体
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	reader := req.Body
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("读取请求体失败: %w", err)
+		}
+		reader = body
+	}
+	defer reader.Close()
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("读取请求体失败: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, nil
+}
+
+// newNonce 生成随机串
+func newNonce() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("生成随机串失败: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
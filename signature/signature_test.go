@@ -0,0 +1,166 @@
+package signature
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/so68/core/cache"
+	"github.com/so68/core/config"
+)
+
+/*
+请求签名测试
+
+本文件用于测试开放接口 HMAC-SHA256 请求签名的客户端签名与服务端校验。
+
+运行命令：
+go test -v -run "^TestSignature.*$"
+
+测试内容：
+1. 客户端 Transport 签名后服务端校验通过，且请求体可继续读取 (Transport, Verify)
+2. 请求体、路径被篡改或密钥错误时签名校验失败
+3. 缺少请求头、未知接入方、时间戳过期、请求体过大
+4. 同一随机串重复使用时拒绝 (防重放)
+*/
+
+// newTestVerifier 创建使用内存缓存的签名校验
+func newTestVerifier(t *testing.T) *Verifier {
+	t.Helper()
+	cacheConfig := &config.CacheConfig{Driver: "memory", CleanupInterval: time.Minute}
+	cacheConfig.SetDefaults()
+	memory, err := cache.NewMemoryCache(cacheConfig, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("create memory cache failed: %v", err)
+	}
+	t.Cleanup(func() { memory.Close() })
+
+	cfg := config.DefaultSignatureConfig()
+	cfg.Enabled = true
+	cfg.MaxBodySize = 64
+	cfg.Clients = map[string]string{"partner": "secret"}
+	verifier, err := NewVerifier(cfg, memory)
+	if err != nil {
+		t.Fatalf("create verifier failed: %v", err)
+	}
+	return verifier
+}
+
+// signedRequest 创建已签名的请求
+func signedRequest(t *testing.T, appID, secret, body string) *http.Request {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/open/orders?page=1", strings.NewReader(body))
+	if err := SignRequest(req, appID, secret); err != nil {
+		t.Fatalf("sign request failed: %v", err)
+	}
+	return req
+}
+
+func TestSignatureTransport(t *testing.T) {
+	verifier := newTestVerifier(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		appID, err := verifier.Verify(r.Context(), r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Write([]byte(appID + ":" + string(body)))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &Transport{AppID: "partner", Secret: "secret"}}
+	resp, err := client.Post(server.URL+"/open/orders?page=1", "application/json", strings.NewReader(`{"id":1}`))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(body) != `partner:{"id":1}` {
+		t.Fatalf("unexpected response: %d %s", resp.StatusCode, body)
+	}
+}
+
+func TestSignatureVerify(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("篡改请求", func(t *testing.T) {
+		verifier := newTestVerifier(t)
+
+		req := signedRequest(t, "partner", "secret", `{"amount":1}`)
+		req.Body = io.NopCloser(strings.NewReader(`{"amount":100}`))
+		if _, err := verifier.Verify(ctx, req); !errors.Is(err, ErrInvalidSignature) {
+			t.Fatalf("expected ErrInvalidSignature for tampered body, got %v", err)
+		}
+
+		req = signedRequest(t, "partner", "secret", `{"amount":1}`)
+		req.URL.RawQuery = "page=2"
+		if _, err := verifier.Verify(ctx, req); !errors.Is(err, ErrInvalidSignature) {
+			t.Fatalf("expected ErrInvalidSignature for tampered query, got %v", err)
+		}
+
+		req = signedRequest(t, "partner", "wrong", `{"amount":1}`)
+		if _, err := verifier.Verify(ctx, req); !errors.Is(err, ErrInvalidSignature) {
+			t.Fatalf("expected ErrInvalidSignature for wrong secret, got %v", err)
+		}
+	})
+
+	t.Run("请求头与时间戳", func(t *testing.T) {
+		verifier := newTestVerifier(t)
+
+		req := signedRequest(t, "partner", "secret", "")
+		req.Header.Del(HeaderNonce)
+		if _, err := verifier.Verify(ctx, req); !errors.Is(err, ErrMissingHeaders) {
+			t.Fatalf("expected ErrMissingHeaders, got %v", err)
+		}
+
+		req = signedRequest(t, "unknown", "secret", "")
+		if _, err := verifier.Verify(ctx, req); !errors.Is(err, ErrUnknownApp) {
+			t.Fatalf("expected ErrUnknownApp, got %v", err)
+		}
+
+		// 时间戳超出时间窗口（签名有效）
+		timestamp := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+		req = httptest.NewRequest(http.MethodGet, "/open/orders", nil)
+		req.Header.Set(HeaderAppID, "partner")
+		req.Header.Set(HeaderTimestamp, timestamp)
+		req.Header.Set(HeaderNonce, "nonce-expired")
+		req.Header.Set(HeaderSignature, Sign("secret", http.MethodGet, "/open/orders", timestamp, "nonce-expired", nil))
+		if _, err := verifier.Verify(ctx, req); !errors.Is(err, ErrExpired) {
+			t.Fatalf("expected ErrExpired, got %v", err)
+		}
+
+		req = signedRequest(t, "partner", "secret", strings.Repeat("a", 65))
+		if _, err := verifier.Verify(ctx, req); !errors.Is(err, ErrBodyTooLarge) {
+			t.Fatalf("expected ErrBodyTooLarge, got %v", err)
+		}
+	})
+
+	t.Run("防重放", func(t *testing.T) {
+		verifier := newTestVerifier(t)
+
+		req := signedRequest(t, "partner", "secret", `{"id":1}`)
+		replay := req.Clone(ctx)
+		replay.Body = io.NopCloser(strings.NewReader(`{"id":1}`))
+
+		appID, err := verifier.Verify(ctx, req)
+		if err != nil || appID != "partner" {
+			t.Fatalf("expected verify success, got %q %v", appID, err)
+		}
+		body, _ := io.ReadAll(req.Body)
+		if string(body) != `{"id":1}` {
+			t.Fatalf("expected body to be restored, got %s", body)
+		}
+		if _, err := verifier.Verify(ctx, replay); !errors.Is(err, ErrReplay) {
+			t.Fatalf("expected ErrReplay, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package signature
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/so68/core/cache"
+	"github.com/so68/core/config"
+)
+
+// nonceKeyPrefix 已使用随机串缓存键前缀
+const nonceKeyPrefix = "signature:nonce:"
+
+var (
+	ErrMissingHeaders   = errors.New("缺少签名请求头")
+	ErrUnknownApp       = errors.New("未知的接入方")
+	ErrExpired          = errors.New("请求时间戳已过期")
+	ErrInvalidNonce     = errors.New("无效的请求随机串")
+	ErrBodyTooLarge     = errors.New("请求体过大")
+	ErrInvalidSignature = errors.New("请求签名错误")
+	ErrReplay           = errors.New("请求已被使用")
+)
+
+// Verifier 请求签名校验（随机串保存在缓存中，时间窗口内只能使用一次）
+type Verifier struct {
+	config *config.SignatureConfig
+	cache  cache.Cache
+	now    func() time.Time
+}
+
+// NewVerifier 根据配置创建请求签名校验
+func NewVerifier(cfg *config.SignatureConfig, cache cache.Cache) (*Verifier, error) {
+	if cache == nil {
+		return nil, fmt.Errorf("请求签名需要启用缓存")
+	}
+	return &Verifier{config: cfg, cache: cache, now: time.Now}, nil
+}
+
+// Verify 校验请求签名，成功时返回接入方 AppID（读取请求体后会还原，后续处理可正常读取）
+// 签名通过后才记录随机串，避免伪造请求占用合法随机串
+func (v *Verifier) Verify(ctx context.Context, req *http.Request) (string, error) {
+	appID := req.Header.Get(HeaderAppID)
+	timestamp := req.Header.Get(HeaderTimestamp)
+	nonce := req.Header.Get(HeaderNonce)
+	sign := req.Header.Get(HeaderSignature)
+	if appID == "" || timestamp == "" || nonce == "" || sign == "" {
+		return "", ErrMissingHeaders
+	}
+
+	secret, ok := v.config.Clients[appID]
+	if !ok || secret == "" {
+		return "", ErrUnknownApp
+	}
+
+	// 时间戳需在时间窗口内
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return "", ErrExpired
+	}
+	if diff := v.now().Sub(time.Unix(unix, 0)); diff > v.config.Window || diff < -v.config.Window {
+		return "", ErrExpired
+	}
+	if len(nonce) < 8 || len(nonce) > 64 {
+		return "", ErrInvalidNonce
+	}
+
+	body, err := v.readBody(req)
+	if err != nil {
+		return "", err
+	}
+	expected := Sign(secret, req.Method, req.URL.RequestURI(), timestamp, nonce, body)
+	if !hmac.Equal([]byte(expected), []byte(sign)) {
+		return "", ErrInvalidSignature
+	}
+
+	// 防重放：随机串首次使用时计数为 1，保留时长覆盖时间窗口的前后两侧
+	key := nonceKeyPrefix + appID + ":" + nonce
+	count, err := v.cache.Increment(ctx, key, 1)
+	if err != nil {
+		return "", fmt.Errorf("记录请求随机串失败: %w", err)
+	}
+	if count > 1 {
+		return "", ErrReplay
+	}
+	if err := v.cache.Expire(ctx, key, 2*v.config.Window); err != nil {
+		return "", fmt.Errorf("记录请求随机串失败: %w", err)
+	}
+	return appID, nil
+}
+
+// readBody 读取请求体（超过上限时拒绝）并还原
+func (v *Verifier) readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(req.Body, v.config.MaxBodySize+1))
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("读取请求体失败: %w", err)
+	}
+	if int64(len(body)) > v.config.MaxBodySize {
+		return nil, ErrBodyTooLarge
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
@@ -0,0 +1,162 @@
+package sms
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+// aliyunEndpoint 阿里云短信默认接口地址
+const aliyunEndpoint = "https://dysmsapi.aliyuncs.com"
+
+// AliyunSender 阿里云短信（RPC 接口，HMAC-SHA1 签名）
+type AliyunSender struct {
+	config   *config.SMSConfig
+	endpoint string
+	client   *http.Client
+	logger   *slog.Logger
+	now      func() time.Time
+}
+
+// aliyunResponse 阿里云短信接口响应
+type aliyunResponse struct {
+	Code      string `json:"Code"`
+	Message   string `json:"Message"`
+	RequestID string `json:"RequestId"`
+	BizID     string `json:"BizId"`
+}
+
+// NewAliyunSender 创建阿里云短信实例
+func NewAliyunSender(cfg *config.SMSConfig, logger *slog.Logger) (*AliyunSender, error) {
+	if cfg.AccessKeyID == "" || cfg.AccessKeySecret == "" {
+		return nil, errors.New("aliyun sms access key is required")
+	}
+	if cfg.SignName == "" {
+		return nil, errors.New("aliyun sms sign name is required")
+	}
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = aliyunEndpoint
+	}
+
+	logger.Info("Aliyun SMS sender initialized",
+		slog.String("endpoint", endpoint),
+		slog.String("sign_name", cfg.SignName),
+	)
+
+	return &AliyunSender{
+		config:   cfg,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   &http.Client{Timeout: cfg.Timeout},
+		logger:   logger,
+		now:      time.Now,
+	}, nil
+}
+
+// Send 发送短信（Template 为模板编号，Params 为模板变量）
+func (a *AliyunSender) Send(ctx context.Context, msg *Message) error {
+	if err := validateMessage(msg); err != nil {
+		return err
+	}
+	templateParam, err := json.Marshal(msg.Params)
+	if err != nil {
+		return fmt.Errorf("marshal sms template params failed: %w", err)
+	}
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	query.Set("AccessKeyId", a.config.AccessKeyID)
+	query.Set("Action", "SendSms")
+	query.Set("Format", "JSON")
+	query.Set("PhoneNumbers", aliyunPhone(msg.To))
+	query.Set("RegionId", a.config.Region)
+	query.Set("SignName", a.config.SignName)
+	query.Set("SignatureMethod", "HMAC-SHA1")
+	query.Set("SignatureNonce", hex.EncodeToString(nonce))
+	query.Set("SignatureVersion", "1.0")
+	query.Set("TemplateCode", msg.Template)
+	query.Set("TemplateParam", string(templateParam))
+	query.Set("Timestamp", a.now().UTC().Format("2006-01-02T15:04:05Z"))
+	query.Set("Version", "2017-05-25")
+	canonical := aliyunCanonicalQuery(query)
+	signed := canonical + "&Signature=" + aliyunPercentEncode(a.signature(http.MethodGet, canonical))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.endpoint+"/?"+signed, nil)
+	if err != nil {
+		return fmt.Errorf("create sms request failed: %w", err)
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send sms failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	result := &aliyunResponse{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("send sms failed: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if result.Code != "OK" {
+		return fmt.Errorf("send sms failed: %s: %s (request id %s)", result.Code, result.Message, result.RequestID)
+	}
+
+	a.logger.Info("SMS sent", slog.String("to", msg.To), slog.String("template", msg.Template), slog.String("biz_id", result.BizID))
+	return nil
+}
+
+// signature 计算签名: Base64(HMAC-SHA1(AccessKeySecret+"&", Method&%2F&percentEncode(CanonicalizedQuery)))
+func (a *AliyunSender) signature(method string, canonical string) string {
+	stringToSign := method + "&" + aliyunPercentEncode("/") + "&" + aliyunPercentEncode(canonical)
+	mac := hmac.New(sha1.New, []byte(a.config.AccessKeySecret+"&"))
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// aliyunPhone 转换为阿里云号码格式（国内号码不带国家码，国际号码为 00+国家码+号码）
+func aliyunPhone(phone string) string {
+	if national, ok := strings.CutPrefix(phone, "+86"); ok {
+		return national
+	}
+	if international, ok := strings.CutPrefix(phone, "+"); ok {
+		return "00" + international
+	}
+	return phone
+}
+
+// aliyunCanonicalQuery 按参数名排序并编码的查询字符串
+func aliyunCanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, aliyunPercentEncode(key)+"="+aliyunPercentEncode(query.Get(key)))
+	}
+	return strings.Join(parts, "&")
+}
+
+// aliyunPercentEncode 按阿里云规则编码（空格为 %20，* 为 %2A，~ 不编码）
+func aliyunPercentEncode(s string) string {
+	encoded := url.QueryEscape(s)
+	return strings.NewReplacer("+", "%20", "*", "%2A", "%7E", "~").Replace(encoded)
+}
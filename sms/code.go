@@ -0,0 +1,166 @@
+package sms
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/so68/core/cache"
+	"github.com/so68/core/config"
+	coreerrors "github.com/so68/core/errors"
+)
+
+// 短信验证码缓存键前缀
+const (
+	codeKeyPrefix     = "sms:code:"     // 验证码
+	attemptsKeyPrefix = "sms:attempts:" // 校验次数
+	intervalKeyPrefix = "sms:interval:" // 发送间隔
+	dailyKeyPrefix    = "sms:daily:"    // 每日发送次数
+)
+
+// 短信验证码错误
+var (
+	ErrInvalidPhone = coreerrors.Validation("手机号格式错误")
+	ErrTooFrequent  = coreerrors.Validation("短信发送过于频繁, 请稍后再试")
+	ErrDailyLimit   = coreerrors.Validation("今日短信发送次数已达上限")
+)
+
+// phonePattern 手机号格式（可带 + 号国家码）
+var phonePattern = regexp.MustCompile(`^\+?[0-9]{6,20}$`)
+
+// CodeManager 短信验证码管理（验证码保存在缓存中，校验通过后失效）
+// - scene 区分验证码用途（如 login、mfa、reset），不同用途的验证码互不通用
+// - 同一手机号按发送间隔与每日上限限流，验证码超过最多校验次数后失效
+type CodeManager struct {
+	config *config.SMSCodeConfig
+	sender Sender
+	cache  cache.Cache
+	logger *slog.Logger
+}
+
+// NewCodeManager 创建短信验证码管理
+func NewCodeManager(cfg *config.SMSCodeConfig, sender Sender, cache cache.Cache, logger *slog.Logger) (*CodeManager, error) {
+	if sender == nil {
+		return nil, fmt.Errorf("短信验证码需要启用短信")
+	}
+	if cache == nil {
+		return nil, fmt.Errorf("短信验证码需要启用缓存")
+	}
+	if cfg == nil {
+		cfg = config.DefaultSMSCodeConfig()
+	}
+	return &CodeManager{config: cfg, sender: sender, cache: cache, logger: logger}, nil
+}
+
+// Send 生成并发送验证码
+func (m *CodeManager) Send(ctx context.Context, scene string, phone string) error {
+	if !phonePattern.MatchString(phone) {
+		return ErrInvalidPhone
+	}
+
+	// 发送间隔：键存在时说明间隔内已发送过
+	intervalKey := intervalKeyPrefix + scene + ":" + phone
+	if exists, err := m.cache.Exists(ctx, intervalKey); err != nil {
+		return fmt.Errorf("检查短信发送间隔失败: %w", err)
+	} else if exists {
+		return ErrTooFrequent
+	}
+	// 每日上限：按手机号统计所有用途
+	if m.config.DailyLimit > 0 {
+		dailyKey := dailyKeyPrefix + phone + ":" + time.Now().Format("20060102")
+		count, err := m.cache.Increment(ctx, dailyKey, 1)
+		if err != nil {
+			return fmt.Errorf("统计短信发送次数失败: %w", err)
+		}
+		if count == 1 {
+			if err := m.cache.Expire(ctx, dailyKey, 24*time.Hour); err != nil {
+				m.logger.Warn("设置短信发送次数过期时间失败", "error", err)
+			}
+		}
+		if count > int64(m.config.DailyLimit) {
+			return ErrDailyLimit
+		}
+	}
+
+	code, err := generateCode(m.config.Length)
+	if err != nil {
+		return fmt.Errorf("生成短信验证码失败: %w", err)
+	}
+	key := m.key(scene, phone)
+	if err := m.cache.Set(ctx, key, code, m.config.TTL); err != nil {
+		return fmt.Errorf("保存短信验证码失败: %w", err)
+	}
+	if err := m.cache.Delete(ctx, attemptsKeyPrefix+scene+":"+phone); err != nil {
+		m.logger.Warn("重置短信验证码校验次数失败", "error", err)
+	}
+	if err := m.cache.Set(ctx, intervalKey, 1, m.config.Interval); err != nil {
+		m.logger.Warn("保存短信发送间隔失败", "error", err)
+	}
+
+	if err := m.sender.Send(ctx, &Message{To: phone, Template: m.config.Template, Params: map[string]string{"code": code}}); err != nil {
+		// 发送失败时删除验证码与发送间隔，允许立即重试
+		_ = m.cache.MDelete(ctx, key, intervalKey)
+		return fmt.Errorf("发送短信验证码失败: %w", err)
+	}
+	return nil
+}
+
+// Verify 校验验证码（通过后失效，连续失败超过最多校验次数后失效）
+func (m *CodeManager) Verify(ctx context.Context, scene string, phone string, input string) bool {
+	if phone == "" || input == "" {
+		return false
+	}
+	key := m.key(scene, phone)
+	code, err := m.cache.Get(ctx, key)
+	if err != nil || code == "" {
+		return false
+	}
+
+	attemptsKey := attemptsKeyPrefix + scene + ":" + phone
+	attempts, err := m.cache.Increment(ctx, attemptsKey, 1)
+	if err != nil {
+		m.logger.Warn("统计短信验证码校验次数失败", "error", err)
+		return false
+	}
+	if attempts == 1 {
+		if err := m.cache.Expire(ctx, attemptsKey, m.config.TTL); err != nil {
+			m.logger.Warn("设置短信验证码校验次数过期时间失败", "error", err)
+		}
+	}
+	if attempts > int64(m.config.MaxAttempts) {
+		_ = m.cache.MDelete(ctx, key, attemptsKey)
+		return false
+	}
+
+	if subtle.ConstantTimeCompare([]byte(code), []byte(strings.TrimSpace(input))) != 1 {
+		return false
+	}
+	if err := m.cache.MDelete(ctx, key, attemptsKey); err != nil {
+		m.logger.Warn("删除短信验证码失败", "error", err)
+	}
+	return true
+}
+
+// key 验证码缓存键
+func (m *CodeManager) key(scene string, phone string) string {
+	return codeKeyPrefix + scene + ":" + phone
+}
+
+// generateCode 生成数字验证码
+func generateCode(length int) (string, error) {
+	var b strings.Builder
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(byte('0' + n.Int64()))
+	}
+	return b.String(), nil
+}
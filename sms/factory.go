@@ -0,0 +1,59 @@
+package sms
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/so68/core/config"
+)
+
+// Factory 短信工厂
+type Factory struct {
+	logger *slog.Logger
+}
+
+// NewFactory 创建短信工厂
+func NewFactory(logger *slog.Logger) *Factory {
+	return &Factory{
+		logger: logger,
+	}
+}
+
+// CreateSender 根据配置创建短信发送器
+func (f *Factory) CreateSender(cfg *config.SMSConfig) (Sender, error) {
+	switch cfg.Driver {
+	case "aliyun":
+		return NewAliyunSender(cfg, f.logger)
+	case "twilio":
+		return NewTwilioSender(cfg, f.logger)
+	case "log":
+		return NewLogSender(cfg, f.logger)
+	default:
+		return nil, fmt.Errorf("unsupported sms driver: %s", cfg.Driver)
+	}
+}
+
+// validateMessage 校验短信消息
+func validateMessage(msg *Message) error {
+	if msg == nil {
+		return errors.New("sms message is nil")
+	}
+	if msg.To == "" {
+		return errors.New("sms recipient is required")
+	}
+	if msg.Template == "" {
+		return errors.New("sms template is required")
+	}
+	return nil
+}
+
+// render 替换模板中的 ${name} 参数
+func render(template string, params map[string]string) string {
+	pairs := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		pairs = append(pairs, "${"+name+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(template)
+}
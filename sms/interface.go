@@ -0,0 +1,18 @@
+package sms
+
+import (
+	"context"
+)
+
+// Message 短信消息
+type Message struct {
+	To       string            // 接收手机号（国际号码使用 E.164 格式，例如 +8613800138000）
+	Template string            // 短信模板（阿里云为模板编号，其他驱动为短信内容，${name} 替换为对应参数）
+	Params   map[string]string // 模板参数
+}
+
+// Sender 短信发送接口
+type Sender interface {
+	// 发送短信
+	Send(ctx context.Context, msg *Message) error
+}
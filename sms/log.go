@@ -0,0 +1,34 @@
+package sms
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/so68/core/config"
+)
+
+// LogSender 仅记录日志的短信实现（开发环境使用）
+type LogSender struct {
+	config *config.SMSConfig
+	logger *slog.Logger
+}
+
+// NewLogSender 创建日志短信实例
+func NewLogSender(cfg *config.SMSConfig, logger *slog.Logger) (*LogSender, error) {
+	return &LogSender{
+		config: cfg,
+		logger: logger,
+	}, nil
+}
+
+// Send 记录短信内容
+func (l *LogSender) Send(ctx context.Context, msg *Message) error {
+	if err := validateMessage(msg); err != nil {
+		return err
+	}
+	l.logger.Info("SMS sent (log driver)",
+		slog.String("to", msg.To),
+		slog.String("content", render(msg.Template, msg.Params)),
+	)
+	return nil
+}
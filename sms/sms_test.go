@@ -0,0 +1,242 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/so68/core/cache"
+	"github.com/so68/core/config"
+)
+
+/*
+短信功能测试
+
+本文件用于测试短信发送相关的功能特性，
+包括短信工厂、阿里云签名、Twilio 请求、验证码发送限流与校验等。
+
+运行命令：
+go test -v -run "^Test.*SMS.*$"
+
+测试内容：
+1. 短信工厂 (CreateSender)
+2. 阿里云短信签名与请求 (AliyunSender)
+3. Twilio 短信请求 (TwilioSender)
+4. 短信验证码发送、限流与校验 (CodeManager)
+*/
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestSMSFactory(t *testing.T) {
+	factory := NewFactory(testLogger())
+
+	tests := []struct {
+		name    string
+		cfg     *config.SMSConfig
+		wantErr bool
+	}{
+		{name: "日志驱动", cfg: &config.SMSConfig{Driver: "log"}},
+		{name: "阿里云", cfg: &config.SMSConfig{Driver: "aliyun", AccessKeyID: "id", AccessKeySecret: "secret", SignName: "sign"}},
+		{name: "Twilio", cfg: &config.SMSConfig{Driver: "twilio", AccountSID: "AC1", AuthToken: "token", From: "+15005550006"}},
+		{name: "阿里云缺少签名", cfg: &config.SMSConfig{Driver: "aliyun", AccessKeyID: "id", AccessKeySecret: "secret"}, wantErr: true},
+		{name: "不支持的驱动", cfg: &config.SMSConfig{Driver: "unknown"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := factory.CreateSender(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateSender() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAliyunSMSSignature(t *testing.T) {
+	// 阿里云短信接口文档中的签名示例
+	sender := &AliyunSender{config: &config.SMSConfig{AccessKeySecret: "testSecret"}}
+	query := url.Values{}
+	query.Set("AccessKeyId", "testId")
+	query.Set("Action", "SendSms")
+	query.Set("Format", "XML")
+	query.Set("OutId", "123")
+	query.Set("PhoneNumbers", "15300000001")
+	query.Set("RegionId", "cn-hangzhou")
+	query.Set("SignName", "阿里云短信测试专用")
+	query.Set("SignatureMethod", "HMAC-SHA1")
+	query.Set("SignatureNonce", "45e25e9b-0a6f-4070-8c85-2956eda1b466")
+	query.Set("SignatureVersion", "1.0")
+	query.Set("TemplateCode", "SMS_71390007")
+	query.Set("TemplateParam", `{"customer":"test"}`)
+	query.Set("Timestamp", "2017-07-12T02:42:19Z")
+	query.Set("Version", "2017-05-25")
+
+	if got := sender.signature(http.MethodGet, aliyunCanonicalQuery(query)); got != "zJDF+Lrzhj/ThnlvIToysFRq6t4=" {
+		t.Errorf("signature() = %s", got)
+	}
+}
+
+func TestAliyunSMSSend(t *testing.T) {
+	var got url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+		if got.Get("PhoneNumbers") == "13800000000" {
+			io.WriteString(w, `{"Code":"OK","Message":"OK","BizId":"1"}`)
+			return
+		}
+		io.WriteString(w, `{"Code":"isv.MOBILE_NUMBER_ILLEGAL","Message":"invalid","RequestId":"r"}`)
+	}))
+	defer server.Close()
+
+	sender, err := NewAliyunSender(&config.SMSConfig{Endpoint: server.URL, AccessKeyID: "id", AccessKeySecret: "secret", SignName: "sign", Region: "cn-hangzhou"}, testLogger())
+	if err != nil {
+		t.Fatalf("NewAliyunSender() error = %v", err)
+	}
+
+	msg := &Message{To: "+8613800000000", Template: "SMS_1", Params: map[string]string{"code": "123456"}}
+	if err := sender.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if got.Get("TemplateCode") != "SMS_1" || got.Get("TemplateParam") != `{"code":"123456"}` || got.Get("Signature") == "" {
+		t.Errorf("request query = %v", got)
+	}
+
+	msg.To = "+15005550006"
+	if err := sender.Send(context.Background(), msg); err == nil || !strings.Contains(err.Error(), "isv.MOBILE_NUMBER_ILLEGAL") {
+		t.Errorf("Send() error = %v, want provider error", err)
+	}
+	if got.Get("PhoneNumbers") != "0015005550006" {
+		t.Errorf("international PhoneNumbers = %s", got.Get("PhoneNumbers"))
+	}
+}
+
+func TestTwilioSMSSend(t *testing.T) {
+	var path, body, user, pass string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		path, body = r.URL.Path, r.PostForm.Get("Body")
+		user, pass, _ = r.BasicAuth()
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"sid":"SM1"}`)
+	}))
+	defer server.Close()
+
+	sender, err := NewTwilioSender(&config.SMSConfig{Endpoint: server.URL, AccountSID: "AC1", AuthToken: "token", From: "+15005550006"}, testLogger())
+	if err != nil {
+		t.Fatalf("NewTwilioSender() error = %v", err)
+	}
+	if err := sender.Send(context.Background(), &Message{To: "+15005550001", Template: "code ${code}", Params: map[string]string{"code": "1234"}}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if path != "/2010-04-01/Accounts/AC1/Messages.json" || body != "code 1234" || user != "AC1" || pass != "token" {
+		t.Errorf("request path = %s, body = %s, auth = %s:%s", path, body, user, pass)
+	}
+}
+
+// recordSender 记录发送的短信
+type recordSender struct {
+	mu       sync.Mutex
+	messages []*Message
+	err      error
+}
+
+func (r *recordSender) Send(ctx context.Context, msg *Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	r.messages = append(r.messages, msg)
+	return nil
+}
+
+func (r *recordSender) lastCode() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.messages[len(r.messages)-1].Params["code"]
+}
+
+func TestSMSCodeManager(t *testing.T) {
+	ctx := context.Background()
+	cacheConfig := &config.CacheConfig{Driver: "memory", CleanupInterval: time.Minute}
+	cacheConfig.SetDefaults()
+	memory, err := cache.NewMemoryCache(cacheConfig, testLogger())
+	if err != nil {
+		t.Fatalf("create memory cache failed: %v", err)
+	}
+	defer memory.Close()
+
+	sender := &recordSender{}
+	codeConfig := config.DefaultSMSCodeConfig()
+	codeConfig.DailyLimit, codeConfig.MaxAttempts = 2, 2
+	manager, err := NewCodeManager(codeConfig, sender, memory, testLogger())
+	if err != nil {
+		t.Fatalf("NewCodeManager() error = %v", err)
+	}
+
+	phone := "+8613800000000"
+	if err := manager.Send(ctx, "login", "abc"); !errors.Is(err, ErrInvalidPhone) {
+		t.Errorf("Send() invalid phone error = %v", err)
+	}
+	if err := manager.Send(ctx, "login", phone); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	code := sender.lastCode()
+	if len(code) != 6 {
+		t.Fatalf("code = %q, want 6 digits", code)
+	}
+	if err := manager.Send(ctx, "login", phone); !errors.Is(err, ErrTooFrequent) {
+		t.Errorf("Send() within interval error = %v, want ErrTooFrequent", err)
+	}
+
+	if manager.Verify(ctx, "mfa", phone, code) {
+		t.Error("Verify() should not accept code of another scene")
+	}
+	if !manager.Verify(ctx, "login", phone, code) {
+		t.Fatal("Verify() should accept correct code")
+	}
+	if manager.Verify(ctx, "login", phone, code) {
+		t.Error("Verify() code should be invalid after use")
+	}
+
+	// 超过最多校验次数后验证码失效
+	if err := manager.Send(ctx, "mfa", phone); err != nil {
+		t.Fatalf("Send() mfa error = %v", err)
+	}
+	code = sender.lastCode()
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+	manager.Verify(ctx, "mfa", phone, wrong)
+	manager.Verify(ctx, "mfa", phone, wrong)
+	if manager.Verify(ctx, "mfa", phone, code) {
+		t.Error("Verify() should reject code after too many attempts")
+	}
+
+	// 每日上限按手机号统计
+	if err := manager.Send(ctx, "reset", phone); !errors.Is(err, ErrDailyLimit) {
+		t.Errorf("Send() over daily limit error = %v, want ErrDailyLimit", err)
+	}
+
+	// 发送失败时允许立即重试
+	sender.err = errors.New("provider down")
+	other := "+8613900000000"
+	if err := manager.Send(ctx, "login", other); err == nil {
+		t.Fatal("Send() should return sender error")
+	}
+	sender.err = nil
+	if err := manager.Send(ctx, "login", other); err != nil {
+		t.Errorf("Send() retry after failure error = %v", err)
+	}
+}
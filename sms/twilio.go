@@ -0,0 +1,97 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/so68/core/config"
+)
+
+// twilioEndpoint Twilio 默认接口地址
+const twilioEndpoint = "https://api.twilio.com"
+
+// TwilioSender Twilio 短信
+type TwilioSender struct {
+	config   *config.SMSConfig
+	endpoint string
+	client   *http.Client
+	logger   *slog.Logger
+}
+
+// twilioResponse Twilio 接口响应
+type twilioResponse struct {
+	SID     string `json:"sid"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewTwilioSender 创建 Twilio 短信实例
+func NewTwilioSender(cfg *config.SMSConfig, logger *slog.Logger) (*TwilioSender, error) {
+	if cfg.AccountSID == "" || cfg.AuthToken == "" {
+		return nil, errors.New("twilio account sid and auth token are required")
+	}
+	if cfg.From == "" {
+		return nil, errors.New("twilio from number is required")
+	}
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = twilioEndpoint
+	}
+
+	logger.Info("Twilio SMS sender initialized",
+		slog.String("endpoint", endpoint),
+		slog.String("from", cfg.From),
+	)
+
+	return &TwilioSender{
+		config:   cfg,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   &http.Client{Timeout: cfg.Timeout},
+		logger:   logger,
+	}, nil
+}
+
+// Send 发送短信（Template 为短信内容，${name} 替换为 Params 中的参数）
+func (t *TwilioSender) Send(ctx context.Context, msg *Message) error {
+	if err := validateMessage(msg); err != nil {
+		return err
+	}
+
+	form := url.Values{}
+	form.Set("To", msg.To)
+	form.Set("From", t.config.From)
+	form.Set("Body", render(msg.Template, msg.Params))
+	apiURL := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", t.endpoint, url.PathEscape(t.config.AccountSID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("create sms request failed: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(t.config.AccountSID, t.config.AuthToken)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send sms failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	result := &twilioResponse{}
+	_ = json.Unmarshal(body, result)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if result.Message != "" {
+			return fmt.Errorf("send sms failed: %d: %s", result.Code, result.Message)
+		}
+		return fmt.Errorf("send sms failed: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	t.logger.Info("SMS sent", slog.String("to", msg.To), slog.String("sid", result.SID))
+	return nil
+}
@@ -0,0 +1,270 @@
+package storage
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/so68/core/config"
+	coreerrors "github.com/so68/core/errors"
+)
+
+// 分片上传错误
+var (
+	ErrUploadNotFound  = coreerrors.NotFound("分片上传不存在或已过期")
+	ErrChunkInvalid    = coreerrors.Validation("分片序号或大小不正确")
+	ErrChunkIncomplete = coreerrors.Validation("分片未全部上传")
+)
+
+const (
+	chunkMetaFile   = "meta.json" // 分片上传信息文件
+	chunkFileSuffix = ".part"     // 分片文件后缀
+)
+
+// uploadIDPattern 分片上传ID格式（防止路径穿越）
+var uploadIDPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// ChunkUpload 分片上传信息
+type ChunkUpload struct {
+	ID        string    `json:"id"`         // 上传ID
+	Owner     string    `json:"owner"`      // 上传者（仅上传者可继续上传）
+	Name      string    `json:"name"`       // 原始文件名
+	Size      int64     `json:"size"`       // 文件大小
+	ChunkSize int64     `json:"chunk_size"` // 分片大小
+	Chunks    int       `json:"chunks"`     // 分片数量
+	CreatedAt time.Time `json:"created_at"` // 创建时间
+}
+
+// ChunkLength 指定分片的字节数（最后一片为剩余部分）
+func (u *ChunkUpload) ChunkLength(index int) int64 {
+	if index == u.Chunks-1 {
+		return u.Size - int64(u.Chunks-1)*u.ChunkSize
+	}
+	return u.ChunkSize
+}
+
+// ChunkStore 分片上传临时存储
+// - 每个上传一个目录：meta.json 记录上传信息，分片按序号保存为 <index>.part
+// - 目录超过保留时间未更新时视为过期，在创建新上传时清理
+type ChunkStore struct {
+	dir       string
+	chunkSize int64
+	expire    time.Duration
+	logger    *slog.Logger
+}
+
+// NewChunkStore 创建分片上传临时存储
+func NewChunkStore(cfg *config.UploadConfig, logger *slog.Logger) (*ChunkStore, error) {
+	dir := cfg.ChunkDir
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "upload-chunks")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("create chunk directory failed: %w", err)
+	}
+	return &ChunkStore{dir: dir, chunkSize: cfg.ChunkSize, expire: cfg.ChunkExpire, logger: logger}, nil
+}
+
+// Init 创建或恢复分片上传，返回上传信息与已上传的分片序号
+// - identifier 为客户端提供的文件标识（如文件哈希），相同上传者、文件名、大小与标识时恢复之前的上传
+// - identifier 为空时总是创建新的上传
+func (s *ChunkStore) Init(owner string, name string, size int64, identifier string) (*ChunkUpload, []int, error) {
+	s.cleanup()
+
+	id, err := s.uploadID(owner, name, size, identifier)
+	if err != nil {
+		return nil, nil, err
+	}
+	if upload, err := s.Get(owner, id); err == nil {
+		uploaded, err := s.Uploaded(upload)
+		if err != nil {
+			return nil, nil, err
+		}
+		return upload, uploaded, nil
+	}
+
+	chunks := int((size + s.chunkSize - 1) / s.chunkSize)
+	if chunks == 0 {
+		chunks = 1
+	}
+	upload := &ChunkUpload{ID: id, Owner: owner, Name: name, Size: size, ChunkSize: s.chunkSize, Chunks: chunks, CreatedAt: time.Now()}
+	if err := os.MkdirAll(s.path(id), 0700); err != nil {
+		return nil, nil, fmt.Errorf("create upload directory failed: %w", err)
+	}
+	data, err := json.Marshal(upload)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := os.WriteFile(filepath.Join(s.path(id), chunkMetaFile), data, 0600); err != nil {
+		return nil, nil, fmt.Errorf("write upload meta failed: %w", err)
+	}
+	return upload, []int{}, nil
+}
+
+// Get 获取分片上传信息（不存在、已过期或不属于上传者时返回 ErrUploadNotFound）
+func (s *ChunkStore) Get(owner string, id string) (*ChunkUpload, error) {
+	if !uploadIDPattern.MatchString(id) {
+		return nil, ErrUploadNotFound
+	}
+	info, err := os.Stat(s.path(id))
+	if err != nil || s.expired(info) {
+		return nil, ErrUploadNotFound
+	}
+	data, err := os.ReadFile(filepath.Join(s.path(id), chunkMetaFile))
+	if err != nil {
+		return nil, ErrUploadNotFound
+	}
+	upload := &ChunkUpload{}
+	if err := json.Unmarshal(data, upload); err != nil || upload.Owner != owner {
+		return nil, ErrUploadNotFound
+	}
+	return upload, nil
+}
+
+// Uploaded 已上传的分片序号（升序）
+func (s *ChunkStore) Uploaded(upload *ChunkUpload) ([]int, error) {
+	uploaded := make([]int, 0, upload.Chunks)
+	for index := 0; index < upload.Chunks; index++ {
+		info, err := os.Stat(s.chunkPath(upload.ID, index))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if info.Size() == upload.ChunkLength(index) {
+			uploaded = append(uploaded, index)
+		}
+	}
+	return uploaded, nil
+}
+
+// Save 保存分片（重复上传同一分片时覆盖）
+func (s *ChunkStore) Save(upload *ChunkUpload, index int, r io.Reader, size int64) error {
+	if index < 0 || index >= upload.Chunks || size != upload.ChunkLength(index) {
+		return ErrChunkInvalid
+	}
+
+	tmp, err := os.CreateTemp(s.path(upload.ID), ".chunk-*")
+	if err != nil {
+		return fmt.Errorf("create chunk failed: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	written, err := io.Copy(tmp, io.LimitReader(r, size+1))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("write chunk failed: %w", err)
+	}
+	if written != size {
+		return ErrChunkInvalid
+	}
+	if err := os.Rename(tmp.Name(), s.chunkPath(upload.ID, index)); err != nil {
+		return fmt.Errorf("save chunk failed: %w", err)
+	}
+	return nil
+}
+
+// Merge 按序合并全部分片，返回读取位置在开头的临时文件（调用方负责关闭，删除上传时一并删除）
+func (s *ChunkStore) Merge(upload *ChunkUpload) (*os.File, error) {
+	uploaded, err := s.Uploaded(upload)
+	if err != nil {
+		return nil, err
+	}
+	if len(uploaded) != upload.Chunks {
+		return nil, ErrChunkIncomplete
+	}
+
+	merged, err := os.CreateTemp(s.path(upload.ID), ".merged-*")
+	if err != nil {
+		return nil, fmt.Errorf("create merged file failed: %w", err)
+	}
+	for index := 0; index < upload.Chunks; index++ {
+		if err := appendFile(merged, s.chunkPath(upload.ID, index)); err != nil {
+			merged.Close()
+			os.Remove(merged.Name())
+			return nil, fmt.Errorf("merge chunk %d failed: %w", index, err)
+		}
+	}
+	if _, err := merged.Seek(0, io.SeekStart); err != nil {
+		merged.Close()
+		os.Remove(merged.Name())
+		return nil, err
+	}
+	return merged, nil
+}
+
+// Remove 删除分片上传
+func (s *ChunkStore) Remove(upload *ChunkUpload) error {
+	return os.RemoveAll(s.path(upload.ID))
+}
+
+// uploadID 生成上传ID（提供文件标识时由上传参数计算，便于断点续传）
+func (s *ChunkStore) uploadID(owner string, name string, size int64, identifier string) (string, error) {
+	if identifier == "" {
+		buf := make([]byte, 16)
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(buf), nil
+	}
+	sum := sha256.Sum256([]byte(strings.Join([]string{owner, name, strconv.FormatInt(size, 10), identifier}, "\x00")))
+	return hex.EncodeToString(sum[:16]), nil
+}
+
+// cleanup 清理过期的分片上传
+func (s *ChunkStore) cleanup() {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || !uploadIDPattern.MatchString(entry.Name()) {
+			continue
+		}
+		if info, err := entry.Info(); err == nil && s.expired(info) {
+			if err := os.RemoveAll(s.path(entry.Name())); err != nil {
+				s.logger.Warn("清理过期分片上传失败", "upload_id", entry.Name(), "error", err)
+			}
+		}
+	}
+}
+
+// expired 上传目录是否已过期（按最后修改时间）
+func (s *ChunkStore) expired(info fs.FileInfo) bool {
+	return s.expire > 0 && time.Since(info.ModTime()) > s.expire
+}
+
+// path 上传目录
+func (s *ChunkStore) path(id string) string {
+	return filepath.Join(s.dir, id)
+}
+
+// chunkPath 分片文件路径
+func (s *ChunkStore) chunkPath(id string, index int) string {
+	return filepath.Join(s.path(id), strconv.Itoa(index)+chunkFileSuffix)
+}
+
+// appendFile 将文件内容追加到 dst
+func appendFile(dst io.Writer, name string) error {
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	_, err = io.Copy(dst, src)
+	return err
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/so68/core/config"
+)
+
+// Factory 文件存储工厂
+type Factory struct {
+	logger *slog.Logger
+}
+
+// NewFactory 创建文件存储工厂
+func NewFactory(logger *slog.Logger) *Factory {
+	return &Factory{
+		logger: logger,
+	}
+}
+
+// CreateStorage 根据配置创建文件存储
+func (f *Factory) CreateStorage(cfg *config.StorageConfig) (Storage, error) {
+	switch cfg.Driver {
+	case "local":
+		return NewLocalStorage(cfg, f.logger)
+	case "s3":
+		return NewS3Storage(cfg, f.logger)
+	case "oss":
+		return NewOSSStorage(cfg, f.logger)
+	default:
+		return nil, fmt.Errorf("unsupported storage driver: %s", cfg.Driver)
+	}
+}
+
+// cleanKey 规范化存储路径（去除开头的 /，拒绝越界路径）
+func cleanKey(key string) (string, error) {
+	cleaned := strings.TrimPrefix(path.Clean("/"+strings.ReplaceAll(key, "\\", "/")), "/")
+	if cleaned == "" || cleaned != strings.TrimPrefix(key, "/") {
+		return "", fmt.Errorf("storage: invalid key %q", key)
+	}
+	return cleaned, nil
+}
+
+// contentTypeOf 按扩展名推断内容类型
+func contentTypeOf(key string, contentType string) string {
+	if contentType != "" {
+		return contentType
+	}
+	if byExt := mime.TypeByExtension(path.Ext(key)); byExt != "" {
+		return byExt
+	}
+	return "application/octet-stream"
+}
+
+// joinURL 拼接访问地址前缀与存储路径
+func joinURL(baseURL string, key string) string {
+	return strings.TrimSuffix(baseURL, "/") + "/" + key
+}
+
+// sizedBody 对象存储上传需要内容长度，长度未知时读入内存
+func sizedBody(body io.Reader, size int64) (io.Reader, int64, error) {
+	if size < 0 {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, 0, fmt.Errorf("storage: read body failed: %w", err)
+		}
+		body, size = bytes.NewReader(data), int64(len(data))
+	}
+	if size == 0 {
+		return http.NoBody, 0, nil
+	}
+	return body, size, nil
+}
@@ -0,0 +1,304 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"sync"
+
+	"github.com/so68/core/config"
+)
+
+// ImageEncoder 图片编码器
+type ImageEncoder struct {
+	ContentType string                                                // 内容类型
+	Ext         string                                                // 扩展名（含点）
+	Encode      func(w io.Writer, img image.Image, quality int) error // 编码（quality 为 1-100，无损格式忽略）
+}
+
+var (
+	imageEncodersMu sync.RWMutex
+	imageEncoders   = map[string]*ImageEncoder{
+		"jpeg": {ContentType: "image/jpeg", Ext: ".jpg", Encode: func(w io.Writer, img image.Image, quality int) error {
+			return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+		}},
+		"png": {ContentType: "image/png", Ext: ".png", Encode: func(w io.Writer, img image.Image, _ int) error {
+			return png.Encode(w, img)
+		}},
+	}
+)
+
+// imageFormats 可处理的内容类型及其格式（其他格式如 webp 标准库无法解码，不处理）
+var imageFormats = map[string]string{
+	"image/jpeg": "jpeg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+// RegisterImageEncoder 注册图片编码器（标准库不支持 WebP 编码，转换为 webp 前须注册第三方编码器）
+func RegisterImageEncoder(format string, encoder *ImageEncoder) {
+	imageEncodersMu.Lock()
+	defer imageEncodersMu.Unlock()
+	imageEncoders[format] = encoder
+}
+
+// lookupImageEncoder 查找图片编码器
+func lookupImageEncoder(format string) (*ImageEncoder, error) {
+	imageEncodersMu.RLock()
+	defer imageEncodersMu.RUnlock()
+	encoder, ok := imageEncoders[format]
+	if !ok {
+		return nil, fmt.Errorf("storage: image encoder %s is not registered", format)
+	}
+	return encoder, nil
+}
+
+// ImageVariant 处理后的图片
+type ImageVariant struct {
+	Name        string // 缩略图名称（处理后的原图为空）
+	Data        []byte // 图片内容
+	ContentType string // 内容类型
+	Ext         string // 扩展名（含点）
+	Width       int    // 宽度
+	Height      int    // 高度
+}
+
+// ProcessImage 按配置处理上传的图片
+// - 去除元数据或转换格式时按 EXIF 方向旋转后重新编码原图，否则返回的原图为 nil（保持不变）
+// - 缩略图等比缩放至配置的宽高范围内（不放大），GIF 缩略图取第一帧并保存为 PNG
+// - 无法解码的格式（如 webp）不处理
+func ProcessImage(data []byte, contentType string, cfg *config.ImageProcessConfig) (*ImageVariant, []*ImageVariant, error) {
+	format, ok := imageFormats[contentType]
+	if cfg == nil || !ok {
+		return nil, nil, nil
+	}
+	reencode := (cfg.StripExif && format != "gif") || (cfg.Format != "" && cfg.Format != format)
+	if !reencode && len(cfg.Thumbnails) == 0 {
+		return nil, nil, nil
+	}
+
+	outputFormat := cfg.Format
+	if outputFormat == "" {
+		outputFormat = format
+		if format == "gif" {
+			outputFormat = "png"
+		}
+	}
+	encoder, err := lookupImageEncoder(outputFormat)
+	if err != nil {
+		return nil, nil, err
+	}
+	quality := cfg.Quality
+	if quality <= 0 || quality > 100 {
+		quality = 85
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, ErrImageCorrupted
+	}
+	if format == "jpeg" {
+		img = orient(img, exifOrientation(data))
+	}
+
+	var main *ImageVariant
+	if reencode {
+		if main, err = encodeImage("", img, encoder, quality); err != nil {
+			return nil, nil, err
+		}
+	}
+	thumbnails := make([]*ImageVariant, 0, len(cfg.Thumbnails))
+	for _, thumbnail := range cfg.Thumbnails {
+		width, height := fitSize(img.Bounds().Dx(), img.Bounds().Dy(), thumbnail.Width, thumbnail.Height)
+		variant, err := encodeImage(thumbnail.Name, resize(img, width, height), encoder, quality)
+		if err != nil {
+			return nil, nil, err
+		}
+		thumbnails = append(thumbnails, variant)
+	}
+	return main, thumbnails, nil
+}
+
+// encodeImage 编码图片（JPEG 不支持透明，透明区域填充白色）
+func encodeImage(name string, img image.Image, encoder *ImageEncoder, quality int) (*ImageVariant, error) {
+	if encoder.ContentType == "image/jpeg" {
+		if opaque, ok := img.(interface{ Opaque() bool }); !ok || !opaque.Opaque() {
+			flattened := image.NewRGBA(img.Bounds())
+			draw.Draw(flattened, flattened.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+			draw.Draw(flattened, flattened.Bounds(), img, img.Bounds().Min, draw.Over)
+			img = flattened
+		}
+	}
+	var buf bytes.Buffer
+	if err := encoder.Encode(&buf, img, quality); err != nil {
+		return nil, fmt.Errorf("storage: encode image failed: %w", err)
+	}
+	return &ImageVariant{
+		Name:        name,
+		Data:        buf.Bytes(),
+		ContentType: encoder.ContentType,
+		Ext:         encoder.Ext,
+		Width:       img.Bounds().Dx(),
+		Height:      img.Bounds().Dy(),
+	}, nil
+}
+
+// fitSize 等比缩放至最大宽高范围内的尺寸（0 表示不限制该方向，不放大）
+func fitSize(width, height, maxWidth, maxHeight int) (int, int) {
+	scale := 1.0
+	if maxWidth > 0 && width > maxWidth {
+		scale = float64(maxWidth) / float64(width)
+	}
+	if maxHeight > 0 && float64(height)*scale > float64(maxHeight) {
+		scale = float64(maxHeight) / float64(height)
+	}
+	return max(1, int(float64(width)*scale+0.5)), max(1, int(float64(height)*scale+0.5))
+}
+
+// toRGBA 转换为原点为 (0, 0) 的 RGBA 图片
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok && rgba.Bounds().Min == (image.Point{}) {
+		return rgba
+	}
+	rgba := image.NewRGBA(image.Rect(0, 0, img.Bounds().Dx(), img.Bounds().Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, img.Bounds().Min, draw.Src)
+	return rgba
+}
+
+// resize 缩小图片（区域平均，每个目标像素取对应源区域的平均值）
+func resize(img image.Image, width, height int) *image.RGBA {
+	src := toRGBA(img)
+	srcWidth, srcHeight := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := y*srcHeight/height, max((y+1)*srcHeight/height, y*srcHeight/height+1)
+		for x := 0; x < width; x++ {
+			x0, x1 := x*srcWidth/width, max((x+1)*srcWidth/width, x*srcWidth/width+1)
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				offset := sy*src.Stride + x0*4
+				for sx := x0; sx < x1; sx++ {
+					for i := 0; i < 4; i++ {
+						sum[i] += int(src.Pix[offset+i])
+					}
+					offset += 4
+				}
+			}
+			count := (y1 - y0) * (x1 - x0)
+			offset := y*dst.Stride + x*4
+			for i := 0; i < 4; i++ {
+				dst.Pix[offset+i] = uint8(sum[i] / count)
+			}
+		}
+	}
+	return dst
+}
+
+// orient 按 EXIF 方向（1-8）旋转或翻转图片
+func orient(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+	src := toRGBA(img)
+	width, height := src.Bounds().Dx(), src.Bounds().Dy()
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		for x := 0; x < dstWidth; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // 水平翻转
+				sx, sy = width-1-x, y
+			case 3: // 旋转 180°
+				sx, sy = width-1-x, height-1-y
+			case 4: // 垂直翻转
+				sx, sy = x, height-1-y
+			case 5: // 沿主对角线翻转
+				sx, sy = y, x
+			case 6: // 顺时针旋转 90°
+				sx, sy = y, height-1-x
+			case 7: // 沿副对角线翻转
+				sx, sy = width-1-y, height-1-x
+			case 8: // 逆时针旋转 90°
+				sx, sy = width-1-y, x
+			}
+			copy(dst.Pix[y*dst.Stride+x*4:y*dst.Stride+x*4+4], src.Pix[sy*src.Stride+sx*4:sy*src.Stride+sx*4+4])
+		}
+	}
+	return dst
+}
+
+// exifOrientation 读取 JPEG 的 EXIF 方向（未设置或无法解析时为 1）
+func exifOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		switch {
+		case marker == 0xFF: // 填充字节
+			i++
+			continue
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD8): // 无长度的标记
+			i += 2
+			continue
+		case marker == 0xDA || marker == 0xD9: // 图像数据开始或结束，之后不再有 EXIF
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		if segment := data[i+4 : i+2+length]; marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return tiffOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+// tiffOrientation 读取 TIFF 结构第一个 IFD 中的方向标签（0x0112）
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	offset := int64(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > int64(len(tiff)) {
+		return 1
+	}
+	count := int64(order.Uint16(tiff[offset:]))
+	for i := int64(0); i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > int64(len(tiff)) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			if orientation := int(order.Uint16(tiff[entry+8:])); orientation >= 1 && orientation <= 8 {
+				return orientation
+			}
+			return 1
+		}
+	}
+	return 1
+}
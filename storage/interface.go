@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrNotFound 文件不存在
+var ErrNotFound = errors.New("storage: object not found")
+
+// Storage 文件存储接口
+// - key 为存储路径（以 / 分隔，例如 uploads/2024/01/avatar.png），不能包含 .. 等越界路径
+type Storage interface {
+	// Put 保存文件（size 为内容长度，未知时传 -1；contentType 为空时按扩展名推断）
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+
+	// Get 读取文件，文件不存在时返回 ErrNotFound，调用方负责关闭
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete 删除文件（文件不存在时不返回错误）
+	Delete(ctx context.Context, key string) error
+
+	// URL 文件访问地址
+	URL(key string) string
+
+	// SignedURL 带签名的临时访问地址（私有存储桶使用，expires 为有效期）
+	SignedURL(ctx context.Context, key string, expires time.Duration) (string, error)
+}
@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+// LocalStorage 本地文件存储
+// - 文件通过静态文件路由对外访问，SignedURL 与 URL 相同
+type LocalStorage struct {
+	root    string
+	baseURL string
+	logger  *slog.Logger
+}
+
+// NewLocalStorage 创建本地文件存储
+func NewLocalStorage(cfg *config.StorageConfig, logger *slog.Logger) (*LocalStorage, error) {
+	if cfg.Root == "" {
+		return nil, errors.New("local storage root is required")
+	}
+	// 根目录在首次写入时创建，未使用上传功能时不在工作目录下留下空目录
+	logger.Info("Local storage initialized", slog.String("root", cfg.Root))
+
+	return &LocalStorage{
+		root:    cfg.Root,
+		baseURL: cfg.BaseURL,
+		logger:  logger,
+	}, nil
+}
+
+// Put 保存文件（先写入临时文件再重命名，避免读取到未写完的文件）
+func (s *LocalStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return fmt.Errorf("storage: create directory failed: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("storage: create file failed: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("storage: write file failed: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("storage: write file failed: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("storage: chmod file failed: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return fmt.Errorf("storage: save file failed: %w", err)
+	}
+	return nil
+}
+
+// Get 读取文件
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("storage: open file failed: %w", err)
+	}
+	return file, nil
+}
+
+// Delete 删除文件
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("storage: delete file failed: %w", err)
+	}
+	return nil
+}
+
+// URL 文件访问地址
+func (s *LocalStorage) URL(key string) string {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return ""
+	}
+	return joinURL(s.baseURL, cleaned)
+}
+
+// SignedURL 本地文件通过静态路由公开访问，返回与 URL 相同的地址
+func (s *LocalStorage) SignedURL(ctx context.Context, key string, expires time.Duration) (string, error) {
+	if _, err := cleanKey(key); err != nil {
+		return "", err
+	}
+	return s.URL(key), nil
+}
+
+// path 存储路径对应的本地文件路径
+func (s *LocalStorage) path(key string) (string, error) {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, filepath.FromSlash(cleaned)), nil
+}
@@ -0,0 +1,199 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+// OSSStorage 阿里云对象存储，使用 OSS 签名（HMAC-SHA1）
+type OSSStorage struct {
+	endpoint  *url.URL
+	bucket    string
+	accessKey string
+	secretKey string
+	baseURL   string
+	client    *http.Client
+	logger    *slog.Logger
+	now       func() time.Time
+}
+
+// NewOSSStorage 创建阿里云对象存储
+func NewOSSStorage(cfg *config.StorageConfig, logger *slog.Logger) (*OSSStorage, error) {
+	endpoint, err := parseEndpoint(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("OSS storage initialized",
+		slog.String("endpoint", endpoint.String()),
+		slog.String("bucket", cfg.Bucket),
+	)
+
+	return &OSSStorage{
+		endpoint:  endpoint,
+		bucket:    cfg.Bucket,
+		accessKey: cfg.AccessKeyID,
+		secretKey: cfg.AccessKeySecret,
+		baseURL:   cfg.BaseURL,
+		client:    &http.Client{Timeout: cfg.Timeout},
+		logger:    logger,
+		now:       time.Now,
+	}, nil
+}
+
+// Put 上传文件（长度未知时先读入内存）
+func (s *OSSStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	body, size, err := sizedBody(body, size)
+	if err != nil {
+		return err
+	}
+	req, err := s.newRequest(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentTypeOf(key, contentType))
+	resp, err := s.do(req, key)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Get 下载文件
+func (s *OSSStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req, key)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Delete 删除文件
+func (s *OSSStorage) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req, key)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// URL 文件访问地址（设置了 BaseURL 时使用 BaseURL，否则为存储桶地址）
+func (s *OSSStorage) URL(key string) string {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return ""
+	}
+	if s.baseURL != "" {
+		return joinURL(s.baseURL, cleaned)
+	}
+	return s.objectURL(cleaned).String()
+}
+
+// SignedURL 签名下载地址
+func (s *OSSStorage) SignedURL(ctx context.Context, key string, expires time.Duration) (string, error) {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	if expires <= 0 {
+		return "", fmt.Errorf("storage: invalid signed url expires %s", expires)
+	}
+
+	expiresAt := strconv.FormatInt(s.now().Add(expires).Unix(), 10)
+	objectURL := s.objectURL(cleaned)
+	query := url.Values{}
+	query.Set("OSSAccessKeyId", s.accessKey)
+	query.Set("Expires", expiresAt)
+	query.Set("Signature", s.signature(http.MethodGet, "", "", expiresAt, http.Header{}, cleaned))
+	objectURL.RawQuery = query.Encode()
+	return objectURL.String(), nil
+}
+
+// newRequest 创建对象请求
+func (s *OSSStorage) newRequest(ctx context.Context, method string, key string, body io.Reader) (*http.Request, error) {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.objectURL(cleaned).String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("storage: create request failed: %w", err)
+	}
+	return req, nil
+}
+
+// do 签名并发送请求，非 2xx 响应转换为错误
+func (s *OSSStorage) do(req *http.Request, key string) (*http.Response, error) {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+	date := s.now().UTC().Format(http.TimeFormat)
+	req.Header.Set("Date", date)
+	signature := s.signature(req.Method, req.Header.Get("Content-MD5"), req.Header.Get("Content-Type"), date, req.Header, cleaned)
+	req.Header.Set("Authorization", "OSS "+s.accessKey+":"+signature)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("storage: request failed: %w", err)
+	}
+	return checkResponse(resp)
+}
+
+// signature 计算签名
+// VERB \n Content-MD5 \n Content-Type \n Date(或 Expires) \n CanonicalizedOSSHeaders CanonicalizedResource
+func (s *OSSStorage) signature(method string, contentMD5 string, contentType string, date string, header http.Header, key string) string {
+	names := make([]string, 0)
+	for name := range header {
+		if lower := strings.ToLower(name); strings.HasPrefix(lower, "x-oss-") {
+			names = append(names, lower)
+		}
+	}
+	sort.Strings(names)
+	var ossHeaders strings.Builder
+	for _, name := range names {
+		ossHeaders.WriteString(name + ":" + strings.TrimSpace(header.Get(name)) + "\n")
+	}
+
+	stringToSign := method + "\n" + contentMD5 + "\n" + contentType + "\n" + date + "\n" + ossHeaders.String() + "/" + s.bucket + "/" + key
+	mac := hmac.New(sha1.New, []byte(s.secretKey))
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// objectURL 对象地址（虚拟主机风格：bucket.endpoint/key）
+func (s *OSSStorage) objectURL(key string) *url.URL {
+	objectURL := *s.endpoint
+	objectURL.Host = s.bucket + "." + s.endpoint.Host
+	objectURL.Path = "/" + key
+	objectURL.RawPath = escapePath(objectURL.Path)
+	return &objectURL
+}
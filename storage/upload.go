@@ -0,0 +1,177 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"image"
+	"io"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	_ "image/gif"  // 注册 GIF 解码器（读取图片尺寸）
+	_ "image/jpeg" // 注册 JPEG 解码器
+	_ "image/png"  // 注册 PNG 解码器
+
+	"github.com/so68/core/config"
+	coreerrors "github.com/so68/core/errors"
+)
+
+// 上传文件校验错误
+var (
+	ErrFileTooLarge   = coreerrors.Validation("文件大小超过限制")
+	ErrFileType       = coreerrors.Validation("不允许上传该类型的文件")
+	ErrFileMismatch   = coreerrors.Validation("文件内容与扩展名不符")
+	ErrImageTooLarge  = coreerrors.Validation("图片尺寸超过限制")
+	ErrImageCorrupted = coreerrors.Validation("图片文件已损坏")
+)
+
+// sniffLength 识别内容类型读取的字节数（见 http.DetectContentType）
+const sniffLength = 512
+
+// sniffTypes 可按内容识别的扩展名及其内容类型，文件内容须与扩展名一致
+var sniffTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+	".pdf":  "application/pdf",
+	".mp4":  "video/mp4",
+	".zip":  "application/zip",
+	".docx": "application/zip",
+	".xlsx": "application/zip",
+}
+
+// Scanner 病毒扫描钩子（返回错误时拒绝上传）
+type Scanner interface {
+	Scan(ctx context.Context, name string, r io.Reader) error
+}
+
+// ScannerFunc 函数形式的病毒扫描钩子
+type ScannerFunc func(ctx context.Context, name string, r io.Reader) error
+
+// Scan 扫描文件
+func (f ScannerFunc) Scan(ctx context.Context, name string, r io.Reader) error {
+	return f(ctx, name, r)
+}
+
+// UploadValidator 上传文件校验
+// - 扩展名白名单与文件大小在接收文件前校验（CheckName）
+// - 内容类型按文件内容识别，不信任客户端提交的 Content-Type
+// - 图片校验尺寸，设置了扫描钩子时最后执行病毒扫描
+type UploadValidator struct {
+	cfg     *config.UploadConfig
+	scanner atomic.Pointer[Scanner]
+}
+
+// NewUploadValidator 创建上传文件校验
+func NewUploadValidator(cfg *config.UploadConfig) *UploadValidator {
+	if cfg == nil {
+		cfg = config.DefaultUploadConfig()
+	}
+	return &UploadValidator{cfg: cfg}
+}
+
+// Config 上传配置
+func (v *UploadValidator) Config() *config.UploadConfig {
+	return v.cfg
+}
+
+// SetScanner 设置病毒扫描钩子（为 nil 时不扫描）
+func (v *UploadValidator) SetScanner(scanner Scanner) {
+	if scanner == nil {
+		v.scanner.Store(nil)
+		return
+	}
+	v.scanner.Store(&scanner)
+}
+
+// CheckName 校验文件扩展名与大小
+func (v *UploadValidator) CheckName(name string, size int64) error {
+	if size > v.cfg.MaxSize {
+		return ErrFileTooLarge
+	}
+	ext := strings.ToLower(filepath.Ext(name))
+	if ext == "" || !slices.ContainsFunc(v.cfg.AllowedExts, func(allowed string) bool { return strings.EqualFold(allowed, ext) }) {
+		return ErrFileType
+	}
+	return nil
+}
+
+// Validate 校验文件内容，返回识别出的内容类型（校验后文件读取位置回到开头）
+func (v *UploadValidator) Validate(ctx context.Context, name string, file io.ReadSeeker, size int64) (string, error) {
+	if err := v.CheckName(name, size); err != nil {
+		return "", err
+	}
+
+	header := make([]byte, sniffLength)
+	n, err := io.ReadFull(file, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	contentType, _, _ := strings.Cut(http.DetectContentType(header[:n]), ";")
+	if !v.allowedType(contentType) {
+		return "", ErrFileType
+	}
+	if expected, ok := sniffTypes[strings.ToLower(filepath.Ext(name))]; ok && expected != contentType {
+		return "", ErrFileMismatch
+	}
+
+	if strings.HasPrefix(contentType, "image/") {
+		if err := v.checkImage(file); err != nil {
+			return "", err
+		}
+	}
+
+	if scanner := v.scanner.Load(); scanner != nil {
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return "", err
+		}
+		if err := (*scanner).Scan(ctx, name, file); err != nil {
+			return "", coreerrors.Wrap(err, coreerrors.CodeValidation, "文件未通过安全扫描")
+		}
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return contentType, nil
+}
+
+// allowedType 内容类型是否在白名单中（支持 image/* 通配）
+func (v *UploadValidator) allowedType(contentType string) bool {
+	for _, allowed := range v.cfg.AllowedTypes {
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok {
+			if strings.HasPrefix(contentType, prefix+"/") {
+				return true
+			}
+		} else if strings.EqualFold(allowed, contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkImage 校验图片尺寸（只解码图片头，无法解码的格式如 webp 不校验尺寸）
+func (v *UploadValidator) checkImage(file io.ReadSeeker) error {
+	if v.cfg.MaxImageWidth <= 0 && v.cfg.MaxImageHeight <= 0 {
+		return nil
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	imageConfig, _, err := image.DecodeConfig(file)
+	if errors.Is(err, image.ErrFormat) {
+		return nil
+	}
+	if err != nil {
+		return ErrImageCorrupted
+	}
+	if (v.cfg.MaxImageWidth > 0 && imageConfig.Width > v.cfg.MaxImageWidth) || (v.cfg.MaxImageHeight > 0 && imageConfig.Height > v.cfg.MaxImageHeight) {
+		return ErrImageTooLarge
+	}
+	return nil
+}
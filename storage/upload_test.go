@@ -0,0 +1,263 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/so68/core/config"
+)
+
+/*
+上传文件测试
+
+本文件用于测试文件上传相关的功能特性，
+包括扩展名与内容类型校验、图片尺寸限制、病毒扫描钩子、分片上传与断点续传、图片处理等。
+
+运行命令：
+go test -v -run "^Test(UploadValidator|ChunkStore|ProcessImage).*$"
+
+测试内容：
+1. 上传文件校验 (UploadValidator)
+2. 分片上传临时存储 (ChunkStore)
+3. 图片处理 (ProcessImage)
+*/
+
+// pngData 生成指定尺寸的 PNG 图片
+func pngData(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestUploadValidator(t *testing.T) {
+	cfg := config.DefaultUploadConfig()
+	cfg.MaxSize = 1 << 20
+	cfg.MaxImageWidth = 100
+	cfg.MaxImageHeight = 100
+	validator := NewUploadValidator(cfg)
+
+	small := pngData(t, 10, 10)
+	large := pngData(t, 200, 10)
+	tests := []struct {
+		name     string
+		filename string
+		data     []byte
+		wantType string
+		wantErr  error
+	}{
+		{name: "图片", filename: "a.PNG", data: small, wantType: "image/png"},
+		{name: "文本", filename: "a.txt", data: []byte("hello"), wantType: "text/plain"},
+		{name: "扩展名不在白名单", filename: "a.exe", data: []byte("MZ"), wantErr: ErrFileType},
+		{name: "没有扩展名", filename: "a", data: []byte("hello"), wantErr: ErrFileType},
+		{name: "伪装成图片的网页", filename: "a.png", data: []byte("<html><script>alert(1)</script></html>"), wantErr: ErrFileType},
+		{name: "内容与扩展名不符", filename: "a.jpg", data: small, wantErr: ErrFileMismatch},
+		{name: "图片尺寸超过限制", filename: "a.png", data: large, wantErr: ErrImageTooLarge},
+		{name: "图片已损坏", filename: "a.png", data: small[:20], wantErr: ErrImageCorrupted},
+		{name: "文件过大", filename: "a.txt", data: bytes.Repeat([]byte("a"), 1<<20+1), wantErr: ErrFileTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := bytes.NewReader(tt.data)
+			contentType, err := validator.Validate(context.Background(), tt.filename, file, int64(len(tt.data)))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if contentType != tt.wantType {
+				t.Errorf("Validate() contentType = %s, want %s", contentType, tt.wantType)
+			}
+			if offset, _ := file.Seek(0, io.SeekCurrent); offset != 0 {
+				t.Errorf("Validate() should rewind file, offset = %d", offset)
+			}
+		})
+	}
+
+	t.Run("病毒扫描", func(t *testing.T) {
+		infected := errors.New("EICAR test signature")
+		validator.SetScanner(ScannerFunc(func(ctx context.Context, name string, r io.Reader) error {
+			data, _ := io.ReadAll(r)
+			if strings.Contains(string(data), "EICAR") {
+				return infected
+			}
+			return nil
+		}))
+		defer validator.SetScanner(nil)
+
+		if _, err := validator.Validate(context.Background(), "a.txt", strings.NewReader("clean"), 5); err != nil {
+			t.Fatalf("Validate() clean file error = %v", err)
+		}
+		if _, err := validator.Validate(context.Background(), "a.txt", strings.NewReader("EICAR"), 5); !errors.Is(err, infected) {
+			t.Fatalf("Validate() infected file error = %v", err)
+		}
+	})
+}
+
+func TestChunkStore(t *testing.T) {
+	cfg := &config.UploadConfig{ChunkSize: 4, ChunkDir: t.TempDir(), ChunkExpire: time.Hour}
+	store, err := NewChunkStore(cfg, testLogger())
+	if err != nil {
+		t.Fatalf("NewChunkStore() error = %v", err)
+	}
+
+	upload, uploaded, err := store.Init("1", "a.txt", 10, "hash")
+	if err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if upload.Chunks != 3 || len(uploaded) != 0 {
+		t.Fatalf("Init() chunks = %d, uploaded = %v", upload.Chunks, uploaded)
+	}
+
+	if err := store.Save(upload, 0, strings.NewReader("0123"), 4); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := store.Save(upload, 2, strings.NewReader("89"), 2); err != nil {
+		t.Fatalf("Save() last chunk error = %v", err)
+	}
+	if err := store.Save(upload, 1, strings.NewReader("45"), 2); !errors.Is(err, ErrChunkInvalid) {
+		t.Errorf("Save() short chunk error = %v, want ErrChunkInvalid", err)
+	}
+	if err := store.Save(upload, 3, strings.NewReader("xx"), 2); !errors.Is(err, ErrChunkInvalid) {
+		t.Errorf("Save() out of range error = %v, want ErrChunkInvalid", err)
+	}
+	if _, err := store.Merge(upload); !errors.Is(err, ErrChunkIncomplete) {
+		t.Errorf("Merge() incomplete error = %v, want ErrChunkIncomplete", err)
+	}
+
+	// 断点续传：相同参数恢复之前的上传
+	resumed, uploaded, err := store.Init("1", "a.txt", 10, "hash")
+	if err != nil || resumed.ID != upload.ID || len(uploaded) != 2 || uploaded[0] != 0 || uploaded[1] != 2 {
+		t.Fatalf("Init() resume = %v, uploaded = %v, error = %v", resumed, uploaded, err)
+	}
+	// 其他管理员无法访问
+	if _, err := store.Get("2", upload.ID); !errors.Is(err, ErrUploadNotFound) {
+		t.Errorf("Get() other owner error = %v, want ErrUploadNotFound", err)
+	}
+	if _, err := store.Get("1", "../../etc"); !errors.Is(err, ErrUploadNotFound) {
+		t.Errorf("Get() invalid id error = %v, want ErrUploadNotFound", err)
+	}
+
+	if err := store.Save(upload, 1, strings.NewReader("4567"), 4); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	merged, err := store.Merge(upload)
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	data, _ := io.ReadAll(merged)
+	merged.Close()
+	if string(data) != "0123456789" {
+		t.Errorf("Merge() = %q", data)
+	}
+
+	if err := store.Remove(upload); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := store.Get("1", upload.ID); !errors.Is(err, ErrUploadNotFound) {
+		t.Errorf("Get() after remove error = %v, want ErrUploadNotFound", err)
+	}
+}
+
+// jpegWithOrientation 生成带 EXIF 方向标签的 JPEG 图片
+func jpegWithOrientation(t *testing.T, width, height int, orientation uint16) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)), nil); err != nil {
+		t.Fatalf("jpeg.Encode() error = %v", err)
+	}
+	// TIFF 头 + 1 个 IFD 条目（方向，SHORT）
+	tiff := []byte("II*\x00\x08\x00\x00\x00\x01\x00\x12\x01\x03\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00")
+	binary.LittleEndian.PutUint16(tiff[18:], orientation)
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := append([]byte{0xFF, 0xE1, 0, 0}, segment...)
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(segment)+2))
+
+	data := buf.Bytes()
+	return append(append([]byte{0xFF, 0xD8}, app1...), data[2:]...)
+}
+
+func TestProcessImage(t *testing.T) {
+	t.Run("按方向旋转并去除EXIF", func(t *testing.T) {
+		data := jpegWithOrientation(t, 40, 20, 6)
+		if got := exifOrientation(data); got != 6 {
+			t.Fatalf("exifOrientation() = %d, want 6", got)
+		}
+		main, thumbnails, err := ProcessImage(data, "image/jpeg", &config.ImageProcessConfig{StripExif: true, Quality: 80})
+		if err != nil {
+			t.Fatalf("ProcessImage() error = %v", err)
+		}
+		if main == nil || main.Width != 20 || main.Height != 40 || len(thumbnails) != 0 {
+			t.Fatalf("ProcessImage() main = %+v, thumbnails = %d", main, len(thumbnails))
+		}
+		if bytes.Contains(main.Data, []byte("Exif")) {
+			t.Error("ProcessImage() should strip EXIF")
+		}
+	})
+
+	t.Run("缩略图与格式转换", func(t *testing.T) {
+		cfg := &config.ImageProcessConfig{Format: "jpeg", Thumbnails: []config.ThumbnailConfig{
+			{Name: "small", Width: 100, Height: 100},
+			{Name: "tiny", Height: 10},
+		}}
+		main, thumbnails, err := ProcessImage(pngData(t, 400, 200), "image/png", cfg)
+		if err != nil {
+			t.Fatalf("ProcessImage() error = %v", err)
+		}
+		if main == nil || main.ContentType != "image/jpeg" || main.Ext != ".jpg" {
+			t.Fatalf("ProcessImage() main = %+v", main)
+		}
+		if len(thumbnails) != 2 || thumbnails[0].Width != 100 || thumbnails[0].Height != 50 || thumbnails[1].Width != 20 || thumbnails[1].Height != 10 {
+			t.Fatalf("ProcessImage() thumbnails = %+v", thumbnails)
+		}
+		if _, format, err := image.Decode(bytes.NewReader(thumbnails[0].Data)); err != nil || format != "jpeg" {
+			t.Errorf("thumbnail format = %s, error = %v", format, err)
+		}
+	})
+
+	t.Run("仅生成缩略图时保持原图", func(t *testing.T) {
+		main, thumbnails, err := ProcessImage(pngData(t, 50, 50), "image/png", &config.ImageProcessConfig{Thumbnails: []config.ThumbnailConfig{{Name: "small", Width: 100}}})
+		if err != nil || main != nil || len(thumbnails) != 1 || thumbnails[0].Width != 50 {
+			t.Fatalf("ProcessImage() main = %+v, thumbnails = %+v, error = %v", main, thumbnails, err)
+		}
+	})
+
+	t.Run("未注册的编码器", func(t *testing.T) {
+		if _, _, err := ProcessImage(pngData(t, 10, 10), "image/png", &config.ImageProcessConfig{Format: "webp"}); err == nil {
+			t.Fatal("ProcessImage() should fail without webp encoder")
+		}
+
+		RegisterImageEncoder("webp", &ImageEncoder{ContentType: "image/webp", Ext: ".webp", Encode: func(w io.Writer, img image.Image, _ int) error {
+			return png.Encode(w, img)
+		}})
+		defer func() {
+			imageEncodersMu.Lock()
+			delete(imageEncoders, "webp")
+			imageEncodersMu.Unlock()
+		}()
+		main, _, err := ProcessImage(pngData(t, 10, 10), "image/png", &config.ImageProcessConfig{Format: "webp"})
+		if err != nil || main == nil || main.Ext != ".webp" {
+			t.Fatalf("ProcessImage() main = %+v, error = %v", main, err)
+		}
+	})
+
+	t.Run("无法处理的格式", func(t *testing.T) {
+		main, thumbnails, err := ProcessImage([]byte("RIFF"), "image/webp", &config.ImageProcessConfig{StripExif: true})
+		if err != nil || main != nil || thumbnails != nil {
+			t.Fatalf("ProcessImage() should skip webp, main = %+v, error = %v", main, err)
+		}
+	})
+}
@@ -0,0 +1,161 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// HandlerFunc 消息处理函数（返回 nil 时确认消息，否则保留待重新投递）
+type HandlerFunc func(ctx context.Context, msg *Message) error
+
+// ConsumeOptions 消费选项
+type ConsumeOptions struct {
+	Start         string        // 消费组起始消息ID（默认 "$"，仅消费新消息）
+	Count         int64         // 每次读取数量（默认 10）
+	Block         time.Duration // 阻塞等待时长（默认 5s）
+	MinIdle       time.Duration // 待确认消息空闲超过该时长后被认领重试（默认 1m）
+	ClaimInterval time.Duration // 认领检查间隔（默认 30s）
+	MaxDeliveries int64         // 最大投递次数，超过后确认并丢弃（默认 5，0 表示不限制）
+}
+
+// DefaultConsumeOptions 默认消费选项
+func DefaultConsumeOptions() *ConsumeOptions {
+	return &ConsumeOptions{
+		Start:         "$",
+		Count:         10,
+		Block:         5 * time.Second,
+		MinIdle:       time.Minute,
+		ClaimInterval: 30 * time.Second,
+		MaxDeliveries: 5,
+	}
+}
+
+// setDefaults 补全未设置的选项
+func (o *ConsumeOptions) setDefaults() {
+	defaults := DefaultConsumeOptions()
+	if o.Start == "" {
+		o.Start = defaults.Start
+	}
+	if o.Count <= 0 {
+		o.Count = defaults.Count
+	}
+	if o.Block <= 0 {
+		o.Block = defaults.Block
+	}
+	if o.MinIdle <= 0 {
+		o.MinIdle = defaults.MinIdle
+	}
+	if o.ClaimInterval <= 0 {
+		o.ClaimInterval = defaults.ClaimInterval
+	}
+	if o.MaxDeliveries < 0 {
+		o.MaxDeliveries = 0
+	}
+}
+
+// Consume 以消费组方式持续消费消息，阻塞直到 ctx 取消
+// - 定期认领空闲超时的待确认消息并重新处理
+// - 处理成功后确认；失败的消息保留在待确认列表，空闲超过 MinIdle 后重试
+// - 投递次数超过 MaxDeliveries 的消息确认并丢弃
+func (s *Streams) Consume(ctx context.Context, stream, group, consumer string, handler HandlerFunc, opts *ConsumeOptions) error {
+	if handler == nil {
+		return errors.New("stream handler is nil")
+	}
+	if opts == nil {
+		opts = DefaultConsumeOptions()
+	}
+	opts.setDefaults()
+
+	if err := s.CreateGroup(ctx, stream, group, opts.Start); err != nil {
+		return err
+	}
+
+	s.logger.Info("Stream consumer started",
+		slog.String("stream", stream),
+		slog.String("group", group),
+		slog.String("consumer", consumer),
+	)
+
+	var lastClaim time.Time
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if time.Since(lastClaim) >= opts.ClaimInterval {
+			lastClaim = time.Now()
+			claimed, err := s.Claim(ctx, stream, group, consumer, opts.MinIdle, opts.Count)
+			if err != nil {
+				s.logger.Error("Failed to claim stream messages", slog.String("stream", stream), slog.Any("error", err))
+			}
+			s.process(ctx, group, claimed, handler, opts)
+		}
+
+		block := opts.Block
+		if wait := opts.ClaimInterval - time.Since(lastClaim); wait < block {
+			block = wait
+		}
+		messages, err := s.ReadGroup(ctx, stream, group, consumer, opts.Count, block)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			s.logger.Error("Failed to read stream messages", slog.String("stream", stream), slog.Any("error", err))
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+		s.process(ctx, group, messages, handler, opts)
+	}
+}
+
+// process 处理一批消息
+func (s *Streams) process(ctx context.Context, group string, messages []*Message, handler HandlerFunc, opts *ConsumeOptions) {
+	for _, msg := range messages {
+		if opts.MaxDeliveries > 0 && msg.Deliveries > opts.MaxDeliveries {
+			s.logger.Error("Stream message exceeded max deliveries, dropped",
+				slog.String("stream", msg.Stream),
+				slog.String("id", msg.ID),
+				slog.Int64("deliveries", msg.Deliveries),
+			)
+			s.ack(ctx, group, msg)
+			continue
+		}
+
+		if err := invoke(ctx, handler, msg); err != nil {
+			s.logger.Warn("Stream message handler failed",
+				slog.String("stream", msg.Stream),
+				slog.String("id", msg.ID),
+				slog.Int64("deliveries", msg.Deliveries),
+				slog.Any("error", err),
+			)
+			continue
+		}
+		s.ack(ctx, group, msg)
+	}
+}
+
+// ack 确认单条消息（ctx 已取消时仍尽量确认，避免重复处理）
+func (s *Streams) ack(ctx context.Context, group string, msg *Message) {
+	ackCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := s.Ack(ackCtx, msg.Stream, group, msg.ID); err != nil {
+		s.logger.Error("Failed to ack stream message", slog.String("stream", msg.Stream), slog.String("id", msg.ID), slog.Any("error", err))
+	}
+}
+
+// invoke 调用处理函数并恢复 panic
+func invoke(ctx context.Context, handler HandlerFunc, msg *Message) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("stream handler panic: %v", r)
+		}
+	}()
+	return handler(ctx, msg)
+}
@@ -0,0 +1,238 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DataField 消息内容字段名（Add 写入的 JSON 内容存放在该字段）
+const DataField = "data"
+
+// Message 流消息
+type Message struct {
+	ID         string                 `json:"id"`         // 消息ID（如 1700000000000-0）
+	Stream     string                 `json:"stream"`     // 流名称（不含前缀）
+	Values     map[string]interface{} `json:"values"`     // 消息字段
+	Deliveries int64                  `json:"deliveries"` // 投递次数（新消息为 1，认领的消息为累计次数）
+}
+
+// Bind 将 data 字段的 JSON 内容解析到目标结构体
+func (m *Message) Bind(target interface{}) error {
+	data, ok := m.Values[DataField].(string)
+	if !ok {
+		return fmt.Errorf("stream message %s has no %s field", m.ID, DataField)
+	}
+	return json.Unmarshal([]byte(data), target)
+}
+
+// PendingInfo 消费组待确认消息概况
+type PendingInfo struct {
+	Count     int64            `json:"count"`     // 待确认消息数
+	Lower     string           `json:"lower"`     // 最小消息ID
+	Higher    string           `json:"higher"`    // 最大消息ID
+	Consumers map[string]int64 `json:"consumers"` // 各消费者待确认数
+}
+
+// Streams 基于 Redis Streams 的轻量事件流
+// - 生产：XADD（可按近似长度裁剪）
+// - 消费：消费组 XREADGROUP + XACK，消费者崩溃后通过 XPENDING + XCLAIM 认领超时消息
+type Streams struct {
+	client redis.UniversalClient
+	prefix string
+	maxLen int64
+	logger *slog.Logger
+}
+
+// Option 配置选项
+type Option func(*Streams)
+
+// WithPrefix 设置键前缀
+func WithPrefix(prefix string) Option {
+	return func(s *Streams) { s.prefix = prefix }
+}
+
+// WithMaxLen 设置流的近似最大长度（0 表示不裁剪）
+func WithMaxLen(maxLen int64) Option {
+	return func(s *Streams) { s.maxLen = maxLen }
+}
+
+// New 创建 Redis Streams 客户端
+func New(client redis.UniversalClient, logger *slog.Logger, opts ...Option) (*Streams, error) {
+	if client == nil {
+		return nil, errors.New("redis streams requires a redis client")
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	s := &Streams{client: client, logger: logger}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// key 获取流的键
+func (s *Streams) key(stream string) string {
+	if s.prefix == "" {
+		return stream
+	}
+	return s.prefix + ":" + stream
+}
+
+// Add 追加消息（payload 序列化为 JSON 存放在 data 字段）
+func (s *Streams) Add(ctx context.Context, stream string, payload interface{}) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize stream payload: %w", err)
+	}
+	return s.AddValues(ctx, stream, map[string]interface{}{DataField: string(data)})
+}
+
+// AddValues 追加原始字段消息
+func (s *Streams) AddValues(ctx context.Context, stream string, values map[string]interface{}) (string, error) {
+	args := &redis.XAddArgs{
+		Stream: s.key(stream),
+		Values: values,
+	}
+	if s.maxLen > 0 {
+		args.MaxLen = s.maxLen
+		args.Approx = true
+	}
+	id, err := s.client.XAdd(ctx, args).Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to add stream message: %w", err)
+	}
+	return id, nil
+}
+
+// Len 获取流长度
+func (s *Streams) Len(ctx context.Context, stream string) (int64, error) {
+	return s.client.XLen(ctx, s.key(stream)).Result()
+}
+
+// CreateGroup 创建消费组（流不存在时自动创建；消费组已存在时忽略）
+// @param start 起始消息ID："$" 仅消费新消息，"0" 从头消费
+func (s *Streams) CreateGroup(ctx context.Context, stream, group, start string) error {
+	if start == "" {
+		start = "$"
+	}
+	err := s.client.XGroupCreateMkStream(ctx, s.key(stream), group, start).Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("failed to create stream group: %w", err)
+	}
+	return nil
+}
+
+// ReadGroup 以消费组方式读取新消息
+// @param count 最大读取数量
+// @param block 阻塞等待时长（不足 1ms 时不阻塞）
+func (s *Streams) ReadGroup(ctx context.Context, stream, group, consumer string, count int64, block time.Duration) ([]*Message, error) {
+	if block < time.Millisecond {
+		// BLOCK 以毫秒为单位且 0 表示永久阻塞，go-redis 中负数表示不阻塞
+		block = -1
+	}
+	result, err := s.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    group,
+		Consumer: consumer,
+		Streams:  []string{s.key(stream), ">"},
+		Count:    count,
+		Block:    block,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stream group: %w", err)
+	}
+
+	var messages []*Message
+	for _, r := range result {
+		for _, m := range r.Messages {
+			messages = append(messages, &Message{ID: m.ID, Stream: stream, Values: m.Values, Deliveries: 1})
+		}
+	}
+	return messages, nil
+}
+
+// Ack 确认消息
+func (s *Streams) Ack(ctx context.Context, stream, group string, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := s.client.XAck(ctx, s.key(stream), group, ids...).Err(); err != nil {
+		return fmt.Errorf("failed to ack stream message: %w", err)
+	}
+	return nil
+}
+
+// Pending 获取消费组待确认消息概况
+func (s *Streams) Pending(ctx context.Context, stream, group string) (*PendingInfo, error) {
+	result, err := s.client.XPending(ctx, s.key(stream), group).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stream pending: %w", err)
+	}
+	return &PendingInfo{
+		Count:     result.Count,
+		Lower:     result.Lower,
+		Higher:    result.Higher,
+		Consumers: result.Consumers,
+	}, nil
+}
+
+// Claim 认领空闲超过 minIdle 的待确认消息（用于接管崩溃消费者的消息）
+// 返回的消息 Deliveries 为包含本次在内的累计投递次数
+func (s *Streams) Claim(ctx context.Context, stream, group, consumer string, minIdle time.Duration, count int64) ([]*Message, error) {
+	key := s.key(stream)
+	pending, err := s.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: key,
+		Group:  group,
+		Idle:   minIdle,
+		Start:  "-",
+		End:    "+",
+		Count:  count,
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stream pending: %w", err)
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(pending))
+	deliveries := make(map[string]int64, len(pending))
+	for i, p := range pending {
+		ids[i] = p.ID
+		deliveries[p.ID] = p.RetryCount + 1
+	}
+
+	claimed, err := s.client.XClaim(ctx, &redis.XClaimArgs{
+		Stream:   key,
+		Group:    group,
+		Consumer: consumer,
+		MinIdle:  minIdle,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim stream message: %w", err)
+	}
+
+	messages := make([]*Message, 0, len(claimed))
+	for _, m := range claimed {
+		messages = append(messages, &Message{ID: m.ID, Stream: stream, Values: m.Values, Deliveries: deliveries[m.ID]})
+	}
+	return messages, nil
+}
@@ -0,0 +1,229 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+/*
+Redis Streams 功能测试
+
+本文件用于测试Streams结构体的各种功能特性，
+包括消息追加、消费组读取、确认、待确认消息认领以及持续消费。
+
+运行命令：
+go test -v -run "^TestStreams.*$"
+
+测试内容：
+1. 消息追加与消费组读取 (Add, CreateGroup, ReadGroup, Bind)
+2. 消息确认与待确认概况 (Ack, Pending)
+3. 待确认消息认领 (Claim)
+4. 持续消费、失败重试与最大投递次数 (Consume)
+*/
+
+// newTestStreams 创建测试用 Streams，Redis 不可用时跳过
+func newTestStreams(t *testing.T) (*Streams, string) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379", DB: 1})
+
+	s, err := New(client, logger, WithPrefix("test"), WithMaxLen(1000))
+	if err != nil {
+		t.Skipf("Skipping test: failed to connect to Redis: %v", err)
+	}
+	name := fmt.Sprintf("stream_%d", time.Now().UnixNano())
+	t.Cleanup(func() {
+		client.Del(context.Background(), s.key(name))
+		client.Close()
+	})
+	return s, name
+}
+
+func TestStreams_AddAndReadGroup(t *testing.T) {
+	s, name := newTestStreams(t)
+	ctx := context.Background()
+
+	if err := s.CreateGroup(ctx, name, "g1", "0"); err != nil {
+		t.Fatalf("CreateGroup failed: %v", err)
+	}
+	// 重复创建应被忽略
+	if err := s.CreateGroup(ctx, name, "g1", "0"); err != nil {
+		t.Fatalf("CreateGroup should ignore existing group: %v", err)
+	}
+
+	if _, err := s.Add(ctx, name, map[string]string{"hello": "world"}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if n, err := s.Len(ctx, name); err != nil || n != 1 {
+		t.Fatalf("Expected length 1, got %d (%v)", n, err)
+	}
+
+	messages, err := s.ReadGroup(ctx, name, "g1", "c1", 10, 0)
+	if err != nil {
+		t.Fatalf("ReadGroup failed: %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	var payload map[string]string
+	if err := messages[0].Bind(&payload); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	if payload["hello"] != "world" {
+		t.Errorf("Unexpected payload: %v", payload)
+	}
+	if messages[0].Deliveries != 1 {
+		t.Errorf("Expected 1 delivery, got %d", messages[0].Deliveries)
+	}
+
+	pending, err := s.Pending(ctx, name, "g1")
+	if err != nil {
+		t.Fatalf("Pending failed: %v", err)
+	}
+	if pending.Count != 1 || pending.Consumers["c1"] != 1 {
+		t.Errorf("Unexpected pending: %+v", pending)
+	}
+
+	if err := s.Ack(ctx, name, "g1", messages[0].ID); err != nil {
+		t.Fatalf("Ack failed: %v", err)
+	}
+	pending, err = s.Pending(ctx, name, "g1")
+	if err != nil {
+		t.Fatalf("Pending failed: %v", err)
+	}
+	if pending.Count != 0 {
+		t.Errorf("Expected no pending messages, got %d", pending.Count)
+	}
+
+	// 没有新消息时返回空
+	messages, err = s.ReadGroup(ctx, name, "g1", "c1", 10, 0)
+	if err != nil || len(messages) != 0 {
+		t.Errorf("Expected no messages, got %d (%v)", len(messages), err)
+	}
+}
+
+func TestStreams_Claim(t *testing.T) {
+	s, name := newTestStreams(t)
+	ctx := context.Background()
+
+	if err := s.CreateGroup(ctx, name, "g1", "0"); err != nil {
+		t.Fatalf("CreateGroup failed: %v", err)
+	}
+	if _, err := s.AddValues(ctx, name, map[string]interface{}{"k": "v"}); err != nil {
+		t.Fatalf("AddValues failed: %v", err)
+	}
+	if _, err := s.ReadGroup(ctx, name, "g1", "crashed", 10, 0); err != nil {
+		t.Fatalf("ReadGroup failed: %v", err)
+	}
+
+	// 未达到空闲时长时不认领
+	claimed, err := s.Claim(ctx, name, "g1", "c2", time.Hour, 10)
+	if err != nil {
+		t.Fatalf("Claim failed: %v", err)
+	}
+	if len(claimed) != 0 {
+		t.Fatalf("Expected no claimed messages, got %d", len(claimed))
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	claimed, err = s.Claim(ctx, name, "g1", "c2", 10*time.Millisecond, 10)
+	if err != nil {
+		t.Fatalf("Claim failed: %v", err)
+	}
+	if len(claimed) != 1 {
+		t.Fatalf("Expected 1 claimed message, got %d", len(claimed))
+	}
+	if claimed[0].Values["k"] != "v" {
+		t.Errorf("Unexpected values: %v", claimed[0].Values)
+	}
+	if claimed[0].Deliveries != 2 {
+		t.Errorf("Expected 2 deliveries, got %d", claimed[0].Deliveries)
+	}
+
+	pending, err := s.Pending(ctx, name, "g1")
+	if err != nil {
+		t.Fatalf("Pending failed: %v", err)
+	}
+	if pending.Consumers["c2"] != 1 {
+		t.Errorf("Expected message owned by c2, got %+v", pending.Consumers)
+	}
+}
+
+func TestStreams_Consume(t *testing.T) {
+	s, name := newTestStreams(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var ok, failing atomic.Int32
+	handler := func(ctx context.Context, msg *Message) error {
+		var payload map[string]bool
+		if err := msg.Bind(&payload); err != nil {
+			return err
+		}
+		if payload["fail"] {
+			failing.Add(1)
+			return errors.New("always fails")
+		}
+		ok.Add(1)
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Consume(ctx, name, "g1", "c1", handler, &ConsumeOptions{
+			Start:         "0",
+			Block:         20 * time.Millisecond,
+			MinIdle:       10 * time.Millisecond,
+			ClaimInterval: 30 * time.Millisecond,
+			MaxDeliveries: 2,
+		})
+	}()
+
+	if _, err := s.Add(ctx, name, map[string]bool{"fail": false}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := s.Add(ctx, name, map[string]bool{"fail": true}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		pending, err := s.Pending(ctx, name, "g1")
+		if err == nil && ok.Load() == 1 && pending.Count == 0 {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	if ok.Load() != 1 {
+		t.Errorf("Expected 1 processed message, got %d", ok.Load())
+	}
+	// 失败的消息投递 MaxDeliveries 次后被丢弃
+	if failing.Load() != 2 {
+		t.Errorf("Expected 2 failed attempts, got %d", failing.Load())
+	}
+	pending, err := s.Pending(ctx, name, "g1")
+	if err != nil {
+		t.Fatalf("Pending failed: %v", err)
+	}
+	if pending.Count != 0 {
+		t.Errorf("Expected no pending messages, got %d", pending.Count)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Consume returned error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Consume did not stop after cancel")
+	}
+}
@@ -0,0 +1,33 @@
+package telemetry
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/extra/redisotel/v9"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"gorm.io/gorm"
+	"gorm.io/plugin/opentelemetry/tracing"
+)
+
+// GinMiddleware gin 链路追踪中间件（为每个请求创建根 Span，并将 trace 上下文写入 c.Request.Context()）
+func GinMiddleware(serviceName string) gin.HandlerFunc {
+	return otelgin.Middleware(serviceName)
+}
+
+// InstrumentGORM 为 GORM 注册链路追踪插件（需使用 db.WithContext(ctx) 传递请求上下文）
+func InstrumentGORM(db *gorm.DB) error {
+	if err := db.Use(tracing.NewPlugin(tracing.WithoutMetrics())); err != nil {
+		return fmt.Errorf("failed to instrument gorm: %w", err)
+	}
+	return nil
+}
+
+// InstrumentRedis 为 Redis 客户端注册链路追踪钩子
+func InstrumentRedis(client redis.UniversalClient) error {
+	if err := redisotel.InstrumentTracing(client); err != nil {
+		return fmt.Errorf("failed to instrument redis: %w", err)
+	}
+	return nil
+}
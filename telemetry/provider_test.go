@@ -0,0 +1,160 @@
+package telemetry
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/so68/core/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+/*
+链路追踪功能测试
+
+本文件用于测试链路追踪提供者的导出器选择、采样与未启用时的空实现。
+
+运行命令：
+go test -v -run "^Test.*$"
+
+测试内容：
+1. 按配置选择导出器，jaeger 使用 OTLP gRPC 导出 (newExporter)
+2. 不支持的导出器返回错误 (newExporter, NewProvider)
+3. 创建提供者并注册为全局 TracerProvider (NewProvider, Tracer, Shutdown)
+4. 采样率为 0 时不记录 Span (NewProvider)
+5. 未创建提供者时 gin 中间件使用空实现 (GinMiddleware)
+*/
+
+// newTestConfig 创建测试配置
+func newTestConfig(exporter string) *config.TelemetryConfig {
+	cfg := config.DefaultTelemetryConfig()
+	cfg.Enabled = true
+	cfg.Exporter = exporter
+	return cfg
+}
+
+func TestNewExporter(t *testing.T) {
+	tests := []struct {
+		exporter string
+		otlp     bool
+	}{
+		{exporter: "otlp-grpc", otlp: true},
+		{exporter: "jaeger", otlp: true},
+		{exporter: "otlp-http", otlp: true},
+		{exporter: "stdout"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.exporter, func(t *testing.T) {
+			exporter, err := newExporter(newTestConfig(tt.exporter))
+			if err != nil {
+				t.Fatalf("newExporter failed: %v", err)
+			}
+			defer exporter.Shutdown(context.Background())
+
+			switch exporter.(type) {
+			case *otlptrace.Exporter:
+				if !tt.otlp {
+					t.Errorf("expected stdout exporter, got %T", exporter)
+				}
+			case *stdouttrace.Exporter:
+				if tt.otlp {
+					t.Errorf("expected otlp exporter, got %T", exporter)
+				}
+			default:
+				t.Errorf("unexpected exporter %T", exporter)
+			}
+		})
+	}
+}
+
+func TestNewExporterUnsupported(t *testing.T) {
+	if _, err := newExporter(newTestConfig("zipkin")); err == nil || !strings.Contains(err.Error(), "unsupported telemetry exporter") {
+		t.Fatalf("expected unsupported exporter error, got %v", err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	if _, err := NewProvider(newTestConfig("zipkin"), "test", logger); err == nil {
+		t.Fatal("expected NewProvider error")
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	defer otel.SetTracerProvider(otel.GetTracerProvider())
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := newTestConfig("stdout")
+	cfg.ServiceName = "custom"
+	provider, err := NewProvider(cfg, "app", logger)
+	if err != nil {
+		t.Fatalf("NewProvider failed: %v", err)
+	}
+
+	_, span := provider.Tracer("test").Start(context.Background(), "operation")
+	if !span.SpanContext().IsValid() || !span.SpanContext().IsSampled() {
+		t.Errorf("expected sampled span, got %+v", span.SpanContext())
+	}
+	span.End()
+
+	// 全局 TracerProvider 已替换为本提供者
+	_, global := otel.Tracer("global").Start(context.Background(), "operation")
+	if !global.SpanContext().IsSampled() {
+		t.Error("expected global tracer provider registered")
+	}
+	global.End()
+
+	if err := provider.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+}
+
+func TestNewProviderSampleRatio(t *testing.T) {
+	defer otel.SetTracerProvider(otel.GetTracerProvider())
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := newTestConfig("stdout")
+	cfg.SampleRatio = 0
+	provider, err := NewProvider(cfg, "app", logger)
+	if err != nil {
+		t.Fatalf("NewProvider failed: %v", err)
+	}
+	defer provider.Shutdown(context.Background())
+
+	_, span := provider.Tracer("test").Start(context.Background(), "operation")
+	defer span.End()
+	if span.SpanContext().IsSampled() || span.IsRecording() {
+		t.Errorf("expected unsampled span, got %+v", span.SpanContext())
+	}
+}
+
+func TestGinMiddlewareNoop(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(noop.NewTracerProvider())
+	defer otel.SetTracerProvider(previous)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(GinMiddleware("app"))
+	var spanContext trace.SpanContext
+	router.GET("/ping", func(c *gin.Context) {
+		spanContext = trace.SpanContextFromContext(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d", w.Code)
+	}
+	if spanContext.IsValid() {
+		t.Errorf("expected no-op span, got %+v", spanContext)
+	}
+}
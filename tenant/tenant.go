@@ -0,0 +1,71 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/so68/core/cache"
+)
+
+// DefaultColumn 默认租户字段
+const DefaultColumn = "tenant_id"
+
+// maxIDLength 租户ID最大长度
+const maxIDLength = 64
+
+// ErrInvalidID 租户ID格式无效
+var ErrInvalidID = errors.New("invalid tenant id")
+
+// Model 自定义租户字段的模型（未实现时使用 tenant_id 字段，模型不含该字段时不按租户隔离）
+type Model interface {
+	// TenantColumn 数据所属的租户ID字段
+	TenantColumn() string
+}
+
+type tenantKey struct{}
+
+// WithID 将租户ID写入上下文
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, id)
+}
+
+// FromContext 获取上下文中的租户ID
+func FromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	id, ok := ctx.Value(tenantKey{}).(string)
+	return id, ok && id != ""
+}
+
+// Validate 校验租户ID（1-64 位字母、数字、下划线或中划线，避免用作缓存键前缀时混入 : 与通配符）
+func Validate(id string) error {
+	if id == "" || len(id) > maxIDLength {
+		return ErrInvalidID
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		if c != '_' && c != '-' && (c < '0' || c > '9') && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			return ErrInvalidID
+		}
+	}
+	return nil
+}
+
+// Cache 返回上下文中租户的缓存视图（键加 tenant:<id> 前缀），上下文中没有租户时返回原缓存
+func Cache(ctx context.Context, c cache.Cache) cache.Cache {
+	id, ok := FromContext(ctx)
+	if !ok {
+		return c
+	}
+	return cache.WithPrefix(c, "tenant:"+id)
+}
+
+// Column 获取模型的租户字段
+func Column(modelType reflect.Type) string {
+	if model, ok := reflect.New(modelType).Interface().(Model); ok {
+		return model.TenantColumn()
+	}
+	return DefaultColumn
+}
@@ -0,0 +1,89 @@
+package tenant
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/so68/core/cache"
+	"github.com/so68/core/config"
+)
+
+/*
+多租户测试
+
+本文件用于测试租户上下文、租户ID校验、租户缓存视图与租户字段。
+
+运行命令：
+go test -v -run "^TestTenant.*$"
+
+测试内容：
+1. 租户上下文 (WithID, FromContext)
+2. 租户ID校验 (Validate)
+3. 租户缓存视图 (Cache)
+4. 模型租户字段 (Column, Model)
+*/
+
+// customTenantModel 自定义租户字段的模型
+type customTenantModel struct{}
+
+func (customTenantModel) TenantColumn() string { return "org_id" }
+
+func TestTenantContext(t *testing.T) {
+	if _, ok := FromContext(context.Background()); ok {
+		t.Error("Expected no tenant in empty context")
+	}
+	if _, ok := FromContext(WithID(context.Background(), "")); ok {
+		t.Error("Expected empty tenant to be ignored")
+	}
+	if id, ok := FromContext(WithID(context.Background(), "acme")); !ok || id != "acme" {
+		t.Errorf("Expected acme, got %q", id)
+	}
+}
+
+func TestTenantValidate(t *testing.T) {
+	for _, id := range []string{"acme", "Tenant_01", "a-b", strings.Repeat("x", 64)} {
+		if err := Validate(id); err != nil {
+			t.Errorf("Validate(%q) failed: %v", id, err)
+		}
+	}
+	for _, id := range []string{"", "a:b", "a*", "a b", "租户", strings.Repeat("x", 65)} {
+		if err := Validate(id); err == nil {
+			t.Errorf("Expected Validate(%q) to fail", id)
+		}
+	}
+}
+
+func TestTenantCache(t *testing.T) {
+	cfg := &config.CacheConfig{Driver: "memory", CleanupInterval: time.Minute}
+	cfg.SetDefaults()
+	c, err := cache.NewMemoryCache(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("Failed to create memory cache: %v", err)
+	}
+	defer c.Close()
+	ctx := context.Background()
+
+	if Cache(ctx, c) != c {
+		t.Error("Expected cache itself without tenant")
+	}
+	if err := Cache(WithID(ctx, "acme"), c).Set(ctx, "k", "v", time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if v, err := c.Get(ctx, "tenant:acme:k"); err != nil || v != "v" {
+		t.Errorf("Expected key under tenant prefix, got %q, %v", v, err)
+	}
+}
+
+func TestTenantColumn(t *testing.T) {
+	if column := Column(reflect.TypeOf(struct{}{})); column != DefaultColumn {
+		t.Errorf("Expected %s, got %s", DefaultColumn, column)
+	}
+	if column := Column(reflect.TypeOf(customTenantModel{})); column != "org_id" {
+		t.Errorf("Expected org_id, got %s", column)
+	}
+}